@@ -4,6 +4,7 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -59,8 +60,27 @@ var (
 	allowedStatus      = buildAllowedStatus()
 	registrySchemaPath = "docs/schema/registry.schema.json"
 	exitFunc           = os.Exit
+	// version is overridden at build time via -ldflags "-X main.version=...".
+	version = "dev"
 )
 
+// Exit codes returned by cli.
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+// exitCodeTable documents each exit code for --exit-codes.
+var exitCodeTable = []struct {
+	code    int
+	meaning string
+}{
+	{exitOK, "registry validation passed (or an informational flag was handled)"},
+	{exitFailure, "registry fix, validation, or report write failed"},
+	{exitUsage, "invalid command-line flags"},
+}
+
 const (
 	schemaTypeObject     = "object"
 	schemaTypeArray      = "array"
@@ -129,46 +149,115 @@ func main() {
 	exitFunc(code)
 }
 
-func cli(args []string, stdout, stderr io.Writer) int {
+func cli(args []string, stdout, stderr io.Writer) (code int) {
 	fs := flag.NewFlagSet("registry-check", flag.ContinueOnError)
 	fs.SetOutput(stderr)
 	var registryPath string
 	var observabilityJSON bool
 	var fix bool
+	var showExitCodes bool
+	var showVersion bool
+	var jsonReportPath string
 	fs.StringVar(&registryPath, "registry", "docs/rfc/registry.yaml", "path to registry yaml")
 	fs.BoolVar(&observabilityJSON, "observability-json", false, "emit structured observability events as JSON lines to stderr")
 	fs.BoolVar(&fix, "fix", false, "rewrite canonicalizable registry issues in place before validation")
+	fs.BoolVar(&showExitCodes, "exit-codes", false, "print the exit code table and exit")
+	fs.BoolVar(&showVersion, "version", false, "print the tool version and exit")
+	fs.StringVar(&jsonReportPath, "json-report", "", "write observability events as JSON lines to this file, including on failure")
 	if err := fs.Parse(args); err != nil {
-		return 2
+		return exitUsage
 	}
-	var recorder observability.Recorder = observability.NoopRecorder{}
+	if showExitCodes {
+		return printExitCodes(stdout)
+	}
+	if showVersion {
+		if _, err := fmt.Fprintf(stdout, "registry-check %s\n", version); err != nil {
+			return exitFailure
+		}
+		return exitOK
+	}
+	var recorders teeRecorder
 	if observabilityJSON {
-		recorder = registryEventRecorderFactory(stderr)
+		recorders = append(recorders, registryEventRecorderFactory(stderr))
+	}
+	if jsonReportPath != "" {
+		var report bytes.Buffer
+		recorders = append(recorders, registryEventRecorderFactory(&report))
+		defer func() {
+			if err := writeJSONReport(jsonReportPath, report.Bytes()); err != nil {
+				_, _ = fmt.Fprintf(stderr, "Registry JSON report failed: %v\n", err)
+				code = exitFailure
+			}
+		}()
+	}
+	var recorder observability.Recorder = observability.NoopRecorder{}
+	if len(recorders) > 0 {
+		recorder = recorders
 	}
 	if fix {
 		fixesApplied, err := fixRegistryFile(registryPath)
 		if err != nil {
+			recorder.Record(context.Background(), observability.Event{
+				Category: observability.CategoryRegistryValidation,
+				Name:     "registry.fix",
+				Status:   observability.StatusError,
+				Error:    err.Error(),
+				Labels:   map[string]string{"registry_path": strings.TrimSpace(registryPath)},
+			})
 			if _, writeErr := fmt.Fprintf(stderr, "Registry fix failed: %v\n", err); writeErr != nil {
-				return 1
+				return exitFailure
 			}
-			return 1
+			return exitFailure
 		}
 		if fixesApplied > 0 {
 			if _, writeErr := fmt.Fprintf(stdout, "Applied %d registry fix(es).\n", fixesApplied); writeErr != nil {
-				return 1
+				return exitFailure
 			}
 		}
 	}
 	if err := runWithRecorder(context.Background(), registryPath, recorder); err != nil {
 		if _, writeErr := fmt.Fprintf(stderr, "Registry validation failed: %v\n", err); writeErr != nil {
-			return 1
+			return exitFailure
 		}
-		return 1
+		return exitFailure
 	}
 	if _, writeErr := fmt.Fprintln(stdout, "Registry validation passed."); writeErr != nil {
-		return 1
+		return exitFailure
+	}
+	return exitOK
+}
+
+func printExitCodes(stdout io.Writer) int {
+	for _, entry := range exitCodeTable {
+		if _, err := fmt.Fprintf(stdout, "%d\t%s\n", entry.code, entry.meaning); err != nil {
+			return exitFailure
+		}
+	}
+	return exitOK
+}
+
+// teeRecorder fans each event out to every wrapped recorder.
+type teeRecorder []observability.Recorder
+
+func (t teeRecorder) Record(ctx context.Context, event observability.Event) {
+	for _, recorder := range t {
+		recorder.Record(ctx, event)
+	}
+}
+
+// writeJSONReport persists buffered JSON-lines events. Unlike --registry the
+// report path may be absolute so CI can direct it into an artifacts directory.
+func writeJSONReport(path string, data []byte) error {
+	clean := filepath.Clean(path)
+	if dir := filepath.Dir(clean); dir != "." {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return fmt.Errorf("create report directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(clean, data, 0o600); err != nil {
+		return fmt.Errorf("write report: %w", err)
 	}
-	return 0
+	return nil
 }
 
 // validatePath ensures the registry file path is within the repository tree and
@@ -642,8 +731,11 @@ func parseRegistry(file *os.File) (*Registry, error) {
 			if listField == "" {
 				return nil, fmt.Errorf("line %d: list item without active list field", lineNum)
 			}
-			item := normalizeScalar(strings.TrimSpace(trimmed[2:]))
-			if err := appendList(currentDoc, listField, item); err != nil {
+			item, err := scalarToken(trimmed[2:])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			if err := appendList(currentDoc, listField, normalizeScalar(item)); err != nil {
 				return nil, fmt.Errorf("line %d: %w", lineNum, err)
 			}
 			continue
@@ -676,18 +768,61 @@ func countLeadingSpaces(s string) int {
 }
 
 // splitKeyValue splits a "key: value" pair into its key and value components.
-// It returns the trimmed key and value; if the ":" delimiter is missing the
-// returned error indicates the malformed input.
+// It returns the trimmed key and the value as read by scalarToken; if the ":"
+// delimiter is missing or the value is malformed the returned error indicates
+// the malformed input.
 func splitKeyValue(part string) (string, string, error) {
 	idx := strings.Index(part, ":")
 	if idx == -1 {
 		return "", "", fmt.Errorf("missing ':' delimiter in %q", part)
 	}
 	key := strings.TrimSpace(part[:idx])
-	value := strings.TrimSpace(part[idx+1:])
+	value, err := scalarToken(part[idx+1:])
+	if err != nil {
+		return "", "", fmt.Errorf("%w in %q", err, part)
+	}
 	return key, value, nil
 }
 
+// scalarToken trims value and, when it opens with a single or double quote,
+// cuts it at the matching closing quote so colons and '#' inside the quotes
+// stay literal. Only a trailing "# comment" may follow the closing quote. The
+// quotes are kept for normalizeScalar to strip on assignment; unquoted values
+// are returned trimmed and otherwise unchanged.
+func scalarToken(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || (value[0] != '"' && value[0] != '\'') {
+		return value, nil
+	}
+	end, err := closingQuote(value)
+	if err != nil {
+		return "", err
+	}
+	if rest := strings.TrimSpace(value[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+		return "", fmt.Errorf("unexpected %q after quoted value", rest)
+	}
+	return value[:end+1], nil
+}
+
+// closingQuote returns the index of the quote that closes the quoted scalar at
+// the start of value. Double-quoted scalars honour backslash escapes and
+// single-quoted scalars treat a doubled quote as a literal quote.
+func closingQuote(value string) (int, error) {
+	quote := value[0]
+	for i := 1; i < len(value); i++ {
+		switch {
+		case quote == '"' && value[i] == '\\':
+			i++
+		case value[i] != quote:
+		case quote == '\'' && i+1 < len(value) && value[i+1] == '\'':
+			i++
+		default:
+			return i, nil
+		}
+	}
+	return 0, errors.New("unterminated quoted value")
+}
+
 // assignScalar assigns a normalized scalar value to the corresponding field on doc based on key.
 // Supported keys: "id", "type", "title", "status", "created", "date", "last_updated", "quorum",
 // "target_release", and "path". It returns an error if the key is not recognized.
@@ -178,3 +178,57 @@ func TestRegistryHelperFunctions(t *testing.T) {
 		t.Fatalf("expected valid date, got %v", err)
 	}
 }
+
+func TestParseRegistryQuotedScalars(t *testing.T) {
+	parse := func(t *testing.T, lines ...string) (*Registry, error) {
+		t.Helper()
+		f, err := os.CreateTemp(t.TempDir(), "quoted-*.yaml")
+		if err != nil {
+			t.Fatalf("temp file: %v", err)
+		}
+		defer func() { _ = f.Close() }()
+		if _, err := f.WriteString(strings.Join(lines, "\n")); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if _, err := f.Seek(0, 0); err != nil {
+			t.Fatalf("seek: %v", err)
+		}
+		return parseRegistry(f)
+	}
+
+	registry, err := parse(t,
+		"documents:",
+		"  - id: ANX-0001",
+		`    title: "Annex: Observability # metrics" # trailing comment`,
+		"    type: Annex",
+		"    path: docs/annex/a.md",
+		"    authors:",
+		"      - 'O''Brien: Ops'",
+		"      - plain author",
+		"  - id: ANX-0002",
+		"    title: Unquoted: keeps text after the first colon # verbatim",
+	)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(registry.Documents) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(registry.Documents))
+	}
+	quoted := registry.Documents[0]
+	if quoted.Title != "Annex: Observability # metrics" {
+		t.Fatalf("expected quoted title with literal colon and hash, got %q", quoted.Title)
+	}
+	if want := []string{"O'Brien: Ops", "plain author"}; strings.Join(quoted.Authors, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected authors %v, got %v", want, quoted.Authors)
+	}
+	if got := registry.Documents[1].Title; got != "Unquoted: keeps text after the first colon # verbatim" {
+		t.Fatalf("expected unquoted title unchanged, got %q", got)
+	}
+
+	if _, err := parse(t, "documents:", `  - id: "ANX-0003`); err == nil || !strings.Contains(err.Error(), "unterminated quoted value") {
+		t.Fatalf("expected unterminated quote error, got %v", err)
+	}
+	if _, err := parse(t, "documents:", "  - id: ANX-0004", `    title: "Annex" extra`); err == nil || !strings.Contains(err.Error(), "after quoted value") {
+		t.Fatalf("expected trailing content error, got %v", err)
+	}
+}
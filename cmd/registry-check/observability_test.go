@@ -1,7 +1,12 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"colonycore/internal/observability"
@@ -55,3 +60,64 @@ func TestRunWithRecorderEmitsFailureEvent(t *testing.T) {
 		t.Fatalf("expected registry.validate error event, got %+v", events.events)
 	}
 }
+
+func TestCLIJSONReportWrittenOnValidationFailure(t *testing.T) {
+	docPath := writeTestFile(t, "report-fail-doc.md", "# Test\n- Status: Accepted\n")
+	regPath := writeTestFile(t, "report-fail-registry.yaml", "documents:\n  - id: RFC-202\n    type: RFC\n    title: Report\n    status: Draft\n    path: "+docPath+"\n")
+	reportPath := filepath.Join(t.TempDir(), "reports", "registry.jsonl")
+	var stdout, stderr bytes.Buffer
+
+	if code := cli([]string{"-registry", regPath, "-json-report", reportPath}, &stdout, &stderr); code != exitFailure {
+		t.Fatalf("expected exit code %d, got %d (stderr %q)", exitFailure, code, stderr.String())
+	}
+	data, err := os.ReadFile(reportPath) // #nosec G304 -- test-controlled temp path
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+	var sawStatusError, sawSummaryError bool
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var event observability.Event
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("report line %q is not JSON: %v", line, err)
+		}
+		if event.Source != "cmd.registry-check" {
+			t.Fatalf("unexpected event source %q", event.Source)
+		}
+		switch {
+		case event.Name == "registry.document.status" && event.Status == observability.StatusError:
+			sawStatusError = true
+		case event.Name == "registry.validate" && event.Status == observability.StatusError:
+			sawSummaryError = true
+		}
+	}
+	if !sawStatusError || !sawSummaryError {
+		t.Fatalf("expected status and summary error events in report, got %s", data)
+	}
+	if strings.Contains(stderr.String(), "{") {
+		t.Fatalf("expected no JSON on stderr without -observability-json, got %q", stderr.String())
+	}
+}
+
+func TestCLIExitCodesAndVersion(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := cli([]string{"-exit-codes"}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("expected -exit-codes to exit 0, got %d", code)
+	}
+	for _, want := range []string{"0\t", "1\t", "2\tinvalid command-line flags"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Fatalf("exit code table missing %q: %q", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	if code := cli([]string{"-version"}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("expected -version to exit 0, got %d", code)
+	}
+	if got := stdout.String(); got != "registry-check "+version+"\n" {
+		t.Fatalf("unexpected version output %q", got)
+	}
+
+	if code := cli([]string{"-no-such-flag"}, &stdout, &stderr); code != exitUsage {
+		t.Fatalf("expected usage exit code %d, got %d", exitUsage, code)
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"colonycore/internal/infra/persistence/memory"
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+// seedEpoch anchors every generated timestamp so output never depends on the
+// wall clock.
+var seedEpoch = time.Date(2024, time.January, 1, 8, 0, 0, 0, time.UTC)
+
+// seedStream separates the seed generator's PCG stream from any other use of
+// the same seed value.
+const seedStream = 0x636f6c6f6e79
+
+var (
+	seedSpecies      = []string{"Xenopus laevis", "Xenopus tropicalis", "Ambystoma mexicanum"}
+	seedEnvironments = []domain.HousingEnvironment{
+		domain.HousingEnvironmentAquatic,
+		domain.HousingEnvironmentTerrestrial,
+		domain.HousingEnvironmentArboreal,
+		domain.HousingEnvironmentHumid,
+	}
+	seedStages = []domain.LifecycleStage{domain.StageLarva, domain.StageJuvenile, domain.StageAdult}
+)
+
+// generator draws IDs, timestamps, and choices from a single seeded source.
+// Every draw happens in a fixed order so a seed always yields the same data.
+type generator struct {
+	rng *rand.Rand
+}
+
+func newGenerator(seed int64) *generator {
+	return &generator{rng: rand.New(rand.NewPCG(uint64(seed), seedStream))} // #nosec G404: deterministic fixtures, not secrets
+}
+
+// id returns a 32 character hex identifier in the same shape as store IDs.
+func (g *generator) id() string {
+	return fmt.Sprintf("%016x%016x", g.rng.Uint64(), g.rng.Uint64())
+}
+
+// between returns an integer in [lo, hi].
+func (g *generator) between(lo, hi int) int {
+	return lo + g.rng.IntN(hi-lo+1)
+}
+
+// at returns seedEpoch plus a random whole number of minutes within days.
+func (g *generator) at(days int) time.Time {
+	return seedEpoch.Add(time.Duration(g.rng.IntN(days*24*60)) * time.Minute)
+}
+
+func pick[T any](g *generator, values []T) T {
+	return values[g.rng.IntN(len(values))]
+}
+
+// Generate builds a referentially consistent snapshot of facilities, housing,
+// genotype markers, lines, strains, protocols, organisms, procedures, and
+// observations from seed. The same seed always produces the same snapshot.
+func Generate(seed int64) memory.Snapshot {
+	g := newGenerator(seed)
+	snapshot := memory.Snapshot{
+		Facilities:   map[string]memory.Facility{},
+		Housing:      map[string]memory.HousingUnit{},
+		Markers:      map[string]memory.GenotypeMarker{},
+		Lines:        map[string]memory.Line{},
+		Strains:      map[string]memory.Strain{},
+		Protocols:    map[string]memory.Protocol{},
+		Organisms:    map[string]memory.Organism{},
+		Procedures:   map[string]memory.Procedure{},
+		Observations: map[string]memory.Observation{},
+	}
+
+	var markerIDs []string
+	for i, n := 1, g.between(3, 5); i <= n; i++ {
+		created := g.at(30)
+		marker := memory.GenotypeMarker{GenotypeMarker: entitymodel.GenotypeMarker{
+			ID:             g.id(),
+			Name:           fmt.Sprintf("Marker %d", i),
+			Locus:          fmt.Sprintf("locus-%d", i),
+			Alleles:        []string{"wt", fmt.Sprintf("m%d", i)},
+			AssayMethod:    "PCR",
+			Interpretation: "heterozygous carrier",
+			Version:        "1",
+			CreatedAt:      created,
+			UpdatedAt:      created,
+		}}
+		snapshot.Markers[marker.ID] = marker
+		markerIDs = append(markerIDs, marker.ID)
+	}
+
+	type lineage struct {
+		lineID   string
+		lineCode string
+		strains  []string
+	}
+	var lineages []lineage
+	for i, n := 1, g.between(2, 3); i <= n; i++ {
+		created := g.at(30)
+		line := memory.Line{Line: entitymodel.Line{
+			ID:                g.id(),
+			Code:              fmt.Sprintf("LN-%02d", i),
+			Name:              fmt.Sprintf("Line %d", i),
+			Origin:            "wild-type",
+			GenotypeMarkerIDs: []string{pick(g, markerIDs)},
+			CreatedAt:         created,
+			UpdatedAt:         created,
+		}}
+		snapshot.Lines[line.ID] = line
+		entry := lineage{lineID: line.ID, lineCode: line.Code}
+		for j, n := 1, g.between(1, 2); j <= n; j++ {
+			strain := memory.Strain{Strain: entitymodel.Strain{
+				ID:        g.id(),
+				Code:      fmt.Sprintf("%s-S%d", line.Code, j),
+				Name:      fmt.Sprintf("%s strain %d", line.Name, j),
+				LineID:    line.ID,
+				CreatedAt: created,
+				UpdatedAt: created,
+			}}
+			snapshot.Strains[strain.ID] = strain
+			entry.strains = append(entry.strains, strain.ID)
+		}
+		lineages = append(lineages, entry)
+	}
+
+	var protocolIDs []string
+	for i, n := 1, g.between(1, 3); i <= n; i++ {
+		created := g.at(30)
+		protocol := memory.Protocol{Protocol: entitymodel.Protocol{
+			ID:          g.id(),
+			Code:        fmt.Sprintf("PROT-%02d", i),
+			Title:       fmt.Sprintf("Protocol %d", i),
+			MaxSubjects: 500,
+			Status:      domain.ProtocolStatusApproved,
+			CreatedAt:   created,
+			UpdatedAt:   created,
+		}}
+		snapshot.Protocols[protocol.ID] = protocol
+		protocolIDs = append(protocolIDs, protocol.ID)
+	}
+
+	var organismIDs []string
+	for i, n := 1, g.between(1, 3); i <= n; i++ {
+		created := g.at(30)
+		facility := memory.Facility{Facility: entitymodel.Facility{
+			ID:           g.id(),
+			Code:         fmt.Sprintf("FAC-%02d", i),
+			Name:         fmt.Sprintf("Facility %d", i),
+			Zone:         fmt.Sprintf("zone-%c", 'A'+i-1),
+			AccessPolicy: "badge",
+			CreatedAt:    created,
+			UpdatedAt:    created,
+		}}
+		snapshot.Facilities[facility.ID] = facility
+
+		for j, n := 1, g.between(2, 4); j <= n; j++ {
+			housing := memory.HousingUnit{HousingUnit: entitymodel.HousingUnit{
+				ID:          g.id(),
+				Name:        fmt.Sprintf("%s-H%02d", facility.Code, j),
+				FacilityID:  facility.ID,
+				Capacity:    g.between(6, 12),
+				Environment: pick(g, seedEnvironments),
+				State:       domain.HousingStateActive,
+				CreatedAt:   created,
+				UpdatedAt:   created,
+			}}
+			snapshot.Housing[housing.ID] = housing
+
+			for k, n := 1, g.between(1, housing.Capacity); k <= n; k++ {
+				lineage := pick(g, lineages)
+				born := g.at(90)
+				organism := memory.Organism{Organism: entitymodel.Organism{
+					ID:         g.id(),
+					Name:       fmt.Sprintf("%s-%02d", housing.Name, k),
+					Species:    pick(g, seedSpecies),
+					Line:       lineage.lineCode,
+					LineID:     stringPtr(lineage.lineID),
+					StrainID:   stringPtr(pick(g, lineage.strains)),
+					HousingID:  stringPtr(housing.ID),
+					ProtocolID: stringPtr(pick(g, protocolIDs)),
+					Stage:      pick(g, seedStages),
+					CreatedAt:  born,
+					UpdatedAt:  born,
+				}}
+				snapshot.Organisms[organism.ID] = organism
+				organismIDs = append(organismIDs, organism.ID)
+			}
+		}
+	}
+
+	for i, n := 1, g.between(2, 5); i <= n; i++ {
+		scheduled := g.at(120)
+		count := min(g.between(1, 3), len(organismIDs))
+		subjects := make([]string, 0, count)
+		for _, index := range g.rng.Perm(len(organismIDs))[:count] {
+			subjects = append(subjects, organismIDs[index])
+		}
+		procedure := memory.Procedure{Procedure: entitymodel.Procedure{
+			ID:          g.id(),
+			Name:        fmt.Sprintf("Procedure %d", i),
+			ProtocolID:  pick(g, protocolIDs),
+			OrganismIDs: subjects,
+			Status:      domain.ProcedureStatusScheduled,
+			ScheduledAt: scheduled,
+			CreatedAt:   scheduled,
+			UpdatedAt:   scheduled,
+		}}
+		snapshot.Procedures[procedure.ID] = procedure
+
+		for _, subject := range subjects {
+			recorded := scheduled.Add(time.Duration(g.between(10, 240)) * time.Minute)
+			observation := memory.Observation{Observation: entitymodel.Observation{
+				ID:          g.id(),
+				ProcedureID: stringPtr(procedure.ID),
+				OrganismID:  stringPtr(subject),
+				Observer:    fmt.Sprintf("tech-%d", g.between(1, 4)),
+				RecordedAt:  recorded,
+				CreatedAt:   recorded,
+				UpdatedAt:   recorded,
+			}}
+			if err := observation.ApplyObservationData(map[string]any{"weight_g": float64(g.between(50, 400)) / 10}); err != nil {
+				panic(fmt.Errorf("seed: apply observation data: %w", err))
+			}
+			snapshot.Observations[observation.ID] = observation
+		}
+	}
+
+	// Round-trip through the memory store so derived relationship fields and
+	// default extension payloads match what a live store would export.
+	store := memory.NewStore(nil)
+	if err := store.ImportState(snapshot); err != nil {
+		panic(fmt.Errorf("seed: import snapshot: %w", err))
+	}
+	return store.ExportState()
+}
+
+func stringPtr(v string) *string { return &v }
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"colonycore/internal/infra/persistence/memory"
+	"colonycore/internal/infra/persistence/postgres"
+	pgtu "colonycore/internal/infra/persistence/postgres/testutil"
+)
+
+func TestRunSameSeedProducesIdenticalJSON(t *testing.T) {
+	var first, second, stderr bytes.Buffer
+	if code := run([]string{"--seed", "42"}, &first, &stderr); code != 0 {
+		t.Fatalf("first run exit %d: %s", code, stderr.String())
+	}
+	if code := run([]string{"--seed", "42"}, &second, &stderr); code != 0 {
+		t.Fatalf("second run exit %d: %s", code, stderr.String())
+	}
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Fatalf("expected byte-identical output for the same seed")
+	}
+
+	var other bytes.Buffer
+	if code := run([]string{"--seed", "43"}, &other, &stderr); code != 0 {
+		t.Fatalf("other run exit %d: %s", code, stderr.String())
+	}
+	if bytes.Equal(first.Bytes(), other.Bytes()) {
+		t.Fatalf("expected different seeds to produce different datasets")
+	}
+}
+
+func TestGeneratedSnapshotValidates(t *testing.T) {
+	for _, seed := range []int64{0, 1, 7, 42, -3} {
+		snapshot := Generate(seed)
+		if err := memory.ValidateSnapshot(snapshot); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		stats := snapshot.Stats()
+		if stats.Facilities == 0 || stats.HousingUnits == 0 || stats.Lines == 0 || stats.Strains == 0 ||
+			stats.GenotypeMarkers == 0 || stats.Protocols == 0 || stats.Organisms == 0 ||
+			stats.Procedures == 0 || stats.Observations == 0 {
+			t.Fatalf("seed %d: expected every generated entity type, got %+v", seed, stats)
+		}
+	}
+}
+
+func TestRunWritesOutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seed.json")
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--seed", "5", "--output", path}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Fatalf("expected no stdout when --output is set, got %q", stdout.String())
+	}
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	var snapshot memory.Snapshot
+	if err := json.Unmarshal(payload, &snapshot); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if err := memory.ValidateSnapshot(snapshot); err != nil {
+		t.Fatalf("decoded snapshot invalid: %v", err)
+	}
+}
+
+type recordingImporter struct {
+	imported *memory.Snapshot
+	err      error
+}
+
+func (r *recordingImporter) Import(_ context.Context, snapshot memory.Snapshot) error {
+	r.imported = &snapshot
+	return r.err
+}
+
+func TestRunImportsIntoPostgres(t *testing.T) {
+	importer := &recordingImporter{}
+	var gotDSN string
+	restore := openImporter
+	openImporter = func(dsn string) (snapshotImporter, error) {
+		gotDSN = dsn
+		return importer, nil
+	}
+	t.Cleanup(func() { openImporter = restore })
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--seed", "9", "--dsn", "postgres://seed"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	if gotDSN != "postgres://seed" || importer.imported == nil {
+		t.Fatalf("expected snapshot imported into %q, got dsn %q", "postgres://seed", gotDSN)
+	}
+	if importer.imported.Stats() != Generate(9).Stats() {
+		t.Fatalf("expected imported snapshot to match generated dataset")
+	}
+
+	importer.err = errors.New("boom")
+	if code := run([]string{"--dsn", "postgres://seed"}, &stdout, &stderr); code != 1 || !strings.Contains(stderr.String(), "import snapshot: boom") {
+		t.Fatalf("expected import failure, got exit %d: %s", code, stderr.String())
+	}
+}
+
+func TestRunRejectsUnexpectedArguments(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"extra"}, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected exit 2, got %d", code)
+	}
+	if code := run([]string{"--seed", "nope"}, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected exit 2 for invalid seed, got %d", code)
+	}
+}
+
+func TestRunImportsThroughPostgresStore(t *testing.T) {
+	db, _ := pgtu.NewStubDB()
+	t.Cleanup(postgres.OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil }))
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--seed", "3", "--dsn", "postgres://seed"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	store, err := postgres.NewStore("postgres://seed", nil)
+	if err != nil {
+		t.Fatalf("reopen store: %v", err)
+	}
+	if got, want := store.ExportState().Stats(), Generate(3).Stats(); got != want {
+		t.Fatalf("expected postgres to hold the generated dataset, got %+v want %+v", got, want)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"colonycore/internal/infra/persistence/memory"
+	"colonycore/pkg/domain"
+)
+
+var (
+	beforePath = filepath.Join("testdata", "before.json")
+	afterPath  = filepath.Join("testdata", "after.json")
+)
+
+func TestRunIdenticalSnapshots(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"snapshot-diff", beforePath, beforePath}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit 0 for identical snapshots, got %d (%s)", code, stderr.String())
+	}
+	if stdout.String() != "no differences\n" {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"snapshot-diff", "-format", "json", beforePath, beforePath}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit 0 for identical snapshots in json, got %d", code)
+	}
+	if strings.TrimSpace(stdout.String()) != "{\n  \"entities\": []\n}" {
+		t.Fatalf("unexpected json output %q", stdout.String())
+	}
+}
+
+func TestRunDifferingSnapshotsText(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"snapshot-diff", beforePath, afterPath}, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit 1 for differing snapshots, got %d (%s)", code, stderr.String())
+	}
+	want := "organism: 1 added, 1 removed, 1 changed\n  + org-3\n  - org-2\n  ~ org-1\n"
+	if stdout.String() != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", stdout.String(), want)
+	}
+}
+
+func TestRunDifferingSnapshotsJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"snapshot-diff", "-format", "json", beforePath, afterPath}, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit 1 for differing snapshots, got %d (%s)", code, stderr.String())
+	}
+	var diff memory.SnapshotDiff
+	if err := json.Unmarshal(stdout.Bytes(), &diff); err != nil {
+		t.Fatalf("decode json output: %v", err)
+	}
+	if len(diff.Entities) != 1 {
+		t.Fatalf("expected only organisms to differ, got %+v", diff.Entities)
+	}
+	got := diff.Entities[0]
+	if got.Entity != domain.EntityOrganism || strings.Join(got.Added, ",") != "org-3" || strings.Join(got.Removed, ",") != "org-2" || strings.Join(got.Changed, ",") != "org-1" {
+		t.Fatalf("unexpected organism diff %+v", got)
+	}
+}
+
+func TestRunUsageAndPathErrors(t *testing.T) {
+	cases := map[string][]string{
+		"missing argument": {"snapshot-diff", beforePath},
+		"unknown format":   {"snapshot-diff", "-format", "yaml", beforePath, afterPath},
+		"absolute path":    {"snapshot-diff", "/etc/passwd", afterPath},
+		"path traversal":   {"snapshot-diff", beforePath, "../seed/main.go"},
+		"missing file":     {"snapshot-diff", beforePath, filepath.Join("testdata", "missing.json")},
+		"invalid json":     {"snapshot-diff", "main.go", afterPath},
+	}
+	for name, args := range cases {
+		t.Run(name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(args, &stdout, &stderr); code != 2 {
+				t.Fatalf("expected exit 2, got %d", code)
+			}
+			if stderr.Len() == 0 {
+				t.Fatalf("expected diagnostics on stderr")
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Package main runs the entity-model schema validator with warnings and
+// machine-readable output.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"colonycore/internal/tools/entitymodel/schemacheck"
+)
+
+const defaultSchemaPath = "docs/schema/entity-model.json"
+
+func main() {
+	os.Exit(run(os.Args, os.Stdout, os.Stderr))
+}
+
+// run validates the schema and returns the process exit code: 0 when the
+// schema is valid, 1 when it has errors (or warnings under --strict), and 2
+// for usage or read failures.
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("validate-schema", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	schemaPath := fs.String("schema", defaultSchemaPath, "path to the entity-model schema")
+	strict := fs.Bool("strict", false, "treat warnings as errors")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	report, err := schemacheck.Check(*schemaPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "validate-schema: %v\n", err)
+		return 2
+	}
+	failed := len(report.Errors) > 0 || (*strict && len(report.Warnings) > 0)
+
+	if *asJSON {
+		out := struct {
+			Schema string `json:"schema"`
+			Strict bool   `json:"strict"`
+			OK     bool   `json:"ok"`
+			schemacheck.Report
+		}{Schema: *schemaPath, Strict: *strict, OK: !failed, Report: report}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			_, _ = fmt.Fprintf(stderr, "validate-schema: write report: %v\n", err)
+			return 2
+		}
+	} else {
+		for _, msg := range report.Errors {
+			_, _ = fmt.Fprintf(stdout, "error: %s\n", msg)
+		}
+		for _, msg := range report.Warnings {
+			_, _ = fmt.Fprintf(stdout, "warning: %s\n", msg)
+		}
+		status := "OK"
+		if failed {
+			status = "FAILED"
+		}
+		_, _ = fmt.Fprintf(stdout, "%s: %s (%d errors, %d warnings)\n", *schemaPath, status, len(report.Errors), len(report.Warnings))
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const warningOnlySchema = `{
+  "version": "0.0.1",
+  "id_semantics": { "type": "uuidv7", "scope": "global", "required": true, "description": "opaque" },
+  "metadata": { "status": "seed" },
+  "enums": {
+    "status": { "values": ["ok"] }
+  },
+  "entities": {
+    "Foo": {
+      "natural_keys": [],
+      "required": ["id", "created_at", "updated_at"],
+      "properties": {
+        "id": {"type":"string"},
+        "created_at": {"type":"string"},
+        "updated_at": {"type":"string"},
+        "status": {"$ref":"#/enums/status"}
+      },
+      "relationships": {},
+      "states": {"enum": "status", "initial": "ok", "terminal": ["ok"]},
+      "invariants": []
+    }
+  }
+}`
+
+func writeSchema(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "entity-model.json")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("write schema: %v", err)
+	}
+	return path
+}
+
+func TestRunStrictFailsOnWarnings(t *testing.T) {
+	path := writeSchema(t, warningOnlySchema)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"validate-schema", "--schema", path}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected warnings to pass without --strict, got %d (%s%s)", code, stdout.String(), stderr.String())
+	}
+	if !strings.Contains(stdout.String(), `warning: entity "Foo" declares no natural keys`) || !strings.Contains(stdout.String(), "OK (0 errors, 1 warnings)") {
+		t.Fatalf("unexpected output %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"validate-schema", "--schema", path, "--strict"}, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected --strict to fail on warnings, got %d", code)
+	}
+	if !strings.Contains(stdout.String(), "FAILED (0 errors, 1 warnings)") {
+		t.Fatalf("unexpected strict output %q", stdout.String())
+	}
+}
+
+func TestRunReportsValidatorErrors(t *testing.T) {
+	path := writeSchema(t, strings.Replace(warningOnlySchema, `"version": "0.0.1"`, `"version": ""`, 1))
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"validate-schema", "--schema", path, "--json"}, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected errors to fail, got %d", code)
+	}
+	var report struct {
+		OK       bool     `json:"ok"`
+		Errors   []string `json:"errors"`
+		Warnings []string `json:"warnings"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v (%s)", err, stdout.String())
+	}
+	if report.OK || len(report.Errors) != 1 || report.Errors[0] != "version must be set (semver expected)" || len(report.Warnings) != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+}
+
+func TestRunRepositorySchemaPassesStrict(t *testing.T) {
+	path := filepath.Join("..", "..", "docs", "schema", "entity-model.json")
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"validate-schema", "--schema", path, "--strict"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected repository schema to pass, got %d (%s%s)", code, stdout.String(), stderr.String())
+	}
+}
+
+func TestRunUsageAndReadErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"validate-schema", "--bogus"}, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected usage error exit 2, got %d", code)
+	}
+	stderr.Reset()
+	if code := run([]string{"validate-schema", "--schema", filepath.Join(t.TempDir(), "missing.json")}, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected read error exit 2, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "read schema") {
+		t.Fatalf("expected read error message, got %q", stderr.String())
+	}
+}
@@ -67,3 +67,8 @@ func EntityModelMetadata() (Metadata, error) {
 	})
 	return schemaMeta, schemaErr
 }
+
+// EntityModelJSON returns a copy of the canonical entity-model JSON document.
+func EntityModelJSON() []byte {
+	return append([]byte(nil), entityModelSchema...)
+}
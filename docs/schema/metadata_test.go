@@ -40,3 +40,14 @@ func TestEntityModelMetadata(t *testing.T) {
 		t.Fatalf("metadata mismatch: got %+v want %+v", got, doc.Metadata)
 	}
 }
+
+func TestEntityModelJSONReturnsCopy(t *testing.T) {
+	first := EntityModelJSON()
+	if !json.Valid(first) {
+		t.Fatal("expected valid entity model JSON")
+	}
+	first[0] = 'x'
+	if EntityModelJSON()[0] == 'x' {
+		t.Fatal("expected EntityModelJSON to return a defensive copy")
+	}
+}
@@ -3,20 +3,26 @@
 
 CREATE TABLE IF NOT EXISTS facilities (
     access_policy TEXT NOT NULL,
+    accreditation_expires_at TIMESTAMPTZ,
+    accreditation_number TEXT,
     code TEXT NOT NULL,
     created_at TIMESTAMPTZ NOT NULL,
+    default_housing_environment TEXT,
     environment_baselines JSONB,
     id UUID NOT NULL,
     name TEXT NOT NULL,
+    timezone TEXT,
     updated_at TIMESTAMPTZ NOT NULL,
     zone TEXT NOT NULL,
-    PRIMARY KEY (id)
+    PRIMARY KEY (id),
+    CHECK ((default_housing_environment IN ('aquatic', 'terrestrial', 'arboreal', 'humid') OR default_housing_environment IS NULL))
 );
 CREATE UNIQUE INDEX IF NOT EXISTS idx_facilities_nk_1 ON facilities (code);
 
 CREATE TABLE IF NOT EXISTS genotype_markers (
     alleles JSONB NOT NULL,
     assay_method TEXT NOT NULL,
+    attributes JSONB,
     created_at TIMESTAMPTZ NOT NULL,
     id UUID NOT NULL,
     interpretation TEXT NOT NULL,
@@ -37,6 +43,7 @@ CREATE TABLE IF NOT EXISTS housing_units (
     name TEXT NOT NULL,
     state TEXT NOT NULL,
     updated_at TIMESTAMPTZ NOT NULL,
+    version INTEGER NOT NULL,
     PRIMARY KEY (id),
     FOREIGN KEY (facility_id) REFERENCES facilities(id),
     CHECK (environment IN ('aquatic', 'terrestrial', 'arboreal', 'humid')),
@@ -56,6 +63,7 @@ CREATE TABLE IF NOT EXISTS lines (
     id UUID NOT NULL,
     name TEXT NOT NULL,
     origin TEXT NOT NULL,
+    tags JSONB,
     updated_at TIMESTAMPTZ NOT NULL,
     PRIMARY KEY (id)
 );
@@ -75,6 +83,7 @@ CREATE TABLE IF NOT EXISTS permits (
     authority TEXT NOT NULL,
     created_at TIMESTAMPTZ NOT NULL,
     id UUID NOT NULL,
+    issue_date TIMESTAMPTZ NOT NULL,
     notes TEXT,
     permit_number TEXT NOT NULL,
     status TEXT NOT NULL,
@@ -96,6 +105,7 @@ CREATE TABLE IF NOT EXISTS permits__facility_ids (
 CREATE INDEX IF NOT EXISTS idx_permits__facility_ids_facility_id ON permits__facility_ids (facility_id);
 
 CREATE TABLE IF NOT EXISTS projects (
+    closed_at TIMESTAMPTZ,
     code TEXT NOT NULL,
     created_at TIMESTAMPTZ NOT NULL,
     description TEXT,
@@ -121,33 +131,16 @@ CREATE TABLE IF NOT EXISTS protocols (
     description TEXT,
     id UUID NOT NULL,
     max_subjects INTEGER NOT NULL,
+    reviewer_ids JSONB,
     status TEXT NOT NULL,
     title TEXT NOT NULL,
     updated_at TIMESTAMPTZ NOT NULL,
+    version INTEGER NOT NULL,
     PRIMARY KEY (id),
     CHECK (status IN ('draft', 'submitted', 'approved', 'on_hold', 'expired', 'archived'))
 );
 CREATE UNIQUE INDEX IF NOT EXISTS idx_protocols_nk_1 ON protocols (code);
 
-CREATE TABLE IF NOT EXISTS cohorts (
-    created_at TIMESTAMPTZ NOT NULL,
-    housing_id UUID,
-    id UUID NOT NULL,
-    name TEXT NOT NULL,
-    project_id UUID,
-    protocol_id UUID,
-    purpose TEXT NOT NULL,
-    updated_at TIMESTAMPTZ NOT NULL,
-    PRIMARY KEY (id),
-    FOREIGN KEY (housing_id) REFERENCES housing_units(id),
-    FOREIGN KEY (project_id) REFERENCES projects(id),
-    FOREIGN KEY (protocol_id) REFERENCES protocols(id)
-);
-CREATE INDEX IF NOT EXISTS idx_cohorts_housing_id ON cohorts (housing_id);
-CREATE INDEX IF NOT EXISTS idx_cohorts_project_id ON cohorts (project_id);
-CREATE INDEX IF NOT EXISTS idx_cohorts_protocol_id ON cohorts (protocol_id);
-CREATE UNIQUE INDEX IF NOT EXISTS idx_cohorts_nk_1 ON cohorts (project_id, name);
-
 CREATE TABLE IF NOT EXISTS permits__protocol_ids (
     permit_id UUID NOT NULL,
     protocol_id UUID NOT NULL,
@@ -157,27 +150,6 @@ CREATE TABLE IF NOT EXISTS permits__protocol_ids (
 );
 CREATE INDEX IF NOT EXISTS idx_permits__protocol_ids_protocol_id ON permits__protocol_ids (protocol_id);
 
-CREATE TABLE IF NOT EXISTS procedures (
-    cohort_id UUID,
-    created_at TIMESTAMPTZ NOT NULL,
-    id UUID NOT NULL,
-    name TEXT NOT NULL,
-    project_id UUID,
-    protocol_id UUID NOT NULL,
-    scheduled_at TIMESTAMPTZ NOT NULL,
-    status TEXT NOT NULL,
-    updated_at TIMESTAMPTZ NOT NULL,
-    PRIMARY KEY (id),
-    FOREIGN KEY (cohort_id) REFERENCES cohorts(id),
-    FOREIGN KEY (project_id) REFERENCES projects(id),
-    FOREIGN KEY (protocol_id) REFERENCES protocols(id),
-    CHECK (status IN ('scheduled', 'in_progress', 'completed', 'cancelled', 'failed'))
-);
-CREATE INDEX IF NOT EXISTS idx_procedures_cohort_id ON procedures (cohort_id);
-CREATE INDEX IF NOT EXISTS idx_procedures_project_id ON procedures (project_id);
-CREATE INDEX IF NOT EXISTS idx_procedures_protocol_id ON procedures (protocol_id);
-CREATE UNIQUE INDEX IF NOT EXISTS idx_procedures_nk_1 ON procedures (protocol_id, name, scheduled_at);
-
 CREATE TABLE IF NOT EXISTS projects__protocol_ids (
     project_id UUID NOT NULL,
     protocol_id UUID NOT NULL,
@@ -188,6 +160,7 @@ CREATE TABLE IF NOT EXISTS projects__protocol_ids (
 CREATE INDEX IF NOT EXISTS idx_projects__protocol_ids_protocol_id ON projects__protocol_ids (protocol_id);
 
 CREATE TABLE IF NOT EXISTS strains (
+    attributes JSONB,
     code TEXT NOT NULL,
     created_at TIMESTAMPTZ NOT NULL,
     description TEXT,
@@ -225,7 +198,8 @@ CREATE TABLE IF NOT EXISTS breeding_units (
     FOREIGN KEY (protocol_id) REFERENCES protocols(id),
     FOREIGN KEY (strain_id) REFERENCES strains(id),
     FOREIGN KEY (target_line_id) REFERENCES lines(id),
-    FOREIGN KEY (target_strain_id) REFERENCES strains(id)
+    FOREIGN KEY (target_strain_id) REFERENCES strains(id),
+    CHECK ((pairing_intent IN ('maintenance', 'expansion', 'experimental', 'rederivation') OR pairing_intent IS NULL))
 );
 CREATE INDEX IF NOT EXISTS idx_breeding_units_housing_id ON breeding_units (housing_id);
 CREATE INDEX IF NOT EXISTS idx_breeding_units_line_id ON breeding_units (line_id);
@@ -235,6 +209,29 @@ CREATE INDEX IF NOT EXISTS idx_breeding_units_target_line_id ON breeding_units (
 CREATE INDEX IF NOT EXISTS idx_breeding_units_target_strain_id ON breeding_units (target_strain_id);
 CREATE UNIQUE INDEX IF NOT EXISTS idx_breeding_units_nk_1 ON breeding_units (name, line_id);
 
+CREATE TABLE IF NOT EXISTS cohorts (
+    created_at TIMESTAMPTZ NOT NULL,
+    created_from_breeding_unit_id UUID,
+    housing_id UUID,
+    id UUID NOT NULL,
+    name TEXT NOT NULL,
+    project_id UUID,
+    protocol_id UUID,
+    purpose TEXT NOT NULL,
+    species TEXT,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (created_from_breeding_unit_id) REFERENCES breeding_units(id),
+    FOREIGN KEY (housing_id) REFERENCES housing_units(id),
+    FOREIGN KEY (project_id) REFERENCES projects(id),
+    FOREIGN KEY (protocol_id) REFERENCES protocols(id)
+);
+CREATE INDEX IF NOT EXISTS idx_cohorts_created_from_breeding_unit_id ON cohorts (created_from_breeding_unit_id);
+CREATE INDEX IF NOT EXISTS idx_cohorts_housing_id ON cohorts (housing_id);
+CREATE INDEX IF NOT EXISTS idx_cohorts_project_id ON cohorts (project_id);
+CREATE INDEX IF NOT EXISTS idx_cohorts_protocol_id ON cohorts (protocol_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cohorts_nk_1 ON cohorts (project_id, name);
+
 CREATE TABLE IF NOT EXISTS organisms (
     attributes JSONB,
     cohort_id UUID,
@@ -250,6 +247,7 @@ CREATE TABLE IF NOT EXISTS organisms (
     stage TEXT NOT NULL,
     strain_id UUID,
     updated_at TIMESTAMPTZ NOT NULL,
+    version INTEGER NOT NULL,
     PRIMARY KEY (id),
     FOREIGN KEY (cohort_id) REFERENCES cohorts(id),
     FOREIGN KEY (housing_id) REFERENCES housing_units(id),
@@ -285,17 +283,57 @@ CREATE TABLE IF NOT EXISTS breeding_units__male_ids (
 );
 CREATE INDEX IF NOT EXISTS idx_breeding_units__male_ids_organism_id ON breeding_units__male_ids (organism_id);
 
+CREATE TABLE IF NOT EXISTS organisms__parent_ids (
+    organism_id UUID NOT NULL,
+    parent_ids_id UUID NOT NULL,
+    PRIMARY KEY (organism_id, parent_ids_id),
+    FOREIGN KEY (organism_id) REFERENCES organisms(id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_ids_id) REFERENCES organisms(id) ON DELETE RESTRICT
+);
+CREATE INDEX IF NOT EXISTS idx_organisms__parent_ids_parent_ids_id ON organisms__parent_ids (parent_ids_id);
+
+CREATE TABLE IF NOT EXISTS procedures (
+    cancellation_reason TEXT,
+    cohort_id UUID,
+    created_at TIMESTAMPTZ NOT NULL,
+    id UUID NOT NULL,
+    name TEXT NOT NULL,
+    project_id UUID,
+    protocol_id UUID NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    status TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (id),
+    FOREIGN KEY (cohort_id) REFERENCES cohorts(id),
+    FOREIGN KEY (project_id) REFERENCES projects(id),
+    FOREIGN KEY (protocol_id) REFERENCES protocols(id),
+    CHECK (status IN ('scheduled', 'in_progress', 'completed', 'cancelled', 'failed'))
+);
+CREATE INDEX IF NOT EXISTS idx_procedures_cohort_id ON procedures (cohort_id);
+CREATE INDEX IF NOT EXISTS idx_procedures_project_id ON procedures (project_id);
+CREATE INDEX IF NOT EXISTS idx_procedures_protocol_id ON procedures (protocol_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_procedures_nk_1 ON procedures (protocol_id, name, scheduled_at);
+CREATE INDEX IF NOT EXISTS idx_procedures_scheduled_at ON procedures (scheduled_at);
+
 CREATE TABLE IF NOT EXISTS observations (
+    attachments JSONB,
+    category TEXT,
     cohort_id UUID,
     created_at TIMESTAMPTZ NOT NULL,
     data JSONB,
     id UUID NOT NULL,
+    length DOUBLE PRECISION,
     notes TEXT,
     observer TEXT NOT NULL,
     organism_id UUID,
     procedure_id UUID,
     recorded_at TIMESTAMPTZ NOT NULL,
+    recorded_by TEXT,
+    reviewed_at TIMESTAMPTZ,
+    reviewed_by TEXT,
+    temperature DOUBLE PRECISION,
     updated_at TIMESTAMPTZ NOT NULL,
+    weight DOUBLE PRECISION,
     PRIMARY KEY (id),
     FOREIGN KEY (cohort_id) REFERENCES cohorts(id),
     FOREIGN KEY (organism_id) REFERENCES organisms(id),
@@ -305,15 +343,7 @@ CREATE INDEX IF NOT EXISTS idx_observations_cohort_id ON observations (cohort_id
 CREATE INDEX IF NOT EXISTS idx_observations_organism_id ON observations (organism_id);
 CREATE INDEX IF NOT EXISTS idx_observations_procedure_id ON observations (procedure_id);
 CREATE UNIQUE INDEX IF NOT EXISTS idx_observations_nk_1 ON observations (procedure_id, recorded_at, observer);
-
-CREATE TABLE IF NOT EXISTS organisms__parent_ids (
-    organism_id UUID NOT NULL,
-    parent_ids_id UUID NOT NULL,
-    PRIMARY KEY (organism_id, parent_ids_id),
-    FOREIGN KEY (organism_id) REFERENCES organisms(id) ON DELETE CASCADE,
-    FOREIGN KEY (parent_ids_id) REFERENCES organisms(id) ON DELETE RESTRICT
-);
-CREATE INDEX IF NOT EXISTS idx_organisms__parent_ids_parent_ids_id ON organisms__parent_ids (parent_ids_id);
+CREATE INDEX IF NOT EXISTS idx_observations_recorded_at ON observations (recorded_at);
 
 CREATE TABLE IF NOT EXISTS procedures__organism_ids (
     procedure_id UUID NOT NULL,
@@ -330,6 +360,8 @@ CREATE TABLE IF NOT EXISTS samples (
     chain_of_custody JSONB NOT NULL,
     cohort_id UUID,
     collected_at TIMESTAMPTZ NOT NULL,
+    collected_by TEXT NOT NULL,
+    collection_protocol TEXT,
     created_at TIMESTAMPTZ NOT NULL,
     facility_id UUID NOT NULL,
     id UUID NOT NULL,
@@ -361,6 +393,7 @@ CREATE INDEX IF NOT EXISTS idx_strains__genotype_marker_ids_genotype_marker_id O
 
 CREATE TABLE IF NOT EXISTS supply_items (
     attributes JSONB,
+    category TEXT,
     created_at TIMESTAMPTZ NOT NULL,
     description TEXT,
     expires_at TIMESTAMPTZ,
@@ -370,9 +403,11 @@ CREATE TABLE IF NOT EXISTS supply_items (
     quantity_on_hand INTEGER NOT NULL,
     reorder_level INTEGER NOT NULL,
     sku TEXT NOT NULL,
+    status TEXT NOT NULL,
     unit TEXT NOT NULL,
     updated_at TIMESTAMPTZ NOT NULL,
-    PRIMARY KEY (id)
+    PRIMARY KEY (id),
+    CHECK (status IN ('active', 'consumed'))
 );
 CREATE UNIQUE INDEX IF NOT EXISTS idx_supply_items_nk_1 ON supply_items (sku);
 
@@ -398,7 +433,7 @@ CREATE TABLE IF NOT EXISTS treatments (
     administration_log JSONB,
     adverse_events JSONB,
     created_at TIMESTAMPTZ NOT NULL,
-    dosage_plan TEXT NOT NULL,
+    dosage_plan JSONB NOT NULL,
     id UUID NOT NULL,
     name TEXT NOT NULL,
     procedure_id UUID NOT NULL,
@@ -0,0 +1,309 @@
+package datasets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"colonycore/pkg/datasetapi"
+)
+
+const (
+	checkpointRootPrefix   = "checkpoints/"
+	checkpointChunkPrefix  = "chunk-"
+	checkpointCursorPrefix = "cursor-"
+)
+
+// exportCheckpoint is the cursor persisted after each spooled chunk. Cursors
+// are immutable objects numbered by chunk so the latest one always describes
+// a fully written chunk sequence.
+type exportCheckpoint struct {
+	RowsEmitted int    `json:"rows_emitted"`
+	LastKey     string `json:"last_key"`
+	Chunks      int    `json:"chunks"`
+	SchemaHash  string `json:"schema_hash,omitempty"`
+}
+
+// spooledRow is one line of a checkpoint chunk.
+type spooledRow struct {
+	Key string         `json:"key"`
+	Row datasetapi.Row `json:"row"`
+}
+
+// SetCheckpointInterval enables incremental export checkpointing. Exports are
+// spooled to the object store in chunks of rows, each followed by a cursor. A
+// resumed export runs its query from the latest cursor (see
+// datasetapi.WithResumeAfter), so rows already spooled are neither read nor
+// re-emitted. Values below one disable checkpointing. It must be called before
+// Start.
+func (w *Worker) SetCheckpointInterval(rows int) {
+	if rows < 1 {
+		rows = 0
+	}
+	w.checkpointRows = rows
+}
+
+func checkpointPrefix(id string) string {
+	return checkpointRootPrefix + id + "/"
+}
+
+func checkpointChunkKey(id string, seq int) string {
+	return fmt.Sprintf("%s%s%06d.jsonl", checkpointPrefix(id), checkpointChunkPrefix, seq)
+}
+
+func checkpointCursorKey(id string, seq int) string {
+	return fmt.Sprintf("%s%s%06d.json", checkpointPrefix(id), checkpointCursorPrefix, seq)
+}
+
+// checkpointSeq parses the sequence number from a chunk or cursor key.
+func checkpointSeq(id, key, kind string) (int, bool) {
+	name, ok := strings.CutPrefix(key, checkpointPrefix(id)+kind)
+	if !ok {
+		return 0, false
+	}
+	digits, _, _ := strings.Cut(name, ".")
+	seq, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, false
+	}
+	return seq, true
+}
+
+// errCheckpointStale reports a resumed run whose rows cannot extend the
+// spooled prefix, such as after the template's columns changed.
+var errCheckpointStale = errors.New("checkpoint no longer matches the template")
+
+// runCheckpointed executes an export through execute, resuming the query after
+// the latest persisted cursor so rows already spooled are not read again, and
+// spools the new rows. It returns the result holding every row in stable
+// order, or reports false once the export has been failed.
+func (w *Worker) runCheckpointed(ctx context.Context, id string, execute func(context.Context) (datasetapi.RunResult, bool), started time.Time) (datasetapi.RunResult, bool) {
+	fail := func(err error) (datasetapi.RunResult, bool) {
+		if ctx.Err() != nil {
+			w.fail(id, ErrExportCancelled.Error(), time.Since(started))
+		} else {
+			w.fail(id, fmt.Sprintf("checkpoint export failed: %v", err), time.Since(started))
+		}
+		return datasetapi.RunResult{}, false
+	}
+	checkpoint, err := w.loadCheckpoint(ctx, id)
+	if err != nil {
+		return fail(err)
+	}
+	result, ok := execute(datasetapi.WithResumeAfter(ctx, checkpoint.LastKey))
+	if !ok {
+		return result, false
+	}
+	rows, err := w.spool(ctx, id, checkpoint, result)
+	if errors.Is(err, errCheckpointStale) {
+		if result, ok = execute(ctx); !ok {
+			return result, false
+		}
+		rows, err = w.spool(ctx, id, exportCheckpoint{}, result)
+	}
+	if err != nil {
+		return fail(err)
+	}
+	result.Rows = rows
+	return result, true
+}
+
+// spool writes result rows in stable order to checkpoint chunks after those
+// covered by checkpoint and returns every spooled row once each has been
+// written exactly once. A result resumed from the checkpoint's cursor holds
+// only the remaining rows; a full result has its spooled prefix skipped, or
+// is spooled from scratch when it no longer reproduces that prefix.
+func (w *Worker) spool(ctx context.Context, id string, checkpoint exportCheckpoint, result datasetapi.RunResult) ([]datasetapi.Row, error) {
+	ordered := datasetapi.OrderRows(result.Schema, result.Rows)
+	keys := make([]string, len(ordered))
+	for i, row := range ordered {
+		keys[i] = datasetapi.RowKey(result.Schema, row)
+	}
+	schemaHash, _ := result.Metadata[datasetapi.SchemaHashMetadataKey].(string)
+	resumedAfter, _ := result.Metadata[datasetapi.ResumedAfterMetadataKey].(string)
+
+	resumed := resumedAfter != ""
+	switch {
+	case resumed && (checkpoint.Chunks == 0 || resumedAfter != checkpoint.LastKey || checkpoint.SchemaHash != schemaHash):
+		if err := w.discardCheckpoint(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, errCheckpointStale
+	case resumed:
+	case checkpointMatches(checkpoint, keys, schemaHash):
+		ordered, keys = ordered[checkpoint.RowsEmitted:], keys[checkpoint.RowsEmitted:]
+	default:
+		// The query no longer reproduces the spooled prefix; start over rather
+		// than splice rows from two different result sets.
+		if err := w.discardCheckpoint(ctx, id); err != nil {
+			return nil, err
+		}
+		checkpoint = exportCheckpoint{SchemaHash: schemaHash}
+	}
+	prefix := checkpoint
+
+	for start := 0; start < len(ordered); start += w.checkpointRows {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		end := min(start+w.checkpointRows, len(ordered))
+		buf := &bytes.Buffer{}
+		encoder := json.NewEncoder(buf)
+		for i := start; i < end; i++ {
+			if err := encoder.Encode(spooledRow{Key: keys[i], Row: ordered[i]}); err != nil {
+				return nil, fmt.Errorf("encode checkpoint row: %w", err)
+			}
+		}
+		seq := checkpoint.Chunks + 1
+		if _, err := w.store.Put(ctx, checkpointChunkKey(id, seq), buf.Bytes(), "application/x-ndjson", map[string]any{"rows": end - start}); err != nil {
+			return nil, err
+		}
+		next := exportCheckpoint{RowsEmitted: prefix.RowsEmitted + end, LastKey: keys[end-1], Chunks: seq, SchemaHash: schemaHash}
+		payload, err := json.Marshal(next)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.store.Put(ctx, checkpointCursorKey(id, seq), payload, "application/json", nil); err != nil {
+			return nil, err
+		}
+		checkpoint = next
+	}
+
+	spooled, err := w.readSpool(ctx, id, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]datasetapi.Row, 0, len(spooled))
+	for i, line := range spooled {
+		if i < prefix.RowsEmitted {
+			rows = append(rows, restoreSpooledRow(result.Schema, line.Row))
+			continue
+		}
+		if line.Key != keys[i-prefix.RowsEmitted] {
+			return nil, fmt.Errorf("checkpoint diverges from result at row %d", i)
+		}
+		rows = append(rows, ordered[i-prefix.RowsEmitted])
+	}
+	return rows, nil
+}
+
+// checkpointMatches reports whether a persisted cursor describes a prefix of
+// the current ordered result.
+func checkpointMatches(checkpoint exportCheckpoint, keys []string, schemaHash string) bool {
+	if checkpoint.RowsEmitted == 0 {
+		return checkpoint.Chunks == 0
+	}
+	if checkpoint.SchemaHash != schemaHash || checkpoint.RowsEmitted > len(keys) {
+		return false
+	}
+	return keys[checkpoint.RowsEmitted-1] == checkpoint.LastKey
+}
+
+// loadCheckpoint returns the latest cursor for id and deletes any chunk written
+// after it, such as a chunk whose cursor never landed.
+func (w *Worker) loadCheckpoint(ctx context.Context, id string) (exportCheckpoint, error) {
+	objects, err := w.store.List(ctx, checkpointPrefix(id))
+	if err != nil {
+		return exportCheckpoint{}, err
+	}
+	latest := 0
+	for _, object := range objects {
+		if seq, ok := checkpointSeq(id, object.ID, checkpointCursorPrefix); ok && seq > latest {
+			latest = seq
+		}
+	}
+	var checkpoint exportCheckpoint
+	if latest > 0 {
+		_, payload, err := w.store.Get(ctx, checkpointCursorKey(id, latest))
+		if err != nil {
+			return exportCheckpoint{}, err
+		}
+		if err := json.Unmarshal(payload, &checkpoint); err != nil {
+			return exportCheckpoint{}, fmt.Errorf("decode checkpoint cursor: %w", err)
+		}
+	}
+	for _, object := range objects {
+		seq, ok := checkpointSeq(id, object.ID, checkpointChunkPrefix)
+		if !ok || seq <= checkpoint.Chunks {
+			continue
+		}
+		if _, err := w.store.Delete(ctx, object.ID); err != nil {
+			return exportCheckpoint{}, err
+		}
+	}
+	return checkpoint, nil
+}
+
+// readSpool reads back every chunk and confirms the spooled rows are in
+// strictly increasing key order and number the cursor's RowsEmitted, so no
+// row is missing or duplicated.
+func (w *Worker) readSpool(ctx context.Context, id string, checkpoint exportCheckpoint) ([]spooledRow, error) {
+	spooled := make([]spooledRow, 0, checkpoint.RowsEmitted)
+	for seq := 1; seq <= checkpoint.Chunks; seq++ {
+		_, payload, err := w.store.Get(ctx, checkpointChunkKey(id, seq))
+		if err != nil {
+			return nil, err
+		}
+		decoder := json.NewDecoder(bytes.NewReader(payload))
+		decoder.UseNumber()
+		for decoder.More() {
+			var line spooledRow
+			if err := decoder.Decode(&line); err != nil {
+				return nil, fmt.Errorf("decode checkpoint chunk %d: %w", seq, err)
+			}
+			if n := len(spooled); n > 0 && spooled[n-1].Key >= line.Key {
+				return nil, fmt.Errorf("checkpoint chunk %d is out of order at row %d", seq, n)
+			}
+			spooled = append(spooled, line)
+		}
+	}
+	if len(spooled) != checkpoint.RowsEmitted {
+		return nil, fmt.Errorf("checkpoint spooled %d of %d rows", len(spooled), checkpoint.RowsEmitted)
+	}
+	return spooled, nil
+}
+
+// restoreSpooledRow converts cells decoded from a checkpoint chunk back to the
+// Go types runners produce for the declared column types.
+func restoreSpooledRow(columns []datasetapi.Column, row datasetapi.Row) datasetapi.Row {
+	for _, column := range columns {
+		switch value := row[column.Name].(type) {
+		case json.Number:
+			if column.Type == "integer" {
+				if n, err := value.Int64(); err == nil {
+					row[column.Name] = n
+					continue
+				}
+			}
+			if f, err := value.Float64(); err == nil {
+				row[column.Name] = f
+			}
+		case string:
+			if column.Type == "timestamp" {
+				if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+					row[column.Name] = t
+				}
+			}
+		}
+	}
+	return row
+}
+
+// discardCheckpoint deletes every checkpoint object for id.
+func (w *Worker) discardCheckpoint(ctx context.Context, id string) error {
+	objects, err := w.store.List(ctx, checkpointPrefix(id))
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		if _, err := w.store.Delete(ctx, object.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+package datasets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"colonycore/pkg/datasetapi"
+)
+
+// checkpointStore fails Puts whose key matches failKey and counts the rows
+// written to checkpoint chunks.
+type checkpointStore struct {
+	*MemoryObjectStore
+
+	mu          sync.Mutex
+	failKey     func(key string) bool
+	chunkRows   int
+	chunkValues []string
+}
+
+func (s *checkpointStore) Put(ctx context.Context, key string, payload []byte, contentType string, metadata map[string]any) (ExportArtifact, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failKey != nil && s.failKey(key) {
+		return ExportArtifact{}, fmt.Errorf("simulated failure writing %s", key)
+	}
+	if strings.Contains(key, "/"+checkpointChunkPrefix) {
+		for _, line := range strings.Split(strings.TrimSpace(string(payload)), "\n") {
+			var spooled spooledRow
+			if err := json.Unmarshal([]byte(line), &spooled); err != nil {
+				return ExportArtifact{}, err
+			}
+			s.chunkRows++
+			s.chunkValues = append(s.chunkValues, fmt.Sprint(spooled.Row["value"]))
+		}
+	}
+	return s.MemoryObjectStore.Put(ctx, key, payload, contentType, metadata)
+}
+
+func (s *checkpointStore) arm(failKey func(string) bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failKey = failKey
+	s.chunkRows = 0
+	s.chunkValues = nil
+}
+
+func (s *checkpointStore) written() (int, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.chunkRows, append([]string(nil), s.chunkValues...)
+}
+
+// newCheckpointRuntime returns five rows, emitting them in a different order on
+// every run to prove the export ordering does not depend on the runner.
+func newCheckpointRuntime() *stubRuntime {
+	formatProvider := datasetapi.GetFormatProvider()
+	runtime := newStubRuntime("frog", "checkpoint", "1", "Checkpoint", "checkpoint dataset",
+		[]datasetapi.Column{{Name: "value", Type: "string"}}, []datasetapi.Format{formatProvider.JSON()})
+	values := []string{"a", "b", "c", "d", "e"}
+	var runs int
+	runtime.runFn = func(context.Context, map[string]any, datasetapi.Scope, datasetapi.Format) (datasetapi.RunResult, []datasetapi.ParameterError, error) {
+		runs++
+		rows := make([]datasetapi.Row, len(values))
+		for i, value := range values {
+			rows[(i+runs)%len(values)] = datasetapi.Row{"value": value}
+		}
+		return datasetapi.RunResult{
+			Schema:      append([]datasetapi.Column(nil), runtime.desc.Columns...),
+			Rows:        rows,
+			GeneratedAt: time.Unix(0, 0).UTC(),
+			Format:      formatProvider.JSON(),
+		}, nil, nil
+	}
+	return runtime
+}
+
+func TestWorkerResumeEmitsRemainingRowsOnce(t *testing.T) {
+	for name, failKey := range map[string]func(string) bool{
+		"chunk write fails":  func(key string) bool { return strings.HasSuffix(key, "chunk-000002.jsonl") },
+		"cursor write fails": func(key string) bool { return strings.HasSuffix(key, "cursor-000002.json") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			runtime := newCheckpointRuntime()
+			store := &checkpointStore{MemoryObjectStore: NewMemoryObjectStore()}
+			store.arm(failKey)
+			worker := NewWorker(fakeCatalog{tpl: runtime}, store, &MemoryAuditLog{})
+			worker.SetCheckpointInterval(2)
+			worker.Start()
+			defer func() { _ = worker.Stop(context.Background()) }()
+
+			record, err := worker.EnqueueExport(context.Background(), ExportInput{
+				TemplateSlug: runtime.desc.Slug,
+				Formats:      []datasetapi.Format{datasetapi.GetFormatProvider().JSON()},
+				RequestedBy:  "analyst",
+			})
+			if err != nil {
+				t.Fatalf("enqueue: %v", err)
+			}
+			failed := waitForExportRecord(t, worker, record.ID, 2*time.Second, func(r ExportRecord) bool {
+				return r.Status == ExportStatusFailed
+			})
+			if !strings.Contains(failed.Error, "checkpoint export failed") {
+				t.Fatalf("expected checkpoint failure, got %q", failed.Error)
+			}
+
+			store.arm(nil)
+			if err := worker.Resume(record.ID); err != nil {
+				t.Fatalf("resume: %v", err)
+			}
+			resumed := waitForExportRecord(t, worker, record.ID, 2*time.Second, func(r ExportRecord) bool {
+				return r.Status == ExportStatusSucceeded || r.Status == ExportStatusFailed
+			})
+			if resumed.Status != ExportStatusSucceeded {
+				t.Fatalf("expected resumed export to succeed, got %s: %s", resumed.Status, resumed.Error)
+			}
+			if rows, values := store.written(); rows != 3 || !slices.Equal(values, []string{"c", "d", "e"}) {
+				t.Fatalf("expected resume to spool only the remaining rows, got %d %v", rows, values)
+			}
+
+			if len(resumed.Artifacts) != 1 {
+				t.Fatalf("expected one artifact, got %+v", resumed.Artifacts)
+			}
+			_, payload, err := store.Get(context.Background(), resumed.Artifacts[0].ID)
+			if err != nil {
+				t.Fatalf("get artifact: %v", err)
+			}
+			var result datasetapi.RunResult
+			if err := json.Unmarshal(payload, &result); err != nil {
+				t.Fatalf("decode artifact: %v", err)
+			}
+			values := make([]string, 0, len(result.Rows))
+			for _, row := range result.Rows {
+				values = append(values, fmt.Sprint(row["value"]))
+			}
+			if !slices.Equal(values, []string{"a", "b", "c", "d", "e"}) {
+				t.Fatalf("expected every row exactly once in stable order, got %v", values)
+			}
+			if leftover, _ := store.List(context.Background(), checkpointRootPrefix); len(leftover) != 0 {
+				t.Fatalf("expected checkpoint objects to be discarded, got %+v", leftover)
+			}
+		})
+	}
+}
+
+func TestWorkerResumeRejectsUnknownAndUnfailedExports(t *testing.T) {
+	runtime := newCheckpointRuntime()
+	worker := NewWorker(fakeCatalog{tpl: runtime}, NewMemoryObjectStore(), nil)
+	worker.SetCheckpointInterval(2)
+	worker.Start()
+	defer func() { _ = worker.Stop(context.Background()) }()
+
+	if err := worker.Resume("missing"); !errors.Is(err, ErrExportNotFound) {
+		t.Fatalf("expected ErrExportNotFound, got %v", err)
+	}
+	record, err := worker.EnqueueExport(context.Background(), ExportInput{
+		TemplateSlug: runtime.desc.Slug,
+		Formats:      []datasetapi.Format{datasetapi.GetFormatProvider().JSON()},
+	})
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	waitForExportRecord(t, worker, record.ID, 2*time.Second, func(r ExportRecord) bool {
+		return r.Status == ExportStatusSucceeded
+	})
+	if err := worker.Resume(record.ID); !errors.Is(err, ErrExportNotResumable) {
+		t.Fatalf("expected ErrExportNotResumable, got %v", err)
+	}
+}
+
+func TestWorkerResumeRunsQueryFromCursor(t *testing.T) {
+	formatProvider := datasetapi.GetFormatProvider()
+	runtime := newStubRuntime("frog", "cursor", "1", "Cursor", "cursor dataset",
+		[]datasetapi.Column{{Name: "value", Type: "string"}, {Name: "count", Type: "integer"}}, []datasetapi.Format{formatProvider.JSON()})
+	var (
+		mu      sync.Mutex
+		cursors []string
+		read    int
+	)
+	runtime.runFn = func(ctx context.Context, _ map[string]any, _ datasetapi.Scope, _ datasetapi.Format) (datasetapi.RunResult, []datasetapi.ParameterError, error) {
+		after, resumed := datasetapi.ResumeAfterFromContext(ctx)
+		result := datasetapi.RunResult{
+			Schema:      append([]datasetapi.Column(nil), runtime.desc.Columns...),
+			Metadata:    map[string]any{},
+			GeneratedAt: time.Unix(0, 0).UTC(),
+			Format:      formatProvider.JSON(),
+		}
+		for i, value := range []string{"a", "b", "c", "d", "e"} {
+			row := datasetapi.Row{"value": value, "count": int64(i)}
+			if resumed && datasetapi.RowKey(result.Schema, row) <= after {
+				continue
+			}
+			result.Rows = append(result.Rows, row)
+		}
+		if resumed {
+			result.Metadata[datasetapi.ResumedAfterMetadataKey] = after
+		}
+		mu.Lock()
+		cursors = append(cursors, after)
+		read += len(result.Rows)
+		mu.Unlock()
+		return result, nil, nil
+	}
+
+	store := &checkpointStore{MemoryObjectStore: NewMemoryObjectStore()}
+	store.arm(func(key string) bool { return strings.HasSuffix(key, "chunk-000002.jsonl") })
+	worker := NewWorker(fakeCatalog{tpl: runtime}, store, &MemoryAuditLog{})
+	worker.SetCheckpointInterval(2)
+	worker.Start()
+	defer func() { _ = worker.Stop(context.Background()) }()
+
+	record, err := worker.EnqueueExport(context.Background(), ExportInput{
+		TemplateSlug: runtime.desc.Slug,
+		Formats:      []datasetapi.Format{formatProvider.JSON()},
+		RequestedBy:  "analyst",
+	})
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	waitForExportRecord(t, worker, record.ID, 2*time.Second, func(r ExportRecord) bool {
+		return r.Status == ExportStatusFailed
+	})
+
+	store.arm(nil)
+	if err := worker.Resume(record.ID); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	resumed := waitForExportRecord(t, worker, record.ID, 2*time.Second, func(r ExportRecord) bool {
+		return r.Status == ExportStatusSucceeded || r.Status == ExportStatusFailed
+	})
+	if resumed.Status != ExportStatusSucceeded {
+		t.Fatalf("expected resumed export to succeed, got %s: %s", resumed.Status, resumed.Error)
+	}
+
+	mu.Lock()
+	gotCursors, gotRead := append([]string(nil), cursors...), read
+	mu.Unlock()
+	wantCursor := datasetapi.RowKey(runtime.desc.Columns, datasetapi.Row{"value": "b", "count": int64(1)})
+	if !slices.Equal(gotCursors, []string{"", wantCursor}) {
+		t.Fatalf("expected the resumed run to start after %s, got cursors %q", wantCursor, gotCursors)
+	}
+	if gotRead != 8 {
+		t.Fatalf("expected the resumed query to read only the remaining 3 rows, read %d in total", gotRead)
+	}
+	if rows, values := store.written(); rows != 3 || !slices.Equal(values, []string{"c", "d", "e"}) {
+		t.Fatalf("expected resume to spool only the remaining rows, got %d %v", rows, values)
+	}
+
+	_, payload, err := store.Get(context.Background(), resumed.Artifacts[0].ID)
+	if err != nil {
+		t.Fatalf("get artifact: %v", err)
+	}
+	var result datasetapi.RunResult
+	if err := json.Unmarshal(payload, &result); err != nil {
+		t.Fatalf("decode artifact: %v", err)
+	}
+	got := make([]string, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		got = append(got, fmt.Sprintf("%v:%v", row["value"], row["count"]))
+	}
+	if !slices.Equal(got, []string{"a:0", "b:1", "c:2", "d:3", "e:4"}) {
+		t.Fatalf("expected spooled and resumed rows exactly once in order, got %v", got)
+	}
+}
+
+func TestRestoreSpooledRowRecoversColumnTypes(t *testing.T) {
+	columns := []datasetapi.Column{
+		{Name: "count", Type: "integer"},
+		{Name: "mass", Type: "number"},
+		{Name: "at", Type: "timestamp"},
+		{Name: "name", Type: "string"},
+	}
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	encoded, err := json.Marshal(datasetapi.Row{"count": int64(7), "mass": 1.5, "at": at, "name": "2024-03-01T12:00:00Z"})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(encoded)))
+	decoder.UseNumber()
+	var row datasetapi.Row
+	if err := decoder.Decode(&row); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	row = restoreSpooledRow(columns, row)
+	if row["count"] != int64(7) || row["mass"] != 1.5 || row["at"] != at || row["name"] != "2024-03-01T12:00:00Z" {
+		t.Fatalf("unexpected restored row %#v", row)
+	}
+}
@@ -0,0 +1,100 @@
+package datasets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"colonycore/internal/core"
+	"colonycore/pkg/datasetapi"
+)
+
+// budgetEstimator estimates one row per granted facility against a fixed
+// budget, mirroring how the core service reports oversized queries.
+type budgetEstimator struct {
+	budget int
+}
+
+func (e budgetEstimator) EstimateDatasetCost(slug string, _ map[string]any, scope datasetapi.RBACScope) (datasetapi.CostEstimate, error) {
+	estimate := datasetapi.CostEstimate{Template: slug, Entity: "organisms", Rows: len(scope.FacilityIDs), Budget: e.budget, Estimated: true}
+	if estimate.Rows > estimate.Budget {
+		estimate.ExceedsBudget = true
+		return estimate, &datasetapi.QueryTooLargeError{Estimate: estimate}
+	}
+	return estimate, nil
+}
+
+func TestEnqueueExportEnforcesCostBudget(t *testing.T) {
+	tpl := buildFacilityTemplate()
+	worker := NewWorker(testCatalog{tpl: tpl}, NewMemoryObjectStore(), &MemoryAuditLog{})
+	worker.SetGrantResolver(facilityGrants(map[string][]string{
+		"analyst": {"facility-a"},
+		"admin":   {"facility-a", "facility-b", "facility-c"},
+	}))
+	worker.SetCostEstimator(budgetEstimator{budget: 2})
+	slug := tpl.Descriptor().Slug
+
+	if _, err := worker.EnqueueExport(context.Background(), ExportInput{
+		TemplateSlug: slug,
+		Formats:      []datasetapi.Format{core.FormatJSON},
+		RequestedBy:  "analyst",
+	}); err != nil {
+		t.Fatalf("expected small export to be queued, got %v", err)
+	}
+
+	_, err := worker.EnqueueExport(context.Background(), ExportInput{
+		TemplateSlug: slug,
+		Formats:      []datasetapi.Format{core.FormatJSON},
+		RequestedBy:  "admin",
+	})
+	var tooLarge *datasetapi.QueryTooLargeError
+	if !errors.As(err, &tooLarge) || !errors.Is(err, datasetapi.ErrQueryTooLarge) {
+		t.Fatalf("expected QueryTooLargeError, got %v", err)
+	}
+	if tooLarge.Estimate.Rows != 3 || tooLarge.Estimate.Budget != 2 || !tooLarge.Estimate.ExceedsBudget {
+		t.Fatalf("expected estimate attached to rejection, got %+v", tooLarge.Estimate)
+	}
+
+	if _, err := worker.EnqueueExport(context.Background(), ExportInput{
+		TemplateSlug:      slug,
+		Formats:           []datasetapi.Format{core.FormatJSON},
+		RequestedBy:       "admin",
+		ConfirmLargeQuery: true,
+	}); err != nil {
+		t.Fatalf("expected confirmed export to be queued, got %v", err)
+	}
+}
+
+func TestHandleExportCreateRejectsQueryTooLarge(t *testing.T) {
+	tpl := buildFacilityTemplate()
+	cat := testCatalog{tpl: tpl}
+	worker := NewWorker(cat, NewMemoryObjectStore(), &MemoryAuditLog{})
+	worker.SetGrantResolver(facilityGrants(map[string][]string{"admin": {"facility-a", "facility-b"}}))
+	worker.SetCostEstimator(budgetEstimator{budget: 1})
+	h := &Handler{Catalog: cat, Exports: worker}
+
+	body := `{"template":{"slug":"` + tpl.Descriptor().Slug + `"},"formats":["json"],"requested_by":"admin"}`
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, asPrincipal(httptest.NewRequest(http.MethodPost, "/api/v1/datasets/exports", strings.NewReader(body)), "admin"))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 got %d body=%s", w.Code, w.Body.String())
+	}
+	var problem problemDetail
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+	if problem.Estimate == nil || problem.Estimate.Rows != 2 || problem.Estimate.Budget != 1 {
+		t.Fatalf("expected estimate in problem detail, got %+v", problem)
+	}
+
+	body = `{"template":{"slug":"` + tpl.Descriptor().Slug + `"},"formats":["json"],"requested_by":"admin","confirm_large_query":true}`
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, asPrincipal(httptest.NewRequest(http.MethodPost, "/api/v1/datasets/exports", strings.NewReader(body)), "admin"))
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected confirmed export to be accepted, got %d body=%s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,172 @@
+package datasets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"colonycore/pkg/datasetapi"
+)
+
+var (
+	// ErrExportNotFound is returned when an export job ID is unknown to the worker.
+	ErrExportNotFound = errors.New("export not found")
+	// ErrExportFinished is returned when cancelling an export that already reached a terminal state.
+	ErrExportFinished = errors.New("export already finished")
+	// ErrExportCancelled is recorded as the failure reason for cancelled exports.
+	ErrExportCancelled = errors.New("export cancelled")
+	// ErrExportNotResumable is returned when resuming an export that has not failed.
+	ErrExportNotResumable = errors.New("export not resumable")
+)
+
+// ExportJob is a background export submission naming a dataset template, its
+// parameters, and the requestor. Scope carries the RBAC filters (roles,
+// projects, protocols) applied when the template runs against the store; when
+// the worker has a GrantResolver, the requestor's grants replace Scope.RBAC.
+type ExportJob struct {
+	Template    string
+	Parameters  map[string]any
+	Formats     []datasetapi.Format
+	Scope       datasetapi.Scope
+	RequestedBy string
+	Reason      string
+}
+
+// JobStatus summarises the lifecycle of a submitted export job.
+type JobStatus struct {
+	ID          string
+	State       ExportStatus
+	Error       string
+	Artifacts   []ExportArtifact
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// Submit queues job on the worker pool and returns its ID. The requestor is
+// used as the scope requestor when the job does not specify one explicitly.
+func (w *Worker) Submit(job ExportJob) (string, error) {
+	scope := job.Scope
+	if scope.Requestor == "" {
+		scope.Requestor = job.RequestedBy
+	}
+	record, err := w.EnqueueExport(context.Background(), ExportInput{
+		TemplateSlug: job.Template,
+		Parameters:   job.Parameters,
+		Formats:      job.Formats,
+		Scope:        scope,
+		RequestedBy:  job.RequestedBy,
+		Reason:       job.Reason,
+	})
+	if err != nil {
+		return "", err
+	}
+	return record.ID, nil
+}
+
+// Status reports the current state of the export job identified by id.
+func (w *Worker) Status(id string) (JobStatus, bool) {
+	record, ok := w.GetExport(id)
+	if !ok {
+		return JobStatus{}, false
+	}
+	return JobStatus{
+		ID:          record.ID,
+		State:       record.Status,
+		Error:       record.Error,
+		Artifacts:   record.Artifacts,
+		CreatedAt:   record.CreatedAt,
+		UpdatedAt:   record.UpdatedAt,
+		CompletedAt: record.CompletedAt,
+	}, true
+}
+
+// Cancel stops the export identified by id. Queued exports are failed
+// immediately and never run; running exports have their context cancelled and
+// fail once the template or artifact store observes it.
+func (w *Worker) Cancel(id string) error {
+	w.mu.Lock()
+	record, ok := w.jobs[id]
+	if !ok {
+		w.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrExportNotFound, id)
+	}
+	if cancel, claimed := w.running[id]; claimed {
+		w.mu.Unlock()
+		cancel()
+		return nil
+	}
+	switch record.Status {
+	case ExportStatusQueued:
+		// Flip the status under the lock so a worker dequeuing the task skips it.
+		record.Status = ExportStatusFailed
+		w.mu.Unlock()
+		w.fail(id, ErrExportCancelled.Error(), 0)
+		return nil
+	default:
+		status := record.Status
+		w.mu.Unlock()
+		return fmt.Errorf("%w: %s is %s", ErrExportFinished, id, status)
+	}
+}
+
+// Resume re-queues the failed export identified by id under the same ID. With
+// checkpointing enabled the worker skips rows already spooled before the
+// failure and emits only the remainder; otherwise the export runs again from
+// the start.
+func (w *Worker) Resume(id string) error {
+	w.mu.Lock()
+	record, ok := w.jobs[id]
+	if !ok {
+		w.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrExportNotFound, id)
+	}
+	if _, claimed := w.running[id]; claimed || record.Status != ExportStatusFailed {
+		status := record.Status
+		w.mu.Unlock()
+		return fmt.Errorf("%w: %s is %s", ErrExportNotResumable, id, status)
+	}
+	now := time.Now().UTC()
+	record.Status = ExportStatusQueued
+	record.Error = ""
+	record.Artifacts = nil
+	record.CompletedAt = nil
+	record.StartedAt = nil
+	record.ProgressState = ExportProgressStateQueued
+	record.ProgressPct = 0
+	record.UpdatedAt = now
+	refreshExportProgress(record, now)
+	task := exportTask{id: id, input: ExportInput{
+		TemplateSlug: record.Template.Slug,
+		Parameters:   cloneMap(record.Parameters),
+		Formats:      append([]datasetapi.Format(nil), record.Formats...),
+		Scope:        record.Scope,
+		RequestedBy:  record.RequestedBy,
+		ProjectID:    record.ProjectID,
+		ProtocolID:   record.ProtocolID,
+		Reason:       record.Reason,
+	}}
+	w.mu.Unlock()
+
+	select {
+	case w.queue <- task:
+	default:
+		w.fail(id, "export queue full", 0)
+		return fmt.Errorf("export queue full")
+	}
+	if w.audit != nil {
+		w.audit.Record(w.ctx, AuditEntry{
+			ID:         newID(),
+			Action:     "dataset_export",
+			Actor:      task.input.RequestedBy,
+			Template:   task.input.TemplateSlug,
+			Status:     ExportStatusQueued,
+			Scope:      task.input.Scope,
+			Reason:     task.input.Reason,
+			Metadata:   map[string]any{"resumed": true},
+			OccurredAt: now,
+		})
+	}
+	return nil
+}
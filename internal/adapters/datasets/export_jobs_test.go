@@ -0,0 +1,207 @@
+package datasets
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/pkg/datasetapi"
+)
+
+func waitForJobState(t *testing.T, w *Worker, id string, want ExportStatus) JobStatus {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		status, ok := w.Status(id)
+		if !ok {
+			t.Fatalf("job %s not found", id)
+		}
+		if status.State == want {
+			return status
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	status, _ := w.Status(id)
+	t.Fatalf("job %s did not reach %s, last status %+v", id, want, status)
+	return JobStatus{}
+}
+
+func hasAuditStatus(entries []AuditEntry, status ExportStatus) bool {
+	for _, entry := range entries {
+		if entry.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+func TestWorkerSubmitCompletesWithScopedRun(t *testing.T) {
+	formatProvider := datasetapi.GetFormatProvider()
+	tpl := buildRuntimeTemplate()
+	scopes := make(chan datasetapi.Scope, 1)
+	run := tpl.runFn
+	tpl.runFn = func(ctx context.Context, params map[string]any, scope datasetapi.Scope, format datasetapi.Format) (datasetapi.RunResult, []datasetapi.ParameterError, error) {
+		scopes <- scope
+		return run(ctx, params, scope, format)
+	}
+	store := NewMemoryObjectStore()
+	audit := &MemoryAuditLog{}
+	w := NewWorker(fakeCatalog{tpl: tpl}, store, audit)
+	w.Start()
+	defer func() { _ = w.Stop(context.Background()) }()
+
+	id, err := w.Submit(ExportJob{
+		Template:    tpl.Descriptor().Slug,
+		Parameters:  map[string]any{"limit": 1},
+		Formats:     []datasetapi.Format{formatProvider.JSON()},
+		Scope:       datasetapi.Scope{Roles: []string{"analyst"}, ProjectIDs: []string{"project-1"}},
+		RequestedBy: "analyst@example",
+	})
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	status := waitForJobState(t, w, id, ExportStatusSucceeded)
+	if len(status.Artifacts) != 1 || status.CompletedAt == nil || status.Error != "" {
+		t.Fatalf("unexpected completed status %+v", status)
+	}
+	if len(store.Objects()) != 1 {
+		t.Fatalf("expected artifact persisted to object store, got %d", len(store.Objects()))
+	}
+	scope := <-scopes
+	if scope.Requestor != "analyst@example" || len(scope.ProjectIDs) != 1 || scope.ProjectIDs[0] != "project-1" {
+		t.Fatalf("expected RBAC scope forwarded to template run, got %+v", scope)
+	}
+	if !hasAuditStatus(audit.Entries(), ExportStatusSucceeded) {
+		t.Fatalf("expected completion audit entry, got %+v", audit.Entries())
+	}
+
+	if _, err := w.Submit(ExportJob{Template: "missing/template@1"}); err == nil {
+		t.Fatalf("expected unknown template to be rejected")
+	}
+	if _, ok := w.Status("unknown"); ok {
+		t.Fatalf("expected unknown job status lookup to fail")
+	}
+}
+
+func TestWorkerSubmitRecordsFailure(t *testing.T) {
+	formatProvider := datasetapi.GetFormatProvider()
+	tpl := buildFailRuntime()
+	audit := &MemoryAuditLog{}
+	w := NewWorker(fakeCatalog{tpl: tpl}, nil, audit)
+	w.Start()
+	defer func() { _ = w.Stop(context.Background()) }()
+
+	id, err := w.Submit(ExportJob{Template: tpl.Descriptor().Slug, Formats: []datasetapi.Format{formatProvider.JSON()}, RequestedBy: "analyst"})
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	status := waitForJobState(t, w, id, ExportStatusFailed)
+	if !strings.Contains(status.Error, "boom run") || status.CompletedAt == nil {
+		t.Fatalf("expected run error recorded, got %+v", status)
+	}
+	if !hasAuditStatus(audit.Entries(), ExportStatusFailed) {
+		t.Fatalf("expected failure audit entry, got %+v", audit.Entries())
+	}
+	if err := w.Cancel(id); !errors.Is(err, ErrExportFinished) {
+		t.Fatalf("expected ErrExportFinished cancelling failed job, got %v", err)
+	}
+}
+
+func TestWorkerCancelQueuedJob(t *testing.T) {
+	formatProvider := datasetapi.GetFormatProvider()
+	tpl := buildRuntimeTemplate()
+	store := NewMemoryObjectStore()
+	w := NewWorker(fakeCatalog{tpl: tpl}, store, &MemoryAuditLog{})
+
+	job := ExportJob{Template: tpl.Descriptor().Slug, Formats: []datasetapi.Format{formatProvider.JSON()}, RequestedBy: "analyst"}
+	cancelledID, err := w.Submit(job)
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if err := w.Cancel(cancelledID); err != nil {
+		t.Fatalf("cancel queued: %v", err)
+	}
+	status, ok := w.Status(cancelledID)
+	if !ok || status.State != ExportStatusFailed || status.Error != ErrExportCancelled.Error() {
+		t.Fatalf("expected cancelled job to be failed, got %+v", status)
+	}
+	if err := w.Cancel("unknown"); !errors.Is(err, ErrExportNotFound) {
+		t.Fatalf("expected ErrExportNotFound, got %v", err)
+	}
+
+	w.Start()
+	defer func() { _ = w.Stop(context.Background()) }()
+	nextID, err := w.Submit(job)
+	if err != nil {
+		t.Fatalf("submit follow-up: %v", err)
+	}
+	waitForJobState(t, w, nextID, ExportStatusSucceeded)
+
+	status, _ = w.Status(cancelledID)
+	if status.State != ExportStatusFailed || len(status.Artifacts) != 0 {
+		t.Fatalf("expected cancelled job to be skipped by the worker, got %+v", status)
+	}
+	if len(store.Objects()) != 1 {
+		t.Fatalf("expected only the follow-up artifact, got %d", len(store.Objects()))
+	}
+}
+
+func TestWorkerCancelRunningJob(t *testing.T) {
+	formatProvider := datasetapi.GetFormatProvider()
+	tpl := buildRuntimeTemplate()
+	started := make(chan struct{})
+	tpl.runFn = func(ctx context.Context, _ map[string]any, _ datasetapi.Scope, _ datasetapi.Format) (datasetapi.RunResult, []datasetapi.ParameterError, error) {
+		close(started)
+		<-ctx.Done()
+		return datasetapi.RunResult{}, nil, ctx.Err()
+	}
+	w := NewWorker(fakeCatalog{tpl: tpl}, nil, nil)
+	w.Start()
+	defer func() { _ = w.Stop(context.Background()) }()
+
+	id, err := w.Submit(ExportJob{Template: tpl.Descriptor().Slug, Formats: []datasetapi.Format{formatProvider.JSON()}})
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	<-started
+	if err := w.Cancel(id); err != nil {
+		t.Fatalf("cancel running: %v", err)
+	}
+	status := waitForJobState(t, w, id, ExportStatusFailed)
+	if status.Error != ErrExportCancelled.Error() {
+		t.Fatalf("expected cancellation reason, got %q", status.Error)
+	}
+}
+
+func TestWorkerConcurrencyRunsJobsInParallel(t *testing.T) {
+	formatProvider := datasetapi.GetFormatProvider()
+	tpl := buildRuntimeTemplate()
+	arrived := make(chan struct{}, 2)
+	release := make(chan struct{})
+	tpl.runFn = func(context.Context, map[string]any, datasetapi.Scope, datasetapi.Format) (datasetapi.RunResult, []datasetapi.ParameterError, error) {
+		arrived <- struct{}{}
+		<-release
+		return datasetapi.RunResult{Format: formatProvider.JSON()}, nil, nil
+	}
+	w := NewWorker(fakeCatalog{tpl: tpl}, nil, nil)
+	w.SetConcurrency(0)
+	w.SetConcurrency(2)
+	w.Start()
+	defer func() { _ = w.Stop(context.Background()) }()
+
+	job := ExportJob{Template: tpl.Descriptor().Slug, Formats: []datasetapi.Format{formatProvider.JSON()}}
+	first, _ := w.Submit(job)
+	second, _ := w.Submit(job)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-arrived:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("expected both jobs to run concurrently")
+		}
+	}
+	close(release)
+	waitForJobState(t, w, first, ExportStatusSucceeded)
+	waitForJobState(t, w, second, ExportStatusSucceeded)
+}
@@ -0,0 +1,96 @@
+package datasets
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"colonycore/internal/core"
+	"colonycore/pkg/datasetapi"
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestWorkerExportReflectsStartTimeState(t *testing.T) {
+	svc := core.NewInMemoryService(core.NewDefaultRulesEngine())
+	ctx := context.Background()
+	if _, _, err := svc.CreateOrganism(ctx, domain.Organism{Organism: entitymodel.Organism{Name: "Before", Species: "Xenopus"}}); err != nil {
+		t.Fatalf("seed organism: %v", err)
+	}
+
+	var firstRead, secondRead int
+	formatProvider := datasetapi.GetFormatProvider()
+	tmpl := datasetapi.Template{
+		Key:           "point-in-time",
+		Version:       "1.0.0",
+		Title:         "Point in time",
+		Description:   "lists organisms while writes land mid-run",
+		Dialect:       datasetapi.GetDialectProvider().SQL(),
+		Query:         "SELECT name FROM organisms",
+		Columns:       []datasetapi.Column{{Name: "name", Type: "string"}},
+		Metadata:      rbacExemptMetadata,
+		OutputFormats: []datasetapi.Format{formatProvider.JSON()},
+		Binder: func(env datasetapi.Environment) (datasetapi.Runner, error) {
+			return func(ctx context.Context, _ datasetapi.RunRequest) (datasetapi.RunResult, error) {
+				firstRead = len(env.Store.ListOrganisms())
+				if _, _, err := svc.CreateOrganism(ctx, domain.Organism{Organism: entitymodel.Organism{Name: "During", Species: "Xenopus"}}); err != nil {
+					return datasetapi.RunResult{}, err
+				}
+				organisms := env.Store.ListOrganisms()
+				secondRead = len(organisms)
+				rows := make([]datasetapi.Row, 0, len(organisms))
+				for _, organism := range organisms {
+					rows = append(rows, datasetapi.Row{"name": organism.Name()})
+				}
+				return datasetapi.RunResult{Rows: rows, Format: formatProvider.JSON()}, nil
+			}, nil
+		},
+	}
+	meta, err := svc.InstallPlugin(testDatasetPlugin{dataset: tmpl})
+	if err != nil {
+		t.Fatalf("install plugin: %v", err)
+	}
+
+	objects := NewMemoryObjectStore()
+	worker := NewWorker(svc, objects, &MemoryAuditLog{})
+	worker.Start()
+	t.Cleanup(func() { _ = worker.Stop(context.Background()) })
+
+	record, err := worker.EnqueueExport(ctx, ExportInput{TemplateSlug: meta.Datasets[0].Slug, Formats: []datasetapi.Format{formatProvider.JSON()}, RequestedBy: "tester"})
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	waitForExportStatus(t, worker, record.ID, ExportStatusSucceeded, 2*time.Second)
+
+	if firstRead != 1 || secondRead != 1 {
+		t.Fatalf("expected both reads to see only the start-time organism, got %d and %d", firstRead, secondRead)
+	}
+	if live := len(svc.Store().ListOrganisms()); live != 2 {
+		t.Fatalf("expected the mid-export write to reach the live store, got %d organisms", live)
+	}
+
+	final, _ := worker.GetExport(record.ID)
+	var artifact *ExportArtifact
+	for i := range final.Artifacts {
+		if final.Artifacts[i].Format == formatProvider.JSON() {
+			artifact = &final.Artifacts[i]
+		}
+	}
+	if artifact == nil {
+		t.Fatalf("expected JSON artifact, got %+v", final.Artifacts)
+	}
+	_, payload, err := objects.Get(ctx, artifact.ID)
+	if err != nil {
+		t.Fatalf("read artifact: %v", err)
+	}
+	var decoded struct {
+		Rows []map[string]any `json:"rows"`
+	}
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		t.Fatalf("decode artifact: %v", err)
+	}
+	if len(decoded.Rows) != 1 || decoded.Rows[0]["name"] != "Before" {
+		t.Fatalf("expected artifact to hold only the start-time organism, got %+v", decoded.Rows)
+	}
+}
@@ -6,8 +6,8 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
-	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
@@ -111,6 +111,9 @@ type ExportInput struct {
 	ProjectID    string
 	ProtocolID   string
 	Reason       string
+	// ConfirmLargeQuery acknowledges that the export may exceed the configured
+	// cost budget and should be queued anyway.
+	ConfirmLargeQuery bool
 }
 
 // ExportScheduler queues dataset export requests and exposes status.
@@ -119,6 +122,24 @@ type ExportScheduler interface {
 	GetExport(id string) (ExportRecord, bool)
 }
 
+// CostEstimator estimates the rows a dataset run would return so oversized
+// exports can be rejected before they are queued.
+type CostEstimator interface {
+	EstimateDatasetCost(slug string, params map[string]any, scope datasetapi.RBACScope) (datasetapi.CostEstimate, error)
+}
+
+// pointInTimeRunner is implemented by template runtimes that can execute a run
+// against a snapshot of the store captured when the run starts.
+//
+// Export jobs prefer it so an artifact reflects one point in time even when
+// writes land while the job is executing. The snapshot is copied under the
+// store's read view and released before rows are produced, so writers are only
+// held off for the copy, not for the job; checkpoint spooling and rendering
+// then operate on rows already materialised from that snapshot.
+type pointInTimeRunner interface {
+	RunPointInTime(ctx context.Context, params map[string]any, scope datasetapi.Scope, format datasetapi.Format) (datasetapi.RunResult, []datasetapi.ParameterError, error)
+}
+
 // ObjectStore persists export artifacts.
 type ObjectStore interface {
 	// Put stores a new immutable object. Implementations SHOULD fail if key exists.
@@ -155,10 +176,16 @@ type Worker struct {
 	store   ObjectStore
 	audit   AuditLogger
 	events  observability.Recorder
+	grants  GrantResolver
+	costs   CostEstimator
 
-	queue chan exportTask
-	mu    sync.RWMutex
-	jobs  map[string]*ExportRecord
+	checkpointRows int
+
+	queue   chan exportTask
+	workers int
+	mu      sync.RWMutex
+	jobs    map[string]*ExportRecord
+	running map[string]context.CancelFunc
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -184,16 +211,42 @@ func NewWorker(c Catalog, store ObjectStore, audit AuditLogger) *Worker {
 		audit:   audit,
 		events:  observability.NoopRecorder{},
 		queue:   make(chan exportTask, 32),
+		workers: 1,
 		jobs:    make(map[string]*ExportRecord),
+		running: make(map[string]context.CancelFunc),
 		ctx:     ctx,
 		cancel:  cancel,
 	}
 }
 
-// Start begins processing export requests.
+// SetConcurrency bounds how many exports run in parallel. It must be called
+// before Start; values below one are ignored.
+func (w *Worker) SetConcurrency(n int) {
+	if n < 1 {
+		return
+	}
+	w.workers = n
+}
+
+// SetGrantResolver configures how requestor grants are resolved into the RBAC
+// scope attached to each export at enqueue time.
+func (w *Worker) SetGrantResolver(resolver GrantResolver) {
+	w.grants = resolver
+}
+
+// SetCostEstimator configures the estimator consulted at enqueue time. Exports
+// estimated to exceed the budget are rejected with datasetapi.ErrQueryTooLarge
+// unless ExportInput.ConfirmLargeQuery is set.
+func (w *Worker) SetCostEstimator(estimator CostEstimator) {
+	w.costs = estimator
+}
+
+// Start begins processing export requests on the configured worker pool.
 func (w *Worker) Start() {
-	w.wg.Add(1)
-	go w.loop()
+	for i := 0; i < w.workers; i++ {
+		w.wg.Add(1)
+		go w.loop()
+	}
 }
 
 // Stop signals the worker to halt and waits for completion.
@@ -246,6 +299,22 @@ func (w *Worker) EnqueueExport(ctx context.Context, input ExportInput) (ExportRe
 		w.emitExportEvent(ctx, "catalog.export.enqueue", observability.StatusError, "", slug, err.Error(), 0, nil)
 		return ExportRecord{}, err
 	}
+	scope, err := withResolvedGrants(ctx, w.grants, firstNonEmpty(requestPrincipal(ctx), input.Scope.Requestor, input.RequestedBy), input.Scope)
+	if err != nil {
+		w.emitExportEvent(ctx, "catalog.export.enqueue", observability.StatusError, "", slug, err.Error(), 0, nil)
+		return ExportRecord{}, err
+	}
+	input.Scope = scope
+	if w.costs != nil {
+		var rbac datasetapi.RBACScope
+		if scope.RBAC != nil {
+			rbac = *scope.RBAC
+		}
+		if _, err := w.costs.EstimateDatasetCost(slug, input.Parameters, rbac); err != nil && (!errors.Is(err, datasetapi.ErrQueryTooLarge) || !input.ConfirmLargeQuery) {
+			w.emitExportEvent(ctx, "catalog.export.enqueue", observability.StatusError, "", slug, err.Error(), 0, nil)
+			return ExportRecord{}, err
+		}
+	}
 
 	formats := input.Formats
 	if len(formats) == 0 {
@@ -342,6 +411,11 @@ func (w *Worker) process(task exportTask) {
 	if record == nil {
 		return
 	}
+	ctx, ok := w.claim(task.id)
+	if !ok {
+		return
+	}
+	defer w.release(task.id)
 
 	template, ok := w.catalog.ResolveDatasetTemplate(task.input.TemplateSlug)
 	if !ok {
@@ -359,13 +433,34 @@ func (w *Worker) process(task exportTask) {
 	}
 
 	w.setProgress(task.id, ExportProgressStateExecutingTemplate, exportProgressExecutePct)
-	result, paramErrs, err := template.Run(w.ctx, cleaned, task.input.Scope, formatProvider.JSON())
-	if err != nil {
-		w.fail(task.id, fmt.Sprintf("dataset run failed: %v", err), time.Since(started))
-		return
+	run := template.Run
+	if pinned, ok := template.(pointInTimeRunner); ok {
+		run = pinned.RunPointInTime
 	}
-	if len(paramErrs) > 0 {
-		w.fail(task.id, fmt.Sprintf("parameter validation failed: %v", paramErrs), time.Since(started))
+	execute := func(ctx context.Context) (datasetapi.RunResult, bool) {
+		result, paramErrs, err := run(ctx, cleaned, task.input.Scope, formatProvider.JSON())
+		if err != nil {
+			if ctx.Err() != nil {
+				w.fail(task.id, ErrExportCancelled.Error(), time.Since(started))
+				return datasetapi.RunResult{}, false
+			}
+			w.fail(task.id, fmt.Sprintf("dataset run failed: %v", err), time.Since(started))
+			return datasetapi.RunResult{}, false
+		}
+		if len(paramErrs) > 0 {
+			w.fail(task.id, fmt.Sprintf("parameter validation failed: %v", paramErrs), time.Since(started))
+			return datasetapi.RunResult{}, false
+		}
+		return result, true
+	}
+	checkpointed := w.store != nil && w.checkpointRows > 0
+	var result datasetapi.RunResult
+	if checkpointed {
+		result, ok = w.runCheckpointed(ctx, task.id, execute, started)
+	} else {
+		result, ok = execute(ctx)
+	}
+	if !ok {
 		return
 	}
 
@@ -378,7 +473,7 @@ func (w *Worker) process(task exportTask) {
 			return
 		}
 		if w.store != nil {
-			stored, err := w.store.Put(w.ctx, rendered.Artifact.ID, rendered.Payload, rendered.Artifact.ContentType, rendered.Artifact.Metadata)
+			stored, err := w.store.Put(ctx, rendered.Artifact.ID, rendered.Payload, rendered.Artifact.ContentType, rendered.Artifact.Metadata)
 			if err != nil {
 				w.fail(task.id, fmt.Sprintf("store artifact failed: %v", err), time.Since(started))
 				return
@@ -402,9 +497,42 @@ func (w *Worker) process(task exportTask) {
 		}
 	}
 
+	if ctx.Err() != nil {
+		w.fail(task.id, ErrExportCancelled.Error(), time.Since(started))
+		return
+	}
+	if checkpointed {
+		// The artifacts are durable; leftover chunks only cost storage, so a
+		// failed cleanup does not fail the export.
+		_ = w.discardCheckpoint(ctx, task.id)
+	}
 	w.complete(task.id, exportArtifacts, time.Since(started))
 }
 
+// claim registers a cancellable context for a queued export. It reports false
+// when the export was cancelled before a worker picked it up.
+func (w *Worker) claim(id string) (context.Context, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	record, ok := w.jobs[id]
+	if !ok || record.Status != ExportStatusQueued {
+		return nil, false
+	}
+	ctx, cancel := context.WithCancel(w.ctx)
+	w.running[id] = cancel
+	return ctx, true
+}
+
+func (w *Worker) release(id string) {
+	w.mu.Lock()
+	cancel := w.running[id]
+	delete(w.running, id)
+	w.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
 func (w *Worker) snapshot(id string) *ExportRecord {
 	w.mu.RLock()
 	record, ok := w.jobs[id]
@@ -613,39 +741,25 @@ func (w *Worker) materialize(format datasetapi.Format, template datasetapi.Templ
 			},
 			Payload: payload,
 		}, nil
-	case formatProvider.CSV():
-		buf := &bytes.Buffer{}
-		writer := csv.NewWriter(buf)
+	case formatProvider.CSV(), formatProvider.Parquet():
+		encoder, ok := datasetapi.EncoderFor(format)
+		if !ok {
+			return renderedArtifact{}, fmt.Errorf("unsupported export format %s", format)
+		}
 		columns := result.Schema
 		if len(columns) == 0 {
 			columns = descriptor.Columns
 		}
-		headers := make([]string, len(columns))
-		for i, column := range columns {
-			headers[i] = column.Name
-		}
-		if err := writer.Write(headers); err != nil {
-			return renderedArtifact{}, err
-		}
-		for _, row := range result.Rows {
-			record := make([]string, len(columns))
-			for i, column := range columns {
-				record[i] = formatValue(row[column.Name])
-			}
-			if err := writer.Write(record); err != nil {
-				return renderedArtifact{}, err
-			}
-		}
-		writer.Flush()
-		if err := writer.Error(); err != nil {
-			return renderedArtifact{}, err
+		buf := &bytes.Buffer{}
+		if err := encoder.Encode(buf, columns, datasetapi.RowValues(columns, result.Rows)); err != nil {
+			return renderedArtifact{}, fmt.Errorf("encode %s: %w", format, err)
 		}
 		payload := buf.Bytes()
 		return renderedArtifact{
 			Artifact: ExportArtifact{
 				ID:          newID(),
-				Format:      formatProvider.CSV(),
-				ContentType: "text/csv",
+				Format:      format,
+				ContentType: encoder.ContentType(),
 				SizeBytes:   int64(len(payload)),
 				Metadata: map[string]any{
 					"rows": len(result.Rows),
@@ -667,25 +781,6 @@ func (w *Worker) materialize(format datasetapi.Format, template datasetapi.Templ
 			},
 			Payload: payload,
 		}, nil
-	case formatProvider.Parquet():
-		payload, err := json.Marshal(result.Rows)
-		if err != nil {
-			return renderedArtifact{}, fmt.Errorf("marshall parquet surrogate: %w", err)
-		}
-		return renderedArtifact{
-			Artifact: ExportArtifact{
-				ID:          newID(),
-				Format:      formatProvider.Parquet(),
-				ContentType: "application/octet-stream",
-				SizeBytes:   int64(len(payload)),
-				Metadata: map[string]any{
-					"note": "parquet placeholder encodes rows as JSON; replace with true writer",
-					"rows": len(result.Rows),
-				},
-				CreatedAt: time.Now().UTC(),
-			},
-			Payload: payload,
-		}, nil
 	case formatProvider.PNG():
 		payload, err := buildPNG(result)
 		if err != nil {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -25,6 +26,7 @@ type Catalog interface {
 type Handler struct {
 	Catalog                Catalog
 	Exports                ExportScheduler
+	Grants                 GrantResolver
 	EntityModel            http.Handler
 	Events                 observability.Recorder
 	Logger                 RequestLogger
@@ -277,6 +279,8 @@ type exportRequest struct {
 	Reason      string `json:"reason"`
 	ProjectID   string `json:"project_id"`
 	ProtocolID  string `json:"protocol_id"`
+	// ConfirmLargeQuery queues the export even when it exceeds the cost budget.
+	ConfirmLargeQuery bool `json:"confirm_large_query"`
 }
 
 func (h *Handler) handleRun(w http.ResponseWriter, r *http.Request, template datasetapi.TemplateRuntime) {
@@ -313,7 +317,7 @@ func (h *Handler) handleRun(w http.ResponseWriter, r *http.Request, template dat
 		return
 	}
 
-	scope := datasetapi.Scope{Requestor: req.Scope.Requestor}
+	scope := datasetapi.Scope{Requestor: requestPrincipal(r.Context())}
 	if len(req.Scope.Roles) > 0 {
 		scope.Roles = append([]string(nil), req.Scope.Roles...)
 	}
@@ -323,6 +327,13 @@ func (h *Handler) handleRun(w http.ResponseWriter, r *http.Request, template dat
 	if len(req.Scope.ProtocolIDs) > 0 {
 		scope.ProtocolIDs = append([]string(nil), req.Scope.ProtocolIDs...)
 	}
+	scope, err := withResolvedGrants(r.Context(), h.Grants, scope.Requestor, scope)
+	if err != nil {
+		status = observability.StatusError
+		errMessage = err.Error()
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
 
 	cleaned, errs := template.ValidateParameters(req.Parameters)
 	if len(errs) > 0 {
@@ -440,7 +451,7 @@ func (h *Handler) handleExportCreate(w http.ResponseWriter, r *http.Request) {
 	}
 	measures["formats_total"] = float64(len(formats))
 
-	scope := datasetapi.Scope{Requestor: req.Scope.Requestor}
+	scope := datasetapi.Scope{Requestor: requestPrincipal(r.Context())}
 	if len(req.Scope.Roles) > 0 {
 		scope.Roles = append([]string(nil), req.Scope.Roles...)
 	}
@@ -456,14 +467,21 @@ func (h *Handler) handleExportCreate(w http.ResponseWriter, r *http.Request) {
 		Parameters:   req.Parameters,
 		Formats:      formats,
 		Scope:        scope,
-		RequestedBy:  firstNonEmpty(req.RequestedBy, req.Scope.Requestor),
+		RequestedBy:  scope.Requestor,
 		Reason:       req.Reason,
 		ProjectID:    req.ProjectID,
 		ProtocolID:   req.ProtocolID,
+
+		ConfirmLargeQuery: req.ConfirmLargeQuery,
 	})
 	if err != nil {
 		status = observability.StatusError
 		errMessage = err.Error()
+		var tooLarge *datasetapi.QueryTooLargeError
+		if errors.As(err, &tooLarge) {
+			writeProblemWithEstimate(w, http.StatusUnprocessableEntity, err.Error(), tooLarge.Estimate)
+			return
+		}
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -19,7 +19,7 @@ func TestHandlerListTemplatesAndNewHandler(t *testing.T) {
 	dialectProvider := datasetapi.GetDialectProvider()
 	formatProvider := datasetapi.GetFormatProvider()
 	svc := core.NewInMemoryService(core.NewDefaultRulesEngine())
-	plugin := testDatasetPlugin{dataset: datasetapi.Template{Key: "list", Version: "1.0.0", Title: "List", Description: "list test", Dialect: dialectProvider.SQL(), Query: "SELECT 1", Columns: []datasetapi.Column{{Name: "value", Type: "string"}}, OutputFormats: []datasetapi.Format{formatProvider.JSON()}, Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
+	plugin := testDatasetPlugin{dataset: datasetapi.Template{Key: "list", Version: "1.0.0", Title: "List", Description: "list test", Dialect: dialectProvider.SQL(), Query: "SELECT 1", Columns: []datasetapi.Column{{Name: "value", Type: "string"}}, Metadata: rbacExemptMetadata, OutputFormats: []datasetapi.Format{formatProvider.JSON()}, Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
 		return func(context.Context, datasetapi.RunRequest) (datasetapi.RunResult, error) {
 			return datasetapi.RunResult{}, nil
 		}, nil
@@ -42,7 +42,7 @@ func TestHandlerListTemplatesAndNewHandler(t *testing.T) {
 func TestHandlerValidateSuccess(t *testing.T) {
 	dialectProvider := datasetapi.GetDialectProvider()
 	formatProvider := datasetapi.GetFormatProvider()
-	template := datasetapi.Template{Key: "validate", Version: "1.0.0", Title: "Validate", Description: "validation", Dialect: dialectProvider.SQL(), Query: "SELECT 1", Parameters: []datasetapi.Parameter{{Name: "stage", Type: "string", Enum: []string{"adult", "larva"}}}, Columns: []datasetapi.Column{{Name: "value", Type: "string"}}, OutputFormats: []datasetapi.Format{formatProvider.JSON()}, Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
+	template := datasetapi.Template{Key: "validate", Version: "1.0.0", Title: "Validate", Description: "validation", Dialect: dialectProvider.SQL(), Query: "SELECT 1", Parameters: []datasetapi.Parameter{{Name: "stage", Type: "string", Enum: []string{"adult", "larva"}}}, Columns: []datasetapi.Column{{Name: "value", Type: "string"}}, Metadata: rbacExemptMetadata, OutputFormats: []datasetapi.Format{formatProvider.JSON()}, Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
 		return func(context.Context, datasetapi.RunRequest) (datasetapi.RunResult, error) {
 			return datasetapi.RunResult{}, nil
 		}, nil
@@ -68,7 +68,7 @@ func TestHandlerValidateSuccess(t *testing.T) {
 func TestNegotiateFormatCSVHeader(t *testing.T) {
 	dialectProvider := datasetapi.GetDialectProvider()
 	formatProvider := datasetapi.GetFormatProvider()
-	template := datasetapi.Template{Key: "csv", Version: "1.0.0", Title: "CSV", Description: "csv", Dialect: dialectProvider.SQL(), Query: "SELECT 1", Columns: []datasetapi.Column{{Name: "value", Type: "string"}}, OutputFormats: []datasetapi.Format{formatProvider.JSON(), formatProvider.CSV()}, Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
+	template := datasetapi.Template{Key: "csv", Version: "1.0.0", Title: "CSV", Description: "csv", Dialect: dialectProvider.SQL(), Query: "SELECT 1", Columns: []datasetapi.Column{{Name: "value", Type: "string"}}, Metadata: rbacExemptMetadata, OutputFormats: []datasetapi.Format{formatProvider.JSON(), formatProvider.CSV()}, Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
 		return func(context.Context, datasetapi.RunRequest) (datasetapi.RunResult, error) {
 			return datasetapi.RunResult{Rows: []datasetapi.Row{{"value": "ok"}}, Format: formatProvider.JSON()}, nil
 		}, nil
@@ -109,7 +109,7 @@ func TestNegotiateFormatCSVHeader(t *testing.T) {
 func TestRunCSVUsesDescriptorColumns(t *testing.T) {
 	dialectProvider := datasetapi.GetDialectProvider()
 	formatProvider := datasetapi.GetFormatProvider()
-	template := datasetapi.Template{Key: "empty", Version: "1.0.0", Title: "EmptySchema", Description: "empty schema", Dialect: dialectProvider.SQL(), Query: "SELECT 1", Columns: []datasetapi.Column{{Name: "col", Type: "string"}}, OutputFormats: []datasetapi.Format{formatProvider.JSON(), formatProvider.CSV()}, Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
+	template := datasetapi.Template{Key: "empty", Version: "1.0.0", Title: "EmptySchema", Description: "empty schema", Dialect: dialectProvider.SQL(), Query: "SELECT 1", Columns: []datasetapi.Column{{Name: "col", Type: "string"}}, Metadata: rbacExemptMetadata, OutputFormats: []datasetapi.Format{formatProvider.JSON(), formatProvider.CSV()}, Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
 		return func(context.Context, datasetapi.RunRequest) (datasetapi.RunResult, error) {
 			return datasetapi.RunResult{Rows: []datasetapi.Row{{"col": "alpha"}}, Format: formatProvider.JSON()}, nil
 		}, nil
@@ -157,7 +157,7 @@ func TestMemoryAuditLogEntries(t *testing.T) {
 func TestServeHTTPExportsLifecycle(t *testing.T) {
 	dialectProvider := datasetapi.GetDialectProvider()
 	formatProvider := datasetapi.GetFormatProvider()
-	template := datasetapi.Template{Key: "lifecycle", Version: "1.0.0", Title: "Lifecycle", Description: "export lifecycle", Dialect: dialectProvider.SQL(), Query: "SELECT 1", Columns: []datasetapi.Column{{Name: "value", Type: "string"}}, OutputFormats: []datasetapi.Format{formatProvider.JSON()}, Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
+	template := datasetapi.Template{Key: "lifecycle", Version: "1.0.0", Title: "Lifecycle", Description: "export lifecycle", Dialect: dialectProvider.SQL(), Query: "SELECT 1", Columns: []datasetapi.Column{{Name: "value", Type: "string"}}, Metadata: rbacExemptMetadata, OutputFormats: []datasetapi.Format{formatProvider.JSON()}, Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
 		return func(context.Context, datasetapi.RunRequest) (datasetapi.RunResult, error) {
 			return datasetapi.RunResult{Rows: []datasetapi.Row{{"value": "ok"}}, Format: formatProvider.JSON()}, nil
 		}, nil
@@ -149,6 +149,7 @@ func buildTemplate() core.DatasetTemplate {
 			Dialect:       dialectProvider.SQL(),
 			Query:         "SELECT 1",
 			Columns:       []datasetapi.Column{{Name: "value", Type: "string"}},
+			Metadata:      rbacExemptMetadata,
 			OutputFormats: []datasetapi.Format{formatProvider.JSON(), formatProvider.CSV()},
 		},
 	}
@@ -73,7 +73,7 @@ func TestHandleExportCreateSuccessWithDerivedSlug(t *testing.T) {
 		"scope":{"requestor":"alice","roles":["analyst"]},
 		"reason":"quarterly review"
 	}`
-	req := httptest.NewRequest(http.MethodPost, "/api/v1/datasets/exports", bytes.NewBufferString(payload))
+	req := asPrincipal(httptest.NewRequest(http.MethodPost, "/api/v1/datasets/exports", bytes.NewBufferString(payload)), "bob")
 	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
@@ -85,8 +85,8 @@ func TestHandleExportCreateSuccessWithDerivedSlug(t *testing.T) {
 	if scheduler.last.TemplateSlug != "stub/metrics@v2" {
 		t.Fatalf("expected derived slug, got %s", scheduler.last.TemplateSlug)
 	}
-	if scheduler.last.RequestedBy != "alice" {
-		t.Fatalf("expected requested by fallback to scope requestor, got %s", scheduler.last.RequestedBy)
+	if scheduler.last.RequestedBy != "bob" || scheduler.last.Scope.Requestor != "bob" {
+		t.Fatalf("expected authenticated principal to override requestor, got %s/%s", scheduler.last.RequestedBy, scheduler.last.Scope.Requestor)
 	}
 	if scheduler.last.Reason != "quarterly review" {
 		t.Fatalf("expected reason carried through, got %s", scheduler.last.Reason)
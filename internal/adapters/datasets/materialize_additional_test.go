@@ -12,7 +12,7 @@ import (
 func TestWorkerMaterializePNGParquet(t *testing.T) {
 	dialectProvider := datasetapi.GetDialectProvider()
 	formatProvider := datasetapi.GetFormatProvider()
-	tmpl := datasetapi.Template{Key: "allfmts", Version: "1.0.0", Title: "All Formats", Description: "cover png/parquet", Dialect: dialectProvider.SQL(), Query: "SELECT 1", Columns: []datasetapi.Column{{Name: "value", Type: "string"}}, OutputFormats: []datasetapi.Format{formatProvider.JSON(), formatProvider.CSV(), formatProvider.HTML(), formatProvider.PNG(), formatProvider.Parquet()}, Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
+	tmpl := datasetapi.Template{Key: "allfmts", Version: "1.0.0", Title: "All Formats", Description: "cover png/parquet", Dialect: dialectProvider.SQL(), Query: "SELECT 1", Columns: []datasetapi.Column{{Name: "value", Type: "string"}}, Metadata: rbacExemptMetadata, OutputFormats: []datasetapi.Format{formatProvider.JSON(), formatProvider.CSV(), formatProvider.HTML(), formatProvider.PNG(), formatProvider.Parquet()}, Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
 		return func(context.Context, datasetapi.RunRequest) (datasetapi.RunResult, error) {
 			return datasetapi.RunResult{Rows: []datasetapi.Row{{"value": "alpha"}}, Format: formatProvider.JSON()}, nil
 		}, nil
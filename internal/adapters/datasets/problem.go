@@ -18,6 +18,8 @@ type problemDetail struct {
 	Status int                         `json:"status"`
 	Detail string                      `json:"detail"`
 	Errors []datasetapi.ParameterError `json:"errors,omitempty"`
+	// Estimate is set when a request is rejected for exceeding the cost budget.
+	Estimate *datasetapi.CostEstimate `json:"estimate,omitempty"`
 }
 
 func writeProblem(w http.ResponseWriter, status int, detail string) {
@@ -25,6 +27,14 @@ func writeProblem(w http.ResponseWriter, status int, detail string) {
 }
 
 func writeProblemWithErrors(w http.ResponseWriter, status int, detail string, errs []datasetapi.ParameterError) {
+	writeProblemDetail(w, status, detail, errs, nil)
+}
+
+func writeProblemWithEstimate(w http.ResponseWriter, status int, detail string, estimate datasetapi.CostEstimate) {
+	writeProblemDetail(w, status, detail, nil, &estimate)
+}
+
+func writeProblemDetail(w http.ResponseWriter, status int, detail string, errs []datasetapi.ParameterError, estimate *datasetapi.CostEstimate) {
 	title := http.StatusText(status)
 	if title == "" {
 		title = "Error"
@@ -44,5 +54,6 @@ func writeProblemWithErrors(w http.ResponseWriter, status int, detail string, er
 	if len(errs) > 0 {
 		problem.Errors = append([]datasetapi.ParameterError(nil), errs...)
 	}
+	problem.Estimate = estimate
 	_ = json.NewEncoder(w).Encode(problem)
 }
@@ -0,0 +1,57 @@
+package datasets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"colonycore/pkg/datasetapi"
+	"colonycore/pkg/pluginapi"
+)
+
+// ErrGrantResolution is returned when an actor's dataset grants cannot be resolved.
+var ErrGrantResolution = errors.New("dataset grants unavailable")
+
+// GrantResolver resolves the facilities and projects an actor may read. The
+// resolved scope is attached to runs and exports at submit time and filters
+// template output when the template executes.
+type GrantResolver interface {
+	ResolveGrants(ctx context.Context, actor string) (datasetapi.RBACScope, error)
+}
+
+// GrantResolverFunc adapts a function to the GrantResolver interface.
+type GrantResolverFunc func(ctx context.Context, actor string) (datasetapi.RBACScope, error)
+
+// ResolveGrants calls f(ctx, actor).
+func (f GrantResolverFunc) ResolveGrants(ctx context.Context, actor string) (datasetapi.RBACScope, error) {
+	return f(ctx, actor)
+}
+
+// requestPrincipal returns the authenticated principal the HTTP layer attached
+// under pluginapi.ActorKey, or "" when the request is unauthenticated.
+func requestPrincipal(ctx context.Context) string {
+	return strings.TrimSpace(pluginapi.AuditContextValue(ctx, pluginapi.ActorKey))
+}
+
+// withResolvedGrants replaces any caller-supplied RBAC scope with the grants
+// resolved for actor. Without a resolver access is denied by default: the
+// scope carries an RBAC scope with no grants, so only rbac.exempt templates
+// return rows.
+func withResolvedGrants(ctx context.Context, resolver GrantResolver, actor string, scope datasetapi.Scope) (datasetapi.Scope, error) {
+	actor = strings.TrimSpace(actor)
+	if resolver == nil {
+		scope.RBAC = &datasetapi.RBACScope{Actor: actor}
+		return scope, nil
+	}
+	if actor == "" {
+		return scope, fmt.Errorf("%w: requestor required", ErrGrantResolution)
+	}
+	grants, err := resolver.ResolveGrants(ctx, actor)
+	if err != nil {
+		return scope, fmt.Errorf("%w: %v", ErrGrantResolution, err)
+	}
+	grants.Actor = actor
+	scope.RBAC = &grants
+	return scope, nil
+}
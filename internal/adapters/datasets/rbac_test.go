@@ -0,0 +1,152 @@
+package datasets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/internal/core"
+	"colonycore/pkg/datasetapi"
+	"colonycore/pkg/pluginapi"
+)
+
+// rbacExemptMetadata marks fixture templates whose output is not scoped by
+// facility or project, so they run under the default deny-all RBAC scope.
+var rbacExemptMetadata = datasetapi.Metadata{Annotations: map[string]string{datasetapi.RBACExemptAnnotation: "true"}}
+
+// asPrincipal attaches actor as the authenticated principal of r.
+func asPrincipal(r *http.Request, actor string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), pluginapi.ActorKey, actor))
+}
+
+func buildFacilityTemplate() core.DatasetTemplate {
+	tpl := buildTemplate()
+	tpl.Metadata = datasetapi.Metadata{}
+	tpl.Columns = []datasetapi.Column{{Name: "value", Type: "string"}, {Name: datasetapi.RBACFacilityColumn, Type: "string"}}
+	core.BindTemplateForTests(&tpl, func(context.Context, datasetapi.RunRequest) (datasetapi.RunResult, error) {
+		return datasetapi.RunResult{
+			Schema: tpl.Columns,
+			Rows: []datasetapi.Row{
+				{"value": "frog-a", datasetapi.RBACFacilityColumn: "facility-a"},
+				{"value": "frog-b", datasetapi.RBACFacilityColumn: "facility-b"},
+			},
+			GeneratedAt: time.Unix(0, 0).UTC(),
+			Format:      core.FormatJSON,
+		}, nil
+	})
+	return tpl
+}
+
+func facilityGrants(grants map[string][]string) GrantResolver {
+	return GrantResolverFunc(func(_ context.Context, actor string) (datasetapi.RBACScope, error) {
+		facilities, ok := grants[actor]
+		if !ok {
+			return datasetapi.RBACScope{}, errors.New("unknown actor")
+		}
+		return datasetapi.RBACScope{FacilityIDs: facilities}, nil
+	})
+}
+
+func TestHandleRunAppliesResolvedGrants(t *testing.T) {
+	tpl := buildFacilityTemplate()
+	h := NewHandler(testCatalog{tpl: tpl})
+	h.Grants = facilityGrants(map[string][]string{"analyst": {"facility-a"}})
+	d := tpl.Descriptor()
+	path := "/api/v1/datasets/templates/" + d.Plugin + "/" + d.Key + "/" + d.Version + "/run"
+
+	body := `{"scope":{"requestor":"stranger"}}`
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, asPrincipal(httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)), "analyst"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d: %s", w.Code, w.Body.String())
+	}
+	var resp runResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode run response: %v", err)
+	}
+	if resp.Scope.RBAC == nil || resp.Scope.RBAC.Actor != "analyst" {
+		t.Fatalf("expected resolved rbac scope in response, got %+v", resp.Scope)
+	}
+	if len(resp.Result.Rows) != 1 || resp.Result.Rows[0]["value"] != "frog-a" {
+		t.Fatalf("expected only facility-a rows, got %+v", resp.Result.Rows)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, asPrincipal(httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"scope":{"requestor":"analyst"}}`)), "stranger"))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for unresolvable grants, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"scope":{"requestor":"analyst"}}`)))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for unauthenticated requestor, got %d", w.Code)
+	}
+}
+
+func TestHandleRunDeniesByDefaultWithoutResolver(t *testing.T) {
+	tpl := buildFacilityTemplate()
+	h := NewHandler(testCatalog{tpl: tpl})
+	d := tpl.Descriptor()
+	path := "/api/v1/datasets/templates/" + d.Plugin + "/" + d.Key + "/" + d.Version + "/run"
+
+	body := `{"scope":{"requestor":"analyst"}}`
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, asPrincipal(httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)), "analyst"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d: %s", w.Code, w.Body.String())
+	}
+	var resp runResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode run response: %v", err)
+	}
+	if resp.Scope.RBAC == nil || len(resp.Scope.RBAC.FacilityIDs) != 0 {
+		t.Fatalf("expected empty rbac scope without a resolver, got %+v", resp.Scope.RBAC)
+	}
+	if len(resp.Result.Rows) != 0 {
+		t.Fatalf("expected no rows without a resolver, got %+v", resp.Result.Rows)
+	}
+}
+
+func TestEnqueueExportResolvesGrants(t *testing.T) {
+	tpl := buildFacilityTemplate()
+	worker := NewWorker(testCatalog{tpl: tpl}, NewMemoryObjectStore(), &MemoryAuditLog{})
+	worker.SetGrantResolver(facilityGrants(map[string][]string{"analyst": {"facility-a"}}))
+	slug := tpl.Descriptor().Slug
+
+	record, err := worker.EnqueueExport(context.Background(), ExportInput{
+		TemplateSlug: slug,
+		Formats:      []datasetapi.Format{core.FormatJSON},
+		Scope:        datasetapi.Scope{RBAC: &datasetapi.RBACScope{FacilityIDs: []string{datasetapi.RBACWildcard}}},
+		RequestedBy:  "analyst",
+	})
+	if err != nil {
+		t.Fatalf("enqueue export: %v", err)
+	}
+	rbac := record.Scope.RBAC
+	if rbac == nil || rbac.Actor != "analyst" || len(rbac.FacilityIDs) != 1 || rbac.FacilityIDs[0] != "facility-a" {
+		t.Fatalf("expected caller-supplied rbac scope to be replaced by resolved grants, got %+v", rbac)
+	}
+
+	if _, err := worker.EnqueueExport(context.Background(), ExportInput{
+		TemplateSlug: slug,
+		Formats:      []datasetapi.Format{core.FormatJSON},
+		RequestedBy:  "stranger",
+	}); !errors.Is(err, ErrGrantResolution) {
+		t.Fatalf("expected ErrGrantResolution, got %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), pluginapi.ActorKey, "stranger")
+	if _, err := worker.EnqueueExport(ctx, ExportInput{
+		TemplateSlug: slug,
+		Formats:      []datasetapi.Format{core.FormatJSON},
+		RequestedBy:  "analyst",
+	}); !errors.Is(err, ErrGrantResolution) {
+		t.Fatalf("expected authenticated principal to take precedence, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"colonycore/pkg/datasetapi"
+	"colonycore/pkg/pluginapi"
+)
+
+// analyticsStreamErrorTrailer carries the error that ended a stream after the
+// response headers were sent, matching the dataset handler's trailer.
+const analyticsStreamErrorTrailer = "X-Stream-Error"
+
+// analyticsRunRequest is the body accepted by the analytics results route.
+type analyticsRunRequest struct {
+	Parameters map[string]any `json:"parameters"`
+}
+
+// newAnalyticsMux routes the species-agnostic analytics endpoints to the host
+// dataset service:
+//
+//	GET  /api/v1/analytics/templates             lists templates
+//	POST /api/v1/analytics/results/{template...}  streams rows as NDJSON
+func (s *Server) newAnalyticsMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/analytics/templates", s.handleAnalyticsTemplates)
+	mux.HandleFunc("POST /api/v1/analytics/results/{template...}", s.handleAnalyticsResults)
+	return mux
+}
+
+func (s *Server) handleAnalyticsTemplates(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"templates": s.Analytics.EnumerateTemplates()})
+}
+
+// handleAnalyticsResults resolves the principal's grants, attaches them as the
+// dataset scope, and streams the template's rows. Parameters are validated
+// before the first row is written so invalid requests still get a 400.
+func (s *Server) handleAnalyticsResults(w http.ResponseWriter, r *http.Request) {
+	templateID := r.PathValue("template")
+	var req analyticsRunRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid analytics request: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+	if err := s.Analytics.ValidateParameters(templateID, req.Parameters); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	actor := pluginapi.AuditContextValue(ctx, pluginapi.ActorKey)
+	grants, err := s.grants.ResolveGrants(ctx, actor)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("dataset grants unavailable: %v", err), http.StatusForbidden)
+		return
+	}
+	grants.Actor = actor
+	ctx = pluginapi.WithDatasetScope(ctx, datasetapi.Scope{Requestor: actor, RBAC: &grants})
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Trailer", analyticsStreamErrorTrailer)
+	if err := s.Analytics.StreamResults(ctx, templateID, req.Parameters, w); err != nil {
+		w.Header().Set(analyticsStreamErrorTrailer, err.Error())
+	}
+}
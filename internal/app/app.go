@@ -0,0 +1,146 @@
+// Package app is the composition root that wires the core service, the dataset
+// HTTP handler, and the export worker into one server.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"colonycore/internal/adapters/datasets"
+	"colonycore/internal/blob"
+	"colonycore/internal/core"
+	"colonycore/pkg/domain"
+	"colonycore/pkg/openapi"
+	"colonycore/pkg/pluginapi"
+)
+
+// ErrUnauthenticated is returned by an Authenticator that cannot identify the
+// principal behind a request.
+var ErrUnauthenticated = errors.New("app: request not authenticated")
+
+const analyticsPathPrefix = "/api/v1/analytics/"
+
+// Authenticator returns the principal that issued r. Dataset runs and exports
+// are attributed to, and RBAC scoped by, this principal rather than any
+// requestor named in the request body.
+type Authenticator func(r *http.Request) (string, error)
+
+// Config lists the dependencies New wires together. Grants and Authenticate
+// are required. When Store is nil, New opens one with core.OpenPersistentStore
+// and wires Blobs in as its observation attachment backend; Blobs cannot be
+// combined with a prebuilt Store, which must be constructed with its own
+// attachment blobs. Exports defaults to an in-memory object store and Audit to
+// an in-memory audit log. CostBudget caps the rows an export may be
+// estimated to return (see core.WithDatasetCostBudget); zero disables it.
+type Config struct {
+	Store          domain.PersistentStore
+	Blobs          blob.Store
+	Grants         datasets.GrantResolver
+	Authenticate   Authenticator
+	Exports        datasets.ObjectStore
+	Audit          datasets.AuditLogger
+	CostBudget     int
+	ServiceOptions []core.ServiceOption
+}
+
+// Server serves the dataset and analytics APIs over a core service. Every
+// request is authenticated before it is routed.
+type Server struct {
+	Service   *core.Service
+	Datasets  *datasets.Handler
+	Exports   *datasets.Worker
+	Analytics pluginapi.DatasetService
+
+	authenticate Authenticator
+	grants       datasets.GrantResolver
+	analytics    *http.ServeMux
+	openAPI      http.Handler
+}
+
+// New builds a Server from cfg. It fails when cfg omits the grant resolver or
+// the authenticator, so dataset access is never left unscoped.
+func New(cfg Config) (*Server, error) {
+	if cfg.Grants == nil {
+		return nil, errors.New("app: dataset grant resolver required")
+	}
+	if cfg.Authenticate == nil {
+		return nil, errors.New("app: authenticator required")
+	}
+	if cfg.Store != nil && cfg.Blobs != nil {
+		return nil, errors.New("app: blobs require a store opened by New")
+	}
+	if cfg.Store == nil {
+		var opts []core.StorageOption
+		if cfg.Blobs != nil {
+			opts = append(opts, core.WithStorageAttachmentBlobs(blob.NewAttachmentBlobs(cfg.Blobs)))
+		}
+		store, err := core.OpenPersistentStore(core.NewDefaultRulesEngine(), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("app: open store: %w", err)
+		}
+		cfg.Store = store
+	}
+	if cfg.Exports == nil {
+		cfg.Exports = datasets.NewMemoryObjectStore()
+	}
+	if cfg.Audit == nil {
+		cfg.Audit = &datasets.MemoryAuditLog{}
+	}
+
+	options := append([]core.ServiceOption{core.WithDatasetCostBudget(cfg.CostBudget)}, cfg.ServiceOptions...)
+	service := core.NewService(cfg.Store, options...)
+	worker := datasets.NewWorker(service, cfg.Exports, cfg.Audit)
+	worker.SetGrantResolver(cfg.Grants)
+	worker.SetCostEstimator(service)
+	handler := datasets.NewHandler(service)
+	handler.Grants = cfg.Grants
+	handler.Exports = worker
+
+	server := &Server{
+		Service:      service,
+		Datasets:     handler,
+		Exports:      worker,
+		Analytics:    service.HostDatasetService(),
+		authenticate: cfg.Authenticate,
+		grants:       cfg.Grants,
+		openAPI:      openapi.Handler(),
+	}
+	server.analytics = server.newAnalyticsMux()
+	return server, nil
+}
+
+// Start starts the export worker pool.
+func (s *Server) Start() {
+	s.Exports.Start()
+}
+
+// Stop stops the export worker pool, waiting until ctx is done.
+func (s *Server) Stop(ctx context.Context) error {
+	return s.Exports.Stop(ctx)
+}
+
+// ServeHTTP authenticates r and routes it to the OpenAPI document (see
+// openapi.Path), the analytics endpoints, or the dataset handler with the
+// principal attached under pluginapi.ActorKey, which core.AuditActorFromContext
+// also reads. Unauthenticated requests are rejected with 401.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	principal, err := s.authenticate(r)
+	principal = strings.TrimSpace(principal)
+	if err != nil || principal == "" {
+		http.Error(w, ErrUnauthenticated.Error(), http.StatusUnauthorized)
+		return
+	}
+	r = r.WithContext(context.WithValue(r.Context(), pluginapi.ActorKey, principal))
+	if r.URL.Path == openapi.Path {
+		s.openAPI.ServeHTTP(w, r)
+		return
+	}
+	if strings.HasPrefix(r.URL.Path, analyticsPathPrefix) {
+		s.analytics.ServeHTTP(w, r)
+		return
+	}
+	s.Datasets.ServeHTTP(w, r)
+}
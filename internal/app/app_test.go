@@ -0,0 +1,259 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/internal/adapters/datasets"
+	"colonycore/internal/blob"
+	"colonycore/internal/core"
+	"colonycore/pkg/datasetapi"
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"colonycore/pkg/openapi"
+	"colonycore/pkg/pluginapi"
+)
+
+type facilityPlugin struct{}
+
+func (facilityPlugin) Name() string    { return "app-test" }
+func (facilityPlugin) Version() string { return "0.0.1" }
+func (facilityPlugin) Register(registry pluginapi.Registry) error {
+	formats := datasetapi.GetFormatProvider()
+	columns := []datasetapi.Column{{Name: "value", Type: "string"}, {Name: datasetapi.RBACFacilityColumn, Type: "string"}}
+	return registry.RegisterDatasetTemplate(datasetapi.Template{
+		Key:           "frogs",
+		Version:       "1.0.0",
+		Title:         "Frogs",
+		Description:   "frogs by facility",
+		Dialect:       datasetapi.GetDialectProvider().SQL(),
+		Query:         "SELECT value, facility_id FROM frogs",
+		Columns:       columns,
+		Metadata:      datasetapi.Metadata{Annotations: map[string]string{datasetapi.CostEntityAnnotation: "organisms"}},
+		OutputFormats: []datasetapi.Format{formats.JSON()},
+		Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
+			return func(context.Context, datasetapi.RunRequest) (datasetapi.RunResult, error) {
+				return datasetapi.RunResult{
+					Schema: columns,
+					Rows: []datasetapi.Row{
+						{"value": "frog-a", datasetapi.RBACFacilityColumn: "facility-a"},
+						{"value": "frog-b", datasetapi.RBACFacilityColumn: "facility-b"},
+					},
+					GeneratedAt: time.Unix(0, 0).UTC(),
+					Format:      formats.JSON(),
+				}, nil
+			}, nil
+		},
+	})
+}
+
+func headerAuthenticator(r *http.Request) (string, error) {
+	if principal := r.Header.Get("X-Principal"); principal != "" {
+		return principal, nil
+	}
+	return "", ErrUnauthenticated
+}
+
+func newTestServer(t *testing.T, configure ...func(*Config)) *Server {
+	t.Helper()
+	cfg := Config{
+		Store: core.NewMemoryStore(core.NewDefaultRulesEngine()),
+		Grants: datasets.GrantResolverFunc(func(_ context.Context, actor string) (datasetapi.RBACScope, error) {
+			if actor != "analyst" {
+				return datasetapi.RBACScope{}, errors.New("unknown actor")
+			}
+			return datasetapi.RBACScope{FacilityIDs: []string{"facility-a"}}, nil
+		}),
+		Authenticate: headerAuthenticator,
+	}
+	for _, apply := range configure {
+		apply(&cfg)
+	}
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("new server: %v", err)
+	}
+	if _, err := server.Service.InstallPlugin(facilityPlugin{}); err != nil {
+		t.Fatalf("install plugin: %v", err)
+	}
+	return server
+}
+
+func TestNewRequiresGrantsAndAuthenticator(t *testing.T) {
+	store := core.NewMemoryStore(core.NewDefaultRulesEngine())
+	grants := datasets.GrantResolverFunc(func(context.Context, string) (datasetapi.RBACScope, error) {
+		return datasetapi.RBACScope{}, nil
+	})
+	if _, err := New(Config{Store: store, Authenticate: headerAuthenticator}); err == nil {
+		t.Fatalf("expected missing grant resolver to be rejected")
+	}
+	if _, err := New(Config{Store: store, Grants: grants}); err == nil {
+		t.Fatalf("expected missing authenticator to be rejected")
+	}
+	if _, err := New(Config{Store: store, Blobs: blob.NewMemory(), Grants: grants, Authenticate: headerAuthenticator}); err == nil {
+		t.Fatalf("expected blobs with a prebuilt store to be rejected")
+	}
+}
+
+func TestNewWiresAttachmentBlobsIntoOpenedStore(t *testing.T) {
+	t.Setenv("COLONYCORE_STORAGE_DRIVER", "memory")
+	ctx := context.Background()
+	blobs := blob.NewMemory()
+	if _, err := blobs.Put(ctx, "obs/scan.tiff", bytes.NewReader([]byte("scan")), blob.PutOptions{ContentType: "image/tiff"}); err != nil {
+		t.Fatalf("put blob: %v", err)
+	}
+	server := newTestServer(t, func(cfg *Config) {
+		cfg.Store = nil
+		cfg.Blobs = blobs
+		cfg.ServiceOptions = []core.ServiceOption{core.WithAttachmentCascadeDelete(true)}
+	})
+	svc := server.Service
+	organism, _, err := svc.CreateOrganism(ctx, domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus laevis"}})
+	if err != nil {
+		t.Fatalf("create organism: %v", err)
+	}
+	observation, _, err := svc.CreateObservation(ctx, domain.Observation{Observation: entitymodel.Observation{OrganismID: &organism.ID, Observer: "tech", RecordedAt: time.Now()}})
+	if err != nil {
+		t.Fatalf("create observation: %v", err)
+	}
+	if _, _, err := svc.AttachObservationFile(ctx, observation.ID, domain.AttachmentRef{Key: "obs/missing.tiff"}); err == nil {
+		t.Fatalf("expected missing blob to be rejected")
+	}
+	attached, _, err := svc.AttachObservationFile(ctx, observation.ID, domain.AttachmentRef{Key: "obs/scan.tiff"})
+	if err != nil || len(attached.Attachments) != 1 || attached.Attachments[0].ContentType != "image/tiff" {
+		t.Fatalf("expected blob to be attached, got %+v, %v", attached.Attachments, err)
+	}
+	if _, err := svc.DeleteObservation(ctx, observation.ID); err != nil {
+		t.Fatalf("delete observation: %v", err)
+	}
+	if _, err := blobs.Head(ctx, "obs/scan.tiff"); err == nil {
+		t.Fatalf("expected cascade delete to remove the blob")
+	}
+}
+
+func TestServerScopesRunsToAuthenticatedPrincipal(t *testing.T) {
+	server := newTestServer(t)
+	path := "/api/v1/datasets/templates/app-test/frogs/1.0.0/run"
+	body := `{"scope":{"requestor":"stranger"}}`
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a principal, got %d", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("X-Principal", "analyst")
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Scope  datasetapi.Scope     `json:"scope"`
+		Result datasetapi.RunResult `json:"result"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode run response: %v", err)
+	}
+	if resp.Scope.Requestor != "analyst" || resp.Scope.RBAC == nil || resp.Scope.RBAC.Actor != "analyst" {
+		t.Fatalf("expected run scoped to the principal, got %+v", resp.Scope)
+	}
+	if len(resp.Result.Rows) != 1 || resp.Result.Rows[0]["value"] != "frog-a" {
+		t.Fatalf("expected only facility-a rows, got %+v", resp.Result.Rows)
+	}
+}
+
+func TestServerRejectsExportsOverCostBudget(t *testing.T) {
+	server := newTestServer(t, func(cfg *Config) { cfg.CostBudget = 1 })
+	for _, name := range []string{"Frog A", "Frog B"} {
+		if _, _, err := server.Service.CreateOrganism(context.Background(), domain.Organism{Organism: entitymodel.Organism{Name: name, Species: "Xenopus laevis"}}); err != nil {
+			t.Fatalf("create organism: %v", err)
+		}
+	}
+	export := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/datasets/exports", strings.NewReader(body))
+		req.Header.Set("X-Principal", "analyst")
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, req)
+		return w
+	}
+
+	w := export(`{"template":{"slug":"app-test/frogs@1.0.0"},"formats":["json"]}`)
+	if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), `"budget":1`) {
+		t.Fatalf("expected 422 with the estimate, got %d: %s", w.Code, w.Body.String())
+	}
+	w = export(`{"template":{"slug":"app-test/frogs@1.0.0"},"formats":["json"],"confirm_large_query":true}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected confirmed export to be queued, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestServerServesOpenAPIDocument(t *testing.T) {
+	server := newTestServer(t)
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, openapi.Path, nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a principal, got %d", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, openapi.Path, nil)
+	req.Header.Set("X-Principal", "analyst")
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != openapi.ContentType {
+		t.Fatalf("expected 200 %s, got %d %q", openapi.ContentType, w.Code, w.Header().Get("Content-Type"))
+	}
+	if !bytes.Equal(w.Body.Bytes(), openapi.Document()) {
+		t.Fatalf("expected the embedded OpenAPI document")
+	}
+}
+
+func TestServerStartStop(t *testing.T) {
+	server := newTestServer(t)
+	server.Start()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := server.Stop(ctx); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+}
+
+func TestServerStreamsAnalyticsForPrincipal(t *testing.T) {
+	server := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/analytics/templates", nil)
+	req.Header.Set("X-Principal", "analyst")
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"id":"app-test/frogs@1.0.0"`) {
+		t.Fatalf("expected template listing, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/analytics/results/app-test/frogs@1.0.0", strings.NewReader(`{"parameters":{}}`))
+	req.Header.Set("X-Principal", "analyst")
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Body.String(); got != "{\"facility_id\":\"facility-a\",\"value\":\"frog-a\"}\n" {
+		t.Fatalf("expected only granted rows, got %q", got)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/analytics/results/app-test/frogs@1.0.0", nil)
+	req.Header.Set("X-Principal", "stranger")
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for unresolvable grants, got %d", w.Code)
+	}
+}
@@ -0,0 +1,47 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+
+	"colonycore/pkg/domain"
+)
+
+// attachmentBlobs adapts a Store to domain.AttachmentBlobStore so persistence
+// can validate and remove observation attachments.
+type attachmentBlobs struct {
+	store Store
+}
+
+// NewAttachmentBlobs wraps store for use as an observation attachment backend.
+// Existence checks use the backend's Stat when it implements MetadataStore and
+// fall back to Head otherwise.
+func NewAttachmentBlobs(store Store) domain.AttachmentBlobStore {
+	return attachmentBlobs{store: store}
+}
+
+func (a attachmentBlobs) Stat(key string) (domain.BlobInfo, error) {
+	if meta, ok := a.store.(MetadataStore); ok {
+		info, err := meta.Stat(key)
+		if err != nil {
+			return domain.BlobInfo{}, err
+		}
+		return domain.BlobInfo{Size: info.Size, ContentType: info.ContentType}, nil
+	}
+	info, err := a.store.Head(context.Background(), key)
+	if err != nil {
+		return domain.BlobInfo{}, err
+	}
+	return domain.BlobInfo{Size: info.Size, ContentType: info.ContentType}, nil
+}
+
+func (a attachmentBlobs) Delete(key string) error {
+	deleted, err := a.store.Delete(context.Background(), key)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("blob %s not found", key)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxMetadataEntries bounds how many metadata pairs a single object may carry.
+	MaxMetadataEntries = 32
+	// MaxMetadataKeyBytes bounds the length of an individual metadata key.
+	MaxMetadataKeyBytes = 128
+	// MaxMetadataValueBytes bounds the length of an individual metadata value.
+	MaxMetadataValueBytes = 1024
+
+	contentSniffBytes = 512
+)
+
+// ErrInvalidMetadata is returned when object metadata fails validation.
+var ErrInvalidMetadata = errors.New("blobstore: invalid metadata")
+
+// ObjectInfo describes a stored object together with its provenance metadata.
+type ObjectInfo struct {
+	Size        int64             `json:"size_bytes"`
+	ContentType string            `json:"content_type,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// ValidateMetadata checks that every key and value is non-empty and within
+// the size bounds above. Keys are checked in sorted order so the reported
+// violation is deterministic.
+func ValidateMetadata(meta map[string]string) error {
+	if len(meta) > MaxMetadataEntries {
+		return fmt.Errorf("%w: %d entries exceeds limit of %d", ErrInvalidMetadata, len(meta), MaxMetadataEntries)
+	}
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if strings.TrimSpace(k) == "" {
+			return fmt.Errorf("%w: empty key", ErrInvalidMetadata)
+		}
+		if len(k) > MaxMetadataKeyBytes {
+			return fmt.Errorf("%w: key of %d bytes exceeds %d bytes", ErrInvalidMetadata, len(k), MaxMetadataKeyBytes)
+		}
+		v := meta[k]
+		if strings.TrimSpace(v) == "" {
+			return fmt.Errorf("%w: empty value for key %q", ErrInvalidMetadata, k)
+		}
+		if len(v) > MaxMetadataValueBytes {
+			return fmt.Errorf("%w: value for key %q exceeds %d bytes", ErrInvalidMetadata, k, MaxMetadataValueBytes)
+		}
+	}
+	return nil
+}
+
+// DetectContentType sniffs the leading bytes of r and returns the detected
+// MIME type along with a reader that replays the consumed prefix.
+func DetectContentType(r io.Reader) (string, io.Reader, error) {
+	head := make([]byte, contentSniffBytes)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", nil, err
+	}
+	head = head[:n]
+	return http.DetectContentType(head), io.MultiReader(bytes.NewReader(head), r), nil
+}
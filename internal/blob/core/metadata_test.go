@@ -0,0 +1,58 @@
+package core
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type errReader struct{ err error }
+
+func (e errReader) Read([]byte) (int, error) { return 0, e.err }
+
+func TestValidateMetadata(t *testing.T) {
+	if err := ValidateMetadata(nil); err != nil {
+		t.Fatalf("expected nil metadata to validate, got %v", err)
+	}
+	if err := ValidateMetadata(map[string]string{"protocol": "prot-1", "project": "proj-1"}); err != nil {
+		t.Fatalf("expected metadata to validate, got %v", err)
+	}
+
+	tooMany := make(map[string]string, MaxMetadataEntries+1)
+	for i := 0; i <= MaxMetadataEntries; i++ {
+		tooMany[strings.Repeat("k", i+1)] = "v"
+	}
+	cases := map[string]map[string]string{
+		"empty key":      {" ": "v"},
+		"empty value":    {"protocol": ""},
+		"oversized key":  {strings.Repeat("k", MaxMetadataKeyBytes+1): "v"},
+		"oversized val":  {"protocol": strings.Repeat("v", MaxMetadataValueBytes+1)},
+		"too many pairs": tooMany,
+	}
+	for name, meta := range cases {
+		if err := ValidateMetadata(meta); !errors.Is(err, ErrInvalidMetadata) {
+			t.Fatalf("%s: expected ErrInvalidMetadata, got %v", name, err)
+		}
+	}
+}
+
+func TestDetectContentType(t *testing.T) {
+	payload := "<html><body>report</body></html>"
+	contentType, r, err := DetectContentType(strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if !strings.HasPrefix(contentType, "text/html") {
+		t.Fatalf("expected text/html, got %q", contentType)
+	}
+	replayed, err := io.ReadAll(r)
+	if err != nil || string(replayed) != payload {
+		t.Fatalf("expected reader to replay payload, got %q err=%v", replayed, err)
+	}
+
+	boom := errors.New("boom")
+	if _, _, err := DetectContentType(errReader{err: boom}); !errors.Is(err, boom) {
+		t.Fatalf("expected read error, got %v", err)
+	}
+}
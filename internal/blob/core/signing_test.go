@@ -0,0 +1,47 @@
+package core
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestURLSignerValidation(t *testing.T) {
+	base := url.URL{Scheme: "http", Host: "local.blob"}
+	if _, err := NewURLSigner(nil, base); !errors.Is(err, ErrSigningKeyRequired) {
+		t.Fatalf("expected ErrSigningKeyRequired, got %v", err)
+	}
+	signer, err := NewURLSigner([]byte("secret"), base)
+	if err != nil {
+		t.Fatalf("NewURLSigner: %v", err)
+	}
+	if _, err := signer.Sign(" ", time.Minute); err == nil {
+		t.Fatalf("expected empty key error")
+	}
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	signer.SetClock(func() time.Time { return now })
+	raw, err := signer.Sign("k", 0)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	signer.SetClock(func() time.Time { return now.Add(DefaultSignedURLTTL - time.Second) })
+	if key, err := signer.Verify(raw); err != nil || key != "k" {
+		t.Fatalf("expected default ttl to cover verification, key=%q err=%v", key, err)
+	}
+	signer.SetClock(nil)
+
+	for name, candidate := range map[string]string{
+		"unparseable":     "http://local.blob/%zz",
+		"wrong origin":    "https://elsewhere/k?expires=1&signature=00",
+		"missing key":     "http://local.blob/?expires=1&signature=00",
+		"bad expiry":      "http://local.blob/k?expires=soon&signature=00",
+		"bad signature":   "http://local.blob/k?expires=1&signature=zz",
+		"empty signature": "http://local.blob/k?expires=1",
+	} {
+		if _, err := signer.Verify(candidate); !errors.Is(err, ErrSignedURLInvalid) {
+			t.Fatalf("%s: expected ErrSignedURLInvalid, got %v", name, err)
+		}
+	}
+}
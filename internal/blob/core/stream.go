@@ -0,0 +1,43 @@
+package core
+
+import (
+	"context"
+	"io"
+)
+
+// DefaultStreamChunkSize bounds how much of a streamed payload is held in
+// memory at once by CopyChunks.
+const DefaultStreamChunkSize = 256 << 10
+
+// CopyChunks copies src into dst one chunk at a time, checking ctx before
+// each read so long uploads abort promptly on cancellation. It returns the
+// bytes written and the first read, write, or context error encountered.
+func CopyChunks(ctx context.Context, dst io.Writer, src io.Reader, chunkSize int) (int64, error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultStreamChunkSize
+	}
+	buf := make([]byte, chunkSize)
+	var written int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+		n, readErr := src.Read(buf)
+		if n > 0 {
+			w, err := dst.Write(buf[:n])
+			written += int64(w)
+			if err != nil {
+				return written, err
+			}
+			if w != n {
+				return written, io.ErrShortWrite
+			}
+		}
+		if readErr == io.EOF {
+			return written, nil
+		}
+		if readErr != nil {
+			return written, readErr
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type shortWriter struct{}
+
+func (shortWriter) Write(p []byte) (int, error) { return len(p) / 2, nil }
+
+type errWriter struct{ err error }
+
+func (e errWriter) Write([]byte) (int, error) { return 0, e.err }
+
+func TestCopyChunks(t *testing.T) {
+	var dst bytes.Buffer
+	n, err := CopyChunks(context.Background(), &dst, strings.NewReader("hello world"), 4)
+	if err != nil || n != 11 || dst.String() != "hello world" {
+		t.Fatalf("unexpected copy result n=%d err=%v dst=%q", n, err, dst.String())
+	}
+
+	dst.Reset()
+	if n, err := CopyChunks(context.Background(), &dst, strings.NewReader("abc"), 0); err != nil || n != 3 {
+		t.Fatalf("expected default chunk size copy, n=%d err=%v", n, err)
+	}
+
+	if _, err := CopyChunks(context.Background(), shortWriter{}, strings.NewReader("abcd"), 4); !errors.Is(err, io.ErrShortWrite) {
+		t.Fatalf("expected short write, got %v", err)
+	}
+
+	boom := errors.New("boom")
+	if _, err := CopyChunks(context.Background(), errWriter{err: boom}, strings.NewReader("abcd"), 4); !errors.Is(err, boom) {
+		t.Fatalf("expected writer error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := CopyChunks(ctx, &dst, strings.NewReader("abcd"), 4); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
@@ -56,5 +56,28 @@ type Store interface {
 	Driver() Driver
 }
 
+// StreamingStore is implemented by backends that can persist a payload of
+// unknown length without buffering it entirely; backends that must buffer,
+// such as the memory driver, cap the payload size instead. Implementations
+// must leave no partial object behind when the context is cancelled or the
+// reader fails.
+type StreamingStore interface {
+	PutStreaming(ctx context.Context, key string, r io.Reader) (int64, error)
+}
+
+// SignedURLStore is implemented by backends that issue self-verifying signed
+// URLs (HMAC + expiry) instead of relying on provider presigning.
+type SignedURLStore interface {
+	SignedURL(key string, ttl time.Duration) (string, error)
+	VerifySignedURL(url string) (key string, err error)
+}
+
+// MetadataStore is implemented by backends that persist validated provenance
+// metadata alongside an object and expose it through Stat.
+type MetadataStore interface {
+	PutWithMetadata(ctx context.Context, key string, r io.Reader, meta map[string]string) error
+	Stat(key string) (ObjectInfo, error)
+}
+
 // ErrUnsupported is returned when an optional capability is not available.
 var ErrUnsupported = errors.New("blobstore: unsupported operation")
@@ -5,6 +5,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestFilesystem_ErrorBranches(t *testing.T) {
@@ -96,3 +97,25 @@ func TestS3_BridgingConstructors(t *testing.T) {
 		t.Fatalf("expected s3 driver")
 	}
 }
+
+func TestFactoryFilesystemWithSigningKey(t *testing.T) {
+	t.Setenv("COLONYCORE_BLOB_DRIVER", "fs")
+	t.Setenv("COLONYCORE_BLOB_FS_ROOT", t.TempDir())
+	t.Setenv("COLONYCORE_BLOB_SIGNING_KEY", "secret")
+	bs, err := Open(context.Background())
+	if err != nil {
+		t.Fatalf("open signed filesystem: %v", err)
+	}
+	signer, ok := bs.(SignedURLStore)
+	if !ok {
+		t.Fatalf("expected filesystem store to implement SignedURLStore")
+	}
+	u, err := signer.SignedURL("artifacts/report.csv", time.Minute)
+	if err != nil {
+		t.Fatalf("signed url: %v", err)
+	}
+	key, err := signer.VerifySignedURL(u)
+	if err != nil || key != "artifacts/report.csv" {
+		t.Fatalf("verify signed url: key=%q err=%v", key, err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 //
 //	COLONYCORE_BLOB_DRIVER: fs|s3|memory (default fs)
 //	COLONYCORE_BLOB_FS_ROOT: directory root when driver=fs (default ./blobdata)
+//	COLONYCORE_BLOB_SIGNING_KEY: optional HMAC secret enabling signed URLs when driver=fs
 //	(S3 specific variables documented in s3.go)
 func Open(ctx context.Context) (Store, error) {
 	driver := os.Getenv("COLONYCORE_BLOB_DRIVER")
@@ -19,6 +20,9 @@ func Open(ctx context.Context) (Store, error) {
 	switch Driver(driver) {
 	case DriverFilesystem:
 		root := os.Getenv("COLONYCORE_BLOB_FS_ROOT")
+		if key := os.Getenv("COLONYCORE_BLOB_SIGNING_KEY"); key != "" {
+			return NewSignedFilesystem(root, []byte(key))
+		}
 		return NewFilesystem(root)
 	case DriverS3:
 		return OpenFromEnv(ctx)
@@ -10,3 +10,9 @@ import (
 func NewFilesystem(root string) (Store, error) {
 	return fs.New(root)
 }
+
+// NewSignedFilesystem constructs a filesystem-backed blob.Store whose SignedURL
+// and VerifySignedURL methods use signingKey for HMAC signatures.
+func NewSignedFilesystem(root string, signingKey []byte) (Store, error) {
+	return fs.NewWithConfig(fs.Config{Root: root, SigningKey: signingKey})
+}
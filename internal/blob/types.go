@@ -16,6 +16,14 @@ type (
 	Info = core.Info
 	// Store is the interface for blob storage backends.
 	Store = core.Store
+	// SignedURLStore is implemented by backends issuing HMAC signed URLs.
+	SignedURLStore = core.SignedURLStore
+	// StreamingStore is implemented by backends supporting chunked streaming uploads.
+	StreamingStore = core.StreamingStore
+	// MetadataStore is implemented by backends persisting validated object metadata.
+	MetadataStore = core.MetadataStore
+	// ObjectInfo describes a stored object and its provenance metadata.
+	ObjectInfo = core.ObjectInfo
 )
 
 const (
@@ -27,5 +35,15 @@ const (
 	DriverMemory = core.DriverMemory
 )
 
-// ErrUnsupported indicates an operation isn't supported by a driver.
-var ErrUnsupported = core.ErrUnsupported
+var (
+	// ErrUnsupported indicates an operation isn't supported by a driver.
+	ErrUnsupported = core.ErrUnsupported
+	// ErrSigningKeyRequired indicates signed URLs were requested without a signing key.
+	ErrSigningKeyRequired = core.ErrSigningKeyRequired
+	// ErrSignedURLInvalid indicates a malformed or tampered signed URL.
+	ErrSignedURLInvalid = core.ErrSignedURLInvalid
+	// ErrSignedURLExpired indicates a signed URL past its expiry.
+	ErrSignedURLExpired = core.ErrSignedURLExpired
+	// ErrInvalidMetadata indicates object metadata failed validation.
+	ErrInvalidMetadata = core.ErrInvalidMetadata
+)
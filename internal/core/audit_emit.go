@@ -0,0 +1,59 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"colonycore/pkg/domain"
+	"colonycore/pkg/pluginapi"
+)
+
+// commitObserverRegistrar is implemented by stores that publish the changes of
+// each committed transaction.
+type commitObserverRegistrar interface {
+	AddCommitObserver(sink domain.ChangeSink)
+}
+
+// ErrCommitsUnobservable is returned when a plugin registers audit emitters
+// on a service whose store does not publish committed transactions.
+var ErrCommitsUnobservable = errors.New("store does not publish committed transactions")
+
+// registerAuditObserver subscribes svc.emitAudit to the store's committed
+// transactions. Stores that cannot register observers are reported with a
+// warning, and false is returned so audit emitter registration can be refused
+// instead of silently never running.
+func registerAuditObserver(svc *Service, store domain.PersistentStore) bool {
+	registrar, ok := store.(commitObserverRegistrar)
+	if !ok {
+		svc.logger.Warn("plugin audit emitters disabled", "error", ErrCommitsUnobservable, "store", fmt.Sprintf("%T", store))
+		return false
+	}
+	registrar.AddCommitObserver(domain.ChangeSinkFunc(svc.emitAudit))
+	return true
+}
+
+// emitAudit delivers a committed transaction to the plugin audit emitters. The
+// actor comes from AuditActorFromContext. Emitter errors are logged and
+// discarded because the transaction has already committed.
+func (s *Service) emitAudit(ctx context.Context, changes []domain.Change) error {
+	s.mu.RLock()
+	emitters := s.auditEmitters
+	s.mu.RUnlock()
+	if len(emitters) == 0 {
+		return nil
+	}
+	entry := pluginapi.NewAuditEntry(
+		AuditActorFromContext(ctx),
+		pluginapi.AuditContextValue(ctx, pluginapi.SessionIDKey),
+		pluginapi.AuditContextValue(ctx, pluginapi.IPAddressKey),
+		s.now(),
+		toPluginChanges(changes),
+	)
+	for _, emitter := range emitters {
+		if err := emitter.EmitAudit(ctx, entry); err != nil {
+			s.logger.Warn("audit emitter failed", "error", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"colonycore/pkg/pluginapi"
+)
+
+type recordingAuditEmitter struct {
+	entries []pluginapi.AuditEntry
+	err     error
+}
+
+func (e *recordingAuditEmitter) EmitAudit(_ context.Context, entry pluginapi.AuditEntry) error {
+	e.entries = append(e.entries, entry)
+	return e.err
+}
+
+func installAuditEmitters(t *testing.T, svc *Service, emitters ...pluginapi.AuditEmitter) {
+	t.Helper()
+	plugin := simplePlugin{name: "audit", version: "1.0.0", register: func(reg *PluginRegistry) error {
+		if err := reg.RegisterAuditEmitter(nil); err == nil {
+			t.Fatalf("expected nil audit emitter error")
+		}
+		for _, emitter := range emitters {
+			if err := reg.RegisterAuditEmitter(emitter); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+	if _, err := svc.InstallPlugin(plugin); err != nil {
+		t.Fatalf("install plugin: %v", err)
+	}
+}
+
+func TestAuditEmitterReceivesCommittedChanges(t *testing.T) {
+	svc := NewInMemoryService(NewRulesEngine())
+	emitter := &recordingAuditEmitter{}
+	installAuditEmitters(t, svc, emitter)
+
+	ctx := context.WithValue(context.Background(), pluginapi.ActorKey, "alice")
+	ctx = context.WithValue(ctx, pluginapi.SessionIDKey, "session-1")
+	ctx = context.WithValue(ctx, pluginapi.IPAddressKey, "10.0.0.7")
+	organism, _, err := svc.CreateOrganism(ctx, domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus", Stage: domain.StageAdult}})
+	if err != nil {
+		t.Fatalf("create organism: %v", err)
+	}
+	if len(emitter.entries) != 1 {
+		t.Fatalf("expected one audit entry, got %d", len(emitter.entries))
+	}
+	entry := emitter.entries[0]
+	if entry.Actor() != "alice" || entry.SessionID() != "session-1" || entry.IPAddress() != "10.0.0.7" {
+		t.Fatalf("unexpected request identity: %q %q %q", entry.Actor(), entry.SessionID(), entry.IPAddress())
+	}
+	if entry.Timestamp().IsZero() {
+		t.Fatalf("expected audit timestamp")
+	}
+	changes := entry.Changes()
+	if len(changes) != 1 {
+		t.Fatalf("expected exactly the committed change, got %d", len(changes))
+	}
+	if changes[0].Entity() != pluginapi.EntityType(domain.EntityOrganism) || changes[0].Action() != pluginapi.Action(domain.ActionCreate) {
+		t.Fatalf("unexpected change %s %s", changes[0].Entity(), changes[0].Action())
+	}
+	var after struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(changes[0].After().Raw(), &after); err != nil || after.ID != organism.ID {
+		t.Fatalf("expected after payload for %s, got %q (%v)", organism.ID, after.ID, err)
+	}
+
+	if _, _, err := svc.UpdateOrganism(ctx, "missing", func(*domain.Organism) error { return nil }); err == nil {
+		t.Fatalf("expected update of missing organism to fail")
+	}
+	if len(emitter.entries) != 1 {
+		t.Fatalf("expected failed transaction not to emit, got %d entries", len(emitter.entries))
+	}
+}
+
+func TestAuditEmitterFallsBackToAuditActor(t *testing.T) {
+	svc := NewInMemoryService(NewRulesEngine())
+	emitter := &recordingAuditEmitter{}
+	installAuditEmitters(t, svc, emitter)
+
+	ctx := WithAuditActor(context.Background(), "bob")
+	if _, _, err := svc.CreateOrganism(ctx, domain.Organism{Organism: entitymodel.Organism{Name: "Toad", Species: "Bufo", Stage: domain.StageAdult}}); err != nil {
+		t.Fatalf("create organism: %v", err)
+	}
+	if len(emitter.entries) != 1 || emitter.entries[0].Actor() != "bob" {
+		t.Fatalf("expected entry attributed to bob, got %+v", emitter.entries)
+	}
+}
+
+func TestAuditEmitterErrorsDoNotRollBack(t *testing.T) {
+	logger := &captureLogger{}
+	svc := NewInMemoryService(NewRulesEngine(), WithLogger(logger))
+	failing := &recordingAuditEmitter{err: errors.New("sink down")}
+	after := &recordingAuditEmitter{}
+	installAuditEmitters(t, svc, failing, after)
+
+	ctx := context.Background()
+	organism, _, err := svc.CreateOrganism(ctx, domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus", Stage: domain.StageAdult}})
+	if err != nil {
+		t.Fatalf("expected emitter error to be discarded, got %v", err)
+	}
+	if _, ok := svc.Store().GetOrganism(organism.ID); !ok {
+		t.Fatalf("expected committed organism to persist")
+	}
+	if len(failing.entries) != 1 || len(after.entries) != 1 {
+		t.Fatalf("expected every emitter to run, got %d and %d", len(failing.entries), len(after.entries))
+	}
+	var warned bool
+	for _, call := range logger.calls {
+		if call == "w:audit emitter failed" {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Fatalf("expected emitter failure to be logged, got %v", logger.calls)
+	}
+}
+
+// unobservableStore hides the memory store's AddCommitObserver.
+type unobservableStore struct{ domain.PersistentStore }
+
+func TestAuditEmittersRequireCommitPublishingStore(t *testing.T) {
+	logger := &captureLogger{}
+	svc := NewService(unobservableStore{NewMemoryStore(NewRulesEngine())}, WithLogger(logger))
+	if len(logger.calls) != 1 || logger.calls[0] != "w:plugin audit emitters disabled" {
+		t.Fatalf("expected warning for unobservable store, got %v", logger.calls)
+	}
+	plugin := simplePlugin{name: "audit", version: "1.0.0", register: func(reg *PluginRegistry) error {
+		return reg.RegisterAuditEmitter(&recordingAuditEmitter{})
+	}}
+	if _, err := svc.InstallPlugin(plugin); !errors.Is(err, ErrCommitsUnobservable) {
+		t.Fatalf("expected ErrCommitsUnobservable, got %v", err)
+	}
+	if _, err := svc.InstallPlugin(simplePlugin{name: "rules", version: "1.0.0", register: func(*PluginRegistry) error { return nil }}); err != nil {
+		t.Fatalf("expected plugins without audit emitters to install, got %v", err)
+	}
+}
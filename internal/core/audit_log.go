@@ -0,0 +1,378 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"colonycore/pkg/domain"
+	"colonycore/pkg/pluginapi"
+)
+
+const (
+	// DefaultAuditQueryLimit bounds audit query pages when AuditQuery.Limit is unset.
+	DefaultAuditQueryLimit = 100
+	// MaxAuditQueryLimit caps the page size an audit query may request.
+	MaxAuditQueryLimit = 1000
+)
+
+// ErrAuditLogUnavailable is returned when the service's audit recorder does not
+// retain entries for querying.
+var ErrAuditLogUnavailable = errors.New("audit log unavailable")
+
+// AuditQuery filters and paginates audit log entries. Zero-valued fields do not
+// constrain results. Since is inclusive and Until is exclusive. Cursor resumes
+// from the next-page token returned by a previous query with the same filter.
+type AuditQuery struct {
+	Entity   domain.EntityType
+	EntityID string
+	Actor    string
+	Action   domain.Action
+	Since    time.Time
+	Until    time.Time
+	Limit    int
+	Cursor   string
+}
+
+// AuditLog is an AuditRecorder that retains entries for querying. Entries are
+// returned oldest first; an empty next cursor marks the final page.
+type AuditLog interface {
+	AuditRecorder
+	QueryAuditLog(filter AuditQuery) ([]AuditEntry, string, error)
+}
+
+type auditActorKey struct{}
+
+// WithAuditActor returns a context attributing audited service operations to actor.
+func WithAuditActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// AuditActorFromContext returns the actor a context attributes service
+// operations to: the principal under pluginapi.ActorKey, falling back to the
+// actor attached by WithAuditActor. The audit log, plugin audit emitters, and
+// force updates all read the actor through it.
+func AuditActorFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if actor := pluginapi.AuditContextValue(ctx, pluginapi.ActorKey); actor != "" {
+		return actor
+	}
+	actor, _ := ctx.Value(auditActorKey{}).(string)
+	return actor
+}
+
+// auditReasonFromContext returns the reason of a force update attached by
+// domain.WithForceUpdate, or "" for ordinary operations.
+func auditReasonFromContext(ctx context.Context) string {
+	override, ok := domain.ForceUpdateFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return override.Reason
+}
+
+// QueryAuditLog queries the configured audit recorder when it implements AuditLog.
+func (s *Service) QueryAuditLog(filter AuditQuery) ([]AuditEntry, string, error) {
+	log, ok := s.audit.(AuditLog)
+	if !ok {
+		return nil, "", ErrAuditLogUnavailable
+	}
+	return log.QueryAuditLog(filter)
+}
+
+// auditCursor identifies the last entry of a page by timestamp and ID.
+type auditCursor struct {
+	at int64
+	id string
+}
+
+func (c auditCursor) encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.at, 10) + ":" + c.id))
+}
+
+func (c auditCursor) before(at int64, id string) bool {
+	return c.at < at || (c.at == at && c.id < id)
+}
+
+func decodeAuditCursor(cursor string) (auditCursor, bool, error) {
+	if cursor == "" {
+		return auditCursor{}, false, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return auditCursor{}, false, fmt.Errorf("invalid audit cursor: %w", err)
+	}
+	at, id, found := strings.Cut(string(raw), ":")
+	if !found || id == "" {
+		return auditCursor{}, false, fmt.Errorf("invalid audit cursor %q", cursor)
+	}
+	nanos, err := strconv.ParseInt(at, 10, 64)
+	if err != nil {
+		return auditCursor{}, false, fmt.Errorf("invalid audit cursor: %w", err)
+	}
+	return auditCursor{at: nanos, id: id}, true, nil
+}
+
+func (q AuditQuery) pageSize() (int, error) {
+	switch {
+	case q.Limit < 0:
+		return 0, fmt.Errorf("audit query limit %d must not be negative", q.Limit)
+	case q.Limit == 0:
+		return DefaultAuditQueryLimit, nil
+	case q.Limit > MaxAuditQueryLimit:
+		return MaxAuditQueryLimit, nil
+	default:
+		return q.Limit, nil
+	}
+}
+
+func (q AuditQuery) matches(entry AuditEntry) bool {
+	switch {
+	case q.Entity != "" && entry.Entity != q.Entity:
+		return false
+	case q.EntityID != "" && entry.EntityID != q.EntityID:
+		return false
+	case q.Actor != "" && entry.Actor != q.Actor:
+		return false
+	case q.Action != "" && entry.Action != q.Action:
+		return false
+	case !q.Since.IsZero() && entry.Timestamp.Before(q.Since):
+		return false
+	case !q.Until.IsZero() && !entry.Timestamp.Before(q.Until):
+		return false
+	}
+	return true
+}
+
+func newAuditEntryID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf("%x", b[:])
+}
+
+// MemoryAuditLog retains audit entries in process memory.
+type MemoryAuditLog struct {
+	mu      sync.RWMutex
+	entries []AuditEntry
+}
+
+// NewMemoryAuditLog constructs an empty in-memory audit log.
+func NewMemoryAuditLog() *MemoryAuditLog {
+	return &MemoryAuditLog{}
+}
+
+// Record implements AuditRecorder.
+func (l *MemoryAuditLog) Record(_ context.Context, entry AuditEntry) {
+	if entry.ID == "" {
+		entry.ID = newAuditEntryID()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	idx := sort.Search(len(l.entries), func(i int) bool {
+		return auditCursor{at: entry.Timestamp.UnixNano(), id: entry.ID}.before(l.entries[i].Timestamp.UnixNano(), l.entries[i].ID)
+	})
+	l.entries = append(l.entries, AuditEntry{})
+	copy(l.entries[idx+1:], l.entries[idx:])
+	l.entries[idx] = entry
+}
+
+// QueryAuditLog implements AuditLog.
+func (l *MemoryAuditLog) QueryAuditLog(filter AuditQuery) ([]AuditEntry, string, error) {
+	limit, err := filter.pageSize()
+	if err != nil {
+		return nil, "", err
+	}
+	after, resume, err := decodeAuditCursor(filter.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	var out []AuditEntry
+	for _, entry := range l.entries {
+		if resume && !after.before(entry.Timestamp.UnixNano(), entry.ID) {
+			continue
+		}
+		if !filter.matches(entry) {
+			continue
+		}
+		if len(out) == limit {
+			last := out[len(out)-1]
+			return out, auditCursor{at: last.Timestamp.UnixNano(), id: last.ID}.encode(), nil
+		}
+		out = append(out, entry)
+	}
+	return out, "", nil
+}
+
+// SQLAuditLog persists audit entries to an audit_log table. Timestamps are
+// stored as UTC Unix nanoseconds so ordering and range filters stay portable
+// across the SQLite and Postgres drivers.
+type SQLAuditLog struct {
+	db *sql.DB
+}
+
+// NewSQLAuditLog creates the audit_log table and its query indexes when absent.
+func NewSQLAuditLog(db *sql.DB) (*SQLAuditLog, error) {
+	if db == nil {
+		return nil, errors.New("audit log database is nil")
+	}
+	for _, stmt := range auditLogDDL {
+		if _, err := db.Exec(stmt); err != nil {
+			return nil, fmt.Errorf("apply audit log ddl: %w", err)
+		}
+	}
+	return &SQLAuditLog{db: db}, nil
+}
+
+// Record implements AuditRecorder. Write failures are reported by Append;
+// Record drops them because AuditRecorder has no error channel.
+func (l *SQLAuditLog) Record(ctx context.Context, entry AuditEntry) {
+	_ = l.Append(ctx, entry)
+}
+
+// Append inserts a single audit entry.
+func (l *SQLAuditLog) Append(ctx context.Context, entry AuditEntry) error {
+	if entry.ID == "" {
+		entry.ID = newAuditEntryID()
+	}
+	_, err := l.db.ExecContext(ctx, insertAuditLogSQL,
+		entry.ID,
+		entry.Operation,
+		string(entry.Entity),
+		entry.EntityID,
+		string(entry.Action),
+		entry.Actor,
+		entry.Reason,
+		string(entry.Status),
+		entry.Error,
+		int64(entry.Duration),
+		entry.Timestamp.UnixNano(),
+	)
+	if err != nil {
+		return fmt.Errorf("insert audit entry: %w", err)
+	}
+	return nil
+}
+
+// QueryAuditLog implements AuditLog with a parameterized keyset query.
+func (l *SQLAuditLog) QueryAuditLog(filter AuditQuery) ([]AuditEntry, string, error) {
+	limit, err := filter.pageSize()
+	if err != nil {
+		return nil, "", err
+	}
+	after, resume, err := decodeAuditCursor(filter.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	var (
+		where []string
+		args  []any
+	)
+	add := func(clause string, arg any) {
+		args = append(args, arg)
+		where = append(where, strings.ReplaceAll(clause, "?", "$"+strconv.Itoa(len(args))))
+	}
+	if filter.Entity != "" {
+		add("entity_type = ?", string(filter.Entity))
+	}
+	if filter.EntityID != "" {
+		add("entity_id = ?", filter.EntityID)
+	}
+	if filter.Actor != "" {
+		add("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		add("action = ?", string(filter.Action))
+	}
+	if !filter.Since.IsZero() {
+		add("created_at >= ?", filter.Since.UnixNano())
+	}
+	if !filter.Until.IsZero() {
+		add("created_at < ?", filter.Until.UnixNano())
+	}
+	if resume {
+		args = append(args, after.at, after.id)
+		at, id := len(args)-1, len(args)
+		where = append(where, fmt.Sprintf("(created_at > $%d OR (created_at = $%d AND id > $%d))", at, at, id))
+	}
+	query := selectAuditLogSQL
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, limit+1)
+	query += fmt.Sprintf(" ORDER BY created_at, id LIMIT $%d", len(args))
+
+	rows, err := l.db.Query(query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("query audit log: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var out []AuditEntry
+	for rows.Next() {
+		var (
+			entry                   AuditEntry
+			entity, action, status  string
+			duration, createdAtNano int64
+		)
+		if err := rows.Scan(&entry.ID, &entry.Operation, &entity, &entry.EntityID, &action, &entry.Actor, &entry.Reason, &status, &entry.Error, &duration, &createdAtNano); err != nil {
+			return nil, "", fmt.Errorf("scan audit entry: %w", err)
+		}
+		entry.Entity = domain.EntityType(entity)
+		entry.Action = domain.Action(action)
+		entry.Status = AuditStatus(status)
+		entry.Duration = time.Duration(duration)
+		entry.Timestamp = time.Unix(0, createdAtNano).UTC()
+		out = append(out, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("iterate audit log: %w", err)
+	}
+	if len(out) <= limit {
+		return out, "", nil
+	}
+	out = out[:limit]
+	last := out[len(out)-1]
+	return out, auditCursor{at: last.Timestamp.UnixNano(), id: last.ID}.encode(), nil
+}
+
+var (
+	_ AuditLog = (*MemoryAuditLog)(nil)
+	_ AuditLog = (*SQLAuditLog)(nil)
+)
+
+var auditLogDDL = []string{
+	`CREATE TABLE IF NOT EXISTS audit_log (
+		id TEXT PRIMARY KEY,
+		operation TEXT NOT NULL,
+		entity_type TEXT NOT NULL,
+		entity_id TEXT NOT NULL,
+		action TEXT NOT NULL,
+		actor TEXT NOT NULL,
+		reason TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL,
+		error TEXT NOT NULL,
+		duration_ns BIGINT NOT NULL,
+		created_at BIGINT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity_type, entity_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_log_actor_created ON audit_log (actor, created_at)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_log_created ON audit_log (created_at, id)`,
+}
+
+const insertAuditLogSQL = `INSERT INTO audit_log (id, operation, entity_type, entity_id, action, actor, reason, status, error, duration_ns, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+const selectAuditLogSQL = `SELECT id, operation, entity_type, entity_id, action, actor, reason, status, error, duration_ns, created_at FROM audit_log`
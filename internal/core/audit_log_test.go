@@ -0,0 +1,225 @@
+package core
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	"colonycore/pkg/domain/entitymodel"
+	"colonycore/pkg/pluginapi"
+)
+
+var auditBase = time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+
+func newSQLAuditLogForTest(t *testing.T) *SQLAuditLog {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "audit.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	log, err := NewSQLAuditLog(db)
+	if err != nil {
+		t.Fatalf("new sql audit log: %v", err)
+	}
+	return log
+}
+
+// seedAuditLog records five entries an hour apart: alice edits organism o1
+// three times and creates project p1; bob updates organism o2.
+func seedAuditLog(log AuditLog) {
+	entries := []AuditEntry{
+		{Operation: "create_organism", Entity: domain.EntityOrganism, Action: domain.ActionCreate, EntityID: "o1", Actor: "alice"},
+		{Operation: "update_organism", Entity: domain.EntityOrganism, Action: domain.ActionUpdate, EntityID: "o1", Actor: "alice"},
+		{Operation: "update_organism", Entity: domain.EntityOrganism, Action: domain.ActionUpdate, EntityID: "o2", Actor: "bob"},
+		{Operation: "create_project", Entity: domain.EntityProject, Action: domain.ActionCreate, EntityID: "p1", Actor: "alice"},
+		{Operation: "update_organism", Entity: domain.EntityOrganism, Action: domain.ActionUpdate, EntityID: "o1", Actor: "alice"},
+	}
+	// Record out of order to exercise timestamp ordering.
+	for _, i := range []int{4, 0, 2, 1, 3} {
+		entry := entries[i]
+		entry.Status = AuditStatusSuccess
+		entry.Timestamp = auditBase.Add(time.Duration(i) * time.Hour)
+		log.Record(context.Background(), entry)
+	}
+}
+
+func auditEntityIDs(entries []AuditEntry) []string {
+	out := make([]string, len(entries))
+	for i, entry := range entries {
+		out[i] = entry.EntityID + "@" + entry.Timestamp.Format("15")
+	}
+	return out
+}
+
+func TestAuditLogQueries(t *testing.T) {
+	logs := map[string]func(t *testing.T) AuditLog{
+		"memory": func(*testing.T) AuditLog { return NewMemoryAuditLog() },
+		"sql":    func(t *testing.T) AuditLog { return newSQLAuditLogForTest(t) },
+	}
+	for name, build := range logs {
+		t.Run(name, func(t *testing.T) {
+			log := build(t)
+			seedAuditLog(log)
+
+			entries, next, err := log.QueryAuditLog(AuditQuery{Entity: domain.EntityOrganism, EntityID: "o1"})
+			if err != nil {
+				t.Fatalf("entity query: %v", err)
+			}
+			if got := auditEntityIDs(entries); len(got) != 3 || got[0] != "o1@09" || got[2] != "o1@13" || next != "" {
+				t.Fatalf("unexpected entity-scoped results %v (next %q)", got, next)
+			}
+
+			entries, _, err = log.QueryAuditLog(AuditQuery{Actor: "alice", Action: domain.ActionCreate})
+			if err != nil {
+				t.Fatalf("actor query: %v", err)
+			}
+			if got := auditEntityIDs(entries); len(got) != 2 || got[0] != "o1@09" || got[1] != "p1@12" {
+				t.Fatalf("unexpected actor-scoped results %v", got)
+			}
+
+			entries, _, err = log.QueryAuditLog(AuditQuery{Since: auditBase.Add(time.Hour), Until: auditBase.Add(3 * time.Hour)})
+			if err != nil {
+				t.Fatalf("window query: %v", err)
+			}
+			if got := auditEntityIDs(entries); len(got) != 2 || got[0] != "o1@10" || got[1] != "o2@11" {
+				t.Fatalf("unexpected time-windowed results %v", got)
+			}
+			if entries[1].Actor != "bob" || entries[1].Status != AuditStatusSuccess || entries[1].Operation != "update_organism" {
+				t.Fatalf("expected entry fields to round-trip, got %+v", entries[1])
+			}
+
+			filter := AuditQuery{Actor: "alice", Limit: 2}
+			first, cursor, err := log.QueryAuditLog(filter)
+			if err != nil {
+				t.Fatalf("first page: %v", err)
+			}
+			if got := auditEntityIDs(first); len(got) != 2 || got[0] != "o1@09" || got[1] != "o1@10" || cursor == "" {
+				t.Fatalf("unexpected first page %v (cursor %q)", got, cursor)
+			}
+			filter.Cursor = cursor
+			second, cursor, err := log.QueryAuditLog(filter)
+			if err != nil {
+				t.Fatalf("second page: %v", err)
+			}
+			if got := auditEntityIDs(second); len(got) != 2 || got[0] != "p1@12" || got[1] != "o1@13" || cursor != "" {
+				t.Fatalf("unexpected second page %v (cursor %q)", got, cursor)
+			}
+
+			if _, _, err := log.QueryAuditLog(AuditQuery{Cursor: "not a cursor"}); err == nil {
+				t.Fatalf("expected invalid cursor error")
+			}
+			if _, _, err := log.QueryAuditLog(AuditQuery{Limit: -1}); err == nil {
+				t.Fatalf("expected negative limit error")
+			}
+		})
+	}
+}
+
+func TestServiceQueryAuditLogRecordsActor(t *testing.T) {
+	if _, _, err := NewInMemoryService(NewRulesEngine()).QueryAuditLog(AuditQuery{}); !errors.Is(err, ErrAuditLogUnavailable) {
+		t.Fatalf("expected ErrAuditLogUnavailable without an audit log, got %v", err)
+	}
+
+	log := NewMemoryAuditLog()
+	svc := NewService(NewMemoryStore(NewRulesEngine()), WithAuditRecorder(log))
+	ctx := WithAuditActor(context.Background(), "alice")
+	organism, _, err := svc.CreateOrganism(ctx, domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus", Stage: domain.StageAdult}})
+	if err != nil {
+		t.Fatalf("create organism: %v", err)
+	}
+	entries, _, err := svc.QueryAuditLog(AuditQuery{Actor: "alice"})
+	if err != nil {
+		t.Fatalf("query audit log: %v", err)
+	}
+	if len(entries) != 1 || entries[0].EntityID != organism.ID || entries[0].ID == "" {
+		t.Fatalf("expected actor-attributed create entry, got %+v", entries)
+	}
+}
+
+func TestServiceUpdateOrganismForceRecordsOverride(t *testing.T) {
+	logs := map[string]func(t *testing.T) AuditLog{
+		"memory": func(*testing.T) AuditLog { return NewMemoryAuditLog() },
+		"sql":    func(t *testing.T) AuditLog { return newSQLAuditLogForTest(t) },
+	}
+	for name, build := range logs {
+		t.Run(name, func(t *testing.T) {
+			store := NewMemoryStore(NewRulesEngine())
+			var observed []domain.Change
+			store.AddCommitObserver(domain.ChangeSinkFunc(func(_ context.Context, changes []domain.Change) error {
+				observed = changes
+				return nil
+			}))
+			svc := NewService(store, WithAuditRecorder(build(t)))
+			ctx := WithAuditActor(context.Background(), "admin")
+			organism, _, err := svc.CreateOrganism(ctx, domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus", Stage: domain.StageAdult}})
+			if err != nil {
+				t.Fatalf("create organism: %v", err)
+			}
+			rename := func(o *domain.Organism) error {
+				o.Name = "Forced"
+				return nil
+			}
+			if _, _, err := svc.UpdateOrganismForce(context.Background(), organism.ID, "repair", rename); !errors.Is(err, domain.ErrForceUpdateUnattributed) {
+				t.Fatalf("expected unattributed force update to fail, got %v", err)
+			}
+			if _, _, err := svc.UpdateOrganismForce(ctx, organism.ID, "", rename); !errors.Is(err, domain.ErrForceUpdateUnattributed) {
+				t.Fatalf("expected force update without reason to fail, got %v", err)
+			}
+			updated, _, err := svc.UpdateOrganismForce(ctx, organism.ID, "repair", rename)
+			if err != nil {
+				t.Fatalf("force update: %v", err)
+			}
+			if updated.Name != "Forced" || updated.Version != 2 {
+				t.Fatalf("unexpected force-updated organism %+v", updated)
+			}
+			if len(observed) != 1 || observed[0].Override == nil || *observed[0].Override != (domain.ForceUpdate{Actor: "admin", Reason: "repair"}) {
+				t.Fatalf("expected force_update change attributed to admin for repair, got %+v", observed)
+			}
+			entries, _, err := svc.QueryAuditLog(AuditQuery{Action: domain.ActionForceUpdate})
+			if err != nil {
+				t.Fatalf("query audit log: %v", err)
+			}
+			if len(entries) != 3 {
+				t.Fatalf("expected two rejected and one applied force_update entries, got %+v", entries)
+			}
+			if last := entries[2]; last.EntityID != organism.ID || last.Actor != "admin" || last.Reason != "repair" || last.Status != AuditStatusSuccess {
+				t.Fatalf("expected force_update success attributed to admin for repair, got %+v", last)
+			}
+		})
+	}
+}
+
+func TestAuditActorFromContextPrefersPluginActor(t *testing.T) {
+	log := NewMemoryAuditLog()
+	svc := NewService(NewMemoryStore(NewRulesEngine()), WithAuditRecorder(log))
+	ctx := context.WithValue(context.Background(), pluginapi.ActorKey, "principal")
+	if got := AuditActorFromContext(WithAuditActor(ctx, "service")); got != "principal" {
+		t.Fatalf("expected pluginapi.ActorKey to take precedence, got %q", got)
+	}
+	if got := AuditActorFromContext(WithAuditActor(context.Background(), "service")); got != "service" {
+		t.Fatalf("expected WithAuditActor fallback, got %q", got)
+	}
+
+	organism, _, err := svc.CreateOrganism(ctx, domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus", Stage: domain.StageAdult}})
+	if err != nil {
+		t.Fatalf("create organism: %v", err)
+	}
+	if _, _, err := svc.UpdateOrganismForce(ctx, organism.ID, "repair", func(o *domain.Organism) error {
+		o.Name = "Forced"
+		return nil
+	}); err != nil {
+		t.Fatalf("expected force update attributed through pluginapi.ActorKey, got %v", err)
+	}
+	entries, _, err := svc.QueryAuditLog(AuditQuery{Actor: "principal"})
+	if err != nil {
+		t.Fatalf("query audit log: %v", err)
+	}
+	if len(entries) != 2 || entries[1].Action != domain.ActionForceUpdate {
+		t.Fatalf("expected create and force_update attributed to the plugin actor, got %+v", entries)
+	}
+}
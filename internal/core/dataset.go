@@ -46,6 +46,7 @@ type DatasetTemplate struct {
 	Plugin string
 
 	host *datasetapi.HostTemplate
+	env  DatasetEnvironment
 }
 
 // Descriptor produces a descriptor snapshot for the template, cloning metadata to guard against mutation.
@@ -104,11 +105,26 @@ func (t DatasetTemplate) Run(ctx context.Context, params map[string]any, scope d
 	return host.Run(ctx, params, scope, format)
 }
 
+// StreamRows executes the dataset template using the bound runner and hands
+// each visible row to emit as it is produced.
+func (t DatasetTemplate) StreamRows(ctx context.Context, params map[string]any, scope datasetapi.Scope, emit datasetapi.RowSink) ([]datasetapi.ParameterError, error) {
+	host, err := t.boundHost()
+	if err != nil {
+		return nil, err
+	}
+	return host.StreamRows(ctx, params, scope, emit)
+}
+
 // Bind attaches a runtime runner using the provided environment.
 func (t *DatasetTemplate) bind(env DatasetEnvironment) error {
 	if t == nil {
 		return errors.New("dataset template nil")
 	}
+	withUnits, err := applyFieldUnits(t.Template)
+	if err != nil {
+		return err
+	}
+	t.Template = withUnits
 	host, err := datasetapi.NewHostTemplate(t.Plugin, t.Template)
 	if err != nil {
 		return err
@@ -118,6 +134,7 @@ func (t *DatasetTemplate) bind(env DatasetEnvironment) error {
 		return err
 	}
 	t.host = &host
+	t.env = env
 	return nil
 }
 
@@ -132,6 +149,12 @@ func (t DatasetTemplate) slug() string {
 	return datasetSlug(t.Plugin, t.Key, t.Version)
 }
 
+// SchemaHash returns the stable hash of the template's declared columns so
+// consumers of runs and exports can detect schema drift between versions.
+func SchemaHash(template DatasetTemplate) string {
+	return datasetapi.SchemaHash(template.Columns)
+}
+
 func (t DatasetTemplate) hostOrNew() (datasetapi.HostTemplate, error) {
 	if t.host != nil {
 		return *t.host, nil
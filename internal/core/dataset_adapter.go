@@ -1,6 +1,11 @@
 package core
 
-import "colonycore/pkg/datasetapi"
+import (
+	"context"
+	"time"
+
+	"colonycore/pkg/datasetapi"
+)
 
 func newDatasetTemplateFromAPI(template datasetapi.Template) (DatasetTemplate, error) {
 	host, err := datasetapi.NewHostTemplate("", template)
@@ -11,5 +16,39 @@ func newDatasetTemplateFromAPI(template datasetapi.Template) (DatasetTemplate, e
 }
 
 func newDatasetTemplateRuntime(template DatasetTemplate) datasetapi.TemplateRuntime {
-	return template.host
+	if template.host == nil || template.env.Store == nil {
+		return template.host
+	}
+	return datasetTemplateRuntime{HostTemplate: template.host, env: template.env}
+}
+
+// datasetTemplateRuntime augments a bound host template with the environment it
+// was bound against so long-running callers can pin a run to one point in time.
+type datasetTemplateRuntime struct {
+	*datasetapi.HostTemplate
+	env DatasetEnvironment
+}
+
+// RunPointInTime executes the template against a snapshot of the store captured
+// at call time instead of the live store. The store's read view is held only
+// while the snapshot is copied; the run then reads that private copy, so rows
+// produced late in a long run never reflect writes committed after it started.
+// Stores whose views cannot list every collection run against the live store.
+func (r datasetTemplateRuntime) RunPointInTime(ctx context.Context, params map[string]any, scope datasetapi.Scope, format datasetapi.Format) (datasetapi.RunResult, []datasetapi.ParameterError, error) {
+	pinned, ok, err := capturePointInTimeStore(ctx, r.env.Store)
+	if err != nil {
+		return datasetapi.RunResult{}, nil, err
+	}
+	if !ok {
+		return r.Run(ctx, params, scope, format)
+	}
+	capturedAt := time.Now().UTC()
+	if r.env.Now != nil {
+		capturedAt = r.env.Now()
+	}
+	env := datasetapi.Environment{
+		Store: newDatasetPersistentStore(pinned),
+		Now:   func() time.Time { return capturedAt },
+	}
+	return r.RunWithEnvironment(ctx, env, params, scope, format)
 }
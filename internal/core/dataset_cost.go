@@ -0,0 +1,216 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"colonycore/pkg/datasetapi"
+	"colonycore/pkg/domain"
+)
+
+// WithDatasetCostBudget caps the number of rows a dataset run may be estimated
+// to return before EstimateCost reports datasetapi.ErrQueryTooLarge. Values
+// below one disable the budget.
+func WithDatasetCostBudget(rows int) ServiceOption {
+	return func(opts *serviceOptions) {
+		if rows < 0 {
+			rows = 0
+		}
+		opts.datasetCostBudget = rows
+	}
+}
+
+// costEntities maps datasetapi.CostEntityAnnotation values to the entity whose
+// records are counted.
+var costEntities = map[string]domain.EntityType{
+	"organisms":        domain.EntityOrganism,
+	"cohorts":          domain.EntityCohort,
+	"housing_units":    domain.EntityHousingUnit,
+	"facilities":       domain.EntityFacility,
+	"samples":          domain.EntitySample,
+	"projects":         domain.EntityProject,
+	"permits":          domain.EntityPermit,
+	"supply_items":     domain.EntitySupplyItem,
+	"procedures":       domain.EntityProcedure,
+	"breeding_units":   domain.EntityBreeding,
+	"lines":            domain.EntityLine,
+	"strains":          domain.EntityStrain,
+	"genotype_markers": domain.EntityGenotypeMarker,
+	"treatments":       domain.EntityTreatment,
+	"observations":     domain.EntityObservation,
+	"protocols":        domain.EntityProtocol,
+}
+
+// countCostEntity counts the records of entity admitted by filter, in place
+// when the store implements domain.EntityCounter and by listing the
+// collection otherwise.
+func countCostEntity(store domain.PersistentStore, entity domain.EntityType, filter domain.CountFilter) (int, error) {
+	if counter, ok := store.(domain.EntityCounter); ok {
+		return counter.CountEntities(entity, filter)
+	}
+	return listCount(store, entity, filter), nil
+}
+
+// listCount is the fallback for stores that cannot count in place.
+func listCount(store domain.PersistentStore, entity domain.EntityType, filter domain.CountFilter) int {
+	housingFacility := func(id *string) []string {
+		if id == nil {
+			return nil
+		}
+		unit, _ := store.GetHousingUnit(*id)
+		return []string{unit.FacilityID}
+	}
+	count := func(n int, attribution func(int) ([]string, []string)) int {
+		total := 0
+		for i := 0; i < n; i++ {
+			if filter.Admits(attribution(i)) {
+				total++
+			}
+		}
+		return total
+	}
+	switch entity {
+	case domain.EntityOrganism:
+		organisms := store.ListOrganisms()
+		return count(len(organisms), func(i int) ([]string, []string) {
+			return housingFacility(organisms[i].HousingID), optionalIDs(organisms[i].ProjectID)
+		})
+	case domain.EntityCohort:
+		cohorts := store.ListCohorts()
+		return count(len(cohorts), func(i int) ([]string, []string) {
+			return housingFacility(cohorts[i].HousingID), optionalIDs(cohorts[i].ProjectID)
+		})
+	case domain.EntityHousingUnit:
+		units := store.ListHousingUnits()
+		return count(len(units), func(i int) ([]string, []string) { return []string{units[i].FacilityID}, nil })
+	case domain.EntityFacility:
+		facilities := store.ListFacilities()
+		return count(len(facilities), func(i int) ([]string, []string) { return []string{facilities[i].ID}, nil })
+	case domain.EntitySample:
+		samples := store.ListSamples()
+		return count(len(samples), func(i int) ([]string, []string) { return []string{samples[i].FacilityID}, nil })
+	case domain.EntityProject:
+		projects := store.ListProjects()
+		return count(len(projects), func(i int) ([]string, []string) {
+			return projects[i].FacilityIDs, []string{projects[i].ID}
+		})
+	case domain.EntityPermit:
+		permits := store.ListPermits()
+		return count(len(permits), func(i int) ([]string, []string) { return permits[i].FacilityIDs, nil })
+	case domain.EntitySupplyItem:
+		items := store.ListSupplyItems()
+		return count(len(items), func(i int) ([]string, []string) { return items[i].FacilityIDs, items[i].ProjectIDs })
+	case domain.EntityProcedure:
+		procedures := store.ListProcedures()
+		return count(len(procedures), func(i int) ([]string, []string) { return nil, optionalIDs(procedures[i].ProjectID) })
+	case domain.EntityBreeding:
+		return len(store.ListBreedingUnits())
+	case domain.EntityLine:
+		return len(store.ListLines())
+	case domain.EntityStrain:
+		return len(store.ListStrains())
+	case domain.EntityGenotypeMarker:
+		return len(store.ListGenotypeMarkers())
+	case domain.EntityTreatment:
+		return len(store.ListTreatments())
+	case domain.EntityObservation:
+		return len(store.ListObservations())
+	default:
+		return len(store.ListProtocols())
+	}
+}
+
+func optionalIDs(id *string) []string {
+	if id == nil {
+		return nil
+	}
+	return []string{*id}
+}
+
+// costFilterIDs returns the grant as a count filter dimension: nil, meaning
+// unfiltered, when the grant includes datasetapi.RBACWildcard, and a non-nil
+// slice otherwise so an empty grant admits only unattributed records.
+func costFilterIDs(ids []string) []string {
+	for _, id := range ids {
+		if id == datasetapi.RBACWildcard {
+			return nil
+		}
+	}
+	return append([]string{}, ids...)
+}
+
+func declaresColumn(columns []datasetapi.Column, name string) bool {
+	for _, column := range columns {
+		if strings.EqualFold(column.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// EstimateCost estimates the rows a run of template would return for params
+// under scope. Templates declaring datasetapi.CostEntityAnnotation are
+// estimated by counting the named store collection in place (see
+// domain.EntityCounter), restricted to the scope's
+// facility and project grants on the dimensions the template exposes and
+// capped by a positive limit parameter. When the estimate exceeds the budget
+// configured with WithDatasetCostBudget, the estimate is returned alongside a
+// *datasetapi.QueryTooLargeError.
+func (s *Service) EstimateCost(template DatasetTemplate, params map[string]any, scope datasetapi.RBACScope) (datasetapi.CostEstimate, error) {
+	estimate := datasetapi.CostEstimate{Template: template.slug(), Budget: s.datasetCostBudget}
+	cleaned, paramErrs := template.ValidateParameters(params)
+	if len(paramErrs) > 0 {
+		messages := make([]string, 0, len(paramErrs))
+		for _, paramErr := range paramErrs {
+			messages = append(messages, fmt.Sprintf("%s: %s", paramErr.Name, paramErr.Message))
+		}
+		return estimate, fmt.Errorf("dataset template %s: invalid parameters: %s", estimate.Template, strings.Join(messages, "; "))
+	}
+
+	estimate.Entity = strings.TrimSpace(template.Metadata.Annotations[datasetapi.CostEntityAnnotation])
+	if estimate.Entity == "" {
+		return estimate, nil
+	}
+	entity, ok := costEntities[estimate.Entity]
+	if !ok {
+		return estimate, fmt.Errorf("dataset template %s: unknown %s %q", estimate.Template, datasetapi.CostEntityAnnotation, estimate.Entity)
+	}
+
+	var filter domain.CountFilter
+	unscoped := scope.Actor == "" && len(scope.FacilityIDs) == 0 && len(scope.ProjectIDs) == 0
+	if !unscoped && !datasetapi.RBACExempt(template.Metadata) {
+		if declaresColumn(template.Columns, datasetapi.RBACFacilityColumn) {
+			filter.FacilityIDs = costFilterIDs(scope.FacilityIDs)
+		}
+		if declaresColumn(template.Columns, datasetapi.RBACProjectColumn) {
+			filter.ProjectIDs = costFilterIDs(scope.ProjectIDs)
+		}
+	}
+	rows, err := countCostEntity(s.store, entity, filter)
+	if err != nil {
+		return estimate, fmt.Errorf("dataset template %s: estimate cost: %w", estimate.Template, err)
+	}
+	estimate.Rows = rows
+	if limit, ok := cleaned[datasetapi.CostLimitParameter].(int); ok && limit > 0 && limit < estimate.Rows {
+		estimate.Rows = limit
+	}
+
+	estimate.Estimated = true
+	if estimate.Budget > 0 && estimate.Rows > estimate.Budget {
+		estimate.ExceedsBudget = true
+		return estimate, &datasetapi.QueryTooLargeError{Estimate: estimate}
+	}
+	return estimate, nil
+}
+
+// EstimateDatasetCost resolves the template installed under slug and estimates
+// its cost with EstimateCost.
+func (s *Service) EstimateDatasetCost(slug string, params map[string]any, scope datasetapi.RBACScope) (datasetapi.CostEstimate, error) {
+	s.mu.RLock()
+	template, ok := s.datasets[slug]
+	s.mu.RUnlock()
+	if !ok {
+		return datasetapi.CostEstimate{Template: slug}, fmt.Errorf("dataset template %s not found", slug)
+	}
+	return s.EstimateCost(template, params, scope)
+}
@@ -0,0 +1,153 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"colonycore/pkg/datasetapi"
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func costTemplate() DatasetTemplate {
+	return DatasetTemplate{
+		Plugin: testPluginFrog,
+		Template: datasetapi.Template{
+			Key:        "organisms",
+			Version:    "1.0.0",
+			Title:      "Organisms",
+			Dialect:    DatasetDialectSQL,
+			Query:      "SELECT id, facility_id FROM organisms",
+			Parameters: []datasetapi.Parameter{{Name: datasetapi.CostLimitParameter, Type: "integer"}},
+			Columns:    []datasetapi.Column{{Name: "id", Type: "string"}, {Name: "facility_id", Type: "string"}},
+			Metadata:   datasetapi.Metadata{Annotations: map[string]string{datasetapi.CostEntityAnnotation: "organisms"}},
+			OutputFormats: []datasetapi.Format{
+				FormatJSON,
+			},
+			Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
+				return func(context.Context, datasetapi.RunRequest) (datasetapi.RunResult, error) {
+					return datasetapi.RunResult{}, nil
+				}, nil
+			},
+		},
+	}
+}
+
+func seedCostOrganisms(t *testing.T, svc *Service, facilityCode string, count int) string {
+	t.Helper()
+	ctx := context.Background()
+	facility, _, err := svc.CreateFacility(ctx, domain.Facility{Facility: entitymodel.Facility{Code: facilityCode, Name: facilityCode, Zone: "A", AccessPolicy: "badge"}})
+	if err != nil {
+		t.Fatalf("create facility: %v", err)
+	}
+	housing, _, err := svc.CreateHousingUnit(ctx, domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: facilityCode + "-tank", FacilityID: facility.ID, Capacity: 10, Environment: domain.HousingEnvironmentAquatic}})
+	if err != nil {
+		t.Fatalf("create housing: %v", err)
+	}
+	for i := 0; i < count; i++ {
+		housingID := housing.ID
+		if _, _, err := svc.CreateOrganism(ctx, domain.Organism{Organism: entitymodel.Organism{Name: facilityCode, Species: "Xenopus laevis", HousingID: &housingID}}); err != nil {
+			t.Fatalf("create organism: %v", err)
+		}
+	}
+	return facility.ID
+}
+
+func TestEstimateCostEnforcesBudget(t *testing.T) {
+	svc := NewInMemoryService(nil, WithDatasetCostBudget(2))
+	large := seedCostOrganisms(t, svc, "LARGE", 3)
+	small := seedCostOrganisms(t, svc, "SMALL", 1)
+	template := costTemplate()
+
+	estimate, err := svc.EstimateCost(template, nil, datasetapi.RBACScope{Actor: "analyst", FacilityIDs: []string{small}})
+	if err != nil {
+		t.Fatalf("expected small query to pass, got %v", err)
+	}
+	if !estimate.Estimated || estimate.Rows != 1 || estimate.ExceedsBudget {
+		t.Fatalf("unexpected small estimate: %+v", estimate)
+	}
+
+	estimate, err = svc.EstimateCost(template, nil, datasetapi.RBACScope{Actor: "analyst", FacilityIDs: []string{large, small}})
+	if !errors.Is(err, datasetapi.ErrQueryTooLarge) {
+		t.Fatalf("expected ErrQueryTooLarge, got %v", err)
+	}
+	var tooLarge *datasetapi.QueryTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("expected QueryTooLargeError, got %T", err)
+	}
+	if tooLarge.Estimate.Rows != 4 || tooLarge.Estimate.Budget != 2 || !tooLarge.Estimate.ExceedsBudget || tooLarge.Estimate.Entity != "organisms" {
+		t.Fatalf("expected estimate attached to error, got %+v", tooLarge.Estimate)
+	}
+	if estimate != tooLarge.Estimate {
+		t.Fatalf("expected returned estimate to match error estimate, got %+v and %+v", estimate, tooLarge.Estimate)
+	}
+
+	if estimate, err := svc.EstimateCost(template, map[string]any{datasetapi.CostLimitParameter: 2}, datasetapi.RBACScope{}); err != nil || estimate.Rows != 2 {
+		t.Fatalf("expected limit to cap the estimate, got %+v, %v", estimate, err)
+	}
+	if estimate, err := svc.EstimateCost(template, nil, datasetapi.RBACScope{Actor: "analyst"}); err != nil || estimate.Rows != 0 {
+		t.Fatalf("expected actor without grants to estimate no rows, got %+v, %v", estimate, err)
+	}
+}
+
+func TestEstimateCostWithoutAnnotationOrBudget(t *testing.T) {
+	svc := NewInMemoryService(nil)
+	seedCostOrganisms(t, svc, "FAC", 3)
+
+	template := costTemplate()
+	if estimate, err := svc.EstimateCost(template, nil, datasetapi.RBACScope{}); err != nil || estimate.Rows != 3 || estimate.ExceedsBudget {
+		t.Fatalf("expected unbudgeted estimate to pass, got %+v, %v", estimate, err)
+	}
+
+	template.Metadata.Annotations = nil
+	estimate, err := svc.EstimateCost(template, nil, datasetapi.RBACScope{})
+	if err != nil || estimate.Estimated {
+		t.Fatalf("expected template without cost annotation to be unestimated, got %+v, %v", estimate, err)
+	}
+
+	template.Metadata.Annotations = map[string]string{datasetapi.CostEntityAnnotation: "widgets"}
+	if _, err := svc.EstimateCost(template, nil, datasetapi.RBACScope{}); err == nil {
+		t.Fatalf("expected unknown cost entity to fail")
+	}
+	if _, err := svc.EstimateCost(template, map[string]any{datasetapi.CostLimitParameter: "many"}, datasetapi.RBACScope{}); err == nil {
+		t.Fatalf("expected invalid parameters to fail")
+	}
+	if _, err := svc.EstimateDatasetCost("frog/missing@1", nil, datasetapi.RBACScope{}); err == nil {
+		t.Fatalf("expected unknown template to fail")
+	}
+}
+
+// listOnlyStore hides domain.EntityCounter so EstimateCost falls back to
+// listing collections.
+type listOnlyStore struct{ domain.PersistentStore }
+
+type failingCounterStore struct{ domain.PersistentStore }
+
+func (failingCounterStore) CountEntities(domain.EntityType, domain.CountFilter) (int, error) {
+	return 0, errors.New("count unavailable")
+}
+
+func TestEstimateCostCountsThroughStore(t *testing.T) {
+	seeded := NewInMemoryService(nil)
+	facility := seedCostOrganisms(t, seeded, "FAC", 3)
+	seedCostOrganisms(t, seeded, "OTHER", 2)
+	template := costTemplate()
+	scope := datasetapi.RBACScope{Actor: "analyst", FacilityIDs: []string{facility}}
+
+	counted, err := seeded.EstimateCost(template, nil, scope)
+	if err != nil || counted.Rows != 3 {
+		t.Fatalf("expected counter to estimate 3 rows, got %+v, %v", counted, err)
+	}
+	listed, err := NewService(listOnlyStore{seeded.Store()}).EstimateCost(template, nil, scope)
+	if err != nil || listed != counted {
+		t.Fatalf("expected list fallback to match counter, got %+v, %v", listed, err)
+	}
+	wildcard := datasetapi.RBACScope{Actor: "analyst", FacilityIDs: []string{datasetapi.RBACWildcard}}
+	if estimate, err := seeded.EstimateCost(template, nil, wildcard); err != nil || estimate.Rows != 5 {
+		t.Fatalf("expected wildcard grant to count every row, got %+v, %v", estimate, err)
+	}
+	if _, err := NewService(failingCounterStore{seeded.Store()}).EstimateCost(template, nil, scope); err == nil {
+		t.Fatalf("expected counter failure to propagate")
+	}
+}
@@ -162,7 +162,7 @@ func facadeBreedingUnitFromDomain(unit domain.BreedingUnit) datasetapi.BreedingU
 		StrainID:       unit.StrainID,
 		TargetLineID:   unit.TargetLineID,
 		TargetStrainID: unit.TargetStrainID,
-		PairingIntent:  unit.PairingIntent,
+		PairingIntent:  pairingIntentString(unit.PairingIntent),
 		PairingNotes:   unit.PairingNotes,
 		Extensions:     extSet,
 		FemaleIDs:      unit.FemaleIDs,
@@ -170,6 +170,14 @@ func facadeBreedingUnitFromDomain(unit domain.BreedingUnit) datasetapi.BreedingU
 	})
 }
 
+func pairingIntentString(intent *domain.PairingIntent) *string {
+	if intent == nil {
+		return nil
+	}
+	value := string(*intent)
+	return &value
+}
+
 func facadeBreedingUnitsFromDomain(units []domain.BreedingUnit) []datasetapi.BreedingUnit {
 	if len(units) == 0 {
 		return nil
@@ -249,9 +257,9 @@ func facadeTreatmentFromDomain(treatment domain.Treatment) datasetapi.Treatment
 		ProcedureID:       treatment.ProcedureID,
 		OrganismIDs:       treatment.OrganismIDs,
 		CohortIDs:         treatment.CohortIDs,
-		DosagePlan:        treatment.DosagePlan,
+		DosagePlan:        describeDosagePlan(treatment.DosagePlan),
 		AdministrationLog: treatment.AdministrationLog,
-		AdverseEvents:     treatment.AdverseEvents,
+		AdverseEvents:     describeAdverseEvents(treatment.AdverseEvents),
 	})
 }
 
@@ -130,7 +130,7 @@ func TestFacadeCohortAndTreatmentMapping(t *testing.T) {
 		ProcedureID:       "procedure-1",
 		OrganismIDs:       []string{"organism-1"},
 		CohortIDs:         []string{"cohort-1"},
-		DosagePlan:        "plan",
+		DosagePlan:        domain.DosagePlan{Drug: "plan", DoseAmount: 1, DoseUnit: "mg"},
 		AdministrationLog: []string{"log"},
 		AdverseEvents:     nil},
 	}
@@ -170,16 +170,17 @@ func TestFacadeOrganismMapping(t *testing.T) {
 
 func TestFacadeBreedingAndProcedureMapping(t *testing.T) {
 	now := time.Now()
+	intent := domain.PairingIntentExpansion
 	breeding := domain.BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{ID: "breeding-1",
 		Name:          "Breeding",
 		Strategy:      "natural",
 		FemaleIDs:     []string{"f1"},
 		MaleIDs:       []string{"m1"},
-		PairingIntent: strPtr("pair"),
+		PairingIntent: &intent,
 		PairingNotes:  strPtr("notes")},
 	}
 	mappedBreeding := facadeBreedingUnitFromDomain(breeding)
-	if mappedBreeding.Name() != "Breeding" || len(mappedBreeding.FemaleIDs()) != 1 {
+	if mappedBreeding.Name() != "Breeding" || len(mappedBreeding.FemaleIDs()) != 1 || mappedBreeding.PairingIntent() != "expansion" {
 		t.Fatalf("unexpected breeding mapping: %+v", mappedBreeding)
 	}
 	if facadeBreedingUnitsFromDomain(nil) != nil || facadeBreedingUnitsFromDomain([]domain.BreedingUnit{}) != nil {
@@ -247,6 +248,7 @@ func TestFacadeFacilityPermitSampleSupplyMapping(t *testing.T) {
 		Status:          domain.SampleStatusStored,
 		StorageLocation: "Freezer",
 		AssayType:       "assay",
+		CollectedBy:     "tech",
 		ChainOfCustody: []domain.SampleCustodyEvent{
 			{Actor: "tech", Location: "lab", Timestamp: now},
 		}},
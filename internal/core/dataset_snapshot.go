@@ -0,0 +1,71 @@
+package core
+
+import (
+	"context"
+
+	"colonycore/internal/infra/persistence/memory"
+	"colonycore/pkg/domain"
+)
+
+// completeView is implemented by store views that also list the collections
+// domain.TransactionView omits. Point-in-time captures need every collection,
+// so views without these listers fall back to running against the live store.
+type completeView interface {
+	ListCohorts() []domain.Cohort
+	ListBreedingUnits() []domain.BreedingUnit
+	ListProcedures() []domain.Procedure
+}
+
+func indexByID[T any](items []T, id func(T) string) map[string]T {
+	out := make(map[string]T, len(items))
+	for _, item := range items {
+		out[id(item)] = item
+	}
+	return out
+}
+
+// capturePointInTimeStore copies the state visible through a single store.View
+// call into a private in-memory store. The view (and any read lock the store
+// holds for it) is released as soon as the copy completes, so long-running
+// dataset jobs read a consistent snapshot without blocking writers. The bool
+// result is false when the store's views cannot list every collection.
+func capturePointInTimeStore(ctx context.Context, store domain.PersistentStore) (domain.PersistentStore, bool, error) {
+	var (
+		snapshot memory.Snapshot
+		complete bool
+	)
+	err := store.View(ctx, func(view domain.TransactionView) error {
+		full, ok := view.(completeView)
+		if !ok {
+			return nil
+		}
+		complete = true
+		snapshot = memory.Snapshot{
+			Organisms:    indexByID(view.ListOrganisms(), func(v domain.Organism) string { return v.ID }),
+			Cohorts:      indexByID(full.ListCohorts(), func(v domain.Cohort) string { return v.ID }),
+			Housing:      indexByID(view.ListHousingUnits(), func(v domain.HousingUnit) string { return v.ID }),
+			Facilities:   indexByID(view.ListFacilities(), func(v domain.Facility) string { return v.ID }),
+			Breeding:     indexByID(full.ListBreedingUnits(), func(v domain.BreedingUnit) string { return v.ID }),
+			Lines:        indexByID(view.ListLines(), func(v domain.Line) string { return v.ID }),
+			Strains:      indexByID(view.ListStrains(), func(v domain.Strain) string { return v.ID }),
+			Markers:      indexByID(view.ListGenotypeMarkers(), func(v domain.GenotypeMarker) string { return v.ID }),
+			Procedures:   indexByID(full.ListProcedures(), func(v domain.Procedure) string { return v.ID }),
+			Treatments:   indexByID(view.ListTreatments(), func(v domain.Treatment) string { return v.ID }),
+			Observations: indexByID(view.ListObservations(), func(v domain.Observation) string { return v.ID }),
+			Samples:      indexByID(view.ListSamples(), func(v domain.Sample) string { return v.ID }),
+			Protocols:    indexByID(view.ListProtocols(), func(v domain.Protocol) string { return v.ID }),
+			Permits:      indexByID(view.ListPermits(), func(v domain.Permit) string { return v.ID }),
+			Projects:     indexByID(view.ListProjects(), func(v domain.Project) string { return v.ID }),
+			Supplies:     indexByID(view.ListSupplyItems(), func(v domain.SupplyItem) string { return v.ID }),
+		}
+		return nil
+	})
+	if err != nil || !complete {
+		return nil, false, err
+	}
+	pinned := memory.NewStore(nil)
+	if err := pinned.ImportState(snapshot); err != nil {
+		return nil, false, err
+	}
+	return pinned, true, nil
+}
@@ -51,7 +51,7 @@ func TestDatasetPersistentStoreAdapter(t *testing.T) {
 	treatment := domain.Treatment{Treatment: entitymodel.Treatment{ID: "treatment", Name: "Treatment", Status: domain.TreatmentStatusInProgress, ProcedureID: procedure.ID, OrganismIDs: []string{organismID}, AdministrationLog: []string{"dose1"}}}
 	observation := domain.Observation{Observation: entitymodel.Observation{ID: "observation", RecordedAt: now, Observer: "tech"}}
 	sample := domain.Sample{Sample: entitymodel.Sample{ID: "sample", Identifier: "S1", SourceType: "organism", FacilityID: facility.ID, CollectedAt: now, Status: domain.SampleStatusStored}}
-	permit := domain.Permit{Permit: entitymodel.Permit{ID: "permit", PermitNumber: "PERMIT", Authority: "Gov", Status: domain.PermitStatusApproved, ValidFrom: now.Add(-24 * time.Hour), ValidUntil: now.Add(24 * time.Hour)}}
+	permit := domain.Permit{Permit: entitymodel.Permit{ID: "permit", PermitNumber: "PERMIT", Authority: "Gov", Status: domain.PermitStatusApproved, IssueDate: now.Add(-24 * time.Hour), ValidFrom: now.Add(-24 * time.Hour), ValidUntil: now.Add(24 * time.Hour)}}
 	supply := domain.SupplyItem{SupplyItem: entitymodel.SupplyItem{ID: "supply", SKU: "SKU", Name: "Item", QuantityOnHand: 5, ReorderLevel: 1}}
 
 	fake := &fakePersistentStore{
@@ -435,18 +435,82 @@ func (f *fakePersistentStore) ListProjects() []domain.Project {
 	return append([]domain.Project(nil), f.projects...)
 }
 
+func (f *fakePersistentStore) GetBreedingUnit(id string) (domain.BreedingUnit, bool) {
+	for _, unit := range f.breedingUnits {
+		if unit.ID == id {
+			return unit, true
+		}
+	}
+	return domain.BreedingUnit{}, false
+}
+
 func (f *fakePersistentStore) ListBreedingUnits() []domain.BreedingUnit {
 	return append([]domain.BreedingUnit(nil), f.breedingUnits...)
 }
 
+func (f *fakePersistentStore) ListBreedingUnitsByIntent(intent domain.PairingIntent) []domain.BreedingUnit {
+	var out []domain.BreedingUnit
+	for _, unit := range f.breedingUnits {
+		if unit.PairingIntent != nil && *unit.PairingIntent == intent {
+			out = append(out, unit)
+		}
+	}
+	return out
+}
+
 func (f *fakePersistentStore) ListProcedures() []domain.Procedure {
 	return append([]domain.Procedure(nil), f.procedures...)
 }
 
+func (f *fakePersistentStore) GetSupplyItem(id string) (domain.SupplyItem, bool) {
+	for _, item := range f.supplyItems {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return domain.SupplyItem{}, false
+}
+
 func (f *fakePersistentStore) ListSupplyItems() []domain.SupplyItem {
 	return append([]domain.SupplyItem(nil), f.supplyItems...)
 }
 
+func (f *fakePersistentStore) GetFacilityByCode(code string) (domain.Facility, bool, error) {
+	for _, fac := range f.facilities {
+		if fac.Code == code {
+			return fac, true, nil
+		}
+	}
+	return domain.Facility{Facility: entitymodel.Facility{}}, false, nil
+}
+
+func (f *fakePersistentStore) GetProtocolByCode(code string) (domain.Protocol, bool, error) {
+	for _, protocol := range f.protocols {
+		if protocol.Code == code {
+			return protocol, true, nil
+		}
+	}
+	return domain.Protocol{Protocol: entitymodel.Protocol{}}, false, nil
+}
+
+func (f *fakePersistentStore) GetLineByCode(code string) (domain.Line, bool, error) {
+	for _, line := range f.lines {
+		if line.Code == code {
+			return line, true, nil
+		}
+	}
+	return domain.Line{Line: entitymodel.Line{}}, false, nil
+}
+
+func (f *fakePersistentStore) GetStrainByCode(lineID, code string) (domain.Strain, bool, error) {
+	for _, strain := range f.strains {
+		if strain.LineID == lineID && strain.Code == code {
+			return strain, true, nil
+		}
+	}
+	return domain.Strain{Strain: entitymodel.Strain{}}, false, nil
+}
+
 type fakeTransactionView struct {
 	store *fakePersistentStore
 }
@@ -470,7 +534,28 @@ func (v fakeTransactionView) ListTreatments() []domain.Treatment {
 func (v fakeTransactionView) ListObservations() []domain.Observation {
 	return v.store.ListObservations()
 }
-func (v fakeTransactionView) ListSamples() []domain.Sample   { return v.store.ListSamples() }
+func (v fakeTransactionView) ListTreatmentsByProcedure(string) []domain.Treatment {
+	return nil
+}
+func (v fakeTransactionView) ListObservationsByOrganism(string, ...domain.ObservationFilter) []domain.Observation {
+	return nil
+}
+func (v fakeTransactionView) ListObservationsByCohort(string) []domain.Observation {
+	return nil
+}
+func (v fakeTransactionView) ActiveStrainCount(string) int          { return 0 }
+func (v fakeTransactionView) ListRetirableStrains() []domain.Strain { return nil }
+func (v fakeTransactionView) ActiveLineCount() int                  { return 0 }
+func (v fakeTransactionView) ReferencesTo(domain.EntityType, string) []domain.Reference {
+	return nil
+}
+func (v fakeTransactionView) ListSamples() []domain.Sample { return v.store.ListSamples() }
+func (v fakeTransactionView) ListSamplesByFacility(string) []domain.Sample {
+	return nil
+}
+func (v fakeTransactionView) ListSamplesByOrganism(string) []domain.Sample {
+	return nil
+}
 func (v fakeTransactionView) ListPermits() []domain.Permit   { return v.store.ListPermits() }
 func (v fakeTransactionView) ListProjects() []domain.Project { return v.store.ListProjects() }
 func (v fakeTransactionView) ListSupplyItems() []domain.SupplyItem {
@@ -481,6 +566,15 @@ func (v fakeTransactionView) FindOrganism(id string) (domain.Organism, bool) {
 	return v.store.GetOrganism(id)
 }
 
+func (v fakeTransactionView) FindCohort(id string) (domain.Cohort, bool) {
+	for _, cohort := range v.store.ListCohorts() {
+		if cohort.ID == id {
+			return cohort, true
+		}
+	}
+	return domain.Cohort{Cohort: entitymodel.Cohort{}}, false
+}
+
 func (v fakeTransactionView) FindHousingUnit(id string) (domain.HousingUnit, bool) {
 	return v.store.GetHousingUnit(id)
 }
@@ -493,6 +587,8 @@ func (v fakeTransactionView) FindLine(id string) (domain.Line, bool) {
 	return v.store.GetLine(id)
 }
 
+func (v fakeTransactionView) FindLinesByTag(...string) []domain.Line { return nil }
+
 func (v fakeTransactionView) FindStrain(id string) (domain.Strain, bool) {
 	return v.store.GetStrain(id)
 }
@@ -87,6 +87,9 @@ func TestDatasetTemplateRunSuccess(t *testing.T) {
 	if len(result.Schema) != 1 || result.Schema[0].Name != testSchemaValueColumn {
 		t.Fatalf("expected schema fallback from template, got %+v", result.Schema)
 	}
+	if hash := SchemaHash(template); hash == "" || result.Metadata[datasetapi.SchemaHashMetadataKey] != hash {
+		t.Fatalf("expected result metadata to carry schema hash %q, got %+v", hash, result.Metadata)
+	}
 	if !result.GeneratedAt.Equal(reference) {
 		t.Fatalf("expected generated timestamp %v, got %v", reference, result.GeneratedAt)
 	}
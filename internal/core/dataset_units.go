@@ -0,0 +1,31 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"colonycore/pkg/datasetapi"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+// applyFieldUnits fills in the unit of every column that projects a measured
+// property of the entity named by datasetapi.SourceEntityAnnotation. Columns
+// are copied so the plugin's template is never mutated.
+func applyFieldUnits(template datasetapi.Template) (datasetapi.Template, error) {
+	entity := strings.TrimSpace(template.Metadata.Annotations[datasetapi.SourceEntityAnnotation])
+	if entity == "" {
+		return template, nil
+	}
+	units, ok := entitymodel.FieldUnits()[entity]
+	if !ok {
+		return template, fmt.Errorf("unknown %s %q", datasetapi.SourceEntityAnnotation, entity)
+	}
+	columns := append([]datasetapi.Column(nil), template.Columns...)
+	for i := range columns {
+		if unit, ok := units[columns[i].Name]; ok && strings.TrimSpace(columns[i].Unit) == "" {
+			columns[i].Unit = unit
+		}
+	}
+	template.Columns = columns
+	return template, nil
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"colonycore/pkg/datasetapi"
+)
+
+func housingUnitsTemplate(entity string) DatasetTemplate {
+	return DatasetTemplate{
+		Plugin: testPluginFrog,
+		Template: datasetapi.Template{
+			Key:     "housing_units",
+			Version: "1.0.0",
+			Title:   "Housing units",
+			Dialect: DatasetDialectSQL,
+			Query:   "SELECT name, capacity, occupancy FROM housing_units",
+			Columns: []datasetapi.Column{
+				{Name: "name", Type: "string"},
+				{Name: "capacity", Type: "integer"},
+				{Name: "occupancy", Type: "integer", Unit: "animals"},
+			},
+			Metadata:      datasetapi.Metadata{Annotations: map[string]string{datasetapi.SourceEntityAnnotation: entity}},
+			OutputFormats: []datasetapi.Format{FormatCSV},
+			Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
+				return func(context.Context, datasetapi.RunRequest) (datasetapi.RunResult, error) {
+					return datasetapi.RunResult{}, nil
+				}, nil
+			},
+		},
+	}
+}
+
+func TestDatasetBindPropagatesFieldUnitsToCSVHeader(t *testing.T) {
+	template := housingUnitsTemplate("HousingUnit")
+	original := template.Columns
+	if err := template.bind(DatasetEnvironment{}); err != nil {
+		t.Fatalf("bind: %v", err)
+	}
+	if original[1].Unit != "" {
+		t.Fatalf("expected plugin columns to stay untouched, got %+v", original)
+	}
+
+	columns := newDatasetTemplateRuntime(template).Descriptor().Columns
+	if columns[1].Unit != "count" || columns[0].Unit != "" || columns[2].Unit != "animals" {
+		t.Fatalf("expected schema unit on capacity only, got %+v", columns)
+	}
+
+	var buf bytes.Buffer
+	if err := datasetapi.EncodeCSV(&buf, columns, slices.Values([][]any{{"Tank", 4, 2}})); err != nil {
+		t.Fatalf("encode csv: %v", err)
+	}
+	if header, _, _ := strings.Cut(buf.String(), "\n"); header != "# units: capacity=count,occupancy=animals" {
+		t.Fatalf("expected units header, got %q", header)
+	}
+}
+
+func TestDatasetBindRejectsUnknownSourceEntity(t *testing.T) {
+	template := housingUnitsTemplate("Aquarium")
+	if err := template.bind(DatasetEnvironment{}); err == nil || !strings.Contains(err.Error(), `unknown source.entity "Aquarium"`) {
+		t.Fatalf("expected unknown entity error, got %v", err)
+	}
+}
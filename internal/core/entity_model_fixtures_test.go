@@ -23,7 +23,9 @@ func TestEntityModelFixturesSatisfyRules(t *testing.T) {
 	}
 
 	memStore := NewMemoryStore(NewDefaultRulesEngine())
-	memStore.ImportState(memSnapshot)
+	if err := memStore.ImportState(memSnapshot); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 	memSnapshot = memStore.ExportState()
 
 	memChanges := changesFromMemorySnapshot(t, memSnapshot)
@@ -46,7 +48,9 @@ func TestEntityModelFixturesSatisfyRules(t *testing.T) {
 	if err := json.Unmarshal(fixtureBytes, &sqliteSnapshot); err != nil {
 		t.Fatalf("unmarshal sqlite snapshot: %v", err)
 	}
-	sqliteStore.ImportState(sqliteSnapshot)
+	if err := sqliteStore.ImportState(sqliteSnapshot); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 	sqliteSnapshot = sqliteStore.ExportState()
 
 	sqliteChanges := changesFromSQLiteSnapshot(t, sqliteSnapshot)
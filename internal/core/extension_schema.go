@@ -0,0 +1,354 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"colonycore/pkg/domain"
+	"colonycore/pkg/domain/extension"
+)
+
+// extensionSchema is the JSON Schema subset accepted for plugin extension
+// attributes. Schemas using keywords outside this subset, other than the
+// annotations in extensionSchemaAnnotations, are rejected at registration so a
+// constraint is never silently skipped.
+type extensionSchema struct {
+	Type                 string                      `json:"type"`
+	Properties           map[string]*extensionSchema `json:"properties"`
+	Required             []string                    `json:"required"`
+	AdditionalProperties *bool                       `json:"additionalProperties"`
+	Items                *extensionSchema            `json:"items"`
+	Enum                 []any                       `json:"enum"`
+	Minimum              *float64                    `json:"minimum"`
+	Maximum              *float64                    `json:"maximum"`
+	MinLength            *int                        `json:"minLength"`
+	MaxLength            *int                        `json:"maxLength"`
+}
+
+var extensionSchemaTypes = map[string]struct{}{
+	"":        {},
+	"object":  {},
+	"array":   {},
+	"string":  {},
+	"number":  {},
+	"integer": {},
+	"boolean": {},
+	"null":    {},
+}
+
+// extensionSchemaKeywords lists the keywords extensionSchema enforces.
+var extensionSchemaKeywords = map[string]struct{}{
+	"type":                 {},
+	"properties":           {},
+	"required":             {},
+	"additionalProperties": {},
+	"items":                {},
+	"enum":                 {},
+	"minimum":              {},
+	"maximum":              {},
+	"minLength":            {},
+	"maxLength":            {},
+}
+
+// extensionSchemaAnnotations lists keywords that carry no constraint and are
+// accepted without being enforced.
+var extensionSchemaAnnotations = map[string]struct{}{
+	"$schema":     {},
+	"$id":         {},
+	"$comment":    {},
+	"title":       {},
+	"description": {},
+	"default":     {},
+	"examples":    {},
+}
+
+func parseExtensionSchema(raw json.RawMessage) (*extensionSchema, error) {
+	var schema extensionSchema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, fmt.Errorf("parse extension schema: %w", err)
+	}
+	if err := checkExtensionSchemaKeywords(raw, ""); err != nil {
+		return nil, err
+	}
+	if err := schema.check(""); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// checkExtensionSchemaKeywords rejects keywords extensionSchema does not
+// enforce, walking nested properties and items schemas.
+func checkExtensionSchemaKeywords(raw json.RawMessage, path string) error {
+	var keywords map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &keywords); err != nil {
+		return fmt.Errorf("parse extension schema %s: %w", displayPath(path), err)
+	}
+	names := make([]string, 0, len(keywords))
+	for name := range keywords {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		_, enforced := extensionSchemaKeywords[name]
+		_, annotation := extensionSchemaAnnotations[name]
+		if !enforced && !annotation {
+			return fmt.Errorf("extension schema %s: unsupported keyword %q", displayPath(path), name)
+		}
+	}
+	if items, ok := keywords["items"]; ok && string(items) != "null" {
+		if err := checkExtensionSchemaKeywords(items, path+"/items"); err != nil {
+			return err
+		}
+	}
+	raw, ok := keywords["properties"]
+	if !ok {
+		return nil
+	}
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &properties); err != nil {
+		return fmt.Errorf("parse extension schema %s: %w", displayPath(path), err)
+	}
+	names = names[:0]
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if string(properties[name]) == "null" {
+			continue
+		}
+		if err := checkExtensionSchemaKeywords(properties[name], path+"/"+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *extensionSchema) check(path string) error {
+	if s == nil {
+		return nil
+	}
+	if _, ok := extensionSchemaTypes[s.Type]; !ok {
+		return fmt.Errorf("extension schema %s: unsupported type %q", displayPath(path), s.Type)
+	}
+	for name, prop := range s.Properties {
+		if err := prop.check(path + "/" + name); err != nil {
+			return err
+		}
+	}
+	return s.Items.check(path + "/items")
+}
+
+// ValidationError describes an extension attribute value that does not
+// satisfy the schema registered for its entity and namespace.
+type ValidationError struct {
+	Entity    string
+	Namespace string
+	Path      string
+	Message   string
+}
+
+// Error implements error.
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s extension %s %s: %s", e.Entity, e.Namespace, displayPath(e.Path), e.Message)
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// validate appends a ValidationError for each constraint the value violates.
+func (s *extensionSchema) validate(path string, value any, report func(path, message string)) {
+	if s == nil {
+		return
+	}
+	if len(s.Enum) > 0 && !enumContains(s.Enum, value) {
+		report(path, fmt.Sprintf("value %v is not one of the allowed values", value))
+		return
+	}
+	switch s.Type {
+	case "":
+	case "null":
+		if value != nil {
+			report(path, "expected null")
+		}
+		return
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			report(path, "expected boolean")
+		}
+		return
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			report(path, "expected string")
+			return
+		}
+		length := len([]rune(str))
+		if s.MinLength != nil && length < *s.MinLength {
+			report(path, fmt.Sprintf("length %d is below minLength %d", length, *s.MinLength))
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			report(path, fmt.Sprintf("length %d exceeds maxLength %d", length, *s.MaxLength))
+		}
+		return
+	case "number", "integer":
+		num, ok := value.(float64)
+		if !ok {
+			report(path, "expected "+s.Type)
+			return
+		}
+		if s.Type == "integer" && num != float64(int64(num)) {
+			report(path, "expected integer")
+			return
+		}
+		if s.Minimum != nil && num < *s.Minimum {
+			report(path, fmt.Sprintf("value %v is below minimum %v", num, *s.Minimum))
+		}
+		if s.Maximum != nil && num > *s.Maximum {
+			report(path, fmt.Sprintf("value %v exceeds maximum %v", num, *s.Maximum))
+		}
+		return
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			report(path, "expected array")
+			return
+		}
+		for i, item := range items {
+			s.Items.validate(fmt.Sprintf("%s/%d", path, i), item, report)
+		}
+		return
+	case "object":
+		if _, ok := value.(map[string]any); !ok {
+			report(path, "expected object")
+			return
+		}
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return
+	}
+	for _, name := range s.Required {
+		if _, present := object[name]; !present {
+			report(path+"/"+name, "is required")
+		}
+	}
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		prop, declared := s.Properties[key]
+		if !declared {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				report(path+"/"+key, "is not allowed")
+			}
+			continue
+		}
+		prop.validate(path+"/"+key, object[key], report)
+	}
+}
+
+func enumContains(values []any, value any) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateExtensionAttributes checks attrs, keyed by plugin namespace, against
+// every extension schema registered for entity. Namespaces without a schema
+// and entities without any registered schema are unconstrained.
+func (s *Service) ValidateExtensionAttributes(entity string, attrs map[string]any) []ValidationError {
+	s.mu.RLock()
+	schemas := s.extensionSchemas[entity]
+	s.mu.RUnlock()
+	if len(schemas) == 0 || len(attrs) == 0 {
+		return nil
+	}
+	normalized, err := normalizeExtensionAttributes(attrs)
+	if err != nil {
+		return []ValidationError{{Entity: entity, Message: err.Error()}}
+	}
+	namespaces := make([]string, 0, len(schemas))
+	for namespace := range schemas {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	var out []ValidationError
+	for _, namespace := range namespaces {
+		payload, ok := normalized[namespace]
+		if !ok {
+			continue
+		}
+		schemas[namespace].validate("", payload, func(path, message string) {
+			out = append(out, ValidationError{Entity: entity, Namespace: namespace, Path: path, Message: message})
+		})
+	}
+	return out
+}
+
+// normalizeExtensionAttributes round-trips attrs through JSON so schema checks
+// see the same numbers, arrays, and objects a persisted payload would hold.
+func normalizeExtensionAttributes(attrs map[string]any) (map[string]any, error) {
+	data, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, fmt.Errorf("encode extension attributes: %w", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("decode extension attributes: %w", err)
+	}
+	return out, nil
+}
+
+// validateOrganismExtensions applies registered organism extension schemas to
+// the organism's attribute namespaces.
+func (s *Service) validateOrganismExtensions(organism domain.Organism) error {
+	s.mu.RLock()
+	constrained := len(s.extensionSchemas[string(domain.EntityOrganism)]) > 0
+	s.mu.RUnlock()
+	if !constrained {
+		return nil
+	}
+	container, err := organism.OrganismExtensions()
+	if err != nil {
+		return err
+	}
+	attrs := container.Raw()[string(extension.HookOrganismAttributes)]
+	return joinValidationErrors(s.ValidateExtensionAttributes(string(domain.EntityOrganism), attrs))
+}
+
+func joinValidationErrors(violations []ValidationError) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	errs := make([]error, len(violations))
+	for i, violation := range violations {
+		errs[i] = violation
+	}
+	return fmt.Errorf("extension attributes invalid: %w", errors.Join(errs...))
+}
+
+// checkExtensionSchemaConflicts rejects schemas already registered by another plugin
+// for the same entity and namespace.
+func (s *Service) checkExtensionSchemaConflicts(schemas map[string]map[string]*extensionSchema) error {
+	for entity, namespaces := range schemas {
+		for namespace := range namespaces {
+			if _, exists := s.extensionSchemas[entity][namespace]; exists {
+				return fmt.Errorf("extension schema for %s namespace %s already registered", entity, namespace)
+			}
+		}
+	}
+	return nil
+}
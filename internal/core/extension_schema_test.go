@@ -0,0 +1,168 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"colonycore/pkg/domain"
+	"colonycore/pkg/domain/entitymodel"
+	"colonycore/pkg/domain/extension"
+)
+
+const frogExtensionSchema = `{
+	"type": "object",
+	"required": ["clutch"],
+	"additionalProperties": false,
+	"properties": {
+		"clutch": {"type": "integer", "minimum": 1},
+		"morph": {"type": "string", "enum": ["albino", "wild"]},
+		"tags": {"type": "array", "items": {"type": "string", "maxLength": 4}}
+	}
+}`
+
+func newExtensionSchemaService(t *testing.T) *Service {
+	t.Helper()
+	svc := NewInMemoryService(NewRulesEngine())
+	plugin := simplePlugin{name: "frog", version: "1.0.0", register: func(reg *PluginRegistry) error {
+		return reg.RegisterExtensionSchema(string(domain.EntityOrganism), "frog", json.RawMessage(frogExtensionSchema))
+	}}
+	if _, err := svc.InstallPlugin(plugin); err != nil {
+		t.Fatalf("install schema plugin: %v", err)
+	}
+	return svc
+}
+
+func TestPluginRegistryRegisterExtensionSchema(t *testing.T) {
+	reg := NewPluginRegistry()
+	if err := reg.RegisterExtensionSchema("", "frog", json.RawMessage(`{}`)); err == nil {
+		t.Fatalf("expected error for missing entity")
+	}
+	if err := reg.RegisterExtensionSchema("organism", "frog", nil); err == nil {
+		t.Fatalf("expected error for empty schema")
+	}
+	if err := reg.RegisterExtensionSchema("organism", "frog", json.RawMessage(`{"type":`)); err == nil {
+		t.Fatalf("expected error for malformed schema")
+	}
+	if err := reg.RegisterExtensionSchema("organism", "frog", json.RawMessage(`{"type":"tuple"}`)); err == nil {
+		t.Fatalf("expected error for unsupported type")
+	}
+	if err := reg.RegisterExtensionSchema("organism", "frog", json.RawMessage(frogExtensionSchema)); err != nil {
+		t.Fatalf("register schema: %v", err)
+	}
+	if err := reg.RegisterExtensionSchema("organism", "frog", json.RawMessage(frogExtensionSchema)); err == nil {
+		t.Fatalf("expected duplicate namespace error")
+	}
+}
+
+func TestRegisterExtensionSchemaRejectsUnsupportedKeywords(t *testing.T) {
+	cases := map[string]string{
+		"top level":       `{"type":"string","pattern":"^[a-z]+$"}`,
+		"nested property": `{"type":"object","properties":{"clutch":{"type":"integer","multipleOf":2}}}`,
+		"array items":     `{"type":"array","items":{"type":"string","format":"date"}}`,
+		"combinator":      `{"oneOf":[{"type":"string"},{"type":"number"}]}`,
+	}
+	for name, schema := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewPluginRegistry().RegisterExtensionSchema("organism", "frog", json.RawMessage(schema))
+			if err == nil || !strings.Contains(err.Error(), "unsupported keyword") {
+				t.Fatalf("expected unsupported keyword error, got %v", err)
+			}
+		})
+	}
+	annotated := `{"$schema":"https://json-schema.org/draft/2020-12/schema","title":"Frog","description":"frog traits","type":"object","properties":{"clutch":{"type":"integer","default":1}}}`
+	if err := NewPluginRegistry().RegisterExtensionSchema("organism", "frog", json.RawMessage(annotated)); err != nil {
+		t.Fatalf("expected annotation keywords to be accepted, got %v", err)
+	}
+}
+
+func TestValidateExtensionAttributes(t *testing.T) {
+	svc := newExtensionSchemaService(t)
+	entity := string(domain.EntityOrganism)
+
+	compliant := map[string]any{"frog": map[string]any{"clutch": 3, "morph": "wild", "tags": []string{"a1"}}}
+	if errs := svc.ValidateExtensionAttributes(entity, compliant); len(errs) != 0 {
+		t.Fatalf("expected compliant attributes to pass, got %v", errs)
+	}
+
+	invalid := map[string]any{"frog": map[string]any{"clutch": 0.5, "morph": "blue", "tags": []string{"toolong"}, "extra": true}}
+	errs := svc.ValidateExtensionAttributes(entity, invalid)
+	paths := make([]string, 0, len(errs))
+	for _, e := range errs {
+		if e.Entity != entity || e.Namespace != "frog" {
+			t.Fatalf("unexpected error scope %+v", e)
+		}
+		paths = append(paths, e.Path)
+	}
+	if got := strings.Join(paths, ","); got != "/clutch,/extra,/morph,/tags/0" {
+		t.Fatalf("expected violations for each bad field, got %v", errs)
+	}
+
+	if errs := svc.ValidateExtensionAttributes(entity, map[string]any{"frog": map[string]any{}}); len(errs) != 1 || errs[0].Path != "/clutch" {
+		t.Fatalf("expected missing required field, got %v", errs)
+	}
+	if errs := svc.ValidateExtensionAttributes(entity, map[string]any{"other": "anything"}); len(errs) != 0 {
+		t.Fatalf("expected unregistered namespace to be unconstrained, got %v", errs)
+	}
+	if errs := svc.ValidateExtensionAttributes(string(domain.EntityCohort), invalid); errs != nil {
+		t.Fatalf("expected unregistered entity to be unconstrained, got %v", errs)
+	}
+}
+
+func TestInstallPluginRejectsDuplicateExtensionSchema(t *testing.T) {
+	svc := newExtensionSchemaService(t)
+	plugin := simplePlugin{name: "frog-copy", version: "1.0.0", register: func(reg *PluginRegistry) error {
+		return reg.RegisterExtensionSchema(string(domain.EntityOrganism), "frog", json.RawMessage(`{"type":"object"}`))
+	}}
+	if _, err := svc.InstallPlugin(plugin); err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Fatalf("expected duplicate schema error, got %v", err)
+	}
+}
+
+func TestOrganismWritesValidateExtensionSchemas(t *testing.T) {
+	svc := newExtensionSchemaService(t)
+	ctx := context.Background()
+	withAttrs := func(attrs map[string]any) domain.Organism {
+		organism := domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus", Stage: domain.StageAdult}}
+		container, err := organism.OrganismExtensions()
+		if err != nil {
+			t.Fatalf("extensions: %v", err)
+		}
+		if err := container.Set(extension.HookOrganismAttributes, "frog", attrs); err != nil {
+			t.Fatalf("set attributes: %v", err)
+		}
+		if err := organism.SetOrganismExtensions(container); err != nil {
+			t.Fatalf("set extensions: %v", err)
+		}
+		return organism
+	}
+
+	if _, _, err := svc.CreateOrganism(ctx, withAttrs(map[string]any{"morph": "wild"})); err == nil || !strings.Contains(err.Error(), "/clutch") {
+		t.Fatalf("expected create to reject missing clutch, got %v", err)
+	}
+	created, _, err := svc.CreateOrganism(ctx, withAttrs(map[string]any{"clutch": 2}))
+	if err != nil {
+		t.Fatalf("create compliant organism: %v", err)
+	}
+
+	_, _, err = svc.UpdateOrganism(ctx, created.ID, func(o *domain.Organism) error {
+		container, err := o.OrganismExtensions()
+		if err != nil {
+			return err
+		}
+		if err := container.Set(extension.HookOrganismAttributes, "frog", map[string]any{"clutch": -1}); err != nil {
+			return err
+		}
+		return o.SetOrganismExtensions(container)
+	})
+	if err == nil || !strings.Contains(err.Error(), "below minimum") {
+		t.Fatalf("expected update to reject clutch below minimum, got %v", err)
+	}
+	if _, _, err := svc.UpdateOrganism(ctx, created.ID, func(o *domain.Organism) error {
+		o.Name = "Renamed"
+		return nil
+	}); err != nil {
+		t.Fatalf("expected compliant update to pass, got %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package core
+
+import "colonycore/pkg/domain"
+
+// WithAttachmentCascadeDelete makes DeleteObservation also delete the blobs
+// attached to the observation. Blob deletion runs after the observation removal
+// commits; failures are logged and leave the blob in place.
+func WithAttachmentCascadeDelete(enabled bool) ServiceOption {
+	return func(opts *serviceOptions) {
+		opts.cascadeAttachments = enabled
+	}
+}
+
+// attachmentBlobProvider is implemented by stores configured with an
+// attachment blob store.
+type attachmentBlobProvider interface {
+	AttachmentBlobs() domain.AttachmentBlobStore
+}
+
+func (s *Service) deleteAttachmentBlobs(observationID string, attachments []domain.AttachmentRef) {
+	if len(attachments) == 0 {
+		return
+	}
+	provider, ok := s.store.(attachmentBlobProvider)
+	if !ok || provider.AttachmentBlobs() == nil {
+		s.logger.Warn("attachment cascade skipped: no blob store", "observation", observationID, "attachments", len(attachments))
+		return
+	}
+	blobs := provider.AttachmentBlobs()
+	for _, attachment := range attachments {
+		if err := blobs.Delete(attachment.Key); err != nil {
+			s.logger.Warn("attachment blob delete failed", "observation", observationID, "key", attachment.Key, "error", err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"colonycore/internal/blob"
+	"colonycore/internal/infra/persistence/memory"
+	"colonycore/pkg/domain"
+	"colonycore/pkg/domain/entitymodel"
+)
+
+func newAttachmentTestService(t *testing.T, cascade bool) (*Service, blob.Store, string) {
+	t.Helper()
+	ctx := context.Background()
+	blobs := blob.NewMemory()
+	if _, err := blobs.Put(ctx, "obs/scan.tiff", bytes.NewReader([]byte("scan-bytes")), blob.PutOptions{ContentType: "image/tiff"}); err != nil {
+		t.Fatalf("put blob: %v", err)
+	}
+	store := memory.NewStore(NewRulesEngine(), memory.WithAttachmentBlobs(blob.NewAttachmentBlobs(blobs)))
+	svc := NewService(store, WithAttachmentCascadeDelete(cascade))
+	organism, _, err := svc.CreateOrganism(ctx, domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus", Stage: domain.StageAdult}})
+	if err != nil {
+		t.Fatalf("create organism: %v", err)
+	}
+	observation, _, err := svc.CreateObservation(ctx, domain.Observation{Observation: entitymodel.Observation{
+		OrganismID: &organism.ID, Observer: "tech", RecordedAt: time.Now(),
+	}})
+	if err != nil {
+		t.Fatalf("create observation: %v", err)
+	}
+	return svc, blobs, observation.ID
+}
+
+func TestServiceAttachObservationFile(t *testing.T) {
+	ctx := context.Background()
+	svc, _, observationID := newAttachmentTestService(t, false)
+
+	attached, _, err := svc.AttachObservationFile(ctx, observationID, domain.AttachmentRef{Key: "obs/scan.tiff"})
+	if err != nil {
+		t.Fatalf("attach existing blob: %v", err)
+	}
+	if len(attached.Attachments) != 1 || attached.Attachments[0].ContentType != "image/tiff" || attached.Attachments[0].SizeBytes != len("scan-bytes") {
+		t.Fatalf("unexpected attachments %+v", attached.Attachments)
+	}
+	if _, _, err := svc.AttachObservationFile(ctx, observationID, domain.AttachmentRef{Key: "obs/absent.tiff"}); err == nil {
+		t.Fatalf("expected missing blob to be rejected")
+	}
+}
+
+func TestServiceDeleteObservationAttachmentCascade(t *testing.T) {
+	ctx := context.Background()
+	for _, cascade := range []bool{false, true} {
+		svc, blobs, observationID := newAttachmentTestService(t, cascade)
+		if _, _, err := svc.AttachObservationFile(ctx, observationID, domain.AttachmentRef{Key: "obs/scan.tiff"}); err != nil {
+			t.Fatalf("attach: %v", err)
+		}
+		if _, err := svc.DeleteObservation(ctx, observationID); err != nil {
+			t.Fatalf("delete observation: %v", err)
+		}
+		_, err := blobs.Head(ctx, "obs/scan.tiff")
+		if cascade && err == nil {
+			t.Fatalf("expected blob removed when cascade enabled")
+		}
+		if !cascade && err != nil {
+			t.Fatalf("expected blob retained without cascade: %v", err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"colonycore/pkg/domain"
+)
+
+// observationCategorySet holds the observation categories allowed by every
+// taxonomy installed on a service.
+type observationCategorySet struct {
+	mu      sync.RWMutex
+	allowed map[string]struct{}
+}
+
+func (c *observationCategorySet) add(categories []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.allowed == nil {
+		c.allowed = make(map[string]struct{}, len(categories))
+	}
+	for _, category := range categories {
+		c.allowed[category] = struct{}{}
+	}
+}
+
+// check rejects a non-empty category that no installed taxonomy allows.
+func (c *observationCategorySet) check(category string) error {
+	if category == "" {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if _, ok := c.allowed[category]; ok {
+		return nil
+	}
+	allowed := make([]string, 0, len(c.allowed))
+	for name := range c.allowed {
+		allowed = append(allowed, name)
+	}
+	sort.Strings(allowed)
+	return fmt.Errorf("observation category %q is not allowed; expected one of [%s]", category, strings.Join(allowed, ", "))
+}
+
+// observationCategoryRule blocks observations created with, or updated to, a
+// category that no installed taxonomy allows. Running as a rule keeps the
+// check on every transaction the store evaluates, not just Service writes.
+// Updates that leave the category unchanged pass so records stored before a
+// taxonomy was installed stay editable.
+type observationCategoryRule struct {
+	categories *observationCategorySet
+}
+
+func (observationCategoryRule) Name() string { return "observation_category" }
+
+func (r observationCategoryRule) Evaluate(_ context.Context, _ domain.RuleView, changes []domain.Change) (domain.Result, error) {
+	res := domain.Result{}
+	for _, change := range changes {
+		if change.Entity != domain.EntityObservation || (change.Action != domain.ActionCreate && change.Action != domain.ActionUpdate) {
+			continue
+		}
+		observation, ok := decodeChangePayload[domain.Observation](change.After)
+		if !ok {
+			continue
+		}
+		category := observationCategory(observation)
+		if change.Action == domain.ActionUpdate {
+			if before, ok := decodeChangePayload[domain.Observation](change.Before); ok && observationCategory(before) == category {
+				continue
+			}
+		}
+		if err := r.categories.check(category); err != nil {
+			res.Violations = append(res.Violations, domain.Violation{
+				Rule:     r.Name(),
+				Severity: domain.SeverityBlock,
+				Message:  err.Error(),
+				Entity:   domain.EntityObservation,
+				EntityID: observation.ID,
+			})
+		}
+	}
+	return res, nil
+}
+
+func observationCategory(observation domain.Observation) string {
+	if observation.Category == nil {
+		return ""
+	}
+	return *observation.Category
+}
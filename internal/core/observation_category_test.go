@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	"colonycore/pkg/domain/entitymodel"
+	"colonycore/pkg/pluginapi"
+)
+
+type staticCategoryTaxonomy []string
+
+func (t staticCategoryTaxonomy) AllowedCategories() []string { return t }
+
+func newCategoryTestService(t *testing.T, taxonomies ...pluginapi.ObservationCategoryTaxonomy) (*Service, func(category *string) error) {
+	t.Helper()
+	svc := NewInMemoryService(NewRulesEngine())
+	for i, taxonomy := range taxonomies {
+		taxonomy := taxonomy
+		plugin := simplePlugin{name: "taxonomy-" + string(rune('a'+i)), version: "1.0.0", register: func(reg *PluginRegistry) error {
+			return reg.RegisterObservationCategoryTaxonomy(taxonomy)
+		}}
+		if _, err := svc.InstallPlugin(plugin); err != nil {
+			t.Fatalf("install taxonomy plugin: %v", err)
+		}
+	}
+	ctx := context.Background()
+	organism, _, err := svc.CreateOrganism(ctx, domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus", Stage: domain.StageAdult}})
+	if err != nil {
+		t.Fatalf("create organism: %v", err)
+	}
+	create := func(category *string) error {
+		_, _, err := svc.CreateObservation(ctx, domain.Observation{Observation: entitymodel.Observation{
+			OrganismID: &organism.ID, Observer: "tech", RecordedAt: time.Now(), Category: category,
+		}})
+		return err
+	}
+	return svc, create
+}
+
+func TestPluginRegistryObservationCategoryTaxonomy(t *testing.T) {
+	reg := NewPluginRegistry()
+	if _, ok := reg.ObservationCategories(); ok {
+		t.Fatalf("expected no taxonomy before registration")
+	}
+	if err := reg.RegisterObservationCategoryTaxonomy(nil); err == nil {
+		t.Fatalf("expected error for nil taxonomy")
+	}
+	if err := reg.RegisterObservationCategoryTaxonomy(staticCategoryTaxonomy{"health", "behaviour"}); err != nil {
+		t.Fatalf("register taxonomy: %v", err)
+	}
+	if err := reg.RegisterObservationCategoryTaxonomy(staticCategoryTaxonomy{"health", "growth"}); err != nil {
+		t.Fatalf("register second taxonomy: %v", err)
+	}
+	categories, ok := reg.ObservationCategories()
+	if !ok || strings.Join(categories, ",") != "behaviour,growth,health" {
+		t.Fatalf("expected merged sorted categories, got %v (registered=%v)", categories, ok)
+	}
+}
+
+func TestCreateObservationAcceptsAllowedCategory(t *testing.T) {
+	_, create := newCategoryTestService(t, staticCategoryTaxonomy{"health", "growth"})
+	category := "growth"
+	if err := create(&category); err != nil {
+		t.Fatalf("expected allowed category to pass, got %v", err)
+	}
+}
+
+func TestCreateObservationRejectsUnknownCategory(t *testing.T) {
+	_, create := newCategoryTestService(t, staticCategoryTaxonomy{"health"}, staticCategoryTaxonomy{"growth"})
+	category := "weather"
+	var violation domain.RuleViolationError
+	if err := create(&category); !errors.As(err, &violation) {
+		t.Fatalf("expected category rejection, got %v", err)
+	}
+	if len(violation.Result.Violations) != 1 {
+		t.Fatalf("expected one violation, got %+v", violation.Result.Violations)
+	}
+	message := violation.Result.Violations[0].Message
+	if !strings.Contains(message, `"weather"`) || !strings.Contains(message, "growth, health") {
+		t.Fatalf("expected category rejection listing allowed values, got %q", message)
+	}
+}
+
+func TestObservationCategoryEnforcedOnStoreWrites(t *testing.T) {
+	svc, create := newCategoryTestService(t, staticCategoryTaxonomy{"health"})
+	health := "health"
+	if err := create(&health); err != nil {
+		t.Fatalf("create observation: %v", err)
+	}
+	observations := svc.Store().ListObservations()
+	if len(observations) != 1 {
+		t.Fatalf("expected one observation, got %d", len(observations))
+	}
+	id := observations[0].ID
+	update := func(category string) error {
+		_, err := svc.Store().RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+			_, err := tx.UpdateObservation(id, func(o *domain.Observation) error {
+				o.Category = &category
+				return nil
+			})
+			return err
+		})
+		return err
+	}
+	var violation domain.RuleViolationError
+	if err := update("weather"); !errors.As(err, &violation) {
+		t.Fatalf("expected direct store update to an unknown category to be blocked, got %v", err)
+	}
+	if err := update("health"); err != nil {
+		t.Fatalf("expected update keeping the category to pass, got %v", err)
+	}
+}
+
+func TestCreateObservationAllowsEmptyCategoryWithoutEmptyEntry(t *testing.T) {
+	_, create := newCategoryTestService(t, staticCategoryTaxonomy{"health"})
+	empty := ""
+	if err := create(&empty); err != nil {
+		t.Fatalf("expected empty category to pass, got %v", err)
+	}
+	if err := create(nil); err != nil {
+		t.Fatalf("expected missing category to pass, got %v", err)
+	}
+}
+
+func TestCreateObservationWithoutTaxonomyAcceptsAnyCategory(t *testing.T) {
+	_, create := newCategoryTestService(t)
+	category := "anything"
+	if err := create(&category); err != nil {
+		t.Fatalf("expected any category without taxonomy, got %v", err)
+	}
+}
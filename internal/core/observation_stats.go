@@ -0,0 +1,125 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ObservationStats summarises one numeric metric recorded in the structured
+// data of a procedure's observations.
+//
+// Count covers only observations whose Data[metric] holds a finite number;
+// Min, Max, Mean, and StdDev (the population standard deviation) are computed
+// over those values and are zero when Count is zero. Observations without the
+// metric are tallied in Missing. Values that are present but not numeric, such
+// as strings, booleans, nested objects, NaN, or ±Inf, are tallied in
+// NonNumeric instead of failing the aggregation; numeric strings like "12.5"
+// are deliberately not parsed.
+type ObservationStats struct {
+	ProcedureID string  `json:"procedure_id"`
+	Metric      string  `json:"metric"`
+	Count       int     `json:"count"`
+	Missing     int     `json:"missing"`
+	NonNumeric  int     `json:"non_numeric"`
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+	Mean        float64 `json:"mean"`
+	StdDev      float64 `json:"stddev"`
+}
+
+// AggregateObservations scans the observations recorded against procedureID
+// and summarises the numeric values stored under metric in their structured
+// data. It returns an error when the metric is blank or the procedure does not
+// exist.
+func (s *Service) AggregateObservations(procedureID string, metric string) (ObservationStats, error) {
+	metric = strings.TrimSpace(metric)
+	if metric == "" {
+		return ObservationStats{}, errors.New("aggregate observations: metric is required")
+	}
+	found := false
+	for _, procedure := range s.store.ListProcedures() {
+		if procedure.ID == procedureID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ObservationStats{}, fmt.Errorf("aggregate observations: procedure %q not found", procedureID)
+	}
+
+	stats := ObservationStats{ProcedureID: procedureID, Metric: metric}
+	var values []float64
+	for _, observation := range s.store.ListObservations() {
+		if observation.ProcedureID == nil || *observation.ProcedureID != procedureID {
+			continue
+		}
+		raw, ok := observation.ObservationData()[metric]
+		if !ok || raw == nil {
+			stats.Missing++
+			continue
+		}
+		value, ok := observationNumber(raw)
+		if !ok {
+			stats.NonNumeric++
+			continue
+		}
+		values = append(values, value)
+	}
+	stats.Count = len(values)
+	if stats.Count == 0 {
+		return stats, nil
+	}
+	stats.Min, stats.Max = values[0], values[0]
+	var sum float64
+	for _, value := range values {
+		stats.Min = math.Min(stats.Min, value)
+		stats.Max = math.Max(stats.Max, value)
+		sum += value
+	}
+	stats.Mean = sum / float64(stats.Count)
+	var squares float64
+	for _, value := range values {
+		squares += (value - stats.Mean) * (value - stats.Mean)
+	}
+	stats.StdDev = math.Sqrt(squares / float64(stats.Count))
+	return stats, nil
+}
+
+// observationNumber converts a decoded observation data value to a finite
+// float64, reporting false for anything that is not a number.
+func observationNumber(raw any) (float64, bool) {
+	var value float64
+	switch v := raw.(type) {
+	case float64:
+		value = v
+	case float32:
+		value = float64(v)
+	case int:
+		value = float64(v)
+	case int32:
+		value = float64(v)
+	case int64:
+		value = float64(v)
+	case uint:
+		value = float64(v)
+	case uint32:
+		value = float64(v)
+	case uint64:
+		value = float64(v)
+	case json.Number:
+		parsed, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+		value = parsed
+	default:
+		return 0, false
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestAggregateObservationsSummarisesNumericMetric(t *testing.T) {
+	svc := NewInMemoryService(nil)
+	ctx := context.Background()
+	protocol, _, err := svc.CreateProtocol(ctx, domain.Protocol{Protocol: entitymodel.Protocol{Code: "AGG", Title: "Aggregation", MaxSubjects: 5}})
+	if err != nil {
+		t.Fatalf("create protocol: %v", err)
+	}
+	newProcedure := func(name string) string {
+		procedure, _, err := svc.CreateProcedure(ctx, domain.Procedure{Procedure: entitymodel.Procedure{
+			Name: name, ProtocolID: protocol.ID, Status: domain.ProcedureStatusScheduled, ScheduledAt: time.Now(),
+		}})
+		if err != nil {
+			t.Fatalf("create procedure: %v", err)
+		}
+		return procedure.ID
+	}
+	target, other := newProcedure("Weigh-in"), newProcedure("Other")
+	record := func(procedureID string, data map[string]any) {
+		observation := domain.Observation{Observation: entitymodel.Observation{ProcedureID: &procedureID, Observer: "tech", RecordedAt: time.Now()}}
+		if err := observation.ApplyObservationData(data); err != nil {
+			t.Fatalf("apply data: %v", err)
+		}
+		if _, _, err := svc.CreateObservation(ctx, observation); err != nil {
+			t.Fatalf("create observation: %v", err)
+		}
+	}
+	record(target, map[string]any{"weight_g": 10})
+	record(target, map[string]any{"weight_g": 20.0})
+	record(target, map[string]any{"weight_g": 30.0, "note": "ok"})
+	record(target, map[string]any{"length_mm": 4.0})
+	record(target, map[string]any{"weight_g": "heavy"})
+	record(target, map[string]any{"weight_g": true})
+	record(other, map[string]any{"weight_g": 1000.0})
+
+	stats, err := svc.AggregateObservations(target, "weight_g")
+	if err != nil {
+		t.Fatalf("aggregate: %v", err)
+	}
+	if stats.Count != 3 || stats.Missing != 1 || stats.NonNumeric != 2 {
+		t.Fatalf("unexpected counts: %+v", stats)
+	}
+	if stats.Min != 10 || stats.Max != 30 || stats.Mean != 20 {
+		t.Fatalf("unexpected min/max/mean: %+v", stats)
+	}
+	if want := math.Sqrt(200.0 / 3); math.Abs(stats.StdDev-want) > 1e-9 {
+		t.Fatalf("expected stddev %v, got %v", want, stats.StdDev)
+	}
+
+	empty, err := svc.AggregateObservations(target, "temperature_c")
+	if err != nil {
+		t.Fatalf("aggregate absent metric: %v", err)
+	}
+	if empty.Count != 0 || empty.Missing != 6 || empty.Mean != 0 {
+		t.Fatalf("expected every observation missing the metric, got %+v", empty)
+	}
+
+	if _, err := svc.AggregateObservations("missing", "weight_g"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected unknown procedure error, got %v", err)
+	}
+	if _, err := svc.AggregateObservations(target, " "); err == nil {
+		t.Fatalf("expected blank metric error")
+	}
+}
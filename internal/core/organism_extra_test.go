@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"colonycore/pkg/domain"
@@ -58,3 +59,24 @@ func TestOrganism_UpdateDeleteBranches(t *testing.T) {
 		t.Fatalf("delete missing: %v", err)
 	}
 }
+
+func TestServiceUpdateOrganismIfVersionRejectsStaleVersion(t *testing.T) {
+	svc := NewService(NewMemoryStore(NewDefaultRulesEngine()))
+	ctx := context.Background()
+	org, _, err := svc.CreateOrganism(ctx, domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "frog", Stage: domain.StageAdult}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	rename := func(o *domain.Organism) error { o.Name = "Renamed"; return nil }
+	if _, _, err := svc.UpdateOrganism(ctx, org.ID, rename); err != nil {
+		t.Fatalf("unchecked update: %v", err)
+	}
+	var conflict domain.ErrVersionConflict
+	if _, _, err := svc.UpdateOrganismIfVersion(ctx, org.ID, org.Version, rename); !errors.As(err, &conflict) || conflict.Expected != 1 || conflict.Actual != 2 {
+		t.Fatalf("expected version conflict against version 2, got %v", err)
+	}
+	updated, _, err := svc.UpdateOrganismIfVersion(ctx, org.ID, 2, rename)
+	if err != nil || updated.Version != 3 {
+		t.Fatalf("expected matching version to update to 3, got %+v (%v)", updated, err)
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 
@@ -14,9 +15,23 @@ type PluginRegistry struct {
 	rules    []domain.Rule
 	schemas  map[string]map[string]any
 	datasets map[string]DatasetTemplate
+	// categories is nil until a plugin registers an observation category taxonomy.
+	categories map[string]struct{}
+	// extensionSchemas holds parsed attribute schemas keyed by entity then namespace.
+	extensionSchemas map[string]map[string]*extensionSchema
+	datasetService   pluginapi.DatasetService
+	auditEmitters    []pluginapi.AuditEmitter
+	readModel        pluginapi.ReadModel
 }
 
-var _ pluginapi.Registry = (*PluginRegistry)(nil)
+var (
+	_ pluginapi.Registry                         = (*PluginRegistry)(nil)
+	_ pluginapi.ObservationCategoryRegistry      = (*PluginRegistry)(nil)
+	_ pluginapi.ExtensionAttributeSchemaRegistry = (*PluginRegistry)(nil)
+	_ pluginapi.DatasetServiceRegistry           = (*PluginRegistry)(nil)
+	_ pluginapi.AuditEmitterRegistry             = (*PluginRegistry)(nil)
+	_ pluginapi.ReadModelProvider                = (*PluginRegistry)(nil)
+)
 
 // NewPluginRegistry constructs a plugin registry.
 func NewPluginRegistry() *PluginRegistry {
@@ -64,6 +79,91 @@ func (r *PluginRegistry) RegisterDatasetTemplate(template datasetapi.Template) e
 	return nil
 }
 
+// RegisterObservationCategoryTaxonomy records the observation categories
+// allowed by the plugin. Multiple taxonomies contribute to a single allowed set.
+func (r *PluginRegistry) RegisterObservationCategoryTaxonomy(taxonomy pluginapi.ObservationCategoryTaxonomy) error {
+	if taxonomy == nil {
+		return fmt.Errorf("observation category taxonomy nil")
+	}
+	if r.categories == nil {
+		r.categories = make(map[string]struct{})
+	}
+	for _, category := range taxonomy.AllowedCategories() {
+		r.categories[category] = struct{}{}
+	}
+	return nil
+}
+
+// ObservationCategories returns the sorted categories allowed by registered
+// taxonomies and whether any taxonomy was registered.
+func (r *PluginRegistry) ObservationCategories() ([]string, bool) {
+	if r.categories == nil {
+		return nil, false
+	}
+	out := make([]string, 0, len(r.categories))
+	for category := range r.categories {
+		out = append(out, category)
+	}
+	sort.Strings(out)
+	return out, true
+}
+
+// RegisterExtensionSchema records a JSON Schema constraining one attribute
+// namespace of an entity. Each entity and namespace pair may be registered once.
+// Schemas using keywords the host does not enforce are rejected.
+func (r *PluginRegistry) RegisterExtensionSchema(entity string, namespace string, schema json.RawMessage) error {
+	if entity == "" || namespace == "" {
+		return fmt.Errorf("extension schema requires entity and namespace")
+	}
+	if len(schema) == 0 {
+		return fmt.Errorf("extension schema for %s namespace %s is empty", entity, namespace)
+	}
+	parsed, err := parseExtensionSchema(schema)
+	if err != nil {
+		return fmt.Errorf("extension schema for %s namespace %s: %w", entity, namespace, err)
+	}
+	if _, exists := r.extensionSchemas[entity][namespace]; exists {
+		return fmt.Errorf("extension schema for %s namespace %s already registered", entity, namespace)
+	}
+	if r.extensionSchemas == nil {
+		r.extensionSchemas = make(map[string]map[string]*extensionSchema)
+	}
+	if r.extensionSchemas[entity] == nil {
+		r.extensionSchemas[entity] = make(map[string]*extensionSchema)
+	}
+	r.extensionSchemas[entity][namespace] = parsed
+	return nil
+}
+
+// RegisterDatasetService records the dataset service exposed by the plugin.
+// A plugin may register at most one service.
+func (r *PluginRegistry) RegisterDatasetService(svc pluginapi.DatasetService) error {
+	if svc == nil {
+		return fmt.Errorf("dataset service nil")
+	}
+	if r.datasetService != nil {
+		return fmt.Errorf("dataset service already registered")
+	}
+	r.datasetService = svc
+	return nil
+}
+
+// RegisterAuditEmitter records an emitter notified after each committed
+// transaction.
+func (r *PluginRegistry) RegisterAuditEmitter(emitter pluginapi.AuditEmitter) error {
+	if emitter == nil {
+		return fmt.Errorf("audit emitter nil")
+	}
+	r.auditEmitters = append(r.auditEmitters, emitter)
+	return nil
+}
+
+// ReadModel returns the read-only query interface over the host store, or nil
+// when the registry was not created by a Service.
+func (r *PluginRegistry) ReadModel() pluginapi.ReadModel {
+	return r.readModel
+}
+
 // Rules returns a copy of registered rules.
 func (r *PluginRegistry) Rules() []domain.Rule {
 	out := make([]domain.Rule, len(r.rules))
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"colonycore/pkg/domain"
+	"colonycore/pkg/pluginapi"
+)
+
+// storeReadModel implements pluginapi.ReadModel over a persistent store. Lists
+// read committed state through View; finds read one record through
+// domain.VerifiedReader when the store implements it, so stores backed by a
+// database answer with an ID-scoped query instead of loading every table.
+// Records are converted with the same adapters rule views use, so plugins only
+// ever receive facade types.
+type storeReadModel struct {
+	store domain.PersistentStore
+}
+
+var _ pluginapi.ReadModel = storeReadModel{}
+
+func newStoreReadModel(store domain.PersistentStore) pluginapi.ReadModel {
+	if store == nil {
+		return nil
+	}
+	return storeReadModel{store: store}
+}
+
+// readModelList runs query against an adapted view of committed state,
+// returning the error View reports.
+func readModelList[T any](m storeReadModel, query func(pluginapi.RuleView) T) (T, error) {
+	var out T
+	err := m.store.View(context.Background(), func(view domain.TransactionView) error {
+		out = query(adaptRuleView(view))
+		return nil
+	})
+	return out, err
+}
+
+// readModelFind returns the record of entity stored under id converted by
+// adapt. Stores implementing domain.VerifiedReader are read through
+// GetVerified, whose errors, including an InvalidEntityError returned beside
+// the record, are passed on; other stores are read through View with find.
+func readModelFind[R, V any](m storeReadModel, entity domain.EntityType, id string, adapt func(R) V, find func(pluginapi.RuleView) (V, bool)) (V, bool, error) {
+	var out V
+	if reader, ok := m.store.(domain.VerifiedReader); ok {
+		record, found, err := domain.GetVerified[R](reader, entity, id)
+		if !found {
+			return out, false, err
+		}
+		return adapt(record), true, err
+	}
+	var found bool
+	err := m.store.View(context.Background(), func(view domain.TransactionView) error {
+		out, found = find(adaptRuleView(view))
+		return nil
+	})
+	return out, found, err
+}
+
+// permitSkew returns the store's permit clock-skew tolerance, or zero when the
+// store does not report one.
+func (m storeReadModel) permitSkew() time.Duration {
+	if v, ok := m.store.(domain.PermitSkewView); ok {
+		return v.PermitSkew()
+	}
+	return 0
+}
+
+func (m storeReadModel) ListOrganisms() ([]pluginapi.OrganismView, error) {
+	return readModelList(m, pluginapi.RuleView.ListOrganisms)
+}
+
+func (m storeReadModel) ListHousingUnits() ([]pluginapi.HousingUnitView, error) {
+	return readModelList(m, pluginapi.RuleView.ListHousingUnits)
+}
+
+func (m storeReadModel) ListFacilities() ([]pluginapi.FacilityView, error) {
+	return readModelList(m, pluginapi.RuleView.ListFacilities)
+}
+
+func (m storeReadModel) ListTreatments() ([]pluginapi.TreatmentView, error) {
+	return readModelList(m, pluginapi.RuleView.ListTreatments)
+}
+
+func (m storeReadModel) ListObservations() ([]pluginapi.ObservationView, error) {
+	return readModelList(m, pluginapi.RuleView.ListObservations)
+}
+
+func (m storeReadModel) ListSamples() ([]pluginapi.SampleView, error) {
+	return readModelList(m, pluginapi.RuleView.ListSamples)
+}
+
+func (m storeReadModel) ListProtocols() ([]pluginapi.ProtocolView, error) {
+	return readModelList(m, pluginapi.RuleView.ListProtocols)
+}
+
+func (m storeReadModel) ListPermits() ([]pluginapi.PermitView, error) {
+	return readModelList(m, pluginapi.RuleView.ListPermits)
+}
+
+func (m storeReadModel) ListProjects() ([]pluginapi.ProjectView, error) {
+	return readModelList(m, pluginapi.RuleView.ListProjects)
+}
+
+func (m storeReadModel) ListSupplyItems() ([]pluginapi.SupplyItemView, error) {
+	return readModelList(m, pluginapi.RuleView.ListSupplyItems)
+}
+
+func (m storeReadModel) FindOrganism(id string) (pluginapi.OrganismView, bool, error) {
+	return readModelFind(m, domain.EntityOrganism, id,
+		func(org domain.Organism) pluginapi.OrganismView { return newOrganismView(org) },
+		func(v pluginapi.RuleView) (pluginapi.OrganismView, bool) { return v.FindOrganism(id) })
+}
+
+func (m storeReadModel) FindHousingUnit(id string) (pluginapi.HousingUnitView, bool, error) {
+	return readModelFind(m, domain.EntityHousingUnit, id,
+		func(unit domain.HousingUnit) pluginapi.HousingUnitView { return newHousingUnitView(unit) },
+		func(v pluginapi.RuleView) (pluginapi.HousingUnitView, bool) { return v.FindHousingUnit(id) })
+}
+
+func (m storeReadModel) FindFacility(id string) (pluginapi.FacilityView, bool, error) {
+	return readModelFind(m, domain.EntityFacility, id,
+		func(facility domain.Facility) pluginapi.FacilityView { return newFacilityView(facility) },
+		func(v pluginapi.RuleView) (pluginapi.FacilityView, bool) { return v.FindFacility(id) })
+}
+
+func (m storeReadModel) FindTreatment(id string) (pluginapi.TreatmentView, bool, error) {
+	return readModelFind(m, domain.EntityTreatment, id,
+		func(treatment domain.Treatment) pluginapi.TreatmentView { return newTreatmentView(treatment) },
+		func(v pluginapi.RuleView) (pluginapi.TreatmentView, bool) { return v.FindTreatment(id) })
+}
+
+func (m storeReadModel) FindObservation(id string) (pluginapi.ObservationView, bool, error) {
+	return readModelFind(m, domain.EntityObservation, id,
+		func(observation domain.Observation) pluginapi.ObservationView { return newObservationView(observation) },
+		func(v pluginapi.RuleView) (pluginapi.ObservationView, bool) { return v.FindObservation(id) })
+}
+
+func (m storeReadModel) FindSample(id string) (pluginapi.SampleView, bool, error) {
+	return readModelFind(m, domain.EntitySample, id,
+		func(sample domain.Sample) pluginapi.SampleView { return newSampleView(sample) },
+		func(v pluginapi.RuleView) (pluginapi.SampleView, bool) { return v.FindSample(id) })
+}
+
+func (m storeReadModel) FindPermit(id string) (pluginapi.PermitView, bool, error) {
+	return readModelFind(m, domain.EntityPermit, id,
+		func(permit domain.Permit) pluginapi.PermitView { return newPermitView(permit, m.permitSkew()) },
+		func(v pluginapi.RuleView) (pluginapi.PermitView, bool) { return v.FindPermit(id) })
+}
+
+func (m storeReadModel) FindSupplyItem(id string) (pluginapi.SupplyItemView, bool, error) {
+	return readModelFind(m, domain.EntitySupplyItem, id,
+		func(item domain.SupplyItem) pluginapi.SupplyItemView { return newSupplyItemView(item) },
+		func(v pluginapi.RuleView) (pluginapi.SupplyItemView, bool) { return v.FindSupplyItem(id) })
+}
@@ -0,0 +1,206 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/internal/infra/persistence/memory"
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"colonycore/pkg/pluginapi"
+)
+
+// readModelPlugin keeps the ReadModel offered at registration, as a plugin
+// computing derived values outside rule evaluation would.
+type readModelPlugin struct {
+	model pluginapi.ReadModel
+}
+
+func (p *readModelPlugin) Name() string    { return "reader" }
+func (p *readModelPlugin) Version() string { return "1.0.0" }
+func (p *readModelPlugin) Register(reg pluginapi.Registry) error {
+	provider, ok := reg.(pluginapi.ReadModelProvider)
+	if !ok {
+		return errors.New("host does not provide a read model")
+	}
+	p.model = provider.ReadModel()
+	return nil
+}
+
+// assertNoDomainShapes fails when v, or any field reachable through its
+// concrete struct type, is declared in pkg/domain.
+func assertNoDomainShapes(t *testing.T, v any) {
+	t.Helper()
+	seen := make(map[reflect.Type]bool)
+	var walk func(reflect.Type)
+	walk = func(typ reflect.Type) {
+		if seen[typ] {
+			return
+		}
+		seen[typ] = true
+		if strings.HasPrefix(typ.PkgPath(), "colonycore/pkg/domain") {
+			t.Fatalf("plugin received domain shape %s in %T", typ, v)
+		}
+		switch typ.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array:
+			walk(typ.Elem())
+		case reflect.Map:
+			walk(typ.Key())
+			walk(typ.Elem())
+		case reflect.Struct:
+			for i := 0; i < typ.NumField(); i++ {
+				walk(typ.Field(i).Type)
+			}
+		}
+	}
+	walk(reflect.TypeOf(v))
+}
+
+func TestPluginReadsOrganismsThroughReadModel(t *testing.T) {
+	svc := NewInMemoryService(NewRulesEngine())
+	plugin := &readModelPlugin{}
+	if _, err := svc.InstallPlugin(plugin); err != nil {
+		t.Fatalf("install plugin: %v", err)
+	}
+	if plugin.model == nil {
+		t.Fatalf("expected the host to provide a read model")
+	}
+	if got, err := plugin.model.ListOrganisms(); err != nil || len(got) != 0 {
+		t.Fatalf("expected no organisms before any commit, got %d (%v)", len(got), err)
+	}
+
+	ctx := context.Background()
+	organism, _, err := svc.CreateOrganism(ctx, domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus", Stage: domain.StageAdult}})
+	if err != nil {
+		t.Fatalf("create organism: %v", err)
+	}
+
+	organisms, err := plugin.model.ListOrganisms()
+	if err != nil || len(organisms) != 1 || organisms[0].ID() != organism.ID || organisms[0].Name() != "Frog" {
+		t.Fatalf("expected the committed organism through the read model, got %v (%v)", organisms, err)
+	}
+	found, ok, err := plugin.model.FindOrganism(organism.ID)
+	if err != nil || !ok || found.Species() != "Xenopus" {
+		t.Fatalf("expected FindOrganism to return the committed organism, got %v, %v", ok, err)
+	}
+	if _, ok, err := plugin.model.FindHousingUnit("missing"); ok || err != nil {
+		t.Fatalf("expected missing housing unit to be reported absent, got %v, %v", ok, err)
+	}
+	for _, view := range []any{organisms[0], found} {
+		if _, isDomain := view.(domain.Organism); isDomain {
+			t.Fatalf("expected a facade, got domain.Organism")
+		}
+		assertNoDomainShapes(t, view)
+	}
+}
+
+func TestStoreReadModelCoversEveryQuery(t *testing.T) {
+	svc := NewInMemoryService(NewRulesEngine())
+	model := newStoreReadModel(svc.store)
+	lists := []func() (int, error){
+		func() (int, error) { v, err := model.ListOrganisms(); return len(v), err },
+		func() (int, error) { v, err := model.ListHousingUnits(); return len(v), err },
+		func() (int, error) { v, err := model.ListFacilities(); return len(v), err },
+		func() (int, error) { v, err := model.ListTreatments(); return len(v), err },
+		func() (int, error) { v, err := model.ListObservations(); return len(v), err },
+		func() (int, error) { v, err := model.ListSamples(); return len(v), err },
+		func() (int, error) { v, err := model.ListProtocols(); return len(v), err },
+		func() (int, error) { v, err := model.ListPermits(); return len(v), err },
+		func() (int, error) { v, err := model.ListProjects(); return len(v), err },
+		func() (int, error) { v, err := model.ListSupplyItems(); return len(v), err },
+	}
+	for i, list := range lists {
+		if n, err := list(); n != 0 || err != nil {
+			t.Fatalf("list #%d: expected empty store, got %d (%v)", i, n, err)
+		}
+	}
+	finds := []func(string) (bool, error){
+		func(id string) (bool, error) { _, ok, err := model.FindOrganism(id); return ok, err },
+		func(id string) (bool, error) { _, ok, err := model.FindHousingUnit(id); return ok, err },
+		func(id string) (bool, error) { _, ok, err := model.FindFacility(id); return ok, err },
+		func(id string) (bool, error) { _, ok, err := model.FindTreatment(id); return ok, err },
+		func(id string) (bool, error) { _, ok, err := model.FindObservation(id); return ok, err },
+		func(id string) (bool, error) { _, ok, err := model.FindSample(id); return ok, err },
+		func(id string) (bool, error) { _, ok, err := model.FindPermit(id); return ok, err },
+		func(id string) (bool, error) { _, ok, err := model.FindSupplyItem(id); return ok, err },
+	}
+	for i, find := range finds {
+		if ok, err := find("missing"); ok || err != nil {
+			t.Fatalf("find #%d: expected missing ID to be absent, got %v (%v)", i, ok, err)
+		}
+	}
+	if newStoreReadModel(nil) != nil {
+		t.Fatalf("expected no read model without a store")
+	}
+	if NewPluginRegistry().ReadModel() != nil {
+		t.Fatalf("expected a standalone registry to offer no read model")
+	}
+}
+
+// failingReadStore fails every View and GetVerified call, and counts the
+// Views it was asked for.
+type failingReadStore struct {
+	domain.PersistentStore
+	views int
+}
+
+var errReadFailed = errors.New("read failed")
+
+func (s *failingReadStore) View(context.Context, func(domain.TransactionView) error) error {
+	s.views++
+	return errReadFailed
+}
+
+func (s *failingReadStore) GetVerified(domain.EntityType, string) (any, bool, error) {
+	return nil, false, errReadFailed
+}
+
+func (s *failingReadStore) ListVerified(domain.EntityType) ([]any, error) {
+	return nil, errReadFailed
+}
+
+func TestStoreReadModelReturnsReadErrors(t *testing.T) {
+	store := &failingReadStore{}
+	model := newStoreReadModel(store)
+	if _, err := model.ListOrganisms(); !errors.Is(err, errReadFailed) {
+		t.Fatalf("expected the View error from ListOrganisms, got %v", err)
+	}
+	store.views = 0
+	if _, ok, err := model.FindPermit("p1"); ok || !errors.Is(err, errReadFailed) {
+		t.Fatalf("expected the read error from FindPermit, got %v, %v", ok, err)
+	}
+	if store.views != 0 {
+		t.Fatalf("expected FindPermit to read one record instead of a View, got %d views", store.views)
+	}
+}
+
+func TestStoreReadModelFindPermitUsesStorePermitSkew(t *testing.T) {
+	store := memory.NewStore(NewRulesEngine(), memory.WithPermitSkew(time.Hour))
+	until := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	permit := domain.Permit{Permit: entitymodel.Permit{
+		ID:                "p1",
+		PermitNumber:      "P-1",
+		Authority:         "Agency",
+		Status:            domain.PermitStatusApproved,
+		IssueDate:         until.Add(-48 * time.Hour),
+		ValidFrom:         until.Add(-24 * time.Hour),
+		ValidUntil:        until,
+		AllowedActivities: []string{"housing"},
+		FacilityIDs:       []string{"f1"},
+		ProtocolIDs:       []string{"pr1"},
+	}}
+	if err := store.ImportState(memory.Snapshot{Permits: map[string]domain.Permit{"p1": permit}}); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	view, ok, err := newStoreReadModel(store).FindPermit("p1")
+	if err != nil || !ok {
+		t.Fatalf("expected permit p1, got %v, %v", ok, err)
+	}
+	if !view.IsActive(until.Add(30 * time.Minute)) {
+		t.Fatalf("expected the store's permit skew to keep the permit active just after it lapses")
+	}
+}
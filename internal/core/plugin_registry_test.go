@@ -1,6 +1,7 @@
 package core
 
 import (
+	"bytes"
 	"context"
 	"testing"
 	"time"
@@ -124,6 +125,9 @@ func (emptyView) ListSamples() []domain.Sample           { return nil }
 func (emptyView) FindOrganism(string) (domain.Organism, bool) {
 	return domain.Organism{Organism: entitymodel.Organism{}}, false
 }
+func (emptyView) FindCohort(string) (domain.Cohort, bool) {
+	return domain.Cohort{Cohort: entitymodel.Cohort{}}, false
+}
 func (emptyView) FindHousingUnit(string) (domain.HousingUnit, bool) {
 	return domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, false
 }
@@ -180,3 +184,74 @@ func TestRuleNameCoverage(t *testing.T) {
 		t.Fatalf("expected protocol rule name")
 	}
 }
+
+func TestInstallPluginRegistersDatasetService(t *testing.T) {
+	svc := NewInMemoryService(NewRulesEngine())
+	datasets := &pluginapi.DefaultDatasetService{}
+	plugin := simplePlugin{name: "analytics", version: "1.0.0", register: func(reg *PluginRegistry) error {
+		if err := reg.RegisterDatasetService(nil); err == nil {
+			t.Fatalf("expected nil dataset service error")
+		}
+		if err := reg.RegisterDatasetService(datasets); err != nil {
+			return err
+		}
+		if err := reg.RegisterDatasetService(datasets); err == nil {
+			t.Fatalf("expected duplicate dataset service error")
+		}
+		return nil
+	}}
+	if _, err := svc.InstallPlugin(plugin); err != nil {
+		t.Fatalf("install plugin: %v", err)
+	}
+	got, ok := svc.DatasetService("analytics")
+	if !ok || got != pluginapi.DatasetService(datasets) {
+		t.Fatalf("expected registered dataset service, got %v (ok=%v)", got, ok)
+	}
+	if _, ok := svc.DatasetService("missing"); ok {
+		t.Fatalf("expected no dataset service for unknown plugin")
+	}
+}
+
+func TestHostDatasetServiceStreamsInstalledTemplates(t *testing.T) {
+	svc := NewInMemoryService(NewRulesEngine())
+	plugin := simplePlugin{name: "frog", version: "1.0.0", register: func(reg *PluginRegistry) error {
+		return reg.RegisterDatasetTemplate(datasetapi.Template{
+			Key:           "facilities",
+			Version:       "1.0.0",
+			Title:         "Facilities",
+			Description:   "rows by facility",
+			Dialect:       DatasetDialectSQL,
+			Query:         "SELECT facility_id",
+			Columns:       []datasetapi.Column{{Name: datasetapi.RBACFacilityColumn, Type: "string"}},
+			OutputFormats: []datasetapi.Format{FormatJSON},
+			Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
+				return func(_ context.Context, req datasetapi.RunRequest) (datasetapi.RunResult, error) {
+					for _, id := range []string{"facility-a", "facility-b"} {
+						if err := req.Emit(datasetapi.Row{datasetapi.RBACFacilityColumn: id}); err != nil {
+							return datasetapi.RunResult{}, err
+						}
+					}
+					return datasetapi.RunResult{}, nil
+				}, nil
+			},
+		})
+	}}
+	if _, err := svc.InstallPlugin(plugin); err != nil {
+		t.Fatalf("install plugin: %v", err)
+	}
+	datasets := svc.HostDatasetService()
+	templates := datasets.EnumerateTemplates()
+	if len(templates) != 1 || templates[0].ID() != "frog/facilities@1.0.0" {
+		t.Fatalf("expected installed template, got %+v", templates)
+	}
+	ctx := pluginapi.WithDatasetScope(context.Background(), datasetapi.Scope{
+		RBAC: &datasetapi.RBACScope{FacilityIDs: []string{"facility-b"}},
+	})
+	var buf bytes.Buffer
+	if err := datasets.StreamResults(ctx, templates[0].ID(), nil, &buf); err != nil {
+		t.Fatalf("stream results: %v", err)
+	}
+	if got := buf.String(); got != "{\"facility_id\":\"facility-b\"}\n" {
+		t.Fatalf("expected scoped NDJSON rows, got %q", got)
+	}
+}
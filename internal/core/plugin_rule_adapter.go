@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -77,7 +78,7 @@ func (a ruleViewAdapter) ListProtocols() []pluginapi.ProtocolView {
 }
 
 func (a ruleViewAdapter) ListPermits() []pluginapi.PermitView {
-	return newPermitViews(a.view.ListPermits())
+	return newPermitViews(a.view.ListPermits(), domain.PermitSkew(a.view))
 }
 
 func (a ruleViewAdapter) ListProjects() []pluginapi.ProjectView {
@@ -141,7 +142,7 @@ func (a ruleViewAdapter) FindPermit(id string) (pluginapi.PermitView, bool) {
 	if !ok {
 		return nil, false
 	}
-	return newPermitView(permit), true
+	return newPermitView(permit, domain.PermitSkew(a.view)), true
 }
 
 func (a ruleViewAdapter) FindSupplyItem(id string) (pluginapi.SupplyItemView, bool) {
@@ -500,9 +501,9 @@ func newTreatmentView(treatment domain.Treatment) treatmentView {
 		procedureID:       treatment.ProcedureID,
 		organismIDs:       cloneStringSlice(treatment.OrganismIDs),
 		cohortIDs:         cloneStringSlice(treatment.CohortIDs),
-		dosagePlan:        treatment.DosagePlan,
+		dosagePlan:        describeDosagePlan(treatment.DosagePlan),
 		administrationLog: cloneStringSlice(treatment.AdministrationLog),
-		adverseEvents:     cloneStringSlice(treatment.AdverseEvents),
+		adverseEvents:     describeAdverseEvents(treatment.AdverseEvents),
 	}
 }
 
@@ -811,9 +812,12 @@ type permitView struct {
 	facilityIDs       []string
 	protocolIDs       []string
 	notes             *string
+	skew              time.Duration
 }
 
-func newPermitView(permit domain.Permit) permitView {
+// newPermitView projects permit for plugin rules. skew is the store's permit
+// clock-skew tolerance, applied by the validity accessors.
+func newPermitView(permit domain.Permit, skew time.Duration) permitView {
 	return permitView{
 		baseView:          newBaseView(permit.ID, permit.CreatedAt, permit.UpdatedAt),
 		permitNumber:      permit.PermitNumber,
@@ -825,6 +829,7 @@ func newPermitView(permit domain.Permit) permitView {
 		facilityIDs:       cloneStringSlice(permit.FacilityIDs),
 		protocolIDs:       cloneStringSlice(permit.ProtocolIDs),
 		notes:             cloneOptionalString(permit.Notes),
+		skew:              skew,
 	}
 }
 
@@ -853,10 +858,15 @@ func (p permitView) GetStatus(reference time.Time) pluginapi.PermitStatusRef {
 	case "submitted":
 		return statuses.Submitted()
 	case "approved":
-		if !p.validUntil.IsZero() && reference.After(p.validUntil) {
+		switch {
+		case p.IsActive(reference):
+			return statuses.Approved()
+		case !p.validUntil.IsZero() && reference.UTC().After(p.validUntil.UTC()):
 			return statuses.Expired()
+		default:
+			// Approved but not yet in force: neither active nor expired.
+			return statuses.OnHold()
 		}
-		return statuses.Approved()
 	case "on_hold":
 		return statuses.OnHold()
 	case "expired":
@@ -869,7 +879,10 @@ func (p permitView) GetStatus(reference time.Time) pluginapi.PermitStatusRef {
 }
 
 func (p permitView) IsActive(reference time.Time) bool {
-	return p.GetStatus(reference).IsActive()
+	var permit domain.Permit
+	permit.Status = domain.PermitStatus(strings.ToLower(p.status))
+	permit.ValidFrom, permit.ValidUntil = p.validFrom, p.validUntil
+	return domain.IsPermitActive(permit, reference, p.skew)
 }
 
 func (p permitView) IsExpired(reference time.Time) bool {
@@ -1085,13 +1098,13 @@ func newProtocolViews(protocols []domain.Protocol) []pluginapi.ProtocolView {
 	return views
 }
 
-func newPermitViews(permits []domain.Permit) []pluginapi.PermitView {
+func newPermitViews(permits []domain.Permit, skew time.Duration) []pluginapi.PermitView {
 	if len(permits) == 0 {
 		return nil
 	}
 	views := make([]pluginapi.PermitView, len(permits))
 	for i, permit := range permits {
-		views[i] = newPermitView(permit)
+		views[i] = newPermitView(permit, skew)
 	}
 	return views
 }
@@ -1192,6 +1205,53 @@ func cloneStringSlice(values []string) []string {
 	return out
 }
 
+// describeDosagePlan renders a structured dosage plan as the human-readable
+// summary exposed through the plugin and dataset APIs. Plans migrated from
+// free text render their original text.
+func describeDosagePlan(plan domain.DosagePlan) string {
+	if domain.IsLegacyDosagePlan(plan) {
+		return *plan.LegacyText
+	}
+	parts := make([]string, 0, 3)
+	if drug := strings.TrimSpace(plan.Drug); drug != "" {
+		parts = append(parts, drug)
+	}
+	if plan.DoseAmount > 0 {
+		parts = append(parts, strings.TrimSpace(strconv.FormatFloat(plan.DoseAmount, 'f', -1, 64)+" "+plan.DoseUnit))
+	}
+	summary := strings.Join(parts, " ")
+	var schedule []string
+	if plan.FrequencyPerDay > 0 {
+		schedule = append(schedule, strconv.FormatFloat(plan.FrequencyPerDay, 'f', -1, 64)+"x/day")
+	}
+	if plan.DurationDays > 0 {
+		schedule = append(schedule, fmt.Sprintf("for %d days", plan.DurationDays))
+	}
+	if len(schedule) == 0 {
+		return summary
+	}
+	if summary == "" {
+		return strings.Join(schedule, " ")
+	}
+	return summary + ", " + strings.Join(schedule, " ")
+}
+
+// describeAdverseEvents renders structured adverse events as the
+// "severity: description" strings exposed through the plugin and dataset APIs.
+func describeAdverseEvents(events []domain.AdverseEvent) []string {
+	if len(events) == 0 {
+		return nil
+	}
+	out := make([]string, len(events))
+	for i, event := range events {
+		out[i] = event.Description
+		if event.Severity != "" {
+			out[i] = string(event.Severity) + ": " + event.Description
+		}
+	}
+	return out
+}
+
 func cloneCustodyEvents(events []domain.SampleCustodyEvent) []domain.SampleCustodyEvent {
 	if len(events) == 0 {
 		return nil
@@ -58,7 +58,7 @@ func TestTreatmentViewAdministrationLog(t *testing.T) {
 func TestTreatmentViewAdverseEvents(t *testing.T) {
 	treatment := domain.Treatment{Treatment: entitymodel.Treatment{ID: "treatment-1",
 		Name:          "Test Treatment",
-		AdverseEvents: []string{"event-1", "event-2"}},
+		AdverseEvents: []domain.AdverseEvent{{Description: "event-1"}, {Description: "event-2"}}},
 	}
 
 	view := newTreatmentView(treatment)
@@ -103,6 +103,10 @@ func (v stubDomainView) FindOrganism(id string) (domain.Organism, bool) {
 	return domain.Organism{Organism: entitymodel.Organism{}}, false
 }
 
+func (v stubDomainView) FindCohort(string) (domain.Cohort, bool) {
+	return domain.Cohort{Cohort: entitymodel.Cohort{}}, false
+}
+
 func (v stubDomainView) FindHousingUnit(id string) (domain.HousingUnit, bool) {
 	for _, housing := range v.housing {
 		if housing.ID == id {
@@ -188,6 +192,7 @@ func TestSampleViewAccessors(t *testing.T) {
 		Status:          domain.SampleStatusStored,
 		StorageLocation: "Freezer A",
 		AssayType:       "PCR",
+		CollectedBy:     "tech",
 		ChainOfCustody: []domain.SampleCustodyEvent{
 			{
 				Actor:     "tech",
@@ -454,9 +459,9 @@ func TestNewViewAccessors(t *testing.T) {
 		ProcedureID:       "proc",
 		OrganismIDs:       []string{"org"},
 		CohortIDs:         []string{"cohort"},
-		DosagePlan:        "dose plan",
+		DosagePlan:        domain.DosagePlan{Drug: "dose plan", DoseAmount: 1, DoseUnit: "mg"},
 		AdministrationLog: []string{"dose"},
-		AdverseEvents:     []string{"note"}},
+		AdverseEvents:     []domain.AdverseEvent{{Description: "note"}}},
 	})
 	if treatment.Name() == "" || treatment.ProcedureID() == "" {
 		t.Fatal("treatment view should expose base fields")
@@ -464,8 +469,8 @@ func TestNewViewAccessors(t *testing.T) {
 	if len(treatment.OrganismIDs()) != 1 || len(treatment.CohortIDs()) != 1 {
 		t.Fatal("treatment view should expose related ids")
 	}
-	if treatment.DosagePlan() == "" {
-		t.Fatal("treatment should expose dosage plan")
+	if treatment.DosagePlan() != "dose plan 1 mg" {
+		t.Fatalf("treatment should expose dosage plan summary, got %q", treatment.DosagePlan())
 	}
 	if !treatment.HasAdverseEvents() || !treatment.IsCompleted() {
 		t.Fatal("treatment view helpers should reflect log state")
@@ -510,6 +515,7 @@ func TestNewViewAccessors(t *testing.T) {
 		Status:          domain.SampleStatusStored,
 		StorageLocation: "freezer",
 		AssayType:       "assay",
+		CollectedBy:     "tech",
 		ChainOfCustody: []domain.SampleCustodyEvent{{
 			Actor:     "tech",
 			Location:  "lab",
@@ -550,7 +556,7 @@ func TestNewViewAccessors(t *testing.T) {
 		FacilityIDs:       []string{"facility"},
 		ProtocolIDs:       []string{"protocol"},
 		Notes:             strPtr("note")},
-	})
+	}, 0)
 	if permit.PermitNumber() == "" || permit.Authority() == "" || permit.Notes() == "" {
 		t.Fatal("permit view should expose base fields")
 	}
@@ -1017,3 +1023,68 @@ func TestRuleViewFindHousing(t *testing.T) {
 		}
 	})
 }
+
+func TestDescribeDosagePlan(t *testing.T) {
+	cases := []struct {
+		plan domain.DosagePlan
+		want string
+	}{
+		{plan: domain.DosagePlan{}, want: ""},
+		{plan: domain.DosagePlan{Drug: "legacy free text"}, want: "legacy free text"},
+		{plan: domain.DosagePlan{Drug: "meloxicam", DoseAmount: 0.5, DoseUnit: "mg/kg", FrequencyPerDay: 2, DurationDays: 5}, want: "meloxicam 0.5 mg/kg, 2x/day for 5 days"},
+		{plan: domain.DosagePlan{DoseAmount: 10, DurationDays: 3}, want: "10, for 3 days"},
+		{plan: domain.DosagePlan{FrequencyPerDay: 1}, want: "1x/day"},
+	}
+	for _, tc := range cases {
+		if got := describeDosagePlan(tc.plan); got != tc.want {
+			t.Fatalf("describeDosagePlan(%+v) = %q, want %q", tc.plan, got, tc.want)
+		}
+	}
+}
+
+type skewDomainView struct {
+	stubDomainView
+	skew time.Duration
+}
+
+func (v skewDomainView) PermitSkew() time.Duration { return v.skew }
+
+func TestRuleViewPermitsApplyStoreSkew(t *testing.T) {
+	until := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+	permits := []domain.Permit{{Permit: entitymodel.Permit{ID: "permit",
+		Status:     domain.PermitStatusApproved,
+		ValidFrom:  until.Add(-24 * time.Hour),
+		ValidUntil: until},
+	}}
+	justExpired := until.Add(time.Minute)
+
+	strict := adaptRuleView(stubDomainView{permits: permits}).ListPermits()[0]
+	if strict.IsActive(justExpired) || !strict.IsExpired(justExpired) {
+		t.Fatalf("expected permit expired without skew")
+	}
+
+	tolerant := adaptRuleView(skewDomainView{stubDomainView: stubDomainView{permits: permits}, skew: 5 * time.Minute})
+	permit, ok := tolerant.FindPermit("permit")
+	if !ok {
+		t.Fatalf("expected permit to be found")
+	}
+	if !permit.IsActive(justExpired) || permit.IsExpired(justExpired) {
+		t.Fatalf("expected permit expired within skew to stay active")
+	}
+	early := until.Add(-25 * time.Hour)
+	if permit.IsActive(early) {
+		t.Fatalf("expected permit inactive before its validity window and skew")
+	}
+	if status := permit.GetStatus(early); status.IsActive() || status.IsExpired() {
+		t.Fatalf("expected permit not yet in force to be neither active nor expired, got %s", status)
+	}
+	if status := permit.GetStatus(justExpired); !status.IsActive() {
+		t.Fatalf("expected status within skew to match IsActive, got %s", status)
+	}
+	if status := strict.GetStatus(justExpired); !status.IsExpired() {
+		t.Fatalf("expected status past the window to be expired, got %s", status)
+	}
+	if !tolerant.ListPermits()[0].IsActive(justExpired) {
+		t.Fatalf("expected listed permits to carry the store skew")
+	}
+}
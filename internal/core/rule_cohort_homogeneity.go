@@ -0,0 +1,60 @@
+package core
+
+import (
+	"colonycore/pkg/domain"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CohortHomogeneityRule blocks enrolling an organism in a cohort whose species
+// differs from the organism's. Cohorts without a species are not checked, and
+// organisms without a recorded species only raise an advisory warning.
+func CohortHomogeneityRule() domain.Rule {
+	return cohortHomogeneityRule{}
+}
+
+type cohortHomogeneityRule struct{}
+
+func (cohortHomogeneityRule) Name() string { return "cohort_homogeneity" }
+
+func (cohortHomogeneityRule) Evaluate(_ context.Context, view domain.RuleView, changes []domain.Change) (domain.Result, error) {
+	res := domain.Result{}
+	for _, change := range changes {
+		if change.Entity != domain.EntityOrganism {
+			continue
+		}
+		organism, ok := decodeChangePayload[domain.Organism](change.After)
+		if !ok || organism.CohortID == nil {
+			continue
+		}
+		if before, ok := decodeChangePayload[domain.Organism](change.Before); ok &&
+			before.CohortID != nil && *before.CohortID == *organism.CohortID && before.Species == organism.Species {
+			continue
+		}
+		cohort, ok := view.FindCohort(*organism.CohortID)
+		if !ok || cohort.Species == nil || strings.TrimSpace(*cohort.Species) == "" {
+			continue
+		}
+		species := strings.TrimSpace(organism.Species)
+		switch {
+		case species == "":
+			res.Violations = append(res.Violations, cohortHomogeneityViolation(organism.ID, domain.SeverityWarn,
+				fmt.Sprintf("organism %s has no species recorded; cohort %s expects %s", organism.ID, cohort.ID, *cohort.Species)))
+		case !strings.EqualFold(species, strings.TrimSpace(*cohort.Species)):
+			res.Violations = append(res.Violations, cohortHomogeneityViolation(organism.ID, domain.SeverityBlock,
+				fmt.Sprintf("organism %s species %s does not match cohort %s species %s", organism.ID, organism.Species, cohort.ID, *cohort.Species)))
+		}
+	}
+	return res, nil
+}
+
+func cohortHomogeneityViolation(organismID string, severity domain.Severity, message string) domain.Violation {
+	return domain.Violation{
+		Rule:     "cohort_homogeneity",
+		Severity: severity,
+		Message:  message,
+		Entity:   domain.EntityOrganism,
+		EntityID: organismID,
+	}
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"errors"
+	"testing"
+)
+
+func seedCohortHomogeneityStore(t *testing.T) domain.PersistentStore {
+	t.Helper()
+	engine := NewRulesEngine()
+	engine.Register(CohortHomogeneityRule())
+	store := NewMemoryStore(engine)
+	frogs := "frog"
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		if _, err := tx.CreateCohort(domain.Cohort{Cohort: entitymodel.Cohort{ID: "frog-cohort", Name: "Frogs", Purpose: "study", Species: &frogs}}); err != nil {
+			return err
+		}
+		if _, err := tx.CreateCohort(domain.Cohort{Cohort: entitymodel.Cohort{ID: "open-cohort", Name: "Open", Purpose: "study"}}); err != nil {
+			return err
+		}
+		for id, species := range map[string]string{"frog-1": "Frog", "fish-1": "zebrafish", "unknown-1": ""} {
+			if _, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{ID: id, Name: id, Species: species, Stage: entitymodel.LifecycleStageAdult}}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("seed cohort homogeneity store: %v", err)
+	}
+	return store
+}
+
+func enrollOrganism(store domain.PersistentStore, organismID, cohortID string) (domain.Result, error) {
+	return store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		_, err := tx.AddOrganismToCohort(organismID, cohortID)
+		return err
+	})
+}
+
+func TestCohortHomogeneityRuleAllowsMatchingSpecies(t *testing.T) {
+	store := seedCohortHomogeneityStore(t)
+	res, err := enrollOrganism(store, "frog-1", "frog-cohort")
+	if err != nil {
+		t.Fatalf("expected matching species to enroll, got %v", err)
+	}
+	if len(res.Violations) != 0 {
+		t.Fatalf("expected no violations, got %+v", res.Violations)
+	}
+	organism, ok := store.GetOrganism("frog-1")
+	if !ok || organism.CohortID == nil || *organism.CohortID != "frog-cohort" {
+		t.Fatalf("expected organism enrolled in frog-cohort, got %+v", organism.CohortID)
+	}
+}
+
+func TestCohortHomogeneityRuleBlocksMismatchedSpecies(t *testing.T) {
+	store := seedCohortHomogeneityStore(t)
+	_, err := enrollOrganism(store, "fish-1", "frog-cohort")
+	var violation domain.RuleViolationError
+	if !errors.As(err, &violation) {
+		t.Fatalf("expected rule violation, got %v", err)
+	}
+	if len(violation.Result.Violations) != 1 || violation.Result.Violations[0].Rule != "cohort_homogeneity" {
+		t.Fatalf("expected cohort_homogeneity violation, got %+v", violation.Result.Violations)
+	}
+	if organism, _ := store.GetOrganism("fish-1"); organism.CohortID != nil {
+		t.Fatalf("expected blocked enrollment to roll back, got cohort %s", *organism.CohortID)
+	}
+
+	_, err = store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		_, err := tx.UpdateOrganism("fish-1", func(o *domain.Organism) error {
+			cohortID := "frog-cohort"
+			o.CohortID = &cohortID
+			return nil
+		})
+		return err
+	})
+	if !errors.As(err, &violation) {
+		t.Fatalf("expected update-based enrollment to be blocked, got %v", err)
+	}
+}
+
+func TestCohortHomogeneityRuleSkipsCohortWithoutSpecies(t *testing.T) {
+	store := seedCohortHomogeneityStore(t)
+	res, err := enrollOrganism(store, "fish-1", "open-cohort")
+	if err != nil {
+		t.Fatalf("expected cohort without species to accept any organism, got %v", err)
+	}
+	if len(res.Violations) != 0 {
+		t.Fatalf("expected no violations, got %+v", res.Violations)
+	}
+}
+
+func TestCohortHomogeneityRuleWarnsOnMissingOrganismSpecies(t *testing.T) {
+	store := seedCohortHomogeneityStore(t)
+	res, err := enrollOrganism(store, "unknown-1", "frog-cohort")
+	if err != nil {
+		t.Fatalf("expected missing organism species to be advisory, got %v", err)
+	}
+	if len(res.Violations) != 1 || res.Violations[0].Severity != domain.SeverityWarn {
+		t.Fatalf("expected a single warning, got %+v", res.Violations)
+	}
+}
+
+func TestAddOrganismToCohortRequiresExistingEntities(t *testing.T) {
+	store := seedCohortHomogeneityStore(t)
+	if _, err := enrollOrganism(store, "missing", "frog-cohort"); err == nil {
+		t.Fatalf("expected missing organism to fail")
+	}
+	if _, err := enrollOrganism(store, "frog-1", "missing"); err == nil {
+		t.Fatalf("expected missing cohort to fail")
+	}
+}
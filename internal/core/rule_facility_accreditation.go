@@ -0,0 +1,124 @@
+package core
+
+import (
+	"colonycore/pkg/domain"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultAccreditationExpiryWindow is how far ahead of a facility's
+// accreditation expiry FacilityAccreditationRule starts warning.
+const DefaultAccreditationExpiryWindow = 60 * 24 * time.Hour
+
+// FacilityAccreditationRule checks newly created procedures against the
+// accreditation of every facility they touch, resolved through the procedure's
+// project, cohort housing, and organism housing. It blocks procedures in
+// facilities whose accreditation has expired and warns when expiry falls within
+// window of clock's current time. Facilities without an accreditation expiry
+// are not checked. A non-positive window falls back to
+// DefaultAccreditationExpiryWindow and a nil clock to the system clock.
+func FacilityAccreditationRule(window time.Duration, clock Clock) domain.Rule {
+	if window <= 0 {
+		window = DefaultAccreditationExpiryWindow
+	}
+	if clock == nil {
+		clock = ClockFunc(nil)
+	}
+	return facilityAccreditationRule{window: window, now: clock.Now}
+}
+
+type facilityAccreditationRule struct {
+	window time.Duration
+	now    func() time.Time
+}
+
+func (facilityAccreditationRule) Name() string { return "facility_accreditation" }
+
+func (r facilityAccreditationRule) Evaluate(_ context.Context, view domain.RuleView, changes []domain.Change) (domain.Result, error) {
+	res := domain.Result{}
+	now := r.now()
+	for _, change := range changes {
+		if change.Entity != domain.EntityProcedure || change.Action != domain.ActionCreate {
+			continue
+		}
+		procedure, ok := decodeChangePayload[domain.Procedure](change.After)
+		if !ok {
+			continue
+		}
+		for _, facilityID := range procedureFacilityIDs(view, procedure) {
+			facility, ok := view.FindFacility(facilityID)
+			if !ok || facility.AccreditationExpiresAt == nil {
+				continue
+			}
+			expires := *facility.AccreditationExpiresAt
+			switch {
+			case !now.Before(expires):
+				res.Violations = append(res.Violations, domain.Violation{
+					Rule:     "facility_accreditation",
+					Severity: domain.SeverityBlock,
+					Message:  fmt.Sprintf("procedure %s scheduled in facility %s whose accreditation %s expired on %s", procedure.ID, facility.Code, accreditationLabel(facility), expires.Format(time.DateOnly)),
+					Entity:   domain.EntityProcedure,
+					EntityID: procedure.ID,
+				})
+			case expires.Sub(now) <= r.window:
+				res.Violations = append(res.Violations, domain.Violation{
+					Rule:     "facility_accreditation",
+					Severity: domain.SeverityWarn,
+					Message:  fmt.Sprintf("procedure %s scheduled in facility %s whose accreditation %s expires on %s", procedure.ID, facility.Code, accreditationLabel(facility), expires.Format(time.DateOnly)),
+					Entity:   domain.EntityProcedure,
+					EntityID: procedure.ID,
+				})
+			}
+		}
+	}
+	return res, nil
+}
+
+// procedureFacilityIDs returns the sorted, de-duplicated facilities a procedure
+// operates in. Unknown references are left to referential integrity checks.
+func procedureFacilityIDs(view domain.RuleView, procedure domain.Procedure) []string {
+	seen := make(map[string]struct{})
+	addHousing := func(housingID *string) {
+		if housingID == nil {
+			return
+		}
+		if housing, ok := view.FindHousingUnit(*housingID); ok {
+			seen[housing.FacilityID] = struct{}{}
+		}
+	}
+	if procedure.ProjectID != nil {
+		for _, project := range view.ListProjects() {
+			if project.ID != *procedure.ProjectID {
+				continue
+			}
+			for _, facilityID := range project.FacilityIDs {
+				seen[facilityID] = struct{}{}
+			}
+		}
+	}
+	if procedure.CohortID != nil {
+		if cohort, ok := view.FindCohort(*procedure.CohortID); ok {
+			addHousing(cohort.HousingID)
+		}
+	}
+	for _, organismID := range procedure.OrganismIDs {
+		if organism, ok := view.FindOrganism(organismID); ok {
+			addHousing(organism.HousingID)
+		}
+	}
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func accreditationLabel(facility domain.Facility) string {
+	if facility.AccreditationNumber == nil {
+		return "(unnumbered)"
+	}
+	return *facility.AccreditationNumber
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFacilityAccreditationRuleExpiryWindow(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(NewRulesEngine())
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var facility domain.Facility
+	var organism domain.Organism
+	_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		var err error
+		if facility, err = tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "VIV", Name: "Vivarium", Zone: "A", AccessPolicy: "badge"}}); err != nil {
+			return err
+		}
+		housing, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Rack", FacilityID: facility.ID, Capacity: 4}})
+		if err != nil {
+			return err
+		}
+		organism, err = tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Subject", Species: "species", Stage: domain.StageAdult, HousingID: &housing.ID}})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("prepare state: %v", err)
+	}
+	procedure := domain.Procedure{Procedure: entitymodel.Procedure{ID: "proc-1", Name: "Dosing", Status: domain.ProcedureStatusScheduled, ScheduledAt: now, ProtocolID: "protocol-1", OrganismIDs: []string{organism.ID}}}
+	changes := []domain.Change{{Entity: domain.EntityProcedure, Action: domain.ActionCreate, After: mustChangePayload(t, procedure)}}
+
+	evaluate := func(expiresAt *time.Time, window time.Duration) domain.Result {
+		t.Helper()
+		if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+			_, err := tx.UpdateFacility(facility.ID, func(f *domain.Facility) error {
+				number := "AAALAC-001"
+				f.AccreditationNumber = &number
+				f.AccreditationExpiresAt = expiresAt
+				return nil
+			})
+			return err
+		}); err != nil {
+			t.Fatalf("update accreditation: %v", err)
+		}
+		rule := FacilityAccreditationRule(window, ClockFunc(func() time.Time { return now }))
+		var res domain.Result
+		_ = store.View(ctx, func(v domain.TransactionView) error {
+			var evalErr error
+			res, evalErr = rule.Evaluate(ctx, v, changes)
+			if evalErr != nil {
+				t.Fatalf("evaluate facility accreditation: %v", evalErr)
+			}
+			return nil
+		})
+		return res
+	}
+	at := func(offset time.Duration) *time.Time {
+		ts := now.Add(offset)
+		return &ts
+	}
+
+	if res := evaluate(nil, 0); len(res.Violations) != 0 {
+		t.Fatalf("expected no violations without accreditation expiry, got %+v", res.Violations)
+	}
+	if res := evaluate(at(90*24*time.Hour), 0); len(res.Violations) != 0 {
+		t.Fatalf("expected no advisory beyond the window, got %+v", res.Violations)
+	}
+	res := evaluate(at(30*24*time.Hour), 0)
+	if len(res.Violations) != 1 || res.Violations[0].Severity != domain.SeverityWarn || res.HasBlocking() {
+		t.Fatalf("expected single advisory within the window, got %+v", res.Violations)
+	}
+	if res.Violations[0].Rule != "facility_accreditation" || res.Violations[0].EntityID != procedure.ID {
+		t.Fatalf("unexpected violation: %+v", res.Violations[0])
+	}
+	if res := evaluate(at(30*24*time.Hour), 7*24*time.Hour); len(res.Violations) != 0 {
+		t.Fatalf("expected configured window to suppress advisory, got %+v", res.Violations)
+	}
+	if res := evaluate(at(-time.Hour), 0); len(res.Violations) != 1 || !res.HasBlocking() {
+		t.Fatalf("expected blocking violation after expiry, got %+v", res.Violations)
+	}
+}
+
+func TestDefaultRulesEngineAccreditationOptions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	expires := now.Add(30 * 24 * time.Hour)
+	store := NewMemoryStore(NewRulesEngine())
+	var organism domain.Organism
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "VIV", Name: "Vivarium", Zone: "A", AccessPolicy: "badge", AccreditationExpiresAt: &expires}})
+		if err != nil {
+			return err
+		}
+		housing, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Rack", FacilityID: facility.ID, Capacity: 4}})
+		if err != nil {
+			return err
+		}
+		organism, err = tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Subject", Species: "species", Stage: domain.StageAdult, HousingID: &housing.ID}})
+		return err
+	}); err != nil {
+		t.Fatalf("prepare state: %v", err)
+	}
+	procedure := domain.Procedure{Procedure: entitymodel.Procedure{ID: "proc-1", Name: "Dosing", Status: domain.ProcedureStatusScheduled, ScheduledAt: now, ProtocolID: "protocol-1", OrganismIDs: []string{organism.ID}}}
+	changes := []domain.Change{{Entity: domain.EntityProcedure, Action: domain.ActionCreate, After: mustChangePayload(t, procedure)}}
+	accreditation := func(opts ...RulesOption) []domain.Violation {
+		t.Helper()
+		var res domain.Result
+		if err := store.View(ctx, func(v domain.TransactionView) error {
+			var err error
+			res, err = NewDefaultRulesEngine(opts...).Evaluate(ctx, v, changes)
+			return err
+		}); err != nil {
+			t.Fatalf("evaluate default rules: %v", err)
+		}
+		var out []domain.Violation
+		for _, violation := range res.Violations {
+			if violation.Rule == "facility_accreditation" {
+				out = append(out, violation)
+			}
+		}
+		return out
+	}
+	clock := ClockFunc(func() time.Time { return now })
+
+	if got := accreditation(WithRulesClock(clock)); len(got) != 1 || got[0].Severity != domain.SeverityWarn {
+		t.Fatalf("expected the default window to warn, got %+v", got)
+	}
+	if got := accreditation(WithRulesClock(clock), WithAccreditationExpiryWindow(7*24*time.Hour)); len(got) != 0 {
+		t.Fatalf("expected a narrower window to suppress the advisory, got %+v", got)
+	}
+	late := ClockFunc(func() time.Time { return expires.Add(time.Hour) })
+	if got := accreditation(WithRulesClock(late)); len(got) != 1 || got[0].Severity != domain.SeverityBlock {
+		t.Fatalf("expected the injected clock to see the accreditation expired, got %+v", got)
+	}
+}
@@ -14,11 +14,13 @@ func LifecycleTransitionRule() domain.Rule {
 type lifecycleTransitionRule struct{}
 
 type lifecycleMachine struct {
-	entity    domain.EntityType
-	label     string
-	terminal  map[string]struct{}
-	valid     map[string]struct{}
-	extractor func(payload domain.ChangePayload) (id string, state string, ok bool)
+	entity   domain.EntityType
+	label    string
+	terminal map[string]struct{}
+	valid    map[string]struct{}
+	// transition, when set, decides every state change instead of terminal.
+	transition func(from, to string) bool
+	extractor  func(payload domain.ChangePayload) (id string, state string, ok bool)
 }
 
 var lifecycleMachines = map[domain.EntityType]lifecycleMachine{
@@ -61,9 +63,11 @@ var lifecycleMachines = map[domain.EntityType]lifecycleMachine{
 		},
 	},
 	domain.EntityProcedure: {
-		entity:   domain.EntityProcedure,
-		label:    "procedure",
-		terminal: toSet(string(domain.ProcedureStatusCompleted), string(domain.ProcedureStatusCancelled), string(domain.ProcedureStatusFailed)),
+		entity: domain.EntityProcedure,
+		label:  "procedure",
+		transition: func(from, to string) bool {
+			return domain.CanTransitionProcedure(domain.ProcedureStatus(from), domain.ProcedureStatus(to))
+		},
 		valid: toSet(
 			string(domain.ProcedureStatusScheduled),
 			string(domain.ProcedureStatusInProgress),
@@ -186,14 +190,23 @@ func (lifecycleTransitionRule) Evaluate(_ context.Context, view domain.RuleView,
 		if !ok {
 			continue
 		}
-		if _, ok := machine.terminal[beforeState]; !ok {
+		afterID, afterState, ok := machine.extractor(change.After)
+		if !ok || afterState == beforeState {
 			continue
 		}
-		afterID, afterState, ok := machine.extractor(change.After)
-		if !ok {
+		if machine.transition != nil {
+			if !machine.transition(beforeState, afterState) {
+				res.Violations = append(res.Violations, domain.Violation{
+					Rule:     "lifecycle_transition",
+					Severity: domain.SeverityBlock,
+					Message:  fmt.Sprintf("cannot move %s %s from %s to %s", machine.label, beforeID, beforeState, afterState),
+					Entity:   machine.entity,
+					EntityID: afterID,
+				})
+			}
 			continue
 		}
-		if afterState != beforeState {
+		if _, ok := machine.terminal[beforeState]; ok {
 			res.Violations = append(res.Violations, domain.Violation{
 				Rule:     "lifecycle_transition",
 				Severity: domain.SeverityBlock,
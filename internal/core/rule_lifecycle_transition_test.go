@@ -32,6 +32,42 @@ func TestLifecycleTransitionBlocksTerminalExit(t *testing.T) {
 	})
 }
 
+func TestLifecycleTransitionUsesProcedureTransitionTable(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(NewRulesEngine())
+	rule := LifecycleTransitionRule()
+
+	procedure := func(status domain.ProcedureStatus) domain.Procedure {
+		return domain.Procedure{Procedure: entitymodel.Procedure{ID: "p1", Name: "Proc", ProtocolID: "proto", Status: status, ScheduledAt: time.Now()}}
+	}
+	cases := []struct {
+		from, to domain.ProcedureStatus
+		blocked  bool
+	}{
+		{domain.ProcedureStatusScheduled, domain.ProcedureStatusInProgress, false},
+		{domain.ProcedureStatusScheduled, domain.ProcedureStatusCompleted, true},
+		{domain.ProcedureStatusInProgress, domain.ProcedureStatusFailed, false},
+		{domain.ProcedureStatusInProgress, domain.ProcedureStatusScheduled, true},
+		{domain.ProcedureStatusCancelled, domain.ProcedureStatusScheduled, true},
+	}
+	_ = store.View(ctx, func(v domain.TransactionView) error {
+		for _, tc := range cases {
+			res, err := rule.Evaluate(ctx, v, []domain.Change{{
+				Entity: domain.EntityProcedure,
+				Before: mustChangePayload(t, procedure(tc.from)),
+				After:  mustChangePayload(t, procedure(tc.to)),
+			}})
+			if err != nil {
+				t.Fatalf("evaluate lifecycle rule: %v", err)
+			}
+			if blocked := len(res.Violations) > 0; blocked != tc.blocked || blocked != !domain.CanTransitionProcedure(tc.from, tc.to) {
+				t.Fatalf("%s to %s: expected blocked=%v, got %+v", tc.from, tc.to, tc.blocked, res.Violations)
+			}
+		}
+		return nil
+	})
+}
+
 func TestLifecycleTransitionInvalidState(t *testing.T) {
 	ctx := context.Background()
 	store := NewMemoryStore(NewRulesEngine())
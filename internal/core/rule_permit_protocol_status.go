@@ -0,0 +1,96 @@
+package core
+
+import (
+	"colonycore/pkg/domain"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// PermitProtocolStatusRule warns when a permit active at clock's current time
+// (see domain.IsPermitActive, with the store's permit skew) only references
+// protocols that have expired or been archived. A nil clock uses the system
+// clock.
+func PermitProtocolStatusRule(clock Clock) domain.Rule {
+	if clock == nil {
+		clock = ClockFunc(nil)
+	}
+	return permitProtocolStatusRule{now: clock.Now}
+}
+
+type permitProtocolStatusRule struct {
+	now func() time.Time
+}
+
+func (permitProtocolStatusRule) Name() string { return "permit_protocol_status" }
+
+func (r permitProtocolStatusRule) Evaluate(_ context.Context, view domain.RuleView, changes []domain.Change) (domain.Result, error) {
+	res := domain.Result{}
+	now, skew := r.now(), domain.PermitSkew(view)
+	protocols := make(map[string]domain.Protocol)
+	for _, protocol := range view.ListProtocols() {
+		protocols[protocol.ID] = protocol
+	}
+	permits := make(map[string]domain.Permit)
+	for _, change := range changes {
+		switch change.Entity {
+		case domain.EntityPermit:
+			permit, ok := decodeChangePayload[domain.Permit](change.After)
+			if !ok {
+				continue
+			}
+			permits[permit.ID] = permit
+		case domain.EntityProtocol:
+			protocol, ok := decodeChangePayload[domain.Protocol](change.After)
+			if !ok {
+				continue
+			}
+			for _, permit := range view.ListPermits() {
+				if slices.Contains(permit.ProtocolIDs, protocol.ID) {
+					permits[permit.ID] = permit
+				}
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(permits))
+	for id := range permits {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		permit := permits[id]
+		if !domain.IsPermitActive(permit, now, skew) || len(permit.ProtocolIDs) == 0 {
+			continue
+		}
+		if permitHasLiveProtocol(permit, protocols) {
+			continue
+		}
+		res.Violations = append(res.Violations, domain.Violation{
+			Rule:     "permit_protocol_status",
+			Severity: domain.SeverityWarn,
+			Message:  fmt.Sprintf("permit %s is active but all referenced protocols are expired or archived", permit.PermitNumber),
+			Entity:   domain.EntityPermit,
+			EntityID: permit.ID,
+		})
+	}
+	return res, nil
+}
+
+// permitHasLiveProtocol reports whether any protocol referenced by the permit
+// is neither expired nor archived. Unknown protocols are left to referential
+// integrity checks and count as live here.
+func permitHasLiveProtocol(permit domain.Permit, protocols map[string]domain.Protocol) bool {
+	for _, protocolID := range permit.ProtocolIDs {
+		protocol, ok := protocols[protocolID]
+		if !ok {
+			return true
+		}
+		if protocol.Status != domain.ProtocolStatusExpired && protocol.Status != domain.ProtocolStatusArchived {
+			return true
+		}
+	}
+	return false
+}
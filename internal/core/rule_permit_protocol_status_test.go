@@ -0,0 +1,108 @@
+package core
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPermitProtocolStatusWarnsWhenAllProtocolsInactive(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(NewRulesEngine())
+	validFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := validFrom.AddDate(0, 6, 0)
+	rule := permitProtocolStatusRule{now: func() time.Time { return now }}
+
+	var permit domain.Permit
+	var expired, archived domain.Protocol
+	_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Name: "Lab"}})
+		if err != nil {
+			return err
+		}
+		if expired, err = tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "P-EXP", Title: "Expired", MaxSubjects: 5, Status: domain.ProtocolStatusExpired}}); err != nil {
+			return err
+		}
+		if archived, err = tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "P-ARC", Title: "Archived", MaxSubjects: 5, Status: domain.ProtocolStatusArchived}}); err != nil {
+			return err
+		}
+		permit, err = tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{
+			PermitNumber:      "PERMIT-1",
+			Authority:         "Gov",
+			Status:            domain.PermitStatusApproved,
+			IssueDate:         validFrom,
+			ValidFrom:         validFrom,
+			ValidUntil:        validFrom.AddDate(1, 0, 0),
+			AllowedActivities: []string{"store"},
+			FacilityIDs:       []string{facility.ID},
+			ProtocolIDs:       []string{expired.ID, archived.ID},
+		}})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("prepare state: %v", err)
+	}
+
+	evaluate := func(changes []domain.Change) domain.Result {
+		var res domain.Result
+		_ = store.View(ctx, func(v domain.TransactionView) error {
+			var evalErr error
+			res, evalErr = rule.Evaluate(ctx, v, changes)
+			if evalErr != nil {
+				t.Fatalf("evaluate permit protocol status: %v", evalErr)
+			}
+			return nil
+		})
+		return res
+	}
+
+	res := evaluate([]domain.Change{{Entity: domain.EntityPermit, After: mustChangePayload(t, permit)}})
+	if len(res.Violations) != 1 {
+		t.Fatalf("expected one warning, got %+v", res.Violations)
+	}
+	violation := res.Violations[0]
+	if violation.Severity != domain.SeverityWarn || violation.EntityID != permit.ID || violation.Rule != "permit_protocol_status" {
+		t.Fatalf("unexpected violation: %+v", violation)
+	}
+	if res.HasBlocking() {
+		t.Fatalf("expected warning only, got blocking result")
+	}
+
+	res = evaluate([]domain.Change{{Entity: domain.EntityProtocol, After: mustChangePayload(t, archived)}})
+	if len(res.Violations) != 1 || res.Violations[0].EntityID != permit.ID {
+		t.Fatalf("expected protocol change to re-check referencing permit, got %+v", res.Violations)
+	}
+
+	draft := permit
+	draft.Status = domain.PermitStatusDraft
+	if res := evaluate([]domain.Change{{Entity: domain.EntityPermit, After: mustChangePayload(t, draft)}}); len(res.Violations) != 0 {
+		t.Fatalf("expected draft permit to be ignored, got %+v", res.Violations)
+	}
+
+	lapsed := permit
+	lapsed.ValidUntil = now.Add(-time.Hour)
+	if res := evaluate([]domain.Change{{Entity: domain.EntityPermit, After: mustChangePayload(t, lapsed)}}); len(res.Violations) != 0 {
+		t.Fatalf("expected approved permit past its validity window to be ignored, got %+v", res.Violations)
+	}
+	pending := permit
+	pending.ValidFrom = now.Add(time.Hour)
+	if res := evaluate([]domain.Change{{Entity: domain.EntityPermit, After: mustChangePayload(t, pending)}}); len(res.Violations) != 0 {
+		t.Fatalf("expected approved permit not yet in force to be ignored, got %+v", res.Violations)
+	}
+
+	live := permit
+	live.ProtocolIDs = append([]string{}, permit.ProtocolIDs...)
+	_, err = store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		approved, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "P-OK", Title: "Approved", MaxSubjects: 5, Status: domain.ProtocolStatusApproved}})
+		live.ProtocolIDs = append(live.ProtocolIDs, approved.ID)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("create approved protocol: %v", err)
+	}
+	if res := evaluate([]domain.Change{{Entity: domain.EntityPermit, After: mustChangePayload(t, live)}}); len(res.Violations) != 0 {
+		t.Fatalf("expected permit with an approved protocol to pass, got %+v", res.Violations)
+	}
+}
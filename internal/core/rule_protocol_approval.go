@@ -0,0 +1,67 @@
+package core
+
+import (
+	"colonycore/pkg/domain"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// ProtocolApprovalRule blocks protocols from being created as approved or
+// moving into approved from any other status until at least minReviewers
+// reviewers are recorded on them. Once approved, the reviewer list is locked
+// so the quorum that approved the protocol cannot be edited away. A
+// non-positive minReviewers falls back to a single reviewer.
+func ProtocolApprovalRule(minReviewers int) domain.Rule {
+	if minReviewers <= 0 {
+		minReviewers = 1
+	}
+	return protocolApprovalRule{minReviewers: minReviewers}
+}
+
+type protocolApprovalRule struct {
+	minReviewers int
+}
+
+func (protocolApprovalRule) Name() string { return "protocol_approval_quorum" }
+
+func (r protocolApprovalRule) Evaluate(_ context.Context, _ domain.RuleView, changes []domain.Change) (domain.Result, error) {
+	res := domain.Result{}
+	for _, change := range changes {
+		if change.Entity != domain.EntityProtocol || (change.Action != domain.ActionCreate && change.Action != domain.ActionUpdate) {
+			continue
+		}
+		after, ok := decodeChangePayload[domain.Protocol](change.After)
+		if !ok {
+			continue
+		}
+		var before domain.Protocol
+		if change.Action == domain.ActionUpdate {
+			if before, ok = decodeChangePayload[domain.Protocol](change.Before); !ok {
+				continue
+			}
+		}
+		switch {
+		case change.Action == domain.ActionUpdate && before.Status == domain.ProtocolStatusApproved:
+			if slices.Equal(before.ReviewerIDs, after.ReviewerIDs) {
+				continue
+			}
+			res.Violations = append(res.Violations, domain.Violation{
+				Rule:     r.Name(),
+				Severity: domain.SeverityBlock,
+				Message:  fmt.Sprintf("protocol %s reviewers cannot change after approval", after.Code),
+				Entity:   domain.EntityProtocol,
+				EntityID: after.ID,
+			})
+		case after.Status == domain.ProtocolStatusApproved && len(after.ReviewerIDs) < r.minReviewers:
+			res.Violations = append(res.Violations, domain.Violation{
+				Rule:     r.Name(),
+				Severity: domain.SeverityBlock,
+				Message:  fmt.Sprintf("protocol %s cannot be approved with %d of %d required reviewers", after.Code, len(after.ReviewerIDs), r.minReviewers),
+				Entity:   domain.EntityProtocol,
+				EntityID: after.ID,
+			})
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"testing"
+)
+
+func TestProtocolApprovalRuleQuorum(t *testing.T) {
+	submitted := domain.Protocol{Protocol: entitymodel.Protocol{ID: "prot-1", Code: "PR-1", Title: "Protocol", MaxSubjects: 5, Status: domain.ProtocolStatusSubmitted}}
+	approve := func(reviewers ...string) []domain.Change {
+		approved := submitted
+		approved.Status = domain.ProtocolStatusApproved
+		approved.ReviewerIDs = reviewers
+		return []domain.Change{{Entity: domain.EntityProtocol, Action: domain.ActionUpdate, Before: mustChangePayload(t, submitted), After: mustChangePayload(t, approved)}}
+	}
+
+	res, err := ProtocolApprovalRule(0).Evaluate(context.Background(), nil, approve())
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if len(res.Violations) != 1 || res.Violations[0].Severity != domain.SeverityBlock || res.Violations[0].EntityID != "prot-1" {
+		t.Fatalf("expected approval without reviewers to block, got %+v", res.Violations)
+	}
+
+	res, err = ProtocolApprovalRule(1).Evaluate(context.Background(), nil, approve("reviewer-a"))
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if len(res.Violations) != 0 {
+		t.Fatalf("expected single reviewer to satisfy quorum of one, got %+v", res.Violations)
+	}
+
+	res, err = ProtocolApprovalRule(2).Evaluate(context.Background(), nil, approve("reviewer-a"))
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if len(res.Violations) != 1 {
+		t.Fatalf("expected quorum of two to block a single reviewer, got %+v", res.Violations)
+	}
+}
+
+func TestProtocolApprovalRuleCoversCreateAndEveryTransition(t *testing.T) {
+	approved := domain.Protocol{Protocol: entitymodel.Protocol{ID: "prot-1", Code: "PR-1", Title: "Protocol", MaxSubjects: 5, Status: domain.ProtocolStatusApproved}}
+	create := []domain.Change{{Entity: domain.EntityProtocol, Action: domain.ActionCreate, After: mustChangePayload(t, approved)}}
+	res, err := ProtocolApprovalRule(1).Evaluate(context.Background(), nil, create)
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if len(res.Violations) != 1 {
+		t.Fatalf("expected creating an approved protocol without reviewers to block, got %+v", res.Violations)
+	}
+
+	for _, from := range []domain.ProtocolStatus{domain.ProtocolStatusDraft, domain.ProtocolStatusOnHold, domain.ProtocolStatusExpired} {
+		before := approved
+		before.Status = from
+		change := []domain.Change{{Entity: domain.EntityProtocol, Action: domain.ActionUpdate, Before: mustChangePayload(t, before), After: mustChangePayload(t, approved)}}
+		res, err := ProtocolApprovalRule(1).Evaluate(context.Background(), nil, change)
+		if err != nil {
+			t.Fatalf("evaluate: %v", err)
+		}
+		if len(res.Violations) != 1 {
+			t.Fatalf("expected %s -> approved without reviewers to block, got %+v", from, res.Violations)
+		}
+	}
+
+	before := approved
+	before.ReviewerIDs = []string{"reviewer-a"}
+	for name, reviewers := range map[string][]string{
+		"added":   {"reviewer-a", "reviewer-b"},
+		"removed": nil,
+	} {
+		after := before
+		after.ReviewerIDs = reviewers
+		change := []domain.Change{{Entity: domain.EntityProtocol, Action: domain.ActionUpdate, Before: mustChangePayload(t, before), After: mustChangePayload(t, after)}}
+		res, err := ProtocolApprovalRule(1).Evaluate(context.Background(), nil, change)
+		if err != nil {
+			t.Fatalf("evaluate: %v", err)
+		}
+		if len(res.Violations) != 1 || res.Violations[0].Severity != domain.SeverityBlock {
+			t.Fatalf("expected reviewers %s after approval to block, got %+v", name, res.Violations)
+		}
+	}
+}
+
+func TestDefaultRulesEngineProtocolApprovalQuorumOption(t *testing.T) {
+	svc := NewInMemoryService(NewDefaultRulesEngine(WithProtocolApprovalQuorum(2)))
+	ctx := context.Background()
+	protocol, _, err := svc.CreateProtocol(ctx, domain.Protocol{Protocol: entitymodel.Protocol{Code: "PR-Q", Title: "Quorum", MaxSubjects: 1, Status: domain.ProtocolStatusSubmitted}})
+	if err != nil {
+		t.Fatalf("create protocol: %v", err)
+	}
+	if _, _, err := svc.AddProtocolReviewer(ctx, protocol.ID, "reviewer-a"); err != nil {
+		t.Fatalf("add reviewer: %v", err)
+	}
+	approve := func(p *domain.Protocol) error {
+		p.Status = domain.ProtocolStatusApproved
+		return nil
+	}
+	if _, _, err := svc.UpdateProtocol(ctx, protocol.ID, approve); err == nil {
+		t.Fatalf("expected a quorum of two to block approval with one reviewer")
+	}
+	if _, _, err := svc.AddProtocolReviewer(ctx, protocol.ID, "reviewer-b"); err != nil {
+		t.Fatalf("add reviewer: %v", err)
+	}
+	if _, _, err := svc.UpdateProtocol(ctx, protocol.ID, approve); err != nil {
+		t.Fatalf("expected approval with two reviewers: %v", err)
+	}
+	if _, _, err := svc.AddProtocolReviewer(ctx, protocol.ID, "reviewer-c"); err == nil {
+		t.Fatalf("expected reviewers to be locked once approved")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"colonycore/pkg/domain"
 	"context"
 	"fmt"
+	"slices"
 )
 
 // ProtocolCoverageRule enforces that procedures and treatments operate under approved protocols.
@@ -21,6 +22,10 @@ func (protocolCoverageRule) Evaluate(_ context.Context, view domain.RuleView, ch
 	for _, proto := range view.ListProtocols() {
 		protocols[proto.ID] = proto
 	}
+	projects := make(map[string]domain.Project)
+	for _, project := range view.ListProjects() {
+		projects[project.ID] = project
+	}
 
 	for _, change := range changes {
 		switch change.Entity {
@@ -29,20 +34,20 @@ func (protocolCoverageRule) Evaluate(_ context.Context, view domain.RuleView, ch
 			if !ok {
 				continue
 			}
-			validateProcedureCoverage(&res, proc, protocols, view)
+			validateProcedureCoverage(&res, proc, protocols, projects, view)
 		case domain.EntityTreatment:
 			treatment, ok := decodeChangePayload[domain.Treatment](change.After)
 			if !ok {
 				continue
 			}
-			validateTreatmentCoverage(&res, treatment, protocols, view)
+			validateTreatmentCoverage(&res, treatment, protocols, projects, view)
 		}
 	}
 
 	return res, nil
 }
 
-func validateProcedureCoverage(res *domain.Result, proc domain.Procedure, protocols map[string]domain.Protocol, view domain.RuleView) {
+func validateProcedureCoverage(res *domain.Result, proc domain.Procedure, protocols map[string]domain.Protocol, projects map[string]domain.Project, view domain.RuleView) {
 	if proc.ProtocolID == "" {
 		res.Violations = append(res.Violations, protocolViolation(proc.ID, "procedure is missing required protocol", domain.EntityProcedure))
 		return
@@ -61,13 +66,13 @@ func validateProcedureCoverage(res *domain.Result, proc domain.Procedure, protoc
 			res.Violations = append(res.Violations, protocolViolation(proc.ID, fmt.Sprintf("procedure references unknown organism %s", organismID), domain.EntityProcedure))
 			continue
 		}
-		if organism.ProtocolID == nil || *organism.ProtocolID != proc.ProtocolID {
-			res.Violations = append(res.Violations, protocolViolation(proc.ID, fmt.Sprintf("organism %s is not covered by protocol %s", organismID, proc.ProtocolID), domain.EntityProcedure))
+		if !organismCoveredByProtocol(organism, proc.ProtocolID, projects) {
+			res.Violations = append(res.Violations, protocolViolation(proc.ID, fmt.Sprintf("organism %s in procedure %s is not covered by protocol %s", organismID, proc.ID, proc.ProtocolID), domain.EntityProcedure))
 		}
 	}
 }
 
-func validateTreatmentCoverage(res *domain.Result, treatment domain.Treatment, protocols map[string]domain.Protocol, view domain.RuleView) {
+func validateTreatmentCoverage(res *domain.Result, treatment domain.Treatment, protocols map[string]domain.Protocol, projects map[string]domain.Project, view domain.RuleView) {
 	if treatment.ProcedureID == "" {
 		res.Violations = append(res.Violations, protocolViolation(treatment.ID, "treatment is missing procedure reference", domain.EntityTreatment))
 		return
@@ -95,12 +100,25 @@ func validateTreatmentCoverage(res *domain.Result, treatment domain.Treatment, p
 			res.Violations = append(res.Violations, protocolViolation(treatment.ID, fmt.Sprintf("treatment references unknown organism %s", organismID), domain.EntityTreatment))
 			continue
 		}
-		if organism.ProtocolID == nil || *organism.ProtocolID != procedure.ProtocolID {
-			res.Violations = append(res.Violations, protocolViolation(treatment.ID, fmt.Sprintf("organism %s is not covered by protocol %s", organismID, procedure.ProtocolID), domain.EntityTreatment))
+		if !organismCoveredByProtocol(organism, procedure.ProtocolID, projects) {
+			res.Violations = append(res.Violations, protocolViolation(treatment.ID, fmt.Sprintf("organism %s in procedure %s is not covered by protocol %s", organismID, procedure.ID, procedure.ProtocolID), domain.EntityTreatment))
 		}
 	}
 }
 
+// organismCoveredByProtocol reports whether the organism is enrolled under
+// protocolID directly or through a project whose protocol list includes it.
+func organismCoveredByProtocol(organism domain.Organism, protocolID string, projects map[string]domain.Project) bool {
+	if organism.ProtocolID != nil && *organism.ProtocolID == protocolID {
+		return true
+	}
+	if organism.ProjectID == nil {
+		return false
+	}
+	project, ok := projects[*organism.ProjectID]
+	return ok && slices.Contains(project.ProtocolIDs, protocolID)
+}
+
 func protocolViolation(entityID, message string, entity domain.EntityType) domain.Violation {
 	return domain.Violation{
 		Rule:     "protocol_coverage",
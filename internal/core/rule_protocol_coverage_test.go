@@ -116,7 +116,7 @@ func TestProtocolCoverageTreatmentBlocksPendingProtocol(t *testing.T) {
 		Name:        "Dose",
 		ProcedureID: procedure.ID,
 		Status:      entitymodel.TreatmentStatusPlanned,
-		DosagePlan:  "standard",
+		DosagePlan:  domain.DosagePlan{Drug: "standard", DoseAmount: 1, DoseUnit: "mg"},
 	}}
 
 	_ = store.View(ctx, func(v domain.TransactionView) error {
@@ -233,7 +233,7 @@ func TestProtocolCoverageTreatmentMissingProcedure(t *testing.T) {
 	treatment := domain.Treatment{Treatment: entitymodel.Treatment{
 		ID:         "treat-missing-procedure",
 		Name:       "Dose",
-		DosagePlan: "plan",
+		DosagePlan: domain.DosagePlan{Drug: "plan", DoseAmount: 1, DoseUnit: "mg"},
 	}}
 
 	_ = store.View(ctx, func(v domain.TransactionView) error {
@@ -257,7 +257,7 @@ func TestProtocolCoverageTreatmentUnknownProcedure(t *testing.T) {
 		ID:          "treat-unknown-procedure",
 		Name:        "Dose",
 		ProcedureID: "missing",
-		DosagePlan:  "plan",
+		DosagePlan:  domain.DosagePlan{Drug: "plan", DoseAmount: 1, DoseUnit: "mg"},
 	}}
 
 	_ = store.View(ctx, func(v domain.TransactionView) error {
@@ -294,7 +294,7 @@ func TestProtocolCoverageTreatmentProcedureMissingProtocol(t *testing.T) {
 		ID:          "treat-no-protocol",
 		Name:        "Dose",
 		ProcedureID: "proc-no-protocol",
-		DosagePlan:  "plan",
+		DosagePlan:  domain.DosagePlan{Drug: "plan", DoseAmount: 1, DoseUnit: "mg"},
 	}}
 
 	_ = store.View(ctx, func(v domain.TransactionView) error {
@@ -486,3 +486,77 @@ func TestProtocolCoverageSkipsInvalidPayload(t *testing.T) {
 		return nil
 	})
 }
+
+func TestProtocolCoverageHonoursProjectProtocols(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(NewRulesEngine())
+	rule := ProtocolCoverageRule()
+
+	var direct, viaProject, uncovered domain.Organism
+	_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		protocolID := "prot-cover"
+		if _, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{ID: protocolID, Code: "P-C", Title: "Coverage", MaxSubjects: 10, Status: entitymodel.ProtocolStatusApproved}}); err != nil {
+			return err
+		}
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "LAB", Name: "Lab", Zone: "A", AccessPolicy: "badge"}})
+		if err != nil {
+			return err
+		}
+		covering, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{Code: "PRJ-C", Title: "Covering", FacilityIDs: []string{facility.ID}, ProtocolIDs: []string{protocolID}}})
+		if err != nil {
+			return err
+		}
+		unrelated, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{Code: "PRJ-U", Title: "Unrelated", FacilityIDs: []string{facility.ID}}})
+		if err != nil {
+			return err
+		}
+		if direct, err = tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Direct", Species: "frog", Stage: entitymodel.LifecycleStageAdult, ProtocolID: &protocolID}}); err != nil {
+			return err
+		}
+		if viaProject, err = tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Project", Species: "frog", Stage: entitymodel.LifecycleStageAdult, ProjectID: &covering.ID}}); err != nil {
+			return err
+		}
+		uncovered, err = tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Uncovered", Species: "frog", Stage: entitymodel.LifecycleStageAdult, ProjectID: &unrelated.ID}})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("prepare state: %v", err)
+	}
+
+	evaluate := func(organismID string) domain.Result {
+		t.Helper()
+		procedure := domain.Procedure{Procedure: entitymodel.Procedure{
+			ID:          "proc-cover",
+			Name:        "Dose",
+			ProtocolID:  "prot-cover",
+			ScheduledAt: time.Now(),
+			Status:      entitymodel.ProcedureStatusScheduled,
+			OrganismIDs: []string{organismID},
+		}}
+		var res domain.Result
+		_ = store.View(ctx, func(v domain.TransactionView) error {
+			var evalErr error
+			res, evalErr = rule.Evaluate(ctx, v, []domain.Change{{Entity: domain.EntityProcedure, After: mustChangePayload(t, procedure)}})
+			if evalErr != nil {
+				t.Fatalf("evaluate protocol coverage: %v", evalErr)
+			}
+			return nil
+		})
+		return res
+	}
+
+	if res := evaluate(direct.ID); len(res.Violations) != 0 {
+		t.Fatalf("expected directly covered organism to pass, got %+v", res.Violations)
+	}
+	if res := evaluate(viaProject.ID); len(res.Violations) != 0 {
+		t.Fatalf("expected project-covered organism to pass, got %+v", res.Violations)
+	}
+	res := evaluate(uncovered.ID)
+	if len(res.Violations) != 1 || !res.HasBlocking() {
+		t.Fatalf("expected blocking violation for uncovered organism, got %+v", res.Violations)
+	}
+	want := "organism " + uncovered.ID + " in procedure proc-cover is not covered by protocol prot-cover"
+	if res.Violations[0].Message != want {
+		t.Fatalf("expected message %q, got %q", want, res.Violations[0].Message)
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"colonycore/pkg/domain"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SevereAdverseEventRule blocks recording a severe adverse event on a treatment
+// until someone acknowledges it by setting the event's acknowledged_by field.
+// Events already stored before the change are not re-checked.
+func SevereAdverseEventRule() domain.Rule {
+	return severeAdverseEventRule{}
+}
+
+type severeAdverseEventRule struct{}
+
+func (severeAdverseEventRule) Name() string { return "severe_adverse_event" }
+
+func (r severeAdverseEventRule) Evaluate(_ context.Context, _ domain.RuleView, changes []domain.Change) (domain.Result, error) {
+	res := domain.Result{}
+	for _, change := range changes {
+		if change.Entity != domain.EntityTreatment || (change.Action != domain.ActionCreate && change.Action != domain.ActionUpdate) {
+			continue
+		}
+		after, ok := decodeChangePayload[domain.Treatment](change.After)
+		if !ok {
+			continue
+		}
+		existing := make(map[string]struct{})
+		if before, ok := decodeChangePayload[domain.Treatment](change.Before); ok {
+			for _, event := range before.AdverseEvents {
+				existing[adverseEventKey(event)] = struct{}{}
+			}
+		}
+		for _, event := range after.AdverseEvents {
+			if event.Severity != domain.AdverseEventSeveritySevere || adverseEventAcknowledged(event) {
+				continue
+			}
+			if _, ok := existing[adverseEventKey(event)]; ok {
+				continue
+			}
+			res.Violations = append(res.Violations, domain.Violation{
+				Rule:     r.Name(),
+				Severity: domain.SeverityBlock,
+				Message:  fmt.Sprintf("treatment %s severe adverse event %q observed %s requires acknowledgment", after.ID, event.Description, event.ObservedAt.Format(time.RFC3339)),
+				Entity:   domain.EntityTreatment,
+				EntityID: after.ID,
+			})
+		}
+	}
+	return res, nil
+}
+
+func adverseEventAcknowledged(event domain.AdverseEvent) bool {
+	return event.AcknowledgedBy != nil && strings.TrimSpace(*event.AcknowledgedBy) != ""
+}
+
+func adverseEventKey(event domain.AdverseEvent) string {
+	return string(event.Severity) + "\x00" + event.ObservedAt.UTC().Format(time.RFC3339Nano) + "\x00" + event.Description
+}
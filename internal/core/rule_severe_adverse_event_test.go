@@ -0,0 +1,61 @@
+package core
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSevereAdverseEventRuleBlocksUnacknowledgedSevereEvents(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryStore(NewDefaultRulesEngine()))
+	var treatmentID string
+	if _, err := svc.Store().RunInTransaction(ctx, func(tx domain.Transaction) error {
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PR-AE", Title: "Adverse", MaxSubjects: 1, Status: domain.ProtocolStatusApproved, ReviewerIDs: []string{"reviewer-1"}}})
+		if err != nil {
+			return err
+		}
+		procedure, err := tx.CreateProcedure(domain.Procedure{Procedure: entitymodel.Procedure{Name: "Proc", Status: domain.ProcedureStatusScheduled, ScheduledAt: time.Now().UTC(), ProtocolID: protocol.ID}})
+		if err != nil {
+			return err
+		}
+		treatment, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{
+			Name:        "Analgesia",
+			ProcedureID: procedure.ID,
+			DosagePlan:  domain.DosagePlan{Drug: "meloxicam", DoseAmount: 0.5, DoseUnit: "mg/kg"},
+		}})
+		treatmentID = treatment.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed treatment: %v", err)
+	}
+
+	for _, severity := range []domain.AdverseEventSeverity{domain.AdverseEventSeverityMild, domain.AdverseEventSeverityModerate} {
+		if _, _, err := svc.AppendAdverseEvent(ctx, treatmentID, domain.AdverseEvent{Description: "swelling", Severity: severity}); err != nil {
+			t.Fatalf("expected %s event to be recorded, got %v", severity, err)
+		}
+	}
+
+	_, res, err := svc.AppendAdverseEvent(ctx, treatmentID, domain.AdverseEvent{Description: "seizure", Severity: domain.AdverseEventSeveritySevere})
+	if err == nil || !res.HasBlocking() {
+		t.Fatalf("expected severe event to be blocked, got %v (%+v)", err, res)
+	}
+	if len(res.Violations) != 1 || res.Violations[0].Rule != "severe_adverse_event" || res.Violations[0].EntityID != treatmentID {
+		t.Fatalf("unexpected violations %+v", res.Violations)
+	}
+
+	vet := "dr-vet"
+	updated, _, err := svc.AppendAdverseEvent(ctx, treatmentID, domain.AdverseEvent{Description: "seizure", Severity: domain.AdverseEventSeveritySevere, AcknowledgedBy: &vet})
+	if err != nil {
+		t.Fatalf("expected acknowledged severe event to be recorded, got %v", err)
+	}
+	if len(updated.AdverseEvents) != 3 {
+		t.Fatalf("expected three recorded events, got %+v", updated.AdverseEvents)
+	}
+
+	if _, _, err := svc.AppendAdverseEvent(ctx, treatmentID, domain.AdverseEvent{Description: "tremor", Severity: domain.AdverseEventSeverityMild}); err != nil {
+		t.Fatalf("expected stored acknowledged severe event not to block later appends, got %v", err)
+	}
+}
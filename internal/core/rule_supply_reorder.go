@@ -0,0 +1,106 @@
+package core
+
+import (
+	"colonycore/pkg/domain"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// SupplyCategoryPolicy configures how SupplyReorderRule treats one supply
+// category.
+type SupplyCategoryPolicy struct {
+	// ReorderFactor scales an item's reorder_level to the stock level at which
+	// the rule starts warning. Values below 1 are treated as 1.
+	ReorderFactor float64
+}
+
+// DefaultSupplyCategories returns the built-in category policies. Drugs reorder
+// at twice their configured level to absorb longer procurement lead times;
+// consumables and equipment reorder at their configured level.
+func DefaultSupplyCategories() map[string]SupplyCategoryPolicy {
+	return map[string]SupplyCategoryPolicy{
+		"drug":       {ReorderFactor: 2},
+		"consumable": {ReorderFactor: 1},
+		"equipment":  {ReorderFactor: 1},
+	}
+}
+
+// SupplyReorderRule checks created and updated supply items against the
+// registered category policies. It blocks items whose category is not
+// registered and warns when quantity on hand falls to the category-scaled
+// reorder threshold. Uncategorised items use their reorder_level unscaled. A
+// nil or empty categories map falls back to DefaultSupplyCategories.
+func SupplyReorderRule(categories map[string]SupplyCategoryPolicy) domain.Rule {
+	if len(categories) == 0 {
+		categories = DefaultSupplyCategories()
+	}
+	normalized := make(map[string]SupplyCategoryPolicy, len(categories))
+	for name, policy := range categories {
+		normalized[strings.ToLower(strings.TrimSpace(name))] = policy
+	}
+	return supplyReorderRule{categories: normalized}
+}
+
+type supplyReorderRule struct {
+	categories map[string]SupplyCategoryPolicy
+}
+
+func (supplyReorderRule) Name() string { return "supply_reorder" }
+
+func (r supplyReorderRule) Evaluate(_ context.Context, _ domain.RuleView, changes []domain.Change) (domain.Result, error) {
+	res := domain.Result{}
+	for _, change := range changes {
+		if change.Entity != domain.EntitySupplyItem || (change.Action != domain.ActionCreate && change.Action != domain.ActionUpdate) {
+			continue
+		}
+		item, ok := decodeChangePayload[domain.SupplyItem](change.After)
+		if !ok {
+			continue
+		}
+		factor := 1.0
+		if item.Category != nil {
+			policy, known := r.categories[*item.Category]
+			if !known {
+				res.Violations = append(res.Violations, domain.Violation{
+					Rule:     r.Name(),
+					Severity: domain.SeverityBlock,
+					Message:  fmt.Sprintf("supply item %s has unknown category %q (known: %s)", item.SKU, *item.Category, strings.Join(r.categoryNames(), ", ")),
+					Entity:   domain.EntitySupplyItem,
+					EntityID: item.ID,
+				})
+				continue
+			}
+			factor = math.Max(policy.ReorderFactor, 1)
+		}
+		threshold := reorderThreshold(item.ReorderLevel, factor)
+		if threshold <= 0 || item.QuantityOnHand > threshold {
+			continue
+		}
+		res.Violations = append(res.Violations, domain.Violation{
+			Rule:     r.Name(),
+			Severity: domain.SeverityWarn,
+			Message:  fmt.Sprintf("supply item %s has %d %s on hand, at or below reorder threshold %d", item.SKU, item.QuantityOnHand, item.Unit, threshold),
+			Entity:   domain.EntitySupplyItem,
+			EntityID: item.ID,
+		})
+	}
+	return res, nil
+}
+
+// reorderThreshold returns the stock level at or below which an item with the
+// given reorder level and category factor needs reordering.
+func reorderThreshold(reorderLevel int, factor float64) int {
+	return int(math.Ceil(float64(reorderLevel) * factor))
+}
+
+func (r supplyReorderRule) categoryNames() []string {
+	names := make([]string, 0, len(r.categories))
+	for name := range r.categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
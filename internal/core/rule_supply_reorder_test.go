@@ -0,0 +1,99 @@
+package core
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"strings"
+	"testing"
+)
+
+func supplyChange(t *testing.T, category string, quantity, reorderLevel int) []domain.Change {
+	t.Helper()
+	item := domain.SupplyItem{SupplyItem: entitymodel.SupplyItem{ID: "supply-" + category, SKU: "SKU-" + category, Name: "Item", Unit: "unit", QuantityOnHand: quantity, ReorderLevel: reorderLevel}}
+	if category != "" {
+		item.Category = &category
+	}
+	return []domain.Change{{Entity: domain.EntitySupplyItem, Action: domain.ActionCreate, After: mustChangePayload(t, item)}}
+}
+
+func TestSupplyReorderRuleCategories(t *testing.T) {
+	rule := SupplyReorderRule(nil)
+
+	res, err := rule.Evaluate(context.Background(), nil, supplyChange(t, "consumable", 50, 10))
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if len(res.Violations) != 0 {
+		t.Fatalf("expected well-stocked consumable to pass, got %+v", res.Violations)
+	}
+
+	res, err = rule.Evaluate(context.Background(), nil, supplyChange(t, "gadget", 50, 10))
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if len(res.Violations) != 1 || res.Violations[0].Severity != domain.SeverityBlock || !strings.Contains(res.Violations[0].Message, `unknown category "gadget"`) {
+		t.Fatalf("expected unknown category to block, got %+v", res.Violations)
+	}
+}
+
+func TestSupplyReorderRuleDrugThresholdExceedsConsumable(t *testing.T) {
+	rule := SupplyReorderRule(DefaultSupplyCategories())
+
+	res, err := rule.Evaluate(context.Background(), nil, supplyChange(t, "consumable", 15, 10))
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if len(res.Violations) != 0 {
+		t.Fatalf("expected consumable above its reorder level to pass, got %+v", res.Violations)
+	}
+
+	res, err = rule.Evaluate(context.Background(), nil, supplyChange(t, "drug", 15, 10))
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if len(res.Violations) != 1 || res.Violations[0].Severity != domain.SeverityWarn || !strings.Contains(res.Violations[0].Message, "reorder threshold 20") {
+		t.Fatalf("expected drug at the same stock to warn against a doubled threshold, got %+v", res.Violations)
+	}
+
+	res, err = rule.Evaluate(context.Background(), nil, supplyChange(t, "", 10, 10))
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if len(res.Violations) != 1 || !strings.Contains(res.Violations[0].Message, "reorder threshold 10") {
+		t.Fatalf("expected uncategorised item to use its reorder level, got %+v", res.Violations)
+	}
+}
+
+func TestServiceRejectsUnknownSupplyCategory(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(NewMemoryStore(NewDefaultRulesEngine()))
+	facility, _, err := svc.CreateFacility(ctx, domain.Facility{Facility: entitymodel.Facility{Code: "VIV", Name: "Vivarium"}})
+	if err != nil {
+		t.Fatalf("create facility: %v", err)
+	}
+	project, _, err := svc.CreateProject(ctx, domain.Project{Project: entitymodel.Project{Code: "PRJ", Title: "Project", FacilityIDs: []string{facility.ID}}})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	newItem := func(sku, category string) domain.SupplyItem {
+		return domain.SupplyItem{SupplyItem: entitymodel.SupplyItem{SKU: sku, Name: "Item", Unit: "box", QuantityOnHand: 100, ReorderLevel: 5, Category: &category, FacilityIDs: []string{facility.ID}, ProjectIDs: []string{project.ID}}}
+	}
+
+	item, _, err := svc.CreateSupplyItem(ctx, newItem("SKU-1", " Drug "))
+	if err != nil {
+		t.Fatalf("create categorised supply item: %v", err)
+	}
+	if item.Category == nil || *item.Category != "drug" {
+		t.Fatalf("expected normalised drug category, got %v", item.Category)
+	}
+	if _, res, err := svc.CreateSupplyItem(ctx, newItem("SKU-2", "gadget")); err == nil || !res.HasBlocking() {
+		t.Fatalf("expected unknown category to be rejected, got %v (%+v)", err, res)
+	}
+	if _, res, err := svc.UpdateSupplyItem(ctx, item.ID, func(s *domain.SupplyItem) error {
+		s.Category = strPtr("gadget")
+		return nil
+	}); err == nil || !res.HasBlocking() {
+		t.Fatalf("expected update to unknown category to be rejected, got %v (%+v)", err, res)
+	}
+}
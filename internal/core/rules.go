@@ -1,26 +1,80 @@
 package core
 
-import "colonycore/pkg/domain"
+import (
+	"time"
+
+	"colonycore/pkg/domain"
+)
 
 // NewRulesEngine constructs an engine instance.
 func NewRulesEngine() *domain.RulesEngine {
 	return domain.NewRulesEngine()
 }
 
-func defaultRules() []domain.Rule {
+// RulesOption configures the built-in policy set registered by
+// NewDefaultRulesEngine.
+type RulesOption func(*rulesConfig)
+
+type rulesConfig struct {
+	protocolApprovalQuorum int
+	accreditationWindow    time.Duration
+	clock                  Clock
+}
+
+// WithProtocolApprovalQuorum sets how many reviewers ProtocolApprovalRule
+// requires before a protocol may be approved. Non-positive values keep the
+// default of one reviewer.
+func WithProtocolApprovalQuorum(minReviewers int) RulesOption {
+	return func(cfg *rulesConfig) {
+		cfg.protocolApprovalQuorum = minReviewers
+	}
+}
+
+// WithAccreditationExpiryWindow sets how far ahead of a facility's
+// accreditation expiry FacilityAccreditationRule starts warning. Non-positive
+// values keep DefaultAccreditationExpiryWindow.
+func WithAccreditationExpiryWindow(window time.Duration) RulesOption {
+	return func(cfg *rulesConfig) {
+		cfg.accreditationWindow = window
+	}
+}
+
+// WithRulesClock sets the clock the time-dependent built-in rules
+// (FacilityAccreditationRule and PermitProtocolStatusRule) compare against.
+// A nil clock keeps the system clock.
+func WithRulesClock(clock Clock) RulesOption {
+	return func(cfg *rulesConfig) {
+		cfg.clock = clock
+	}
+}
+
+func defaultRules(opts ...RulesOption) []domain.Rule {
+	var cfg rulesConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
 	return []domain.Rule{
 		NewHousingCapacityRule(),
 		NewProtocolSubjectCapRule(),
 		LineageIntegrityRule(),
 		LifecycleTransitionRule(),
 		ProtocolCoverageRule(),
+		CohortHomogeneityRule(),
+		PermitProtocolStatusRule(cfg.clock),
+		FacilityAccreditationRule(cfg.accreditationWindow, cfg.clock),
+		ProtocolApprovalRule(cfg.protocolApprovalQuorum),
+		SevereAdverseEventRule(),
+		SupplyReorderRule(DefaultSupplyCategories()),
 	}
 }
 
-// NewDefaultRulesEngine builds a rules engine with the built-in policy set.
-func NewDefaultRulesEngine() *domain.RulesEngine {
+// NewDefaultRulesEngine builds a rules engine with the built-in policy set,
+// configured by opts.
+func NewDefaultRulesEngine(opts ...RulesOption) *domain.RulesEngine {
 	engine := NewRulesEngine()
-	for _, rule := range defaultRules() {
+	for _, rule := range defaultRules(opts...) {
 		engine.Register(rule)
 	}
 	return engine
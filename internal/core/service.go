@@ -54,11 +54,18 @@ const (
 )
 
 // AuditEntry captures structured audit metadata for service operations.
+//
+// ID is assigned by audit logs that retain entries; Actor is taken from the
+// operation context via AuditActorFromContext. Reason is set for force updates
+// and carries the justification attached by domain.WithForceUpdate.
 type AuditEntry struct {
+	ID        string
 	Operation string
 	Entity    domain.EntityType
 	Action    domain.Action
 	EntityID  string
+	Actor     string
+	Reason    string
 	Status    AuditStatus
 	Error     string
 	Duration  time.Duration
@@ -145,6 +152,9 @@ type serviceOptions struct {
 	metrics MetricsRecorder
 	tracer  Tracer
 	events  EventRecorder
+
+	datasetCostBudget  int
+	cascadeAttachments bool
 }
 
 // WithClock overrides the default clock used by the service.
@@ -269,6 +279,24 @@ type Service struct {
 	plugins  map[string]PluginMetadata
 	datasets map[string]DatasetTemplate
 	mu       sync.RWMutex
+
+	// observationCategories is nil until a plugin registers a category
+	// taxonomy, at which point observationCategoryRule joins the engine.
+	observationCategories *observationCategorySet
+	// extensionSchemas holds plugin attribute schemas keyed by entity then namespace.
+	extensionSchemas map[string]map[string]*extensionSchema
+	// datasetServices holds plugin dataset services keyed by plugin name.
+	datasetServices map[string]pluginapi.DatasetService
+	// hostDatasets serves every installed dataset template.
+	hostDatasets pluginapi.DefaultDatasetService
+	// auditEmitters receive an AuditEntry after each committed transaction.
+	auditEmitters []pluginapi.AuditEmitter
+	// publishesCommits reports whether the store notifies commit observers,
+	// without which audit emitters never run.
+	publishesCommits bool
+
+	datasetCostBudget  int
+	cascadeAttachments bool
 }
 
 // NewService constructs a service backed by the supplied store.
@@ -295,12 +323,16 @@ func NewService(store domain.PersistentStore, opts ...ServiceOption) *Service {
 		events:   options.events,
 		plugins:  make(map[string]PluginMetadata),
 		datasets: make(map[string]DatasetTemplate),
+
+		datasetCostBudget:  options.datasetCostBudget,
+		cascadeAttachments: options.cascadeAttachments,
 	}
 	svc.engine = extractRulesEngine(store)
 	if svc.engine != nil {
 		svc.engine.SetObserver(serviceRuleObserver{events: svc.events})
 	}
 	svc.now = selectNowFunc(store, svc.clock)
+	svc.publishesCommits = registerAuditObserver(svc, store)
 	return svc
 }
 
@@ -354,6 +386,37 @@ func (s *Service) DeleteProject(ctx context.Context, id string) (domain.Result,
 	return res, err
 }
 
+// CloseProject closes a project and cancels its open procedures.
+func (s *Service) CloseProject(ctx context.Context, id string, closedAt time.Time) (domain.Project, domain.Result, error) {
+	var closed domain.Project
+	res, dur, err := s.run(ctx, "close_project", func(tx domain.Transaction) error {
+		var innerErr error
+		closed, innerErr = tx.CloseProject(id, closedAt)
+		return innerErr
+	})
+	if err == nil {
+		s.recordAuditSuccess(ctx, "close_project", closed.ID, dur)
+	}
+	return closed, res, err
+}
+
+// TransitionProcedures moves a batch of procedures to one status, rejecting
+// the whole batch when any procedure is missing or the move is not allowed.
+func (s *Service) TransitionProcedures(ctx context.Context, ids []string, to domain.ProcedureStatus) ([]domain.Procedure, domain.Result, error) {
+	var updated []domain.Procedure
+	res, dur, err := s.run(ctx, "transition_procedures", func(tx domain.Transaction) error {
+		var innerErr error
+		updated, innerErr = tx.TransitionProcedures(ids, to)
+		return innerErr
+	})
+	if err == nil {
+		for _, procedure := range updated {
+			s.recordAuditSuccess(ctx, "transition_procedures", procedure.ID, dur)
+		}
+	}
+	return updated, res, err
+}
+
 // CreateProtocol persists a new protocol.
 func (s *Service) CreateProtocol(ctx context.Context, protocol domain.Protocol) (domain.Protocol, domain.Result, error) {
 	var created domain.Protocol
@@ -382,6 +445,20 @@ func (s *Service) UpdateProtocol(ctx context.Context, id string, mutator func(*d
 	return updated, res, err
 }
 
+// AddProtocolReviewer records a reviewer on a protocol.
+func (s *Service) AddProtocolReviewer(ctx context.Context, protocolID, reviewerID string) (domain.Protocol, domain.Result, error) {
+	var updated domain.Protocol
+	res, dur, err := s.run(ctx, "add_protocol_reviewer", func(tx domain.Transaction) error {
+		var innerErr error
+		updated, innerErr = tx.AddProtocolReviewer(protocolID, reviewerID)
+		return innerErr
+	})
+	if err == nil {
+		s.recordAuditSuccess(ctx, "add_protocol_reviewer", updated.ID, dur)
+	}
+	return updated, res, err
+}
+
 // DeleteProtocol removes a protocol.
 func (s *Service) DeleteProtocol(ctx context.Context, id string) (domain.Result, error) {
 	res, dur, err := s.run(ctx, "delete_protocol", func(tx domain.Transaction) error {
@@ -471,6 +548,22 @@ func (s *Service) DeleteHousingUnit(ctx context.Context, id string) (domain.Resu
 	return res, err
 }
 
+// TransferHousing moves a housing unit and its occupants to another facility,
+// refusing transfers that leave a living occupant in an environment the target
+// facility does not host.
+func (s *Service) TransferHousing(ctx context.Context, housingID, targetFacilityID string) (domain.HousingUnit, domain.Result, error) {
+	var transferred domain.HousingUnit
+	res, dur, err := s.run(ctx, "transfer_housing", func(tx domain.Transaction) error {
+		var innerErr error
+		transferred, innerErr = tx.TransferHousing(housingID, targetFacilityID)
+		return innerErr
+	})
+	if err == nil {
+		s.recordAuditSuccess(ctx, "transfer_housing", transferred.ID, dur)
+	}
+	return transferred, res, err
+}
+
 // CreateCohort persists a new cohort.
 func (s *Service) CreateCohort(ctx context.Context, cohort domain.Cohort) (domain.Cohort, domain.Result, error) {
 	var created domain.Cohort
@@ -485,8 +578,12 @@ func (s *Service) CreateCohort(ctx context.Context, cohort domain.Cohort) (domai
 	return created, res, err
 }
 
-// CreateOrganism persists a new organism.
+// CreateOrganism persists a new organism. Organism extension attributes are
+// checked against any plugin-registered extension schemas first.
 func (s *Service) CreateOrganism(ctx context.Context, organism domain.Organism) (domain.Organism, domain.Result, error) {
+	if err := s.validateOrganismExtensions(organism); err != nil {
+		return domain.Organism{}, domain.Result{}, err
+	}
 	var created domain.Organism
 	res, dur, err := s.run(ctx, "create_organism", func(tx domain.Transaction) error {
 		var innerErr error
@@ -499,12 +596,18 @@ func (s *Service) CreateOrganism(ctx context.Context, organism domain.Organism)
 	return created, res, err
 }
 
-// UpdateOrganism mutates an organism using the provided mutator.
+// UpdateOrganism mutates an organism using the provided mutator. The mutated
+// extension attributes must satisfy plugin-registered extension schemas.
 func (s *Service) UpdateOrganism(ctx context.Context, id string, mutator func(*domain.Organism) error) (domain.Organism, domain.Result, error) {
 	var updated domain.Organism
 	res, dur, err := s.run(ctx, "update_organism", func(tx domain.Transaction) error {
 		var innerErr error
-		updated, innerErr = tx.UpdateOrganism(id, mutator)
+		updated, innerErr = tx.UpdateOrganism(id, func(organism *domain.Organism) error {
+			if err := mutator(organism); err != nil {
+				return err
+			}
+			return s.validateOrganismExtensions(*organism)
+		})
 		return innerErr
 	})
 	if err == nil {
@@ -513,6 +616,81 @@ func (s *Service) UpdateOrganism(ctx context.Context, id string, mutator func(*d
 	return updated, res, err
 }
 
+// UpdateOrganismIfVersion mutates an organism like UpdateOrganism. A non-zero
+// expectedVersion rejects the update with domain.ErrVersionConflict when
+// another writer has changed the organism since it was read.
+func (s *Service) UpdateOrganismIfVersion(ctx context.Context, id string, expectedVersion int, mutator func(*domain.Organism) error) (domain.Organism, domain.Result, error) {
+	var updated domain.Organism
+	res, dur, err := s.run(ctx, "update_organism", func(tx domain.Transaction) error {
+		var innerErr error
+		updated, innerErr = tx.UpdateOrganismIfVersion(id, expectedVersion, func(organism *domain.Organism) error {
+			if err := mutator(organism); err != nil {
+				return err
+			}
+			return s.validateOrganismExtensions(*organism)
+		})
+		return innerErr
+	})
+	if err == nil {
+		s.recordAuditSuccess(ctx, "update_organism", updated.ID, dur)
+	}
+	return updated, res, err
+}
+
+// UpdateOrganismForce mutates an organism whatever its stored version,
+// overriding a version conflict on behalf of the AuditActorFromContext actor
+// for reason. The update is recorded as domain.ActionForceUpdate, with the
+// actor and reason kept on the change's Override and the audit entry. It fails with
+// domain.ErrForceUpdateUnattributed when the context has no actor or reason is
+// blank. It bypasses conflict detection, so callers must gate it
+// behind an access policy that limits it to administrators.
+func (s *Service) UpdateOrganismForce(ctx context.Context, id, reason string, mutator func(*domain.Organism) error) (domain.Organism, domain.Result, error) {
+	ctx = domain.WithForceUpdate(ctx, AuditActorFromContext(ctx), reason)
+	var updated domain.Organism
+	res, dur, err := s.run(ctx, "force_update_organism", func(tx domain.Transaction) error {
+		var innerErr error
+		updated, innerErr = tx.UpdateOrganismForce(id, func(organism *domain.Organism) error {
+			if err := mutator(organism); err != nil {
+				return err
+			}
+			return s.validateOrganismExtensions(*organism)
+		})
+		return innerErr
+	})
+	if err == nil {
+		s.recordAuditSuccess(ctx, "force_update_organism", updated.ID, dur)
+	}
+	return updated, res, err
+}
+
+// AddOrganismToCohort enrolls an organism in a cohort.
+func (s *Service) AddOrganismToCohort(ctx context.Context, organismID, cohortID string) (domain.Organism, domain.Result, error) {
+	var enrolled domain.Organism
+	res, dur, err := s.run(ctx, "add_organism_to_cohort", func(tx domain.Transaction) error {
+		var innerErr error
+		enrolled, innerErr = tx.AddOrganismToCohort(organismID, cohortID)
+		return innerErr
+	})
+	if err == nil {
+		s.recordAuditSuccess(ctx, "add_organism_to_cohort", enrolled.ID, dur)
+	}
+	return enrolled, res, err
+}
+
+// MoveOrganismToProject reassigns an organism to another project.
+func (s *Service) MoveOrganismToProject(ctx context.Context, organismID, targetProjectID string) (domain.Organism, domain.Result, error) {
+	var moved domain.Organism
+	res, dur, err := s.run(ctx, "move_organism_to_project", func(tx domain.Transaction) error {
+		var innerErr error
+		moved, innerErr = tx.MoveOrganismToProject(organismID, targetProjectID)
+		return innerErr
+	})
+	if err == nil {
+		s.recordAuditSuccess(ctx, "move_organism_to_project", moved.ID, dur)
+	}
+	return moved, res, err
+}
+
 // DeleteOrganism removes an organism record.
 func (s *Service) DeleteOrganism(ctx context.Context, id string) (domain.Result, error) {
 	res, dur, err := s.run(ctx, "delete_organism", func(tx domain.Transaction) error {
@@ -645,6 +823,20 @@ func (s *Service) UpdateTreatment(ctx context.Context, id string, mutator func(*
 	return updated, res, err
 }
 
+// AppendAdverseEvent records an adverse event on a treatment.
+func (s *Service) AppendAdverseEvent(ctx context.Context, treatmentID string, event domain.AdverseEvent) (domain.Treatment, domain.Result, error) {
+	var updated domain.Treatment
+	res, dur, err := s.run(ctx, "append_adverse_event", func(tx domain.Transaction) error {
+		var innerErr error
+		updated, innerErr = tx.AppendAdverseEvent(treatmentID, event)
+		return innerErr
+	})
+	if err == nil {
+		s.recordAuditSuccess(ctx, "append_adverse_event", updated.ID, dur)
+	}
+	return updated, res, err
+}
+
 // DeleteTreatment removes a treatment.
 func (s *Service) DeleteTreatment(ctx context.Context, id string) (domain.Result, error) {
 	res, dur, err := s.run(ctx, "delete_treatment", func(tx domain.Transaction) error {
@@ -656,7 +848,9 @@ func (s *Service) DeleteTreatment(ctx context.Context, id string) (domain.Result
 	return res, err
 }
 
-// CreateObservation persists an observation.
+// CreateObservation persists an observation. When a plugin has registered an
+// observation category taxonomy, a non-empty Category must be one it allows;
+// the observation_category rule blocks the transaction otherwise.
 func (s *Service) CreateObservation(ctx context.Context, observation domain.Observation) (domain.Observation, domain.Result, error) {
 	var created domain.Observation
 	res, dur, err := s.run(ctx, "create_observation", func(tx domain.Transaction) error {
@@ -684,13 +878,50 @@ func (s *Service) UpdateObservation(ctx context.Context, id string, mutator func
 	return updated, res, err
 }
 
-// DeleteObservation removes an observation.
+// ReviewObservation records a reviewer sign-off on an observation.
+func (s *Service) ReviewObservation(ctx context.Context, id, reviewer string, at time.Time) (domain.Observation, domain.Result, error) {
+	var reviewed domain.Observation
+	res, dur, err := s.run(ctx, "review_observation", func(tx domain.Transaction) error {
+		var innerErr error
+		reviewed, innerErr = tx.ReviewObservation(id, reviewer, at)
+		return innerErr
+	})
+	if err == nil {
+		s.recordAuditSuccess(ctx, "review_observation", reviewed.ID, dur)
+	}
+	return reviewed, res, err
+}
+
+// AttachObservationFile links an existing blob-store file to an observation.
+func (s *Service) AttachObservationFile(ctx context.Context, observationID string, ref domain.AttachmentRef) (domain.Observation, domain.Result, error) {
+	var updated domain.Observation
+	res, dur, err := s.run(ctx, "attach_observation_file", func(tx domain.Transaction) error {
+		var innerErr error
+		updated, innerErr = tx.AttachObservationFile(observationID, ref)
+		return innerErr
+	})
+	if err == nil {
+		s.recordAuditSuccess(ctx, "attach_observation_file", updated.ID, dur)
+	}
+	return updated, res, err
+}
+
+// DeleteObservation removes an observation. When the service was built with
+// WithAttachmentCascadeDelete, the observation's attachment blobs are deleted
+// after the removal commits.
 func (s *Service) DeleteObservation(ctx context.Context, id string) (domain.Result, error) {
+	var attachments []domain.AttachmentRef
 	res, dur, err := s.run(ctx, "delete_observation", func(tx domain.Transaction) error {
+		if s.cascadeAttachments {
+			if observation, ok := tx.Snapshot().FindObservation(id); ok {
+				attachments = observation.Attachments
+			}
+		}
 		return tx.DeleteObservation(id)
 	})
 	if err == nil {
 		s.recordAuditSuccess(ctx, "delete_observation", id, dur)
+		s.deleteAttachmentBlobs(id, attachments)
 	}
 	return res, err
 }
@@ -801,6 +1032,20 @@ func (s *Service) UpdateSupplyItem(ctx context.Context, id string, mutator func(
 	return updated, res, err
 }
 
+// ConsumeSupplyItem draws stock down from a supply item.
+func (s *Service) ConsumeSupplyItem(ctx context.Context, id string, quantity float64, consumedBy string) (domain.SupplyItem, domain.Result, error) {
+	var consumed domain.SupplyItem
+	res, dur, err := s.run(ctx, "consume_supply_item", func(tx domain.Transaction) error {
+		var innerErr error
+		consumed, innerErr = tx.ConsumeSupplyItem(id, quantity, consumedBy)
+		return innerErr
+	})
+	if err == nil {
+		s.recordAuditSuccess(ctx, "consume_supply_item", consumed.ID, dur)
+	}
+	return consumed, res, err
+}
+
 // DeleteSupplyItem removes a supply item.
 func (s *Service) DeleteSupplyItem(ctx context.Context, id string) (domain.Result, error) {
 	res, dur, err := s.run(ctx, "delete_supply_item", func(tx domain.Transaction) error {
@@ -891,6 +1136,7 @@ func (s *Service) InstallPlugin(plugin pluginapi.Plugin) (meta PluginMetadata, e
 
 	registrationStarted := time.Now()
 	registry := NewPluginRegistry()
+	registry.readModel = newStoreReadModel(s.store)
 	if err = plugin.Register(registry); err != nil {
 		event := observability.Event{
 			Category:   observability.CategoryPluginLifecycle,
@@ -904,6 +1150,15 @@ func (s *Service) InstallPlugin(plugin pluginapi.Plugin) (meta PluginMetadata, e
 		return PluginMetadata{}, err
 	}
 
+	if err = s.checkExtensionSchemaConflicts(registry.extensionSchemas); err != nil {
+		return PluginMetadata{}, err
+	}
+
+	if len(registry.auditEmitters) > 0 && !s.publishesCommits {
+		err = fmt.Errorf("plugin %s registers audit emitters but %w", plugin.Name(), ErrCommitsUnobservable)
+		return PluginMetadata{}, err
+	}
+
 	rules := registry.Rules()
 	schemas := registry.Schemas()
 	datasetCount := len(registry.DatasetTemplates())
@@ -956,6 +1211,9 @@ func (s *Service) InstallPlugin(plugin pluginapi.Plugin) (meta PluginMetadata, e
 			return PluginMetadata{}, err
 		}
 		s.datasets[slug] = dataset
+		if err = s.hostDatasets.Register(newDatasetTemplateRuntime(dataset)); err != nil {
+			return PluginMetadata{}, err
+		}
 		meta.Datasets = append(meta.Datasets, dataset.Descriptor())
 	}
 
@@ -963,6 +1221,37 @@ func (s *Service) InstallPlugin(plugin pluginapi.Plugin) (meta PluginMetadata, e
 		datasetapi.SortTemplateDescriptors(meta.Datasets)
 	}
 
+	if categories, ok := registry.ObservationCategories(); ok {
+		if s.observationCategories == nil {
+			s.observationCategories = &observationCategorySet{}
+			if s.engine != nil {
+				s.engine.Register(observationCategoryRule{categories: s.observationCategories})
+			}
+		}
+		s.observationCategories.add(categories)
+	}
+
+	for entity, namespaces := range registry.extensionSchemas {
+		if s.extensionSchemas == nil {
+			s.extensionSchemas = make(map[string]map[string]*extensionSchema)
+		}
+		if s.extensionSchemas[entity] == nil {
+			s.extensionSchemas[entity] = make(map[string]*extensionSchema, len(namespaces))
+		}
+		for namespace, schema := range namespaces {
+			s.extensionSchemas[entity][namespace] = schema
+		}
+	}
+
+	if registry.datasetService != nil {
+		if s.datasetServices == nil {
+			s.datasetServices = make(map[string]pluginapi.DatasetService)
+		}
+		s.datasetServices[plugin.Name()] = registry.datasetService
+	}
+
+	s.auditEmitters = append(s.auditEmitters[:len(s.auditEmitters):len(s.auditEmitters)], registry.auditEmitters...)
+
 	s.plugins[plugin.Name()] = meta
 	measures["rules_total"] = float64(len(rules))
 	measures["schemas_total"] = float64(len(schemas))
@@ -1009,6 +1298,20 @@ func (s *Service) DatasetTemplates() []datasetapi.TemplateDescriptor {
 	return out
 }
 
+// DatasetService returns the dataset service registered by the named plugin.
+func (s *Service) DatasetService(plugin string) (pluginapi.DatasetService, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	svc, ok := s.datasetServices[plugin]
+	return svc, ok
+}
+
+// HostDatasetService returns a dataset service over every installed dataset
+// template, addressed by slug.
+func (s *Service) HostDatasetService() pluginapi.DatasetService {
+	return &s.hostDatasets
+}
+
 // ResolveDatasetTemplate fetches a dataset template by slug.
 func (s *Service) ResolveDatasetTemplate(slug string) (datasetapi.TemplateRuntime, bool) {
 	s.mu.RLock()
@@ -1041,6 +1344,8 @@ func (s *Service) recordAuditSuccess(ctx context.Context, op, entityID string, d
 		Entity:    meta.entity,
 		Action:    meta.action,
 		EntityID:  entityID,
+		Actor:     AuditActorFromContext(ctx),
+		Reason:    auditReasonFromContext(ctx),
 		Status:    AuditStatusSuccess,
 		Duration:  duration,
 		Timestamp: timestamp,
@@ -1057,6 +1362,8 @@ func (s *Service) recordAuditFailure(ctx context.Context, op string, meta operat
 		Operation: op,
 		Entity:    meta.entity,
 		Action:    meta.action,
+		Actor:     AuditActorFromContext(ctx),
+		Reason:    auditReasonFromContext(ctx),
 		Status:    AuditStatusError,
 		Duration:  duration,
 		Timestamp: timestamp,
@@ -1083,31 +1390,39 @@ var operationMetadata = map[string]operationMeta{
 	"create_project":           {entity: domain.EntityProject, action: domain.ActionCreate},
 	"update_project":           {entity: domain.EntityProject, action: domain.ActionUpdate},
 	"delete_project":           {entity: domain.EntityProject, action: domain.ActionDelete},
+	"close_project":            {entity: domain.EntityProject, action: domain.ActionClose},
 	"create_protocol":          {entity: domain.EntityProtocol, action: domain.ActionCreate},
 	"update_protocol":          {entity: domain.EntityProtocol, action: domain.ActionUpdate},
 	"delete_protocol":          {entity: domain.EntityProtocol, action: domain.ActionDelete},
+	"add_protocol_reviewer":    {entity: domain.EntityProtocol, action: domain.ActionUpdate},
 	"create_facility":          {entity: domain.EntityFacility, action: domain.ActionCreate},
 	"update_facility":          {entity: domain.EntityFacility, action: domain.ActionUpdate},
 	"delete_facility":          {entity: domain.EntityFacility, action: domain.ActionDelete},
 	"create_housing_unit":      {entity: domain.EntityHousingUnit, action: domain.ActionCreate},
 	"update_housing_unit":      {entity: domain.EntityHousingUnit, action: domain.ActionUpdate},
 	"delete_housing_unit":      {entity: domain.EntityHousingUnit, action: domain.ActionDelete},
+	"transfer_housing":         {entity: domain.EntityHousingUnit, action: domain.ActionUpdate},
 	"create_cohort":            {entity: domain.EntityCohort, action: domain.ActionCreate},
 	"create_organism":          {entity: domain.EntityOrganism, action: domain.ActionCreate},
 	"update_organism":          {entity: domain.EntityOrganism, action: domain.ActionUpdate},
+	"force_update_organism":    {entity: domain.EntityOrganism, action: domain.ActionForceUpdate},
 	"delete_organism":          {entity: domain.EntityOrganism, action: domain.ActionDelete},
 	"assign_organism_housing":  {entity: domain.EntityOrganism, action: domain.ActionUpdate},
 	"assign_organism_protocol": {entity: domain.EntityOrganism, action: domain.ActionUpdate},
+	"add_organism_to_cohort":   {entity: domain.EntityOrganism, action: domain.ActionUpdate},
 	"create_breeding_unit":     {entity: domain.EntityBreeding, action: domain.ActionCreate},
 	"create_procedure":         {entity: domain.EntityProcedure, action: domain.ActionCreate},
 	"update_procedure":         {entity: domain.EntityProcedure, action: domain.ActionUpdate},
 	"delete_procedure":         {entity: domain.EntityProcedure, action: domain.ActionDelete},
+	"transition_procedures":    {entity: domain.EntityProcedure, action: domain.ActionUpdate},
 	"create_treatment":         {entity: domain.EntityTreatment, action: domain.ActionCreate},
 	"update_treatment":         {entity: domain.EntityTreatment, action: domain.ActionUpdate},
 	"delete_treatment":         {entity: domain.EntityTreatment, action: domain.ActionDelete},
+	"append_adverse_event":     {entity: domain.EntityTreatment, action: domain.ActionUpdate},
 	"create_observation":       {entity: domain.EntityObservation, action: domain.ActionCreate},
 	"update_observation":       {entity: domain.EntityObservation, action: domain.ActionUpdate},
 	"delete_observation":       {entity: domain.EntityObservation, action: domain.ActionDelete},
+	"attach_observation_file":  {entity: domain.EntityObservation, action: domain.ActionUpdate},
 	"create_sample":            {entity: domain.EntitySample, action: domain.ActionCreate},
 	"update_sample":            {entity: domain.EntitySample, action: domain.ActionUpdate},
 	"delete_sample":            {entity: domain.EntitySample, action: domain.ActionDelete},
@@ -1117,6 +1432,7 @@ var operationMetadata = map[string]operationMeta{
 	"create_supply_item":       {entity: domain.EntitySupplyItem, action: domain.ActionCreate},
 	"update_supply_item":       {entity: domain.EntitySupplyItem, action: domain.ActionUpdate},
 	"delete_supply_item":       {entity: domain.EntitySupplyItem, action: domain.ActionDelete},
+	"consume_supply_item":      {entity: domain.EntitySupplyItem, action: domain.ActionConsume},
 }
 
 func (s *Service) run(ctx context.Context, op string, fn func(domain.Transaction) error) (domain.Result, time.Duration, error) {
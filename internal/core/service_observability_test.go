@@ -253,7 +253,8 @@ func TestServiceObservabilityComplianceEntities(t *testing.T) {
 	}
 
 	treatment, _, err := svc.CreateTreatment(ctx, domain.Treatment{Treatment: entitymodel.Treatment{Name: "Treatment",
-		ProcedureID: procedure.ID},
+		ProcedureID: procedure.ID,
+		DosagePlan:  domain.DosagePlan{Drug: "compound", DoseAmount: 1, DoseUnit: "mg"}},
 	})
 	if err != nil {
 		t.Fatalf("create treatment: %v", err)
@@ -262,7 +263,7 @@ func TestServiceObservabilityComplianceEntities(t *testing.T) {
 		t.Fatalf("expected audit entry for create_treatment")
 	}
 	if _, _, err := svc.UpdateTreatment(ctx, treatment.ID, func(t *domain.Treatment) error {
-		t.DosagePlan = updatedDesc
+		t.DosagePlan = domain.DosagePlan{Drug: updatedDesc, DoseAmount: 1, DoseUnit: "mg"}
 		return nil
 	}); err != nil {
 		t.Fatalf("update treatment: %v", err)
@@ -299,6 +300,7 @@ func TestServiceObservabilityComplianceEntities(t *testing.T) {
 		Status:          domain.SampleStatusStored,
 		StorageLocation: "Freezer-1",
 		AssayType:       "PCR",
+		CollectedBy:     "tech",
 		ChainOfCustody: []domain.SampleCustodyEvent{{
 			Actor:     "tech",
 			Location:  "Freezer-1",
@@ -317,6 +319,7 @@ func TestServiceObservabilityComplianceEntities(t *testing.T) {
 
 	permit, _, err := svc.CreatePermit(ctx, domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PER-1",
 		Authority:         "Regulator",
+		IssueDate:         time.Now().UTC(),
 		ValidFrom:         time.Now().UTC(),
 		ValidUntil:        time.Now().UTC().Add(24 * time.Hour),
 		AllowedActivities: []string{"collect"},
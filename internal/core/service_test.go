@@ -198,7 +198,7 @@ func TestServiceExtendedCRUD(t *testing.T) {
 	if err != nil {
 		t.Fatalf("create project: %v", err)
 	}
-	protocol, _, err := svc.CreateProtocol(ctx, domain.Protocol{Protocol: entitymodel.Protocol{Code: "PROT-EXT", Title: "Extended Protocol", MaxSubjects: 10, Status: domain.ProtocolStatusApproved}})
+	protocol, _, err := svc.CreateProtocol(ctx, domain.Protocol{Protocol: entitymodel.Protocol{Code: "PROT-EXT", Title: "Extended Protocol", MaxSubjects: 10, Status: domain.ProtocolStatusApproved, ReviewerIDs: []string{"reviewer-1"}}})
 	if err != nil {
 		t.Fatalf("create protocol: %v", err)
 	}
@@ -270,7 +270,7 @@ func TestServiceExtendedCRUD(t *testing.T) {
 	}
 
 	if _, res, err := svc.UpdateProcedure(ctx, procedure.ID, func(p *domain.Procedure) error {
-		p.Status = domain.ProcedureStatusCompleted
+		p.Status = domain.ProcedureStatusInProgress
 		return nil
 	}); err != nil {
 		t.Fatalf("update procedure: %v", err)
@@ -481,7 +481,7 @@ func TestServiceEmitsChangesForNewEntities(t *testing.T) {
 	treatment, res, err := svc.CreateTreatment(ctx, domain.Treatment{Treatment: entitymodel.Treatment{Name: "Dose",
 		ProcedureID: procedure.ID,
 		OrganismIDs: []string{organism.ID},
-		DosagePlan:  "10mg"},
+		DosagePlan:  domain.DosagePlan{Drug: "compound", DoseAmount: 10, DoseUnit: "mg"}},
 	})
 	if err != nil {
 		t.Fatalf("create treatment: %v", err)
@@ -490,7 +490,7 @@ func TestServiceEmitsChangesForNewEntities(t *testing.T) {
 	assertSingleChange(t, collector.take(), domain.EntityTreatment, domain.ActionCreate)
 
 	if _, res, err := svc.UpdateTreatment(ctx, treatment.ID, func(tmt *domain.Treatment) error {
-		tmt.DosagePlan = "20mg"
+		tmt.DosagePlan = domain.DosagePlan{Drug: "compound", DoseAmount: 20, DoseUnit: "mg"}
 		return nil
 	}); err != nil {
 		t.Fatalf("update treatment: %v", err)
@@ -532,6 +532,16 @@ func TestServiceEmitsChangesForNewEntities(t *testing.T) {
 	}
 	assertSingleChange(t, collector.take(), domain.EntityObservation, domain.ActionUpdate)
 
+	if reviewed, res, err := svc.ReviewObservation(ctx, observation.ID, "lead", now); err != nil {
+		t.Fatalf("review observation: %v", err)
+	} else {
+		assertNoViolations(t, res)
+		if reviewed.ReviewedBy == nil || *reviewed.ReviewedBy != "lead" {
+			t.Fatalf("expected reviewer to be recorded, got %+v", reviewed.ReviewedBy)
+		}
+	}
+	assertSingleChange(t, collector.take(), domain.EntityObservation, domain.ActionUpdate)
+
 	if res, err := svc.DeleteObservation(ctx, observation.ID); err != nil {
 		t.Fatalf("delete observation: %v", err)
 	} else {
@@ -546,6 +556,7 @@ func TestServiceEmitsChangesForNewEntities(t *testing.T) {
 		CollectedAt:     now,
 		Status:          domain.SampleStatusStored,
 		StorageLocation: "freezer-1",
+		CollectedBy:     "tech",
 		ChainOfCustody: []domain.SampleCustodyEvent{{
 			Actor:     "tech",
 			Location:  "freezer-1",
@@ -578,6 +589,7 @@ func TestServiceEmitsChangesForNewEntities(t *testing.T) {
 	permit, res, err := svc.CreatePermit(ctx, domain.Permit{Permit: entitymodel.Permit{PermitNumber: "P-1",
 		Authority:         "Gov",
 		Status:            domain.PermitStatusApproved,
+		IssueDate:         now.Add(-time.Hour),
 		ValidFrom:         now.Add(-time.Hour),
 		ValidUntil:        now.Add(time.Hour),
 		AllowedActivities: []string{"collect"},
@@ -631,6 +643,16 @@ func TestServiceEmitsChangesForNewEntities(t *testing.T) {
 	}
 	assertSingleChange(t, collector.take(), domain.EntitySupplyItem, domain.ActionUpdate)
 
+	if consumed, res, err := svc.ConsumeSupplyItem(ctx, supply.ID, 5, "tech"); err != nil {
+		t.Fatalf("consume supply item: %v", err)
+	} else {
+		assertNoViolations(t, res)
+		if consumed.QuantityOnHand != 0 || consumed.Status != domain.SupplyStatusConsumed {
+			t.Fatalf("expected supply item to be consumed, got %+v", consumed.SupplyItem)
+		}
+	}
+	assertSingleChange(t, collector.take(), domain.EntitySupplyItem, domain.ActionConsume)
+
 	if res, err := svc.DeleteSupplyItem(ctx, supply.ID); err != nil {
 		t.Fatalf("delete supply item: %v", err)
 	} else {
@@ -865,18 +887,46 @@ func (s clocklessStore) ListProjects() []domain.Project {
 	return s.inner.ListProjects()
 }
 
+func (s clocklessStore) GetBreedingUnit(id string) (domain.BreedingUnit, bool) {
+	return s.inner.GetBreedingUnit(id)
+}
+
 func (s clocklessStore) ListBreedingUnits() []domain.BreedingUnit {
 	return s.inner.ListBreedingUnits()
 }
 
+func (s clocklessStore) ListBreedingUnitsByIntent(intent domain.PairingIntent) []domain.BreedingUnit {
+	return s.inner.ListBreedingUnitsByIntent(intent)
+}
+
 func (s clocklessStore) ListProcedures() []domain.Procedure {
 	return s.inner.ListProcedures()
 }
 
+func (s clocklessStore) GetSupplyItem(id string) (domain.SupplyItem, bool) {
+	return s.inner.GetSupplyItem(id)
+}
+
 func (s clocklessStore) ListSupplyItems() []domain.SupplyItem {
 	return s.inner.ListSupplyItems()
 }
 
+func (s clocklessStore) GetFacilityByCode(code string) (domain.Facility, bool, error) {
+	return s.inner.GetFacilityByCode(code)
+}
+
+func (s clocklessStore) GetProtocolByCode(code string) (domain.Protocol, bool, error) {
+	return s.inner.GetProtocolByCode(code)
+}
+
+func (s clocklessStore) GetLineByCode(code string) (domain.Line, bool, error) {
+	return s.inner.GetLineByCode(code)
+}
+
+func (s clocklessStore) GetStrainByCode(lineID, code string) (domain.Strain, bool, error) {
+	return s.inner.GetStrainByCode(lineID, code)
+}
+
 func (s clocklessStore) RulesEngine() *domain.RulesEngine {
 	return s.inner.RulesEngine()
 }
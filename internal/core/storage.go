@@ -2,6 +2,7 @@ package core
 
 import (
 	"colonycore/internal/infra/persistence/memory"
+	"colonycore/internal/infra/persistence/postgres"
 	"colonycore/internal/infra/persistence/sqlite"
 	"colonycore/pkg/domain"
 	"fmt"
@@ -21,26 +22,48 @@ const (
 	StoragePostgres StorageDriver = "postgres" // PostgreSQL server
 )
 
+// StorageOption configures the store opened by OpenPersistentStore.
+type StorageOption func(*storageOptions)
+
+type storageOptions struct {
+	attachmentBlobs domain.AttachmentBlobStore
+}
+
+// WithStorageAttachmentBlobs configures the blob store the opened store
+// consults to validate observation attachments and that cascade deletes (see
+// WithAttachmentCascadeDelete) remove blobs from.
+func WithStorageAttachmentBlobs(blobs domain.AttachmentBlobStore) StorageOption {
+	return func(opts *storageOptions) {
+		opts.attachmentBlobs = blobs
+	}
+}
+
 // OpenPersistentStore selects a backend using environment variables.
 // Defaults to sqlite when unset.
 //
 //	COLONYCORE_STORAGE_DRIVER: memory|sqlite|postgres (default sqlite)
 //	COLONYCORE_SQLITE_PATH: path to sqlite file (default ./colonycore.db)
 //	COLONYCORE_POSTGRES_DSN: postgres DSN when driver=postgres
-func OpenPersistentStore(engine *domain.RulesEngine) (domain.PersistentStore, error) {
+func OpenPersistentStore(engine *domain.RulesEngine, opts ...StorageOption) (domain.PersistentStore, error) {
+	var cfg storageOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
 	driver := os.Getenv("COLONYCORE_STORAGE_DRIVER")
 	if driver == "" {
 		driver = string(StorageSQLite)
 	}
 	switch StorageDriver(driver) {
 	case StorageMemory:
-		return memory.NewStore(engine), nil
+		return memory.NewStore(engine, memory.WithAttachmentBlobs(cfg.attachmentBlobs)), nil
 	case StorageSQLite:
 		path := os.Getenv("COLONYCORE_SQLITE_PATH")
-		return sqlite.NewStore(path, engine)
+		return sqlite.NewStore(path, engine, sqlite.WithAttachmentBlobs(cfg.attachmentBlobs))
 	case StoragePostgres:
 		dsn := os.Getenv("COLONYCORE_POSTGRES_DSN")
-		ps, err := NewPostgresStore(dsn, engine)
+		ps, err := postgres.NewStore(dsn, engine, postgres.WithAttachmentBlobs(cfg.attachmentBlobs))
 		if err != nil {
 			return nil, err
 		}
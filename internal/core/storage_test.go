@@ -140,3 +140,26 @@ func TestNewPostgresStore(t *testing.T) {
 }
 
 // --- postgres stub driver for storage tests ---
+
+type storageBlobsStub struct{ domain.AttachmentBlobStore }
+
+func TestOpenPersistentStoreWiresAttachmentBlobs(t *testing.T) {
+	blobs := &storageBlobsStub{}
+	db, _ := pgtu.NewStubDB()
+	restore := postgres.OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+	withEnv("COLONYCORE_SQLITE_PATH", filepath.Join(t.TempDir(), "blobs.db"), func() {
+		for _, driver := range []StorageDriver{StorageMemory, StorageSQLite, StoragePostgres} {
+			withEnv("COLONYCORE_STORAGE_DRIVER", string(driver), func() {
+				store, err := OpenPersistentStore(NewDefaultRulesEngine(), WithStorageAttachmentBlobs(blobs))
+				if err != nil {
+					t.Fatalf("%s: open: %v", driver, err)
+				}
+				provider, ok := store.(attachmentBlobProvider)
+				if !ok || provider.AttachmentBlobs() != blobs {
+					t.Fatalf("%s: expected attachment blobs to be wired, got %T", driver, store)
+				}
+			})
+		}
+	})
+}
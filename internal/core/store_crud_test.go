@@ -222,7 +222,7 @@ func TestMemoryStoreCRUDAndQueries(t *testing.T) {
 			return err
 		}
 		if _, err := tx.UpdateProcedure(procedureID, func(p *domain.Procedure) error {
-			p.Status = domain.ProcedureStatusCompleted
+			p.Status = domain.ProcedureStatusInProgress
 			return nil
 		}); err != nil {
 			return err
@@ -24,18 +24,39 @@ import (
 // sidecar (filename + `.meta`) stores content type & user metadata.
 // This is intentionally simple and not concurrent-writer safe beyond per-file creation.
 type Store struct {
-	root string
+	root   string
+	signer *core.URLSigner
+}
+
+// Config holds explicit construction parameters for the filesystem store.
+type Config struct {
+	Root       string // directory root (default ./blobdata)
+	SigningKey []byte // optional HMAC secret enabling SignedURL/VerifySignedURL
 }
 
 // New returns a filesystem-backed blob store rooted at path, creating it if needed.
 func New(root string) (*Store, error) {
+	return NewWithConfig(Config{Root: root})
+}
+
+// NewWithConfig returns a filesystem-backed blob store using cfg.
+func NewWithConfig(cfg Config) (*Store, error) {
+	root := cfg.Root
 	if root == "" {
 		root = "./blobdata"
 	}
 	if err := os.MkdirAll(root, 0o750); err != nil { // tightened perms to satisfy security baseline
 		return nil, err
 	}
-	return &Store{root: root}, nil
+	store := &Store{root: root}
+	if len(cfg.SigningKey) > 0 {
+		signer, err := core.NewURLSigner(cfg.SigningKey, localBaseURL)
+		if err != nil {
+			return nil, err
+		}
+		store.signer = signer
+	}
+	return store, nil
 }
 
 // Driver returns the blob driver identifier.
@@ -80,7 +101,22 @@ type metaFile struct {
 }
 
 // Put stores a new immutable blob; it fails if the key already exists.
-func (s *Store) Put(_ context.Context, key string, r io.Reader, opts core.PutOptions) (core.Info, error) {
+func (s *Store) Put(ctx context.Context, key string, r io.Reader, opts core.PutOptions) (core.Info, error) {
+	return s.put(ctx, key, r, opts)
+}
+
+// PutStreaming stores r under key in fixed-size chunks without buffering the
+// whole payload, returning the bytes written. Cancellation or a read error
+// mid-stream discards the temp file so no partial object becomes visible.
+func (s *Store) PutStreaming(ctx context.Context, key string, r io.Reader) (int64, error) {
+	info, err := s.put(ctx, key, r, core.PutOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return info.Size, nil
+}
+
+func (s *Store) put(ctx context.Context, key string, r io.Reader, opts core.PutOptions) (core.Info, error) {
 	dataPath, metaPath, err := s.pathFor(key)
 	if err != nil {
 		return core.Info{}, err
@@ -99,7 +135,7 @@ func (s *Store) Put(_ context.Context, key string, r io.Reader, opts core.PutOpt
 	}
 	defer func() { _ = os.Remove(tmp.Name()) }()
 	h := sha256.New()
-	size, copyErr := io.Copy(io.MultiWriter(tmp, h), r)
+	size, copyErr := core.CopyChunks(ctx, io.MultiWriter(tmp, h), r, core.DefaultStreamChunkSize)
 	if copyErr != nil {
 		_ = tmp.Close()
 		return core.Info{}, copyErr
@@ -108,25 +144,52 @@ func (s *Store) Put(_ context.Context, key string, r io.Reader, opts core.PutOpt
 		_ = tmp.Close()
 		return core.Info{}, err
 	}
-	if _, err := tmp.Seek(0, 0); err != nil {
-		_ = tmp.Close()
+	if err := tmp.Close(); err != nil {
 		return core.Info{}, err
 	}
 	etag := hex.EncodeToString(h.Sum(nil))
 	// atomically move into place
 	if err := os.Rename(tmp.Name(), dataPath); err != nil {
-		_ = tmp.Close()
 		return core.Info{}, err
 	}
 	now := time.Now().UTC()
 	mf := metaFile{ContentType: opts.ContentType, Metadata: cloneMetadata(opts.Metadata), ETag: etag, Size: size, CreatedAt: now, UpdatedAt: now}
 	if err := writeJSON(metaPath, mf); err != nil {
+		// drop the data file so a blob never exists without its sidecar
+		_ = os.Remove(dataPath)
 		return core.Info{}, err
 	}
 	info := core.Info{Key: key, Size: size, ContentType: opts.ContentType, ETag: etag, Metadata: cloneMetadata(opts.Metadata), LastModified: now, URL: s.localURL(key)}
 	return info, nil
 }
 
+// PutWithMetadata stores r under key with validated provenance metadata. The
+// content type is sniffed from the leading bytes of the payload.
+func (s *Store) PutWithMetadata(ctx context.Context, key string, r io.Reader, meta map[string]string) error {
+	if err := core.ValidateMetadata(meta); err != nil {
+		return err
+	}
+	contentType, body, err := core.DetectContentType(r)
+	if err != nil {
+		return err
+	}
+	_, err = s.put(ctx, key, body, core.PutOptions{ContentType: contentType, Metadata: meta})
+	return err
+}
+
+// Stat returns size, content type, creation time, and metadata for key.
+func (s *Store) Stat(key string) (core.ObjectInfo, error) {
+	_, metaPath, err := s.pathFor(key)
+	if err != nil {
+		return core.ObjectInfo{}, err
+	}
+	mf, err := readMeta(metaPath)
+	if err != nil {
+		return core.ObjectInfo{}, err
+	}
+	return core.ObjectInfo{Size: mf.Size, ContentType: mf.ContentType, CreatedAt: mf.CreatedAt, Metadata: cloneMetadata(mf.Metadata)}, nil
+}
+
 // Get returns the blob content and metadata for key.
 func (s *Store) Get(_ context.Context, key string) (core.Info, io.ReadCloser, error) {
 	dataPath, metaPath, err := s.pathFor(key)
@@ -225,9 +288,42 @@ func (s *Store) PresignURL(_ context.Context, key string, opts core.SignedURLOpt
 	return s.localURL(key), nil
 }
 
+// SignedURL returns a local URL for key carrying an HMAC signature and expiry.
+// A non-positive ttl falls back to core.DefaultSignedURLTTL.
+func (s *Store) SignedURL(key string, ttl time.Duration) (string, error) {
+	if s.signer == nil {
+		return "", core.ErrSigningKeyRequired
+	}
+	clean, err := sanitizeKey(key)
+	if err != nil {
+		return "", err
+	}
+	return s.signer.Sign(clean, ttl)
+}
+
+// VerifySignedURL validates a URL produced by SignedURL and returns its key.
+// Tampered URLs yield core.ErrSignedURLInvalid; stale ones core.ErrSignedURLExpired.
+func (s *Store) VerifySignedURL(raw string) (string, error) {
+	if s.signer == nil {
+		return "", core.ErrSigningKeyRequired
+	}
+	key, err := s.signer.Verify(raw)
+	if err != nil {
+		return "", err
+	}
+	if _, err := sanitizeKey(key); err != nil {
+		return "", fmt.Errorf("%w: %v", core.ErrSignedURLInvalid, err)
+	}
+	return key, nil
+}
+
+// localBaseURL is the stable opaque origin for local URLs. Clients can detect dev by scheme host.
+var localBaseURL = url.URL{Scheme: "http", Host: "local.blob"}
+
 func (s *Store) localURL(key string) string {
-	// Provide a stable opaque URL. Clients can detect dev by scheme host.
-	return (&url.URL{Scheme: "http", Host: "local.blob", Path: "/" + key}).String()
+	u := localBaseURL
+	u.Path = "/" + key
+	return u.String()
 }
 
 // --- helpers ---
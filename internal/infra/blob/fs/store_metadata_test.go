@@ -0,0 +1,63 @@
+package fs
+
+import (
+	"colonycore/internal/blob/core"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPutWithMetadataAndStat(t *testing.T) {
+	store, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	ctx := context.Background()
+	before := time.Now().UTC().Add(-time.Second)
+	meta := map[string]string{"protocol": "prot-1", "project": "proj-1", "requestor": "analyst"}
+	if err := store.PutWithMetadata(ctx, "reports/summary.json", strings.NewReader(`{"rows":3}`), meta); err != nil {
+		t.Fatalf("put with metadata: %v", err)
+	}
+	meta["protocol"] = "mutated"
+
+	info, err := store.Stat("reports/summary.json")
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if info.Size != int64(len(`{"rows":3}`)) {
+		t.Fatalf("expected size %d, got %d", len(`{"rows":3}`), info.Size)
+	}
+	if !strings.HasPrefix(info.ContentType, "text/plain") {
+		t.Fatalf("expected sniffed content type, got %q", info.ContentType)
+	}
+	if info.CreatedAt.Before(before) {
+		t.Fatalf("expected created_at after %v, got %v", before, info.CreatedAt)
+	}
+	if info.Metadata["protocol"] != "prot-1" || info.Metadata["project"] != "proj-1" || info.Metadata["requestor"] != "analyst" {
+		t.Fatalf("unexpected metadata %+v", info.Metadata)
+	}
+
+	if _, err := store.Stat("reports/missing.json"); err == nil {
+		t.Fatalf("expected stat of missing key to fail")
+	}
+	if _, err := store.Stat("../escape"); err == nil {
+		t.Fatalf("expected stat of invalid key to fail")
+	}
+}
+
+func TestPutWithMetadataRejectsOversizedValue(t *testing.T) {
+	store, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	meta := map[string]string{"protocol": strings.Repeat("x", core.MaxMetadataValueBytes+1)}
+	err = store.PutWithMetadata(context.Background(), "reports/big.json", strings.NewReader("{}"), meta)
+	if !errors.Is(err, core.ErrInvalidMetadata) {
+		t.Fatalf("expected ErrInvalidMetadata, got %v", err)
+	}
+	if _, err := store.Stat("reports/big.json"); err == nil {
+		t.Fatalf("expected rejected object to be absent")
+	}
+}
@@ -0,0 +1,103 @@
+package fs
+
+import (
+	"colonycore/internal/blob/core"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSignedStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := NewWithConfig(Config{Root: t.TempDir(), SigningKey: []byte("test-secret")})
+	if err != nil {
+		t.Fatalf("NewWithConfig: %v", err)
+	}
+	return store
+}
+
+func TestSignedURLRoundTrip(t *testing.T) {
+	store := newSignedStore(t)
+	raw, err := store.SignedURL("datasets/export.csv", time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	if !strings.HasPrefix(raw, "http://local.blob/datasets/export.csv?") {
+		t.Fatalf("unexpected signed url %s", raw)
+	}
+	key, err := store.VerifySignedURL(raw)
+	if err != nil {
+		t.Fatalf("VerifySignedURL: %v", err)
+	}
+	if key != "datasets/export.csv" {
+		t.Fatalf("expected key datasets/export.csv, got %s", key)
+	}
+}
+
+func TestSignedURLExpired(t *testing.T) {
+	store := newSignedStore(t)
+	issued := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.signer.SetClock(func() time.Time { return issued })
+	raw, err := store.SignedURL("k", time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	store.signer.SetClock(func() time.Time { return issued.Add(2 * time.Minute) })
+	if _, err := store.VerifySignedURL(raw); !errors.Is(err, core.ErrSignedURLExpired) {
+		t.Fatalf("expected ErrSignedURLExpired, got %v", err)
+	}
+}
+
+func TestSignedURLTampered(t *testing.T) {
+	store := newSignedStore(t)
+	raw, err := store.SignedURL("k", time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	q := u.Query()
+	sig := []byte(q.Get("signature"))
+	if sig[0] == 'a' {
+		sig[0] = 'b'
+	} else {
+		sig[0] = 'a'
+	}
+	q.Set("signature", string(sig))
+	u.RawQuery = q.Encode()
+	if _, err := store.VerifySignedURL(u.String()); !errors.Is(err, core.ErrSignedURLInvalid) {
+		t.Fatalf("expected ErrSignedURLInvalid for signature tamper, got %v", err)
+	}
+
+	u, _ = url.Parse(raw)
+	u.Path = "/other"
+	if _, err := store.VerifySignedURL(u.String()); !errors.Is(err, core.ErrSignedURLInvalid) {
+		t.Fatalf("expected ErrSignedURLInvalid for key tamper, got %v", err)
+	}
+
+	u, _ = url.Parse(raw)
+	q = u.Query()
+	q.Set("expires", "99999999999")
+	u.RawQuery = q.Encode()
+	if _, err := store.VerifySignedURL(u.String()); !errors.Is(err, core.ErrSignedURLInvalid) {
+		t.Fatalf("expected ErrSignedURLInvalid for expiry tamper, got %v", err)
+	}
+}
+
+func TestSignedURLRequiresSigningKey(t *testing.T) {
+	store := newTempStore(t)
+	if _, err := store.SignedURL("k", time.Minute); !errors.Is(err, core.ErrSigningKeyRequired) {
+		t.Fatalf("expected ErrSigningKeyRequired, got %v", err)
+	}
+	if _, err := store.VerifySignedURL("http://local.blob/k"); !errors.Is(err, core.ErrSigningKeyRequired) {
+		t.Fatalf("expected ErrSigningKeyRequired, got %v", err)
+	}
+	signed := newSignedStore(t)
+	if _, err := signed.SignedURL("../escape", time.Minute); err == nil {
+		t.Fatalf("expected traversal key to be rejected")
+	}
+}
@@ -0,0 +1,167 @@
+package fs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// patternReader yields size bytes of a repeating pattern without holding them in memory.
+type patternReader struct {
+	remaining int64
+	offset    int
+}
+
+func (p *patternReader) Read(b []byte) (int, error) {
+	if p.remaining <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(b)) > p.remaining {
+		b = b[:p.remaining]
+	}
+	for i := range b {
+		b[i] = byte('a' + (p.offset+i)%26)
+	}
+	p.offset += len(b)
+	p.remaining -= int64(len(b))
+	return len(b), nil
+}
+
+// hookReader invokes hook once after threshold bytes have been read.
+type hookReader struct {
+	r         io.Reader
+	read      int64
+	threshold int64
+	hook      func() error
+	fired     bool
+}
+
+func (h *hookReader) Read(b []byte) (int, error) {
+	if !h.fired && h.read >= h.threshold {
+		h.fired = true
+		if err := h.hook(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := h.r.Read(b)
+	h.read += int64(n)
+	return n, err
+}
+
+func TestPutStreamingMultiMegabyte(t *testing.T) {
+	store := newTempStore(t)
+	ctx := context.Background()
+	const size = 5<<20 + 123
+
+	n, err := store.PutStreaming(ctx, "exports/large.parquet", &patternReader{remaining: size})
+	if err != nil {
+		t.Fatalf("PutStreaming: %v", err)
+	}
+	if n != size {
+		t.Fatalf("expected %d bytes written, got %d", size, n)
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, &patternReader{remaining: size}); err != nil {
+		t.Fatalf("hash pattern: %v", err)
+	}
+	info, err := store.Head(ctx, "exports/large.parquet")
+	if err != nil {
+		t.Fatalf("Head: %v", err)
+	}
+	if info.Size != size || info.ETag != hex.EncodeToString(h.Sum(nil)) {
+		t.Fatalf("unexpected info after streaming: %+v", info)
+	}
+	if _, err := store.PutStreaming(ctx, "exports/large.parquet", strings.NewReader("x")); err == nil {
+		t.Fatalf("expected duplicate key to fail")
+	}
+}
+
+func TestPutStreamingCancelledMidStreamLeavesNoObject(t *testing.T) {
+	store := newTempStore(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reader := &hookReader{
+		r:         &patternReader{remaining: 4 << 20},
+		threshold: 1 << 20,
+		hook:      func() error { cancel(); return nil },
+	}
+	if _, err := store.PutStreaming(ctx, "exports/cancelled.bin", reader); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	assertNoObject(t, store, "exports/cancelled.bin")
+}
+
+func TestPutStreamingReadErrorLeavesNoObject(t *testing.T) {
+	store := newTempStore(t)
+	boom := errors.New("reader failed")
+	reader := &hookReader{
+		r:         &patternReader{remaining: 2 << 20},
+		threshold: 512 << 10,
+		hook:      func() error { return boom },
+	}
+	if _, err := store.PutStreaming(context.Background(), "exports/broken.bin", reader); !errors.Is(err, boom) {
+		t.Fatalf("expected reader error, got %v", err)
+	}
+	assertNoObject(t, store, "exports/broken.bin")
+}
+
+func TestPutRemovesDataWhenMetaWriteFails(t *testing.T) {
+	store := newTempStore(t)
+	orig := jsonMarshal
+	jsonMarshal = func(any) ([]byte, error) { return nil, errors.New("marshal failed") }
+	defer func() { jsonMarshal = orig }()
+
+	if _, err := store.PutStreaming(context.Background(), "meta/fail.bin", bytes.NewReader([]byte("payload"))); err == nil {
+		t.Fatalf("expected meta write failure")
+	}
+	assertNoObject(t, store, "meta/fail.bin")
+}
+
+func assertNoObject(t *testing.T, store *Store, key string) {
+	t.Helper()
+	if _, err := store.Head(context.Background(), key); err == nil {
+		t.Fatalf("expected %s to be absent", key)
+	}
+	dataPath, _, err := store.pathFor(key)
+	if err != nil {
+		t.Fatalf("pathFor: %v", err)
+	}
+	if _, err := os.Stat(dataPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected no data file for %s, got %v", key, err)
+	}
+	infos, err := store.List(context.Background(), "")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	for _, info := range infos {
+		if info.Key == key {
+			t.Fatalf("expected %s to be absent from listing", key)
+		}
+	}
+	entries, err := os.ReadDir(store.root)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		sub, err := os.ReadDir(store.root + "/" + entry.Name())
+		if err != nil {
+			t.Fatalf("ReadDir sub: %v", err)
+		}
+		for _, f := range sub {
+			if strings.HasPrefix(f.Name(), ".tmp-") {
+				t.Fatalf("expected temp file cleanup, found %s", f.Name())
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"colonycore/internal/blob/core"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"sort"
@@ -17,14 +18,27 @@ type blobEntry struct {
 	data []byte
 }
 
+// MaxStreamingSize caps the payload PutStreaming accepts. The memory driver
+// keeps every blob in process memory, so unlike the filesystem driver it
+// cannot stream a payload through to storage.
+const MaxStreamingSize = 64 << 20
+
+// ErrStreamTooLarge is returned by PutStreaming when the payload exceeds
+// MaxStreamingSize.
+var ErrStreamTooLarge = errors.New("stream exceeds the memory blob size limit")
+
 // Store implements core.Store backed by process memory. Intended for tests.
 type Store struct {
 	mu   sync.RWMutex
 	objs map[string]blobEntry
+
+	maxStreamingSize int64
 }
 
 // New returns an in-memory blob store.
-func New() *Store { return &Store{objs: make(map[string]blobEntry)} }
+func New() *Store {
+	return &Store{objs: make(map[string]blobEntry), maxStreamingSize: MaxStreamingSize}
+}
 
 // Driver returns the blob driver identifier.
 func (s *Store) Driver() core.Driver { return core.DriverMemory }
@@ -46,6 +60,68 @@ func (s *Store) Put(_ context.Context, key string, r io.Reader, opts core.PutOpt
 	return info, nil
 }
 
+// PutStreaming reads r in chunks and stores the payload under key. The whole
+// payload is held in memory, so streams larger than MaxStreamingSize fail with
+// ErrStreamTooLarge as soon as they cross the limit. The blob is only
+// published once the reader is fully drained, so cancellation, a read error,
+// or an oversized stream leaves no entry behind.
+func (s *Store) PutStreaming(ctx context.Context, key string, r io.Reader) (int64, error) {
+	s.mu.RLock()
+	_, exists := s.objs[key]
+	s.mu.RUnlock()
+	if exists {
+		return 0, fmt.Errorf("blob %s already exists", key)
+	}
+	buf := &cappedBuffer{limit: s.maxStreamingSize}
+	if _, err := core.CopyChunks(ctx, buf, r, core.DefaultStreamChunkSize); err != nil {
+		return 0, err
+	}
+	info, err := s.Put(ctx, key, &buf.buf, core.PutOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return info.Size, nil
+}
+
+// cappedBuffer buffers writes until they would exceed limit bytes.
+type cappedBuffer struct {
+	buf   bytes.Buffer
+	limit int64
+}
+
+func (c *cappedBuffer) Write(p []byte) (int, error) {
+	if int64(c.buf.Len())+int64(len(p)) > c.limit {
+		return 0, fmt.Errorf("%w of %d bytes", ErrStreamTooLarge, c.limit)
+	}
+	return c.buf.Write(p)
+}
+
+// PutWithMetadata stores r under key with validated provenance metadata and a
+// content type sniffed from the payload.
+func (s *Store) PutWithMetadata(ctx context.Context, key string, r io.Reader, meta map[string]string) error {
+	if err := core.ValidateMetadata(meta); err != nil {
+		return err
+	}
+	contentType, body, err := core.DetectContentType(r)
+	if err != nil {
+		return err
+	}
+	_, err = s.Put(ctx, key, body, core.PutOptions{ContentType: contentType, Metadata: meta})
+	return err
+}
+
+// Stat returns size, content type, creation time, and metadata for key.
+// Memory blobs are immutable so the last-modified time is the creation time.
+func (s *Store) Stat(key string) (core.ObjectInfo, error) {
+	s.mu.RLock()
+	obj, ok := s.objs[key]
+	s.mu.RUnlock()
+	if !ok {
+		return core.ObjectInfo{}, fmt.Errorf("blob %s not found", key)
+	}
+	return core.ObjectInfo{Size: obj.info.Size, ContentType: obj.info.ContentType, CreatedAt: obj.info.LastModified, Metadata: cloneMetadata(obj.info.Metadata)}, nil
+}
+
 // Get returns blob metadata and a read closer to its content.
 func (s *Store) Get(_ context.Context, key string) (core.Info, io.ReadCloser, error) {
 	s.mu.RLock()
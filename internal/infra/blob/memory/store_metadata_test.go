@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"colonycore/internal/blob/core"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPutWithMetadataAndStat(t *testing.T) {
+	store := New()
+	ctx := context.Background()
+	meta := map[string]string{"protocol": "prot-1", "project": "proj-1"}
+	if err := store.PutWithMetadata(ctx, "reports/summary.csv", strings.NewReader("a,b\n1,2\n"), meta); err != nil {
+		t.Fatalf("put with metadata: %v", err)
+	}
+	meta["protocol"] = "mutated"
+
+	info, err := store.Stat("reports/summary.csv")
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if info.Size != 8 || !strings.HasPrefix(info.ContentType, "text/plain") || info.CreatedAt.IsZero() {
+		t.Fatalf("unexpected object info %+v", info)
+	}
+	if info.Metadata["protocol"] != "prot-1" || info.Metadata["project"] != "proj-1" {
+		t.Fatalf("unexpected metadata %+v", info.Metadata)
+	}
+	info.Metadata["project"] = "mutated"
+	again, _ := store.Stat("reports/summary.csv")
+	if again.Metadata["project"] != "proj-1" {
+		t.Fatalf("expected stat to return a metadata copy")
+	}
+
+	if err := store.PutWithMetadata(ctx, "reports/summary.csv", strings.NewReader("x"), meta); err == nil {
+		t.Fatalf("expected duplicate key to fail")
+	}
+	if _, err := store.Stat("reports/missing.csv"); err == nil {
+		t.Fatalf("expected stat of missing key to fail")
+	}
+}
+
+func TestPutWithMetadataRejectsOversizedValue(t *testing.T) {
+	store := New()
+	meta := map[string]string{"protocol": strings.Repeat("x", core.MaxMetadataValueBytes+1)}
+	if err := store.PutWithMetadata(context.Background(), "big", strings.NewReader("{}"), meta); !errors.Is(err, core.ErrInvalidMetadata) {
+		t.Fatalf("expected ErrInvalidMetadata, got %v", err)
+	}
+	if _, err := store.Stat("big"); err == nil {
+		t.Fatalf("expected rejected object to be absent")
+	}
+	if err := store.PutWithMetadata(context.Background(), "broken", failingReader{}, map[string]string{"k": "v"}); err == nil {
+		t.Fatalf("expected reader error, got %v", err)
+	}
+}
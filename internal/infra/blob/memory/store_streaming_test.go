@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+type partialReader struct {
+	data []byte
+	err  error
+}
+
+func (f *partialReader) Read(b []byte) (int, error) {
+	if len(f.data) == 0 {
+		return 0, f.err
+	}
+	n := copy(b, f.data)
+	f.data = f.data[n:]
+	return n, nil
+}
+
+func TestPutStreaming(t *testing.T) {
+	store := New()
+	ctx := context.Background()
+	payload := bytes.Repeat([]byte("z"), 3<<20)
+
+	n, err := store.PutStreaming(ctx, "stream/ok", bytes.NewReader(payload))
+	if err != nil || n != int64(len(payload)) {
+		t.Fatalf("PutStreaming: n=%d err=%v", n, err)
+	}
+	_, rc, err := store.Get(ctx, "stream/ok")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, _ := io.ReadAll(rc)
+	_ = rc.Close()
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("streamed payload mismatch")
+	}
+	if _, err := store.PutStreaming(ctx, "stream/ok", bytes.NewReader(nil)); err == nil {
+		t.Fatalf("expected duplicate key to fail")
+	}
+
+	boom := errors.New("boom")
+	if _, err := store.PutStreaming(ctx, "stream/broken", &partialReader{data: []byte("partial"), err: boom}); !errors.Is(err, boom) {
+		t.Fatalf("expected reader error, got %v", err)
+	}
+	if _, err := store.Head(ctx, "stream/broken"); err == nil {
+		t.Fatalf("expected no object after read error")
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := store.PutStreaming(cancelled, "stream/cancelled", bytes.NewReader(payload)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if _, err := store.Head(ctx, "stream/cancelled"); err == nil {
+		t.Fatalf("expected no object after cancellation")
+	}
+}
+
+func TestPutStreamingRejectsOversizedStreams(t *testing.T) {
+	store := New()
+	store.maxStreamingSize = 1 << 20
+	ctx := context.Background()
+
+	if _, err := store.PutStreaming(ctx, "stream/at-limit", bytes.NewReader(bytes.Repeat([]byte("a"), 1<<20))); err != nil {
+		t.Fatalf("expected a stream at the limit to be stored: %v", err)
+	}
+	oversized := io.MultiReader(bytes.NewReader(bytes.Repeat([]byte("b"), 1<<20)), bytes.NewReader([]byte("!")))
+	if _, err := store.PutStreaming(ctx, "stream/oversized", oversized); !errors.Is(err, ErrStreamTooLarge) {
+		t.Fatalf("expected ErrStreamTooLarge, got %v", err)
+	}
+	if _, err := store.Head(ctx, "stream/oversized"); err == nil {
+		t.Fatalf("expected no object after an oversized stream")
+	}
+	if New().maxStreamingSize != MaxStreamingSize {
+		t.Fatalf("expected New to apply MaxStreamingSize")
+	}
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"fmt"
+
+	"colonycore/pkg/domain"
+	"colonycore/pkg/domain/entitymodel"
+)
+
+// WithAttachmentBlobs configures the blob store consulted when observation
+// attachments are linked. Without it AttachObservationFile fails with
+// domain.ErrAttachmentBlobsUnavailable.
+func WithAttachmentBlobs(blobs domain.AttachmentBlobStore) StoreOption {
+	return func(s *Store) {
+		s.blobs = blobs
+	}
+}
+
+// AttachmentBlobs returns the configured attachment blob store, or nil.
+func (s *Store) AttachmentBlobs() domain.AttachmentBlobStore {
+	return s.blobs
+}
+
+// AttachObservationFile links an existing blob to an observation. The blob
+// must exist in the configured attachment blob store, and a key may only be
+// attached once per observation.
+func (tx *transaction) AttachObservationFile(observationID string, ref domain.AttachmentRef) (Observation, error) {
+	current, ok := tx.state.observations[observationID]
+	if !ok {
+		return Observation{Observation: entitymodel.Observation{}}, fmt.Errorf("observation %q not found", observationID)
+	}
+	resolved, err := domain.ResolveAttachment(tx.store.blobs, ref)
+	if err != nil {
+		return Observation{Observation: entitymodel.Observation{}}, err
+	}
+	for _, existing := range current.Attachments {
+		if existing.Key == resolved.Key {
+			return Observation{Observation: entitymodel.Observation{}}, fmt.Errorf("observation %q already has attachment %q", observationID, resolved.Key)
+		}
+	}
+	before := cloneObservation(current)
+	current.Attachments = append(current.Attachments, resolved)
+	current.UpdatedAt = tx.now
+	tx.state.observations[observationID] = cloneObservation(current)
+	tx.recordChange(Change{Entity: domain.EntityObservation, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneObservation(current))})
+	return cloneObservation(current), nil
+}
@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"fmt"
+	"reflect"
+
+	"colonycore/pkg/domain"
+)
+
+// ErrAttributesTooComplex reports a plugin extension payload that exceeds the
+// store's configured attribute limits. Depth counts the payload object itself
+// as one level, with each nested object or array adding another; Keys counts
+// object keys at every level of the payload.
+type ErrAttributesTooComplex struct {
+	Entity   domain.EntityType
+	ID       string
+	Hook     string
+	Plugin   string
+	Depth    int
+	Keys     int
+	MaxDepth int
+	MaxKeys  int
+}
+
+func (e ErrAttributesTooComplex) Error() string {
+	if e.MaxDepth > 0 && e.Depth > e.MaxDepth {
+		return fmt.Sprintf("%s %q %s payload for plugin %s is nested %d levels deep, exceeding limit %d", e.Entity, e.ID, e.Hook, e.Plugin, e.Depth, e.MaxDepth)
+	}
+	return fmt.Sprintf("%s %q %s payload for plugin %s has %d keys, exceeding limit %d", e.Entity, e.ID, e.Hook, e.Plugin, e.Keys, e.MaxKeys)
+}
+
+// WithAttributeLimits bounds the nesting depth and total key count of each
+// plugin payload stored in an entity's extension attributes. Creates and
+// updates that exceed either limit fail with ErrAttributesTooComplex.
+// Non-positive values leave the corresponding dimension unlimited, which is
+// the default.
+func WithAttributeLimits(maxDepth, maxKeys int) StoreOption {
+	return func(s *Store) {
+		s.maxAttributeDepth = max(maxDepth, 0)
+		s.maxAttributeKeys = max(maxKeys, 0)
+	}
+}
+
+// extensionPayloads is satisfied by the extension containers returned from the
+// domain entity *Extensions accessors.
+type extensionPayloads interface {
+	Raw() map[string]map[string]any
+}
+
+// checkAttributeLimits measures every plugin payload in the container returned
+// by extensions against the store limits.
+func checkAttributeLimits[C extensionPayloads](tx *transaction, entity domain.EntityType, id string, extensions func() (C, error)) error {
+	maxDepth, maxKeys := tx.store.maxAttributeDepth, tx.store.maxAttributeKeys
+	if maxDepth == 0 && maxKeys == 0 {
+		return nil
+	}
+	container, err := extensions()
+	if err != nil {
+		return fmt.Errorf("%s %q: load extensions: %w", entity, id, err)
+	}
+	raw := container.Raw()
+	for _, hook := range sortedKeys(raw) {
+		plugins := raw[hook]
+		for _, plugin := range sortedKeys(plugins) {
+			depth, keys := measureAttributes(plugins[plugin])
+			if (maxDepth > 0 && depth > maxDepth) || (maxKeys > 0 && keys > maxKeys) {
+				return ErrAttributesTooComplex{
+					Entity:   entity,
+					ID:       id,
+					Hook:     hook,
+					Plugin:   plugin,
+					Depth:    depth,
+					Keys:     keys,
+					MaxDepth: maxDepth,
+					MaxKeys:  maxKeys,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// measureAttributes returns the container nesting depth of value and the
+// number of object keys it holds at every level. Typed maps and slices count
+// the same as their JSON-decoded equivalents.
+func measureAttributes(value any) (depth, keys int) {
+	if value == nil {
+		return 0, 0
+	}
+	v := reflect.ValueOf(value)
+	var children []reflect.Value
+	switch v.Kind() {
+	case reflect.Map:
+		keys = v.Len()
+		iter := v.MapRange()
+		for iter.Next() {
+			children = append(children, iter.Value())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			children = append(children, v.Index(i))
+		}
+	default:
+		return 0, 0
+	}
+	deepest := 0
+	for _, child := range children {
+		if !child.IsValid() || !child.CanInterface() {
+			continue
+		}
+		childDepth, childKeys := measureAttributes(child.Interface())
+		deepest = max(deepest, childDepth)
+		keys += childKeys
+	}
+	return deepest + 1, keys
+}
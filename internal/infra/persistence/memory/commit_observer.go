@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+
+	"colonycore/pkg/domain"
+)
+
+// AddCommitObserver registers sink to receive the changes of every transaction
+// that commits at least one change. Observers run synchronously after the
+// commit, outside the store lock, in registration order. Publish errors are
+// discarded: the transaction has already committed and observers own their
+// error reporting. A nil sink is ignored.
+func (s *Store) AddCommitObserver(sink domain.ChangeSink) {
+	if sink == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	observers := make([]domain.ChangeSink, len(s.observers), len(s.observers)+1)
+	copy(observers, s.observers)
+	s.observers = append(observers, sink)
+}
+
+// committedTransaction carries what a successful commit must publish once the
+// store lock is released.
+type committedTransaction struct {
+	changes   []Change
+	observers []domain.ChangeSink
+}
+
+func (c committedTransaction) notify(ctx context.Context) {
+	if len(c.changes) == 0 {
+		return
+	}
+	for _, sink := range c.observers {
+		_ = sink.Publish(ctx, append([]Change(nil), c.changes...))
+	}
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestCommitObserversReceiveCommittedChanges(t *testing.T) {
+	store := NewStore(nil)
+	var batches [][]domain.Change
+	store.AddCommitObserver(nil)
+	store.AddCommitObserver(domain.ChangeSinkFunc(func(_ context.Context, changes []domain.Change) error {
+		batches = append(batches, changes)
+		return errors.New("observer failure is discarded")
+	}))
+	ctx := context.Background()
+
+	var created Organism
+	if _, err := store.RunInTransaction(ctx, func(tx Transaction) error {
+		var err error
+		created, err = tx.CreateOrganism(Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus"}})
+		return err
+	}); err != nil {
+		t.Fatalf("expected observer error to be discarded, got %v", err)
+	}
+	if len(batches) != 1 || len(batches[0]) != 1 {
+		t.Fatalf("expected one batch with the committed change, got %+v", batches)
+	}
+	if change := batches[0][0]; change.Entity != domain.EntityOrganism || change.Action != domain.ActionCreate {
+		t.Fatalf("unexpected change %s %s", change.Entity, change.Action)
+	}
+	if _, ok := store.GetOrganism(created.ID); !ok {
+		t.Fatalf("expected organism committed despite observer error")
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(Transaction) error { return nil }); err != nil {
+		t.Fatalf("empty transaction: %v", err)
+	}
+	if _, err := store.RunInTransaction(ctx, func(tx Transaction) error {
+		if _, err := tx.CreateOrganism(Organism{Organism: entitymodel.Organism{Name: "Toad", Species: "Bufo"}}); err != nil {
+			return err
+		}
+		return errors.New("abort")
+	}); err == nil {
+		t.Fatalf("expected aborted transaction")
+	}
+	store.RulesEngine().Register(blockingRule{})
+	if _, err := store.RunInTransaction(ctx, func(tx Transaction) error {
+		_, err := tx.UpdateOrganism(created.ID, func(o *Organism) error {
+			o.Name = "Blocked"
+			return nil
+		})
+		return err
+	}); err == nil {
+		t.Fatalf("expected blocking rule to reject transaction")
+	}
+	if len(batches) != 1 {
+		t.Fatalf("expected empty, aborted, and blocked transactions not to notify, got %d batches", len(batches))
+	}
+}
@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"fmt"
+	"strings"
+
+	"colonycore/pkg/domain"
+)
+
+// WithIDPrefixes prefixes generated IDs by entity type (for example
+// "org_" for organisms) so they identify their entity in logs. The random
+// suffix is unchanged, and caller-supplied IDs are kept as given unless they
+// carry the prefix of a different entity type, in which case the create is
+// rejected. Empty prefixes are ignored.
+func WithIDPrefixes(prefixes map[domain.EntityType]string) StoreOption {
+	return func(s *Store) {
+		s.idPrefixes = make(map[domain.EntityType]string, len(prefixes))
+		for entity, prefix := range prefixes {
+			if prefix != "" {
+				s.idPrefixes[entity] = prefix
+			}
+		}
+	}
+}
+
+// newEntityID returns a fresh ID carrying the configured prefix for entity.
+func (s *Store) newEntityID(entity domain.EntityType) string {
+	return s.idPrefixes[entity] + s.newID()
+}
+
+// checkIDPrefix rejects a supplied ID that starts with another entity type's
+// prefix, unless its own prefix matches at least as much of the ID.
+func (s *Store) checkIDPrefix(entity domain.EntityType, id string) error {
+	matched := 0
+	if own := s.idPrefixes[entity]; own != "" && strings.HasPrefix(id, own) {
+		matched = len(own)
+	}
+	var owner domain.EntityType
+	var found string
+	for other, prefix := range s.idPrefixes {
+		if other == entity || len(prefix) <= matched || !strings.HasPrefix(id, prefix) {
+			continue
+		}
+		if len(prefix) > len(found) || (len(prefix) == len(found) && other < owner) {
+			owner, found = other, prefix
+		}
+	}
+	if found == "" {
+		return nil
+	}
+	return fmt.Errorf("%s id %q has the %s prefix %q", entity, id, owner, found)
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"colonycore/pkg/domain"
+)
+
+// RepairPolicy selects how an import treats a line, permit, project, or supply
+// item whose required reference list is left empty once references to records
+// missing from the snapshot are dropped.
+type RepairPolicy string
+
+const (
+	// RepairPolicyDelete drops the record, cascading as for any other missing
+	// record: strains of a deleted line are dropped and references to a
+	// deleted line or project are cleared.
+	RepairPolicyDelete RepairPolicy = "delete"
+	// RepairPolicyKeep retains the record with an empty list and flags it in
+	// the ImportReport. It is the default, matching earlier releases.
+	RepairPolicyKeep RepairPolicy = "keep"
+	// RepairPolicyFail aborts the import with ErrRequiredListEmptied and leaves
+	// the store unchanged.
+	RepairPolicyFail RepairPolicy = "fail"
+)
+
+const defaultRepairPolicy = RepairPolicyKeep
+
+// ErrRequiredListEmptied reports an import aborted under RepairPolicyFail.
+var ErrRequiredListEmptied = errors.New("import would empty required reference lists")
+
+// EmptyListRepair identifies a required reference list emptied during import.
+type EmptyListRepair struct {
+	Entity domain.EntityType `json:"entity"`
+	ID     string            `json:"id"`
+	Field  string            `json:"field"`
+}
+
+// ImportReport describes the repairs an import applied. EmptiedLists is sorted
+// by entity, ID, and field; under RepairPolicyDelete the listed records were
+// removed, otherwise they were kept with the emptied list. Received counts the
+// records in the imported snapshot and Dropped those the import removed, such
+// as records failing validation or left without a required parent.
+type ImportReport struct {
+	Policy       RepairPolicy      `json:"policy"`
+	EmptiedLists []EmptyListRepair `json:"emptied_lists,omitempty"`
+	Received     SnapshotStats     `json:"received"`
+	Dropped      SnapshotStats     `json:"dropped"`
+}
+
+// ImportOption configures Import and ImportState.
+type ImportOption func(*importOptions)
+
+type importOptions struct {
+	repairPolicy RepairPolicy
+}
+
+// WithRepairPolicy selects how records with emptied required reference lists
+// are handled. Unknown policies make the import fail.
+func WithRepairPolicy(policy RepairPolicy) ImportOption {
+	return func(o *importOptions) {
+		o.repairPolicy = policy
+	}
+}
+
+// Import replaces the store state with the provided snapshot and reports the
+// repairs applied. A snapshot stamped with a newer schema version than this
+// binary fails with ErrSnapshotTooNew; like a RepairPolicyFail abort, this
+// leaves the store unchanged.
+func (s *Store) Import(snapshot Snapshot, opts ...ImportOption) (ImportReport, error) {
+	if err := CheckSnapshotVersion(snapshot.SchemaVersion); err != nil {
+		return ImportReport{}, err
+	}
+	cfg := importOptions{repairPolicy: defaultRepairPolicy}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	switch cfg.repairPolicy {
+	case RepairPolicyDelete, RepairPolicyKeep, RepairPolicyFail:
+	default:
+		return ImportReport{}, fmt.Errorf("unknown repair policy %q", cfg.repairPolicy)
+	}
+	received := snapshot.Stats()
+	migrated, emptied := migrateSnapshotWithPolicy(snapshot, cfg.repairPolicy)
+	report := ImportReport{Policy: cfg.repairPolicy, EmptiedLists: emptied, Received: received, Dropped: received.Sub(migrated.Stats())}
+	if cfg.repairPolicy == RepairPolicyFail && len(emptied) > 0 {
+		return report, fmt.Errorf("%w: %s", ErrRequiredListEmptied, describeEmptyListRepairs(emptied))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = memoryStateFromSnapshot(migrated)
+	if s.nameIndex != nil {
+		s.nameIndex.rebuild(s.state.organisms)
+	}
+	return report, nil
+}
+
+func sortEmptyListRepairs(repairs []EmptyListRepair) {
+	sort.Slice(repairs, func(i, j int) bool {
+		a, b := repairs[i], repairs[j]
+		if a.Entity != b.Entity {
+			return a.Entity < b.Entity
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Field < b.Field
+	})
+}
+
+func describeEmptyListRepairs(repairs []EmptyListRepair) string {
+	parts := make([]string, len(repairs))
+	for i, r := range repairs {
+		parts[i] = fmt.Sprintf("%s %q %s", r.Entity, r.ID, r.Field)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// orphanedLineSnapshot holds a line whose only genotype marker is missing from
+// the snapshot, along with a strain, organism, and breeding unit that depend
+// on it.
+func orphanedLineSnapshot() Snapshot {
+	lineID, strainID := "line-1", "strain-1"
+	return Snapshot{
+		Lines: map[string]Line{
+			lineID: {Line: entitymodel.Line{ID: lineID, Code: "L1", Name: "Line", Origin: "lab", GenotypeMarkerIDs: []string{"marker-gone"}}},
+		},
+		Strains: map[string]Strain{
+			strainID: {Strain: entitymodel.Strain{ID: strainID, Code: "S1", Name: "Strain", LineID: lineID}},
+		},
+		Organisms: map[string]Organism{
+			"organism-1": {Organism: entitymodel.Organism{ID: "organism-1", Name: "Subject", Species: "mouse", LineID: &lineID, StrainID: &strainID}},
+		},
+		Breeding: map[string]BreedingUnit{
+			"breeding-1": {BreedingUnit: entitymodel.BreedingUnit{ID: "breeding-1", Name: "Pair", LineID: &lineID, StrainID: &strainID}},
+		},
+	}
+}
+
+func TestImportRepairPolicyForEmptiedLineMarkers(t *testing.T) {
+	want := []EmptyListRepair{{Entity: domain.EntityLine, ID: "line-1", Field: "genotype_marker_ids"}}
+
+	t.Run("delete", func(t *testing.T) {
+		store := NewStore(nil)
+		report, err := store.Import(orphanedLineSnapshot(), WithRepairPolicy(RepairPolicyDelete))
+		if err != nil {
+			t.Fatalf("import: %v", err)
+		}
+		if report.Policy != RepairPolicyDelete || !reflect.DeepEqual(report.EmptiedLists, want) {
+			t.Fatalf("unexpected report %+v", report)
+		}
+		if report.Received.Total() != 4 || report.Dropped != (SnapshotStats{Lines: 1, Strains: 1}) {
+			t.Fatalf("expected the line and its strain counted as dropped, got received %+v dropped %+v", report.Received, report.Dropped)
+		}
+		if _, ok := store.GetLine("line-1"); ok {
+			t.Fatalf("expected line with emptied markers deleted")
+		}
+		if strains := store.ListStrains(); len(strains) != 0 {
+			t.Fatalf("expected strains of deleted line dropped, got %+v", strains)
+		}
+		organism, ok := store.GetOrganism("organism-1")
+		if !ok || organism.LineID != nil || organism.StrainID != nil {
+			t.Fatalf("expected organism kept with line and strain cleared, got %+v", organism)
+		}
+		breeding, ok := store.GetBreedingUnit("breeding-1")
+		if !ok || breeding.LineID != nil || breeding.StrainID != nil {
+			t.Fatalf("expected breeding unit kept with line and strain cleared, got %+v", breeding)
+		}
+	})
+
+	t.Run("keep", func(t *testing.T) {
+		store := NewStore(nil)
+		report, err := store.Import(orphanedLineSnapshot(), WithRepairPolicy(RepairPolicyKeep))
+		if err != nil {
+			t.Fatalf("import: %v", err)
+		}
+		if report.Policy != RepairPolicyKeep || !reflect.DeepEqual(report.EmptiedLists, want) {
+			t.Fatalf("unexpected report %+v", report)
+		}
+		if report.Received.Total() != 4 || report.Dropped.Total() != 0 {
+			t.Fatalf("expected nothing dropped, got received %+v dropped %+v", report.Received, report.Dropped)
+		}
+		line, ok := store.GetLine("line-1")
+		if !ok || len(line.GenotypeMarkerIDs) != 0 {
+			t.Fatalf("expected line kept with empty markers, got %+v", line)
+		}
+		if strains := store.ListStrains(); len(strains) != 1 {
+			t.Fatalf("expected strain kept, got %+v", strains)
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		store := NewStore(nil)
+		report, err := store.Import(orphanedLineSnapshot(), WithRepairPolicy(RepairPolicyFail))
+		if !errors.Is(err, ErrRequiredListEmptied) {
+			t.Fatalf("expected ErrRequiredListEmptied, got %v", err)
+		}
+		if !reflect.DeepEqual(report.EmptiedLists, want) {
+			t.Fatalf("expected failing report to list emptied line, got %+v", report)
+		}
+		if lines := store.ListLines(); len(lines) != 0 {
+			t.Fatalf("expected store left unchanged, got %+v", lines)
+		}
+	})
+
+	t.Run("default keeps", func(t *testing.T) {
+		store := NewStore(nil)
+		if err := store.ImportState(orphanedLineSnapshot()); err != nil {
+			t.Fatalf("import state: %v", err)
+		}
+		if _, ok := store.GetLine("line-1"); !ok {
+			t.Fatalf("expected default policy to keep line")
+		}
+	})
+}
+
+func TestImportRepairPolicyCascadesDeletedProjects(t *testing.T) {
+	projectID := "project-1"
+	snapshot := Snapshot{
+		Facilities: map[string]Facility{
+			"facility-1": {Facility: entitymodel.Facility{ID: "facility-1", Code: "VIV", Name: "Vivarium"}},
+		},
+		Projects: map[string]Project{
+			projectID: {Project: entitymodel.Project{ID: projectID, Code: "PRJ", Title: "Project", FacilityIDs: []string{"facility-gone"}}},
+		},
+		Organisms: map[string]Organism{
+			"organism-1": {Organism: entitymodel.Organism{ID: "organism-1", Name: "Subject", Species: "mouse", ProjectID: &projectID}},
+		},
+		Supplies: map[string]SupplyItem{
+			"supply-1": {SupplyItem: entitymodel.SupplyItem{ID: "supply-1", SKU: "SKU", Name: "Gloves", FacilityIDs: []string{"facility-1"}, ProjectIDs: []string{projectID}}},
+		},
+	}
+	store := NewStore(nil)
+	report, err := store.Import(snapshot, WithRepairPolicy(RepairPolicyDelete))
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	want := []EmptyListRepair{
+		{Entity: domain.EntityProject, ID: projectID, Field: "facility_ids"},
+		{Entity: domain.EntitySupplyItem, ID: "supply-1", Field: "project_ids"},
+	}
+	if !reflect.DeepEqual(report.EmptiedLists, want) {
+		t.Fatalf("unexpected emptied lists %+v", report.EmptiedLists)
+	}
+	if projects := store.ListProjects(); len(projects) != 0 {
+		t.Fatalf("expected project deleted, got %+v", projects)
+	}
+	if supplies := store.ListSupplyItems(); len(supplies) != 0 {
+		t.Fatalf("expected supply item with no surviving projects deleted, got %+v", supplies)
+	}
+	if organism, ok := store.GetOrganism("organism-1"); !ok || organism.ProjectID != nil {
+		t.Fatalf("expected organism project cleared, got %+v", organism)
+	}
+}
+
+func TestImportRejectsUnknownRepairPolicy(t *testing.T) {
+	store := NewStore(nil)
+	if _, err := store.Import(Snapshot{}, WithRepairPolicy("shrug")); err == nil {
+		t.Fatalf("expected unknown repair policy error")
+	}
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FindLinesByTag returns the lines carrying every given tag, compared
+// case-insensitively, ordered by ID. Calling it without tags, or with a blank
+// tag, returns no lines.
+func (v transactionView) FindLinesByTag(tags ...string) []Line {
+	if len(tags) == 0 {
+		return nil
+	}
+	want := make([]string, len(tags))
+	for i, tag := range tags {
+		want[i] = normalizeLineTag(tag)
+		if want[i] == "" {
+			return nil
+		}
+	}
+	var out []Line
+	for _, line := range v.state.lines {
+		have := make(map[string]struct{}, len(line.Tags))
+		for _, tag := range line.Tags {
+			have[normalizeLineTag(tag)] = struct{}{}
+		}
+		matched := true
+		for _, tag := range want {
+			if _, ok := have[tag]; !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			out = append(out, cloneLine(line))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// FindLinesByTag exposes tag search within the transaction scope.
+func (tx *transaction) FindLinesByTag(tags ...string) []Line {
+	return tx.view().FindLinesByTag(tags...)
+}
+
+// validateLineTags rejects blank tags and tags repeated regardless of case.
+func validateLineTags(tags []string) error {
+	seen := make(map[string]struct{}, len(tags))
+	for i, tag := range tags {
+		key := normalizeLineTag(tag)
+		if key == "" {
+			return fmt.Errorf("line.tags[%d] must not be empty", i)
+		}
+		if _, dup := seen[key]; dup {
+			return fmt.Errorf("line.tags contains duplicate tag %q", tag)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+func normalizeLineTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
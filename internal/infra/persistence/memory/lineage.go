@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// DefaultMaxLineageDepth bounds lineage traversals when no cap is configured.
+const DefaultMaxLineageDepth = 50
+
+// ErrLineageDepthExceeded reports a lineage traversal that reached the store's
+// depth cap while generations remained. Callers should narrow the query rather
+// than rely on a silently truncated pedigree.
+var ErrLineageDepthExceeded = errors.New("lineage depth exceeded")
+
+// StoreOption configures optional Store behaviour.
+type StoreOption func(*Store)
+
+// WithMaxLineageDepth caps how many generations Ancestors and Descendants walk,
+// regardless of the depth requested by callers. Non-positive values keep the
+// default cap.
+func WithMaxLineageDepth(n int) StoreOption {
+	return func(s *Store) {
+		if n > 0 {
+			s.maxLineageDepth = n
+		}
+	}
+}
+
+// Ancestors returns the organisms reachable through ParentIDs from the given
+// organism, nearest generation first. maxDepth limits the walk to that many
+// generations; a non-positive maxDepth walks up to the store cap.
+func (s *Store) Ancestors(organismID string, maxDepth int) ([]Organism, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return WalkAncestors(s.state.organisms, organismID, maxDepth, s.maxLineageDepth)
+}
+
+// Descendants returns the organisms that list the given organism as an
+// ancestor, nearest generation first. maxDepth behaves as for Ancestors.
+func (s *Store) Descendants(organismID string, maxDepth int) ([]Organism, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return WalkDescendants(s.state.organisms, organismID, maxDepth, s.maxLineageDepth)
+}
+
+// WalkAncestors performs Store.Ancestors over organisms keyed by ID, with
+// maxLineageDepth as the store cap; a non-positive cap uses
+// DefaultMaxLineageDepth. Stores that load organisms themselves use it so
+// every backend walks lineage the same way.
+func WalkAncestors(organisms map[string]Organism, organismID string, maxDepth, maxLineageDepth int) ([]Organism, error) {
+	return walkLineage(organisms, organismID, maxDepth, maxLineageDepth, func(o Organism) []string {
+		return o.ParentIDs
+	})
+}
+
+// WalkDescendants performs Store.Descendants over organisms keyed by ID. The
+// depth arguments behave as for WalkAncestors.
+func WalkDescendants(organisms map[string]Organism, organismID string, maxDepth, maxLineageDepth int) ([]Organism, error) {
+	children := make(map[string][]string)
+	for _, organism := range organisms {
+		for _, parentID := range organism.ParentIDs {
+			children[parentID] = append(children[parentID], organism.ID)
+		}
+	}
+	return walkLineage(organisms, organismID, maxDepth, maxLineageDepth, func(o Organism) []string {
+		return children[o.ID]
+	})
+}
+
+// walkLineage performs a breadth-first walk from organismID. The caller's
+// maxDepth truncates quietly; the store cap fails with ErrLineageDepthExceeded
+// when another generation is still pending.
+func walkLineage(organisms map[string]Organism, organismID string, maxDepth, maxLineageDepth int, next func(Organism) []string) ([]Organism, error) {
+	root, ok := organisms[organismID]
+	if !ok {
+		return nil, fmt.Errorf("organism %q not found", organismID)
+	}
+	if maxLineageDepth <= 0 {
+		maxLineageDepth = DefaultMaxLineageDepth
+	}
+	limit, capped := maxLineageDepth, true
+	if maxDepth > 0 && maxDepth <= limit {
+		limit, capped = maxDepth, false
+	}
+	visited := map[string]struct{}{organismID: {}}
+	frontier := []Organism{root}
+	var out []Organism
+	for depth := 0; ; depth++ {
+		var generation []Organism
+		for _, organism := range frontier {
+			for _, id := range next(organism) {
+				if _, seen := visited[id]; seen {
+					continue
+				}
+				relative, ok := organisms[id]
+				if !ok {
+					continue
+				}
+				visited[id] = struct{}{}
+				generation = append(generation, relative)
+			}
+		}
+		if len(generation) == 0 {
+			return out, nil
+		}
+		if depth == limit {
+			if capped {
+				return nil, fmt.Errorf("%w: organism %q has more than %d generations", ErrLineageDepthExceeded, organismID, limit)
+			}
+			return out, nil
+		}
+		sort.Slice(generation, func(i, j int) bool { return generation[i].ID < generation[j].ID })
+		for _, organism := range generation {
+			out = append(out, cloneOrganism(organism))
+		}
+		frontier = generation
+	}
+}
@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// WithNameIndex maintains a sorted secondary index of normalized organism
+// names so FindOrganismsByName answers prefix queries by binary search instead
+// of scanning every organism. The organism create, update, and delete paths
+// record the IDs they write, and the index entries for those IDs are updated
+// once the transaction commits, so rolled-back transactions never reach it.
+// Imports rebuild it. It is off by default.
+func WithNameIndex(enabled bool) StoreOption {
+	return func(s *Store) {
+		if enabled {
+			s.nameIndex = &nameIndex{ids: make(map[string]string)}
+		} else {
+			s.nameIndex = nil
+		}
+	}
+}
+
+// FindOrganismsByName returns committed organisms whose name starts with
+// prefix, ignoring case and surrounding whitespace. Results are ordered by
+// normalized name, then ID. Without WithNameIndex the lookup scans every
+// organism and sorts only the matches.
+func (s *Store) FindOrganismsByName(prefix string) []Organism {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key := normalizeOrganismName(prefix)
+	if s.nameIndex == nil {
+		var matches []nameIndexEntry
+		for id, o := range s.state.organisms {
+			if name := normalizeOrganismName(o.Name); strings.HasPrefix(name, key) {
+				matches = append(matches, nameIndexEntry{key: name, id: id})
+			}
+		}
+		slices.SortFunc(matches, compareNameIndexEntries)
+		out := make([]Organism, 0, len(matches))
+		for _, match := range matches {
+			out = append(out, cloneOrganism(s.state.organisms[match.id]))
+		}
+		return out
+	}
+	ids := s.nameIndex.lookup(key)
+	out := make([]Organism, 0, len(ids))
+	for _, id := range ids {
+		if o, ok := s.state.organisms[id]; ok {
+			out = append(out, cloneOrganism(o))
+		}
+	}
+	return out
+}
+
+// syncNameIndex updates the name index for the organisms a committed
+// transaction wrote. Callers hold s.mu and have already installed the
+// committed state.
+func (s *Store) syncNameIndex(organismIDs []string) {
+	if s.nameIndex == nil {
+		return
+	}
+	for _, id := range organismIDs {
+		if o, exists := s.state.organisms[id]; exists {
+			s.nameIndex.set(id, o.Name)
+		} else {
+			s.nameIndex.remove(id)
+		}
+	}
+}
+
+func normalizeOrganismName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+type nameIndexEntry struct {
+	key string
+	id  string
+}
+
+func compareNameIndexEntries(a, b nameIndexEntry) int {
+	if c := cmp.Compare(a.key, b.key); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.id, b.id)
+}
+
+// nameIndex keeps entries sorted by normalized name, then ID, alongside the
+// key currently indexed for each organism ID.
+type nameIndex struct {
+	entries []nameIndexEntry
+	ids     map[string]string
+}
+
+func (idx *nameIndex) rebuild(organisms map[string]Organism) {
+	idx.entries = make([]nameIndexEntry, 0, len(organisms))
+	idx.ids = make(map[string]string, len(organisms))
+	for id, o := range organisms {
+		key := normalizeOrganismName(o.Name)
+		idx.entries = append(idx.entries, nameIndexEntry{key: key, id: id})
+		idx.ids[id] = key
+	}
+	slices.SortFunc(idx.entries, compareNameIndexEntries)
+}
+
+func (idx *nameIndex) set(id, name string) {
+	key := normalizeOrganismName(name)
+	if current, ok := idx.ids[id]; ok {
+		if current == key {
+			return
+		}
+		idx.remove(id)
+	}
+	entry := nameIndexEntry{key: key, id: id}
+	pos, _ := slices.BinarySearchFunc(idx.entries, entry, compareNameIndexEntries)
+	idx.entries = slices.Insert(idx.entries, pos, entry)
+	idx.ids[id] = key
+}
+
+func (idx *nameIndex) remove(id string) {
+	key, ok := idx.ids[id]
+	if !ok {
+		return
+	}
+	if pos, found := slices.BinarySearchFunc(idx.entries, nameIndexEntry{key: key, id: id}, compareNameIndexEntries); found {
+		idx.entries = slices.Delete(idx.entries, pos, pos+1)
+	}
+	delete(idx.ids, id)
+}
+
+// lookup returns the IDs of entries whose key starts with prefix, in index
+// order.
+func (idx *nameIndex) lookup(prefix string) []string {
+	start, _ := slices.BinarySearchFunc(idx.entries, nameIndexEntry{key: prefix}, compareNameIndexEntries)
+	var ids []string
+	for _, entry := range idx.entries[start:] {
+		if !strings.HasPrefix(entry.key, prefix) {
+			break
+		}
+		ids = append(ids, entry.id)
+	}
+	return ids
+}
@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func organismNames(organisms []Organism) []string {
+	names := make([]string, len(organisms))
+	for i, o := range organisms {
+		names[i] = o.Name
+	}
+	return names
+}
+
+func seedNamedOrganisms(t *testing.T, store *Store, names ...string) map[string]string {
+	t.Helper()
+	ids := make(map[string]string, len(names))
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		for _, name := range names {
+			o, err := tx.CreateOrganism(Organism{Organism: entitymodel.Organism{Name: name, Species: "Xenopus"}})
+			if err != nil {
+				return err
+			}
+			ids[name] = o.ID
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("seed organisms: %v", err)
+	}
+	return ids
+}
+
+func TestFindOrganismsByNamePrefix(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		store := NewStore(nil, WithNameIndex(enabled))
+		seedNamedOrganisms(t, store, "Frog Alpha", "frog beta", "  FROGLET ", "Toad")
+
+		if got, want := organismNames(store.FindOrganismsByName("frog")), []string{"Frog Alpha", "frog beta", "  FROGLET "}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("index=%v: expected %v, got %v", enabled, want, got)
+		}
+		if got, want := organismNames(store.FindOrganismsByName(" FROG  B")), []string{"frog beta"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("index=%v: expected case-insensitive match %v, got %v", enabled, want, got)
+		}
+		if got := store.FindOrganismsByName("newt"); len(got) != 0 {
+			t.Fatalf("index=%v: expected no match, got %v", enabled, organismNames(got))
+		}
+		if got := store.FindOrganismsByName(""); len(got) != 4 {
+			t.Fatalf("index=%v: expected empty prefix to match all organisms, got %d", enabled, len(got))
+		}
+	}
+}
+
+func TestNameIndexTracksUpdatesAndDeletes(t *testing.T) {
+	store := NewStore(nil, WithNameIndex(true))
+	ids := seedNamedOrganisms(t, store, "Frog", "Toad")
+	ctx := context.Background()
+
+	if _, err := store.RunInTransaction(ctx, func(tx Transaction) error {
+		if _, err := tx.UpdateOrganism(ids["Frog"], func(o *Organism) error {
+			o.Name = "Newt"
+			return nil
+		}); err != nil {
+			return err
+		}
+		return tx.DeleteOrganism(ids["Toad"])
+	}); err != nil {
+		t.Fatalf("update and delete: %v", err)
+	}
+	if got := store.FindOrganismsByName("frog"); len(got) != 0 {
+		t.Fatalf("expected renamed organism to leave old key, got %v", organismNames(got))
+	}
+	if got := store.FindOrganismsByName("toad"); len(got) != 0 {
+		t.Fatalf("expected deleted organism to leave index, got %v", organismNames(got))
+	}
+	if got, want := organismNames(store.FindOrganismsByName("NEW")), []string{"Newt"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	snapshot := store.ExportState()
+	reloaded := NewStore(nil, WithNameIndex(true))
+	if err := reloaded.ImportState(snapshot); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if got, want := organismNames(reloaded.FindOrganismsByName("newt")), []string{"Newt"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected import to rebuild index with %v, got %v", want, got)
+	}
+}
+
+func TestNameIndexIgnoresRolledBackTransaction(t *testing.T) {
+	store := NewStore(nil, WithNameIndex(true))
+	ids := seedNamedOrganisms(t, store, "Frog")
+
+	rollback := errors.New("rollback")
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		if _, err := tx.CreateOrganism(Organism{Organism: entitymodel.Organism{Name: "Frogspawn", Species: "Xenopus"}}); err != nil {
+			return err
+		}
+		if _, err := tx.UpdateOrganism(ids["Frog"], func(o *Organism) error {
+			o.Name = "Toad"
+			return nil
+		}); err != nil {
+			return err
+		}
+		return rollback
+	}); !errors.Is(err, rollback) {
+		t.Fatalf("expected rollback error, got %v", err)
+	}
+	if got, want := organismNames(store.FindOrganismsByName("frog")), []string{"Frog"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected only committed organism %v, got %v", want, got)
+	}
+	if got := store.FindOrganismsByName("toad"); len(got) != 0 {
+		t.Fatalf("expected rolled-back rename to stay out of index, got %v", organismNames(got))
+	}
+}
+
+func TestNameIndexMatchesRebuildAfterWrites(t *testing.T) {
+	store := NewStore(nil, WithNameIndex(true))
+	ids := seedNamedOrganisms(t, store, "Frog", "Toad", "Newt")
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		if _, err := tx.UpdateOrganism(ids["Toad"], func(o *Organism) error {
+			o.Name = "Frogmouth"
+			return nil
+		}); err != nil {
+			return err
+		}
+		if err := tx.DeleteOrganism(ids["Newt"]); err != nil {
+			return err
+		}
+		_, err := tx.CreateOrganism(Organism{Organism: entitymodel.Organism{Name: "frog prince", Species: "Xenopus"}})
+		return err
+	}); err != nil {
+		t.Fatalf("write organisms: %v", err)
+	}
+
+	store.mu.RLock()
+	incremental := *store.nameIndex
+	rebuilt := nameIndex{}
+	rebuilt.rebuild(store.state.organisms)
+	store.mu.RUnlock()
+	if !reflect.DeepEqual(incremental.entries, rebuilt.entries) || !reflect.DeepEqual(incremental.ids, rebuilt.ids) {
+		t.Fatalf("expected incremental index %+v to match rebuild %+v", incremental, rebuilt)
+	}
+}
@@ -0,0 +1,65 @@
+package memory
+
+import entitymodel "colonycore/pkg/domain/entitymodel"
+
+// GetFacilityByCode returns the committed facility whose code equals code.
+func (s *Store) GetFacilityByCode(code string) (Facility, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, ok := findByNaturalKey(s.state.facilities, func(f Facility) bool { return f.Code == code })
+	if !ok {
+		return Facility{Facility: entitymodel.Facility{}}, false, nil
+	}
+	return cloneFacility(decorateFacility(&s.state, f)), true, nil
+}
+
+// GetProtocolByCode returns the committed protocol whose code equals code.
+func (s *Store) GetProtocolByCode(code string) (Protocol, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := findByNaturalKey(s.state.protocols, func(p Protocol) bool { return p.Code == code })
+	if !ok {
+		return Protocol{Protocol: entitymodel.Protocol{}}, false, nil
+	}
+	return cloneProtocol(p), true, nil
+}
+
+// GetLineByCode returns the committed line whose code equals code.
+func (s *Store) GetLineByCode(code string) (Line, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	line, ok := findByNaturalKey(s.state.lines, func(l Line) bool { return l.Code == code })
+	if !ok {
+		return Line{Line: entitymodel.Line{}}, false, nil
+	}
+	return cloneLine(line), true, nil
+}
+
+// GetStrainByCode returns the committed strain with code on lineID. Strain
+// codes are unique per line, so the line is part of the key.
+func (s *Store) GetStrainByCode(lineID, code string) (Strain, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	strain, ok := findByNaturalKey(s.state.strains, func(st Strain) bool { return st.LineID == lineID && st.Code == code })
+	if !ok {
+		return Strain{Strain: entitymodel.Strain{}}, false, nil
+	}
+	return cloneStrain(strain), true, nil
+}
+
+// findByNaturalKey returns the record matching a natural key. The stores do
+// not reject duplicate keys on write, so when several records match the one
+// with the lowest ID wins.
+func findByNaturalKey[T any](records map[string]T, match func(T) bool) (T, bool) {
+	var (
+		found   T
+		foundID string
+		ok      bool
+	)
+	for id, record := range records {
+		if match(record) && (!ok || id < foundID) {
+			found, foundID, ok = record, id, true
+		}
+	}
+	return found, ok
+}
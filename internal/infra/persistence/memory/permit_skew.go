@@ -0,0 +1,25 @@
+package memory
+
+import "time"
+
+// WithPermitSkew sets the clock-skew tolerance that rule views report through
+// domain.PermitSkew, so rules checking active permits with
+// domain.IsPermitActive accept permits up to skew outside their validity
+// window. Negative values are treated as zero. The default is zero.
+func WithPermitSkew(skew time.Duration) StoreOption {
+	return func(s *Store) {
+		s.permitSkew = max(skew, 0)
+	}
+}
+
+// PermitSkew returns the permit clock-skew tolerance configured for the store,
+// so readers outside a transaction can build permit views.
+func (s *Store) PermitSkew() time.Duration {
+	return s.permitSkew
+}
+
+// PermitSkew returns the permit clock-skew tolerance configured for the store
+// the view was taken from.
+func (v transactionView) PermitSkew() time.Duration {
+	return v.permitSkew
+}
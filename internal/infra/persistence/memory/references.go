@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"colonycore/pkg/domain"
+)
+
+// referenceWalker visits the stored fields that point at other records.
+// Derived inverse lists such as Facility.HousingUnitIDs are never stored and
+// so are not visited.
+type referenceWalker struct {
+	visit func(ref domain.Reference, target domain.EntityType, targetID string)
+}
+
+func (c referenceWalker) one(source domain.EntityType, sourceID, field string, target domain.EntityType, targetID string) {
+	c.visit(domain.Reference{Entity: source, ID: sourceID, Field: field}, target, targetID)
+}
+
+func (c referenceWalker) optional(source domain.EntityType, sourceID, field string, target domain.EntityType, targetID *string) {
+	if targetID != nil {
+		c.one(source, sourceID, field, target, *targetID)
+	}
+}
+
+func (c referenceWalker) each(source domain.EntityType, sourceID, field string, target domain.EntityType, targetIDs []string) {
+	for _, targetID := range targetIDs {
+		c.one(source, sourceID, field, target, targetID)
+	}
+}
+
+// walkReferences calls visit for every reference stored in state, in map
+// iteration order.
+func walkReferences(state *memoryState, visit func(ref domain.Reference, target domain.EntityType, targetID string)) {
+	c := referenceWalker{visit: visit}
+	for _, o := range state.organisms {
+		c.optional(domain.EntityOrganism, o.ID, "line_id", domain.EntityLine, o.LineID)
+		c.optional(domain.EntityOrganism, o.ID, "strain_id", domain.EntityStrain, o.StrainID)
+		c.each(domain.EntityOrganism, o.ID, "parent_ids", domain.EntityOrganism, o.ParentIDs)
+		c.optional(domain.EntityOrganism, o.ID, "cohort_id", domain.EntityCohort, o.CohortID)
+		c.optional(domain.EntityOrganism, o.ID, "housing_id", domain.EntityHousingUnit, o.HousingID)
+		c.optional(domain.EntityOrganism, o.ID, "protocol_id", domain.EntityProtocol, o.ProtocolID)
+		c.optional(domain.EntityOrganism, o.ID, "project_id", domain.EntityProject, o.ProjectID)
+	}
+	for _, co := range state.cohorts {
+		c.optional(domain.EntityCohort, co.ID, "project_id", domain.EntityProject, co.ProjectID)
+		c.optional(domain.EntityCohort, co.ID, "housing_id", domain.EntityHousingUnit, co.HousingID)
+		c.optional(domain.EntityCohort, co.ID, "protocol_id", domain.EntityProtocol, co.ProtocolID)
+		c.optional(domain.EntityCohort, co.ID, "created_from_breeding_unit_id", domain.EntityBreeding, co.CreatedFromBreedingUnitID)
+	}
+	for _, h := range state.housing {
+		c.one(domain.EntityHousingUnit, h.ID, "facility_id", domain.EntityFacility, h.FacilityID)
+	}
+	for _, b := range state.breeding {
+		c.optional(domain.EntityBreeding, b.ID, "housing_id", domain.EntityHousingUnit, b.HousingID)
+		c.optional(domain.EntityBreeding, b.ID, "protocol_id", domain.EntityProtocol, b.ProtocolID)
+		c.optional(domain.EntityBreeding, b.ID, "line_id", domain.EntityLine, b.LineID)
+		c.optional(domain.EntityBreeding, b.ID, "strain_id", domain.EntityStrain, b.StrainID)
+		c.optional(domain.EntityBreeding, b.ID, "target_line_id", domain.EntityLine, b.TargetLineID)
+		c.optional(domain.EntityBreeding, b.ID, "target_strain_id", domain.EntityStrain, b.TargetStrainID)
+		c.each(domain.EntityBreeding, b.ID, "female_ids", domain.EntityOrganism, b.FemaleIDs)
+		c.each(domain.EntityBreeding, b.ID, "male_ids", domain.EntityOrganism, b.MaleIDs)
+	}
+	for _, l := range state.lines {
+		c.each(domain.EntityLine, l.ID, "genotype_marker_ids", domain.EntityGenotypeMarker, l.GenotypeMarkerIDs)
+	}
+	for _, s := range state.strains {
+		c.one(domain.EntityStrain, s.ID, "line_id", domain.EntityLine, s.LineID)
+		c.each(domain.EntityStrain, s.ID, "genotype_marker_ids", domain.EntityGenotypeMarker, s.GenotypeMarkerIDs)
+	}
+	for _, p := range state.procedures {
+		c.one(domain.EntityProcedure, p.ID, "protocol_id", domain.EntityProtocol, p.ProtocolID)
+		c.optional(domain.EntityProcedure, p.ID, "project_id", domain.EntityProject, p.ProjectID)
+		c.optional(domain.EntityProcedure, p.ID, "cohort_id", domain.EntityCohort, p.CohortID)
+		c.each(domain.EntityProcedure, p.ID, "organism_ids", domain.EntityOrganism, p.OrganismIDs)
+	}
+	for _, t := range state.treatments {
+		c.one(domain.EntityTreatment, t.ID, "procedure_id", domain.EntityProcedure, t.ProcedureID)
+		c.each(domain.EntityTreatment, t.ID, "organism_ids", domain.EntityOrganism, t.OrganismIDs)
+		c.each(domain.EntityTreatment, t.ID, "cohort_ids", domain.EntityCohort, t.CohortIDs)
+	}
+	for _, o := range state.observations {
+		c.optional(domain.EntityObservation, o.ID, "procedure_id", domain.EntityProcedure, o.ProcedureID)
+		c.optional(domain.EntityObservation, o.ID, "organism_id", domain.EntityOrganism, o.OrganismID)
+		c.optional(domain.EntityObservation, o.ID, "cohort_id", domain.EntityCohort, o.CohortID)
+	}
+	for _, s := range state.samples {
+		c.optional(domain.EntitySample, s.ID, "organism_id", domain.EntityOrganism, s.OrganismID)
+		c.optional(domain.EntitySample, s.ID, "cohort_id", domain.EntityCohort, s.CohortID)
+		c.one(domain.EntitySample, s.ID, "facility_id", domain.EntityFacility, s.FacilityID)
+	}
+	for _, p := range state.permits {
+		c.each(domain.EntityPermit, p.ID, "facility_ids", domain.EntityFacility, p.FacilityIDs)
+		c.each(domain.EntityPermit, p.ID, "protocol_ids", domain.EntityProtocol, p.ProtocolIDs)
+	}
+	for _, p := range state.projects {
+		c.each(domain.EntityProject, p.ID, "facility_ids", domain.EntityFacility, p.FacilityIDs)
+		c.each(domain.EntityProject, p.ID, "protocol_ids", domain.EntityProtocol, p.ProtocolIDs)
+	}
+	for _, s := range state.supplies {
+		c.each(domain.EntitySupplyItem, s.ID, "facility_ids", domain.EntityFacility, s.FacilityIDs)
+		c.each(domain.EntitySupplyItem, s.ID, "project_ids", domain.EntityProject, s.ProjectIDs)
+	}
+}
+
+// referencesTo lists every record field in state that points at the given
+// entity, ordered by source entity type, source ID, and field.
+func referencesTo(state *memoryState, entity domain.EntityType, id string) []domain.Reference {
+	var refs []domain.Reference
+	walkReferences(state, func(ref domain.Reference, target domain.EntityType, targetID string) {
+		if target == entity && targetID == id {
+			refs = append(refs, ref)
+		}
+	})
+	sort.Slice(refs, func(i, j int) bool {
+		a, b := refs[i], refs[j]
+		if a.Entity != b.Entity {
+			return a.Entity < b.Entity
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Field < b.Field
+	})
+	return slices.Compact(refs)
+}
+
+// ReferencesTo returns every record that points at the given entity, naming
+// the source entity type, ID, and field.
+func (v transactionView) ReferencesTo(entity domain.EntityType, id string) []domain.Reference {
+	return referencesTo(v.state, entity, id)
+}
+
+// requireUnreferenced fails when a record of one of the blocking entity types
+// still points at the given entity. Blockers are checked in the order given.
+func (tx *transaction) requireUnreferenced(entity domain.EntityType, id string, blockers ...domain.EntityType) error {
+	refs := referencesTo(&tx.state, entity, id)
+	for _, blocker := range blockers {
+		for _, ref := range refs {
+			if ref.Entity == blocker {
+				return fmt.Errorf("%s %q still referenced by %s %q", referenceLabel(entity), id, referenceLabel(ref.Entity), ref.ID)
+			}
+		}
+	}
+	return nil
+}
+
+func referenceLabel(e domain.EntityType) string {
+	return strings.ReplaceAll(string(e), "_", " ")
+}
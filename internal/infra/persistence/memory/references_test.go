@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func referenceFixture() Snapshot {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	organismID := "org-1"
+	return Snapshot{
+		Facilities: map[string]Facility{"fac-1": {Facility: entitymodel.Facility{ID: "fac-1", Name: "Lab"}}},
+		Housing:    map[string]HousingUnit{"hu-1": {HousingUnit: entitymodel.HousingUnit{ID: "hu-1", Name: "Tank", FacilityID: "fac-1", Capacity: 4}}},
+		Protocols:  map[string]Protocol{"prot-1": {Protocol: entitymodel.Protocol{ID: "prot-1", Code: "P", Title: "Protocol", MaxSubjects: 5}}},
+		Projects: map[string]Project{"proj-1": {Project: entitymodel.Project{
+			ID: "proj-1", Code: "PRJ", Title: "Project", FacilityIDs: []string{"fac-1"},
+		}}},
+		Permits: map[string]Permit{"permit-1": {Permit: entitymodel.Permit{
+			ID: "permit-1", PermitNumber: "PERMIT", Authority: "Gov", IssueDate: now, ValidFrom: now, ValidUntil: now.AddDate(1, 0, 0),
+			AllowedActivities: []string{"store"}, FacilityIDs: []string{"fac-1"}, ProtocolIDs: []string{"prot-1"},
+		}}},
+		Supplies: map[string]SupplyItem{"supply-1": {SupplyItem: entitymodel.SupplyItem{
+			ID: "supply-1", SKU: "SKU", Name: "Gloves", QuantityOnHand: 5, Unit: "box", FacilityIDs: []string{"fac-1"}, ProjectIDs: []string{"proj-1"},
+		}}},
+		Organisms: map[string]Organism{organismID: {Organism: entitymodel.Organism{ID: organismID, Name: "Frog", Species: "Xenopus", Stage: domain.StageAdult}}},
+		Samples: map[string]Sample{"sample-1": {Sample: entitymodel.Sample{
+			ID: "sample-1", Identifier: "S-1", SourceType: "blood", FacilityID: "fac-1", OrganismID: &organismID, CollectedAt: now,
+			CollectedBy: "tech", Status: domain.SampleStatusStored, StorageLocation: "freezer", AssayType: "pcr",
+			ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "freezer", Timestamp: now}},
+		}}},
+		Procedures: map[string]Procedure{"proc-1": {Procedure: entitymodel.Procedure{
+			ID: "proc-1", Name: "Exam", Status: domain.ProcedureStatusScheduled, ScheduledAt: now, ProtocolID: "prot-1", OrganismIDs: []string{organismID},
+		}}},
+		Treatments: map[string]Treatment{"treat-1": {Treatment: entitymodel.Treatment{
+			ID: "treat-1", Name: "Dose", ProcedureID: "proc-1", OrganismIDs: []string{organismID},
+			DosagePlan: domain.DosagePlan{Drug: "compound", DoseAmount: 1, DoseUnit: "mg"},
+		}}},
+		Breeding: map[string]BreedingUnit{"breed-1": {BreedingUnit: entitymodel.BreedingUnit{
+			ID: "breed-1", Name: "Pair", Strategy: "pair", FemaleIDs: []string{organismID},
+		}}},
+	}
+}
+
+func TestReferencesToListsEveryReferencingRecord(t *testing.T) {
+	snapshot := referenceFixture()
+	if err := ValidateSnapshot(snapshot); err != nil {
+		t.Fatalf("fixture invalid: %v", err)
+	}
+	store := NewStore(nil)
+	if err := store.ImportState(snapshot); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
+
+	cases := []struct {
+		entity domain.EntityType
+		id     string
+		want   []domain.Reference
+	}{
+		{
+			entity: domain.EntityFacility,
+			id:     "fac-1",
+			want: []domain.Reference{
+				{Entity: domain.EntityHousingUnit, ID: "hu-1", Field: "facility_id"},
+				{Entity: domain.EntityPermit, ID: "permit-1", Field: "facility_ids"},
+				{Entity: domain.EntityProject, ID: "proj-1", Field: "facility_ids"},
+				{Entity: domain.EntitySample, ID: "sample-1", Field: "facility_id"},
+				{Entity: domain.EntitySupplyItem, ID: "supply-1", Field: "facility_ids"},
+			},
+		},
+		{
+			entity: domain.EntityOrganism,
+			id:     "org-1",
+			want: []domain.Reference{
+				{Entity: domain.EntityBreeding, ID: "breed-1", Field: "female_ids"},
+				{Entity: domain.EntityProcedure, ID: "proc-1", Field: "organism_ids"},
+				{Entity: domain.EntitySample, ID: "sample-1", Field: "organism_id"},
+				{Entity: domain.EntityTreatment, ID: "treat-1", Field: "organism_ids"},
+			},
+		},
+		{entity: domain.EntityOrganism, id: "missing"},
+	}
+	for _, tc := range cases {
+		if err := store.View(context.Background(), func(view domain.TransactionView) error {
+			got := view.ReferencesTo(tc.entity, tc.id)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("references to %s %s:\n got %+v\nwant %+v", tc.entity, tc.id, got, tc.want)
+			}
+			return nil
+		}); err != nil {
+			t.Fatalf("view: %v", err)
+		}
+	}
+}
+
+func TestDeleteReportsFirstBlockingReference(t *testing.T) {
+	store := NewStore(nil)
+	if err := store.ImportState(referenceFixture()); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
+
+	_, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		return tx.DeleteOrganism("org-1")
+	})
+	if err == nil || !strings.Contains(err.Error(), `organism "org-1" still referenced by sample "sample-1"`) {
+		t.Fatalf("expected sample to block organism delete, got %v", err)
+	}
+	_, err = store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		return tx.DeleteProtocol("prot-1")
+	})
+	if err == nil || !strings.Contains(err.Error(), `protocol "prot-1" still referenced by permit "permit-1"`) {
+		t.Fatalf("expected permit to block protocol delete, got %v", err)
+	}
+}
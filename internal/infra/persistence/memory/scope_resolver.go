@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"fmt"
+
+	"colonycore/pkg/domain"
+)
+
+// WithScopeResolver sets the resolver consulted by natural-key uniqueness
+// checks to find the scope a record belongs to. Facility-scoped keys (sample
+// identifiers and housing unit names) and project-scoped keys (cohort names)
+// are enforced on write, so two records only conflict when the resolver places
+// them in the same facility or project; SelfCheck groups those keys the same
+// way. A nil resolver restores domain.DefaultScopeResolver.
+func WithScopeResolver(resolver domain.ScopeResolver) StoreOption {
+	return func(s *Store) {
+		s.scopeResolver = resolver
+	}
+}
+
+func (s *Store) resolveScope(record any, scope domain.NaturalKeyScope) (string, bool) {
+	if s.scopeResolver == nil {
+		return domain.DefaultScopeResolver(record, scope)
+	}
+	return s.scopeResolver(record, scope)
+}
+
+// checkScopedKey rejects record when another record in records shares its key
+// within the same resolved scope. The record stored under id is skipped so
+// updates do not conflict with themselves, and the lowest conflicting ID is
+// reported.
+func checkScopedKey[T any](s *Store, entity domain.EntityType, field string, scope domain.NaturalKeyScope, records map[string]T, id string, record T, key func(T) string) error {
+	scopeID, ok := s.resolveScope(record, scope)
+	if !ok {
+		return nil
+	}
+	value, conflict := key(record), ""
+	for otherID, other := range records {
+		if otherID == id || key(other) != value || (conflict != "" && otherID > conflict) {
+			continue
+		}
+		if otherScope, ok := s.resolveScope(other, scope); ok && otherScope == scopeID {
+			conflict = otherID
+		}
+	}
+	if conflict == "" {
+		return nil
+	}
+	label := referenceLabel(entity)
+	if scope == domain.ScopeGlobal {
+		return fmt.Errorf("%s %s %q already used by %s %q", label, field, value, label, conflict)
+	}
+	return fmt.Errorf("%s %s %q already used by %s %q in %s %q", label, field, value, label, conflict, scope, scopeID)
+}
+
+func cohortName(c Cohort) string { return c.Name }
+
+func housingUnitName(h HousingUnit) string { return h.Name }
+
+func sampleIdentifier(s Sample) string { return s.Identifier }
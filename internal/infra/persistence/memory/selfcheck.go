@@ -0,0 +1,270 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"colonycore/pkg/domain"
+	"colonycore/pkg/domain/entitymodel"
+)
+
+// SelfCheckReport is the result of Store.SelfCheck. Each category lists one
+// message per problem in a stable order; Passed is true only when every
+// category is empty.
+type SelfCheckReport struct {
+	Passed               bool     `json:"passed"`
+	ReferentialIntegrity []string `json:"referential_integrity,omitempty"`
+	Validation           []string `json:"validation,omitempty"`
+	NaturalKeys          []string `json:"natural_keys,omitempty"`
+}
+
+// SelfCheck inspects the committed state without modifying it and reports
+// references to missing records, entities that fail write-time validation,
+// and natural keys shared by more than one record. It is intended as a
+// startup or readiness diagnostic; the error is non-nil only when ctx is
+// cancelled.
+func (s *Store) SelfCheck(ctx context.Context) (SelfCheckReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return selfCheckState(ctx, &s.state, s.resolveScope)
+}
+
+// CheckSnapshot runs the SelfCheck passes against snapshot as stored, without
+// the repairs ImportState applies, so stores that persist the normalized
+// snapshot elsewhere can report the same diagnostics. A nil resolve uses
+// domain.DefaultScopeResolver.
+func CheckSnapshot(ctx context.Context, snapshot Snapshot, resolve domain.ScopeResolver) (SelfCheckReport, error) {
+	if resolve == nil {
+		resolve = domain.DefaultScopeResolver
+	}
+	state := memoryStateFromSnapshot(snapshot)
+	return selfCheckState(ctx, &state, resolve)
+}
+
+func selfCheckState(ctx context.Context, state *memoryState, resolve domain.ScopeResolver) (SelfCheckReport, error) {
+	var report SelfCheckReport
+	passes := []struct {
+		out *[]string
+		run func(*memoryState) []string
+	}{
+		{&report.ReferentialIntegrity, checkReferentialIntegrity},
+		{&report.Validation, checkEntityValidation},
+		{&report.NaturalKeys, func(state *memoryState) []string { return checkNaturalKeys(state, resolve) }},
+	}
+	for _, pass := range passes {
+		if err := ctx.Err(); err != nil {
+			return SelfCheckReport{}, err
+		}
+		*pass.out = pass.run(state)
+	}
+	report.Passed = len(report.ReferentialIntegrity) == 0 && len(report.Validation) == 0 && len(report.NaturalKeys) == 0
+	return report, nil
+}
+
+// checkReferentialIntegrity lists stored references whose target record does
+// not exist.
+func checkReferentialIntegrity(state *memoryState) []string {
+	var problems []string
+	walkReferences(state, func(ref domain.Reference, target domain.EntityType, targetID string) {
+		if !entityExists(state, target, targetID) {
+			problems = append(problems, fmt.Sprintf("%s %q: %s references missing %s %q", referenceLabel(ref.Entity), ref.ID, ref.Field, referenceLabel(target), targetID))
+		}
+	})
+	sort.Strings(problems)
+	return problems
+}
+
+func entityExists(state *memoryState, entity domain.EntityType, id string) bool {
+	var ok bool
+	switch entity {
+	case domain.EntityOrganism:
+		_, ok = state.organisms[id]
+	case domain.EntityCohort:
+		_, ok = state.cohorts[id]
+	case domain.EntityHousingUnit:
+		_, ok = state.housing[id]
+	case domain.EntityFacility:
+		_, ok = state.facilities[id]
+	case domain.EntityBreeding:
+		_, ok = state.breeding[id]
+	case domain.EntityLine:
+		_, ok = state.lines[id]
+	case domain.EntityStrain:
+		_, ok = state.strains[id]
+	case domain.EntityGenotypeMarker:
+		_, ok = state.markers[id]
+	case domain.EntityProcedure:
+		_, ok = state.procedures[id]
+	case domain.EntityTreatment:
+		_, ok = state.treatments[id]
+	case domain.EntityObservation:
+		_, ok = state.observations[id]
+	case domain.EntitySample:
+		_, ok = state.samples[id]
+	case domain.EntityProtocol:
+		_, ok = state.protocols[id]
+	case domain.EntityPermit:
+		_, ok = state.permits[id]
+	case domain.EntityProject:
+		_, ok = state.projects[id]
+	case domain.EntitySupplyItem:
+		_, ok = state.supplies[id]
+	}
+	return ok
+}
+
+// checkEntityValidation re-runs the per-entity checks the store applies on
+// write against every stored record.
+func checkEntityValidation(state *memoryState) []string {
+	var problems []string
+	for _, entity := range []domain.EntityType{
+		domain.EntityOrganism, domain.EntityHousingUnit, domain.EntityFacility, domain.EntityBreeding,
+		domain.EntityLine, domain.EntityStrain, domain.EntityGenotypeMarker, domain.EntityProcedure,
+		domain.EntityTreatment, domain.EntitySample, domain.EntityProtocol, domain.EntityPermit,
+		domain.EntityProject, domain.EntitySupplyItem,
+	} {
+		records, _ := entityRecords(state, entity, "")
+		for _, id := range sortedKeys(records) {
+			if err := VerifyRecord(entity, id, records[id]); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+	return problems
+}
+
+// naturalKeyIndex groups record IDs by the natural key declared for their
+// entity in the schema. Records whose key includes an unset optional field are
+// not indexed, matching SQL unique-constraint semantics for NULL.
+type naturalKeyIndex struct {
+	entity   domain.EntityType
+	fields   []string
+	idsByKey map[string][]string
+}
+
+func (ix *naturalKeyIndex) add(id string, values []*string) {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		if v == nil {
+			return
+		}
+		parts[i] = *v
+	}
+	key := strings.Join(parts, "\x00")
+	ix.idsByKey[key] = append(ix.idsByKey[key], id)
+}
+
+func (ix *naturalKeyIndex) duplicates() []string {
+	var problems []string
+	for _, key := range sortedKeys(ix.idsByKey) {
+		ids := ix.idsByKey[key]
+		if len(ids) < 2 {
+			continue
+		}
+		sort.Strings(ids)
+		problems = append(problems, fmt.Sprintf("%s (%s) %q shared by %s", referenceLabel(ix.entity), strings.Join(ix.fields, ", "), strings.ReplaceAll(key, "\x00", "/"), strings.Join(ids, ", ")))
+	}
+	return problems
+}
+
+// checkNaturalKeys reports records that share a natural key declared in the
+// entity model schema. Facility- and project-scoped keys are grouped by the
+// scope ID resolve returns rather than by the stored <scope>_id field.
+func checkNaturalKeys(state *memoryState, resolve domain.ScopeResolver) []string {
+	keys := entitymodel.NaturalKeys()
+	var problems []string
+	for _, name := range sortedKeys(keys) {
+		entity := schemaEntityType(name)
+		records, err := entityRecords(state, entity, "")
+		if err != nil {
+			continue
+		}
+		for _, key := range keys[name] {
+			ix := &naturalKeyIndex{entity: entity, fields: key.Fields, idsByKey: map[string][]string{}}
+			for id, record := range records {
+				values := make([]*string, len(key.Fields))
+				for i, field := range key.Fields {
+					values[i] = keyField(record, field, domain.NaturalKeyScope(key.Scope), resolve)
+				}
+				ix.add(id, values)
+			}
+			problems = append(problems, ix.duplicates()...)
+		}
+	}
+	return problems
+}
+
+// schemaEntityType maps an entity model name such as "HousingUnit" to its
+// domain.EntityType ("housing_unit").
+func schemaEntityType(name string) domain.EntityType {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return domain.EntityType(b.String())
+}
+
+// keyField returns the value of record's schema field as a key part, or nil
+// when the field is unset. The <scope>_id field of a facility- or
+// project-scoped key is replaced by the scope ID resolve assigns record.
+func keyField(record any, field string, scope domain.NaturalKeyScope, resolve domain.ScopeResolver) *string {
+	if (scope == domain.ScopeFacility || scope == domain.ScopeProject) && field == string(scope)+"_id" {
+		id, ok := resolve(record, scope)
+		if !ok {
+			return nil
+		}
+		return &id
+	}
+	value, ok := jsonField(reflect.ValueOf(record), field)
+	if !ok {
+		return nil
+	}
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	var part string
+	if t, isTime := value.Interface().(time.Time); isTime {
+		part = t.UTC().Format(time.RFC3339Nano)
+	} else if value.Kind() == reflect.String {
+		part = value.String()
+	} else {
+		part = fmt.Sprint(value.Interface())
+	}
+	return &part
+}
+
+// jsonField finds the struct field of v, including fields promoted from
+// embedded structs, whose JSON name is name.
+func jsonField(v reflect.Value, name string) (reflect.Value, bool) {
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous {
+			if found, ok := jsonField(v.Field(i), name); ok {
+				return found, true
+			}
+			continue
+		}
+		if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag == name && f.IsExported() {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"colonycore/pkg/domain"
+)
+
+// EntityDiff lists the record IDs of one entity type that differ between two
+// snapshots. Each list is sorted.
+type EntityDiff struct {
+	Entity  domain.EntityType `json:"entity"`
+	Added   []string          `json:"added,omitempty"`
+	Removed []string          `json:"removed,omitempty"`
+	Changed []string          `json:"changed,omitempty"`
+}
+
+// Empty reports whether the entity has no differences.
+func (d EntityDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// SnapshotDiff holds the per-entity differences between two snapshots. Only
+// entity types with at least one difference are listed, in snapshot field
+// order.
+type SnapshotDiff struct {
+	Entities []EntityDiff `json:"entities"`
+}
+
+// Empty reports whether the snapshots hold the same records.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.Entities) == 0
+}
+
+// DiffSnapshots compares two snapshots record by record. A record counts as
+// changed when its JSON encoding differs; the schema version is ignored.
+func DiffSnapshots(before, after Snapshot) (SnapshotDiff, error) {
+	diff := SnapshotDiff{Entities: []EntityDiff{}}
+	errs := []error{
+		diffEntity(&diff, domain.EntityOrganism, before.Organisms, after.Organisms),
+		diffEntity(&diff, domain.EntityCohort, before.Cohorts, after.Cohorts),
+		diffEntity(&diff, domain.EntityHousingUnit, before.Housing, after.Housing),
+		diffEntity(&diff, domain.EntityFacility, before.Facilities, after.Facilities),
+		diffEntity(&diff, domain.EntityBreeding, before.Breeding, after.Breeding),
+		diffEntity(&diff, domain.EntityLine, before.Lines, after.Lines),
+		diffEntity(&diff, domain.EntityStrain, before.Strains, after.Strains),
+		diffEntity(&diff, domain.EntityGenotypeMarker, before.Markers, after.Markers),
+		diffEntity(&diff, domain.EntityProcedure, before.Procedures, after.Procedures),
+		diffEntity(&diff, domain.EntityTreatment, before.Treatments, after.Treatments),
+		diffEntity(&diff, domain.EntityObservation, before.Observations, after.Observations),
+		diffEntity(&diff, domain.EntitySample, before.Samples, after.Samples),
+		diffEntity(&diff, domain.EntityProtocol, before.Protocols, after.Protocols),
+		diffEntity(&diff, domain.EntityPermit, before.Permits, after.Permits),
+		diffEntity(&diff, domain.EntityProject, before.Projects, after.Projects),
+		diffEntity(&diff, domain.EntitySupplyItem, before.Supplies, after.Supplies),
+	}
+	if err := errors.Join(errs...); err != nil {
+		return SnapshotDiff{}, err
+	}
+	return diff, nil
+}
+
+// diffEntity appends the differences for one entity type to diff.
+func diffEntity[T any](diff *SnapshotDiff, entity domain.EntityType, before, after map[string]T) error {
+	d := EntityDiff{Entity: entity}
+	for id, next := range after {
+		prev, ok := before[id]
+		if !ok {
+			d.Added = append(d.Added, id)
+			continue
+		}
+		same, err := sameRecord(prev, next)
+		if err != nil {
+			return fmt.Errorf("diff %s %q: %w", entity, id, err)
+		}
+		if !same {
+			d.Changed = append(d.Changed, id)
+		}
+	}
+	for id := range before {
+		if _, ok := after[id]; !ok {
+			d.Removed = append(d.Removed, id)
+		}
+	}
+	if d.Empty() {
+		return nil
+	}
+	sort.Strings(d.Added)
+	sort.Strings(d.Removed)
+	sort.Strings(d.Changed)
+	diff.Entities = append(diff.Entities, d)
+	return nil
+}
+
+func sameRecord[T any](a, b T) (bool, error) {
+	left, err := json.Marshal(a)
+	if err != nil {
+		return false, err
+	}
+	right, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(left, right), nil
+}
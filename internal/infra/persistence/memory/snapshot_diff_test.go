@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestDiffSnapshotsReportsPerEntityChanges(t *testing.T) {
+	before := Snapshot{
+		SchemaVersion: "1",
+		Organisms: map[string]Organism{
+			"org-1": {Organism: entitymodel.Organism{ID: "org-1", Name: "Alpha"}},
+			"org-2": {Organism: entitymodel.Organism{ID: "org-2", Name: "Bravo"}},
+		},
+		Lines: map[string]Line{"line-1": {Line: entitymodel.Line{ID: "line-1", Code: "L1"}}},
+	}
+	same, err := DiffSnapshots(before, Snapshot{Organisms: before.Organisms, Lines: before.Lines, SchemaVersion: "2"})
+	if err != nil {
+		t.Fatalf("diff identical snapshots: %v", err)
+	}
+	if !same.Empty() {
+		t.Fatalf("expected no differences across schema versions, got %+v", same)
+	}
+
+	after := Snapshot{
+		Organisms: map[string]Organism{
+			"org-1": {Organism: entitymodel.Organism{ID: "org-1", Name: "Alpha Prime"}},
+			"org-3": {Organism: entitymodel.Organism{ID: "org-3", Name: "Charlie"}},
+		},
+		Lines: before.Lines,
+	}
+	diff, err := DiffSnapshots(before, after)
+	if err != nil {
+		t.Fatalf("diff snapshots: %v", err)
+	}
+	if diff.Empty() || len(diff.Entities) != 1 {
+		t.Fatalf("expected a single differing entity, got %+v", diff.Entities)
+	}
+	got := diff.Entities[0]
+	if got.Entity != domain.EntityOrganism || len(got.Added) != 1 || got.Added[0] != "org-3" ||
+		len(got.Removed) != 1 || got.Removed[0] != "org-2" || len(got.Changed) != 1 || got.Changed[0] != "org-1" {
+		t.Fatalf("unexpected organism diff %+v", got)
+	}
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MarshalStableSnapshot encodes s as indented JSON whose bytes depend only on
+// its logical content, so snapshot files checked into version control diff
+// cleanly. Object keys are sorted at every level, including attribute maps,
+// and every record's ID-list field (a field named "*_ids", such as parent_ids
+// or derived lists like housing_unit_ids) is sorted, since those lists are
+// sets. Ordered data such as chain of custody and adverse events keeps its
+// order. Numbers are written exactly as encoded, and the output ends with a
+// newline.
+func MarshalStableSnapshot(s Snapshot) ([]byte, error) {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("encode snapshot: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var doc map[string]any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode snapshot: %w", err)
+	}
+	for _, collection := range doc {
+		records, ok := collection.(map[string]any)
+		if !ok {
+			continue
+		}
+		for _, record := range records {
+			if fields, ok := record.(map[string]any); ok {
+				sortIDListFields(fields)
+			}
+		}
+	}
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode stable snapshot: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+// sortIDListFields sorts the string lists stored under "*_ids" keys of one
+// encoded record.
+func sortIDListFields(fields map[string]any) {
+	for key, value := range fields {
+		if !strings.HasSuffix(key, "_ids") {
+			continue
+		}
+		list, ok := value.([]any)
+		if !ok {
+			continue
+		}
+		ids := make([]string, 0, len(list))
+		for _, item := range list {
+			id, ok := item.(string)
+			if !ok {
+				ids = nil
+				break
+			}
+			ids = append(ids, id)
+		}
+		if ids == nil {
+			continue
+		}
+		sort.Strings(ids)
+		for i, id := range ids {
+			list[i] = id
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func stableFixture(t *testing.T, parents, facilities []string, attrs map[string]any, custody []string) Snapshot {
+	t.Helper()
+	organism := domain.Organism{Organism: entitymodel.Organism{ID: "frog", Name: "Frog", Species: "Xenopus", Stage: domain.StageAdult, ParentIDs: parents}}
+	if err := organism.SetCoreAttributes(attrs); err != nil {
+		t.Fatalf("SetCoreAttributes: %v", err)
+	}
+	sample := domain.Sample{Sample: entitymodel.Sample{ID: "sample", Identifier: "S-1", FacilityID: "fac-a"}}
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, actor := range custody {
+		sample.ChainOfCustody = append(sample.ChainOfCustody, entitymodel.SampleCustodyEvent{Actor: actor, Location: "freezer", Timestamp: base.Add(time.Duration(i) * time.Hour)})
+	}
+	return Snapshot{
+		Organisms: map[string]Organism{organism.ID: organism},
+		Projects:  map[string]Project{"proj": {Project: entitymodel.Project{ID: "proj", Code: "P", Title: "Project", FacilityIDs: facilities}}},
+		Samples:   map[string]Sample{sample.ID: sample},
+	}
+}
+
+func TestMarshalStableSnapshotIgnoresInsertionOrder(t *testing.T) {
+	first := map[string]any{}
+	first["weight"] = 12.5
+	first["color"] = map[string]any{"dorsal": "green", "ventral": "white"}
+	second := map[string]any{}
+	second["color"] = map[string]any{"ventral": "white", "dorsal": "green"}
+	second["weight"] = 12.5
+
+	a, err := MarshalStableSnapshot(stableFixture(t, []string{"p2", "p1"}, []string{"fac-b", "fac-a"}, first, []string{"alice", "bob"}))
+	if err != nil {
+		t.Fatalf("MarshalStableSnapshot: %v", err)
+	}
+	b, err := MarshalStableSnapshot(stableFixture(t, []string{"p1", "p2"}, []string{"fac-a", "fac-b"}, second, []string{"alice", "bob"}))
+	if err != nil {
+		t.Fatalf("MarshalStableSnapshot: %v", err)
+	}
+	if !bytes.Equal(a, b) {
+		t.Fatalf("expected identical stable bytes:\n%s\n---\n%s", a, b)
+	}
+	if !bytes.HasSuffix(a, []byte("}\n")) {
+		t.Fatalf("expected trailing newline")
+	}
+
+	var decoded Snapshot
+	if err := json.Unmarshal(a, &decoded); err != nil {
+		t.Fatalf("stable output must decode as a snapshot: %v", err)
+	}
+	if got := decoded.Organisms["frog"].ParentIDs; len(got) != 2 || got[0] != "p1" || got[1] != "p2" {
+		t.Fatalf("expected sorted parent ids, got %v", got)
+	}
+
+	reordered, err := MarshalStableSnapshot(stableFixture(t, []string{"p1", "p2"}, []string{"fac-a", "fac-b"}, second, []string{"bob", "alice"}))
+	if err != nil {
+		t.Fatalf("MarshalStableSnapshot: %v", err)
+	}
+	if bytes.Equal(a, reordered) {
+		t.Fatalf("expected chain of custody order to be preserved")
+	}
+}
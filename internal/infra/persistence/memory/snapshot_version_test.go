@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func versionedSnapshot(version string) Snapshot {
+	return Snapshot{
+		SchemaVersion: version,
+		Facilities: map[string]Facility{
+			"facility-1": {Facility: entitymodel.Facility{ID: "facility-1", Code: "VIV", Name: "Vivarium"}},
+		},
+	}
+}
+
+func TestImportStateChecksSchemaVersion(t *testing.T) {
+	current, err := parseSchemaVersion(entitymodel.SchemaVersion)
+	if err != nil {
+		t.Fatalf("parse binary schema version: %v", err)
+	}
+	for _, version := range []string{"", "0.0.0", entitymodel.SchemaVersion} {
+		store := NewStore(nil)
+		if err := store.ImportState(versionedSnapshot(version)); err != nil {
+			t.Fatalf("expected snapshot version %q to import, got %v", version, err)
+		}
+		if _, ok := store.GetFacility("facility-1"); !ok {
+			t.Fatalf("expected facility imported from version %q", version)
+		}
+		if got := store.ExportState().SchemaVersion; got != entitymodel.SchemaVersion {
+			t.Fatalf("expected export stamped with %s, got %q", entitymodel.SchemaVersion, got)
+		}
+	}
+
+	newer := []string{
+		fmt.Sprintf("%d.%d.%d", current[0], current[1], current[2]+1),
+		fmt.Sprintf("%d.%d.0", current[0], current[1]+1),
+		fmt.Sprintf("%d.0.0", current[0]+1),
+	}
+	for _, version := range newer {
+		store := NewStore(nil)
+		err := store.ImportState(versionedSnapshot(version))
+		if !errors.Is(err, ErrSnapshotTooNew) {
+			t.Fatalf("expected ErrSnapshotTooNew for version %q, got %v", version, err)
+		}
+		if len(store.ExportState().Facilities) != 0 {
+			t.Fatalf("expected rejected snapshot %q to leave the store empty", version)
+		}
+	}
+
+	if err := NewStore(nil).ImportState(versionedSnapshot("next")); err == nil || errors.Is(err, ErrSnapshotTooNew) {
+		t.Fatalf("expected malformed version error, got %v", err)
+	}
+}
+
+func TestUnmarshalSnapshotChecksVersionBeforeFields(t *testing.T) {
+	newer := []byte(`{"schema_version":"999.0.0","facilities":{"facility-1":{"id":"facility-1","added_in_a_later_release":true}}}`)
+	if _, err := UnmarshalSnapshot(newer); !errors.Is(err, ErrSnapshotTooNew) {
+		t.Fatalf("expected ErrSnapshotTooNew for a newer snapshot with an unknown field, got %v", err)
+	}
+	current := []byte(`{"schema_version":"` + entitymodel.SchemaVersion + `","facilities":{"facility-1":{"id":"facility-1","added_in_a_later_release":true}}}`)
+	if _, err := UnmarshalSnapshot(current); !errors.Is(err, domain.ErrUnknownField) {
+		t.Fatalf("expected ErrUnknownField at the current version, got %v", err)
+	}
+	snapshot, err := UnmarshalSnapshot([]byte(`{"facilities":{"facility-1":{"id":"facility-1","code":"VIV","name":"Vivarium"}}}`))
+	if err != nil {
+		t.Fatalf("decode unstamped snapshot: %v", err)
+	}
+	if snapshot.Facilities["facility-1"].Name != "Vivarium" {
+		t.Fatalf("expected facility decoded, got %+v", snapshot.Facilities)
+	}
+}
@@ -70,11 +70,15 @@ func TestSnapshotAllEntities(t *testing.T) {
 		t.Fatalf("expected populated snapshot: %+v", snap)
 	}
 	// Clear then re-import to exercise memoryStateFromSnapshot cloning for all maps.
-	store.ImportState(Snapshot{})
+	if err := store.ImportState(Snapshot{}); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 	if len(store.ListOrganisms()) != 0 {
 		t.Fatalf("expected cleared state")
 	}
-	store.ImportState(snap)
+	if err := store.ImportState(snap); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 	if len(store.ListOrganisms()) != 1 {
 		t.Fatalf("expected restored organism")
 	}
@@ -135,12 +139,12 @@ func TestImportStateAppliesRelationshipMigrations(t *testing.T) {
 			"obs-2": {Observation: entitymodel.Observation{ID: "obs-2", ProcedureID: ptr("missing"), Observer: "Tech", RecordedAt: now}},
 		},
 		Samples: map[string]domain.Sample{
-			"sample-1": {Sample: entitymodel.Sample{ID: "sample-1", Identifier: "S1", SourceType: "blood", FacilityID: facilityKey, OrganismID: ptr("org-1"), CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "freezer", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "freezer", Timestamp: now}}}},
-			"sample-2": {Sample: entitymodel.Sample{ID: "sample-2", Identifier: "S2", SourceType: "blood", FacilityID: "missing", CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "freezer", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "freezer", Timestamp: now}}}},
+			"sample-1": {Sample: entitymodel.Sample{ID: "sample-1", Identifier: "S1", SourceType: "blood", FacilityID: facilityKey, OrganismID: ptr("org-1"), CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "freezer", CollectedBy: "tech", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "freezer", Timestamp: now}}}},
+			"sample-2": {Sample: entitymodel.Sample{ID: "sample-2", Identifier: "S2", SourceType: "blood", FacilityID: "missing", CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "freezer", CollectedBy: "tech", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "freezer", Timestamp: now}}}},
 		},
 		Protocols: protocols,
 		Permits: map[string]domain.Permit{
-			"permit-1": {Permit: entitymodel.Permit{ID: "permit-1", PermitNumber: "P1", Authority: "Gov", Status: domain.PermitStatusApproved, ValidFrom: now, ValidUntil: now.AddDate(1, 0, 0), FacilityIDs: []string{facilityKey, "missing", facilityKey}, ProtocolIDs: []string{"prot-1", "missing"}}},
+			"permit-1": {Permit: entitymodel.Permit{ID: "permit-1", PermitNumber: "P1", Authority: "Gov", Status: domain.PermitStatusApproved, IssueDate: now, ValidFrom: now, ValidUntil: now.AddDate(1, 0, 0), FacilityIDs: []string{facilityKey, "missing", facilityKey}, ProtocolIDs: []string{"prot-1", "missing"}}},
 		},
 		Projects: map[string]domain.Project{
 			"proj-1": {Project: entitymodel.Project{ID: "proj-1", Code: "P1", Title: "Project", FacilityIDs: []string{facilityKey, facilityKey, "missing"}}},
@@ -150,7 +154,9 @@ func TestImportStateAppliesRelationshipMigrations(t *testing.T) {
 		},
 	}
 
-	store.ImportState(snapshot)
+	if err := store.ImportState(snapshot); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 
 	if units := store.ListHousingUnits(); len(units) != 1 || units[0].ID != "house-1" {
 		t.Fatalf("expected only valid housing to remain, got %+v", units)
@@ -230,15 +236,15 @@ func TestMigrateSnapshotCleansDataVariants(t *testing.T) {
 			"observation-drop":  {Observation: entitymodel.Observation{ID: "observation-drop", ProcedureID: ptr("missing"), Observer: "Tech", RecordedAt: now}},
 		},
 		Samples: map[string]domain.Sample{
-			"sample-valid":            {Sample: entitymodel.Sample{ID: "sample-valid", Identifier: "S", SourceType: "blood", FacilityID: facilityID, OrganismID: ptr("org-keep"), CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}},
-			"sample-drop":             {Sample: entitymodel.Sample{ID: "sample-drop", Identifier: "S2", SourceType: "blood", FacilityID: facilityID, OrganismID: ptr("missing"), CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}},
-			"sample-missing-facility": {Sample: entitymodel.Sample{ID: "sample-missing-facility", Identifier: "S3", SourceType: "blood", FacilityID: "missing", CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}},
+			"sample-valid":            {Sample: entitymodel.Sample{ID: "sample-valid", Identifier: "S", SourceType: "blood", FacilityID: facilityID, OrganismID: ptr("org-keep"), CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", CollectedBy: "tech", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}},
+			"sample-drop":             {Sample: entitymodel.Sample{ID: "sample-drop", Identifier: "S2", SourceType: "blood", FacilityID: facilityID, OrganismID: ptr("missing"), CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", CollectedBy: "tech", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}},
+			"sample-missing-facility": {Sample: entitymodel.Sample{ID: "sample-missing-facility", Identifier: "S3", SourceType: "blood", FacilityID: "missing", CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", CollectedBy: "tech", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}},
 		},
 		Protocols: map[string]domain.Protocol{
 			"prot-keep": {Protocol: entitymodel.Protocol{ID: "prot-keep", Code: "PR", Title: "Protocol", MaxSubjects: 5, Status: domain.ProtocolStatusApproved}},
 		},
 		Permits: map[string]domain.Permit{
-			"permit-valid": {Permit: entitymodel.Permit{ID: "permit-valid", PermitNumber: "P", Authority: "Gov", Status: domain.PermitStatusApproved, ValidFrom: now, ValidUntil: now.Add(time.Hour), FacilityIDs: []string{facilityID, facilityID, "missing"}, ProtocolIDs: []string{"prot-keep", "missing"}}},
+			"permit-valid": {Permit: entitymodel.Permit{ID: "permit-valid", PermitNumber: "P", Authority: "Gov", Status: domain.PermitStatusApproved, IssueDate: now, ValidFrom: now, ValidUntil: now.Add(time.Hour), FacilityIDs: []string{facilityID, facilityID, "missing"}, ProtocolIDs: []string{"prot-keep", "missing"}}},
 		},
 		Projects: map[string]domain.Project{
 			"project-valid": {Project: entitymodel.Project{ID: "project-valid", Code: "PRJ", Title: "Project", FacilityIDs: []string{facilityID, facilityID, "missing"}}},
@@ -353,7 +359,9 @@ func TestObservationExtensionsSnapshotRoundTrip(t *testing.T) {
 	}
 
 	store := NewStore(nil)
-	store.ImportState(snapshot)
+	if err := store.ImportState(snapshot); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 	encoded, err := json.Marshal(store.ExportState())
 	if err != nil {
 		t.Fatalf("marshal snapshot: %v", err)
@@ -480,7 +488,9 @@ func TestSnapshotPreservesExtensionContainers(t *testing.T) {
 	}
 
 	store := NewStore(nil)
-	store.ImportState(snapshot)
+	if err := store.ImportState(snapshot); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 	exported := store.ExportState()
 
 	facilityAfter := exported.Facilities[facility.ID]
@@ -695,6 +705,7 @@ func TestStateNormalizationDefaultsAndValidation(t *testing.T) {
 
 		permit, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PER",
 			Authority:         "Gov",
+			IssueDate:         now,
 			ValidFrom:         now,
 			ValidUntil:        now.Add(time.Hour),
 			AllowedActivities: []string{"store"},
@@ -710,6 +721,7 @@ func TestStateNormalizationDefaultsAndValidation(t *testing.T) {
 		if _, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PER-2",
 			Authority:         "Gov",
 			Status:            domain.PermitStatus("invalid"),
+			IssueDate:         now,
 			ValidFrom:         now,
 			ValidUntil:        now.Add(time.Hour),
 			AllowedActivities: []string{"store"},
@@ -778,10 +790,11 @@ func TestProcedureObservationSampleLifecycle(t *testing.T) {
 		}
 		treatment, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{Name: "Treat",
 			Status:            domain.TreatmentStatusPlanned,
+			DosagePlan:        domain.DosagePlan{Drug: "compound", DoseAmount: 1, DoseUnit: "mg"},
 			ProcedureID:       procedure.ID,
 			OrganismIDs:       []string{organism.ID},
 			AdministrationLog: []string{},
-			AdverseEvents:     []string{}},
+			AdverseEvents:     []domain.AdverseEvent{}},
 		})
 		if err != nil {
 			return err
@@ -815,6 +828,7 @@ func TestProcedureObservationSampleLifecycle(t *testing.T) {
 			Status:          domain.SampleStatusStored,
 			StorageLocation: "loc",
 			OrganismID:      &organism.ID,
+			CollectedBy:     "tech",
 			ChainOfCustody: []domain.SampleCustodyEvent{{
 				Actor:     "tech",
 				Location:  "loc",
@@ -10,13 +10,17 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
 // Compile-time contract assertions ensuring memory.Store adheres to the domain persistence interfaces.
 var _ domain.PersistentStore = (*Store)(nil)
+var _ domain.VerifiedReader = (*Store)(nil)
 
 type (
 	// Organism aliases domain.Organism for in-memory persistence operations.
@@ -86,6 +90,13 @@ var (
 		domain.HousingEnvironmentArboreal:    {},
 		domain.HousingEnvironmentHumid:       {},
 	}
+	defaultPairingIntent = domain.PairingIntentMaintenance
+	validPairingIntents  = map[domain.PairingIntent]struct{}{
+		domain.PairingIntentMaintenance:  {},
+		domain.PairingIntentExpansion:    {},
+		domain.PairingIntentExperimental: {},
+		domain.PairingIntentRederivation: {},
+	}
 	defaultProtocolStatus = domain.ProtocolStatusDraft
 	validProtocolStatuses = map[domain.ProtocolStatus]struct{}{
 		domain.ProtocolStatusDraft:     {},
@@ -126,6 +137,11 @@ var (
 		domain.SampleStatusConsumed:  {},
 		domain.SampleStatusDisposed:  {},
 	}
+	defaultSupplyStatus = domain.SupplyStatusActive
+	validSupplyStatuses = map[domain.SupplyStatus]struct{}{
+		domain.SupplyStatusActive:   {},
+		domain.SupplyStatusConsumed: {},
+	}
 )
 
 func normalizeHousingUnit(h *HousingUnit) error {
@@ -144,6 +160,38 @@ func normalizeHousingUnit(h *HousingUnit) error {
 	return nil
 }
 
+func normalizeBreedingUnit(b *BreedingUnit) error {
+	if b.PairingIntent == nil || *b.PairingIntent == "" {
+		intent := defaultPairingIntent
+		b.PairingIntent = &intent
+	}
+	if _, ok := validPairingIntents[*b.PairingIntent]; !ok {
+		return fmt.Errorf("unsupported pairing intent %q", *b.PairingIntent)
+	}
+	return nil
+}
+
+// migrateLegacyPairingIntent moves free-text pairing intents recorded before
+// the pairing_intent enum existed into PairingNotes so the text is preserved.
+func migrateLegacyPairingIntent(b *BreedingUnit) {
+	if b.PairingIntent == nil {
+		return
+	}
+	if _, ok := validPairingIntents[*b.PairingIntent]; ok {
+		return
+	}
+	legacy := strings.TrimSpace(string(*b.PairingIntent))
+	b.PairingIntent = nil
+	if legacy == "" {
+		return
+	}
+	notes := legacy
+	if b.PairingNotes != nil && strings.TrimSpace(*b.PairingNotes) != "" {
+		notes = legacy + "; " + *b.PairingNotes
+	}
+	b.PairingNotes = &notes
+}
+
 func normalizeProtocol(p *Protocol) error {
 	if p.Status == "" {
 		p.Status = defaultProtocolStatus
@@ -161,9 +209,76 @@ func normalizePermit(p *Permit) error {
 	if _, ok := validPermitStatuses[p.Status]; !ok {
 		return fmt.Errorf("unsupported permit status %q", p.Status)
 	}
+	if p.ValidUntil.Before(p.ValidFrom) {
+		return fmt.Errorf("permit valid_until %s must not be before valid_from %s", p.ValidUntil.Format(time.RFC3339), p.ValidFrom.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// validatePermitIssueDate rejects permits without an issue date and permits
+// that become valid before the authority issued them.
+func validatePermitIssueDate(p Permit) error {
+	if p.IssueDate.IsZero() {
+		return errors.New("permit requires issue_date")
+	}
+	if p.IssueDate.After(p.ValidFrom) {
+		return fmt.Errorf("permit issue_date %s must not be after valid_from %s", p.IssueDate.Format(time.RFC3339), p.ValidFrom.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// validatePermitIssueDateUpdate checks an updated permit's issue date. Permits
+// stored before issue_date was required may keep it zero until an update
+// records one, so legacy permits stay editable; an update cannot clear an
+// issue date once recorded.
+func validatePermitIssueDateUpdate(before, after Permit) error {
+	if before.IssueDate.IsZero() && after.IssueDate.IsZero() {
+		return nil
+	}
+	return validatePermitIssueDate(after)
+}
+
+// validateFacility checks the facility fields the store enforces on write.
+func validateFacility(f Facility) error {
+	if err := validateFacilityTimezone(f); err != nil {
+		return err
+	}
+	if f.DefaultHousingEnvironment != nil {
+		if _, ok := validHousingEnvironments[*f.DefaultHousingEnvironment]; !ok {
+			return fmt.Errorf("unsupported default housing environment %q", *f.DefaultHousingEnvironment)
+		}
+	}
 	return nil
 }
 
+// facilityHousingEnvironment returns the environment assigned to new housing
+// in f that does not declare one.
+func facilityHousingEnvironment(f Facility) domain.HousingEnvironment {
+	if f.DefaultHousingEnvironment != nil {
+		return *f.DefaultHousingEnvironment
+	}
+	return defaultHousingEnvironment
+}
+
+// checkFacilityEnvironment rejects housing in env that facility f does not
+// host. A facility that declares a default housing environment hosts only that
+// environment; one without a default hosts any.
+func checkFacilityEnvironment(f Facility, env domain.HousingEnvironment) error {
+	if f.DefaultHousingEnvironment == nil || *f.DefaultHousingEnvironment == env {
+		return nil
+	}
+	return fmt.Errorf("%s housing is incompatible with %s facility %q", env, *f.DefaultHousingEnvironment, f.ID)
+}
+
+// validateFacilityTimezone rejects facility time zones that are not IANA
+// location names.
+func validateFacilityTimezone(f Facility) error {
+	if f.Timezone == nil {
+		return nil
+	}
+	return domain.ValidateTimezone(*f.Timezone)
+}
+
 func normalizeProcedure(p *Procedure) error {
 	if p.Status == "" {
 		p.Status = defaultProcedureStatus
@@ -184,6 +299,48 @@ func normalizeTreatment(t *Treatment) error {
 	return nil
 }
 
+// validateDosagePlan checks a structured dosage plan. Plans migrated from
+// free text are accepted as stored until an update gives them structured
+// fields, so legacy treatments stay editable.
+func validateDosagePlan(plan domain.DosagePlan) error {
+	if domain.IsLegacyDosagePlan(plan) {
+		return nil
+	}
+	if plan.DoseAmount <= 0 {
+		return errors.New("treatment.dosage_plan.dose_amount must be greater than zero")
+	}
+	if plan.DurationDays < 0 {
+		return errors.New("treatment.dosage_plan.duration_days must not be negative")
+	}
+	return nil
+}
+
+// validateSampleCustody checks the provenance every sample must carry: who
+// collected it and at least one chain-of-custody event.
+func validateSampleCustody(s Sample) error {
+	if strings.TrimSpace(s.CollectedBy) == "" {
+		return errors.New("sample requires collected by")
+	}
+	if len(s.ChainOfCustody) == 0 {
+		return errors.New("sample requires chain of custody")
+	}
+	return nil
+}
+
+// validateSampleCustodyUpdate checks an updated sample's provenance. Samples
+// stored before collected_by was required may keep it blank until an update
+// records a collector, so legacy samples stay editable; an update cannot clear
+// a collector once recorded.
+func validateSampleCustodyUpdate(before, after Sample) error {
+	if strings.TrimSpace(before.CollectedBy) == "" && strings.TrimSpace(after.CollectedBy) == "" {
+		if len(after.ChainOfCustody) == 0 {
+			return errors.New("sample requires chain of custody")
+		}
+		return nil
+	}
+	return validateSampleCustody(after)
+}
+
 func normalizeSample(s *Sample) error {
 	if s.Status == "" {
 		s.Status = defaultSampleStatus
@@ -194,6 +351,42 @@ func normalizeSample(s *Sample) error {
 	return nil
 }
 
+// NormalizeSample returns a copy of sample with the defaults and checks the
+// store applies on import, so backends that read samples outside a snapshot
+// return them in the shape TransactionView does.
+func NormalizeSample(sample Sample) (Sample, error) {
+	cp := cloneSample(sample)
+	if err := normalizeSample(&cp); err != nil {
+		return Sample{}, err
+	}
+	return cp, nil
+}
+
+func normalizeSupplyItem(s *SupplyItem) error {
+	if s.Status == "" {
+		s.Status = defaultSupplyStatus
+	}
+	if _, ok := validSupplyStatuses[s.Status]; !ok {
+		return fmt.Errorf("unsupported supply status %q", s.Status)
+	}
+	if s.Category != nil {
+		category := strings.ToLower(strings.TrimSpace(*s.Category))
+		if category == "" {
+			s.Category = nil
+		} else {
+			s.Category = &category
+		}
+	}
+	return nil
+}
+
+// supplyConsumptionLogKey names the core supply attribute that accumulates
+// consumption events recorded by ConsumeSupplyItem.
+const supplyConsumptionLogKey = "consumption_log"
+
+// projectClosedReason is recorded on procedures cancelled by CloseProject.
+const projectClosedReason = "project closed"
+
 // Infra implementations use domain types directly via their interfaces
 // No constant aliases needed - use domain.EntityType, domain.Action values directly
 
@@ -218,22 +411,94 @@ type memoryState struct {
 
 // Snapshot captures a point-in-time clone of the store state.
 type Snapshot struct {
-	Organisms    map[string]Organism       `json:"organisms"`
-	Cohorts      map[string]Cohort         `json:"cohorts"`
-	Housing      map[string]HousingUnit    `json:"housing"`
-	Facilities   map[string]Facility       `json:"facilities"`
-	Breeding     map[string]BreedingUnit   `json:"breeding"`
-	Lines        map[string]Line           `json:"lines"`
-	Strains      map[string]Strain         `json:"strains"`
-	Markers      map[string]GenotypeMarker `json:"markers"`
-	Procedures   map[string]Procedure      `json:"procedures"`
-	Treatments   map[string]Treatment      `json:"treatments"`
-	Observations map[string]Observation    `json:"observations"`
-	Samples      map[string]Sample         `json:"samples"`
-	Protocols    map[string]Protocol       `json:"protocols"`
-	Permits      map[string]Permit         `json:"permits"`
-	Projects     map[string]Project        `json:"projects"`
-	Supplies     map[string]SupplyItem     `json:"supplies"`
+	SchemaVersion string                    `json:"schema_version,omitempty"`
+	Organisms     map[string]Organism       `json:"organisms"`
+	Cohorts       map[string]Cohort         `json:"cohorts"`
+	Housing       map[string]HousingUnit    `json:"housing"`
+	Facilities    map[string]Facility       `json:"facilities"`
+	Breeding      map[string]BreedingUnit   `json:"breeding"`
+	Lines         map[string]Line           `json:"lines"`
+	Strains       map[string]Strain         `json:"strains"`
+	Markers       map[string]GenotypeMarker `json:"markers"`
+	Procedures    map[string]Procedure      `json:"procedures"`
+	Treatments    map[string]Treatment      `json:"treatments"`
+	Observations  map[string]Observation    `json:"observations"`
+	Samples       map[string]Sample         `json:"samples"`
+	Protocols     map[string]Protocol       `json:"protocols"`
+	Permits       map[string]Permit         `json:"permits"`
+	Projects      map[string]Project        `json:"projects"`
+	Supplies      map[string]SupplyItem     `json:"supplies"`
+}
+
+// SnapshotStats reports the number of entities of each type held in a Snapshot.
+type SnapshotStats struct {
+	Organisms       int `json:"organisms"`
+	Cohorts         int `json:"cohorts"`
+	HousingUnits    int `json:"housing_units"`
+	Facilities      int `json:"facilities"`
+	BreedingUnits   int `json:"breeding_units"`
+	Lines           int `json:"lines"`
+	Strains         int `json:"strains"`
+	GenotypeMarkers int `json:"genotype_markers"`
+	Procedures      int `json:"procedures"`
+	Treatments      int `json:"treatments"`
+	Observations    int `json:"observations"`
+	Samples         int `json:"samples"`
+	Protocols       int `json:"protocols"`
+	Permits         int `json:"permits"`
+	Projects        int `json:"projects"`
+	SupplyItems     int `json:"supply_items"`
+}
+
+// Stats returns per-entity counts for the snapshot without inspecting records.
+func (s Snapshot) Stats() SnapshotStats {
+	return SnapshotStats{
+		Organisms:       len(s.Organisms),
+		Cohorts:         len(s.Cohorts),
+		HousingUnits:    len(s.Housing),
+		Facilities:      len(s.Facilities),
+		BreedingUnits:   len(s.Breeding),
+		Lines:           len(s.Lines),
+		Strains:         len(s.Strains),
+		GenotypeMarkers: len(s.Markers),
+		Procedures:      len(s.Procedures),
+		Treatments:      len(s.Treatments),
+		Observations:    len(s.Observations),
+		Samples:         len(s.Samples),
+		Protocols:       len(s.Protocols),
+		Permits:         len(s.Permits),
+		Projects:        len(s.Projects),
+		SupplyItems:     len(s.Supplies),
+	}
+}
+
+// Sub returns the per-entity difference s minus other, such as the records an
+// import dropped between the snapshot it received and the one it kept.
+func (s SnapshotStats) Sub(other SnapshotStats) SnapshotStats {
+	return SnapshotStats{
+		Organisms:       s.Organisms - other.Organisms,
+		Cohorts:         s.Cohorts - other.Cohorts,
+		HousingUnits:    s.HousingUnits - other.HousingUnits,
+		Facilities:      s.Facilities - other.Facilities,
+		BreedingUnits:   s.BreedingUnits - other.BreedingUnits,
+		Lines:           s.Lines - other.Lines,
+		Strains:         s.Strains - other.Strains,
+		GenotypeMarkers: s.GenotypeMarkers - other.GenotypeMarkers,
+		Procedures:      s.Procedures - other.Procedures,
+		Treatments:      s.Treatments - other.Treatments,
+		Observations:    s.Observations - other.Observations,
+		Samples:         s.Samples - other.Samples,
+		Protocols:       s.Protocols - other.Protocols,
+		Permits:         s.Permits - other.Permits,
+		Projects:        s.Projects - other.Projects,
+		SupplyItems:     s.SupplyItems - other.SupplyItems,
+	}
+}
+
+// Total returns the number of records across every entity type.
+func (s SnapshotStats) Total() int {
+	return s.Organisms + s.Cohorts + s.HousingUnits + s.Facilities + s.BreedingUnits + s.Lines + s.Strains + s.GenotypeMarkers +
+		s.Procedures + s.Treatments + s.Observations + s.Samples + s.Protocols + s.Permits + s.Projects + s.SupplyItems
 }
 
 func newMemoryState() memoryState {
@@ -380,8 +645,28 @@ func memoryStateFromSnapshot(s Snapshot) memoryState {
 	return state
 }
 
+// migrateSnapshot repairs an imported snapshot in a fixed sequence of passes:
+// attribute defaults and dangling optional references on organisms, breeding
+// units, markers, and lines come first, then strains whose line is gone are
+// dropped, followed by protocols, housing units, treatments, observations,
+// samples, permits, projects, procedures, and supplies, and finally the derived
+// facility and project ID lists are rebuilt from the surviving records. Passes
+// that delete orphans walk their map in sorted key order so the surviving set
+// is identical across runs.
+//
 //nolint:gocyclo // migrateSnapshot aggregates multiple migration concerns in one pass for parity with existing snapshots.
 func migrateSnapshot(snapshot Snapshot) Snapshot {
+	migrated, _ := migrateSnapshotWithPolicy(snapshot, defaultRepairPolicy)
+	return migrated
+}
+
+// migrateSnapshotWithPolicy runs the migrateSnapshot passes, applying policy
+// to lines, permits, projects, and supply items whose required reference list
+// is emptied by dropping references to missing records. It returns the
+// migrated snapshot and every emptied list, sorted by entity, ID, and field.
+//
+//nolint:gocyclo // see migrateSnapshot.
+func migrateSnapshotWithPolicy(snapshot Snapshot, policy RepairPolicy) (Snapshot, []EmptyListRepair) {
 	if snapshot.Organisms == nil {
 		snapshot.Organisms = map[string]Organism{}
 	}
@@ -460,6 +745,17 @@ func migrateSnapshot(snapshot Snapshot) Snapshot {
 		return ok
 	}
 
+	var emptied []EmptyListRepair
+	emptiedBy := func(entity domain.EntityType, id, field string, filtered []string) bool {
+		if len(filtered) > 0 {
+			return false
+		}
+		emptied = append(emptied, EmptyListRepair{Entity: entity, ID: id, Field: field})
+		return true
+	}
+	deleteEmptied := policy == RepairPolicyDelete
+	deletedProjects := make(map[string]struct{})
+
 	for id, organism := range snapshot.Organisms {
 		if attrs := organism.CoreAttributes(); attrs == nil {
 			mustApply("apply organism attributes", organism.SetCoreAttributes(map[string]any{}))
@@ -493,6 +789,8 @@ func migrateSnapshot(snapshot Snapshot) Snapshot {
 		if breeding.TargetStrainID != nil && !strainExists(*breeding.TargetStrainID) {
 			breeding.TargetStrainID = nil
 		}
+		migrateLegacyPairingIntent(&breeding)
+		mustApply("normalize breeding pairing intent", normalizeBreedingUnit(&breeding))
 		snapshot.Breeding[id] = breeding
 	}
 
@@ -508,7 +806,8 @@ func migrateSnapshot(snapshot Snapshot) Snapshot {
 		snapshot.Markers[id] = marker
 	}
 
-	for id, line := range snapshot.Lines {
+	for _, id := range sortedKeys(snapshot.Lines) {
+		line := snapshot.Lines[id]
 		if attrs := line.DefaultAttributes(); attrs == nil {
 			mustApply("apply line default attributes", line.ApplyDefaultAttributes(map[string]any{}))
 		} else {
@@ -521,11 +820,16 @@ func migrateSnapshot(snapshot Snapshot) Snapshot {
 		}
 		if filtered, changed := filterIDs(line.GenotypeMarkerIDs, markerExists); changed {
 			line.GenotypeMarkerIDs = filtered
+			if emptiedBy(domain.EntityLine, id, "genotype_marker_ids", filtered) && deleteEmptied {
+				delete(snapshot.Lines, id)
+				continue
+			}
 		}
 		snapshot.Lines[id] = line
 	}
 
-	for id, strain := range snapshot.Strains {
+	for _, id := range sortedKeys(snapshot.Strains) {
+		strain := snapshot.Strains[id]
 		if !lineExists(strain.LineID) {
 			delete(snapshot.Strains, id)
 			continue
@@ -551,6 +855,22 @@ func migrateSnapshot(snapshot Snapshot) Snapshot {
 		snapshot.Organisms[id] = organism
 	}
 
+	for id, breeding := range snapshot.Breeding {
+		if breeding.LineID != nil && !lineExists(*breeding.LineID) {
+			breeding.LineID = nil
+		}
+		if breeding.StrainID != nil && !strainExists(*breeding.StrainID) {
+			breeding.StrainID = nil
+		}
+		if breeding.TargetLineID != nil && !lineExists(*breeding.TargetLineID) {
+			breeding.TargetLineID = nil
+		}
+		if breeding.TargetStrainID != nil && !strainExists(*breeding.TargetStrainID) {
+			breeding.TargetStrainID = nil
+		}
+		snapshot.Breeding[id] = breeding
+	}
+
 	procedureExists := func(id string) bool {
 		_, ok := snapshot.Procedures[id]
 		return ok
@@ -560,7 +880,8 @@ func migrateSnapshot(snapshot Snapshot) Snapshot {
 		return ok
 	}
 
-	for id, protocol := range snapshot.Protocols {
+	for _, id := range sortedKeys(snapshot.Protocols) {
+		protocol := snapshot.Protocols[id]
 		if err := normalizeProtocol(&protocol); err != nil {
 			delete(snapshot.Protocols, id)
 			continue
@@ -568,7 +889,8 @@ func migrateSnapshot(snapshot Snapshot) Snapshot {
 		snapshot.Protocols[id] = protocol
 	}
 
-	for id, housing := range snapshot.Housing {
+	for _, id := range sortedKeys(snapshot.Housing) {
+		housing := snapshot.Housing[id]
 		if housing.FacilityID == "" || !facilityExists(housing.FacilityID) {
 			delete(snapshot.Housing, id)
 			continue
@@ -583,7 +905,8 @@ func migrateSnapshot(snapshot Snapshot) Snapshot {
 		snapshot.Housing[id] = housing
 	}
 
-	for id, treatment := range snapshot.Treatments {
+	for _, id := range sortedKeys(snapshot.Treatments) {
+		treatment := snapshot.Treatments[id]
 		if treatment.ProcedureID == "" || !procedureExists(treatment.ProcedureID) {
 			delete(snapshot.Treatments, id)
 			continue
@@ -601,7 +924,9 @@ func migrateSnapshot(snapshot Snapshot) Snapshot {
 		snapshot.Treatments[id] = treatment
 	}
 
-	for id, observation := range snapshot.Observations {
+	for _, id := range sortedKeys(snapshot.Observations) {
+		observation := snapshot.Observations[id]
+		syncObservationRecorder(&observation)
 		if data := observation.ObservationData(); data == nil {
 			mustApply("apply observation data", observation.ApplyObservationData(map[string]any{}))
 		} else {
@@ -623,7 +948,8 @@ func migrateSnapshot(snapshot Snapshot) Snapshot {
 		snapshot.Observations[id] = observation
 	}
 
-	for id, sample := range snapshot.Samples {
+	for _, id := range sortedKeys(snapshot.Samples) {
+		sample := snapshot.Samples[id]
 		if attrs := sample.SampleAttributes(); attrs == nil {
 			mustApply("apply sample attributes", sample.ApplySampleAttributes(map[string]any{}))
 		} else {
@@ -650,12 +976,20 @@ func migrateSnapshot(snapshot Snapshot) Snapshot {
 		snapshot.Samples[id] = sample
 	}
 
-	for id, permit := range snapshot.Permits {
+	for _, id := range sortedKeys(snapshot.Permits) {
+		permit := snapshot.Permits[id]
+		emptiedList := false
 		if filtered, changed := filterIDs(permit.FacilityIDs, facilityExists); changed {
 			permit.FacilityIDs = filtered
+			emptiedList = emptiedBy(domain.EntityPermit, id, "facility_ids", filtered)
 		}
 		if filtered, changed := filterIDs(permit.ProtocolIDs, protocolExists); changed {
 			permit.ProtocolIDs = filtered
+			emptiedList = emptiedBy(domain.EntityPermit, id, "protocol_ids", filtered) || emptiedList
+		}
+		if emptiedList && deleteEmptied {
+			delete(snapshot.Permits, id)
+			continue
 		}
 		if err := normalizePermit(&permit); err != nil {
 			delete(snapshot.Permits, id)
@@ -664,18 +998,51 @@ func migrateSnapshot(snapshot Snapshot) Snapshot {
 		snapshot.Permits[id] = permit
 	}
 
-	for id, project := range snapshot.Projects {
+	for _, id := range sortedKeys(snapshot.Projects) {
+		project := snapshot.Projects[id]
 		if filtered, changed := filterIDs(project.FacilityIDs, facilityExists); changed {
 			project.FacilityIDs = filtered
+			if emptiedBy(domain.EntityProject, id, "facility_ids", filtered) && deleteEmptied {
+				delete(snapshot.Projects, id)
+				deletedProjects[id] = struct{}{}
+				continue
+			}
+		}
+		if filtered, changed := filterIDs(project.ProtocolIDs, protocolExists); changed {
+			project.ProtocolIDs = filtered
 		}
 		snapshot.Projects[id] = project
 	}
 
-	for id, procedure := range snapshot.Procedures {
+	projectDeleted := func(id *string) bool {
+		if id == nil {
+			return false
+		}
+		_, ok := deletedProjects[*id]
+		return ok
+	}
+	for id, organism := range snapshot.Organisms {
+		if projectDeleted(organism.ProjectID) {
+			organism.ProjectID = nil
+			snapshot.Organisms[id] = organism
+		}
+	}
+	for id, cohort := range snapshot.Cohorts {
+		if projectDeleted(cohort.ProjectID) {
+			cohort.ProjectID = nil
+			snapshot.Cohorts[id] = cohort
+		}
+	}
+
+	for _, id := range sortedKeys(snapshot.Procedures) {
+		procedure := snapshot.Procedures[id]
 		if err := normalizeProcedure(&procedure); err != nil {
 			delete(snapshot.Procedures, id)
 			continue
 		}
+		if projectDeleted(procedure.ProjectID) {
+			procedure.ProjectID = nil
+		}
 		var treatmentIDs []string
 		for _, treatment := range snapshot.Treatments {
 			if treatment.ProcedureID == id {
@@ -697,17 +1064,29 @@ func migrateSnapshot(snapshot Snapshot) Snapshot {
 		snapshot.Procedures[id] = procedure
 	}
 
-	for id, item := range snapshot.Supplies {
+	for _, id := range sortedKeys(snapshot.Supplies) {
+		item := snapshot.Supplies[id]
 		if attrs := item.SupplyAttributes(); attrs == nil {
 			mustApply("apply supply attributes", item.ApplySupplyAttributes(map[string]any{}))
 		} else {
 			mustApply("apply supply attributes", item.ApplySupplyAttributes(attrs))
 		}
+		emptiedList := false
 		if filtered, changed := filterIDs(item.FacilityIDs, facilityExists); changed {
 			item.FacilityIDs = filtered
+			emptiedList = emptiedBy(domain.EntitySupplyItem, id, "facility_ids", filtered)
 		}
 		if filtered, changed := filterIDs(item.ProjectIDs, projectExists); changed {
 			item.ProjectIDs = filtered
+			emptiedList = emptiedBy(domain.EntitySupplyItem, id, "project_ids", filtered) || emptiedList
+		}
+		if emptiedList && deleteEmptied {
+			delete(snapshot.Supplies, id)
+			continue
+		}
+		if err := normalizeSupplyItem(&item); err != nil {
+			delete(snapshot.Supplies, id)
+			continue
 		}
 		snapshot.Supplies[id] = item
 	}
@@ -774,7 +1153,8 @@ func migrateSnapshot(snapshot Snapshot) Snapshot {
 		snapshot.Projects[id] = project
 	}
 
-	return snapshot
+	sortEmptyListRepairs(emptied)
+	return snapshot, emptied
 }
 
 func (s memoryState) clone() memoryState {
@@ -876,6 +1256,9 @@ func cloneLine(l Line) Line {
 		cp.DeprecationReason = &reason
 	}
 	cp.GenotypeMarkerIDs = append([]string(nil), l.GenotypeMarkerIDs...)
+	if l.Tags != nil {
+		cp.Tags = append([]string(nil), l.Tags...)
+	}
 	container, err := l.LineExtensions()
 	if err != nil {
 		panic(fmt.Errorf("memory: clone line extensions: %w", err))
@@ -935,7 +1318,11 @@ func cloneProcedure(p Procedure) Procedure {
 	cp.ObservationIDs = append([]string(nil), p.ObservationIDs...)
 	return cp
 }
-func cloneProtocol(p Protocol) Protocol { return p }
+func cloneProtocol(p Protocol) Protocol {
+	cp := p
+	cp.ReviewerIDs = append([]string(nil), p.ReviewerIDs...)
+	return cp
+}
 func cloneProject(p Project) Project {
 	cp := p
 	cp.FacilityIDs = append([]string(nil), p.FacilityIDs...)
@@ -943,6 +1330,10 @@ func cloneProject(p Project) Project {
 	cp.OrganismIDs = append([]string(nil), p.OrganismIDs...)
 	cp.ProcedureIDs = append([]string(nil), p.ProcedureIDs...)
 	cp.SupplyItemIDs = append([]string(nil), p.SupplyItemIDs...)
+	if p.ClosedAt != nil {
+		t := *p.ClosedAt
+		cp.ClosedAt = &t
+	}
 	return cp
 }
 
@@ -955,6 +1346,12 @@ func cloneFacility(f Facility) Facility {
 	if err := cp.SetFacilityExtensions(container); err != nil {
 		panic(fmt.Errorf("memory: set facility baselines: %w", err))
 	}
+	cp.Timezone = cloneOptionalString(f.Timezone)
+	cp.AccreditationNumber = cloneOptionalString(f.AccreditationNumber)
+	if f.AccreditationExpiresAt != nil {
+		t := *f.AccreditationExpiresAt
+		cp.AccreditationExpiresAt = &t
+	}
 	cp.HousingUnitIDs = append([]string(nil), f.HousingUnitIDs...)
 	cp.ProjectIDs = append([]string(nil), f.ProjectIDs...)
 	return cp
@@ -965,12 +1362,39 @@ func cloneTreatment(t Treatment) Treatment {
 	cp.OrganismIDs = append([]string(nil), t.OrganismIDs...)
 	cp.CohortIDs = append([]string(nil), t.CohortIDs...)
 	cp.AdministrationLog = append([]string(nil), t.AdministrationLog...)
-	cp.AdverseEvents = append([]string(nil), t.AdverseEvents...)
+	cp.AdverseEvents = append([]domain.AdverseEvent(nil), t.AdverseEvents...)
 	return cp
 }
 
+func cloneOptionalString(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	cp := *v
+	return &cp
+}
+
 func cloneObservation(o Observation) Observation {
 	cp := o
+	cp.ProcedureID = cloneOptionalString(o.ProcedureID)
+	cp.OrganismID = cloneOptionalString(o.OrganismID)
+	cp.CohortID = cloneOptionalString(o.CohortID)
+	cp.Notes = cloneOptionalString(o.Notes)
+	if o.RecordedBy != nil {
+		recordedBy := *o.RecordedBy
+		cp.RecordedBy = &recordedBy
+	}
+	if o.ReviewedBy != nil {
+		reviewedBy := *o.ReviewedBy
+		cp.ReviewedBy = &reviewedBy
+	}
+	if o.ReviewedAt != nil {
+		t := *o.ReviewedAt
+		cp.ReviewedAt = &t
+	}
+	if o.Attachments != nil {
+		cp.Attachments = append([]domain.AttachmentRef(nil), o.Attachments...)
+	}
 	container, err := o.ObservationExtensions()
 	if err != nil {
 		panic(fmt.Errorf("memory: clone observation data: %w", err))
@@ -981,9 +1405,42 @@ func cloneObservation(o Observation) Observation {
 	return cp
 }
 
+// syncObservationRecorder keeps the legacy observer field and recorded_by in
+// step so readers of either field see the same value. Where the two disagree,
+// as in records stored before they were kept in step, recorded_by wins.
+func syncObservationRecorder(o *Observation) {
+	if o.RecordedBy == nil {
+		if o.Observer != "" {
+			recordedBy := o.Observer
+			o.RecordedBy = &recordedBy
+		}
+		return
+	}
+	o.Observer = *o.RecordedBy
+}
+
+// reconcileObservationRecorder syncs observer and recorded_by on a write that
+// turns before into o; before is the zero value for a create. A field the write
+// left unchanged follows the one it changed, so the two never drift apart, and
+// setting both to different values is rejected.
+func reconcileObservationRecorder(before Observation, o *Observation) error {
+	observerChanged := o.Observer != before.Observer
+	recordedByChanged := (o.RecordedBy == nil) != (before.RecordedBy == nil) ||
+		(o.RecordedBy != nil && *o.RecordedBy != *before.RecordedBy)
+	if observerChanged && recordedByChanged && o.Observer != "" && o.RecordedBy != nil && *o.RecordedBy != o.Observer {
+		return fmt.Errorf("observation observer %q and recorded_by %q disagree", o.Observer, *o.RecordedBy)
+	}
+	if observerChanged && !recordedByChanged {
+		o.RecordedBy = nil
+	}
+	syncObservationRecorder(o)
+	return nil
+}
+
 func cloneSample(s Sample) Sample {
 	cp := s
 	cp.ChainOfCustody = append([]domain.SampleCustodyEvent(nil), s.ChainOfCustody...)
+	cp.CollectionProtocol = cloneOptionalString(s.CollectionProtocol)
 	container, err := s.SampleExtensions()
 	if err != nil {
 		panic(fmt.Errorf("memory: clone sample attributes: %w", err))
@@ -1148,10 +1605,10 @@ func projectSupplyItemIDs(state *memoryState, projectID string) []string {
 	return ids
 }
 
-func decorateProject(state *memoryState, project Project) Project {
-	project.OrganismIDs = projectOrganismIDs(state, project.ID)
-	project.ProcedureIDs = projectProcedureIDs(state, project.ID)
-	project.SupplyItemIDs = projectSupplyItemIDs(state, project.ID)
+func (v transactionView) decorateProject(project Project) Project {
+	project.OrganismIDs = projectOrganismIDs(v.state, project.ID)
+	project.ProcedureIDs = projectProcedureIDs(v.state, project.ID)
+	project.SupplyItemIDs = projectSupplyItemIDs(v.state, project.ID)
 	return project
 }
 
@@ -1175,22 +1632,37 @@ func cloneSupplyItem(s SupplyItem) SupplyItem {
 
 // Store provides an in-memory transactional store for the core domain.
 type Store struct {
-	mu     sync.RWMutex
-	state  memoryState
-	engine *RulesEngine
-	nowFn  func() time.Time
+	mu                sync.RWMutex
+	state             memoryState
+	engine            *RulesEngine
+	nowFn             func() time.Time
+	maxLineageDepth   int
+	maxAttributeDepth int
+	maxAttributeKeys  int
+	blobs             domain.AttachmentBlobStore
+	verifyOnRead      bool
+	observers         []domain.ChangeSink
+	nameIndex         *nameIndex
+	permitSkew        time.Duration
+	idPrefixes        map[domain.EntityType]string
+	scopeResolver     domain.ScopeResolver
 }
 
 // NewStore constructs an in-memory store backed by the provided rules engine.
-func NewStore(engine *RulesEngine) *Store {
+func NewStore(engine *RulesEngine, opts ...StoreOption) *Store {
 	if engine == nil {
 		engine = domain.NewRulesEngine()
 	}
-	return &Store{
-		state:  newMemoryState(),
-		engine: engine,
-		nowFn:  func() time.Time { return time.Now().UTC() },
+	store := &Store{
+		state:           newMemoryState(),
+		engine:          engine,
+		nowFn:           func() time.Time { return time.Now().UTC() },
+		maxLineageDepth: DefaultMaxLineageDepth,
 	}
+	for _, opt := range opts {
+		opt(store)
+	}
+	return store
 }
 
 func (s *Store) newID() string {
@@ -1205,14 +1677,18 @@ func (s *Store) newID() string {
 func (s *Store) ExportState() Snapshot {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return snapshotFromMemoryState(s.state)
+	snapshot := snapshotFromMemoryState(s.state)
+	snapshot.SchemaVersion = entitymodel.SchemaVersion
+	return snapshot
 }
 
-// ImportState replaces the store state with the provided snapshot.
-func (s *Store) ImportState(snapshot Snapshot) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.state = memoryStateFromSnapshot(migrateSnapshot(snapshot))
+// ImportState replaces the store state with the provided snapshot. A snapshot
+// stamped with a newer schema version than this binary fails with
+// ErrSnapshotTooNew and leaves the store unchanged. Use Import to inspect the
+// repairs applied along the way.
+func (s *Store) ImportState(snapshot Snapshot, opts ...ImportOption) error {
+	_, err := s.Import(snapshot, opts...)
+	return err
 }
 
 // RulesEngine exposes the currently configured engine for integration points like plugins.
@@ -1232,19 +1708,24 @@ func (s *Store) NowFunc() func() time.Time {
 // Transaction represents a mutation set applied to the store state.
 type transaction struct {
 	store   *Store
+	ctx     context.Context
 	state   memoryState
 	changes []Change
 	now     time.Time
 	err     error
+	// organismIDs lists the organisms written by the transaction so the name
+	// index can be updated from the committed state.
+	organismIDs []string
 }
 
 // TransactionView exposes a read-only snapshot of the transactional state to rules.
 type transactionView struct {
-	state *memoryState
+	state      *memoryState
+	permitSkew time.Duration
 }
 
-func newTransactionView(state *memoryState) TransactionView {
-	return transactionView{state: state}
+func newTransactionView(state *memoryState, permitSkew time.Duration) transactionView {
+	return transactionView{state: state, permitSkew: permitSkew}
 }
 
 // ListOrganisms returns all organisms within the transaction snapshot.
@@ -1292,6 +1773,28 @@ func (v transactionView) ListStrains() []Strain {
 	return out
 }
 
+// ActiveStrainCount reports how many strains of lineID have not been retired.
+func (v transactionView) ActiveStrainCount(lineID string) int {
+	count := 0
+	for _, strain := range v.state.strains {
+		if strain.LineID == lineID && strain.RetiredAt == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// ActiveLineCount reports how many lines have not been deprecated.
+func (v transactionView) ActiveLineCount() int {
+	count := 0
+	for _, line := range v.state.lines {
+		if line.DeprecatedAt == nil {
+			count++
+		}
+	}
+	return count
+}
+
 // ListGenotypeMarkers returns all genotype markers in the snapshot.
 func (v transactionView) ListGenotypeMarkers() []GenotypeMarker {
 	out := make([]GenotypeMarker, 0, len(v.state.markers))
@@ -1373,6 +1876,16 @@ func (v transactionView) ListTreatments() []Treatment {
 	return out
 }
 
+// ListTreatmentsByProcedure returns the treatments linked to procedureID, ordered by ID.
+func (v transactionView) ListTreatmentsByProcedure(procedureID string) []Treatment {
+	ids := procedureTreatmentIDs(v.state, procedureID)
+	out := make([]Treatment, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, cloneTreatment(v.state.treatments[id]))
+	}
+	return out
+}
+
 // FindTreatment retrieves a treatment by ID from the snapshot.
 func (v transactionView) FindTreatment(id string) (Treatment, bool) {
 	t, ok := v.state.treatments[id]
@@ -1391,6 +1904,37 @@ func (v transactionView) ListObservations() []Observation {
 	return out
 }
 
+// ListObservationsByOrganism returns observations recorded directly against the
+// organism that satisfy every filter.
+func (v transactionView) ListObservationsByOrganism(organismID string, filters ...domain.ObservationFilter) []Observation {
+	return v.filterObservations(func(o Observation) bool {
+		return o.OrganismID != nil && *o.OrganismID == organismID && domain.MatchesObservationFilters(o, filters...)
+	})
+}
+
+// ListObservationsByCohort returns observations recorded directly against the cohort.
+func (v transactionView) ListObservationsByCohort(cohortID string) []Observation {
+	return v.filterObservations(func(o Observation) bool {
+		return o.CohortID != nil && *o.CohortID == cohortID
+	})
+}
+
+func (v transactionView) filterObservations(match func(Observation) bool) []Observation {
+	out := make([]Observation, 0)
+	for _, o := range v.state.observations {
+		if match(o) {
+			out = append(out, cloneObservation(o))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].RecordedAt.Equal(out[j].RecordedAt) {
+			return out[i].RecordedAt.Before(out[j].RecordedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
 // FindObservation retrieves an observation by ID from the snapshot.
 func (v transactionView) FindObservation(id string) (Observation, bool) {
 	o, ok := v.state.observations[id]
@@ -1409,6 +1953,32 @@ func (v transactionView) ListSamples() []Sample {
 	return out
 }
 
+// ListSamplesByFacility returns samples stored at the facility, ordered by ID.
+func (v transactionView) ListSamplesByFacility(facilityID string) []Sample {
+	return v.filterSamples(func(s Sample) bool {
+		return s.FacilityID == facilityID
+	})
+}
+
+// ListSamplesByOrganism returns samples collected directly from the organism,
+// ordered by ID. Samples linked only through a cohort are excluded.
+func (v transactionView) ListSamplesByOrganism(organismID string) []Sample {
+	return v.filterSamples(func(s Sample) bool {
+		return s.OrganismID != nil && *s.OrganismID == organismID
+	})
+}
+
+func (v transactionView) filterSamples(match func(Sample) bool) []Sample {
+	out := make([]Sample, 0)
+	for _, s := range v.state.samples {
+		if match(s) {
+			out = append(out, cloneSample(s))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
 // FindSample retrieves a sample by ID from the snapshot.
 func (v transactionView) FindSample(id string) (Sample, bool) {
 	s, ok := v.state.samples[id]
@@ -1440,7 +2010,35 @@ func (v transactionView) FindPermit(id string) (Permit, bool) {
 func (v transactionView) ListProjects() []Project {
 	out := make([]Project, 0, len(v.state.projects))
 	for _, p := range v.state.projects {
-		out = append(out, cloneProject(decorateProject(v.state, p)))
+		out = append(out, cloneProject(v.decorateProject(p)))
+	}
+	return out
+}
+
+// ListCohorts returns all cohorts in the snapshot. It is not part of
+// domain.TransactionView; point-in-time readers discover it by assertion.
+func (v transactionView) ListCohorts() []Cohort {
+	out := make([]Cohort, 0, len(v.state.cohorts))
+	for _, c := range v.state.cohorts {
+		out = append(out, cloneCohort(c))
+	}
+	return out
+}
+
+// ListBreedingUnits returns all breeding units in the snapshot.
+func (v transactionView) ListBreedingUnits() []BreedingUnit {
+	out := make([]BreedingUnit, 0, len(v.state.breeding))
+	for _, b := range v.state.breeding {
+		out = append(out, cloneBreeding(b))
+	}
+	return out
+}
+
+// ListProcedures returns all procedures in the snapshot.
+func (v transactionView) ListProcedures() []Procedure {
+	out := make([]Procedure, 0, len(v.state.procedures))
+	for _, p := range v.state.procedures {
+		out = append(out, cloneProcedure(decorateProcedure(v.state, p)))
 	}
 	return out
 }
@@ -1463,48 +2061,70 @@ func (v transactionView) FindSupplyItem(id string) (SupplyItem, bool) {
 	return cloneSupplyItem(s), true
 }
 
+// FindCohort retrieves a cohort by ID from the snapshot.
+func (v transactionView) FindCohort(id string) (Cohort, bool) {
+	c, ok := v.state.cohorts[id]
+	if !ok {
+		return Cohort{Cohort: entitymodel.Cohort{}}, false
+	}
+	return cloneCohort(c), true
+}
+
 // FindProcedure retrieves a procedure by ID from the snapshot.
 func (v transactionView) FindProcedure(id string) (Procedure, bool) {
 	p, ok := v.state.procedures[id]
 	if !ok {
 		return Procedure{Procedure: entitymodel.Procedure{}}, false
 	}
-	return cloneProcedure(p), true
+	return cloneProcedure(decorateProcedure(v.state, p)), true
 }
 
 // RunInTransaction executes fn within a transactional copy of the store state.
 func (s *Store) RunInTransaction(ctx context.Context, fn func(tx Transaction) error) (Result, error) {
+	result, commit, err := s.runInTransaction(ctx, fn)
+	if err != nil {
+		return result, err
+	}
+	commit.notify(ctx)
+	return result, nil
+}
+
+// runInTransaction applies fn under the store lock and returns the committed
+// changes alongside the observers to notify once the lock is released.
+func (s *Store) runInTransaction(ctx context.Context, fn func(tx Transaction) error) (Result, committedTransaction, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	tx := &transaction{
 		store: s,
+		ctx:   ctx,
 		state: s.state.clone(),
 		now:   s.nowFn(),
 	}
 
 	if err := fn(tx); err != nil {
-		return Result{}, err
+		return Result{}, committedTransaction{}, err
 	}
 	if tx.err != nil {
-		return Result{}, tx.err
+		return Result{}, committedTransaction{}, tx.err
 	}
 
 	var result Result
 	if s.engine != nil {
-		view := newTransactionView(&tx.state)
+		view := newTransactionView(&tx.state, s.permitSkew)
 		res, err := s.engine.Evaluate(ctx, view, tx.changes)
 		if err != nil {
-			return Result{}, err
+			return Result{}, committedTransaction{}, err
 		}
 		result = res
 		if res.HasBlocking() {
-			return res, domain.RuleViolationError{Result: res}
+			return res, committedTransaction{}, domain.RuleViolationError{Result: res}
 		}
 	}
 
 	s.state = tx.state
-	return result, nil
+	s.syncNameIndex(tx.organismIDs)
+	return result, committedTransaction{changes: tx.changes, observers: s.observers}, nil
 }
 
 // View executes fn against a read-only snapshot of the store state.
@@ -1513,7 +2133,7 @@ func (s *Store) View(_ context.Context, fn func(TransactionView) error) error {
 	defer s.mu.RUnlock()
 
 	snapshot := s.state.clone()
-	view := newTransactionView(&snapshot)
+	view := newTransactionView(&snapshot, s.permitSkew)
 	return fn(view)
 }
 
@@ -1536,9 +2156,16 @@ func changePayloadFromValue[T any](tx *transaction, value T) domain.ChangePayloa
 	return payload
 }
 
-// Snapshot returns a read-only view over the transactional state.
+// Snapshot returns a read-only view over the transactional state, carrying
+// the store's permit skew like the view the rules engine evaluates.
 func (tx *transaction) Snapshot() TransactionView {
-	return newTransactionView(&tx.state)
+	return tx.view()
+}
+
+// view exposes the transaction's working state through the snapshot view so
+// derived fields are computed the same way for reads and writes.
+func (tx *transaction) view() transactionView {
+	return newTransactionView(&tx.state, tx.store.permitSkew)
 }
 
 // FindHousingUnit exposes housing lookup within the transaction scope.
@@ -1640,19 +2267,30 @@ func (tx *transaction) FindSupplyItem(id string) (SupplyItem, bool) {
 	return cloneSupplyItem(s), true
 }
 
+// FindProject exposes project lookup within the transaction scope.
+func (tx *transaction) FindProject(id string) (Project, bool) {
+	p, ok := tx.state.projects[id]
+	if !ok {
+		return Project{Project: entitymodel.Project{}}, false
+	}
+	return cloneProject(tx.view().decorateProject(p)), true
+}
+
 // FindProcedure exposes procedure lookup within the transaction scope.
 func (tx *transaction) FindProcedure(id string) (Procedure, bool) {
 	p, ok := tx.state.procedures[id]
 	if !ok {
 		return Procedure{Procedure: entitymodel.Procedure{}}, false
 	}
-	return cloneProcedure(p), true
+	return cloneProcedure(decorateProcedure(&tx.state, p)), true
 }
 
 // CreateOrganism stores a new organism within the transaction.
 func (tx *transaction) CreateOrganism(o Organism) (Organism, error) {
 	if o.ID == "" {
-		o.ID = tx.store.newID()
+		o.ID = tx.store.newEntityID(domain.EntityOrganism)
+	} else if err := tx.store.checkIDPrefix(domain.EntityOrganism, o.ID); err != nil {
+		return Organism{Organism: entitymodel.Organism{}}, err
 	}
 	if o.Stage == "" {
 		o.Stage = domain.StagePlanned
@@ -1662,57 +2300,173 @@ func (tx *transaction) CreateOrganism(o Organism) (Organism, error) {
 	}
 	o.CreatedAt = tx.now
 	o.UpdatedAt = tx.now
+	o.Version = 1
 	if attrs := o.CoreAttributes(); attrs == nil {
 		mustApply("apply organism attributes", o.SetCoreAttributes(map[string]any{}))
 	} else {
 		mustApply("apply organism attributes", o.SetCoreAttributes(attrs))
 	}
+	if err := checkAttributeLimits(tx, domain.EntityOrganism, o.ID, o.OrganismExtensions); err != nil {
+		return Organism{Organism: entitymodel.Organism{}}, err
+	}
 	tx.state.organisms[o.ID] = cloneOrganism(o)
+	tx.organismIDs = append(tx.organismIDs, o.ID)
 	tx.recordChange(Change{Entity: domain.EntityOrganism, Action: domain.ActionCreate, After: changePayloadFromValue(tx, cloneOrganism(o))})
 	return cloneOrganism(o), nil
 }
 
 // UpdateOrganism mutates an organism using the provided mutator function.
 func (tx *transaction) UpdateOrganism(id string, mutator func(*Organism) error) (Organism, error) {
+	return tx.updateOrganism(id, mutator, 0, nil)
+}
+
+// UpdateOrganismIfVersion mutates an organism like UpdateOrganism. A non-zero
+// expectedVersion must match the stored version or the update fails with
+// domain.ErrVersionConflict.
+func (tx *transaction) UpdateOrganismIfVersion(id string, expectedVersion int, mutator func(*Organism) error) (Organism, error) {
+	return tx.updateOrganism(id, mutator, expectedVersion, nil)
+}
+
+// UpdateOrganismForce applies mutator whatever the stored version and records
+// the change as ActionForceUpdate. The transaction context must carry an
+// actor and reason from domain.WithForceUpdate.
+func (tx *transaction) UpdateOrganismForce(id string, mutator func(*Organism) error) (Organism, error) {
+	override, ok := domain.ForceUpdateFromContext(tx.ctx)
+	if !ok {
+		return Organism{Organism: entitymodel.Organism{}}, domain.ErrForceUpdateUnattributed
+	}
+	return tx.updateOrganism(id, mutator, 0, &override)
+}
+
+// updateOrganism applies mutator to an organism. A nil override records an
+// ActionUpdate after the expected version check; a non-nil override skips the
+// check and records an attributed ActionForceUpdate.
+func (tx *transaction) updateOrganism(id string, mutator func(*Organism) error, expectedVersion int, override *domain.ForceUpdate) (Organism, error) {
 	current, ok := tx.state.organisms[id]
 	if !ok {
 		return Organism{Organism: entitymodel.Organism{}}, fmt.Errorf("organism %q not found", id)
 	}
+	if err := checkExpectedVersion(domain.EntityOrganism, id, current.Version, expectedVersion); err != nil {
+		return Organism{Organism: entitymodel.Organism{}}, err
+	}
 	before := cloneOrganism(current)
 	if err := mutator(&current); err != nil {
 		return Organism{Organism: entitymodel.Organism{}}, err
 	}
 	current.ID = id
 	current.UpdatedAt = tx.now
+	current.Version = before.Version + 1
+	if err := checkAttributeLimits(tx, domain.EntityOrganism, id, current.OrganismExtensions); err != nil {
+		return Organism{Organism: entitymodel.Organism{}}, err
+	}
 	tx.state.organisms[id] = cloneOrganism(current)
-	tx.recordChange(Change{Entity: domain.EntityOrganism, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneOrganism(current))})
+	tx.organismIDs = append(tx.organismIDs, id)
+	change := Change{Entity: domain.EntityOrganism, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneOrganism(current))}
+	if override != nil {
+		change.Action = domain.ActionForceUpdate
+		change.Override = override
+	}
+	tx.recordChange(change)
 	return cloneOrganism(current), nil
 }
 
+// checkExpectedVersion compares a non-zero expected version against the
+// stored version.
+func checkExpectedVersion(entity domain.EntityType, id string, actual, expected int) error {
+	if expected == 0 || expected == actual {
+		return nil
+	}
+	return domain.ErrVersionConflict{Entity: entity, ID: id, Expected: expected, Actual: actual}
+}
+
 // DeleteOrganism removes an organism from the transaction state.
 func (tx *transaction) DeleteOrganism(id string) error {
 	current, ok := tx.state.organisms[id]
 	if !ok {
 		return fmt.Errorf("organism %q not found", id)
 	}
-	for _, sample := range tx.state.samples {
-		if sample.OrganismID != nil && *sample.OrganismID == id {
-			return fmt.Errorf("organism %q still referenced by sample %q", id, sample.ID)
-		}
+	if err := tx.requireUnreferenced(domain.EntityOrganism, id, domain.EntitySample); err != nil {
+		return err
 	}
 	delete(tx.state.organisms, id)
+	tx.organismIDs = append(tx.organismIDs, id)
 	tx.recordChange(Change{Entity: domain.EntityOrganism, Action: domain.ActionDelete, Before: changePayloadFromValue(tx, cloneOrganism(current))})
 	return nil
 }
 
+// AddOrganismToCohort enrolls an existing organism in an existing cohort.
+// Species homogeneity is enforced by the rules engine when the transaction
+// commits.
+func (tx *transaction) AddOrganismToCohort(organismID, cohortID string) (Organism, error) {
+	current, ok := tx.state.organisms[organismID]
+	if !ok {
+		return Organism{Organism: entitymodel.Organism{}}, fmt.Errorf("organism %q not found", organismID)
+	}
+	if _, ok := tx.state.cohorts[cohortID]; !ok {
+		return Organism{Organism: entitymodel.Organism{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityOrganism, Field: "cohort_id", ReferencedEntity: domain.EntityCohort, ReferencedID: cohortID}
+	}
+	before := cloneOrganism(current)
+	current.CohortID = &cohortID
+	current.UpdatedAt = tx.now
+	current.Version = before.Version + 1
+	tx.state.organisms[organismID] = cloneOrganism(current)
+	tx.organismIDs = append(tx.organismIDs, organismID)
+	tx.recordChange(Change{Entity: domain.EntityOrganism, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneOrganism(current))})
+	return cloneOrganism(current), nil
+}
+
+// MoveOrganismToProject reassigns an organism to another project. The move is
+// rejected when the organism's housing lies outside the target project's
+// facilities or when it follows a protocol the target project does not
+// permit. Project organism lists are derived, so both projects reflect the
+// move as soon as the organism is updated.
+func (tx *transaction) MoveOrganismToProject(organismID, targetProjectID string) (Organism, error) {
+	current, ok := tx.state.organisms[organismID]
+	if !ok {
+		return Organism{Organism: entitymodel.Organism{}}, fmt.Errorf("organism %q not found", organismID)
+	}
+	project, ok := tx.state.projects[targetProjectID]
+	if !ok {
+		return Organism{Organism: entitymodel.Organism{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityOrganism, Field: "project_id", ReferencedEntity: domain.EntityProject, ReferencedID: targetProjectID}
+	}
+	if current.HousingID != nil {
+		housing, ok := tx.state.housing[*current.HousingID]
+		if !ok {
+			return Organism{Organism: entitymodel.Organism{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityOrganism, Field: "housing_id", ReferencedEntity: domain.EntityHousingUnit, ReferencedID: *current.HousingID}
+		}
+		if !containsString(project.FacilityIDs, housing.FacilityID) {
+			return Organism{Organism: entitymodel.Organism{}}, fmt.Errorf("organism %q is housed in facility %q, which project %q does not include", organismID, housing.FacilityID, targetProjectID)
+		}
+	}
+	if current.ProtocolID != nil && !containsString(project.ProtocolIDs, *current.ProtocolID) {
+		return Organism{Organism: entitymodel.Organism{}}, fmt.Errorf("organism %q follows protocol %q, which project %q does not permit", organismID, *current.ProtocolID, targetProjectID)
+	}
+	before := cloneOrganism(current)
+	current.ProjectID = &targetProjectID
+	current.UpdatedAt = tx.now
+	current.Version = before.Version + 1
+	tx.state.organisms[organismID] = cloneOrganism(current)
+	tx.organismIDs = append(tx.organismIDs, organismID)
+	tx.recordChange(Change{Entity: domain.EntityOrganism, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneOrganism(current))})
+	return cloneOrganism(current), nil
+}
+
 // CreateCohort stores a new cohort.
 func (tx *transaction) CreateCohort(c Cohort) (Cohort, error) {
 	if c.ID == "" {
-		c.ID = tx.store.newID()
+		c.ID = tx.store.newEntityID(domain.EntityCohort)
+	} else if err := tx.store.checkIDPrefix(domain.EntityCohort, c.ID); err != nil {
+		return Cohort{Cohort: entitymodel.Cohort{}}, err
 	}
 	if _, exists := tx.state.cohorts[c.ID]; exists {
 		return Cohort{Cohort: entitymodel.Cohort{}}, fmt.Errorf("cohort %q already exists", c.ID)
 	}
+	if err := tx.requireCohortBreedingUnit(c); err != nil {
+		return Cohort{Cohort: entitymodel.Cohort{}}, err
+	}
+	if err := checkScopedKey(tx.store, domain.EntityCohort, "name", domain.ScopeProject, tx.state.cohorts, c.ID, c, cohortName); err != nil {
+		return Cohort{Cohort: entitymodel.Cohort{}}, err
+	}
 	c.CreatedAt = tx.now
 	c.UpdatedAt = tx.now
 	tx.state.cohorts[c.ID] = cloneCohort(c)
@@ -1720,6 +2474,17 @@ func (tx *transaction) CreateCohort(c Cohort) (Cohort, error) {
 	return cloneCohort(c), nil
 }
 
+// requireCohortBreedingUnit checks the optional litter provenance link.
+func (tx *transaction) requireCohortBreedingUnit(c Cohort) error {
+	if c.CreatedFromBreedingUnitID == nil {
+		return nil
+	}
+	if _, ok := tx.state.breeding[*c.CreatedFromBreedingUnitID]; !ok {
+		return domain.ErrReferentialIntegrity{Entity: domain.EntityCohort, Field: "created_from_breeding_unit_id", ReferencedEntity: domain.EntityBreeding, ReferencedID: *c.CreatedFromBreedingUnitID}
+	}
+	return nil
+}
+
 // UpdateCohort mutates an existing cohort.
 func (tx *transaction) UpdateCohort(id string, mutator func(*Cohort) error) (Cohort, error) {
 	current, ok := tx.state.cohorts[id]
@@ -1730,33 +2495,103 @@ func (tx *transaction) UpdateCohort(id string, mutator func(*Cohort) error) (Coh
 	if err := mutator(&current); err != nil {
 		return Cohort{Cohort: entitymodel.Cohort{}}, err
 	}
-	current.ID = id
+	if err := tx.requireCohortBreedingUnit(current); err != nil {
+		return Cohort{Cohort: entitymodel.Cohort{}}, err
+	}
+	if err := checkScopedKey(tx.store, domain.EntityCohort, "name", domain.ScopeProject, tx.state.cohorts, id, current, cohortName); err != nil {
+		return Cohort{Cohort: entitymodel.Cohort{}}, err
+	}
+	current.ID = id
 	current.UpdatedAt = tx.now
 	tx.state.cohorts[id] = cloneCohort(current)
 	tx.recordChange(Change{Entity: domain.EntityCohort, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneCohort(current))})
 	return cloneCohort(current), nil
 }
 
-// DeleteCohort removes a cohort from state.
+// DeleteCohort removes a cohort from state. It refuses while any sample,
+// organism, observation, treatment, or procedure still references the cohort;
+// DeleteCohortCascade detaches those records instead.
 func (tx *transaction) DeleteCohort(id string) error {
 	current, ok := tx.state.cohorts[id]
 	if !ok {
 		return fmt.Errorf("cohort %q not found", id)
 	}
-	for _, sample := range tx.state.samples {
-		if sample.CohortID != nil && *sample.CohortID == id {
-			return fmt.Errorf("cohort %q still referenced by sample %q", id, sample.ID)
-		}
+	if err := tx.requireUnreferenced(domain.EntityCohort, id, cohortReferenceBlockers...); err != nil {
+		return err
 	}
 	delete(tx.state.cohorts, id)
 	tx.recordChange(Change{Entity: domain.EntityCohort, Action: domain.ActionDelete, Before: changePayloadFromValue(tx, cloneCohort(current))})
 	return nil
 }
 
+// cohortReferenceBlockers lists, in reporting order, the entities whose
+// references keep DeleteCohort from removing a cohort.
+var cohortReferenceBlockers = []domain.EntityType{
+	domain.EntitySample,
+	domain.EntityOrganism,
+	domain.EntityObservation,
+	domain.EntityTreatment,
+	domain.EntityProcedure,
+}
+
+// DeleteCohortCascade removes a cohort after detaching every record that
+// references it. Organisms, observations, samples, and procedures have their
+// cohort_id cleared and treatments drop the cohort from cohort_ids, each
+// through its regular update so the usual validation runs and one update is
+// recorded per record. Those updates precede the cohort's delete in the change
+// log, keeping the delete safe for stores that enforce foreign keys.
+func (tx *transaction) DeleteCohortCascade(id string) error {
+	if _, ok := tx.state.cohorts[id]; !ok {
+		return fmt.Errorf("cohort %q not found", id)
+	}
+	for _, ref := range referencesTo(&tx.state, domain.EntityCohort, id) {
+		if err := tx.detachCohortReference(ref, id); err != nil {
+			return fmt.Errorf("detach %s %q from cohort %q: %w", referenceLabel(ref.Entity), ref.ID, id, err)
+		}
+	}
+	return tx.DeleteCohort(id)
+}
+
+func (tx *transaction) detachCohortReference(ref domain.Reference, cohortID string) error {
+	var err error
+	switch ref.Entity {
+	case domain.EntityOrganism:
+		_, err = tx.UpdateOrganism(ref.ID, func(o *Organism) error {
+			o.CohortID = nil
+			return nil
+		})
+	case domain.EntityObservation:
+		_, err = tx.UpdateObservation(ref.ID, func(o *Observation) error {
+			o.CohortID = nil
+			return nil
+		})
+	case domain.EntitySample:
+		_, err = tx.UpdateSample(ref.ID, func(s *Sample) error {
+			s.CohortID = nil
+			return nil
+		})
+	case domain.EntityProcedure:
+		_, err = tx.UpdateProcedure(ref.ID, func(p *Procedure) error {
+			p.CohortID = nil
+			return nil
+		})
+	case domain.EntityTreatment:
+		_, err = tx.UpdateTreatment(ref.ID, func(t *Treatment) error {
+			t.CohortIDs = slices.DeleteFunc(t.CohortIDs, func(id string) bool { return id == cohortID })
+			return nil
+		})
+	default:
+		err = fmt.Errorf("unexpected reference field %s", ref.Field)
+	}
+	return err
+}
+
 // CreateHousingUnit stores new housing metadata.
 func (tx *transaction) CreateHousingUnit(h HousingUnit) (HousingUnit, error) {
 	if h.ID == "" {
-		h.ID = tx.store.newID()
+		h.ID = tx.store.newEntityID(domain.EntityHousingUnit)
+	} else if err := tx.store.checkIDPrefix(domain.EntityHousingUnit, h.ID); err != nil {
+		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, err
 	}
 	if _, exists := tx.state.housing[h.ID]; exists {
 		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, fmt.Errorf("housing unit %q already exists", h.ID)
@@ -1764,17 +2599,28 @@ func (tx *transaction) CreateHousingUnit(h HousingUnit) (HousingUnit, error) {
 	if h.FacilityID == "" {
 		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, errors.New("housing unit requires facility id")
 	}
-	if _, ok := tx.state.facilities[h.FacilityID]; !ok {
-		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, fmt.Errorf("facility %q not found", h.FacilityID)
+	facility, ok := tx.state.facilities[h.FacilityID]
+	if !ok {
+		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityHousingUnit, Field: "facility_id", ReferencedEntity: domain.EntityFacility, ReferencedID: h.FacilityID}
 	}
 	if h.Capacity <= 0 {
 		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, errors.New("housing capacity must be positive")
 	}
+	if h.Environment == "" {
+		h.Environment = facilityHousingEnvironment(facility)
+	}
 	if err := normalizeHousingUnit(&h); err != nil {
 		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, err
 	}
+	if err := checkFacilityEnvironment(facility, h.Environment); err != nil {
+		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, err
+	}
+	if err := checkScopedKey(tx.store, domain.EntityHousingUnit, "name", domain.ScopeFacility, tx.state.housing, h.ID, h, housingUnitName); err != nil {
+		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, err
+	}
 	h.CreatedAt = tx.now
 	h.UpdatedAt = tx.now
+	h.Version = 1
 	tx.state.housing[h.ID] = cloneHousing(h)
 	tx.recordChange(Change{Entity: domain.EntityHousingUnit, Action: domain.ActionCreate, After: changePayloadFromValue(tx, cloneHousing(h))})
 	return cloneHousing(h), nil
@@ -1793,8 +2639,9 @@ func (tx *transaction) UpdateHousingUnit(id string, mutator func(*HousingUnit) e
 	if current.FacilityID == "" {
 		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, errors.New("housing unit requires facility id")
 	}
-	if _, ok := tx.state.facilities[current.FacilityID]; !ok {
-		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, fmt.Errorf("facility %q not found", current.FacilityID)
+	facility, ok := tx.state.facilities[current.FacilityID]
+	if !ok {
+		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityHousingUnit, Field: "facility_id", ReferencedEntity: domain.EntityFacility, ReferencedID: current.FacilityID}
 	}
 	if current.Capacity <= 0 {
 		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, errors.New("housing capacity must be positive")
@@ -1802,13 +2649,61 @@ func (tx *transaction) UpdateHousingUnit(id string, mutator func(*HousingUnit) e
 	if err := normalizeHousingUnit(&current); err != nil {
 		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, err
 	}
+	if current.FacilityID != before.FacilityID || current.Environment != before.Environment {
+		if err := checkFacilityEnvironment(facility, current.Environment); err != nil {
+			return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, err
+		}
+	}
+	if err := checkScopedKey(tx.store, domain.EntityHousingUnit, "name", domain.ScopeFacility, tx.state.housing, id, current, housingUnitName); err != nil {
+		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, err
+	}
 	current.ID = id
 	current.UpdatedAt = tx.now
+	current.Version = before.Version + 1
 	tx.state.housing[id] = cloneHousing(current)
 	tx.recordChange(Change{Entity: domain.EntityHousingUnit, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneHousing(current))})
 	return cloneHousing(current), nil
 }
 
+// TransferHousing moves a housing unit and its occupants to another facility.
+// Occupants keep their HousingID, so they move with the unit. The transfer is
+// refused when a living occupant would end up in a unit whose environment the
+// target facility does not host, applying the same facility environment check
+// as CreateHousingUnit. Transferring a unit to the facility it already belongs
+// to returns it unchanged.
+func (tx *transaction) TransferHousing(housingID, targetFacilityID string) (HousingUnit, error) {
+	current, ok := tx.state.housing[housingID]
+	if !ok {
+		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, fmt.Errorf("housing unit %q not found", housingID)
+	}
+	target, ok := tx.state.facilities[targetFacilityID]
+	if !ok {
+		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityHousingUnit, Field: "facility_id", ReferencedEntity: domain.EntityFacility, ReferencedID: targetFacilityID}
+	}
+	if current.FacilityID == targetFacilityID {
+		return cloneHousing(current), nil
+	}
+	if err := checkFacilityEnvironment(target, current.Environment); err != nil {
+		for _, organismID := range sortedKeys(tx.state.organisms) {
+			organism := tx.state.organisms[organismID]
+			if organism.HousingID == nil || *organism.HousingID != housingID || isTerminalStage(organism.Stage) {
+				continue
+			}
+			return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, fmt.Errorf("organism %q in housing unit %q: %w", organismID, housingID, err)
+		}
+	}
+	before := cloneHousing(current)
+	current.FacilityID = targetFacilityID
+	if err := checkScopedKey(tx.store, domain.EntityHousingUnit, "name", domain.ScopeFacility, tx.state.housing, housingID, current, housingUnitName); err != nil {
+		return HousingUnit{HousingUnit: entitymodel.HousingUnit{}}, err
+	}
+	current.UpdatedAt = tx.now
+	current.Version = before.Version + 1
+	tx.state.housing[housingID] = cloneHousing(current)
+	tx.recordChange(Change{Entity: domain.EntityHousingUnit, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneHousing(current))})
+	return cloneHousing(current), nil
+}
+
 // DeleteHousingUnit removes housing metadata.
 func (tx *transaction) DeleteHousingUnit(id string) error {
 	current, ok := tx.state.housing[id]
@@ -1823,11 +2718,16 @@ func (tx *transaction) DeleteHousingUnit(id string) error {
 // CreateFacility stores a new facility record.
 func (tx *transaction) CreateFacility(f Facility) (Facility, error) {
 	if f.ID == "" {
-		f.ID = tx.store.newID()
+		f.ID = tx.store.newEntityID(domain.EntityFacility)
+	} else if err := tx.store.checkIDPrefix(domain.EntityFacility, f.ID); err != nil {
+		return Facility{Facility: entitymodel.Facility{}}, err
 	}
 	if _, exists := tx.state.facilities[f.ID]; exists {
 		return Facility{Facility: entitymodel.Facility{}}, fmt.Errorf("facility %q already exists", f.ID)
 	}
+	if err := validateFacility(f); err != nil {
+		return Facility{Facility: entitymodel.Facility{}}, err
+	}
 	f.CreatedAt = tx.now
 	f.UpdatedAt = tx.now
 	f.HousingUnitIDs = nil
@@ -1837,6 +2737,9 @@ func (tx *transaction) CreateFacility(f Facility) (Facility, error) {
 	} else {
 		mustApply("apply facility baselines", f.ApplyEnvironmentBaselines(baselines))
 	}
+	if err := checkAttributeLimits(tx, domain.EntityFacility, f.ID, f.FacilityExtensions); err != nil {
+		return Facility{Facility: entitymodel.Facility{}}, err
+	}
 	tx.state.facilities[f.ID] = cloneFacility(f)
 	created := decorateFacility(&tx.state, f)
 	tx.recordChange(Change{Entity: domain.EntityFacility, Action: domain.ActionCreate, After: changePayloadFromValue(tx, cloneFacility(created))})
@@ -1844,6 +2747,8 @@ func (tx *transaction) CreateFacility(f Facility) (Facility, error) {
 }
 
 // UpdateFacility mutates an existing facility.
+// The mutator sees the derived ID lists populated; they are recomputed on
+// exit.
 func (tx *transaction) UpdateFacility(id string, mutator func(*Facility) error) (Facility, error) {
 	current, ok := tx.state.facilities[id]
 	if !ok {
@@ -1851,9 +2756,13 @@ func (tx *transaction) UpdateFacility(id string, mutator func(*Facility) error)
 	}
 	beforeDecorated := decorateFacility(&tx.state, current)
 	before := cloneFacility(beforeDecorated)
+	current = cloneFacility(beforeDecorated)
 	if err := mutator(&current); err != nil {
 		return Facility{Facility: entitymodel.Facility{}}, err
 	}
+	if err := validateFacility(current); err != nil {
+		return Facility{Facility: entitymodel.Facility{}}, err
+	}
 	if baselines := current.EnvironmentBaselines(); baselines == nil {
 		mustApply("apply facility baselines", current.ApplyEnvironmentBaselines(map[string]any{}))
 	} else {
@@ -1863,6 +2772,9 @@ func (tx *transaction) UpdateFacility(id string, mutator func(*Facility) error)
 	current.ProjectIDs = nil
 	current.ID = id
 	current.UpdatedAt = tx.now
+	if err := checkAttributeLimits(tx, domain.EntityFacility, id, current.FacilityExtensions); err != nil {
+		return Facility{Facility: entitymodel.Facility{}}, err
+	}
 	tx.state.facilities[id] = cloneFacility(current)
 	afterDecorated := decorateFacility(&tx.state, current)
 	tx.recordChange(Change{Entity: domain.EntityFacility, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneFacility(afterDecorated))})
@@ -1879,30 +2791,8 @@ func (tx *transaction) DeleteFacility(id string) error {
 	if count := len(facilityHousingIDs(&tx.state, id)); count > 0 {
 		return fmt.Errorf("facility %q has %d housing units; remove them before delete", id, count)
 	}
-	for _, housing := range tx.state.housing {
-		if housing.FacilityID == id {
-			return fmt.Errorf("facility %q still referenced by housing unit %q", id, housing.ID)
-		}
-	}
-	for _, sample := range tx.state.samples {
-		if sample.FacilityID == id {
-			return fmt.Errorf("facility %q still referenced by sample %q", id, sample.ID)
-		}
-	}
-	for _, project := range tx.state.projects {
-		if containsString(project.FacilityIDs, id) {
-			return fmt.Errorf("facility %q still referenced by project %q", id, project.ID)
-		}
-	}
-	for _, permit := range tx.state.permits {
-		if containsString(permit.FacilityIDs, id) {
-			return fmt.Errorf("facility %q still referenced by permit %q", id, permit.ID)
-		}
-	}
-	for _, item := range tx.state.supplies {
-		if containsString(item.FacilityIDs, id) {
-			return fmt.Errorf("facility %q still referenced by supply item %q", id, item.ID)
-		}
+	if err := tx.requireUnreferenced(domain.EntityFacility, id, domain.EntityHousingUnit, domain.EntitySample, domain.EntityProject, domain.EntityPermit, domain.EntitySupplyItem); err != nil {
+		return err
 	}
 	delete(tx.state.facilities, id)
 	tx.recordChange(Change{Entity: domain.EntityFacility, Action: domain.ActionDelete, Before: changePayloadFromValue(tx, cloneFacility(decoratedCurrent))})
@@ -1912,11 +2802,21 @@ func (tx *transaction) DeleteFacility(id string) error {
 // CreateBreedingUnit stores a new breeding unit definition.
 func (tx *transaction) CreateBreedingUnit(b BreedingUnit) (BreedingUnit, error) {
 	if b.ID == "" {
-		b.ID = tx.store.newID()
+		b.ID = tx.store.newEntityID(domain.EntityBreeding)
+	} else if err := tx.store.checkIDPrefix(domain.EntityBreeding, b.ID); err != nil {
+		return BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{}}, err
 	}
 	if _, exists := tx.state.breeding[b.ID]; exists {
 		return BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{}}, fmt.Errorf("breeding unit %q already exists", b.ID)
 	}
+	if err := normalizeBreedingUnit(&b); err != nil {
+		return BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{}}, err
+	}
+	if b.HousingID != nil {
+		if _, ok := tx.state.housing[*b.HousingID]; !ok {
+			return BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityBreeding, Field: "housing_id", ReferencedEntity: domain.EntityHousingUnit, ReferencedID: *b.HousingID}
+		}
+	}
 	b.CreatedAt = tx.now
 	b.UpdatedAt = tx.now
 	if attrs := b.PairingAttributes(); attrs == nil {
@@ -1924,6 +2824,9 @@ func (tx *transaction) CreateBreedingUnit(b BreedingUnit) (BreedingUnit, error)
 	} else {
 		mustApply("apply breeding attributes", b.ApplyPairingAttributes(attrs))
 	}
+	if err := checkAttributeLimits(tx, domain.EntityBreeding, b.ID, b.BreedingUnitExtensions); err != nil {
+		return BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{}}, err
+	}
 	tx.state.breeding[b.ID] = cloneBreeding(b)
 	tx.recordChange(Change{Entity: domain.EntityBreeding, Action: domain.ActionCreate, After: changePayloadFromValue(tx, cloneBreeding(b))})
 	return cloneBreeding(b), nil
@@ -1939,6 +2842,14 @@ func (tx *transaction) UpdateBreedingUnit(id string, mutator func(*BreedingUnit)
 	if err := mutator(&current); err != nil {
 		return BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{}}, err
 	}
+	if err := normalizeBreedingUnit(&current); err != nil {
+		return BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{}}, err
+	}
+	if current.HousingID != nil && (before.HousingID == nil || *before.HousingID != *current.HousingID) {
+		if _, ok := tx.state.housing[*current.HousingID]; !ok {
+			return BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityBreeding, Field: "housing_id", ReferencedEntity: domain.EntityHousingUnit, ReferencedID: *current.HousingID}
+		}
+	}
 	if attrs := current.PairingAttributes(); attrs == nil {
 		mustApply("apply breeding attributes", current.ApplyPairingAttributes(map[string]any{}))
 	} else {
@@ -1946,6 +2857,9 @@ func (tx *transaction) UpdateBreedingUnit(id string, mutator func(*BreedingUnit)
 	}
 	current.ID = id
 	current.UpdatedAt = tx.now
+	if err := checkAttributeLimits(tx, domain.EntityBreeding, id, current.BreedingUnitExtensions); err != nil {
+		return BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{}}, err
+	}
 	tx.state.breeding[id] = cloneBreeding(current)
 	tx.recordChange(Change{Entity: domain.EntityBreeding, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneBreeding(current))})
 	return cloneBreeding(current), nil
@@ -1957,6 +2871,9 @@ func (tx *transaction) DeleteBreedingUnit(id string) error {
 	if !ok {
 		return fmt.Errorf("breeding unit %q not found", id)
 	}
+	if err := tx.requireUnreferenced(domain.EntityBreeding, id, domain.EntityCohort); err != nil {
+		return err
+	}
 	delete(tx.state.breeding, id)
 	tx.recordChange(Change{Entity: domain.EntityBreeding, Action: domain.ActionDelete, Before: changePayloadFromValue(tx, cloneBreeding(current))})
 	return nil
@@ -1965,7 +2882,9 @@ func (tx *transaction) DeleteBreedingUnit(id string) error {
 // CreateLine stores a new line record.
 func (tx *transaction) CreateLine(l Line) (Line, error) {
 	if l.ID == "" {
-		l.ID = tx.store.newID()
+		l.ID = tx.store.newEntityID(domain.EntityLine)
+	} else if err := tx.store.checkIDPrefix(domain.EntityLine, l.ID); err != nil {
+		return Line{Line: entitymodel.Line{}}, err
 	}
 	if _, exists := tx.state.lines[l.ID]; exists {
 		return Line{Line: entitymodel.Line{}}, fmt.Errorf("line %q already exists", l.ID)
@@ -1976,6 +2895,9 @@ func (tx *transaction) CreateLine(l Line) (Line, error) {
 	if err := requireNonEmpty("line.genotype_marker_ids", l.GenotypeMarkerIDs); err != nil {
 		return Line{Line: entitymodel.Line{}}, err
 	}
+	if err := validateLineTags(l.Tags); err != nil {
+		return Line{Line: entitymodel.Line{}}, err
+	}
 	if attrs := l.DefaultAttributes(); attrs == nil {
 		mustApply("apply line default attributes", l.ApplyDefaultAttributes(map[string]any{}))
 	} else {
@@ -1988,6 +2910,9 @@ func (tx *transaction) CreateLine(l Line) (Line, error) {
 	}
 	l.CreatedAt = tx.now
 	l.UpdatedAt = tx.now
+	if err := checkAttributeLimits(tx, domain.EntityLine, l.ID, l.LineExtensions); err != nil {
+		return Line{Line: entitymodel.Line{}}, err
+	}
 	tx.state.lines[l.ID] = cloneLine(l)
 	tx.recordChange(Change{Entity: domain.EntityLine, Action: domain.ActionCreate, After: changePayloadFromValue(tx, cloneLine(l))})
 	return cloneLine(l), nil
@@ -2009,6 +2934,9 @@ func (tx *transaction) UpdateLine(id string, mutator func(*Line) error) (Line, e
 	if err := requireNonEmpty("line.genotype_marker_ids", current.GenotypeMarkerIDs); err != nil {
 		return Line{Line: entitymodel.Line{}}, err
 	}
+	if err := validateLineTags(current.Tags); err != nil {
+		return Line{Line: entitymodel.Line{}}, err
+	}
 	if attrs := current.DefaultAttributes(); attrs == nil {
 		mustApply("apply line default attributes", current.ApplyDefaultAttributes(map[string]any{}))
 	} else {
@@ -2021,6 +2949,9 @@ func (tx *transaction) UpdateLine(id string, mutator func(*Line) error) (Line, e
 	}
 	current.ID = id
 	current.UpdatedAt = tx.now
+	if err := checkAttributeLimits(tx, domain.EntityLine, id, current.LineExtensions); err != nil {
+		return Line{Line: entitymodel.Line{}}, err
+	}
 	tx.state.lines[id] = cloneLine(current)
 	tx.recordChange(Change{Entity: domain.EntityLine, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneLine(current))})
 	return cloneLine(current), nil
@@ -2032,23 +2963,8 @@ func (tx *transaction) DeleteLine(id string) error {
 	if !ok {
 		return fmt.Errorf("line %q not found", id)
 	}
-	for _, strain := range tx.state.strains {
-		if strain.LineID == id {
-			return fmt.Errorf("line %q still referenced by strain %q", id, strain.ID)
-		}
-	}
-	for _, breeding := range tx.state.breeding {
-		if breeding.LineID != nil && *breeding.LineID == id {
-			return fmt.Errorf("line %q still referenced by breeding unit %q", id, breeding.ID)
-		}
-		if breeding.TargetLineID != nil && *breeding.TargetLineID == id {
-			return fmt.Errorf("line %q still referenced by breeding unit %q", id, breeding.ID)
-		}
-	}
-	for _, organism := range tx.state.organisms {
-		if organism.LineID != nil && *organism.LineID == id {
-			return fmt.Errorf("line %q still referenced by organism %q", id, organism.ID)
-		}
+	if err := tx.requireUnreferenced(domain.EntityLine, id, domain.EntityStrain, domain.EntityBreeding, domain.EntityOrganism); err != nil {
+		return err
 	}
 	delete(tx.state.lines, id)
 	tx.recordChange(Change{Entity: domain.EntityLine, Action: domain.ActionDelete, Before: changePayloadFromValue(tx, cloneLine(current))})
@@ -2058,7 +2974,9 @@ func (tx *transaction) DeleteLine(id string) error {
 // CreateStrain stores a new strain record.
 func (tx *transaction) CreateStrain(s Strain) (Strain, error) {
 	if s.ID == "" {
-		s.ID = tx.store.newID()
+		s.ID = tx.store.newEntityID(domain.EntityStrain)
+	} else if err := tx.store.checkIDPrefix(domain.EntityStrain, s.ID); err != nil {
+		return Strain{Strain: entitymodel.Strain{}}, err
 	}
 	if _, exists := tx.state.strains[s.ID]; exists {
 		return Strain{Strain: entitymodel.Strain{}}, fmt.Errorf("strain %q already exists", s.ID)
@@ -2067,7 +2985,7 @@ func (tx *transaction) CreateStrain(s Strain) (Strain, error) {
 		return Strain{Strain: entitymodel.Strain{}}, errors.New("strain requires line id")
 	}
 	if _, ok := tx.state.lines[s.LineID]; !ok {
-		return Strain{Strain: entitymodel.Strain{}}, fmt.Errorf("line %q not found for strain", s.LineID)
+		return Strain{Strain: entitymodel.Strain{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityStrain, Field: "line_id", ReferencedEntity: domain.EntityLine, ReferencedID: s.LineID}
 	}
 	if filtered, changed := filterIDs(s.GenotypeMarkerIDs, func(markerID string) bool { _, ok := tx.state.markers[markerID]; return ok }); changed {
 		s.GenotypeMarkerIDs = filtered
@@ -2079,6 +2997,9 @@ func (tx *transaction) CreateStrain(s Strain) (Strain, error) {
 	}
 	s.CreatedAt = tx.now
 	s.UpdatedAt = tx.now
+	if err := checkAttributeLimits(tx, domain.EntityStrain, s.ID, s.StrainExtensions); err != nil {
+		return Strain{Strain: entitymodel.Strain{}}, err
+	}
 	tx.state.strains[s.ID] = cloneStrain(s)
 	tx.recordChange(Change{Entity: domain.EntityStrain, Action: domain.ActionCreate, After: changePayloadFromValue(tx, cloneStrain(s))})
 	return cloneStrain(s), nil
@@ -2098,7 +3019,7 @@ func (tx *transaction) UpdateStrain(id string, mutator func(*Strain) error) (Str
 		return Strain{Strain: entitymodel.Strain{}}, errors.New("strain requires line id")
 	}
 	if _, ok := tx.state.lines[current.LineID]; !ok {
-		return Strain{Strain: entitymodel.Strain{}}, fmt.Errorf("line %q not found for strain", current.LineID)
+		return Strain{Strain: entitymodel.Strain{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityStrain, Field: "line_id", ReferencedEntity: domain.EntityLine, ReferencedID: current.LineID}
 	}
 	if filtered, changed := filterIDs(current.GenotypeMarkerIDs, func(markerID string) bool { _, ok := tx.state.markers[markerID]; return ok }); changed {
 		current.GenotypeMarkerIDs = filtered
@@ -2110,6 +3031,9 @@ func (tx *transaction) UpdateStrain(id string, mutator func(*Strain) error) (Str
 	}
 	current.ID = id
 	current.UpdatedAt = tx.now
+	if err := checkAttributeLimits(tx, domain.EntityStrain, id, current.StrainExtensions); err != nil {
+		return Strain{Strain: entitymodel.Strain{}}, err
+	}
 	tx.state.strains[id] = cloneStrain(current)
 	tx.recordChange(Change{Entity: domain.EntityStrain, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneStrain(current))})
 	return cloneStrain(current), nil
@@ -2121,18 +3045,8 @@ func (tx *transaction) DeleteStrain(id string) error {
 	if !ok {
 		return fmt.Errorf("strain %q not found", id)
 	}
-	for _, organism := range tx.state.organisms {
-		if organism.StrainID != nil && *organism.StrainID == id {
-			return fmt.Errorf("strain %q still referenced by organism %q", id, organism.ID)
-		}
-	}
-	for _, breeding := range tx.state.breeding {
-		if breeding.StrainID != nil && *breeding.StrainID == id {
-			return fmt.Errorf("strain %q still referenced by breeding unit %q", id, breeding.ID)
-		}
-		if breeding.TargetStrainID != nil && *breeding.TargetStrainID == id {
-			return fmt.Errorf("strain %q still referenced by breeding unit %q", id, breeding.ID)
-		}
+	if err := tx.requireUnreferenced(domain.EntityStrain, id, domain.EntityOrganism, domain.EntityBreeding); err != nil {
+		return err
 	}
 	delete(tx.state.strains, id)
 	tx.recordChange(Change{Entity: domain.EntityStrain, Action: domain.ActionDelete, Before: changePayloadFromValue(tx, cloneStrain(current))})
@@ -2142,7 +3056,9 @@ func (tx *transaction) DeleteStrain(id string) error {
 // CreateGenotypeMarker stores a new genotype marker record.
 func (tx *transaction) CreateGenotypeMarker(g GenotypeMarker) (GenotypeMarker, error) {
 	if g.ID == "" {
-		g.ID = tx.store.newID()
+		g.ID = tx.store.newEntityID(domain.EntityGenotypeMarker)
+	} else if err := tx.store.checkIDPrefix(domain.EntityGenotypeMarker, g.ID); err != nil {
+		return GenotypeMarker{GenotypeMarker: entitymodel.GenotypeMarker{}}, err
 	}
 	if _, exists := tx.state.markers[g.ID]; exists {
 		return GenotypeMarker{GenotypeMarker: entitymodel.GenotypeMarker{}}, fmt.Errorf("genotype marker %q already exists", g.ID)
@@ -2157,6 +3073,9 @@ func (tx *transaction) CreateGenotypeMarker(g GenotypeMarker) (GenotypeMarker, e
 	}
 	g.CreatedAt = tx.now
 	g.UpdatedAt = tx.now
+	if err := checkAttributeLimits(tx, domain.EntityGenotypeMarker, g.ID, g.GenotypeMarkerExtensions); err != nil {
+		return GenotypeMarker{GenotypeMarker: entitymodel.GenotypeMarker{}}, err
+	}
 	tx.state.markers[g.ID] = cloneGenotypeMarker(g)
 	tx.recordChange(Change{Entity: domain.EntityGenotypeMarker, Action: domain.ActionCreate, After: changePayloadFromValue(tx, cloneGenotypeMarker(g))})
 	return cloneGenotypeMarker(g), nil
@@ -2182,6 +3101,9 @@ func (tx *transaction) UpdateGenotypeMarker(id string, mutator func(*GenotypeMar
 	}
 	current.ID = id
 	current.UpdatedAt = tx.now
+	if err := checkAttributeLimits(tx, domain.EntityGenotypeMarker, id, current.GenotypeMarkerExtensions); err != nil {
+		return GenotypeMarker{GenotypeMarker: entitymodel.GenotypeMarker{}}, err
+	}
 	tx.state.markers[id] = cloneGenotypeMarker(current)
 	tx.recordChange(Change{Entity: domain.EntityGenotypeMarker, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneGenotypeMarker(current))})
 	return cloneGenotypeMarker(current), nil
@@ -2193,15 +3115,8 @@ func (tx *transaction) DeleteGenotypeMarker(id string) error {
 	if !ok {
 		return fmt.Errorf("genotype marker %q not found", id)
 	}
-	for _, line := range tx.state.lines {
-		if containsString(line.GenotypeMarkerIDs, id) {
-			return fmt.Errorf("genotype marker %q still referenced by line %q", id, line.ID)
-		}
-	}
-	for _, strain := range tx.state.strains {
-		if containsString(strain.GenotypeMarkerIDs, id) {
-			return fmt.Errorf("genotype marker %q still referenced by strain %q", id, strain.ID)
-		}
+	if err := tx.requireUnreferenced(domain.EntityGenotypeMarker, id, domain.EntityLine, domain.EntityStrain); err != nil {
+		return err
 	}
 	delete(tx.state.markers, id)
 	tx.recordChange(Change{Entity: domain.EntityGenotypeMarker, Action: domain.ActionDelete, Before: changePayloadFromValue(tx, cloneGenotypeMarker(current))})
@@ -2211,7 +3126,9 @@ func (tx *transaction) DeleteGenotypeMarker(id string) error {
 // CreateProcedure stores a procedure record.
 func (tx *transaction) CreateProcedure(p Procedure) (Procedure, error) {
 	if p.ID == "" {
-		p.ID = tx.store.newID()
+		p.ID = tx.store.newEntityID(domain.EntityProcedure)
+	} else if err := tx.store.checkIDPrefix(domain.EntityProcedure, p.ID); err != nil {
+		return Procedure{Procedure: entitymodel.Procedure{}}, err
 	}
 	if _, exists := tx.state.procedures[p.ID]; exists {
 		return Procedure{Procedure: entitymodel.Procedure{}}, fmt.Errorf("procedure %q already exists", p.ID)
@@ -2230,6 +3147,9 @@ func (tx *transaction) CreateProcedure(p Procedure) (Procedure, error) {
 }
 
 // UpdateProcedure mutates a procedure.
+// The mutator sees the derived ID lists populated; they are recomputed on
+// exit. A status change must be allowed by domain.CanTransitionProcedure, as
+// in TransitionProcedures.
 func (tx *transaction) UpdateProcedure(id string, mutator func(*Procedure) error) (Procedure, error) {
 	current, ok := tx.state.procedures[id]
 	if !ok {
@@ -2237,12 +3157,16 @@ func (tx *transaction) UpdateProcedure(id string, mutator func(*Procedure) error
 	}
 	beforeDecorated := decorateProcedure(&tx.state, current)
 	before := cloneProcedure(beforeDecorated)
+	current = cloneProcedure(beforeDecorated)
 	if err := mutator(&current); err != nil {
 		return Procedure{Procedure: entitymodel.Procedure{}}, err
 	}
 	if err := normalizeProcedure(&current); err != nil {
 		return Procedure{Procedure: entitymodel.Procedure{}}, err
 	}
+	if current.Status != before.Status && !domain.CanTransitionProcedure(before.Status, current.Status) {
+		return Procedure{Procedure: entitymodel.Procedure{}}, fmt.Errorf("procedure %q cannot transition from %s to %s", id, before.Status, current.Status)
+	}
 	current.TreatmentIDs = nil
 	current.ObservationIDs = nil
 	current.ID = id
@@ -2260,15 +3184,8 @@ func (tx *transaction) DeleteProcedure(id string) error {
 		return fmt.Errorf("procedure %q not found", id)
 	}
 	decoratedCurrent := decorateProcedure(&tx.state, current)
-	for _, treatment := range tx.state.treatments {
-		if treatment.ProcedureID == id {
-			return fmt.Errorf("procedure %q still referenced by treatment %q", id, treatment.ID)
-		}
-	}
-	for _, observation := range tx.state.observations {
-		if observation.ProcedureID != nil && *observation.ProcedureID == id {
-			return fmt.Errorf("procedure %q still referenced by observation %q", id, observation.ID)
-		}
+	if err := tx.requireUnreferenced(domain.EntityProcedure, id, domain.EntityTreatment, domain.EntityObservation); err != nil {
+		return err
 	}
 	delete(tx.state.procedures, id)
 	tx.recordChange(Change{Entity: domain.EntityProcedure, Action: domain.ActionDelete, Before: changePayloadFromValue(tx, cloneProcedure(decoratedCurrent))})
@@ -2278,7 +3195,9 @@ func (tx *transaction) DeleteProcedure(id string) error {
 // CreateTreatment stores a treatment record.
 func (tx *transaction) CreateTreatment(t Treatment) (Treatment, error) {
 	if t.ID == "" {
-		t.ID = tx.store.newID()
+		t.ID = tx.store.newEntityID(domain.EntityTreatment)
+	} else if err := tx.store.checkIDPrefix(domain.EntityTreatment, t.ID); err != nil {
+		return Treatment{Treatment: entitymodel.Treatment{}}, err
 	}
 	if _, exists := tx.state.treatments[t.ID]; exists {
 		return Treatment{Treatment: entitymodel.Treatment{}}, fmt.Errorf("treatment %q already exists", t.ID)
@@ -2287,21 +3206,27 @@ func (tx *transaction) CreateTreatment(t Treatment) (Treatment, error) {
 		return Treatment{Treatment: entitymodel.Treatment{}}, errors.New("treatment requires procedure id")
 	}
 	if _, ok := tx.state.procedures[t.ProcedureID]; !ok {
-		return Treatment{Treatment: entitymodel.Treatment{}}, fmt.Errorf("procedure %q not found", t.ProcedureID)
+		return Treatment{Treatment: entitymodel.Treatment{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityTreatment, Field: "procedure_id", ReferencedEntity: domain.EntityProcedure, ReferencedID: t.ProcedureID}
 	}
 	if err := normalizeTreatment(&t); err != nil {
 		return Treatment{Treatment: entitymodel.Treatment{}}, err
 	}
+	if t.DosagePlan.LegacyText != nil {
+		return Treatment{Treatment: entitymodel.Treatment{}}, errors.New("treatment.dosage_plan.legacy_text is only set on migrated plans")
+	}
+	if err := validateDosagePlan(t.DosagePlan); err != nil {
+		return Treatment{Treatment: entitymodel.Treatment{}}, err
+	}
 	t.OrganismIDs = dedupeStrings(t.OrganismIDs)
 	for _, organismID := range t.OrganismIDs {
 		if _, ok := tx.state.organisms[organismID]; !ok {
-			return Treatment{Treatment: entitymodel.Treatment{}}, fmt.Errorf("organism %q not found for treatment", organismID)
+			return Treatment{Treatment: entitymodel.Treatment{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityTreatment, Field: "organism_ids", ReferencedEntity: domain.EntityOrganism, ReferencedID: organismID}
 		}
 	}
 	t.CohortIDs = dedupeStrings(t.CohortIDs)
 	for _, cohortID := range t.CohortIDs {
 		if _, ok := tx.state.cohorts[cohortID]; !ok {
-			return Treatment{Treatment: entitymodel.Treatment{}}, fmt.Errorf("cohort %q not found for treatment", cohortID)
+			return Treatment{Treatment: entitymodel.Treatment{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityTreatment, Field: "cohort_ids", ReferencedEntity: domain.EntityCohort, ReferencedID: cohortID}
 		}
 	}
 	t.CreatedAt = tx.now
@@ -2325,23 +3250,26 @@ func (tx *transaction) UpdateTreatment(id string, mutator func(*Treatment) error
 		return Treatment{Treatment: entitymodel.Treatment{}}, errors.New("treatment requires procedure id")
 	}
 	if _, ok := tx.state.procedures[current.ProcedureID]; !ok {
-		return Treatment{Treatment: entitymodel.Treatment{}}, fmt.Errorf("procedure %q not found", current.ProcedureID)
+		return Treatment{Treatment: entitymodel.Treatment{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityTreatment, Field: "procedure_id", ReferencedEntity: domain.EntityProcedure, ReferencedID: current.ProcedureID}
 	}
 	current.OrganismIDs = dedupeStrings(current.OrganismIDs)
 	for _, organismID := range current.OrganismIDs {
 		if _, ok := tx.state.organisms[organismID]; !ok {
-			return Treatment{Treatment: entitymodel.Treatment{}}, fmt.Errorf("organism %q not found for treatment", organismID)
+			return Treatment{Treatment: entitymodel.Treatment{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityTreatment, Field: "organism_ids", ReferencedEntity: domain.EntityOrganism, ReferencedID: organismID}
 		}
 	}
 	current.CohortIDs = dedupeStrings(current.CohortIDs)
 	for _, cohortID := range current.CohortIDs {
 		if _, ok := tx.state.cohorts[cohortID]; !ok {
-			return Treatment{Treatment: entitymodel.Treatment{}}, fmt.Errorf("cohort %q not found for treatment", cohortID)
+			return Treatment{Treatment: entitymodel.Treatment{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityTreatment, Field: "cohort_ids", ReferencedEntity: domain.EntityCohort, ReferencedID: cohortID}
 		}
 	}
 	if err := normalizeTreatment(&current); err != nil {
 		return Treatment{Treatment: entitymodel.Treatment{}}, err
 	}
+	if err := validateDosagePlan(current.DosagePlan); err != nil {
+		return Treatment{Treatment: entitymodel.Treatment{}}, err
+	}
 	current.ID = id
 	current.UpdatedAt = tx.now
 	tx.state.treatments[id] = cloneTreatment(current)
@@ -2349,6 +3277,29 @@ func (tx *transaction) UpdateTreatment(id string, mutator func(*Treatment) error
 	return cloneTreatment(current), nil
 }
 
+// AppendAdverseEvent records an adverse event on a treatment. A zero
+// ObservedAt defaults to the transaction time. Severe events are subject to
+// the severe_adverse_event rule when the transaction commits.
+func (tx *transaction) AppendAdverseEvent(treatmentID string, event domain.AdverseEvent) (Treatment, error) {
+	current, ok := tx.state.treatments[treatmentID]
+	if !ok {
+		return Treatment{Treatment: entitymodel.Treatment{}}, fmt.Errorf("treatment %q not found", treatmentID)
+	}
+	event.Description = strings.TrimSpace(event.Description)
+	if err := domain.ValidateAdverseEvent(event); err != nil {
+		return Treatment{Treatment: entitymodel.Treatment{}}, fmt.Errorf("treatment %q: %w", treatmentID, err)
+	}
+	if event.ObservedAt.IsZero() {
+		event.ObservedAt = tx.now
+	}
+	before := cloneTreatment(current)
+	current.AdverseEvents = append(append([]domain.AdverseEvent(nil), current.AdverseEvents...), event)
+	current.UpdatedAt = tx.now
+	tx.state.treatments[treatmentID] = cloneTreatment(current)
+	tx.recordChange(Change{Entity: domain.EntityTreatment, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneTreatment(current))})
+	return cloneTreatment(current), nil
+}
+
 // DeleteTreatment removes a treatment from state.
 func (tx *transaction) DeleteTreatment(id string) error {
 	current, ok := tx.state.treatments[id]
@@ -2363,7 +3314,9 @@ func (tx *transaction) DeleteTreatment(id string) error {
 // CreateObservation stores an observation record.
 func (tx *transaction) CreateObservation(o Observation) (Observation, error) {
 	if o.ID == "" {
-		o.ID = tx.store.newID()
+		o.ID = tx.store.newEntityID(domain.EntityObservation)
+	} else if err := tx.store.checkIDPrefix(domain.EntityObservation, o.ID); err != nil {
+		return Observation{Observation: entitymodel.Observation{}}, err
 	}
 	if _, exists := tx.state.observations[o.ID]; exists {
 		return Observation{Observation: entitymodel.Observation{}}, fmt.Errorf("observation %q already exists", o.ID)
@@ -2373,19 +3326,22 @@ func (tx *transaction) CreateObservation(o Observation) (Observation, error) {
 	}
 	if o.ProcedureID != nil {
 		if _, ok := tx.state.procedures[*o.ProcedureID]; !ok {
-			return Observation{Observation: entitymodel.Observation{}}, fmt.Errorf("procedure %q not found for observation", *o.ProcedureID)
+			return Observation{Observation: entitymodel.Observation{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityObservation, Field: "procedure_id", ReferencedEntity: domain.EntityProcedure, ReferencedID: *o.ProcedureID}
 		}
 	}
 	if o.OrganismID != nil {
 		if _, ok := tx.state.organisms[*o.OrganismID]; !ok {
-			return Observation{Observation: entitymodel.Observation{}}, fmt.Errorf("organism %q not found for observation", *o.OrganismID)
+			return Observation{Observation: entitymodel.Observation{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityObservation, Field: "organism_id", ReferencedEntity: domain.EntityOrganism, ReferencedID: *o.OrganismID}
 		}
 	}
 	if o.CohortID != nil {
 		if _, ok := tx.state.cohorts[*o.CohortID]; !ok {
-			return Observation{Observation: entitymodel.Observation{}}, fmt.Errorf("cohort %q not found for observation", *o.CohortID)
+			return Observation{Observation: entitymodel.Observation{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityObservation, Field: "cohort_id", ReferencedEntity: domain.EntityCohort, ReferencedID: *o.CohortID}
 		}
 	}
+	if err := reconcileObservationRecorder(Observation{Observation: entitymodel.Observation{}}, &o); err != nil {
+		return Observation{Observation: entitymodel.Observation{}}, err
+	}
 	o.CreatedAt = tx.now
 	o.UpdatedAt = tx.now
 	if data := o.ObservationData(); data == nil {
@@ -2393,6 +3349,12 @@ func (tx *transaction) CreateObservation(o Observation) (Observation, error) {
 	} else {
 		mustApply("apply observation data", o.ApplyObservationData(data))
 	}
+	if err := o.ApplyMeasurementData(); err != nil {
+		return Observation{Observation: entitymodel.Observation{}}, err
+	}
+	if err := checkAttributeLimits(tx, domain.EntityObservation, o.ID, o.ObservationExtensions); err != nil {
+		return Observation{Observation: entitymodel.Observation{}}, err
+	}
 	tx.state.observations[o.ID] = cloneObservation(o)
 	tx.recordChange(Change{Entity: domain.EntityObservation, Action: domain.ActionCreate, After: changePayloadFromValue(tx, cloneObservation(o))})
 	return cloneObservation(o), nil
@@ -2413,26 +3375,65 @@ func (tx *transaction) UpdateObservation(id string, mutator func(*Observation) e
 	}
 	if current.ProcedureID != nil {
 		if _, ok := tx.state.procedures[*current.ProcedureID]; !ok {
-			return Observation{Observation: entitymodel.Observation{}}, fmt.Errorf("procedure %q not found for observation", *current.ProcedureID)
+			return Observation{Observation: entitymodel.Observation{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityObservation, Field: "procedure_id", ReferencedEntity: domain.EntityProcedure, ReferencedID: *current.ProcedureID}
 		}
 	}
 	if current.OrganismID != nil {
 		if _, ok := tx.state.organisms[*current.OrganismID]; !ok {
-			return Observation{Observation: entitymodel.Observation{}}, fmt.Errorf("organism %q not found for observation", *current.OrganismID)
+			return Observation{Observation: entitymodel.Observation{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityObservation, Field: "organism_id", ReferencedEntity: domain.EntityOrganism, ReferencedID: *current.OrganismID}
 		}
 	}
 	if current.CohortID != nil {
 		if _, ok := tx.state.cohorts[*current.CohortID]; !ok {
-			return Observation{Observation: entitymodel.Observation{}}, fmt.Errorf("cohort %q not found for observation", *current.CohortID)
+			return Observation{Observation: entitymodel.Observation{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityObservation, Field: "cohort_id", ReferencedEntity: domain.EntityCohort, ReferencedID: *current.CohortID}
 		}
 	}
+	if err := reconcileObservationRecorder(before, &current); err != nil {
+		return Observation{Observation: entitymodel.Observation{}}, err
+	}
 	if data := current.ObservationData(); data == nil {
 		mustApply("apply observation data", current.ApplyObservationData(map[string]any{}))
 	} else {
 		mustApply("apply observation data", current.ApplyObservationData(data))
 	}
+	if err := current.ApplyMeasurementData(); err != nil {
+		return Observation{Observation: entitymodel.Observation{}}, err
+	}
 	current.ID = id
 	current.UpdatedAt = tx.now
+	if err := checkAttributeLimits(tx, domain.EntityObservation, id, current.ObservationExtensions); err != nil {
+		return Observation{Observation: entitymodel.Observation{}}, err
+	}
+	tx.state.observations[id] = cloneObservation(current)
+	tx.recordChange(Change{Entity: domain.EntityObservation, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneObservation(current))})
+	return cloneObservation(current), nil
+}
+
+// ReviewObservation records a reviewer sign-off on an observation. Repeating
+// an identical review is a no-op; any other re-review is rejected.
+func (tx *transaction) ReviewObservation(id, reviewer string, at time.Time) (Observation, error) {
+	reviewer = strings.TrimSpace(reviewer)
+	if reviewer == "" {
+		return Observation{Observation: entitymodel.Observation{}}, errors.New("observation review requires reviewer")
+	}
+	if at.IsZero() {
+		return Observation{Observation: entitymodel.Observation{}}, errors.New("observation review requires reviewed_at")
+	}
+	current, ok := tx.state.observations[id]
+	if !ok {
+		return Observation{Observation: entitymodel.Observation{}}, fmt.Errorf("observation %q not found", id)
+	}
+	if current.ReviewedBy != nil {
+		if *current.ReviewedBy == reviewer && current.ReviewedAt != nil && current.ReviewedAt.Equal(at) {
+			return cloneObservation(current), nil
+		}
+		return Observation{Observation: entitymodel.Observation{}}, fmt.Errorf("observation %q already reviewed by %q", id, *current.ReviewedBy)
+	}
+	before := cloneObservation(current)
+	reviewedAt := at.UTC()
+	current.ReviewedBy = &reviewer
+	current.ReviewedAt = &reviewedAt
+	current.UpdatedAt = tx.now
 	tx.state.observations[id] = cloneObservation(current)
 	tx.recordChange(Change{Entity: domain.EntityObservation, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneObservation(current))})
 	return cloneObservation(current), nil
@@ -2452,7 +3453,9 @@ func (tx *transaction) DeleteObservation(id string) error {
 // CreateSample stores a sample record.
 func (tx *transaction) CreateSample(s Sample) (Sample, error) {
 	if s.ID == "" {
-		s.ID = tx.store.newID()
+		s.ID = tx.store.newEntityID(domain.EntitySample)
+	} else if err := tx.store.checkIDPrefix(domain.EntitySample, s.ID); err != nil {
+		return Sample{Sample: entitymodel.Sample{}}, err
 	}
 	if _, exists := tx.state.samples[s.ID]; exists {
 		return Sample{Sample: entitymodel.Sample{}}, fmt.Errorf("sample %q already exists", s.ID)
@@ -2461,27 +3464,30 @@ func (tx *transaction) CreateSample(s Sample) (Sample, error) {
 		return Sample{Sample: entitymodel.Sample{}}, errors.New("sample requires facility id")
 	}
 	if _, ok := tx.state.facilities[s.FacilityID]; !ok {
-		return Sample{Sample: entitymodel.Sample{}}, fmt.Errorf("facility %q not found for sample", s.FacilityID)
+		return Sample{Sample: entitymodel.Sample{}}, domain.ErrReferentialIntegrity{Entity: domain.EntitySample, Field: "facility_id", ReferencedEntity: domain.EntityFacility, ReferencedID: s.FacilityID}
 	}
 	if s.OrganismID == nil && s.CohortID == nil {
 		return Sample{Sample: entitymodel.Sample{}}, errors.New("sample requires organism or cohort reference")
 	}
 	if s.OrganismID != nil {
 		if _, ok := tx.state.organisms[*s.OrganismID]; !ok {
-			return Sample{Sample: entitymodel.Sample{}}, fmt.Errorf("organism %q not found for sample", *s.OrganismID)
+			return Sample{Sample: entitymodel.Sample{}}, domain.ErrReferentialIntegrity{Entity: domain.EntitySample, Field: "organism_id", ReferencedEntity: domain.EntityOrganism, ReferencedID: *s.OrganismID}
 		}
 	}
 	if s.CohortID != nil {
 		if _, ok := tx.state.cohorts[*s.CohortID]; !ok {
-			return Sample{Sample: entitymodel.Sample{}}, fmt.Errorf("cohort %q not found for sample", *s.CohortID)
+			return Sample{Sample: entitymodel.Sample{}}, domain.ErrReferentialIntegrity{Entity: domain.EntitySample, Field: "cohort_id", ReferencedEntity: domain.EntityCohort, ReferencedID: *s.CohortID}
 		}
 	}
-	if len(s.ChainOfCustody) == 0 {
-		return Sample{Sample: entitymodel.Sample{}}, errors.New("sample requires chain of custody")
+	if err := validateSampleCustody(s); err != nil {
+		return Sample{Sample: entitymodel.Sample{}}, err
 	}
 	if err := normalizeSample(&s); err != nil {
 		return Sample{Sample: entitymodel.Sample{}}, err
 	}
+	if err := checkScopedKey(tx.store, domain.EntitySample, "identifier", domain.ScopeFacility, tx.state.samples, s.ID, s, sampleIdentifier); err != nil {
+		return Sample{Sample: entitymodel.Sample{}}, err
+	}
 	s.CreatedAt = tx.now
 	s.UpdatedAt = tx.now
 	if attrs := s.SampleAttributes(); attrs == nil {
@@ -2489,6 +3495,9 @@ func (tx *transaction) CreateSample(s Sample) (Sample, error) {
 	} else {
 		mustApply("apply sample attributes", s.ApplySampleAttributes(attrs))
 	}
+	if err := checkAttributeLimits(tx, domain.EntitySample, s.ID, s.SampleExtensions); err != nil {
+		return Sample{Sample: entitymodel.Sample{}}, err
+	}
 	tx.state.samples[s.ID] = cloneSample(s)
 	tx.recordChange(Change{Entity: domain.EntitySample, Action: domain.ActionCreate, After: changePayloadFromValue(tx, cloneSample(s))})
 	return cloneSample(s), nil
@@ -2508,27 +3517,30 @@ func (tx *transaction) UpdateSample(id string, mutator func(*Sample) error) (Sam
 		return Sample{Sample: entitymodel.Sample{}}, errors.New("sample requires facility id")
 	}
 	if _, ok := tx.state.facilities[current.FacilityID]; !ok {
-		return Sample{Sample: entitymodel.Sample{}}, fmt.Errorf("facility %q not found for sample", current.FacilityID)
+		return Sample{Sample: entitymodel.Sample{}}, domain.ErrReferentialIntegrity{Entity: domain.EntitySample, Field: "facility_id", ReferencedEntity: domain.EntityFacility, ReferencedID: current.FacilityID}
 	}
 	if current.OrganismID == nil && current.CohortID == nil {
 		return Sample{Sample: entitymodel.Sample{}}, errors.New("sample requires organism or cohort reference")
 	}
 	if current.OrganismID != nil {
 		if _, ok := tx.state.organisms[*current.OrganismID]; !ok {
-			return Sample{Sample: entitymodel.Sample{}}, fmt.Errorf("organism %q not found for sample", *current.OrganismID)
+			return Sample{Sample: entitymodel.Sample{}}, domain.ErrReferentialIntegrity{Entity: domain.EntitySample, Field: "organism_id", ReferencedEntity: domain.EntityOrganism, ReferencedID: *current.OrganismID}
 		}
 	}
 	if current.CohortID != nil {
 		if _, ok := tx.state.cohorts[*current.CohortID]; !ok {
-			return Sample{Sample: entitymodel.Sample{}}, fmt.Errorf("cohort %q not found for sample", *current.CohortID)
+			return Sample{Sample: entitymodel.Sample{}}, domain.ErrReferentialIntegrity{Entity: domain.EntitySample, Field: "cohort_id", ReferencedEntity: domain.EntityCohort, ReferencedID: *current.CohortID}
 		}
 	}
-	if len(current.ChainOfCustody) == 0 {
-		return Sample{Sample: entitymodel.Sample{}}, errors.New("sample requires chain of custody")
+	if err := validateSampleCustodyUpdate(before, current); err != nil {
+		return Sample{Sample: entitymodel.Sample{}}, err
 	}
 	if err := normalizeSample(&current); err != nil {
 		return Sample{Sample: entitymodel.Sample{}}, err
 	}
+	if err := checkScopedKey(tx.store, domain.EntitySample, "identifier", domain.ScopeFacility, tx.state.samples, id, current, sampleIdentifier); err != nil {
+		return Sample{Sample: entitymodel.Sample{}}, err
+	}
 	if attrs := current.SampleAttributes(); attrs == nil {
 		mustApply("apply sample attributes", current.ApplySampleAttributes(map[string]any{}))
 	} else {
@@ -2536,6 +3548,9 @@ func (tx *transaction) UpdateSample(id string, mutator func(*Sample) error) (Sam
 	}
 	current.ID = id
 	current.UpdatedAt = tx.now
+	if err := checkAttributeLimits(tx, domain.EntitySample, id, current.SampleExtensions); err != nil {
+		return Sample{Sample: entitymodel.Sample{}}, err
+	}
 	tx.state.samples[id] = cloneSample(current)
 	tx.recordChange(Change{Entity: domain.EntitySample, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneSample(current))})
 	return cloneSample(current), nil
@@ -2555,7 +3570,9 @@ func (tx *transaction) DeleteSample(id string) error {
 // CreateProtocol stores a new protocol record.
 func (tx *transaction) CreateProtocol(p Protocol) (Protocol, error) {
 	if p.ID == "" {
-		p.ID = tx.store.newID()
+		p.ID = tx.store.newEntityID(domain.EntityProtocol)
+	} else if err := tx.store.checkIDPrefix(domain.EntityProtocol, p.ID); err != nil {
+		return Protocol{Protocol: entitymodel.Protocol{}}, err
 	}
 	if _, exists := tx.state.protocols[p.ID]; exists {
 		return Protocol{Protocol: entitymodel.Protocol{}}, fmt.Errorf("protocol %q already exists", p.ID)
@@ -2565,6 +3582,7 @@ func (tx *transaction) CreateProtocol(p Protocol) (Protocol, error) {
 	}
 	p.CreatedAt = tx.now
 	p.UpdatedAt = tx.now
+	p.Version = 1
 	tx.state.protocols[p.ID] = cloneProtocol(p)
 	tx.recordChange(Change{Entity: domain.EntityProtocol, Action: domain.ActionCreate, After: changePayloadFromValue(tx, cloneProtocol(p))})
 	return cloneProtocol(p), nil
@@ -2585,21 +3603,43 @@ func (tx *transaction) UpdateProtocol(id string, mutator func(*Protocol) error)
 	}
 	current.ID = id
 	current.UpdatedAt = tx.now
+	current.Version = before.Version + 1
 	tx.state.protocols[id] = cloneProtocol(current)
 	tx.recordChange(Change{Entity: domain.EntityProtocol, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneProtocol(current))})
 	return cloneProtocol(current), nil
 }
 
+// AddProtocolReviewer records reviewerID on the protocol's reviewer list.
+// Adding a reviewer that is already listed leaves the protocol unchanged.
+func (tx *transaction) AddProtocolReviewer(protocolID, reviewerID string) (Protocol, error) {
+	current, ok := tx.state.protocols[protocolID]
+	if !ok {
+		return Protocol{Protocol: entitymodel.Protocol{}}, fmt.Errorf("protocol %q not found", protocolID)
+	}
+	reviewerID = strings.TrimSpace(reviewerID)
+	if reviewerID == "" {
+		return Protocol{Protocol: entitymodel.Protocol{}}, fmt.Errorf("protocol %q reviewer id is required", protocolID)
+	}
+	if containsString(current.ReviewerIDs, reviewerID) {
+		return cloneProtocol(current), nil
+	}
+	before := cloneProtocol(current)
+	current.ReviewerIDs = append(append([]string(nil), current.ReviewerIDs...), reviewerID)
+	current.UpdatedAt = tx.now
+	current.Version = before.Version + 1
+	tx.state.protocols[protocolID] = cloneProtocol(current)
+	tx.recordChange(Change{Entity: domain.EntityProtocol, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneProtocol(current))})
+	return cloneProtocol(current), nil
+}
+
 // DeleteProtocol removes a protocol from state.
 func (tx *transaction) DeleteProtocol(id string) error {
 	current, ok := tx.state.protocols[id]
 	if !ok {
 		return fmt.Errorf("protocol %q not found", id)
 	}
-	for _, permit := range tx.state.permits {
-		if containsString(permit.ProtocolIDs, id) {
-			return fmt.Errorf("protocol %q still referenced by permit %q", id, permit.ID)
-		}
+	if err := tx.requireUnreferenced(domain.EntityProtocol, id, domain.EntityPermit, domain.EntityProject); err != nil {
+		return err
 	}
 	delete(tx.state.protocols, id)
 	tx.recordChange(Change{Entity: domain.EntityProtocol, Action: domain.ActionDelete, Before: changePayloadFromValue(tx, cloneProtocol(current))})
@@ -2609,7 +3649,9 @@ func (tx *transaction) DeleteProtocol(id string) error {
 // CreatePermit stores a permit record.
 func (tx *transaction) CreatePermit(p Permit) (Permit, error) {
 	if p.ID == "" {
-		p.ID = tx.store.newID()
+		p.ID = tx.store.newEntityID(domain.EntityPermit)
+	} else if err := tx.store.checkIDPrefix(domain.EntityPermit, p.ID); err != nil {
+		return Permit{Permit: entitymodel.Permit{}}, err
 	}
 	if _, exists := tx.state.permits[p.ID]; exists {
 		return Permit{Permit: entitymodel.Permit{}}, fmt.Errorf("permit %q already exists", p.ID)
@@ -2623,7 +3665,7 @@ func (tx *transaction) CreatePermit(p Permit) (Permit, error) {
 	}
 	for _, facilityID := range p.FacilityIDs {
 		if _, ok := tx.state.facilities[facilityID]; !ok {
-			return Permit{Permit: entitymodel.Permit{}}, fmt.Errorf("facility %q not found for permit", facilityID)
+			return Permit{Permit: entitymodel.Permit{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityPermit, Field: "facility_ids", ReferencedEntity: domain.EntityFacility, ReferencedID: facilityID}
 		}
 	}
 	p.ProtocolIDs = dedupeStrings(p.ProtocolIDs)
@@ -2632,12 +3674,15 @@ func (tx *transaction) CreatePermit(p Permit) (Permit, error) {
 	}
 	for _, protocolID := range p.ProtocolIDs {
 		if _, ok := tx.state.protocols[protocolID]; !ok {
-			return Permit{Permit: entitymodel.Permit{}}, fmt.Errorf("protocol %q not found for permit", protocolID)
+			return Permit{Permit: entitymodel.Permit{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityPermit, Field: "protocol_ids", ReferencedEntity: domain.EntityProtocol, ReferencedID: protocolID}
 		}
 	}
 	if err := normalizePermit(&p); err != nil {
 		return Permit{Permit: entitymodel.Permit{}}, err
 	}
+	if err := validatePermitIssueDate(p); err != nil {
+		return Permit{Permit: entitymodel.Permit{}}, err
+	}
 	p.CreatedAt = tx.now
 	p.UpdatedAt = tx.now
 	tx.state.permits[p.ID] = clonePermit(p)
@@ -2664,7 +3709,7 @@ func (tx *transaction) UpdatePermit(id string, mutator func(*Permit) error) (Per
 	}
 	for _, facilityID := range current.FacilityIDs {
 		if _, ok := tx.state.facilities[facilityID]; !ok {
-			return Permit{Permit: entitymodel.Permit{}}, fmt.Errorf("facility %q not found for permit", facilityID)
+			return Permit{Permit: entitymodel.Permit{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityPermit, Field: "facility_ids", ReferencedEntity: domain.EntityFacility, ReferencedID: facilityID}
 		}
 	}
 	current.ProtocolIDs = dedupeStrings(current.ProtocolIDs)
@@ -2673,12 +3718,15 @@ func (tx *transaction) UpdatePermit(id string, mutator func(*Permit) error) (Per
 	}
 	for _, protocolID := range current.ProtocolIDs {
 		if _, ok := tx.state.protocols[protocolID]; !ok {
-			return Permit{Permit: entitymodel.Permit{}}, fmt.Errorf("protocol %q not found for permit", protocolID)
+			return Permit{Permit: entitymodel.Permit{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityPermit, Field: "protocol_ids", ReferencedEntity: domain.EntityProtocol, ReferencedID: protocolID}
 		}
 	}
 	if err := normalizePermit(&current); err != nil {
 		return Permit{Permit: entitymodel.Permit{}}, err
 	}
+	if err := validatePermitIssueDateUpdate(before, current); err != nil {
+		return Permit{Permit: entitymodel.Permit{}}, err
+	}
 	current.ID = id
 	current.UpdatedAt = tx.now
 	tx.state.permits[id] = clonePermit(current)
@@ -2700,7 +3748,9 @@ func (tx *transaction) DeletePermit(id string) error {
 // CreateProject stores a project record.
 func (tx *transaction) CreateProject(p Project) (Project, error) {
 	if p.ID == "" {
-		p.ID = tx.store.newID()
+		p.ID = tx.store.newEntityID(domain.EntityProject)
+	} else if err := tx.store.checkIDPrefix(domain.EntityProject, p.ID); err != nil {
+		return Project{Project: entitymodel.Project{}}, err
 	}
 	if _, exists := tx.state.projects[p.ID]; exists {
 		return Project{Project: entitymodel.Project{}}, fmt.Errorf("project %q already exists", p.ID)
@@ -2711,7 +3761,13 @@ func (tx *transaction) CreateProject(p Project) (Project, error) {
 	}
 	for _, facilityID := range p.FacilityIDs {
 		if _, ok := tx.state.facilities[facilityID]; !ok {
-			return Project{Project: entitymodel.Project{}}, fmt.Errorf("facility %q not found for project", facilityID)
+			return Project{Project: entitymodel.Project{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityProject, Field: "facility_ids", ReferencedEntity: domain.EntityFacility, ReferencedID: facilityID}
+		}
+	}
+	p.ProtocolIDs = dedupeStrings(p.ProtocolIDs)
+	for _, protocolID := range p.ProtocolIDs {
+		if _, ok := tx.state.protocols[protocolID]; !ok {
+			return Project{Project: entitymodel.Project{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityProject, Field: "protocol_ids", ReferencedEntity: domain.EntityProtocol, ReferencedID: protocolID}
 		}
 	}
 	p.OrganismIDs = nil
@@ -2720,19 +3776,22 @@ func (tx *transaction) CreateProject(p Project) (Project, error) {
 	p.CreatedAt = tx.now
 	p.UpdatedAt = tx.now
 	tx.state.projects[p.ID] = cloneProject(p)
-	created := decorateProject(&tx.state, p)
+	created := tx.view().decorateProject(p)
 	tx.recordChange(Change{Entity: domain.EntityProject, Action: domain.ActionCreate, After: changePayloadFromValue(tx, cloneProject(created))})
 	return cloneProject(created), nil
 }
 
 // UpdateProject mutates an existing project record.
+// The mutator sees the derived ID lists populated; they are recomputed on
+// exit.
 func (tx *transaction) UpdateProject(id string, mutator func(*Project) error) (Project, error) {
 	current, ok := tx.state.projects[id]
 	if !ok {
 		return Project{Project: entitymodel.Project{}}, fmt.Errorf("project %q not found", id)
 	}
-	beforeDecorated := decorateProject(&tx.state, current)
+	beforeDecorated := tx.view().decorateProject(current)
 	before := cloneProject(beforeDecorated)
+	current = cloneProject(beforeDecorated)
 	if err := mutator(&current); err != nil {
 		return Project{Project: entitymodel.Project{}}, err
 	}
@@ -2742,7 +3801,13 @@ func (tx *transaction) UpdateProject(id string, mutator func(*Project) error) (P
 	}
 	for _, facilityID := range current.FacilityIDs {
 		if _, ok := tx.state.facilities[facilityID]; !ok {
-			return Project{Project: entitymodel.Project{}}, fmt.Errorf("facility %q not found for project", facilityID)
+			return Project{Project: entitymodel.Project{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityProject, Field: "facility_ids", ReferencedEntity: domain.EntityFacility, ReferencedID: facilityID}
+		}
+	}
+	current.ProtocolIDs = dedupeStrings(current.ProtocolIDs)
+	for _, protocolID := range current.ProtocolIDs {
+		if _, ok := tx.state.protocols[protocolID]; !ok {
+			return Project{Project: entitymodel.Project{}}, domain.ErrReferentialIntegrity{Entity: domain.EntityProject, Field: "protocol_ids", ReferencedEntity: domain.EntityProtocol, ReferencedID: protocolID}
 		}
 	}
 	current.OrganismIDs = nil
@@ -2751,7 +3816,7 @@ func (tx *transaction) UpdateProject(id string, mutator func(*Project) error) (P
 	current.ID = id
 	current.UpdatedAt = tx.now
 	tx.state.projects[id] = cloneProject(current)
-	afterDecorated := decorateProject(&tx.state, current)
+	afterDecorated := tx.view().decorateProject(current)
 	tx.recordChange(Change{Entity: domain.EntityProject, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneProject(afterDecorated))})
 	return cloneProject(afterDecorated), nil
 }
@@ -2762,21 +3827,98 @@ func (tx *transaction) DeleteProject(id string) error {
 	if !ok {
 		return fmt.Errorf("project %q not found", id)
 	}
-	decoratedCurrent := decorateProject(&tx.state, current)
-	for _, supply := range tx.state.supplies {
-		if containsString(supply.ProjectIDs, id) {
-			return fmt.Errorf("project %q still referenced by supply item %q", id, supply.ID)
-		}
+	decoratedCurrent := tx.view().decorateProject(current)
+	if err := tx.requireUnreferenced(domain.EntityProject, id, domain.EntitySupplyItem); err != nil {
+		return err
 	}
 	delete(tx.state.projects, id)
 	tx.recordChange(Change{Entity: domain.EntityProject, Action: domain.ActionDelete, Before: changePayloadFromValue(tx, cloneProject(decoratedCurrent))})
 	return nil
 }
 
+// CloseProject marks a project closed at closedAt and cancels its scheduled
+// and in-progress procedures. Closing an already-closed project returns it
+// unchanged.
+func (tx *transaction) CloseProject(id string, closedAt time.Time) (Project, error) {
+	current, ok := tx.state.projects[id]
+	if !ok {
+		return Project{Project: entitymodel.Project{}}, fmt.Errorf("project %q not found", id)
+	}
+	if current.ClosedAt != nil {
+		return cloneProject(tx.view().decorateProject(current)), nil
+	}
+	if closedAt.IsZero() {
+		closedAt = tx.now
+	}
+	before := cloneProject(tx.view().decorateProject(current))
+	for _, procedureID := range sortedKeys(tx.state.procedures) {
+		procedure := tx.state.procedures[procedureID]
+		if procedure.ProjectID == nil || *procedure.ProjectID != id {
+			continue
+		}
+		if procedure.Status != domain.ProcedureStatusScheduled && procedure.Status != domain.ProcedureStatusInProgress {
+			continue
+		}
+		procedureBefore := cloneProcedure(decorateProcedure(&tx.state, procedure))
+		reason := projectClosedReason
+		procedure.Status = domain.ProcedureStatusCancelled
+		procedure.CancellationReason = &reason
+		procedure.UpdatedAt = tx.now
+		tx.state.procedures[procedureID] = cloneProcedure(procedure)
+		tx.recordChange(Change{Entity: domain.EntityProcedure, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, procedureBefore), After: changePayloadFromValue(tx, cloneProcedure(decorateProcedure(&tx.state, procedure)))})
+	}
+	closed := closedAt.UTC()
+	current.ClosedAt = &closed
+	current.UpdatedAt = tx.now
+	tx.state.projects[id] = cloneProject(current)
+	afterDecorated := tx.view().decorateProject(current)
+	tx.recordChange(Change{Entity: domain.EntityProject, Action: domain.ActionClose, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneProject(afterDecorated))})
+	return cloneProject(afterDecorated), nil
+}
+
+// TransitionProcedures moves every listed procedure to status to. Each move
+// must be allowed by domain.CanTransitionProcedure; all of them are checked
+// before any is applied, so a missing ID or an illegal transition leaves every
+// procedure unchanged. Procedures already in status to are returned without
+// recording a change, and duplicate IDs are ignored.
+func (tx *transaction) TransitionProcedures(ids []string, to domain.ProcedureStatus) ([]Procedure, error) {
+	if _, ok := validProcedureStatuses[to]; !ok {
+		return nil, fmt.Errorf("unsupported procedure status %q", to)
+	}
+	ids = dedupeStrings(ids)
+	for _, id := range ids {
+		current, ok := tx.state.procedures[id]
+		if !ok {
+			return nil, fmt.Errorf("procedure %q not found", id)
+		}
+		if current.Status != to && !domain.CanTransitionProcedure(current.Status, to) {
+			return nil, fmt.Errorf("procedure %q cannot transition from %s to %s", id, current.Status, to)
+		}
+	}
+	out := make([]Procedure, 0, len(ids))
+	for _, id := range ids {
+		current := tx.state.procedures[id]
+		before := cloneProcedure(decorateProcedure(&tx.state, current))
+		if current.Status == to {
+			out = append(out, before)
+			continue
+		}
+		current.Status = to
+		current.UpdatedAt = tx.now
+		tx.state.procedures[id] = cloneProcedure(current)
+		after := cloneProcedure(decorateProcedure(&tx.state, current))
+		tx.recordChange(Change{Entity: domain.EntityProcedure, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, after)})
+		out = append(out, after)
+	}
+	return out, nil
+}
+
 // CreateSupplyItem stores a supply item record.
 func (tx *transaction) CreateSupplyItem(s SupplyItem) (SupplyItem, error) {
 	if s.ID == "" {
-		s.ID = tx.store.newID()
+		s.ID = tx.store.newEntityID(domain.EntitySupplyItem)
+	} else if err := tx.store.checkIDPrefix(domain.EntitySupplyItem, s.ID); err != nil {
+		return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, err
 	}
 	if _, exists := tx.state.supplies[s.ID]; exists {
 		return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, fmt.Errorf("supply item %q already exists", s.ID)
@@ -2787,7 +3929,7 @@ func (tx *transaction) CreateSupplyItem(s SupplyItem) (SupplyItem, error) {
 	}
 	for _, facilityID := range s.FacilityIDs {
 		if _, ok := tx.state.facilities[facilityID]; !ok {
-			return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, fmt.Errorf("facility %q not found for supply item", facilityID)
+			return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, domain.ErrReferentialIntegrity{Entity: domain.EntitySupplyItem, Field: "facility_ids", ReferencedEntity: domain.EntityFacility, ReferencedID: facilityID}
 		}
 	}
 	s.ProjectIDs = dedupeStrings(s.ProjectIDs)
@@ -2796,9 +3938,12 @@ func (tx *transaction) CreateSupplyItem(s SupplyItem) (SupplyItem, error) {
 	}
 	for _, projectID := range s.ProjectIDs {
 		if _, ok := tx.state.projects[projectID]; !ok {
-			return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, fmt.Errorf("project %q not found for supply item", projectID)
+			return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, domain.ErrReferentialIntegrity{Entity: domain.EntitySupplyItem, Field: "project_ids", ReferencedEntity: domain.EntityProject, ReferencedID: projectID}
 		}
 	}
+	if err := normalizeSupplyItem(&s); err != nil {
+		return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, err
+	}
 	s.CreatedAt = tx.now
 	s.UpdatedAt = tx.now
 	if attrs := s.SupplyAttributes(); attrs == nil {
@@ -2806,6 +3951,9 @@ func (tx *transaction) CreateSupplyItem(s SupplyItem) (SupplyItem, error) {
 	} else {
 		mustApply("apply supply attributes", s.ApplySupplyAttributes(attrs))
 	}
+	if err := checkAttributeLimits(tx, domain.EntitySupplyItem, s.ID, s.SupplyItemExtensions); err != nil {
+		return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, err
+	}
 	tx.state.supplies[s.ID] = cloneSupplyItem(s)
 	tx.recordChange(Change{Entity: domain.EntitySupplyItem, Action: domain.ActionCreate, After: changePayloadFromValue(tx, cloneSupplyItem(s))})
 	return cloneSupplyItem(s), nil
@@ -2827,7 +3975,7 @@ func (tx *transaction) UpdateSupplyItem(id string, mutator func(*SupplyItem) err
 	}
 	for _, facilityID := range current.FacilityIDs {
 		if _, ok := tx.state.facilities[facilityID]; !ok {
-			return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, fmt.Errorf("facility %q not found for supply item", facilityID)
+			return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, domain.ErrReferentialIntegrity{Entity: domain.EntitySupplyItem, Field: "facility_ids", ReferencedEntity: domain.EntityFacility, ReferencedID: facilityID}
 		}
 	}
 	current.ProjectIDs = dedupeStrings(current.ProjectIDs)
@@ -2836,9 +3984,12 @@ func (tx *transaction) UpdateSupplyItem(id string, mutator func(*SupplyItem) err
 	}
 	for _, projectID := range current.ProjectIDs {
 		if _, ok := tx.state.projects[projectID]; !ok {
-			return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, fmt.Errorf("project %q not found for supply item", projectID)
+			return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, domain.ErrReferentialIntegrity{Entity: domain.EntitySupplyItem, Field: "project_ids", ReferencedEntity: domain.EntityProject, ReferencedID: projectID}
 		}
 	}
+	if err := normalizeSupplyItem(&current); err != nil {
+		return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, err
+	}
 	if attrs := current.SupplyAttributes(); attrs == nil {
 		mustApply("apply supply attributes", current.ApplySupplyAttributes(map[string]any{}))
 	} else {
@@ -2850,11 +4001,57 @@ func (tx *transaction) UpdateSupplyItem(id string, mutator func(*SupplyItem) err
 	}
 	current.ID = id
 	current.UpdatedAt = tx.now
+	if err := checkAttributeLimits(tx, domain.EntitySupplyItem, id, current.SupplyItemExtensions); err != nil {
+		return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, err
+	}
 	tx.state.supplies[id] = cloneSupplyItem(current)
 	tx.recordChange(Change{Entity: domain.EntitySupplyItem, Action: domain.ActionUpdate, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneSupplyItem(current))})
 	return cloneSupplyItem(current), nil
 }
 
+// ConsumeSupplyItem draws quantity down from a supply item's stock and appends
+// a consumption event to its core attributes. Stock reaching zero marks the
+// item consumed.
+func (tx *transaction) ConsumeSupplyItem(id string, quantity float64, consumedBy string) (SupplyItem, error) {
+	consumedBy = strings.TrimSpace(consumedBy)
+	if consumedBy == "" {
+		return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, errors.New("supply consumption requires consumed_by")
+	}
+	if !(quantity > 0) || math.IsInf(quantity, 0) {
+		return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, fmt.Errorf("supply consumption quantity must be greater than zero, got %v", quantity)
+	}
+	if quantity != math.Trunc(quantity) {
+		return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, fmt.Errorf("supply consumption quantity must be a whole number of units, got %v", quantity)
+	}
+	current, ok := tx.state.supplies[id]
+	if !ok {
+		return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, fmt.Errorf("supply item %q not found", id)
+	}
+	if float64(current.QuantityOnHand) < quantity {
+		return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, fmt.Errorf("supply item %q has %d %s on hand, cannot consume %v", id, current.QuantityOnHand, current.Unit, quantity)
+	}
+	before := cloneSupplyItem(current)
+	current.QuantityOnHand -= int(quantity)
+	if current.QuantityOnHand == 0 {
+		current.Status = domain.SupplyStatusConsumed
+	}
+	attrs := current.SupplyAttributes()
+	if attrs == nil {
+		attrs = map[string]any{}
+	}
+	events, _ := attrs[supplyConsumptionLogKey].([]any)
+	attrs[supplyConsumptionLogKey] = append(append([]any(nil), events...), map[string]any{
+		"quantity":    quantity,
+		"consumed_by": consumedBy,
+		"consumed_at": tx.now.UTC().Format(time.RFC3339Nano),
+	})
+	mustApply("apply supply attributes", current.ApplySupplyAttributes(attrs))
+	current.UpdatedAt = tx.now
+	tx.state.supplies[id] = cloneSupplyItem(current)
+	tx.recordChange(Change{Entity: domain.EntitySupplyItem, Action: domain.ActionConsume, Before: changePayloadFromValue(tx, before), After: changePayloadFromValue(tx, cloneSupplyItem(current))})
+	return cloneSupplyItem(current), nil
+}
+
 // DeleteSupplyItem removes a supply item from state.
 func (tx *transaction) DeleteSupplyItem(id string) error {
 	current, ok := tx.state.supplies[id]
@@ -3084,11 +4281,22 @@ func (s *Store) ListProjects() []Project {
 	defer s.mu.RUnlock()
 	out := make([]Project, 0, len(s.state.projects))
 	for _, p := range s.state.projects {
-		out = append(out, cloneProject(decorateProject(&s.state, p)))
+		out = append(out, cloneProject(transactionView{state: &s.state}.decorateProject(p)))
 	}
 	return out
 }
 
+// GetBreedingUnit retrieves a breeding unit by ID.
+func (s *Store) GetBreedingUnit(id string) (BreedingUnit, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	b, ok := s.state.breeding[id]
+	if !ok {
+		return BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{}}, false
+	}
+	return cloneBreeding(b), true
+}
+
 // ListBreedingUnits returns all breeding units.
 func (s *Store) ListBreedingUnits() []BreedingUnit {
 	s.mu.RLock()
@@ -3100,6 +4308,20 @@ func (s *Store) ListBreedingUnits() []BreedingUnit {
 	return out
 }
 
+// ListBreedingUnitsByIntent returns the breeding units recorded with intent, ordered by ID.
+func (s *Store) ListBreedingUnitsByIntent(intent domain.PairingIntent) []BreedingUnit {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]BreedingUnit, 0)
+	for _, b := range s.state.breeding {
+		if b.PairingIntent != nil && *b.PairingIntent == intent {
+			out = append(out, cloneBreeding(b))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
 // ListProcedures returns all procedures.
 func (s *Store) ListProcedures() []Procedure {
 	s.mu.RLock()
@@ -3111,6 +4333,17 @@ func (s *Store) ListProcedures() []Procedure {
 	return out
 }
 
+// GetSupplyItem retrieves a supply item by ID.
+func (s *Store) GetSupplyItem(id string) (SupplyItem, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	item, ok := s.state.supplies[id]
+	if !ok {
+		return SupplyItem{SupplyItem: entitymodel.SupplyItem{}}, false
+	}
+	return cloneSupplyItem(item), true
+}
+
 // ListSupplyItems returns all supply items.
 func (s *Store) ListSupplyItems() []SupplyItem {
 	s.mu.RLock()
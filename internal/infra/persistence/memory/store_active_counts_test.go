@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestActiveStrainAndLineCounts(t *testing.T) {
+	store := NewStore(nil)
+	ctx := context.Background()
+	var lineA, lineB, strainID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		marker, err := tx.CreateGenotypeMarker(domain.GenotypeMarker{GenotypeMarker: entitymodel.GenotypeMarker{Name: "Marker", Locus: "loc", Alleles: []string{"A"}, AssayMethod: "PCR", Interpretation: "ctrl", Version: "v1"}})
+		if err != nil {
+			return err
+		}
+		for i, code := range []string{"LA", "LB"} {
+			line, err := tx.CreateLine(domain.Line{Line: entitymodel.Line{Code: code, Name: code, Origin: "field", GenotypeMarkerIDs: []string{marker.ID}}})
+			if err != nil {
+				return err
+			}
+			if i == 0 {
+				lineA = line.ID
+			} else {
+				lineB = line.ID
+			}
+		}
+		for _, code := range []string{"S1", "S2"} {
+			strain, err := tx.CreateStrain(domain.Strain{Strain: entitymodel.Strain{Code: code, Name: code, LineID: lineA}})
+			if err != nil {
+				return err
+			}
+			strainID = strain.ID
+		}
+		_, err = tx.CreateStrain(domain.Strain{Strain: entitymodel.Strain{Code: "S3", Name: "S3", LineID: lineB}})
+		return err
+	}); err != nil {
+		t.Fatalf("seed lines and strains: %v", err)
+	}
+
+	counts := func() (int, int, int) {
+		var a, b, lines int
+		if err := store.View(ctx, func(view domain.TransactionView) error {
+			a, b, lines = view.ActiveStrainCount(lineA), view.ActiveStrainCount(lineB), view.ActiveLineCount()
+			return nil
+		}); err != nil {
+			t.Fatalf("view: %v", err)
+		}
+		return a, b, lines
+	}
+	if a, b, lines := counts(); a != 2 || b != 1 || lines != 2 {
+		t.Fatalf("expected counts 2/1 strains and 2 lines, got %d/%d and %d", a, b, lines)
+	}
+
+	retiredAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		if _, err := tx.UpdateStrain(strainID, func(s *domain.Strain) error {
+			s.RetiredAt = &retiredAt
+			return nil
+		}); err != nil {
+			return err
+		}
+		_, err := tx.UpdateLine(lineB, func(l *domain.Line) error {
+			l.DeprecatedAt = &retiredAt
+			return nil
+		})
+		return err
+	}); err != nil {
+		t.Fatalf("retire strain: %v", err)
+	}
+	if a, b, lines := counts(); a != 1 || b != 1 || lines != 1 {
+		t.Fatalf("expected counts 1/1 strains and 1 line after retirement, got %d/%d and %d", a, b, lines)
+	}
+}
@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func seedAdverseEventTreatment(t *testing.T, store *Store) string {
+	t.Helper()
+	var treatmentID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PR-AE", Title: "Adverse", MaxSubjects: 1, Status: domain.ProtocolStatusApproved}})
+		if err != nil {
+			return err
+		}
+		procedure, err := tx.CreateProcedure(domain.Procedure{Procedure: entitymodel.Procedure{Name: "Proc", Status: domain.ProcedureStatusScheduled, ScheduledAt: time.Now().UTC(), ProtocolID: protocol.ID}})
+		if err != nil {
+			return err
+		}
+		treatment, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{
+			Name:        "Analgesia",
+			ProcedureID: procedure.ID,
+			DosagePlan:  domain.DosagePlan{Drug: "meloxicam", DoseAmount: 0.5, DoseUnit: "mg/kg"},
+		}})
+		treatmentID = treatment.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed treatment: %v", err)
+	}
+	return treatmentID
+}
+
+func appendAdverseEvent(store *Store, treatmentID string, event domain.AdverseEvent) (domain.Treatment, error) {
+	var updated domain.Treatment
+	_, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		var err error
+		updated, err = tx.AppendAdverseEvent(treatmentID, event)
+		return err
+	})
+	return updated, err
+}
+
+func TestAppendAdverseEventRecordsEachSeverity(t *testing.T) {
+	store := NewStore(nil)
+	treatmentID := seedAdverseEventTreatment(t, store)
+	observed := time.Date(2025, 3, 4, 9, 30, 0, 0, time.UTC)
+
+	severities := []domain.AdverseEventSeverity{domain.AdverseEventSeverityMild, domain.AdverseEventSeverityModerate, domain.AdverseEventSeveritySevere}
+	for i, severity := range severities {
+		updated, err := appendAdverseEvent(store, treatmentID, domain.AdverseEvent{Description: " weight loss ", Severity: severity, ObservedAt: observed})
+		if err != nil {
+			t.Fatalf("append %s event: %v", severity, err)
+		}
+		if len(updated.AdverseEvents) != i+1 {
+			t.Fatalf("expected %d events after %s, got %+v", i+1, severity, updated.AdverseEvents)
+		}
+		got := updated.AdverseEvents[i]
+		if got.Severity != severity || got.Description != "weight loss" || !got.ObservedAt.Equal(observed) {
+			t.Fatalf("unexpected %s event %+v", severity, got)
+		}
+	}
+
+	updated, err := appendAdverseEvent(store, treatmentID, domain.AdverseEvent{Description: "lethargy", Severity: domain.AdverseEventSeverityMild})
+	if err != nil {
+		t.Fatalf("append event without observed_at: %v", err)
+	}
+	if updated.AdverseEvents[len(updated.AdverseEvents)-1].ObservedAt.IsZero() {
+		t.Fatalf("expected observed_at to default to the transaction time")
+	}
+}
+
+func TestAppendAdverseEventRejectsInvalidEvents(t *testing.T) {
+	store := NewStore(nil)
+	treatmentID := seedAdverseEventTreatment(t, store)
+
+	if _, err := appendAdverseEvent(store, treatmentID, domain.AdverseEvent{Description: "  ", Severity: domain.AdverseEventSeverityMild}); err == nil || !strings.Contains(err.Error(), "description is required") {
+		t.Fatalf("expected empty description rejection, got %v", err)
+	}
+	if _, err := appendAdverseEvent(store, treatmentID, domain.AdverseEvent{Description: "rash", Severity: "critical"}); err == nil || !strings.Contains(err.Error(), "unsupported adverse event severity") {
+		t.Fatalf("expected severity rejection, got %v", err)
+	}
+	if _, err := appendAdverseEvent(store, "missing", domain.AdverseEvent{Description: "rash", Severity: domain.AdverseEventSeverityMild}); err == nil {
+		t.Fatalf("expected unknown treatment error")
+	}
+	if stored := store.ListTreatments()[0]; len(stored.AdverseEvents) != 0 {
+		t.Fatalf("expected no events after rejections, got %+v", stored.AdverseEvents)
+	}
+}
+
+func TestTreatmentUnmarshalAcceptsLegacyAdverseEvents(t *testing.T) {
+	var treatment domain.Treatment
+	if err := json.Unmarshal([]byte(`{"id":"t1","adverse_events":["redness",{"description":"seizure","severity":"severe","observed_at":"2025-01-02T03:04:05Z"}]}`), &treatment); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(treatment.AdverseEvents) != 2 || treatment.AdverseEvents[0].Description != "redness" || treatment.AdverseEvents[0].Severity != "" {
+		t.Fatalf("expected legacy entry preserved as description, got %+v", treatment.AdverseEvents)
+	}
+	if treatment.AdverseEvents[1].Severity != domain.AdverseEventSeveritySevere {
+		t.Fatalf("expected structured entry decoded, got %+v", treatment.AdverseEvents[1])
+	}
+}
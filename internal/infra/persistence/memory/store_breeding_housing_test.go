@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestBreedingUnitHousingReferentialIntegrity(t *testing.T) {
+	store := NewStore(nil)
+	ctx := context.Background()
+
+	var housingID, unitID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "FAC", Name: "Facility", Zone: "Z", AccessPolicy: "open"}})
+		if err != nil {
+			return err
+		}
+		housing, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: facility.ID, Capacity: 2}})
+		if err != nil {
+			return err
+		}
+		housingID = housing.ID
+		if _, err := tx.CreateBreedingUnit(domain.BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{Name: "Unhoused", Strategy: "pair"}}); err != nil {
+			return err
+		}
+		unit, err := tx.CreateBreedingUnit(domain.BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{Name: "Housed", Strategy: "pair", HousingID: &housingID}})
+		unitID = unit.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed breeding units: %v", err)
+	}
+
+	missing := "missing-housing"
+	want := domain.ErrReferentialIntegrity{Entity: domain.EntityBreeding, Field: "housing_id", ReferencedEntity: domain.EntityHousingUnit, ReferencedID: missing}
+	_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.CreateBreedingUnit(domain.BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{Name: "Orphan", Strategy: "pair", HousingID: &missing}})
+		return err
+	})
+	var integrityErr domain.ErrReferentialIntegrity
+	if !errors.As(err, &integrityErr) || integrityErr != want {
+		t.Fatalf("expected %v on create, got %v", want, err)
+	}
+	_, err = store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdateBreedingUnit(unitID, func(b *domain.BreedingUnit) error {
+			b.HousingID = &missing
+			return nil
+		})
+		return err
+	})
+	if !errors.As(err, &integrityErr) || integrityErr != want {
+		t.Fatalf("expected %v on update, got %v", want, err)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdateBreedingUnit(unitID, func(b *domain.BreedingUnit) error {
+			b.HousingID = nil
+			return nil
+		})
+		return err
+	}); err != nil {
+		t.Fatalf("clear housing: %v", err)
+	}
+	unit, ok := store.GetBreedingUnit(unitID)
+	if !ok || unit.HousingID != nil {
+		t.Fatalf("expected housing cleared, got %+v", unit)
+	}
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestBreedingUnitPairingIntentDefaultsAndValidation(t *testing.T) {
+	store := NewStore(nil)
+	ctx := context.Background()
+	var created domain.BreedingUnit
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		var err error
+		created, err = tx.CreateBreedingUnit(domain.BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{Name: "Pair", Strategy: "pair"}})
+		return err
+	}); err != nil {
+		t.Fatalf("create breeding unit: %v", err)
+	}
+	if created.PairingIntent == nil || *created.PairingIntent != domain.PairingIntentMaintenance {
+		t.Fatalf("expected default maintenance intent, got %v", created.PairingIntent)
+	}
+
+	invalid := domain.PairingIntent("outcross")
+	_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.CreateBreedingUnit(domain.BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{Name: "Bad", Strategy: "pair", PairingIntent: &invalid}})
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), "unsupported pairing intent") {
+		t.Fatalf("expected invalid intent rejection on create, got %v", err)
+	}
+	_, err = store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdateBreedingUnit(created.ID, func(b *domain.BreedingUnit) error {
+			b.PairingIntent = &invalid
+			return nil
+		})
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), "unsupported pairing intent") {
+		t.Fatalf("expected invalid intent rejection on update, got %v", err)
+	}
+}
+
+func TestListBreedingUnitsByIntent(t *testing.T) {
+	store := NewStore(nil)
+	experimental := domain.PairingIntentExperimental
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		for _, unit := range []domain.BreedingUnit{
+			{BreedingUnit: entitymodel.BreedingUnit{ID: "b-2", Name: "Cross B", Strategy: "pair", PairingIntent: &experimental}},
+			{BreedingUnit: entitymodel.BreedingUnit{ID: "b-1", Name: "Cross A", Strategy: "pair", PairingIntent: &experimental}},
+			{BreedingUnit: entitymodel.BreedingUnit{ID: "b-3", Name: "Maintain", Strategy: "pair"}},
+		} {
+			if _, err := tx.CreateBreedingUnit(unit); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("seed breeding units: %v", err)
+	}
+
+	got := store.ListBreedingUnitsByIntent(domain.PairingIntentExperimental)
+	if len(got) != 2 || got[0].ID != "b-1" || got[1].ID != "b-2" {
+		t.Fatalf("expected experimental units b-1, b-2, got %+v", got)
+	}
+	if got := store.ListBreedingUnitsByIntent(domain.PairingIntentMaintenance); len(got) != 1 || got[0].ID != "b-3" {
+		t.Fatalf("expected defaulted maintenance unit b-3, got %+v", got)
+	}
+	if got := store.ListBreedingUnitsByIntent(domain.PairingIntentRederivation); len(got) != 0 {
+		t.Fatalf("expected no rederivation units, got %+v", got)
+	}
+}
+
+func TestMigrateSnapshotMovesLegacyPairingIntentToNotes(t *testing.T) {
+	legacy := domain.PairingIntent("Expand fixture line")
+	notes := "Healthy adults selected"
+	snapshot := migrateSnapshot(Snapshot{Breeding: map[string]BreedingUnit{
+		"b-1": {BreedingUnit: entitymodel.BreedingUnit{ID: "b-1", Name: "Legacy", Strategy: "pair", PairingIntent: &legacy, PairingNotes: &notes}},
+	}})
+	got := snapshot.Breeding["b-1"]
+	if got.PairingIntent == nil || *got.PairingIntent != domain.PairingIntentMaintenance {
+		t.Fatalf("expected legacy intent replaced with default, got %v", got.PairingIntent)
+	}
+	if got.PairingNotes == nil || *got.PairingNotes != "Expand fixture line; Healthy adults selected" {
+		t.Fatalf("expected legacy intent preserved in notes, got %v", got.PairingNotes)
+	}
+}
@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCloseProjectCancelsOpenProcedures(t *testing.T) {
+	var changes []domain.Change
+	engine := domain.NewRulesEngine()
+	engine.Register(supplyChangeRecorder{changes: &changes})
+	store := NewStore(engine)
+	ctx := context.Background()
+	var projectID string
+	procedureIDs := map[domain.ProcedureStatus]string{}
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "VIV", Name: "Vivarium"}})
+		if err != nil {
+			return err
+		}
+		project, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{Code: "PRJ", Title: "Project", FacilityIDs: []string{facility.ID}}})
+		if err != nil {
+			return err
+		}
+		projectID = project.ID
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PR", Title: "Protocol", MaxSubjects: 5, Status: domain.ProtocolStatusApproved}})
+		if err != nil {
+			return err
+		}
+		for i, status := range []domain.ProcedureStatus{domain.ProcedureStatusScheduled, domain.ProcedureStatusInProgress, domain.ProcedureStatusCompleted} {
+			procedure, err := tx.CreateProcedure(domain.Procedure{Procedure: entitymodel.Procedure{
+				Name:        string(status),
+				Status:      status,
+				ScheduledAt: time.Date(2025, 1, i+1, 9, 0, 0, 0, time.UTC),
+				ProtocolID:  protocol.ID,
+				ProjectID:   &project.ID,
+			}})
+			if err != nil {
+				return err
+			}
+			procedureIDs[status] = procedure.ID
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("seed project: %v", err)
+	}
+
+	closedAt := time.Date(2025, 6, 30, 17, 0, 0, 0, time.UTC)
+	changes = nil
+	var closed domain.Project
+	res, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		var err error
+		closed, err = tx.CloseProject(projectID, closedAt)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("close project: %v (%+v)", err, res)
+	}
+	if closed.ClosedAt == nil || !closed.ClosedAt.Equal(closedAt) {
+		t.Fatalf("expected closed_at %s, got %v", closedAt, closed.ClosedAt)
+	}
+
+	var actions []string
+	for _, change := range changes {
+		actions = append(actions, string(change.Entity)+":"+string(change.Action))
+	}
+	if len(actions) != 3 || actions[0] != "procedure:update" || actions[1] != "procedure:update" || actions[2] != "project:close" {
+		t.Fatalf("expected two procedure updates followed by a project close, got %v", actions)
+	}
+
+	procedures := map[string]domain.Procedure{}
+	for _, procedure := range store.ListProcedures() {
+		procedures[procedure.ID] = procedure
+	}
+	for _, status := range []domain.ProcedureStatus{domain.ProcedureStatusScheduled, domain.ProcedureStatusInProgress} {
+		procedure := procedures[procedureIDs[status]]
+		if procedure.Status != domain.ProcedureStatusCancelled || procedure.CancellationReason == nil || *procedure.CancellationReason != "project closed" {
+			t.Fatalf("expected %s procedure to be cancelled for project closure, got %+v", status, procedure)
+		}
+	}
+	completed := procedures[procedureIDs[domain.ProcedureStatusCompleted]]
+	if completed.Status != domain.ProcedureStatusCompleted || completed.CancellationReason != nil {
+		t.Fatalf("expected completed procedure to be untouched, got %+v", completed)
+	}
+
+	changes = nil
+	var again domain.Project
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		var err error
+		again, err = tx.CloseProject(projectID, closedAt.Add(24*time.Hour))
+		return err
+	}); err != nil {
+		t.Fatalf("close project again: %v", err)
+	}
+	if again.ClosedAt == nil || !again.ClosedAt.Equal(closedAt) {
+		t.Fatalf("expected repeated close to keep original closed_at, got %v", again.ClosedAt)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected repeated close to record no changes, got %+v", changes)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.CloseProject("missing", closedAt)
+		return err
+	}); err == nil {
+		t.Fatalf("expected error closing unknown project")
+	}
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestCohortCreatedFromBreedingUnit(t *testing.T) {
+	store := NewStore(nil)
+	ctx := context.Background()
+
+	var breedingID, litterID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		unit, err := tx.CreateBreedingUnit(domain.BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{Name: "Pair", Strategy: "pair"}})
+		if err != nil {
+			return err
+		}
+		breedingID = unit.ID
+		litter, err := tx.CreateCohort(domain.Cohort{Cohort: entitymodel.Cohort{Name: "Litter", Purpose: "offspring", CreatedFromBreedingUnitID: &breedingID}})
+		if err != nil {
+			return err
+		}
+		litterID = litter.ID
+		plain, err := tx.CreateCohort(domain.Cohort{Cohort: entitymodel.Cohort{Name: "Plain", Purpose: "study"}})
+		if err != nil {
+			return err
+		}
+		if plain.CreatedFromBreedingUnitID != nil {
+			t.Fatalf("expected plain cohort without provenance, got %q", *plain.CreatedFromBreedingUnitID)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	if err := store.View(ctx, func(view domain.TransactionView) error {
+		litter, ok := view.FindCohort(litterID)
+		if !ok || litter.CreatedFromBreedingUnitID == nil || *litter.CreatedFromBreedingUnitID != breedingID {
+			t.Fatalf("expected litter linked to breeding unit %s, got %+v", breedingID, litter)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+
+	missing := "missing-breeding"
+	want := domain.ErrReferentialIntegrity{Entity: domain.EntityCohort, Field: "created_from_breeding_unit_id", ReferencedEntity: domain.EntityBreeding, ReferencedID: missing}
+	_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.CreateCohort(domain.Cohort{Cohort: entitymodel.Cohort{Name: "Orphan", Purpose: "offspring", CreatedFromBreedingUnitID: &missing}})
+		return err
+	})
+	var integrityErr domain.ErrReferentialIntegrity
+	if !errors.As(err, &integrityErr) || integrityErr != want {
+		t.Fatalf("expected %v on create, got %v", want, err)
+	}
+	_, err = store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdateCohort(litterID, func(c *domain.Cohort) error {
+			c.CreatedFromBreedingUnitID = &missing
+			return nil
+		})
+		return err
+	})
+	if !errors.As(err, &integrityErr) || integrityErr != want {
+		t.Fatalf("expected %v on update, got %v", want, err)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		return tx.DeleteBreedingUnit(breedingID)
+	}); err == nil {
+		t.Fatalf("expected delete of referenced breeding unit to fail")
+	}
+}
@@ -168,9 +168,9 @@ func seedMemoryStore(t *testing.T, store *memory.Store) memoryIDs {
 			ProcedureID:       ids.procedureID,
 			OrganismIDs:       []string{ids.organismAID},
 			CohortIDs:         []string{ids.cohortID},
-			DosagePlan:        "10mg/kg",
+			DosagePlan:        domain.DosagePlan{Drug: "compound", DoseAmount: 10, DoseUnit: "mg/kg"},
 			AdministrationLog: []string{"t0: administered"},
-			AdverseEvents:     []string{}},
+			AdverseEvents:     []domain.AdverseEvent{}},
 		})
 		treatment := must(t, treatmentVal, err)
 		ids.treatmentID = treatment.ID
@@ -210,6 +210,7 @@ func seedMemoryStore(t *testing.T, store *memory.Store) memoryIDs {
 			Status:          domain.SampleStatusStored,
 			StorageLocation: "freezer-1",
 			AssayType:       "PCR",
+			CollectedBy:     "tech",
 			ChainOfCustody:  custody},
 		}
 		mustNoErr(t, sampleInput.ApplySampleAttributes(map[string]any{"volume_ml": 1.5}))
@@ -227,6 +228,7 @@ func seedMemoryStore(t *testing.T, store *memory.Store) memoryIDs {
 
 		permitVal, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: permitNumberFixture,
 			Authority:         "Agency",
+			IssueDate:         time.Now().Add(-time.Hour),
 			ValidFrom:         time.Now().Add(-time.Hour),
 			ValidUntil:        time.Now().Add(24 * time.Hour),
 			AllowedActivities: []string{"collect"},
@@ -436,7 +438,7 @@ func exerciseMemoryUpdates(t *testing.T, store *memory.Store, ids memoryIDs) {
 		})
 		mustNoErr(t, err)
 		_, err = tx.UpdateProcedure(ids.procedureID, func(p *domain.Procedure) error {
-			p.Status = domain.ProcedureStatusCompleted
+			p.Status = domain.ProcedureStatusInProgress
 			return nil
 		})
 		mustNoErr(t, err)
@@ -452,7 +454,7 @@ func exerciseMemoryUpdates(t *testing.T, store *memory.Store, ids memoryIDs) {
 		mustNoErr(t, err)
 		_, err = tx.UpdateTreatment(ids.treatmentID, func(tr *domain.Treatment) error {
 			tr.AdministrationLog = append(tr.AdministrationLog, "t2: follow-up")
-			tr.AdverseEvents = append(tr.AdverseEvents, "minor redness")
+			tr.AdverseEvents = append(tr.AdverseEvents, domain.AdverseEvent{Description: "minor redness", Severity: domain.AdverseEventSeverityMild, ObservedAt: time.Now()})
 			return nil
 		})
 		mustNoErr(t, err)
@@ -713,6 +715,7 @@ func TestDeleteFacilityEnforcesReferences(t *testing.T) {
 				CollectedAt:     now,
 				Status:          domain.SampleStatusStored,
 				StorageLocation: "room",
+				CollectedBy:     "tech",
 				ChainOfCustody: []domain.SampleCustodyEvent{{
 					Actor:     "tech",
 					Location:  "room",
@@ -725,6 +728,7 @@ func TestDeleteFacilityEnforcesReferences(t *testing.T) {
 			permit, err = tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PERM",
 				Authority:         "Gov",
 				Status:            domain.PermitStatusApproved,
+				IssueDate:         now,
 				ValidFrom:         now,
 				ValidUntil:        now.Add(time.Hour),
 				AllowedActivities: []string{"collect"},
@@ -842,7 +846,7 @@ func TestRelationshipValidations(t *testing.T) {
 			return err
 		}
 
-		if _, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{Name: "ValidTreatment", Status: domain.TreatmentStatusPlanned, ProcedureID: procedure.ID, OrganismIDs: []string{organism.ID}}}); err != nil {
+		if _, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{Name: "ValidTreatment", Status: domain.TreatmentStatusPlanned, ProcedureID: procedure.ID, OrganismIDs: []string{organism.ID}, DosagePlan: domain.DosagePlan{Drug: "compound", DoseAmount: 1, DoseUnit: "mg"}}}); err != nil {
 			t.Fatalf("expected treatment creation to succeed: %v", err)
 		}
 
@@ -854,13 +858,13 @@ func TestRelationshipValidations(t *testing.T) {
 			t.Fatalf("expected observation creation to succeed: %v", err)
 		}
 
-		if _, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{Identifier: "S0", SourceType: "blood", CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}}); err == nil {
+		if _, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{Identifier: "S0", SourceType: "blood", CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", CollectedBy: "tech", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}}); err == nil {
 			t.Fatalf("expected sample without facility to fail")
 		}
-		if _, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{Identifier: "S1", SourceType: "blood", FacilityID: facility.ID, CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}}); err == nil {
+		if _, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{Identifier: "S1", SourceType: "blood", FacilityID: facility.ID, CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", CollectedBy: "tech", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}}); err == nil {
 			t.Fatalf("expected sample without organism or cohort to fail")
 		}
-		if _, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{Identifier: "S2", SourceType: "blood", FacilityID: facility.ID, OrganismID: &organism.ID, CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}}); err != nil {
+		if _, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{Identifier: "S2", SourceType: "blood", FacilityID: facility.ID, OrganismID: &organism.ID, CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", CollectedBy: "tech", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}}); err != nil {
 			t.Fatalf("expected sample creation to succeed: %v", err)
 		}
 
@@ -872,6 +876,7 @@ func TestRelationshipValidations(t *testing.T) {
 			FacilityIDs:       []string{facility.ID},
 			ProtocolIDs:       []string{"missing"},
 			Status:            domain.PermitStatusSubmitted,
+			IssueDate:         now,
 			ValidFrom:         now,
 			ValidUntil:        now.Add(time.Hour)},
 		}); err == nil {
@@ -880,6 +885,7 @@ func TestRelationshipValidations(t *testing.T) {
 		if _, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PERM-OK",
 			Authority:         "Gov",
 			Status:            domain.PermitStatusSubmitted,
+			IssueDate:         now,
 			ValidFrom:         now,
 			ValidUntil:        now.Add(time.Hour),
 			AllowedActivities: []string{"collect"},
@@ -1006,6 +1012,7 @@ func TestUpdatePermitDedupe(t *testing.T) {
 			}
 			permit, err = tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PERM-DEDUP",
 				Authority:         "Gov",
+				IssueDate:         time.Now().UTC(),
 				ValidFrom:         time.Now().UTC(),
 				ValidUntil:        time.Now().UTC().Add(time.Hour),
 				AllowedActivities: []string{"collect"},
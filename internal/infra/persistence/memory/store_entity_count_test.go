@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestCountEntitiesAppliesFilter(t *testing.T) {
+	store := NewStore(nil)
+	var facilityA, facilityB, projectID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		a, err := tx.CreateFacility(Facility{Facility: entitymodel.Facility{Code: "FAC-A", Name: "Facility A"}})
+		if err != nil {
+			return err
+		}
+		b, err := tx.CreateFacility(Facility{Facility: entitymodel.Facility{Code: "FAC-B", Name: "Facility B"}})
+		if err != nil {
+			return err
+		}
+		facilityA, facilityB = a.ID, b.ID
+		project, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{Code: "PRJ", Title: "Project", FacilityIDs: []string{facilityA}}})
+		if err != nil {
+			return err
+		}
+		projectID = project.ID
+		for _, facility := range []string{facilityA, facilityB} {
+			unit, err := tx.CreateHousingUnit(HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank " + facility, FacilityID: facility, Capacity: 4}})
+			if err != nil {
+				return err
+			}
+			if _, err := tx.CreateOrganism(Organism{Organism: entitymodel.Organism{Name: "Housed", Species: "Xenopus", HousingID: &unit.ID}}); err != nil {
+				return err
+			}
+		}
+		_, err = tx.CreateOrganism(Organism{Organism: entitymodel.Organism{Name: "Unhoused", Species: "Xenopus", ProjectID: &projectID}})
+		return err
+	}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		entity domain.EntityType
+		filter domain.CountFilter
+		want   int
+	}{
+		{"unfiltered", domain.EntityOrganism, domain.CountFilter{}, 3},
+		{"facility", domain.EntityOrganism, domain.CountFilter{FacilityIDs: []string{facilityA}}, 2},
+		{"no facility grants", domain.EntityOrganism, domain.CountFilter{FacilityIDs: []string{}}, 1},
+		{"facility and project", domain.EntityOrganism, domain.CountFilter{FacilityIDs: []string{facilityB}, ProjectIDs: []string{}}, 1},
+		{"housing", domain.EntityHousingUnit, domain.CountFilter{FacilityIDs: []string{facilityB}}, 1},
+		{"projects", domain.EntityProject, domain.CountFilter{ProjectIDs: []string{"other"}}, 0},
+		{"unattributed", domain.EntityProtocol, domain.CountFilter{FacilityIDs: []string{}}, 0},
+	}
+	for _, tc := range cases {
+		got, err := store.CountEntities(tc.entity, tc.filter)
+		if err != nil {
+			t.Fatalf("%s: count: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: expected %d, got %d", tc.name, tc.want, got)
+		}
+	}
+	if _, err := store.CountEntities(domain.EntityType("unknown"), domain.CountFilter{}); err == nil {
+		t.Fatalf("expected unknown entity to fail")
+	}
+}
@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestCreateHousingUnitUsesFacilityDefaultEnvironment(t *testing.T) {
+	store := NewStore(nil)
+	aquatic := domain.HousingEnvironmentAquatic
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		pond, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "AQ", Name: "Aquatics", DefaultHousingEnvironment: &aquatic}})
+		if err != nil {
+			return err
+		}
+		plain, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "TR", Name: "Terrestrial"}})
+		if err != nil {
+			return err
+		}
+
+		tank, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: pond.ID, Capacity: 2}})
+		if err != nil {
+			return err
+		}
+		if tank.Environment != domain.HousingEnvironmentAquatic {
+			t.Fatalf("expected aquatic default from facility, got %q", tank.Environment)
+		}
+		if _, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Terrarium", FacilityID: pond.ID, Capacity: 2, Environment: domain.HousingEnvironmentHumid}}); err == nil || !strings.Contains(err.Error(), "humid housing is incompatible with aquatic facility") {
+			t.Fatalf("expected aquatic facility to refuse humid housing, got %v", err)
+		}
+		humid, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Terrarium", FacilityID: plain.ID, Capacity: 2, Environment: domain.HousingEnvironmentHumid}})
+		if err != nil {
+			return err
+		}
+		if humid.Environment != domain.HousingEnvironmentHumid {
+			t.Fatalf("expected explicit environment in a facility without a default, got %q", humid.Environment)
+		}
+		cage, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Cage", FacilityID: plain.ID, Capacity: 2}})
+		if err != nil {
+			return err
+		}
+		if cage.Environment != domain.HousingEnvironmentTerrestrial {
+			t.Fatalf("expected terrestrial fallback, got %q", cage.Environment)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("create housing: %v", err)
+	}
+}
+
+func TestUpdateHousingUnitChecksFacilityEnvironment(t *testing.T) {
+	store := NewStore(nil)
+	aquatic := domain.HousingEnvironmentAquatic
+	var pondID, tankID, cageID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		pond, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "AQ", Name: "Aquatics", DefaultHousingEnvironment: &aquatic}})
+		if err != nil {
+			return err
+		}
+		plain, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "PL", Name: "Plain"}})
+		if err != nil {
+			return err
+		}
+		tank, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: pond.ID, Capacity: 2}})
+		if err != nil {
+			return err
+		}
+		cage, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Cage", FacilityID: plain.ID, Capacity: 2}})
+		pondID, tankID, cageID = pond.ID, tank.ID, cage.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	updates := []struct {
+		name      string
+		housingID string
+		mutate    func(*domain.HousingUnit)
+	}{
+		{"move terrestrial cage into aquatic facility", cageID, func(h *domain.HousingUnit) { h.FacilityID = pondID }},
+		{"change aquatic tank to humid", tankID, func(h *domain.HousingUnit) { h.Environment = domain.HousingEnvironmentHumid }},
+	}
+	for _, update := range updates {
+		_, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+			_, err := tx.UpdateHousingUnit(update.housingID, func(h *domain.HousingUnit) error {
+				update.mutate(h)
+				return nil
+			})
+			return err
+		})
+		if err == nil || !strings.Contains(err.Error(), "incompatible with aquatic facility") {
+			t.Fatalf("%s: expected facility environment check, got %v", update.name, err)
+		}
+	}
+}
+
+func TestFacilityDefaultHousingEnvironmentValidated(t *testing.T) {
+	store := NewStore(nil)
+	invalid := domain.HousingEnvironment("lunar")
+	_, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		_, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "BAD", Name: "Bad", DefaultHousingEnvironment: &invalid}})
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), `unsupported default housing environment "lunar"`) {
+		t.Fatalf("expected invalid default environment on create, got %v", err)
+	}
+
+	var facilityID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "OK", Name: "Facility"}})
+		facilityID = facility.ID
+		return err
+	}); err != nil {
+		t.Fatalf("create facility: %v", err)
+	}
+	_, err = store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		_, err := tx.UpdateFacility(facilityID, func(f *domain.Facility) error {
+			f.DefaultHousingEnvironment = &invalid
+			return nil
+		})
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), "unsupported default housing environment") {
+		t.Fatalf("expected invalid default environment on update, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFacilityTimezoneValidation(t *testing.T) {
+	store := NewStore(nil)
+	ctx := context.Background()
+	valid, invalid := "Europe/London", "Nowhere/Special"
+
+	var facilityID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Name: "Lab", Timezone: &valid}})
+		facilityID = facility.ID
+		return err
+	}); err != nil {
+		t.Fatalf("create facility with IANA timezone: %v", err)
+	}
+	if got, _ := store.GetFacility(facilityID); got.Timezone == nil || *got.Timezone != valid {
+		t.Fatalf("expected timezone %q to persist, got %v", valid, got.Timezone)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Name: "Bad", Timezone: &invalid}})
+		return err
+	}); err == nil || !strings.Contains(err.Error(), invalid) {
+		t.Fatalf("expected invalid timezone to be rejected on create, got %v", err)
+	}
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdateFacility(facilityID, func(f *domain.Facility) error {
+			f.Timezone = &invalid
+			return nil
+		})
+		return err
+	}); err == nil || !strings.Contains(err.Error(), invalid) {
+		t.Fatalf("expected invalid timezone to be rejected on update, got %v", err)
+	}
+	if got, _ := store.GetFacility(facilityID); *got.Timezone != valid {
+		t.Fatalf("expected rejected update to leave timezone %q, got %q", valid, *got.Timezone)
+	}
+}
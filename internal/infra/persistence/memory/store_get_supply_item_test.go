@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestGetSupplyItemReturnsReferences(t *testing.T) {
+	store := NewStore(nil, WithVerifyOnRead(true))
+	var supplyID, facilityID, projectID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "FAC", Name: "Facility"}})
+		if err != nil {
+			return err
+		}
+		project, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{Code: "PRJ", Title: "Project", FacilityIDs: []string{facility.ID}}})
+		if err != nil {
+			return err
+		}
+		supply, err := tx.CreateSupplyItem(domain.SupplyItem{SupplyItem: entitymodel.SupplyItem{
+			SKU:         "SKU-1",
+			Name:        "Diet Blocks",
+			Unit:        "grams",
+			FacilityIDs: []string{facility.ID},
+			ProjectIDs:  []string{project.ID},
+		}})
+		supplyID, facilityID, projectID = supply.ID, facility.ID, project.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed supply item: %v", err)
+	}
+
+	item, ok := store.GetSupplyItem(supplyID)
+	if !ok || item.SKU != "SKU-1" {
+		t.Fatalf("expected supply item, got %+v (%v)", item, ok)
+	}
+	if len(item.FacilityIDs) != 1 || item.FacilityIDs[0] != facilityID || len(item.ProjectIDs) != 1 || item.ProjectIDs[0] != projectID {
+		t.Fatalf("expected facility and project references, got %v %v", item.FacilityIDs, item.ProjectIDs)
+	}
+	item.FacilityIDs[0] = "mutated"
+	if again, _ := store.GetSupplyItem(supplyID); again.FacilityIDs[0] != facilityID {
+		t.Fatalf("expected GetSupplyItem to return a defensive copy")
+	}
+	if _, ok := store.GetSupplyItem("missing"); ok {
+		t.Fatalf("expected missing supply item to return false")
+	}
+
+	store.mu.Lock()
+	stored := store.state.supplies[supplyID]
+	stored.ProjectIDs = nil
+	store.state.supplies[supplyID] = stored
+	store.mu.Unlock()
+	if _, ok, err := domain.GetVerified[SupplyItem](store, domain.EntitySupplyItem, supplyID); !ok || err == nil {
+		t.Fatalf("expected verified read to report the supply item without projects, got ok=%v err=%v", ok, err)
+	}
+	if err := store.Verify(domain.EntitySupplyItem, supplyID); err == nil {
+		t.Fatalf("expected verify to report missing project_ids")
+	}
+}
@@ -86,6 +86,7 @@ func TestStoreDeleteGuards(t *testing.T) {
 		_, err = tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PERM-1",
 			Authority:         "Gov",
 			Status:            domain.PermitStatusApproved,
+			IssueDate:         validFrom,
 			ValidFrom:         validFrom,
 			ValidUntil:        validUntil,
 			AllowedActivities: []string{"store"},
@@ -347,6 +348,7 @@ func TestSnapshotRoundTripCoverage(t *testing.T) {
 		PermitNumber:      "PERMIT",
 		Authority:         "Gov",
 		Status:            domain.PermitStatusApproved,
+		IssueDate:         now,
 		ValidFrom:         now,
 		ValidUntil:        now.Add(time.Hour),
 		AllowedActivities: []string{"store"},
@@ -443,7 +445,7 @@ func TestMigrateSnapshotPrunesInvalidReferences(t *testing.T) {
 			procID: {Procedure: entitymodel.Procedure{ID: procID, Name: "Proc", ProtocolID: "protocol-keep", Status: domain.ProcedureStatusScheduled}},
 		},
 		Permits: map[string]Permit{
-			"permit-keep": {Permit: entitymodel.Permit{ID: "permit-keep", PermitNumber: "PN", Authority: "Auth", ValidFrom: now, ValidUntil: now.Add(time.Hour), AllowedActivities: []string{"store"}, FacilityIDs: []string{facilityID, "missing"}, ProtocolIDs: []string{"protocol-keep", "missing"}}},
+			"permit-keep": {Permit: entitymodel.Permit{ID: "permit-keep", PermitNumber: "PN", Authority: "Auth", IssueDate: now, ValidFrom: now, ValidUntil: now.Add(time.Hour), AllowedActivities: []string{"store"}, FacilityIDs: []string{facilityID, "missing"}, ProtocolIDs: []string{"protocol-keep", "missing"}}},
 		},
 		Projects: map[string]Project{
 			"project-keep": {Project: entitymodel.Project{ID: "project-keep", Code: "PRJ", Title: "Project", FacilityIDs: []string{facilityID, "missing"}}},
@@ -247,7 +247,9 @@ func TestMemoryImportStateLineStrainNormalization(t *testing.T) {
 		},
 	}
 
-	store.ImportState(snapshot)
+	if err := store.ImportState(snapshot); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 	exported := store.ExportState()
 
 	line, ok := exported.Lines[lineID]
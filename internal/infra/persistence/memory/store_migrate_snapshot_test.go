@@ -1,8 +1,12 @@
 package memory
 
 import (
-	entitymodel "colonycore/pkg/domain/entitymodel"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"testing"
+
+	entitymodel "colonycore/pkg/domain/entitymodel"
 )
 
 func TestMigrateSnapshotInitialisesAndFilters(t *testing.T) {
@@ -33,3 +37,64 @@ func TestMigrateSnapshotInitialisesAndFilters(t *testing.T) {
 		t.Fatalf("expected treatments with missing procedures to be dropped, got %d", len(migrated.Treatments))
 	}
 }
+
+func ambiguousOrphanSnapshot() Snapshot {
+	missingStrain := "strain-orphan-a"
+	missingProcedure := "procedure-missing"
+	return Snapshot{
+		Facilities: map[string]Facility{
+			"facility-1": {Facility: entitymodel.Facility{ID: "facility-1", Code: "FAC", Name: "Facility"}},
+		},
+		Strains: map[string]Strain{
+			"strain-orphan-a": {Strain: entitymodel.Strain{ID: "strain-orphan-a", Code: "A", LineID: "line-missing"}},
+			"strain-orphan-b": {Strain: entitymodel.Strain{ID: "strain-orphan-b", Code: "B", LineID: "line-missing"}},
+		},
+		Organisms: map[string]Organism{
+			"organism-1": {Organism: entitymodel.Organism{ID: "organism-1", Name: "Subject", Species: "species", Stage: entitymodel.LifecycleStageAdult, StrainID: &missingStrain}},
+		},
+		Housing: map[string]HousingUnit{
+			"housing-orphan": {HousingUnit: entitymodel.HousingUnit{ID: "housing-orphan", Name: "Orphan", FacilityID: "facility-missing", Capacity: 1}},
+			"housing-kept":   {HousingUnit: entitymodel.HousingUnit{ID: "housing-kept", Name: "Kept", FacilityID: "facility-1", Capacity: 0}},
+		},
+		Treatments: map[string]Treatment{
+			"treatment-orphan": {Treatment: entitymodel.Treatment{ID: "treatment-orphan", Name: "Dose", ProcedureID: missingProcedure}},
+		},
+		Observations: map[string]Observation{
+			"observation-orphan": {Observation: entitymodel.Observation{ID: "observation-orphan", Observer: "tech", ProcedureID: &missingProcedure}},
+			"observation-kept":   {Observation: entitymodel.Observation{ID: "observation-kept", Observer: "tech", OrganismID: ptr("organism-1")}},
+		},
+	}
+}
+
+func snapshotHash(t *testing.T, snapshot Snapshot) string {
+	t.Helper()
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("marshal snapshot: %v", err)
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestMigrateSnapshotOrphanDeletionIsDeterministic(t *testing.T) {
+	first := NewStore(nil)
+	if err := first.ImportState(ambiguousOrphanSnapshot()); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
+	second := NewStore(nil)
+	if err := second.ImportState(ambiguousOrphanSnapshot()); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
+
+	exported := first.ExportState()
+	if got, want := snapshotHash(t, exported), snapshotHash(t, second.ExportState()); got != want {
+		t.Fatalf("expected identical snapshots across imports, got %s and %s", got, want)
+	}
+	if len(exported.Strains) != 0 || len(exported.Treatments) != 0 || len(exported.Housing) != 1 || len(exported.Observations) != 1 {
+		t.Fatalf("unexpected surviving set: %d strains, %d treatments, %d housing, %d observations",
+			len(exported.Strains), len(exported.Treatments), len(exported.Housing), len(exported.Observations))
+	}
+	if organism := exported.Organisms["organism-1"]; organism.StrainID != nil {
+		t.Fatalf("expected dangling strain reference cleared, got %q", *organism.StrainID)
+	}
+}
@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestNaturalKeyLookups(t *testing.T) {
+	store := NewStore(nil)
+	var facilityID, protocolID, lineID, strainID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "VIV", Name: "Vivarium"}})
+		if err != nil {
+			return err
+		}
+		facilityID = facility.ID
+		if _, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{Code: "PRJ", Title: "Project", FacilityIDs: []string{facility.ID}}}); err != nil {
+			return err
+		}
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PR-1", Title: "Protocol", MaxSubjects: 5, Status: domain.ProtocolStatusApproved}})
+		if err != nil {
+			return err
+		}
+		protocolID = protocol.ID
+		marker, err := tx.CreateGenotypeMarker(domain.GenotypeMarker{GenotypeMarker: entitymodel.GenotypeMarker{Name: "Marker", Locus: "loc", Alleles: []string{"A"}, AssayMethod: "PCR", Interpretation: "ctrl", Version: "v1"}})
+		if err != nil {
+			return err
+		}
+		line, err := tx.CreateLine(domain.Line{Line: entitymodel.Line{Code: "L1", Name: "Line", Origin: "field", GenotypeMarkerIDs: []string{marker.ID}}})
+		if err != nil {
+			return err
+		}
+		lineID = line.ID
+		strain, err := tx.CreateStrain(domain.Strain{Strain: entitymodel.Strain{Code: "S1", Name: "Strain", LineID: line.ID}})
+		if err != nil {
+			return err
+		}
+		strainID = strain.ID
+		return nil
+	}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	facility, ok, err := store.GetFacilityByCode("VIV")
+	if err != nil || !ok || facility.ID != facilityID || len(facility.ProjectIDs) != 1 {
+		t.Fatalf("expected facility %s with its project, got %+v (%v)", facilityID, facility, ok)
+	}
+	if _, ok, err := store.GetFacilityByCode("missing"); ok || err != nil {
+		t.Fatalf("expected unknown facility code to miss")
+	}
+	protocol, ok, err := store.GetProtocolByCode("PR-1")
+	if err != nil || !ok || protocol.ID != protocolID {
+		t.Fatalf("expected protocol %s, got %+v (%v)", protocolID, protocol, ok)
+	}
+	if missing, ok, err := store.GetProtocolByCode("missing"); err != nil || ok || missing.ID != "" {
+		t.Fatalf("expected unknown protocol code to return the zero value, got %+v (%v)", missing, ok)
+	}
+	if line, ok, err := store.GetLineByCode("L1"); err != nil || !ok || line.ID != lineID {
+		t.Fatalf("expected line %s, got %+v (%v)", lineID, line, ok)
+	}
+	if strain, ok, err := store.GetStrainByCode(lineID, "S1"); err != nil || !ok || strain.ID != strainID {
+		t.Fatalf("expected strain %s, got %+v (%v)", strainID, strain, ok)
+	}
+	if _, ok, err := store.GetStrainByCode("other-line", "S1"); ok || err != nil {
+		t.Fatalf("expected strain code to be scoped to its line")
+	}
+}
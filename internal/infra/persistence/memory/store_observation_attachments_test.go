@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+type fakeAttachmentBlobs map[string]domain.BlobInfo
+
+func (f fakeAttachmentBlobs) Stat(key string) (domain.BlobInfo, error) {
+	info, ok := f[key]
+	if !ok {
+		return domain.BlobInfo{}, fmt.Errorf("blob %s not found", key)
+	}
+	return info, nil
+}
+
+func (f fakeAttachmentBlobs) Delete(key string) error {
+	delete(f, key)
+	return nil
+}
+
+func seedAttachmentObservation(t *testing.T, store *Store) string {
+	t.Helper()
+	var observationID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Subject", Species: "species", Stage: domain.StageAdult}})
+		if err != nil {
+			return err
+		}
+		observation, err := tx.CreateObservation(domain.Observation{Observation: entitymodel.Observation{
+			OrganismID: &organism.ID,
+			RecordedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			Observer:   "tech",
+		}})
+		observationID = observation.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed observation: %v", err)
+	}
+	return observationID
+}
+
+func attachObservationFile(store *Store, observationID string, ref domain.AttachmentRef) (domain.Observation, error) {
+	var attached domain.Observation
+	_, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		var err error
+		attached, err = tx.AttachObservationFile(observationID, ref)
+		return err
+	})
+	return attached, err
+}
+
+func TestAttachObservationFileLinksExistingBlob(t *testing.T) {
+	blobs := fakeAttachmentBlobs{"obs/photo.png": {Size: 2048, ContentType: "image/png"}}
+	store := NewStore(nil, WithAttachmentBlobs(blobs))
+	observationID := seedAttachmentObservation(t, store)
+
+	attached, err := attachObservationFile(store, observationID, domain.AttachmentRef{Key: "obs/photo.png"})
+	if err != nil {
+		t.Fatalf("attach existing blob: %v", err)
+	}
+	want := domain.AttachmentRef{Key: "obs/photo.png", ContentType: "image/png", SizeBytes: 2048}
+	if len(attached.Attachments) != 1 || attached.Attachments[0] != want {
+		t.Fatalf("expected attachment filled from blob stat, got %+v", attached.Attachments)
+	}
+	if attached.UpdatedAt.IsZero() {
+		t.Fatalf("expected UpdatedAt to be set on attach")
+	}
+	stored := store.ListObservations()[0]
+	if len(stored.Attachments) != 1 || stored.Attachments[0] != want {
+		t.Fatalf("expected attachment persisted, got %+v", stored.Attachments)
+	}
+
+	if _, err := attachObservationFile(store, observationID, want); err == nil || !strings.Contains(err.Error(), "already has attachment") {
+		t.Fatalf("expected duplicate attachment rejection, got %v", err)
+	}
+	if _, err := attachObservationFile(store, observationID, domain.AttachmentRef{Key: "obs/photo.png", SizeBytes: 10}); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("expected size mismatch rejection, got %v", err)
+	}
+}
+
+func TestAttachObservationFileRejectsMissingBlob(t *testing.T) {
+	store := NewStore(nil, WithAttachmentBlobs(fakeAttachmentBlobs{}))
+	observationID := seedAttachmentObservation(t, store)
+
+	if _, err := attachObservationFile(store, observationID, domain.AttachmentRef{Key: "obs/missing.raw", ContentType: "application/octet-stream"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected missing blob rejection, got %v", err)
+	}
+	if stored := store.ListObservations()[0]; len(stored.Attachments) != 0 {
+		t.Fatalf("expected no attachment after rejection, got %+v", stored.Attachments)
+	}
+	if _, err := attachObservationFile(store, "missing", domain.AttachmentRef{Key: "obs/missing.raw"}); err == nil {
+		t.Fatalf("expected unknown observation error")
+	}
+
+	unconfigured := NewStore(nil)
+	observationID = seedAttachmentObservation(t, unconfigured)
+	if _, err := attachObservationFile(unconfigured, observationID, domain.AttachmentRef{Key: "obs/photo.png"}); !errors.Is(err, domain.ErrAttachmentBlobsUnavailable) {
+		t.Fatalf("expected ErrAttachmentBlobsUnavailable, got %v", err)
+	}
+}
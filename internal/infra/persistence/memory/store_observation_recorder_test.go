@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestObservationObserverAndRecordedByStayInSync(t *testing.T) {
+	store := NewStore(nil)
+	ctx := context.Background()
+
+	var observationID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Subject", Species: "species", Stage: domain.StageAdult}})
+		if err != nil {
+			return err
+		}
+		mismatched := "other"
+		if _, err := tx.CreateObservation(domain.Observation{Observation: entitymodel.Observation{
+			OrganismID: &organism.ID, RecordedAt: time.Now().UTC(), Observer: "tech", RecordedBy: &mismatched,
+		}}); err == nil || !strings.Contains(err.Error(), "disagree") {
+			t.Fatalf("expected mismatched observer and recorded_by to be rejected, got %v", err)
+		}
+		observation, err := tx.CreateObservation(domain.Observation{Observation: entitymodel.Observation{
+			OrganismID: &organism.ID, RecordedAt: time.Now().UTC(), Observer: "tech",
+		}})
+		observationID = observation.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed observation: %v", err)
+	}
+
+	update := func(mutate func(*domain.Observation)) (domain.Observation, error) {
+		var updated domain.Observation
+		_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+			var err error
+			updated, err = tx.UpdateObservation(observationID, func(o *domain.Observation) error {
+				mutate(o)
+				return nil
+			})
+			return err
+		})
+		return updated, err
+	}
+	expectRecorder := func(got domain.Observation, want string) {
+		t.Helper()
+		if got.Observer != want || got.RecordedBy == nil || *got.RecordedBy != want {
+			t.Fatalf("expected observer and recorded_by %q, got %q and %v", want, got.Observer, got.RecordedBy)
+		}
+	}
+
+	updated, err := update(func(o *domain.Observation) { o.Observer = "vet" })
+	if err != nil {
+		t.Fatalf("update observer: %v", err)
+	}
+	expectRecorder(updated, "vet")
+
+	updated, err = update(func(o *domain.Observation) {
+		recordedBy := "lead"
+		o.RecordedBy = &recordedBy
+	})
+	if err != nil {
+		t.Fatalf("update recorded_by: %v", err)
+	}
+	expectRecorder(updated, "lead")
+
+	if _, err := update(func(o *domain.Observation) {
+		recordedBy := "x"
+		o.Observer = "y"
+		o.RecordedBy = &recordedBy
+	}); err == nil || !strings.Contains(err.Error(), "disagree") {
+		t.Fatalf("expected conflicting update to be rejected, got %v", err)
+	}
+	if err := store.View(ctx, func(view domain.TransactionView) error {
+		stored, _ := view.FindObservation(observationID)
+		expectRecorder(stored, "lead")
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+}
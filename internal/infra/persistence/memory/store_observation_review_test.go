@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReviewObservationWorkflow(t *testing.T) {
+	store := NewStore(nil)
+	ctx := context.Background()
+	reviewedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	var observationID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{
+			Name:    "Subject",
+			Species: "species",
+			Stage:   domain.StageAdult,
+		}})
+		if err != nil {
+			return err
+		}
+		observation, err := tx.CreateObservation(domain.Observation{Observation: entitymodel.Observation{
+			OrganismID: &organism.ID,
+			RecordedAt: reviewedAt.Add(-time.Hour),
+			Observer:   "tech",
+		}})
+		if err != nil {
+			return err
+		}
+		if observation.RecordedBy == nil || *observation.RecordedBy != "tech" {
+			t.Fatalf("expected recorded_by to mirror observer, got %+v", observation.RecordedBy)
+		}
+		observationID = observation.ID
+		return nil
+	}); err != nil {
+		t.Fatalf("seed observation: %v", err)
+	}
+
+	var firstUpdatedAt time.Time
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		reviewed, err := tx.ReviewObservation(observationID, "lead", reviewedAt)
+		if err != nil {
+			return err
+		}
+		if reviewed.ReviewedBy == nil || *reviewed.ReviewedBy != "lead" {
+			t.Fatalf("expected reviewer lead, got %+v", reviewed.ReviewedBy)
+		}
+		if reviewed.ReviewedAt == nil || !reviewed.ReviewedAt.Equal(reviewedAt) {
+			t.Fatalf("expected reviewed_at %v, got %+v", reviewedAt, reviewed.ReviewedAt)
+		}
+		firstUpdatedAt = reviewed.UpdatedAt
+		return nil
+	}); err != nil {
+		t.Fatalf("initial review: %v", err)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		again, err := tx.ReviewObservation(observationID, "lead", reviewedAt)
+		if err != nil {
+			return err
+		}
+		if !again.UpdatedAt.Equal(firstUpdatedAt) {
+			t.Fatalf("expected idempotent review to leave updated_at untouched")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("expected identical re-review to be idempotent: %v", err)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.ReviewObservation(observationID, "other", reviewedAt)
+		return err
+	}); err == nil || !strings.Contains(err.Error(), "already reviewed") {
+		t.Fatalf("expected re-review by a different reviewer to fail, got %v", err)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.ReviewObservation(observationID, "  ", reviewedAt)
+		return err
+	}); err == nil || !strings.Contains(err.Error(), "requires reviewer") {
+		t.Fatalf("expected missing reviewer to fail, got %v", err)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.ReviewObservation(observationID, "lead", time.Time{})
+		return err
+	}); err == nil {
+		t.Fatalf("expected zero review time to fail")
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.ReviewObservation("missing", "lead", reviewedAt)
+		return err
+	}); err == nil {
+		t.Fatalf("expected missing observation to fail")
+	}
+}
@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestMoveOrganismToProject(t *testing.T) {
+	store := NewStore(nil)
+	ctx := context.Background()
+
+	ids := map[string]string{}
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		labA, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Name: "Lab A"}})
+		if err != nil {
+			return err
+		}
+		labB, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Name: "Lab B"}})
+		if err != nil {
+			return err
+		}
+		housing, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: labA.ID, Capacity: 4}})
+		if err != nil {
+			return err
+		}
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PROT", Title: "Protocol", MaxSubjects: 5}})
+		if err != nil {
+			return err
+		}
+		projects := map[string]entitymodel.Project{
+			"source":    {Code: "SRC", Title: "Source", FacilityIDs: []string{labA.ID}, ProtocolIDs: []string{protocol.ID}},
+			"target":    {Code: "TGT", Title: "Target", FacilityIDs: []string{labA.ID, labB.ID}, ProtocolIDs: []string{protocol.ID}},
+			"remote":    {Code: "REM", Title: "Remote", FacilityIDs: []string{labB.ID}, ProtocolIDs: []string{protocol.ID}},
+			"unrelated": {Code: "UNR", Title: "Unrelated", FacilityIDs: []string{labA.ID}},
+		}
+		for name, project := range projects {
+			created, err := tx.CreateProject(domain.Project{Project: project})
+			if err != nil {
+				return err
+			}
+			ids[name] = created.ID
+		}
+		source := ids["source"]
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{
+			Name:       "Frog",
+			Species:    "Xenopus",
+			HousingID:  &housing.ID,
+			ProtocolID: &protocol.ID,
+			ProjectID:  &source,
+		}})
+		ids["organism"] = organism.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed store: %v", err)
+	}
+
+	move := func(projectID string) (domain.Organism, error) {
+		var moved domain.Organism
+		_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+			var err error
+			moved, err = tx.MoveOrganismToProject(ids["organism"], projectID)
+			return err
+		})
+		return moved, err
+	}
+
+	if _, err := move("missing-project"); err == nil || !strings.Contains(err.Error(), `project "missing-project" not found`) {
+		t.Fatalf("expected missing project error, got %v", err)
+	}
+	if _, err := move(ids["remote"]); err == nil || !strings.Contains(err.Error(), "does not include") {
+		t.Fatalf("expected facility incompatibility error, got %v", err)
+	}
+	if _, err := move(ids["unrelated"]); err == nil || !strings.Contains(err.Error(), "does not permit") {
+		t.Fatalf("expected protocol incompatibility error, got %v", err)
+	}
+
+	moved, err := move(ids["target"])
+	if err != nil {
+		t.Fatalf("move organism: %v", err)
+	}
+	if moved.ProjectID == nil || *moved.ProjectID != ids["target"] {
+		t.Fatalf("expected organism in target project, got %v", moved.ProjectID)
+	}
+	for _, project := range store.ListProjects() {
+		holds := containsString(project.OrganismIDs, ids["organism"])
+		if holds != (project.ID == ids["target"]) {
+			t.Fatalf("project %s organism ids %v do not reflect the move", project.Code, project.OrganismIDs)
+		}
+	}
+}
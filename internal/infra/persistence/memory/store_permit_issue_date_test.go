@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreatePermitValidatesIssueDate(t *testing.T) {
+	store := NewStore(nil)
+	ctx := context.Background()
+	validFrom := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	var facilityID, protocolID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Name: "Lab"}})
+		if err != nil {
+			return err
+		}
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PROT", Title: "Protocol", MaxSubjects: 5}})
+		if err != nil {
+			return err
+		}
+		facilityID, protocolID = facility.ID, protocol.ID
+		return nil
+	}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	createPermit := func(number string, issued time.Time) (domain.Permit, error) {
+		var created domain.Permit
+		_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+			var err error
+			created, err = tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{
+				PermitNumber:      number,
+				Authority:         "Gov",
+				IssueDate:         issued,
+				ValidFrom:         validFrom,
+				ValidUntil:        validFrom.AddDate(1, 0, 0),
+				AllowedActivities: []string{"store"},
+				FacilityIDs:       []string{facilityID},
+				ProtocolIDs:       []string{protocolID},
+			}})
+			return err
+		})
+		return created, err
+	}
+
+	if _, err := createPermit("PERMIT-SAME", validFrom); err != nil {
+		t.Fatalf("expected issue date equal to valid_from to pass: %v", err)
+	}
+	issued := validFrom.AddDate(0, 0, -14)
+	earlier, err := createPermit("PERMIT-EARLY", issued)
+	if err != nil {
+		t.Fatalf("expected issue date before valid_from to pass: %v", err)
+	}
+	if _, err := createPermit("PERMIT-LATE", validFrom.Add(time.Hour)); err == nil || !strings.Contains(err.Error(), "issue_date") {
+		t.Fatalf("expected issue date after valid_from to fail, got %v", err)
+	}
+	if _, err := createPermit("PERMIT-UNDATED", time.Time{}); err == nil || !strings.Contains(err.Error(), "requires issue_date") {
+		t.Fatalf("expected missing issue date to fail, got %v", err)
+	}
+
+	if err := store.View(ctx, func(view domain.TransactionView) error {
+		stored, ok := view.FindPermit(earlier.ID)
+		if !ok {
+			t.Fatalf("expected permit %s to exist", earlier.ID)
+		}
+		if !stored.IssueDate.Equal(issued) {
+			t.Fatalf("expected issue date %s, got %s", issued, stored.IssueDate)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+	restored := NewStore(nil)
+	if err := restored.ImportState(store.ExportState()); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
+	if err := restored.View(ctx, func(view domain.TransactionView) error {
+		stored, _ := view.FindPermit(earlier.ID)
+		if !stored.IssueDate.Equal(issued) {
+			t.Fatalf("expected issue date to survive export/import, got %s", stored.IssueDate)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view restored: %v", err)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdatePermit(earlier.ID, func(p *domain.Permit) error {
+			p.IssueDate = validFrom.AddDate(0, 0, 1)
+			return nil
+		})
+		return err
+	}); err == nil {
+		t.Fatalf("expected update moving issue date past valid_from to fail")
+	}
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdatePermit(earlier.ID, func(p *domain.Permit) error {
+			p.IssueDate = time.Time{}
+			return nil
+		})
+		return err
+	}); err == nil || !strings.Contains(err.Error(), "requires issue_date") {
+		t.Fatalf("expected update clearing the issue date to fail, got %v", err)
+	}
+}
+
+func TestUpdatePermitKeepsLegacyPermitsEditable(t *testing.T) {
+	ctx := context.Background()
+	validFrom := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	store := NewStore(nil)
+	if err := store.ImportState(Snapshot{
+		Facilities: map[string]Facility{"facility-1": {Facility: entitymodel.Facility{ID: "facility-1", Code: "LAB", Name: "Lab"}}},
+		Protocols:  map[string]Protocol{"protocol-1": {Protocol: entitymodel.Protocol{ID: "protocol-1", Code: "PROT", Title: "Protocol", MaxSubjects: 5, Status: domain.ProtocolStatusDraft}}},
+		Permits: map[string]Permit{"permit-legacy": {Permit: entitymodel.Permit{
+			ID:                "permit-legacy",
+			PermitNumber:      "LEGACY",
+			Authority:         "Gov",
+			Status:            domain.PermitStatusDraft,
+			ValidFrom:         validFrom,
+			ValidUntil:        validFrom.AddDate(1, 0, 0),
+			AllowedActivities: []string{"store"},
+			FacilityIDs:       []string{"facility-1"},
+			ProtocolIDs:       []string{"protocol-1"},
+		}}},
+	}); err != nil {
+		t.Fatalf("import legacy permit: %v", err)
+	}
+	update := func(mutator func(*domain.Permit)) (domain.Permit, error) {
+		var updated domain.Permit
+		_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+			var err error
+			updated, err = tx.UpdatePermit("permit-legacy", func(p *domain.Permit) error {
+				mutator(p)
+				return nil
+			})
+			return err
+		})
+		return updated, err
+	}
+
+	if _, err := update(func(p *domain.Permit) { p.AllowedActivities = []string{"store", "transport"} }); err != nil {
+		t.Fatalf("expected legacy permit without issue date to stay editable: %v", err)
+	}
+	if _, err := update(func(p *domain.Permit) { p.IssueDate = validFrom.AddDate(0, 0, 1) }); err == nil || !strings.Contains(err.Error(), "issue_date") {
+		t.Fatalf("expected an issue date after valid_from to fail, got %v", err)
+	}
+	issued := validFrom.AddDate(0, 0, -7)
+	if updated, err := update(func(p *domain.Permit) { p.IssueDate = issued }); err != nil || !updated.IssueDate.Equal(issued) {
+		t.Fatalf("expected update to record the issue date, got %+v %v", updated.IssueDate, err)
+	}
+	if _, err := update(func(p *domain.Permit) { p.IssueDate = time.Time{} }); err == nil || !strings.Contains(err.Error(), "requires issue_date") {
+		t.Fatalf("expected clearing a recorded issue date to fail, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+)
+
+type permitSkewRecorder struct {
+	skew *time.Duration
+}
+
+func (permitSkewRecorder) Name() string { return "permit-skew-recorder" }
+
+func (r permitSkewRecorder) Evaluate(_ context.Context, view domain.RuleView, _ []domain.Change) (domain.Result, error) {
+	*r.skew = domain.PermitSkew(view)
+	return domain.Result{}, nil
+}
+
+func TestWithPermitSkewReachesRuleAndTransactionViews(t *testing.T) {
+	var seen time.Duration
+	engine := domain.NewRulesEngine()
+	engine.Register(permitSkewRecorder{skew: &seen})
+	store := NewStore(engine, WithPermitSkew(2*time.Minute))
+
+	if _, err := store.RunInTransaction(context.Background(), func(Transaction) error { return nil }); err != nil {
+		t.Fatalf("run transaction: %v", err)
+	}
+	if seen != 2*time.Minute {
+		t.Fatalf("expected rules to see the configured skew, got %s", seen)
+	}
+	if err := store.View(context.Background(), func(view TransactionView) error {
+		seen = domain.PermitSkew(view)
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+	if seen != 2*time.Minute {
+		t.Fatalf("expected views to report the configured skew, got %s", seen)
+	}
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		seen = domain.PermitSkew(tx.Snapshot())
+		return nil
+	}); err != nil {
+		t.Fatalf("run transaction: %v", err)
+	}
+	if seen != 2*time.Minute {
+		t.Fatalf("expected transaction snapshots to report the configured skew, got %s", seen)
+	}
+
+	if _, err := NewStore(engine, WithPermitSkew(-time.Minute)).RunInTransaction(context.Background(), func(Transaction) error { return nil }); err != nil {
+		t.Fatalf("run transaction: %v", err)
+	}
+	if seen != 0 {
+		t.Fatalf("expected negative skew to be treated as zero, got %s", seen)
+	}
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPermitRejectsInvertedValidityWindow(t *testing.T) {
+	store := NewStore(nil)
+	ctx := context.Background()
+	validFrom := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	var permitID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Name: "Lab"}})
+		if err != nil {
+			return err
+		}
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PROT", Title: "Protocol", MaxSubjects: 5}})
+		if err != nil {
+			return err
+		}
+		permit := domain.Permit{Permit: entitymodel.Permit{
+			PermitNumber:      "PERMIT-INVERTED",
+			Authority:         "Gov",
+			IssueDate:         validFrom,
+			ValidFrom:         validFrom,
+			ValidUntil:        validFrom.AddDate(0, 0, -1),
+			AllowedActivities: []string{"store"},
+			FacilityIDs:       []string{facility.ID},
+			ProtocolIDs:       []string{protocol.ID},
+		}}
+		if _, err := tx.CreatePermit(permit); err == nil || !strings.Contains(err.Error(), "valid_until") {
+			t.Fatalf("expected inverted validity window to fail, got %v", err)
+		}
+		permit.PermitNumber = "PERMIT-SAME-DAY"
+		permit.ValidUntil = validFrom
+		created, err := tx.CreatePermit(permit)
+		if err != nil {
+			t.Fatalf("expected valid_until equal to valid_from to pass: %v", err)
+		}
+		permitID = created.ID
+		return nil
+	}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdatePermit(permitID, func(p *domain.Permit) error {
+			p.ValidUntil = validFrom.Add(-time.Hour)
+			return nil
+		})
+		return err
+	}); err == nil || !strings.Contains(err.Error(), "valid_until") {
+		t.Fatalf("expected update with inverted validity window to fail, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestProjectProtocolLinks(t *testing.T) {
+	store := NewStore(nil)
+	ctx := context.Background()
+
+	var facilityID, protocolID, projectID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Name: "Lab"}})
+		if err != nil {
+			return err
+		}
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PROT", Title: "Protocol", MaxSubjects: 5}})
+		if err != nil {
+			return err
+		}
+		project, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{
+			Code:        "PRJ",
+			Title:       "Project",
+			FacilityIDs: []string{facility.ID},
+			ProtocolIDs: []string{protocol.ID, protocol.ID},
+		}})
+		facilityID, protocolID, projectID = facility.ID, protocol.ID, project.ID
+		return err
+	}); err != nil {
+		t.Fatalf("create project with protocol: %v", err)
+	}
+	projects := store.ListProjects()
+	if len(projects) != 1 || len(projects[0].ProtocolIDs) != 1 || projects[0].ProtocolIDs[0] != protocolID {
+		t.Fatalf("expected deduplicated protocol link, got %+v", projects)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{
+			Code:        "PRJ-BAD",
+			Title:       "Bad",
+			FacilityIDs: []string{facilityID},
+			ProtocolIDs: []string{"missing-protocol"},
+		}})
+		return err
+	}); err == nil || !strings.Contains(err.Error(), `protocol "missing-protocol" not found for project`) {
+		t.Fatalf("expected unknown protocol to be rejected on create, got %v", err)
+	}
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdateProject(projectID, func(p *domain.Project) error {
+			p.ProtocolIDs = append(p.ProtocolIDs, "missing-protocol")
+			return nil
+		})
+		return err
+	}); err == nil || !strings.Contains(err.Error(), "missing-protocol") {
+		t.Fatalf("expected unknown protocol to be rejected on update, got %v", err)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		return tx.DeleteProtocol(protocolID)
+	}); err == nil || !strings.Contains(err.Error(), "still referenced by project") {
+		t.Fatalf("expected protocol delete to be blocked by project, got %v", err)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		if _, err := tx.UpdateProject(projectID, func(p *domain.Project) error {
+			p.ProtocolIDs = nil
+			return nil
+		}); err != nil {
+			return err
+		}
+		return tx.DeleteProtocol(protocolID)
+	}); err != nil {
+		t.Fatalf("expected protocol delete after unlinking to succeed: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"testing"
+)
+
+func TestAddProtocolReviewerIsIdempotent(t *testing.T) {
+	store := NewStore(nil)
+	ctx := context.Background()
+
+	var protocolID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PROT", Title: "Protocol", MaxSubjects: 5}})
+		protocolID = protocol.ID
+		return err
+	}); err != nil {
+		t.Fatalf("create protocol: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+			_, err := tx.AddProtocolReviewer(protocolID, " reviewer-a ")
+			return err
+		}); err != nil {
+			t.Fatalf("add reviewer (attempt %d): %v", i+1, err)
+		}
+	}
+	protocols := store.ListProtocols()
+	if len(protocols) != 1 || len(protocols[0].ReviewerIDs) != 1 || protocols[0].ReviewerIDs[0] != "reviewer-a" {
+		t.Fatalf("expected single reviewer after duplicate addition, got %+v", protocols)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.AddProtocolReviewer(protocolID, "  ")
+		return err
+	}); err == nil {
+		t.Fatalf("expected blank reviewer id to be rejected")
+	}
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.AddProtocolReviewer("missing", "reviewer-a")
+		return err
+	}); err == nil {
+		t.Fatalf("expected unknown protocol error")
+	}
+}
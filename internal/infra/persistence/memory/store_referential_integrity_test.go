@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestTransactionReferentialIntegrityErrors(t *testing.T) {
+	now := time.Now().UTC()
+	cases := []struct {
+		name string
+		run  func(tx domain.Transaction) error
+		want domain.ErrReferentialIntegrity
+	}{
+		{
+			name: "housing unit missing facility",
+			run: func(tx domain.Transaction) error {
+				_, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{
+					Name: "Rack", FacilityID: "missing-facility", Capacity: 2,
+				}})
+				return err
+			},
+			want: domain.ErrReferentialIntegrity{Entity: domain.EntityHousingUnit, Field: "facility_id", ReferencedEntity: domain.EntityFacility, ReferencedID: "missing-facility"},
+		},
+		{
+			name: "treatment missing procedure",
+			run: func(tx domain.Transaction) error {
+				_, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{
+					Name: "Dose", ProcedureID: "missing-procedure",
+				}})
+				return err
+			},
+			want: domain.ErrReferentialIntegrity{Entity: domain.EntityTreatment, Field: "procedure_id", ReferencedEntity: domain.EntityProcedure, ReferencedID: "missing-procedure"},
+		},
+		{
+			name: "sample missing facility",
+			run: func(tx domain.Transaction) error {
+				_, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{
+					Identifier:      "S1",
+					SourceType:      "blood",
+					FacilityID:      "missing-facility",
+					CollectedAt:     now,
+					CollectedBy:     "tech",
+					StorageLocation: "freezer",
+					AssayType:       "PCR",
+					ChainOfCustody:  []domain.SampleCustodyEvent{{Actor: "tech", Location: "bench", Timestamp: now}},
+				}})
+				return err
+			},
+			want: domain.ErrReferentialIntegrity{Entity: domain.EntitySample, Field: "facility_id", ReferencedEntity: domain.EntityFacility, ReferencedID: "missing-facility"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewStore(nil)
+			_, err := store.RunInTransaction(context.Background(), tc.run)
+			var ri domain.ErrReferentialIntegrity
+			if !errors.As(err, &ri) {
+				t.Fatalf("expected ErrReferentialIntegrity, got %v", err)
+			}
+			if ri != tc.want {
+				t.Fatalf("unexpected integrity error %+v, want %+v", ri, tc.want)
+			}
+		})
+	}
+}
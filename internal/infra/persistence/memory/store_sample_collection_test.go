@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestSampleCollectedByValidation(t *testing.T) {
+	store := NewStore(nil)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	protocol := "SOP-BLOOD-7"
+
+	var sampleID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Name: "Lab"}})
+		if err != nil {
+			return err
+		}
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus"}})
+		if err != nil {
+			return err
+		}
+		sample := domain.Sample{Sample: entitymodel.Sample{
+			Identifier:      "S1",
+			SourceType:      "blood",
+			FacilityID:      facility.ID,
+			OrganismID:      &organism.ID,
+			CollectedAt:     now,
+			StorageLocation: "freezer",
+			AssayType:       "PCR",
+			ChainOfCustody:  []domain.SampleCustodyEvent{{Actor: "tech", Location: "bench", Timestamp: now}},
+		}}
+
+		blank := sample
+		blank.CollectedBy = "  "
+		if _, err := tx.CreateSample(blank); err == nil || !strings.Contains(err.Error(), "collected by") {
+			t.Fatalf("expected blank collected_by to be rejected, got %v", err)
+		}
+
+		sample.CollectedBy = "tech"
+		sample.CollectionProtocol = &protocol
+		created, err := tx.CreateSample(sample)
+		sampleID = created.ID
+		return err
+	}); err != nil {
+		t.Fatalf("create sample with collector: %v", err)
+	}
+
+	getSample := func() domain.Sample {
+		for _, sample := range store.ListSamples() {
+			if sample.ID == sampleID {
+				return sample
+			}
+		}
+		t.Fatalf("sample %q not found", sampleID)
+		return domain.Sample{}
+	}
+	got := getSample()
+	if got.CollectedBy != "tech" || got.CollectionProtocol == nil || *got.CollectionProtocol != protocol {
+		t.Fatalf("expected collection provenance to persist, got %+v", got)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdateSample(sampleID, func(s *domain.Sample) error {
+			s.CollectedBy = ""
+			return nil
+		})
+		return err
+	}); err == nil || !strings.Contains(err.Error(), "collected by") {
+		t.Fatalf("expected clearing collected_by to be rejected, got %v", err)
+	}
+	if got := getSample(); got.CollectedBy != "tech" {
+		t.Fatalf("expected rejected update to leave collected_by intact, got %q", got.CollectedBy)
+	}
+}
+
+func TestLegacySampleWithoutCollectorStaysEditable(t *testing.T) {
+	store := NewStore(nil)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	var sampleID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Name: "Lab"}})
+		if err != nil {
+			return err
+		}
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus"}})
+		if err != nil {
+			return err
+		}
+		created, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{
+			Identifier:      "S1",
+			SourceType:      "blood",
+			FacilityID:      facility.ID,
+			OrganismID:      &organism.ID,
+			CollectedAt:     now,
+			StorageLocation: "freezer",
+			AssayType:       "PCR",
+			CollectedBy:     "tech",
+			ChainOfCustody:  []domain.SampleCustodyEvent{{Actor: "tech", Location: "bench", Timestamp: now}},
+		}})
+		sampleID = created.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed sample: %v", err)
+	}
+	snapshot := store.ExportState()
+	legacy := snapshot.Samples[sampleID]
+	legacy.CollectedBy = ""
+	snapshot.Samples[sampleID] = legacy
+	if err := store.ImportState(snapshot); err != nil {
+		t.Fatalf("import legacy sample: %v", err)
+	}
+
+	update := func(mutate func(*domain.Sample)) error {
+		_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+			_, err := tx.UpdateSample(sampleID, func(s *domain.Sample) error {
+				mutate(s)
+				return nil
+			})
+			return err
+		})
+		return err
+	}
+	if err := update(func(s *domain.Sample) { s.StorageLocation = "shelf" }); err != nil {
+		t.Fatalf("expected legacy sample without collector to stay editable, got %v", err)
+	}
+	if err := update(func(s *domain.Sample) { s.ChainOfCustody = nil }); err == nil || !strings.Contains(err.Error(), "chain of custody") {
+		t.Fatalf("expected legacy sample to still require chain of custody, got %v", err)
+	}
+	if err := update(func(s *domain.Sample) { s.CollectedBy = "backfill" }); err != nil {
+		t.Fatalf("backfill collector: %v", err)
+	}
+	if err := update(func(s *domain.Sample) { s.CollectedBy = " " }); err == nil || !strings.Contains(err.Error(), "collected by") {
+		t.Fatalf("expected backfilled collector to be required, got %v", err)
+	}
+}
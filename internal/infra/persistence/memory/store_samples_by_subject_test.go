@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestListSamplesByFacilityAndOrganism(t *testing.T) {
+	store := NewStore(nil)
+	now := time.Now().UTC()
+	ids := func(samples []domain.Sample) []string {
+		out := make([]string, 0, len(samples))
+		for _, sample := range samples {
+			out = append(out, sample.ID)
+		}
+		return out
+	}
+
+	var facilityID, otherFacilityID, organismID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "FAC", Name: "Facility", Zone: "Z", AccessPolicy: "open"}})
+		if err != nil {
+			return err
+		}
+		other, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "OTH", Name: "Other", Zone: "Z", AccessPolicy: "open"}})
+		if err != nil {
+			return err
+		}
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Subject", Species: "species", Stage: domain.StageAdult}})
+		if err != nil {
+			return err
+		}
+		cohort, err := tx.CreateCohort(domain.Cohort{Cohort: entitymodel.Cohort{Name: "Cohort", Purpose: "study"}})
+		if err != nil {
+			return err
+		}
+		facilityID, otherFacilityID, organismID = facility.ID, other.ID, organism.ID
+		newSample := func(id, facilityID string, organismID, cohortID *string) domain.Sample {
+			return domain.Sample{Sample: entitymodel.Sample{ID: id, Identifier: id, SourceType: "blood", FacilityID: facilityID,
+				OrganismID: organismID, CohortID: cohortID, CollectedAt: now, CollectedBy: "tech", Status: domain.SampleStatusStored, StorageLocation: "loc",
+				ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "loc", Timestamp: now}}}}
+		}
+		for _, sample := range []domain.Sample{
+			newSample("s-organism", facility.ID, &organism.ID, nil),
+			newSample("s-cohort", facility.ID, nil, &cohort.ID),
+			newSample("s-elsewhere", other.ID, &organism.ID, nil),
+		} {
+			if _, err := tx.CreateSample(sample); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("seed samples: %v", err)
+	}
+
+	if err := store.View(context.Background(), func(view domain.TransactionView) error {
+		if got := ids(view.ListSamplesByFacility(facilityID)); len(got) != 2 || got[0] != "s-cohort" || got[1] != "s-organism" {
+			t.Fatalf("expected facility samples including cohort-linked sample, got %v", got)
+		}
+		if got := ids(view.ListSamplesByFacility(otherFacilityID)); len(got) != 1 || got[0] != "s-elsewhere" {
+			t.Fatalf("expected single sample at other facility, got %v", got)
+		}
+		if got := ids(view.ListSamplesByOrganism(organismID)); len(got) != 2 || got[0] != "s-elsewhere" || got[1] != "s-organism" {
+			t.Fatalf("expected organism samples without cohort-only sample, got %v", got)
+		}
+		if got := view.ListSamplesByOrganism("missing"); len(got) != 0 {
+			t.Fatalf("expected no samples for unknown organism, got %v", ids(got))
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+type scopedSampleFixture struct {
+	facilityA, facilityB, organism string
+}
+
+func seedScopedSampleFixture(t *testing.T, store *Store) scopedSampleFixture {
+	t.Helper()
+	var fx scopedSampleFixture
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		a, err := tx.CreateFacility(Facility{Facility: entitymodel.Facility{Code: "FAC-A", Name: "Facility A"}})
+		if err != nil {
+			return err
+		}
+		b, err := tx.CreateFacility(Facility{Facility: entitymodel.Facility{Code: "FAC-B", Name: "Facility B"}})
+		if err != nil {
+			return err
+		}
+		organism, err := tx.CreateOrganism(Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus"}})
+		fx = scopedSampleFixture{facilityA: a.ID, facilityB: b.ID, organism: organism.ID}
+		return err
+	}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	return fx
+}
+
+func scopedSample(identifier, facilityID, organismID string) Sample {
+	return Sample{Sample: entitymodel.Sample{Identifier: identifier, SourceType: "blood", FacilityID: facilityID, OrganismID: &organismID,
+		CollectedAt: time.Now().UTC(), Status: domain.SampleStatusStored, StorageLocation: "freezer", AssayType: "PCR", CollectedBy: "tech",
+		ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "bench", Timestamp: time.Now().UTC()}}}}
+}
+
+func createScopedSample(store *Store, sample Sample) (Sample, error) {
+	var created Sample
+	_, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		var err error
+		created, err = tx.CreateSample(sample)
+		return err
+	})
+	return created, err
+}
+
+func TestSampleIdentifierUniqueWithinFacility(t *testing.T) {
+	store := NewStore(nil)
+	fx := seedScopedSampleFixture(t, store)
+
+	first, err := createScopedSample(store, scopedSample("S-1", fx.facilityA, fx.organism))
+	if err != nil {
+		t.Fatalf("create first sample: %v", err)
+	}
+	if _, err := createScopedSample(store, scopedSample("S-1", fx.facilityB, fx.organism)); err != nil {
+		t.Fatalf("expected shared identifier in another facility to be allowed: %v", err)
+	}
+	_, err = createScopedSample(store, scopedSample("S-1", fx.facilityA, fx.organism))
+	want := `sample identifier "S-1" already used by sample "` + first.ID + `" in facility "` + fx.facilityA + `"`
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected same-facility duplicate to be blocked with %q, got %v", want, err)
+	}
+	if got := len(store.ListSamples()); got != 2 {
+		t.Fatalf("expected two stored samples, got %d", got)
+	}
+}
+
+func TestUpdateSampleIntoConflictingFacilityBlocked(t *testing.T) {
+	store := NewStore(nil)
+	fx := seedScopedSampleFixture(t, store)
+	if _, err := createScopedSample(store, scopedSample("S-1", fx.facilityA, fx.organism)); err != nil {
+		t.Fatalf("create sample: %v", err)
+	}
+	moved, err := createScopedSample(store, scopedSample("S-1", fx.facilityB, fx.organism))
+	if err != nil {
+		t.Fatalf("create sample: %v", err)
+	}
+
+	_, err = store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		if _, err := tx.UpdateSample(moved.ID, func(s *Sample) error {
+			s.StorageLocation = "rack 2"
+			return nil
+		}); err != nil {
+			return err
+		}
+		_, err := tx.UpdateSample(moved.ID, func(s *Sample) error {
+			s.FacilityID = fx.facilityA
+			return nil
+		})
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), "already used by sample") {
+		t.Fatalf("expected move into conflicting facility to be blocked, got %v", err)
+	}
+}
+
+func TestWithScopeResolverOverridesFacilityScope(t *testing.T) {
+	// A site-wide resolver places every facility in one scope, so identifiers
+	// must be unique across facilities.
+	site := func(record any, scope domain.NaturalKeyScope) (string, bool) {
+		if scope == domain.ScopeFacility {
+			return "site", true
+		}
+		return domain.DefaultScopeResolver(record, scope)
+	}
+	store := NewStore(nil, WithScopeResolver(site))
+	fx := seedScopedSampleFixture(t, store)
+	if _, err := createScopedSample(store, scopedSample("S-1", fx.facilityA, fx.organism)); err != nil {
+		t.Fatalf("create sample: %v", err)
+	}
+	if _, err := createScopedSample(store, scopedSample("S-1", fx.facilityB, fx.organism)); err == nil || !strings.Contains(err.Error(), `in facility "site"`) {
+		t.Fatalf("expected site-wide scope to block the duplicate, got %v", err)
+	}
+
+	unscoped := NewStore(nil, WithScopeResolver(func(any, domain.NaturalKeyScope) (string, bool) { return "", false }))
+	fx = seedScopedSampleFixture(t, unscoped)
+	for range 2 {
+		if _, err := createScopedSample(unscoped, scopedSample("S-1", fx.facilityA, fx.organism)); err != nil {
+			t.Fatalf("expected unresolved scope to skip the check: %v", err)
+		}
+	}
+	report, err := unscoped.SelfCheck(context.Background())
+	if err != nil || len(report.NaturalKeys) != 0 {
+		t.Fatalf("expected self-check to follow the resolver, got %v %v", report.NaturalKeys, err)
+	}
+}
+
+func TestHousingUnitNameUniqueWithinFacility(t *testing.T) {
+	store := NewStore(nil)
+	fx := seedScopedSampleFixture(t, store)
+	_, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		if _, err := tx.CreateHousingUnit(HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: fx.facilityA, Capacity: 2}}); err != nil {
+			return err
+		}
+		_, err := tx.CreateHousingUnit(HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: fx.facilityB, Capacity: 2}})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("expected shared housing name across facilities to be allowed: %v", err)
+	}
+	var tankB string
+	for _, unit := range store.ListHousingUnits() {
+		if unit.FacilityID == fx.facilityB {
+			tankB = unit.ID
+		}
+	}
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		_, err := tx.TransferHousing(tankB, fx.facilityA)
+		return err
+	}); err == nil || !strings.Contains(err.Error(), `housing unit name "Tank" already used`) {
+		t.Fatalf("expected transfer into a facility with the same housing name to be blocked, got %v", err)
+	}
+}
+
+func TestCohortNameUniqueWithinProject(t *testing.T) {
+	store := NewStore(nil)
+	fx := seedScopedSampleFixture(t, store)
+	var projectA, projectB, moved string
+	_, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		a, err := tx.CreateProject(Project{Project: entitymodel.Project{Code: "PRJ-A", Title: "Project A", FacilityIDs: []string{fx.facilityA}}})
+		if err != nil {
+			return err
+		}
+		b, err := tx.CreateProject(Project{Project: entitymodel.Project{Code: "PRJ-B", Title: "Project B", FacilityIDs: []string{fx.facilityA}}})
+		if err != nil {
+			return err
+		}
+		projectA, projectB = a.ID, b.ID
+		if _, err := tx.CreateCohort(Cohort{Cohort: entitymodel.Cohort{Name: "Cohort", ProjectID: &projectA}}); err != nil {
+			return err
+		}
+		if _, err := tx.CreateCohort(Cohort{Cohort: entitymodel.Cohort{Name: "Unassigned"}}); err != nil {
+			return err
+		}
+		if _, err := tx.CreateCohort(Cohort{Cohort: entitymodel.Cohort{Name: "Unassigned"}}); err != nil {
+			return err
+		}
+		other, err := tx.CreateCohort(Cohort{Cohort: entitymodel.Cohort{Name: "Cohort", ProjectID: &projectB}})
+		moved = other.ID
+		return err
+	})
+	if err != nil {
+		t.Fatalf("expected shared cohort names across projects and outside any project to be allowed: %v", err)
+	}
+
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		_, err := tx.CreateCohort(Cohort{Cohort: entitymodel.Cohort{Name: "Cohort", ProjectID: &projectA}})
+		return err
+	}); err == nil || !strings.Contains(err.Error(), `cohort name "Cohort" already used`) || !strings.Contains(err.Error(), `in project "`+projectA+`"`) {
+		t.Fatalf("expected same-project duplicate to be blocked, got %v", err)
+	}
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		_, err := tx.UpdateCohort(moved, func(c *Cohort) error {
+			c.ProjectID = &projectA
+			return nil
+		})
+		return err
+	}); err == nil || !strings.Contains(err.Error(), `cohort name "Cohort" already used`) {
+		t.Fatalf("expected move into a project with the same cohort name to be blocked, got %v", err)
+	}
+}
@@ -102,7 +102,7 @@ func TestMemoryStoreNormalizesLifecycleStatuses(t *testing.T) {
 		treatment, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{
 			Name:        "Treatment",
 			ProcedureID: procedure.ID,
-			DosagePlan:  "dose",
+			DosagePlan:  domain.DosagePlan{Drug: "dose", DoseAmount: 1, DoseUnit: "mg"},
 			OrganismIDs: []string{organism.ID},
 		}})
 		if err != nil {
@@ -131,6 +131,7 @@ func TestMemoryStoreNormalizesLifecycleStatuses(t *testing.T) {
 			CollectedAt:     now,
 			StorageLocation: "cold",
 			AssayType:       "assay",
+			CollectedBy:     "tech",
 			ChainOfCustody:  []domain.SampleCustodyEvent{{Actor: "tech", Location: "cold", Timestamp: now}},
 		}})
 		if err != nil {
@@ -245,7 +246,7 @@ func TestMemoryStoreRejectsInvalidLifecycleStatuses(t *testing.T) {
 			Name:        "InvalidTreatment",
 			ProcedureID: procedure.ID,
 			Status:      domain.TreatmentStatus("invalid"),
-			DosagePlan:  "dose",
+			DosagePlan:  domain.DosagePlan{Drug: "dose", DoseAmount: 1, DoseUnit: "mg"},
 		}}); err == nil {
 			return fmt.Errorf("expected invalid treatment status to error")
 		}
@@ -259,6 +260,7 @@ func TestMemoryStoreRejectsInvalidLifecycleStatuses(t *testing.T) {
 			Status:          domain.SampleStatus("invalid"),
 			StorageLocation: "cold",
 			AssayType:       "assay",
+			CollectedBy:     "tech",
 			ChainOfCustody:  []domain.SampleCustodyEvent{{Actor: "tech", Location: "cold", Timestamp: now}},
 		}}); err == nil {
 			return fmt.Errorf("expected invalid sample status to error")
@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"strings"
+	"testing"
+)
+
+type supplyChangeRecorder struct {
+	changes *[]domain.Change
+}
+
+func (supplyChangeRecorder) Name() string { return "supply-change-recorder" }
+
+func (r supplyChangeRecorder) Evaluate(_ context.Context, _ domain.RuleView, changes []domain.Change) (domain.Result, error) {
+	*r.changes = append(*r.changes, changes...)
+	return domain.Result{}, nil
+}
+
+func seedConsumableSupply(t *testing.T, store *Store, quantity int) string {
+	t.Helper()
+	var supplyID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Name: "Lab"}})
+		if err != nil {
+			return err
+		}
+		project, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{Code: "PRJ", Title: "Project", FacilityIDs: []string{facility.ID}}})
+		if err != nil {
+			return err
+		}
+		supply, err := tx.CreateSupplyItem(domain.SupplyItem{SupplyItem: entitymodel.SupplyItem{
+			SKU:            "FEED-1",
+			Name:           "Feed",
+			QuantityOnHand: quantity,
+			Unit:           "kg",
+			FacilityIDs:    []string{facility.ID},
+			ProjectIDs:     []string{project.ID},
+		}})
+		if err != nil {
+			return err
+		}
+		if supply.Status != domain.SupplyStatusActive {
+			t.Fatalf("expected new supply item to default to active, got %q", supply.Status)
+		}
+		supplyID = supply.ID
+		return nil
+	}); err != nil {
+		t.Fatalf("seed supply item: %v", err)
+	}
+	return supplyID
+}
+
+func consumeSupply(store *Store, id string, quantity float64, consumedBy string) (domain.SupplyItem, error) {
+	var consumed domain.SupplyItem
+	_, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		var err error
+		consumed, err = tx.ConsumeSupplyItem(id, quantity, consumedBy)
+		return err
+	})
+	return consumed, err
+}
+
+func findSupply(t *testing.T, store *Store, id string) (domain.SupplyItem, bool) {
+	t.Helper()
+	var (
+		supply domain.SupplyItem
+		ok     bool
+	)
+	if err := store.View(context.Background(), func(view domain.TransactionView) error {
+		supply, ok = view.FindSupplyItem(id)
+		return nil
+	}); err != nil {
+		t.Fatalf("view supply item: %v", err)
+	}
+	return supply, ok
+}
+
+func TestConsumeSupplyItemDecrementsAndRecordsEvent(t *testing.T) {
+	var changes []domain.Change
+	store := NewStore(domain.NewRulesEngine())
+	store.RulesEngine().Register(supplyChangeRecorder{changes: &changes})
+	id := seedConsumableSupply(t, store, 10)
+	changes = nil
+
+	consumed, err := consumeSupply(store, id, 4, "tech")
+	if err != nil {
+		t.Fatalf("consume supply: %v", err)
+	}
+	if consumed.QuantityOnHand != 6 {
+		t.Fatalf("expected 6 remaining, got %d", consumed.QuantityOnHand)
+	}
+	if consumed.Status != domain.SupplyStatusActive {
+		t.Fatalf("expected supply to stay active, got %q", consumed.Status)
+	}
+	events, _ := consumed.SupplyAttributes()[supplyConsumptionLogKey].([]any)
+	if len(events) != 1 {
+		t.Fatalf("expected one consumption event, got %+v", consumed.SupplyAttributes())
+	}
+	event, _ := events[0].(map[string]any)
+	if event["consumed_by"] != "tech" || event["quantity"] != float64(4) || event["consumed_at"] == "" {
+		t.Fatalf("unexpected consumption event %+v", event)
+	}
+	if len(changes) != 1 || changes[0].Entity != domain.EntitySupplyItem || changes[0].Action != domain.ActionConsume {
+		t.Fatalf("expected a single consume change, got %+v", changes)
+	}
+
+	stored, ok := findSupply(t, store, id)
+	if !ok || stored.QuantityOnHand != 6 {
+		t.Fatalf("expected committed quantity 6, got %+v", stored.SupplyItem)
+	}
+}
+
+func TestConsumeSupplyItemPartialConsumptionAccumulatesEvents(t *testing.T) {
+	store := NewStore(nil)
+	id := seedConsumableSupply(t, store, 10)
+
+	for _, quantity := range []float64{2, 3} {
+		if _, err := consumeSupply(store, id, quantity, "tech"); err != nil {
+			t.Fatalf("consume %v: %v", quantity, err)
+		}
+	}
+	stored, ok := findSupply(t, store, id)
+	if !ok {
+		t.Fatalf("expected supply item to exist")
+	}
+	if stored.QuantityOnHand != 5 || stored.Status != domain.SupplyStatusActive {
+		t.Fatalf("expected 5 remaining and active status, got %d %q", stored.QuantityOnHand, stored.Status)
+	}
+	if events, _ := stored.SupplyAttributes()[supplyConsumptionLogKey].([]any); len(events) != 2 {
+		t.Fatalf("expected two consumption events, got %d", len(events))
+	}
+}
+
+func TestConsumeSupplyItemExactRemainingMarksConsumed(t *testing.T) {
+	store := NewStore(nil)
+	id := seedConsumableSupply(t, store, 3)
+
+	consumed, err := consumeSupply(store, id, 3, "tech")
+	if err != nil {
+		t.Fatalf("consume supply: %v", err)
+	}
+	if consumed.QuantityOnHand != 0 || consumed.Status != domain.SupplyStatusConsumed {
+		t.Fatalf("expected consumed status at zero stock, got %d %q", consumed.QuantityOnHand, consumed.Status)
+	}
+	if _, err := consumeSupply(store, id, 1, "tech"); err == nil {
+		t.Fatalf("expected consuming from exhausted stock to fail")
+	}
+}
+
+func TestConsumeSupplyItemRejectsOverConsumptionAndInvalidInput(t *testing.T) {
+	store := NewStore(nil)
+	id := seedConsumableSupply(t, store, 2)
+
+	if _, err := consumeSupply(store, id, 5, "tech"); err == nil || !strings.Contains(err.Error(), "cannot consume") {
+		t.Fatalf("expected over-consumption error, got %v", err)
+	}
+	cases := []struct {
+		name       string
+		id         string
+		quantity   float64
+		consumedBy string
+	}{
+		{"zero quantity", id, 0, "tech"},
+		{"negative quantity", id, -1, "tech"},
+		{"fractional quantity", id, 0.5, "tech"},
+		{"missing consumer", id, 1, "  "},
+		{"missing item", "missing", 1, "tech"},
+	}
+	for _, tc := range cases {
+		if _, err := consumeSupply(store, tc.id, tc.quantity, tc.consumedBy); err == nil {
+			t.Fatalf("%s: expected error", tc.name)
+		}
+	}
+	stored, _ := findSupply(t, store, id)
+	if stored.QuantityOnHand != 2 || stored.Status != domain.SupplyStatusActive {
+		t.Fatalf("expected failed consumption to leave stock untouched, got %d %q", stored.QuantityOnHand, stored.Status)
+	}
+	if events := stored.SupplyAttributes()[supplyConsumptionLogKey]; events != nil {
+		t.Fatalf("expected no consumption events, got %+v", events)
+	}
+}
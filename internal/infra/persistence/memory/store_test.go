@@ -35,11 +35,15 @@ func TestStoreRunInTransactionAndSnapshots(t *testing.T) {
 		t.Fatalf("expected persisted organism")
 	}
 	snapshot := store.ExportState()
-	store.ImportState(Snapshot{})
+	if err := store.ImportState(Snapshot{}); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 	if len(store.ListOrganisms()) != 0 {
 		t.Fatalf("expected cleared state")
 	}
-	store.ImportState(snapshot)
+	if err := store.ImportState(snapshot); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 	if len(store.ListOrganisms()) != 1 {
 		t.Fatalf("expected restored state")
 	}
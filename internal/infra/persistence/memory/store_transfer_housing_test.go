@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+type transferFixture struct {
+	aquaticID, terrestrialID, plainID, tankID, frogID string
+}
+
+// seedHousingTransfer creates an aquatic tank holding a frog in an aquatic
+// facility, plus a terrestrial facility and one without a default environment.
+func seedHousingTransfer(t *testing.T, store *Store) transferFixture {
+	t.Helper()
+	var f transferFixture
+	aquatic, terrestrial := domain.HousingEnvironmentAquatic, domain.HousingEnvironmentTerrestrial
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		pond, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "AQ", Name: "Aquatics", DefaultHousingEnvironment: &aquatic}})
+		if err != nil {
+			return err
+		}
+		barn, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "TR", Name: "Terrestrial", DefaultHousingEnvironment: &terrestrial}})
+		if err != nil {
+			return err
+		}
+		plain, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "PL", Name: "Plain"}})
+		if err != nil {
+			return err
+		}
+		tank, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: pond.ID, Capacity: 2}})
+		if err != nil {
+			return err
+		}
+		frog, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "frog", Stage: domain.StageAdult, HousingID: &tank.ID}})
+		if err != nil {
+			return err
+		}
+		f = transferFixture{aquaticID: pond.ID, terrestrialID: barn.ID, plainID: plain.ID, tankID: tank.ID, frogID: frog.ID}
+		return nil
+	}); err != nil {
+		t.Fatalf("seed housing: %v", err)
+	}
+	return f
+}
+
+func TestTransferHousingMovesUnitAndOccupants(t *testing.T) {
+	store := NewStore(nil)
+	f := seedHousingTransfer(t, store)
+	var changes []domain.Change
+	store.AddCommitObserver(domain.ChangeSinkFunc(func(_ context.Context, batch []domain.Change) error {
+		changes = append(changes, batch...)
+		return nil
+	}))
+
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		moved, err := tx.TransferHousing(f.tankID, f.plainID)
+		if err != nil {
+			return err
+		}
+		if moved.FacilityID != f.plainID || moved.Environment != domain.HousingEnvironmentAquatic || moved.Version != 2 {
+			t.Fatalf("expected aquatic tank moved to %s at version 2, got %+v", f.plainID, moved)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("transfer housing: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Entity != domain.EntityHousingUnit || changes[0].Action != domain.ActionUpdate {
+		t.Fatalf("expected one housing unit update, got %+v", changes)
+	}
+	if frog, _ := store.GetOrganism(f.frogID); frog.HousingID == nil || *frog.HousingID != f.tankID {
+		t.Fatalf("expected occupant to stay in the transferred unit, got %+v", frog.HousingID)
+	}
+	if facility, _ := store.GetFacility(f.plainID); len(facility.HousingUnitIDs) != 1 || facility.HousingUnitIDs[0] != f.tankID {
+		t.Fatalf("expected target facility to list the tank, got %v", facility.HousingUnitIDs)
+	}
+	if facility, _ := store.GetFacility(f.aquaticID); len(facility.HousingUnitIDs) != 0 {
+		t.Fatalf("expected source facility to drop the tank, got %v", facility.HousingUnitIDs)
+	}
+}
+
+func TestTransferHousingRejectsIncompatibleEnvironment(t *testing.T) {
+	store := NewStore(nil)
+	f := seedHousingTransfer(t, store)
+	var changes []domain.Change
+	store.AddCommitObserver(domain.ChangeSinkFunc(func(_ context.Context, batch []domain.Change) error {
+		changes = append(changes, batch...)
+		return nil
+	}))
+
+	_, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		_, err := tx.TransferHousing(f.tankID, f.terrestrialID)
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), f.frogID) || !strings.Contains(err.Error(), "terrestrial") {
+		t.Fatalf("expected incompatible occupant error, got %v", err)
+	}
+	if housing, _ := store.GetHousingUnit(f.tankID); housing.FacilityID != f.aquaticID || housing.Version != 1 {
+		t.Fatalf("expected aborted transfer to leave the tank untouched, got %+v", housing)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected no changes from an aborted transfer, got %+v", changes)
+	}
+
+	_, err = store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		_, err := tx.TransferHousing(f.tankID, "missing")
+		return err
+	})
+	var integrity domain.ErrReferentialIntegrity
+	if !errors.As(err, &integrity) || integrity.ReferencedID != "missing" {
+		t.Fatalf("expected missing target facility error, got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func seedProcedureStatuses(t *testing.T, store *Store, statuses ...domain.ProcedureStatus) []string {
+	t.Helper()
+	var ids []string
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PR-BULK", Title: "Bulk", MaxSubjects: 1, Status: domain.ProtocolStatusApproved}})
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			procedure, err := tx.CreateProcedure(domain.Procedure{Procedure: entitymodel.Procedure{Name: "Proc", Status: status, ScheduledAt: time.Now().UTC(), ProtocolID: protocol.ID}})
+			if err != nil {
+				return err
+			}
+			ids = append(ids, procedure.ID)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("seed procedures: %v", err)
+	}
+	return ids
+}
+
+func TestTransitionProceduresCompletesBatch(t *testing.T) {
+	store := NewStore(nil)
+	ids := seedProcedureStatuses(t, store, domain.ProcedureStatusInProgress, domain.ProcedureStatusInProgress, domain.ProcedureStatusCompleted)
+	var changes []domain.Change
+	store.AddCommitObserver(domain.ChangeSinkFunc(func(_ context.Context, batch []domain.Change) error {
+		changes = append(changes, batch...)
+		return nil
+	}))
+
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		updated, err := tx.TransitionProcedures([]string{ids[0], ids[1], ids[2], ids[0]}, domain.ProcedureStatusCompleted)
+		if err != nil {
+			return err
+		}
+		if len(updated) != 3 {
+			t.Fatalf("expected one result per distinct id, got %d", len(updated))
+		}
+		for i, procedure := range updated {
+			if procedure.ID != ids[i] || procedure.Status != domain.ProcedureStatusCompleted {
+				t.Fatalf("expected %s completed, got %s %s", ids[i], procedure.ID, procedure.Status)
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("transition procedures: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("expected one change per transitioned procedure, got %d", len(changes))
+	}
+	for _, change := range changes {
+		if change.Entity != domain.EntityProcedure || change.Action != domain.ActionUpdate {
+			t.Fatalf("unexpected change %s %s", change.Entity, change.Action)
+		}
+	}
+}
+
+func TestTransitionProceduresRejectsIllegalTransition(t *testing.T) {
+	store := NewStore(nil)
+	ids := seedProcedureStatuses(t, store, domain.ProcedureStatusScheduled, domain.ProcedureStatusCompleted)
+
+	_, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		_, err := tx.TransitionProcedures(ids, domain.ProcedureStatusScheduled)
+		if err == nil {
+			t.Fatalf("expected completed to scheduled to be rejected")
+		}
+		if _, err := tx.TransitionProcedures(ids, domain.ProcedureStatusInProgress); err == nil {
+			t.Fatalf("expected completed to in_progress to be rejected")
+		}
+		if procedure, _ := tx.Snapshot().FindProcedure(ids[0]); procedure.Status != domain.ProcedureStatusScheduled {
+			t.Fatalf("expected the batch to be aborted before %s changed, got %s", ids[0], procedure.Status)
+		}
+		if _, err := tx.TransitionProcedures(ids[:1], "paused"); err == nil {
+			t.Fatalf("expected unsupported status to be rejected")
+		}
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), "cannot transition from completed to scheduled") {
+		t.Fatalf("expected illegal transition error, got %v", err)
+	}
+}
+
+func TestTransitionProceduresRejectsMissingID(t *testing.T) {
+	store := NewStore(nil)
+	ids := seedProcedureStatuses(t, store, domain.ProcedureStatusScheduled)
+
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		_, err := tx.TransitionProcedures([]string{ids[0], "missing"}, domain.ProcedureStatusCancelled)
+		return err
+	}); err == nil || !strings.Contains(err.Error(), `procedure "missing" not found`) {
+		t.Fatalf("expected missing procedure error, got %v", err)
+	}
+	if err := store.View(context.Background(), func(view domain.TransactionView) error {
+		if procedure, _ := view.FindProcedure(ids[0]); procedure.Status != domain.ProcedureStatusScheduled {
+			t.Fatalf("expected procedure left scheduled, got %s", procedure.Status)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+}
+
+func TestUpdateProcedureEnforcesTransitionTable(t *testing.T) {
+	store := NewStore(nil)
+	ids := seedProcedureStatuses(t, store, domain.ProcedureStatusScheduled)
+	update := func(status domain.ProcedureStatus) error {
+		_, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+			_, err := tx.UpdateProcedure(ids[0], func(p *domain.Procedure) error {
+				p.Status = status
+				return nil
+			})
+			return err
+		})
+		return err
+	}
+
+	if err := update(domain.ProcedureStatusCompleted); err == nil || !strings.Contains(err.Error(), "cannot transition from scheduled to completed") {
+		t.Fatalf("expected UpdateProcedure to reject a transition TransitionProcedures rejects, got %v", err)
+	}
+	if err := update(domain.ProcedureStatusInProgress); err != nil {
+		t.Fatalf("scheduled to in_progress: %v", err)
+	}
+	if err := update(domain.ProcedureStatusCompleted); err != nil {
+		t.Fatalf("in_progress to completed: %v", err)
+	}
+	if err := update(domain.ProcedureStatusScheduled); err == nil {
+		t.Fatalf("expected completed to scheduled to be rejected")
+	}
+}
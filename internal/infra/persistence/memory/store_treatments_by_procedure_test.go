@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestListTreatmentsByProcedureTracksTransactionChanges(t *testing.T) {
+	store := NewStore(nil)
+	now := time.Now().UTC()
+	ids := func(treatments []domain.Treatment) []string {
+		out := make([]string, 0, len(treatments))
+		for _, treatment := range treatments {
+			out = append(out, treatment.ID)
+		}
+		return out
+	}
+
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PROT", Title: "Protocol", MaxSubjects: 5}})
+		if err != nil {
+			return err
+		}
+		var procedures []domain.Procedure
+		for _, name := range []string{"Proc A", "Proc B"} {
+			procedure, err := tx.CreateProcedure(domain.Procedure{Procedure: entitymodel.Procedure{Name: name, Status: domain.ProcedureStatusScheduled, ScheduledAt: now, ProtocolID: protocol.ID}})
+			if err != nil {
+				return err
+			}
+			procedures = append(procedures, procedure)
+		}
+		newTreatment := func(id, procedureID string) domain.Treatment {
+			return domain.Treatment{Treatment: entitymodel.Treatment{ID: id, Name: id, Status: domain.TreatmentStatusPlanned, ProcedureID: procedureID,
+				DosagePlan: domain.DosagePlan{Drug: "compound", DoseAmount: 1, DoseUnit: "mg"}}}
+		}
+		for _, treatment := range []domain.Treatment{newTreatment("t-2", procedures[0].ID), newTreatment("t-1", procedures[0].ID), newTreatment("t-3", procedures[1].ID)} {
+			if _, err := tx.CreateTreatment(treatment); err != nil {
+				return err
+			}
+		}
+
+		got := tx.Snapshot().ListTreatmentsByProcedure(procedures[0].ID)
+		if want := []string{"t-1", "t-2"}; len(got) != 2 || ids(got)[0] != want[0] || ids(got)[1] != want[1] {
+			t.Fatalf("expected %v after create, got %v", want, ids(got))
+		}
+
+		if _, err := tx.UpdateTreatment("t-1", func(treatment *domain.Treatment) error {
+			treatment.Status = domain.TreatmentStatusInProgress
+			return nil
+		}); err != nil {
+			return err
+		}
+		got = tx.Snapshot().ListTreatmentsByProcedure(procedures[0].ID)
+		if got[0].Status != domain.TreatmentStatusInProgress {
+			t.Fatalf("expected status update to be visible, got %+v", got[0])
+		}
+
+		if err := tx.DeleteTreatment("t-2"); err != nil {
+			return err
+		}
+		if got := ids(tx.Snapshot().ListTreatmentsByProcedure(procedures[0].ID)); len(got) != 1 || got[0] != "t-1" {
+			t.Fatalf("expected deleted treatment to drop out, got %v", got)
+		}
+		if got := ids(tx.Snapshot().ListTreatmentsByProcedure(procedures[1].ID)); len(got) != 1 || got[0] != "t-3" {
+			t.Fatalf("expected other procedure to keep its treatment, got %v", got)
+		}
+		if got := tx.Snapshot().ListTreatmentsByProcedure("missing"); len(got) != 0 {
+			t.Fatalf("expected no treatments for unknown procedure, got %v", ids(got))
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("transaction: %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+package memory
+
+import (
+	"testing"
+
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"colonycore/testutil"
+)
+
+func TestValidEnumMapsCoverSchema(t *testing.T) {
+	testutil.AssertExhaustive(t, entitymodel.AllHousingState(), validHousingStates)
+	testutil.AssertExhaustive(t, entitymodel.AllHousingEnvironment(), validHousingEnvironments)
+	testutil.AssertExhaustive(t, entitymodel.AllPairingIntent(), validPairingIntents)
+	testutil.AssertExhaustive(t, entitymodel.AllProtocolStatus(), validProtocolStatuses)
+	testutil.AssertExhaustive(t, entitymodel.AllPermitStatus(), validPermitStatuses)
+	testutil.AssertExhaustive(t, entitymodel.AllProcedureStatus(), validProcedureStatuses)
+	testutil.AssertExhaustive(t, entitymodel.AllTreatmentStatus(), validTreatmentStatuses)
+	testutil.AssertExhaustive(t, entitymodel.AllSampleStatus(), validSampleStatuses)
+	testutil.AssertExhaustive(t, entitymodel.AllSupplyStatus(), validSupplyStatuses)
+}
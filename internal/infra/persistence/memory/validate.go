@@ -0,0 +1,211 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// snapshotValidator accumulates the problems found while validating a snapshot.
+type snapshotValidator struct {
+	problems []error
+}
+
+func (v *snapshotValidator) fail(format string, args ...any) {
+	v.problems = append(v.problems, fmt.Errorf(format, args...))
+}
+
+func (v *snapshotValidator) check(entity, id string, err error) {
+	if err != nil {
+		v.fail("%s %q: %w", entity, id, err)
+	}
+}
+
+func (v *snapshotValidator) key(entity, key, id string) {
+	if key != id {
+		v.fail("%s %q stored under key %q", entity, id, key)
+	}
+}
+
+func (v *snapshotValidator) ref(entity, id, field, target string, exists bool) {
+	if !exists {
+		v.fail("%s %q: %s references unknown %q", entity, id, field, target)
+	}
+}
+
+func (v *snapshotValidator) optional(entity, id, field string, target *string, exists func(string) bool) {
+	if target != nil {
+		v.ref(entity, id, field, *target, exists(*target))
+	}
+}
+
+func (v *snapshotValidator) each(entity, id, field string, targets []string, exists func(string) bool) {
+	for _, target := range targets {
+		v.ref(entity, id, field, target, exists(target))
+	}
+}
+
+func (v *snapshotValidator) nonEmpty(entity, id, field string, values []string) {
+	v.check(entity, id, requireNonEmpty(field, values))
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ValidateSnapshot reports every entity in snapshot that the store would
+// reject or silently repair on import: records stored under a key other than
+// their ID, unsupported statuses, missing required relationships, and
+// references to entities absent from the snapshot. The returned error joins
+// one error per problem in a stable order; nil means the snapshot is valid.
+func ValidateSnapshot(snapshot Snapshot) error {
+	v := &snapshotValidator{}
+	s := snapshot
+	facility := func(id string) bool { _, ok := s.Facilities[id]; return ok }
+	housing := func(id string) bool { _, ok := s.Housing[id]; return ok }
+	line := func(id string) bool { _, ok := s.Lines[id]; return ok }
+	strain := func(id string) bool { _, ok := s.Strains[id]; return ok }
+	marker := func(id string) bool { _, ok := s.Markers[id]; return ok }
+	protocol := func(id string) bool { _, ok := s.Protocols[id]; return ok }
+	project := func(id string) bool { _, ok := s.Projects[id]; return ok }
+	cohort := func(id string) bool { _, ok := s.Cohorts[id]; return ok }
+	organism := func(id string) bool { _, ok := s.Organisms[id]; return ok }
+	procedure := func(id string) bool { _, ok := s.Procedures[id]; return ok }
+	breeding := func(id string) bool { _, ok := s.Breeding[id]; return ok }
+
+	for _, key := range sortedKeys(s.Facilities) {
+		f := s.Facilities[key]
+		v.key("facility", key, f.ID)
+		v.check("facility", f.ID, validateFacility(f))
+	}
+	for _, key := range sortedKeys(s.Markers) {
+		v.key("genotype marker", key, s.Markers[key].ID)
+	}
+	for _, key := range sortedKeys(s.Housing) {
+		h := s.Housing[key]
+		v.key("housing unit", key, h.ID)
+		v.check("housing unit", h.ID, normalizeHousingUnit(&h))
+		if h.Capacity <= 0 {
+			v.fail("housing unit %q: capacity must be positive", h.ID)
+		}
+		v.ref("housing unit", h.ID, "facility_id", h.FacilityID, facility(h.FacilityID))
+	}
+	for _, key := range sortedKeys(s.Lines) {
+		l := s.Lines[key]
+		v.key("line", key, l.ID)
+		v.nonEmpty("line", l.ID, "line.genotype_marker_ids", l.GenotypeMarkerIDs)
+		v.each("line", l.ID, "genotype_marker_ids", l.GenotypeMarkerIDs, marker)
+	}
+	for _, key := range sortedKeys(s.Strains) {
+		st := s.Strains[key]
+		v.key("strain", key, st.ID)
+		v.ref("strain", st.ID, "line_id", st.LineID, line(st.LineID))
+		v.each("strain", st.ID, "genotype_marker_ids", st.GenotypeMarkerIDs, marker)
+	}
+	for _, key := range sortedKeys(s.Protocols) {
+		p := s.Protocols[key]
+		v.key("protocol", key, p.ID)
+		v.check("protocol", p.ID, normalizeProtocol(&p))
+	}
+	for _, key := range sortedKeys(s.Projects) {
+		p := s.Projects[key]
+		v.key("project", key, p.ID)
+		v.nonEmpty("project", p.ID, "project.facility_ids", p.FacilityIDs)
+		v.each("project", p.ID, "facility_ids", p.FacilityIDs, facility)
+		v.each("project", p.ID, "protocol_ids", p.ProtocolIDs, protocol)
+	}
+	for _, key := range sortedKeys(s.Cohorts) {
+		c := s.Cohorts[key]
+		v.key("cohort", key, c.ID)
+		v.optional("cohort", c.ID, "housing_id", c.HousingID, housing)
+		v.optional("cohort", c.ID, "project_id", c.ProjectID, project)
+		v.optional("cohort", c.ID, "protocol_id", c.ProtocolID, protocol)
+		v.optional("cohort", c.ID, "created_from_breeding_unit_id", c.CreatedFromBreedingUnitID, breeding)
+	}
+	for _, key := range sortedKeys(s.Organisms) {
+		o := s.Organisms[key]
+		v.key("organism", key, o.ID)
+		v.optional("organism", o.ID, "cohort_id", o.CohortID, cohort)
+		v.optional("organism", o.ID, "housing_id", o.HousingID, housing)
+		v.optional("organism", o.ID, "line_id", o.LineID, line)
+		v.optional("organism", o.ID, "strain_id", o.StrainID, strain)
+		v.optional("organism", o.ID, "project_id", o.ProjectID, project)
+		v.optional("organism", o.ID, "protocol_id", o.ProtocolID, protocol)
+		v.each("organism", o.ID, "parent_ids", o.ParentIDs, organism)
+	}
+	for _, key := range sortedKeys(s.Breeding) {
+		b := s.Breeding[key]
+		v.key("breeding unit", key, b.ID)
+		v.optional("breeding unit", b.ID, "housing_id", b.HousingID, housing)
+		v.optional("breeding unit", b.ID, "line_id", b.LineID, line)
+		v.optional("breeding unit", b.ID, "strain_id", b.StrainID, strain)
+		v.optional("breeding unit", b.ID, "target_line_id", b.TargetLineID, line)
+		v.optional("breeding unit", b.ID, "target_strain_id", b.TargetStrainID, strain)
+		v.optional("breeding unit", b.ID, "protocol_id", b.ProtocolID, protocol)
+		v.each("breeding unit", b.ID, "female_ids", b.FemaleIDs, organism)
+		v.each("breeding unit", b.ID, "male_ids", b.MaleIDs, organism)
+	}
+	for _, key := range sortedKeys(s.Procedures) {
+		p := s.Procedures[key]
+		v.key("procedure", key, p.ID)
+		v.check("procedure", p.ID, normalizeProcedure(&p))
+		v.ref("procedure", p.ID, "protocol_id", p.ProtocolID, protocol(p.ProtocolID))
+		v.optional("procedure", p.ID, "project_id", p.ProjectID, project)
+		v.optional("procedure", p.ID, "cohort_id", p.CohortID, cohort)
+		v.each("procedure", p.ID, "organism_ids", p.OrganismIDs, organism)
+	}
+	for _, key := range sortedKeys(s.Treatments) {
+		t := s.Treatments[key]
+		v.key("treatment", key, t.ID)
+		v.check("treatment", t.ID, normalizeTreatment(&t))
+		v.check("treatment", t.ID, validateDosagePlan(t.DosagePlan))
+		v.ref("treatment", t.ID, "procedure_id", t.ProcedureID, procedure(t.ProcedureID))
+		v.each("treatment", t.ID, "organism_ids", t.OrganismIDs, organism)
+		v.each("treatment", t.ID, "cohort_ids", t.CohortIDs, cohort)
+	}
+	for _, key := range sortedKeys(s.Observations) {
+		o := s.Observations[key]
+		v.key("observation", key, o.ID)
+		if o.ProcedureID == nil && o.OrganismID == nil && o.CohortID == nil {
+			v.fail("observation %q: requires procedure, organism, or cohort reference", o.ID)
+		}
+		v.optional("observation", o.ID, "procedure_id", o.ProcedureID, procedure)
+		v.optional("observation", o.ID, "organism_id", o.OrganismID, organism)
+		v.optional("observation", o.ID, "cohort_id", o.CohortID, cohort)
+	}
+	for _, key := range sortedKeys(s.Samples) {
+		sample := s.Samples[key]
+		v.key("sample", key, sample.ID)
+		v.check("sample", sample.ID, normalizeSample(&sample))
+		v.check("sample", sample.ID, validateSampleCustody(sample))
+		v.ref("sample", sample.ID, "facility_id", sample.FacilityID, facility(sample.FacilityID))
+		v.optional("sample", sample.ID, "organism_id", sample.OrganismID, organism)
+		v.optional("sample", sample.ID, "cohort_id", sample.CohortID, cohort)
+	}
+	for _, key := range sortedKeys(s.Permits) {
+		p := s.Permits[key]
+		v.key("permit", key, p.ID)
+		v.check("permit", p.ID, normalizePermit(&p))
+		v.check("permit", p.ID, validatePermitIssueDate(p))
+		v.nonEmpty("permit", p.ID, "permit.allowed_activities", p.AllowedActivities)
+		v.nonEmpty("permit", p.ID, "permit.facility_ids", p.FacilityIDs)
+		v.nonEmpty("permit", p.ID, "permit.protocol_ids", p.ProtocolIDs)
+		v.each("permit", p.ID, "facility_ids", p.FacilityIDs, facility)
+		v.each("permit", p.ID, "protocol_ids", p.ProtocolIDs, protocol)
+	}
+	for _, key := range sortedKeys(s.Supplies) {
+		item := s.Supplies[key]
+		v.key("supply item", key, item.ID)
+		v.check("supply item", item.ID, normalizeSupplyItem(&item))
+		v.nonEmpty("supply item", item.ID, "supply_item.facility_ids", item.FacilityIDs)
+		v.nonEmpty("supply item", item.ID, "supply_item.project_ids", item.ProjectIDs)
+		v.each("supply item", item.ID, "facility_ids", item.FacilityIDs, facility)
+		v.each("supply item", item.ID, "project_ids", item.ProjectIDs, project)
+	}
+	return errors.Join(v.problems...)
+}
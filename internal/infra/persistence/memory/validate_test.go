@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestValidateSnapshotAcceptsStoreExport(t *testing.T) {
+	store := NewStore(nil)
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Name: "Lab"}})
+		if err != nil {
+			return err
+		}
+		housing, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: facility.ID, Capacity: 2}})
+		if err != nil {
+			return err
+		}
+		_, err = tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus", HousingID: &housing.ID}})
+		return err
+	}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if err := ValidateSnapshot(store.ExportState()); err != nil {
+		t.Fatalf("expected store export to validate, got %v", err)
+	}
+}
+
+func TestValidateSnapshotReportsEveryProblem(t *testing.T) {
+	missing := "missing-housing"
+	snapshot := Snapshot{
+		Housing: map[string]HousingUnit{
+			"tank": {HousingUnit: entitymodel.HousingUnit{ID: "tank", FacilityID: "nowhere", Capacity: 1}},
+		},
+		Organisms: map[string]Organism{
+			"frog":  {Organism: entitymodel.Organism{ID: "frog", HousingID: &missing}},
+			"alias": {Organism: entitymodel.Organism{ID: "toad"}},
+		},
+		Protocols: map[string]Protocol{
+			"prot": {Protocol: entitymodel.Protocol{ID: "prot", Status: "bogus"}},
+		},
+	}
+	err := ValidateSnapshot(snapshot)
+	if err == nil {
+		t.Fatalf("expected validation errors")
+	}
+	for _, want := range []string{
+		`housing unit "tank": facility_id references unknown "nowhere"`,
+		`organism "frog": housing_id references unknown "missing-housing"`,
+		`organism "toad" stored under key "alias"`,
+		`protocol "prot": unsupported protocol status "bogus"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in %v", want, err)
+		}
+	}
+}
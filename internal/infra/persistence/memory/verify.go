@@ -0,0 +1,244 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"colonycore/pkg/domain"
+)
+
+// InvalidEntityError reports a stored entity that no longer satisfies the
+// invariants the store enforces on write.
+type InvalidEntityError = domain.InvalidEntityError
+
+// WithVerifyOnRead re-validates records returned by GetVerified and
+// ListVerified (see domain.VerifiedReader), which then report an
+// InvalidEntityError for stored data that violates a write-time invariant
+// instead of handing it back silently. The Get* and List* accessors are not
+// affected. Verification is off by default because it costs a validation pass
+// per read.
+func WithVerifyOnRead(enabled bool) StoreOption {
+	return func(s *Store) {
+		s.verifyOnRead = enabled
+	}
+}
+
+// GetVerified implements domain.VerifiedReader over committed state.
+func (s *Store) GetVerified(entity domain.EntityType, id string) (any, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	records, err := entityRecords(&s.state, entity, id)
+	if err != nil {
+		return nil, false, err
+	}
+	record, ok := records[id]
+	if !ok {
+		return nil, false, nil
+	}
+	if !s.verifyOnRead {
+		return record, true, nil
+	}
+	return record, true, VerifyRecord(entity, id, record)
+}
+
+// ListVerified implements domain.VerifiedReader over committed state. Records
+// are returned in ID order.
+func (s *Store) ListVerified(entity domain.EntityType) ([]any, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	records, err := entityRecords(&s.state, entity, "")
+	if err != nil {
+		return nil, err
+	}
+	out := make([]any, 0, len(records))
+	var errs []error
+	for _, id := range sortedKeys(records) {
+		out = append(out, records[id])
+		if s.verifyOnRead {
+			errs = append(errs, VerifyRecord(entity, id, records[id]))
+		}
+	}
+	return out, errors.Join(errs...)
+}
+
+// Verify validates the committed record of entity stored under id, returning
+// an InvalidEntityError when it violates a write-time invariant regardless of
+// WithVerifyOnRead. Missing records and unknown entity types return nil.
+func (s *Store) Verify(entity domain.EntityType, id string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	records, err := entityRecords(&s.state, entity, id)
+	if err != nil {
+		return nil
+	}
+	if record, ok := records[id]; ok {
+		return VerifyRecord(entity, id, record)
+	}
+	return nil
+}
+
+// VerifyRecord re-runs the write-time checks for record, stored as entity
+// under id, and wraps any failure in an InvalidEntityError. Other backends
+// use it to verify records against the same invariants as this store.
+func VerifyRecord(entity domain.EntityType, id string, record any) error {
+	var err error
+	switch r := record.(type) {
+	case Organism:
+		err = verifyOrganism(r)
+	case HousingUnit:
+		err = verifyHousingUnit(r)
+	case Facility:
+		err = errors.Join(verifyFacility(r), validateFacility(r))
+	case BreedingUnit:
+		err = verifyBreedingUnit(r)
+	case Line:
+		err = verifyLine(r)
+	case Strain:
+		err = verifyStrain(r)
+	case GenotypeMarker:
+		err = verifyGenotypeMarker(r)
+	case Procedure:
+		err = normalizeProcedure(&r)
+	case Treatment:
+		err = errors.Join(normalizeTreatment(&r), validateDosagePlan(r.DosagePlan))
+	case Sample:
+		err = errors.Join(normalizeSample(&r), validateSampleCustody(r))
+	case Protocol:
+		err = normalizeProtocol(&r)
+	case Permit:
+		err = errors.Join(verifyPermit(r), validatePermitIssueDate(r))
+	case Project:
+		err = requireNonEmpty("project.facility_ids", r.FacilityIDs)
+	case SupplyItem:
+		err = verifySupplyItem(r)
+	case Cohort, Observation:
+	default:
+		err = fmt.Errorf("unsupported record type %T", record)
+	}
+	if err != nil {
+		return InvalidEntityError{Entity: entity, ID: id, Err: err}
+	}
+	return nil
+}
+
+// entityRecords copies the records of entity, decorated as the read accessors
+// return them and keyed by ID. A non-empty id restricts the copy to that
+// record.
+func entityRecords(st *memoryState, entity domain.EntityType, id string) (map[string]any, error) {
+	switch entity {
+	case domain.EntityOrganism:
+		return copyRecords(st.organisms, id, cloneOrganism), nil
+	case domain.EntityCohort:
+		return copyRecords(st.cohorts, id, cloneCohort), nil
+	case domain.EntityHousingUnit:
+		return copyRecords(st.housing, id, cloneHousing), nil
+	case domain.EntityFacility:
+		return copyRecords(st.facilities, id, func(f Facility) Facility { return cloneFacility(decorateFacility(st, f)) }), nil
+	case domain.EntityBreeding:
+		return copyRecords(st.breeding, id, cloneBreeding), nil
+	case domain.EntityLine:
+		return copyRecords(st.lines, id, cloneLine), nil
+	case domain.EntityStrain:
+		return copyRecords(st.strains, id, cloneStrain), nil
+	case domain.EntityGenotypeMarker:
+		return copyRecords(st.markers, id, cloneGenotypeMarker), nil
+	case domain.EntityProcedure:
+		return copyRecords(st.procedures, id, func(p Procedure) Procedure { return cloneProcedure(decorateProcedure(st, p)) }), nil
+	case domain.EntityTreatment:
+		return copyRecords(st.treatments, id, cloneTreatment), nil
+	case domain.EntityObservation:
+		return copyRecords(st.observations, id, cloneObservation), nil
+	case domain.EntitySample:
+		return copyRecords(st.samples, id, cloneSample), nil
+	case domain.EntityProtocol:
+		return copyRecords(st.protocols, id, cloneProtocol), nil
+	case domain.EntityPermit:
+		return copyRecords(st.permits, id, clonePermit), nil
+	case domain.EntityProject:
+		view := transactionView{state: st}
+		return copyRecords(st.projects, id, func(p Project) Project { return cloneProject(view.decorateProject(p)) }), nil
+	case domain.EntitySupplyItem:
+		return copyRecords(st.supplies, id, cloneSupplyItem), nil
+	default:
+		return nil, fmt.Errorf("read %s: unknown entity", entity)
+	}
+}
+
+func copyRecords[T any](records map[string]T, id string, clone func(T) T) map[string]any {
+	if id != "" {
+		out := make(map[string]any, 1)
+		if record, ok := records[id]; ok {
+			out[id] = clone(record)
+		}
+		return out
+	}
+	out := make(map[string]any, len(records))
+	for key, record := range records {
+		out[key] = clone(record)
+	}
+	return out
+}
+
+func requireText(field, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("%s is required", field)
+	}
+	return nil
+}
+
+func verifyOrganism(o Organism) error {
+	var stage error
+	switch o.Stage {
+	case domain.StagePlanned, domain.StageLarva, domain.StageJuvenile, domain.StageAdult, domain.StageRetired, domain.StageDeceased:
+	default:
+		stage = fmt.Errorf("unsupported lifecycle stage %q", o.Stage)
+	}
+	return errors.Join(requireText("name", o.Name), requireText("species", o.Species), stage)
+}
+
+func verifyHousingUnit(h HousingUnit) error {
+	var capacity error
+	if h.Capacity <= 0 {
+		capacity = fmt.Errorf("capacity %d must be positive", h.Capacity)
+	}
+	return errors.Join(requireText("facility_id", h.FacilityID), capacity, normalizeHousingUnit(&h))
+}
+
+func verifyFacility(f Facility) error {
+	return errors.Join(requireText("code", f.Code), requireText("name", f.Name))
+}
+
+func verifyLine(l Line) error {
+	return errors.Join(
+		requireText("code", l.Code),
+		requireText("name", l.Name),
+		requireNonEmpty("line.genotype_marker_ids", l.GenotypeMarkerIDs),
+	)
+}
+
+func verifyStrain(st Strain) error {
+	return errors.Join(requireText("code", st.Code), requireText("line_id", st.LineID))
+}
+
+func verifyGenotypeMarker(g GenotypeMarker) error {
+	return errors.Join(requireText("name", g.Name), requireNonEmpty("genotype_marker.alleles", g.Alleles))
+}
+
+func verifyPermit(p Permit) error {
+	return errors.Join(requireText("permit_number", p.PermitNumber), normalizePermit(&p))
+}
+
+func verifyBreedingUnit(b BreedingUnit) error {
+	return errors.Join(requireText("name", b.Name), normalizeBreedingUnit(&b))
+}
+
+func verifySupplyItem(item SupplyItem) error {
+	return errors.Join(
+		requireText("sku", item.SKU),
+		requireText("name", item.Name),
+		requireNonEmpty("supply_item.facility_ids", item.FacilityIDs),
+		requireNonEmpty("supply_item.project_ids", item.ProjectIDs),
+		normalizeSupplyItem(&item),
+	)
+}
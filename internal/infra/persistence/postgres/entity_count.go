@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"colonycore/pkg/domain"
+)
+
+// countQuery describes how CountEntities counts one entity. from names the
+// table aliased as e plus any joins; facility and project are predicates on a
+// text[] parameter, written as %s, or "" when the entity is unattributed in
+// that dimension.
+type countQuery struct {
+	from     string
+	facility string
+	project  string
+}
+
+// countQueries mirrors the attribution documented on domain.CountFilter:
+// records without attribution in a filtered dimension are still counted.
+var countQueries = map[domain.EntityType]countQuery{
+	domain.EntityOrganism: {
+		from:     `organisms e LEFT JOIN housing_units h ON h.id = e.housing_id`,
+		facility: `(e.housing_id IS NULL OR h.facility_id::text = ANY(%s))`,
+		project:  `(e.project_id IS NULL OR e.project_id::text = ANY(%s))`,
+	},
+	domain.EntityCohort: {
+		from:     `cohorts e LEFT JOIN housing_units h ON h.id = e.housing_id`,
+		facility: `(e.housing_id IS NULL OR h.facility_id::text = ANY(%s))`,
+		project:  `(e.project_id IS NULL OR e.project_id::text = ANY(%s))`,
+	},
+	domain.EntityHousingUnit: {from: `housing_units e`, facility: `e.facility_id::text = ANY(%s)`},
+	domain.EntityFacility:    {from: `facilities e`, facility: `e.id::text = ANY(%s)`},
+	domain.EntitySample:      {from: `samples e`, facility: `e.facility_id::text = ANY(%s)`},
+	domain.EntityProject: {
+		from:     `projects e`,
+		facility: linkedOrUnlinked(`facilities__project_ids`, `project_id`, `facility_id`),
+		project:  `e.id::text = ANY(%s)`,
+	},
+	domain.EntityPermit: {
+		from:     `permits e`,
+		facility: linkedOrUnlinked(`permits__facility_ids`, `permit_id`, `facility_id`),
+	},
+	domain.EntitySupplyItem: {
+		from:     `supply_items e`,
+		facility: linkedOrUnlinked(`supply_items__facility_ids`, `supply_item_id`, `facility_id`),
+		project:  linkedOrUnlinked(`projects__supply_item_ids`, `supply_item_id`, `project_id`),
+	},
+	domain.EntityProcedure:      {from: `procedures e`, project: `(e.project_id IS NULL OR e.project_id::text = ANY(%s))`},
+	domain.EntityBreeding:       {from: `breeding_units e`},
+	domain.EntityLine:           {from: `lines e`},
+	domain.EntityStrain:         {from: `strains e`},
+	domain.EntityGenotypeMarker: {from: `genotype_markers e`},
+	domain.EntityTreatment:      {from: `treatments e`},
+	domain.EntityObservation:    {from: `observations e`},
+	domain.EntityProtocol:       {from: `protocols e`},
+}
+
+// linkedOrUnlinked admits records with no rows in the join table or with a row
+// whose column value is granted.
+func linkedOrUnlinked(table, owner, column string) string {
+	return fmt.Sprintf(`(NOT EXISTS (SELECT 1 FROM %[1]s j WHERE j.%[2]s = e.id) OR EXISTS (SELECT 1 FROM %[1]s j WHERE j.%[2]s = e.id AND j.%[3]s::text = ANY(%%s)))`, table, owner, column)
+}
+
+// countEntitiesSQL builds the COUNT query for q under filter and its
+// arguments. Unfiltered dimensions add no predicate.
+func countEntitiesSQL(q countQuery, filter domain.CountFilter) (string, []any) {
+	var predicates []string
+	var args []any
+	for _, dimension := range []struct {
+		predicate string
+		ids       []string
+	}{
+		{q.facility, filter.FacilityIDs},
+		{q.project, filter.ProjectIDs},
+	} {
+		if dimension.predicate == "" || dimension.ids == nil {
+			continue
+		}
+		args = append(args, dimension.ids)
+		predicates = append(predicates, fmt.Sprintf(dimension.predicate, fmt.Sprintf("$%d", len(args))))
+	}
+	query := `SELECT COUNT(*) FROM ` + q.from
+	if len(predicates) > 0 {
+		query += ` WHERE ` + strings.Join(predicates, ` AND `)
+	}
+	return query, args
+}
+
+// CountEntities counts the records of entity admitted by filter with a single
+// COUNT query. Attribution follows domain.CountFilter; database errors are
+// returned.
+func (s *Store) CountEntities(entity domain.EntityType, filter domain.CountFilter) (int, error) {
+	q, ok := countQueries[entity]
+	if !ok {
+		return 0, fmt.Errorf("count %s: unknown entity", entity)
+	}
+	query, args := countEntitiesSQL(q, filter)
+	var count int
+	if err := s.db.QueryRowContext(context.Background(), query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count %s: %w", entity, err)
+	}
+	return count, nil
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	pgtu "colonycore/internal/infra/persistence/postgres/testutil"
+	"colonycore/pkg/domain"
+)
+
+func TestCountEntitiesQueriesInPlace(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+	store, err := NewStore("ignored", domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	conn.Tables["organisms"] = []map[string]any{{"id": "o1"}, {"id": "o2"}}
+	conn.Queries = nil
+
+	count, err := store.CountEntities(domain.EntityOrganism, domain.CountFilter{})
+	if err != nil || count != 2 {
+		t.Fatalf("expected 2 organisms, got %d (%v)", count, err)
+	}
+	if len(conn.Queries) != 1 || !strings.HasPrefix(conn.Queries[0], "SELECT COUNT(*) FROM organisms") {
+		t.Fatalf("expected a single count query, got %v", conn.Queries)
+	}
+
+	conn.FailTables = map[string]bool{"organisms": true}
+	if _, err := store.CountEntities(domain.EntityOrganism, domain.CountFilter{}); err == nil {
+		t.Fatalf("expected query failure to propagate")
+	}
+	if _, err := store.CountEntities(domain.EntityType("unknown"), domain.CountFilter{}); err == nil {
+		t.Fatalf("expected unknown entity to fail")
+	}
+}
+
+func TestCountEntitiesSQLFiltersGrantedDimensions(t *testing.T) {
+	q := countQueries[domain.EntityOrganism]
+	query, args := countEntitiesSQL(q, domain.CountFilter{})
+	if strings.Contains(query, "WHERE") || len(args) != 0 {
+		t.Fatalf("expected unfiltered query, got %q %v", query, args)
+	}
+	query, args = countEntitiesSQL(q, domain.CountFilter{ProjectIDs: []string{}})
+	if !strings.HasSuffix(query, "WHERE (e.project_id IS NULL OR e.project_id::text = ANY($1))") || len(args) != 1 {
+		t.Fatalf("expected project predicate only, got %q %v", query, args)
+	}
+	query, args = countEntitiesSQL(countQueries[domain.EntitySupplyItem], domain.CountFilter{FacilityIDs: []string{"f1"}, ProjectIDs: []string{"p1"}})
+	if !strings.Contains(query, "j.facility_id::text = ANY($1)") || !strings.Contains(query, "j.project_id::text = ANY($2)") || len(args) != 2 {
+		t.Fatalf("expected facility and project predicates, got %q %v", query, args)
+	}
+	if _, args := countEntitiesSQL(countQueries[domain.EntityProtocol], domain.CountFilter{FacilityIDs: []string{"f1"}}); len(args) != 0 {
+		t.Fatalf("expected unattributed entity to ignore filters, got %v", args)
+	}
+}
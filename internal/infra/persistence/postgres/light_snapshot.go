@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// LightRecord is the identity projection of one record: its ID, the column a
+// list UI shows for it, and its code where the entity has one.
+type LightRecord struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Code string `json:"code,omitempty"`
+}
+
+// LightSnapshot is a compact projection of the store for list UIs such as
+// pickers and dropdowns. Each bucket is sorted by ID. Light snapshots omit
+// attributes and every other JSON column, all relationship fields (including
+// derived links such as project organism IDs), and observations, which have no
+// name column.
+type LightSnapshot struct {
+	Organisms  []LightRecord `json:"organisms"`
+	Cohorts    []LightRecord `json:"cohorts"`
+	Housing    []LightRecord `json:"housing"`
+	Facilities []LightRecord `json:"facilities"`
+	Breeding   []LightRecord `json:"breeding"`
+	Lines      []LightRecord `json:"lines"`
+	Strains    []LightRecord `json:"strains"`
+	Markers    []LightRecord `json:"markers"`
+	Procedures []LightRecord `json:"procedures"`
+	Treatments []LightRecord `json:"treatments"`
+	Samples    []LightRecord `json:"samples"`
+	Protocols  []LightRecord `json:"protocols"`
+	Permits    []LightRecord `json:"permits"`
+	Projects   []LightRecord `json:"projects"`
+	Supplies   []LightRecord `json:"supplies"`
+}
+
+// lightQuery selects id, the display column, and optionally the code column
+// of one table into a LightSnapshot bucket.
+type lightQuery struct {
+	table   string
+	query   string
+	hasCode bool
+	bucket  func(*LightSnapshot) *[]LightRecord
+}
+
+// lightQueries lists the per-table projections in the order they are loaded.
+// Samples are labelled by identifier, permits by permit number, protocols and
+// projects by title, and supply items carry their SKU as the code.
+var lightQueries = []lightQuery{
+	{"facilities", `SELECT id, name, code FROM facilities`, true, func(s *LightSnapshot) *[]LightRecord { return &s.Facilities }},
+	{"genotype_markers", `SELECT id, name FROM genotype_markers`, false, func(s *LightSnapshot) *[]LightRecord { return &s.Markers }},
+	{"lines", `SELECT id, name, code FROM lines`, true, func(s *LightSnapshot) *[]LightRecord { return &s.Lines }},
+	{"strains", `SELECT id, name, code FROM strains`, true, func(s *LightSnapshot) *[]LightRecord { return &s.Strains }},
+	{"housing_units", `SELECT id, name FROM housing_units`, false, func(s *LightSnapshot) *[]LightRecord { return &s.Housing }},
+	{"protocols", `SELECT id, title, code FROM protocols`, true, func(s *LightSnapshot) *[]LightRecord { return &s.Protocols }},
+	{"projects", `SELECT id, title, code FROM projects`, true, func(s *LightSnapshot) *[]LightRecord { return &s.Projects }},
+	{"permits", `SELECT id, permit_number FROM permits`, false, func(s *LightSnapshot) *[]LightRecord { return &s.Permits }},
+	{"cohorts", `SELECT id, name FROM cohorts`, false, func(s *LightSnapshot) *[]LightRecord { return &s.Cohorts }},
+	{"breeding_units", `SELECT id, name FROM breeding_units`, false, func(s *LightSnapshot) *[]LightRecord { return &s.Breeding }},
+	{"organisms", `SELECT id, name FROM organisms`, false, func(s *LightSnapshot) *[]LightRecord { return &s.Organisms }},
+	{"procedures", `SELECT id, name FROM procedures`, false, func(s *LightSnapshot) *[]LightRecord { return &s.Procedures }},
+	{"treatments", `SELECT id, name FROM treatments`, false, func(s *LightSnapshot) *[]LightRecord { return &s.Treatments }},
+	{"samples", `SELECT id, identifier FROM samples`, false, func(s *LightSnapshot) *[]LightRecord { return &s.Samples }},
+	{"supply_items", `SELECT id, name, sku FROM supply_items`, true, func(s *LightSnapshot) *[]LightRecord { return &s.Supplies }},
+}
+
+// LoadLightSnapshot loads the identity projection of every entity table. It
+// reads only scalar ID, name, and code columns and never touches join tables
+// or JSON columns, so it is much cheaper than ExportState when a caller only
+// needs to list records. All tables are read in one read-only repeatable-read
+// transaction, so the buckets reflect a single committed state. See
+// LightSnapshot for what it omits.
+func (s *Store) LoadLightSnapshot(ctx context.Context) (LightSnapshot, error) {
+	return loadLightSnapshot(ctx, s.db)
+}
+
+func loadLightSnapshot(ctx context.Context, db *sql.DB) (LightSnapshot, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return LightSnapshot{}, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	snapshot, err := loadLightBuckets(ctx, tx)
+	if err != nil {
+		return LightSnapshot{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return LightSnapshot{}, fmt.Errorf("commit tx: %w", err)
+	}
+	return snapshot, nil
+}
+
+// loadLightBuckets runs every light query against db, which callers bind to a
+// single transaction.
+func loadLightBuckets(ctx context.Context, db execQuerier) (LightSnapshot, error) {
+	var snapshot LightSnapshot
+	for _, q := range lightQueries {
+		records, err := loadLightRecords(ctx, db, q)
+		if err != nil {
+			return LightSnapshot{}, err
+		}
+		*q.bucket(&snapshot) = records
+	}
+	return snapshot, nil
+}
+
+func loadLightRecords(ctx context.Context, db execQuerier, q lightQuery) ([]LightRecord, error) {
+	rows, err := db.QueryContext(ctx, q.query)
+	if err != nil {
+		return nil, fmt.Errorf("select light %s: %w", q.table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	records := []LightRecord{}
+	for rows.Next() {
+		var record LightRecord
+		dest := []any{&record.ID, &record.Name}
+		if q.hasCode {
+			dest = append(dest, &record.Code)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan light %s: %w", q.table, err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate light %s: %w", q.table, err)
+	}
+	slices.SortFunc(records, func(a, b LightRecord) int { return strings.Compare(a.ID, b.ID) })
+	return records, nil
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	pgtu "colonycore/internal/infra/persistence/postgres/testutil"
+	"colonycore/pkg/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadLightSnapshotProjectsIdentityColumns(t *testing.T) {
+	ctx := context.Background()
+	db, conn := pgtu.NewStubDB()
+	fixture := loadFixtureSnapshot(t)
+	if err := persistNormalized(ctx, db, fixture); err != nil {
+		t.Fatalf("seed fixture: %v", err)
+	}
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+	store, err := NewStore("", domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	conn.Queries = nil
+	light, err := store.LoadLightSnapshot(ctx)
+	if err != nil {
+		t.Fatalf("LoadLightSnapshot: %v", err)
+	}
+
+	for _, query := range conn.Queries {
+		table, _, _ := strings.Cut(strings.TrimSpace(query[strings.Index(strings.ToLower(query), " from ")+6:]), " ")
+		if strings.Contains(table, "__") {
+			t.Fatalf("light load queried join table %s: %s", table, query)
+		}
+		for _, column := range []string{"attributes", "environment_baselines", "data", "chain_of_custody", "dosage_plan"} {
+			if strings.Contains(query, column) {
+				t.Fatalf("light load selected JSON column %s: %s", column, query)
+			}
+		}
+	}
+	if len(conn.Queries) != len(lightQueries) {
+		t.Fatalf("expected one query per table, got %d", len(conn.Queries))
+	}
+
+	if len(light.Organisms) != len(fixture.Organisms) || len(light.Facilities) != len(fixture.Facilities) || len(light.Samples) != len(fixture.Samples) {
+		t.Fatalf("expected light buckets to match fixture counts, got %+v", light)
+	}
+	for i, record := range light.Organisms {
+		organism, ok := fixture.Organisms[record.ID]
+		if !ok || record.Name != organism.Name {
+			t.Fatalf("unexpected organism record %+v", record)
+		}
+		if i > 0 && light.Organisms[i-1].ID >= record.ID {
+			t.Fatalf("expected organisms sorted by id")
+		}
+	}
+	for _, record := range light.Facilities {
+		if facility := fixture.Facilities[record.ID]; record.Name != facility.Name || record.Code != facility.Code {
+			t.Fatalf("unexpected facility record %+v", record)
+		}
+	}
+	for _, record := range light.Samples {
+		if sample := fixture.Samples[record.ID]; record.Name != sample.Identifier || record.Code != "" {
+			t.Fatalf("unexpected sample record %+v", record)
+		}
+	}
+	for _, record := range light.Protocols {
+		if protocol := fixture.Protocols[record.ID]; record.Name != protocol.Title || record.Code != protocol.Code {
+			t.Fatalf("unexpected protocol record %+v", record)
+		}
+	}
+	for _, record := range light.Supplies {
+		if supply := fixture.Supplies[record.ID]; record.Name != supply.Name || record.Code != supply.SKU {
+			t.Fatalf("unexpected supply record %+v", record)
+		}
+	}
+}
+
+func TestLoadLightSnapshotReportsQueryErrors(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	conn.FailTables = map[string]bool{"strains": true}
+	if _, err := loadLightSnapshot(context.Background(), db); err == nil || !strings.Contains(err.Error(), "select light strains") {
+		t.Fatalf("expected strains query error, got %v", err)
+	}
+
+	db, conn = pgtu.NewStubDB()
+	conn.RowsErr = errors.New("boom")
+	if _, err := loadLightSnapshot(context.Background(), db); err == nil {
+		t.Fatalf("expected rows error")
+	}
+
+	db, conn = pgtu.NewStubDB()
+	conn.FailBegin = true
+	if _, err := loadLightSnapshot(context.Background(), db); err == nil || !strings.Contains(err.Error(), "begin tx") {
+		t.Fatalf("expected begin error, got %v", err)
+	}
+
+	db, conn = pgtu.NewStubDB()
+	conn.FailCommit = true
+	if _, err := loadLightSnapshot(context.Background(), db); err == nil || !strings.Contains(err.Error(), "commit tx") {
+		t.Fatalf("expected commit error, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+
+	"colonycore/internal/infra/persistence/memory"
+	"colonycore/pkg/domain"
+)
+
+// WithMaxLineageDepth caps how many generations Ancestors and Descendants walk.
+// See memory.WithMaxLineageDepth.
+func WithMaxLineageDepth(n int) Option {
+	return func(s *Store) {
+		if n > 0 {
+			s.maxLineageDepth = n
+		}
+	}
+}
+
+// Ancestors returns the organisms reachable through ParentIDs from the given
+// organism, nearest generation first. It reads only the organism rows and
+// their parent join rows; errors reading them are returned rather than
+// answered from the cache. See memory.Store.Ancestors for the depth rules.
+func (s *Store) Ancestors(organismID string, maxDepth int) ([]domain.Organism, error) {
+	organisms, err := s.loadLineage(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return memory.WalkAncestors(organisms, organismID, maxDepth, s.maxLineageDepth)
+}
+
+// Descendants returns the organisms that list the given organism as an
+// ancestor, nearest generation first. It reads the database as Ancestors does.
+func (s *Store) Descendants(organismID string, maxDepth int) ([]domain.Organism, error) {
+	organisms, err := s.loadLineage(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return memory.WalkDescendants(organisms, organismID, maxDepth, s.maxLineageDepth)
+}
+
+func (s *Store) loadLineage(ctx context.Context) (map[string]domain.Organism, error) {
+	organisms, err := loadOrganisms(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadOrganismParents(ctx, s.db, organisms); err != nil {
+		return nil, err
+	}
+	return organisms, nil
+}
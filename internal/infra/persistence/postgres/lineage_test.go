@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"colonycore/internal/infra/persistence/memory"
+	pgtu "colonycore/internal/infra/persistence/postgres/testutil"
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+// newLineageStore seeds org-0 <- org-1 <- ... <- org-(n-1) into a stub
+// database and opens a store over it.
+func newLineageStore(t *testing.T, n int, opts ...Option) (*Store, *pgtu.StubConn) {
+	t.Helper()
+	db, conn := pgtu.NewStubDB()
+	snapshot := memory.Snapshot{Organisms: make(map[string]domain.Organism, n)}
+	for i := 0; i < n; i++ {
+		organism := entitymodel.Organism{ID: fmt.Sprintf("org-%d", i), Name: fmt.Sprintf("Frog %d", i), Species: "Xenopus"}
+		if i > 0 {
+			organism.ParentIDs = []string{fmt.Sprintf("org-%d", i-1)}
+		}
+		snapshot.Organisms[organism.ID] = domain.Organism{Organism: organism}
+	}
+	if err := persistNormalized(context.Background(), db, snapshot); err != nil {
+		t.Fatalf("seed lineage: %v", err)
+	}
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	t.Cleanup(restore)
+	store, err := NewStore("", domain.NewRulesEngine(), opts...)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	return store, conn
+}
+
+func TestLineageTraversal(t *testing.T) {
+	store, _ := newLineageStore(t, 10, WithMaxLineageDepth(5))
+
+	ancestors, err := store.Ancestors("org-4", 0)
+	if err != nil {
+		t.Fatalf("expected within-cap ancestors to succeed: %v", err)
+	}
+	if len(ancestors) != 4 || ancestors[0].ID != "org-3" || ancestors[3].ID != "org-0" {
+		t.Fatalf("unexpected ancestors %+v", ancestors)
+	}
+	if _, err := store.Ancestors("org-9", 0); !errors.Is(err, memory.ErrLineageDepthExceeded) {
+		t.Fatalf("expected cap to fail the walk, got %v", err)
+	}
+	ancestors, err = store.Ancestors("org-9", 2)
+	if err != nil || len(ancestors) != 2 || ancestors[1].ID != "org-7" {
+		t.Fatalf("expected a caller depth to truncate quietly, got %+v (%v)", ancestors, err)
+	}
+
+	descendants, err := store.Descendants("org-5", 0)
+	if err != nil || len(descendants) != 4 || descendants[0].ID != "org-6" {
+		t.Fatalf("unexpected descendants %+v (%v)", descendants, err)
+	}
+	if _, err := store.Descendants("org-0", 0); !errors.Is(err, memory.ErrLineageDepthExceeded) {
+		t.Fatalf("expected cap to fail the descendant walk, got %v", err)
+	}
+	if _, err := store.Ancestors("missing", 0); err == nil {
+		t.Fatalf("expected unknown organism to fail")
+	}
+}
+
+func TestLineagePropagatesDatabaseErrors(t *testing.T) {
+	store, conn := newLineageStore(t, 3)
+	conn.FailTables = map[string]bool{"organisms__parent_ids": true}
+	if _, err := store.Ancestors("org-2", 0); err == nil {
+		t.Fatalf("expected a failed parent read to fail Ancestors")
+	}
+	conn.FailTables = map[string]bool{"organisms": true}
+	if _, err := store.Descendants("org-0", 0); err == nil {
+		t.Fatalf("expected a failed organism read to fail Descendants")
+	}
+}
@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"context"
+
+	"colonycore/internal/infra/persistence/memory"
+)
+
+// SelfCheck reports references to missing records, entities that fail
+// write-time validation, and natural keys shared by more than one record, as
+// memory.Store.SelfCheck does. It reads the normalized tables directly,
+// refreshing the cache, and returns load errors instead of checking the cached
+// snapshot. Scoped natural keys are grouped by domain.DefaultScopeResolver,
+// the scoping the unique indexes in the DDL enforce.
+func (s *Store) SelfCheck(ctx context.Context) (memory.SelfCheckReport, error) {
+	s.mu.Lock()
+	snap, err := loadNormalizedSnapshot(ctx, s.db)
+	if err == nil {
+		s.cache = snap
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return memory.SelfCheckReport{}, err
+	}
+	return memory.CheckSnapshot(ctx, snap, nil)
+}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	pgtu "colonycore/internal/infra/persistence/postgres/testutil"
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestSelfCheckReportsDanglingReferenceAndDuplicateCode(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+	store, err := NewStore("ignored", domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "VIV", Name: "Vivarium"}})
+		if err != nil {
+			return err
+		}
+		_, err = tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: facility.ID, Capacity: 2}})
+		return err
+	}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	report, err := store.SelfCheck(ctx)
+	if err != nil {
+		t.Fatalf("self check: %v", err)
+	}
+	if !report.Passed {
+		t.Fatalf("expected consistent store to pass, got %+v", report)
+	}
+
+	conn.Tables["housing_units"][0]["facility_id"] = "facility-gone"
+	duplicate := make(map[string]any, len(conn.Tables["facilities"][0]))
+	for column, value := range conn.Tables["facilities"][0] {
+		duplicate[column] = value
+	}
+	duplicate["id"] = "dup"
+	conn.Tables["facilities"] = append(conn.Tables["facilities"], duplicate)
+
+	report, err = store.SelfCheck(ctx)
+	if err != nil {
+		t.Fatalf("self check: %v", err)
+	}
+	if report.Passed {
+		t.Fatalf("expected self check to fail, got %+v", report)
+	}
+	if len(report.ReferentialIntegrity) != 1 || !strings.Contains(report.ReferentialIntegrity[0], `facility_id references missing facility "facility-gone"`) {
+		t.Fatalf("expected dangling facility reference, got %v", report.ReferentialIntegrity)
+	}
+	if len(report.NaturalKeys) != 1 || !strings.Contains(report.NaturalKeys[0], `facility (code) "VIV" shared by`) {
+		t.Fatalf("expected duplicate facility code, got %v", report.NaturalKeys)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := store.SelfCheck(cancelled); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancellation, got %v", err)
+	}
+	conn.FailTables = map[string]bool{"facilities": true}
+	if _, err := store.SelfCheck(ctx); err == nil {
+		t.Fatalf("expected load failure to be returned")
+	}
+}
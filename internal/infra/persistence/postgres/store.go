@@ -12,9 +12,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib" // register pgx as a database/sql driver
@@ -22,6 +25,7 @@ import (
 
 // Compile-time contract assertion ensuring the store satisfies the domain interface.
 var _ domain.PersistentStore = (*Store)(nil)
+var _ domain.VerifiedReader = (*Store)(nil)
 
 const (
 	defaultDriver = "pgx"
@@ -36,17 +40,107 @@ var (
 
 // Store persists state to Postgres while executing CRUD directly against the generated DDL.
 // It still uses the in-memory transaction engine for rule evaluation but commits deltas to
-// the normalized tables instead of snapshot mirroring.
+// the normalized tables instead of snapshot mirroring. Scoped natural keys are
+// always resolved with domain.DefaultScopeResolver, because the DDL's unique
+// indexes (for example housing_units (facility_id, name)) fix the same scopes;
+// a custom resolver would let writes through that the database then rejects.
+//
+// Reads that target a subset of rows (GetLineByID, ListSamplesByFacility,
+// ActiveStrainCount, GetFacilityByCode, ...) return errors reading the
+// database. The PersistentStore getters and lists have no error result, so
+// like GetOrganism they serve the last good cache when the database cannot be
+// read; GetLine, GetStrain, GetBreedingUnit, and GetSupplyItem wrap the
+// corresponding *ByID method that way.
 type Store struct {
 	db     *sql.DB
 	engine *domain.RulesEngine
 	mu     sync.Mutex
 	cache  memory.Snapshot
+	blobs  domain.AttachmentBlobStore
+
+	// permitSkew is handed to the memory store that evaluates rules.
+	permitSkew time.Duration
+	// idPrefixes is handed to the memory store that assigns new IDs.
+	idPrefixes map[domain.EntityType]string
+	// verifyOnRead makes GetVerified and ListVerified re-validate records.
+	verifyOnRead bool
+	// maxLineageDepth caps Ancestors and Descendants walks.
+	maxLineageDepth int
+	// maxAttributeDepth and maxAttributeKeys are handed to the memory store
+	// that checks plugin extension payloads.
+	maxAttributeDepth int
+	maxAttributeKeys  int
+
+	// txSlots bounds concurrent RunInTransaction calls when non-nil.
+	txSlots  chan struct{}
+	inFlight atomic.Int64
+
+	// observers receive the changes of each committed transaction.
+	observers []domain.ChangeSink
+}
+
+// Option configures optional Store behaviour.
+type Option func(*Store)
+
+// WithAttachmentBlobs configures the blob store consulted when observation
+// attachments are linked.
+func WithAttachmentBlobs(blobs domain.AttachmentBlobStore) Option {
+	return func(s *Store) {
+		s.blobs = blobs
+	}
+}
+
+// WithPermitSkew sets the clock-skew tolerance that rule views report through
+// domain.PermitSkew. Negative values are treated as zero.
+func WithPermitSkew(skew time.Duration) Option {
+	return func(s *Store) {
+		s.permitSkew = max(skew, 0)
+	}
+}
+
+// PermitSkew returns the permit clock-skew tolerance configured with
+// WithPermitSkew.
+func (s *Store) PermitSkew() time.Duration {
+	return s.permitSkew
+}
+
+// WithIDPrefixes prefixes generated IDs by entity type. See
+// memory.WithIDPrefixes.
+func WithIDPrefixes(prefixes map[domain.EntityType]string) Option {
+	return func(s *Store) {
+		s.idPrefixes = maps.Clone(prefixes)
+	}
+}
+
+// WithAttributeLimits bounds the nesting depth and total key count of each
+// plugin payload stored in extension attributes. See
+// memory.WithAttributeLimits.
+func WithAttributeLimits(maxDepth, maxKeys int) Option {
+	return func(s *Store) {
+		s.maxAttributeDepth = max(maxDepth, 0)
+		s.maxAttributeKeys = max(maxKeys, 0)
+	}
+}
+
+// WithMaxConcurrentTransactions caps the number of RunInTransaction calls that
+// may proceed at once. Callers beyond the limit wait for a free slot or for
+// their context to be cancelled, returning ctx.Err() in the latter case.
+// Admitted transactions still commit one at a time under the store mutex, so
+// the limit bounds how many callers can pile up behind it. Reads are not
+// throttled. Values below one leave transactions unbounded.
+func WithMaxConcurrentTransactions(n int) Option {
+	return func(s *Store) {
+		if n < 1 {
+			s.txSlots = nil
+			return
+		}
+		s.txSlots = make(chan struct{}, n)
+	}
 }
 
 // NewStore opens a Postgres-backed store using the provided DSN (falls back to defaultDSN).
 // It applies the generated entity-model DDL and hydrates an in-memory snapshot cache from Postgres.
-func NewStore(dsn string, engine *domain.RulesEngine) (*Store, error) {
+func NewStore(dsn string, engine *domain.RulesEngine, opts ...Option) (*Store, error) {
 	if dsn == "" {
 		dsn = defaultDSN
 	}
@@ -67,16 +161,63 @@ func NewStore(dsn string, engine *domain.RulesEngine) (*Store, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Store{
-		db:     db,
-		engine: engine,
-		cache:  cache,
+	if err := stampSchemaVersion(ctx, db); err != nil {
+		return nil, err
+	}
+	s := &Store{
+		db:              db,
+		engine:          engine,
+		cache:           cache,
+		maxLineageDepth: memory.DefaultMaxLineageDepth,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// AttachmentBlobs returns the configured attachment blob store, or nil.
+func (s *Store) AttachmentBlobs() domain.AttachmentBlobStore {
+	return s.blobs
+}
+
+// InFlightTransactions reports how many RunInTransaction calls currently hold
+// a concurrency slot, excluding callers still queued for one.
+func (s *Store) InFlightTransactions() int {
+	return int(s.inFlight.Load())
+}
+
+// acquireTxSlot blocks until a transaction slot is free or ctx is done. The
+// returned release func must be called once the transaction finishes.
+func (s *Store) acquireTxSlot(ctx context.Context) (func(), error) {
+	if s.txSlots != nil {
+		select {
+		case s.txSlots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	s.inFlight.Add(1)
+	return func() {
+		s.inFlight.Add(-1)
+		if s.txSlots != nil {
+			<-s.txSlots
+		}
 	}, nil
 }
 
 // RunInTransaction evaluates the user-supplied function against an in-memory transaction
 // and persists the resulting delta directly to the normalized schema inside a single DB transaction.
 func (s *Store) RunInTransaction(ctx context.Context, fn func(domain.Transaction) error) (domain.Result, error) {
+	release, err := s.acquireTxSlot(ctx)
+	if err != nil {
+		return domain.Result{}, err
+	}
+	defer release()
+
+	var published committedTransaction
+	defer func() { published.notify(ctx) }()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -96,33 +237,178 @@ func (s *Store) RunInTransaction(ctx context.Context, fn func(domain.Transaction
 		return domain.Result{}, err
 	}
 
-	mem := memory.NewStore(s.engine)
-	mem.ImportState(before)
+	mem := memory.NewStore(s.engine, memory.WithAttachmentBlobs(s.blobs), memory.WithPermitSkew(s.permitSkew), memory.WithIDPrefixes(s.idPrefixes), memory.WithAttributeLimits(s.maxAttributeDepth, s.maxAttributeKeys))
+	if err := mem.ImportState(before); err != nil {
+		return domain.Result{}, err
+	}
 
+	var changes []domain.Change
+	mem.AddCommitObserver(domain.ChangeSinkFunc(func(_ context.Context, batch []domain.Change) error {
+		changes = batch
+		return nil
+	}))
 	res, err := mem.RunInTransaction(ctx, fn)
 	if err != nil {
 		return res, err
 	}
-	after := mem.ExportState()
-
-	if err := applySnapshotDelta(ctx, tx, before, after); err != nil {
+	d, err := changeLogDelta(changes)
+	if err != nil {
+		return res, err
+	}
+	if err := applyDelta(ctx, tx, d); err != nil {
 		return res, err
 	}
 	if err := tx.Commit(); err != nil {
 		return res, fmt.Errorf("commit: %w", err)
 	}
 	committed = true
-	s.cache = after
+	s.cache = mem.ExportState()
+	published = committedTransaction{changes: changes, observers: s.observers}
 	return res, nil
 }
 
+// ApplyChangeLog writes an ordered change log, such as the changes a memory
+// store publishes to its commit observers, to the normalized tables in one
+// database transaction. Changes are folded per record so only the last state
+// of each record is written, then deletes run from leaf to root and upserts
+// from root to leaf to satisfy foreign keys. Unlike the snapshot diff used for
+// imports, no full snapshot is loaded or compared. The cached snapshot is
+// patched with the same changes.
+func (s *Store) ApplyChangeLog(ctx context.Context, changes []domain.Change) error {
+	d, err := changeLogDelta(changes)
+	if err != nil {
+		return err
+	}
+	release, err := s.acquireTxSlot(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+	if err := applyDelta(ctx, tx, d); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	committed = true
+	s.cache = d.patch(cloneSnapshot(s.cache))
+	return nil
+}
+
 // DB exposes the underlying sql.DB for integration testing hooks.
 func (s *Store) DB() *sql.DB { return s.db }
 
 func applyEntityModelDDL(ctx context.Context, db *sql.DB) error {
+	if err := applyDDLStatements(ctx, db, schemaVersionDDL); err != nil {
+		return err
+	}
+	if err := checkStoredSchema(ctx, db); err != nil {
+		return err
+	}
 	return applyDDLStatements(ctx, db, sqlbundle.Postgres())
 }
 
+// ErrSchemaMigrationRequired reports a database whose tables were created by
+// an older entity-model schema. No in-place migrations ship, and the bundle's
+// IF NOT EXISTS statements would leave the old tables without newer columns
+// and constraints, so the store refuses to open it.
+var ErrSchemaMigrationRequired = errors.New("postgres tables predate this schema version; migrate or recreate the database")
+
+// checkStoredSchema refuses databases whose tables an older binary created:
+// those stamped with an older schema version, and those holding entity tables
+// without a stamp, which predate version stamping. Newer stamps are left to
+// loadNormalizedSnapshot, which reports memory.ErrSnapshotTooNew.
+func checkStoredSchema(ctx context.Context, db execQuerier) error {
+	version, err := loadSchemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	if version == "" {
+		legacy, err := tableExists(ctx, db, "facilities")
+		if err != nil {
+			return err
+		}
+		if legacy {
+			return fmt.Errorf("%w: unversioned tables, binary %s", ErrSchemaMigrationRequired, entitymodel.SchemaVersion)
+		}
+		return nil
+	}
+	cmp, err := memory.CompareSchemaVersions(version, entitymodel.SchemaVersion)
+	if err != nil {
+		return err
+	}
+	if cmp < 0 {
+		return fmt.Errorf("%w: database %s, binary %s", ErrSchemaMigrationRequired, version, entitymodel.SchemaVersion)
+	}
+	return nil
+}
+
+// tableExists reports whether table exists in the connection's current schema.
+func tableExists(ctx context.Context, db execQuerier, table string) (bool, error) {
+	rows, err := db.QueryContext(ctx, `SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1`, table)
+	if err != nil {
+		return false, fmt.Errorf("look up table %s: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+	found := rows.Next()
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("look up table %s: %w", table, err)
+	}
+	return found, nil
+}
+
+// schemaVersionDDL creates the single-row table recording the newest
+// entity-model schema version that has opened the database.
+const schemaVersionDDL = `CREATE TABLE IF NOT EXISTS colonycore_schema_version (
+    id integer PRIMARY KEY CHECK (id = 1),
+    version text NOT NULL
+)`
+
+// loadSchemaVersion returns the stamped schema version, or "" when the
+// database predates version stamping.
+func loadSchemaVersion(ctx context.Context, db execQuerier) (string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT version FROM colonycore_schema_version`)
+	if err != nil {
+		return "", fmt.Errorf("select schema version: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var version string
+	for rows.Next() {
+		if err := rows.Scan(&version); err != nil {
+			return "", fmt.Errorf("scan schema version: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("iterate schema version: %w", err)
+	}
+	return version, nil
+}
+
+// stampSchemaVersion records this binary's schema version once the stored
+// data has passed memory.CheckSnapshotVersion, so older binaries refuse the
+// database from then on.
+func stampSchemaVersion(ctx context.Context, db execQuerier) error {
+	const stmt = `INSERT INTO colonycore_schema_version (id, version) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET version = EXCLUDED.version`
+	if _, err := db.ExecContext(ctx, stmt, 1, entitymodel.SchemaVersion); err != nil {
+		return fmt.Errorf("stamp schema version: %w", err)
+	}
+	return nil
+}
+
 // snapshotOrCache returns the latest database snapshot or falls back to the last good cache.
 func (s *Store) snapshotOrCache(ctx context.Context) memory.Snapshot {
 	s.mu.Lock()
@@ -138,8 +424,10 @@ func (s *Store) snapshotOrCache(ctx context.Context) memory.Snapshot {
 // View executes fn against a read-only snapshot of the Postgres-backed state.
 func (s *Store) View(ctx context.Context, fn func(domain.TransactionView) error) error {
 	snapshot := s.snapshotOrCache(ctx)
-	mem := memory.NewStore(s.engine)
-	mem.ImportState(snapshot)
+	mem := memory.NewStore(s.engine, memory.WithPermitSkew(s.permitSkew))
+	if err := mem.ImportState(snapshot); err != nil {
+		return err
+	}
 	return mem.View(ctx, fn)
 }
 
@@ -179,30 +467,101 @@ func (s *Store) ListFacilities() []domain.Facility {
 	return mapValues(s.snapshotOrCache(context.Background()).Facilities)
 }
 
-// GetLine returns a line by ID.
+// GetLine returns a line by ID via GetLineByID, falling back to the cached
+// snapshot when the database cannot be read.
 func (s *Store) GetLine(id string) (domain.Line, bool) {
-	snap := s.snapshotOrCache(context.Background())
-	l, ok := snap.Lines[id]
+	l, ok, err := s.GetLineByID(id)
+	if err == nil {
+		return l, ok
+	}
+	l, ok = cachedEntry(s, id, func(snap memory.Snapshot) map[string]domain.Line { return snap.Lines })
+	l.GenotypeMarkerIDs = append([]string(nil), l.GenotypeMarkerIDs...)
+	l.Tags = append([]string(nil), l.Tags...)
 	return l, ok
 }
 
+// GetLineByID returns a line by ID, loading only its row and genotype marker
+// join rows and refreshing that entry in the cache. Errors reading the
+// database are returned.
+func (s *Store) GetLineByID(id string) (domain.Line, bool, error) {
+	lines, err := loadSingleLine(context.Background(), s.db, id)
+	if err != nil {
+		return domain.Line{}, false, err
+	}
+	s.mu.Lock()
+	s.cache.Lines = refreshCacheEntry(s.cache.Lines, id, lines)
+	s.mu.Unlock()
+	l, ok := lines[id]
+	if !ok {
+		return domain.Line{}, false, nil
+	}
+	l.GenotypeMarkerIDs = append([]string(nil), l.GenotypeMarkerIDs...)
+	l.Tags = append([]string(nil), l.Tags...)
+	return l, true, nil
+}
+
 // ListLines returns all lines.
 func (s *Store) ListLines() []domain.Line {
 	return mapValues(s.snapshotOrCache(context.Background()).Lines)
 }
 
-// GetStrain returns a strain by ID.
+// GetStrain returns a strain by ID via GetStrainByID, falling back to the
+// cached snapshot when the database cannot be read.
 func (s *Store) GetStrain(id string) (domain.Strain, bool) {
-	snap := s.snapshotOrCache(context.Background())
-	st, ok := snap.Strains[id]
+	st, ok, err := s.GetStrainByID(id)
+	if err == nil {
+		return st, ok
+	}
+	st, ok = cachedEntry(s, id, func(snap memory.Snapshot) map[string]domain.Strain { return snap.Strains })
+	st.GenotypeMarkerIDs = append([]string(nil), st.GenotypeMarkerIDs...)
 	return st, ok
 }
 
+// GetStrainByID returns a strain by ID, loading only its row and genotype
+// marker join rows and refreshing that entry in the cache. Errors reading the
+// database are returned.
+func (s *Store) GetStrainByID(id string) (domain.Strain, bool, error) {
+	strains, err := loadSingleStrain(context.Background(), s.db, id)
+	if err != nil {
+		return domain.Strain{}, false, err
+	}
+	s.mu.Lock()
+	s.cache.Strains = refreshCacheEntry(s.cache.Strains, id, strains)
+	s.mu.Unlock()
+	st, ok := strains[id]
+	if !ok {
+		return domain.Strain{}, false, nil
+	}
+	st.GenotypeMarkerIDs = append([]string(nil), st.GenotypeMarkerIDs...)
+	return st, true, nil
+}
+
 // ListStrains returns all strains.
 func (s *Store) ListStrains() []domain.Strain {
 	return mapValues(s.snapshotOrCache(context.Background()).Strains)
 }
 
+// ActiveStrainCount returns the number of non-retired strains on lineID using a
+// COUNT query. Errors running the query are returned. Views obtained through
+// View count against their snapshot instead.
+func (s *Store) ActiveStrainCount(lineID string) (int, error) {
+	var count int
+	if err := s.db.QueryRowContext(context.Background(), countActiveStrainsSQL, lineID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count active strains: %w", err)
+	}
+	return count, nil
+}
+
+// ActiveLineCount returns the number of non-deprecated lines using a COUNT
+// query. Errors running the query are returned.
+func (s *Store) ActiveLineCount() (int, error) {
+	var count int
+	if err := s.db.QueryRowContext(context.Background(), countActiveLinesSQL).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count active lines: %w", err)
+	}
+	return count, nil
+}
+
 // GetGenotypeMarker returns a genotype marker by ID.
 func (s *Store) GetGenotypeMarker(id string) (domain.GenotypeMarker, bool) {
 	snap := s.snapshotOrCache(context.Background())
@@ -225,16 +584,116 @@ func (s *Store) ListTreatments() []domain.Treatment {
 	return mapValues(s.snapshotOrCache(context.Background()).Treatments)
 }
 
+// ListTreatmentsByProcedure returns the treatments linked to procedureID,
+// ordered by ID, via the procedure_id index. Errors reading the database are
+// returned.
+func (s *Store) ListTreatmentsByProcedure(procedureID string) ([]domain.Treatment, error) {
+	ctx := context.Background()
+	treatments, err := loadTreatmentsWhere(ctx, s.db, selectTreatmentsByProcedureSQL, procedureID)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadTreatmentCohortsWhere(ctx, s.db, treatments, selectTreatmentCohortsByProcedureSQL, procedureID); err != nil {
+		return nil, err
+	}
+	if err := loadTreatmentOrganismsWhere(ctx, s.db, treatments, selectTreatmentOrgsByProcedureSQL, procedureID); err != nil {
+		return nil, err
+	}
+	out := mapValues(treatments)
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
 // ListObservations returns all observations.
 func (s *Store) ListObservations() []domain.Observation {
 	return mapValues(s.snapshotOrCache(context.Background()).Observations)
 }
 
+// ListObservationsByOrganism returns observations recorded directly against
+// organismID that satisfy every filter, loading candidates via the organism_id
+// index. Errors reading the database are returned.
+func (s *Store) ListObservationsByOrganism(organismID string, filters ...domain.ObservationFilter) ([]domain.Observation, error) {
+	observations, err := s.listObservationsWhere(context.Background(), selectObservationsByOrganismSQL, organismID)
+	if err != nil {
+		return nil, err
+	}
+	out := observations[:0]
+	for _, o := range observations {
+		if domain.MatchesObservationFilters(o, filters...) {
+			out = append(out, o)
+		}
+	}
+	return out, nil
+}
+
+// ListObservationsByCohort returns observations recorded directly against
+// cohortID via the cohort_id index. Errors reading the database are returned.
+func (s *Store) ListObservationsByCohort(cohortID string) ([]domain.Observation, error) {
+	return s.listObservationsWhere(context.Background(), selectObservationsByCohortSQL, cohortID)
+}
+
+// ListObservationsBetween returns observations recorded in the half-open
+// interval [from, to), ordered by recorded_at, via the recorded_at index.
+// Errors reading the database are returned.
+func (s *Store) ListObservationsBetween(from, to time.Time) ([]domain.Observation, error) {
+	return s.listObservationsWhere(context.Background(), selectObservationsBetweenSQL, from.UTC(), to.UTC())
+}
+
+func (s *Store) listObservationsWhere(ctx context.Context, query string, args ...any) ([]domain.Observation, error) {
+	observations, err := loadObservationsWhere(ctx, s.db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return sortedObservations(mapValues(observations)), nil
+}
+
+func sortedObservations(observations []domain.Observation) []domain.Observation {
+	sort.Slice(observations, func(i, j int) bool {
+		if !observations[i].RecordedAt.Equal(observations[j].RecordedAt) {
+			return observations[i].RecordedAt.Before(observations[j].RecordedAt)
+		}
+		return observations[i].ID < observations[j].ID
+	})
+	return observations
+}
+
 // ListSamples returns all samples.
 func (s *Store) ListSamples() []domain.Sample {
 	return mapValues(s.snapshotOrCache(context.Background()).Samples)
 }
 
+// ListSamplesByFacility returns samples stored at facilityID, ordered by ID,
+// via the facility_id column. Samples are normalized as on import, so the
+// result matches the TransactionView method; rows the import would drop are
+// skipped. Errors reading the database are returned.
+func (s *Store) ListSamplesByFacility(facilityID string) ([]domain.Sample, error) {
+	return s.listSamplesWhere(context.Background(), selectSamplesByFacilitySQL, facilityID)
+}
+
+// ListSamplesByOrganism returns samples collected directly from organismID,
+// ordered by ID, via the organism_id column. Samples linked only through a
+// cohort are excluded. Errors reading the database are returned.
+func (s *Store) ListSamplesByOrganism(organismID string) ([]domain.Sample, error) {
+	return s.listSamplesWhere(context.Background(), selectSamplesByOrganismSQL, organismID)
+}
+
+func (s *Store) listSamplesWhere(ctx context.Context, query, id string) ([]domain.Sample, error) {
+	samples, err := loadSamplesWhere(ctx, s.db, query, id)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.Sample, 0, len(samples))
+	for _, sample := range samples {
+		normalized, err := memory.NormalizeSample(sample)
+		if err != nil {
+			continue
+		}
+		out = append(out, normalized)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
 // ListProtocols returns all protocols.
 func (s *Store) ListProtocols() []domain.Protocol {
 	return mapValues(s.snapshotOrCache(context.Background()).Protocols)
@@ -257,21 +716,202 @@ func (s *Store) ListProjects() []domain.Project {
 	return mapValues(s.snapshotOrCache(context.Background()).Projects)
 }
 
+// GetBreedingUnit returns a breeding unit by ID via GetBreedingUnitByID,
+// falling back to the cached snapshot when the database cannot be read.
+func (s *Store) GetBreedingUnit(id string) (domain.BreedingUnit, bool) {
+	unit, ok, err := s.GetBreedingUnitByID(id)
+	if err == nil {
+		return unit, ok
+	}
+	unit, ok = cachedEntry(s, id, func(snap memory.Snapshot) map[string]domain.BreedingUnit { return snap.Breeding })
+	unit.FemaleIDs = append([]string(nil), unit.FemaleIDs...)
+	unit.MaleIDs = append([]string(nil), unit.MaleIDs...)
+	return unit, ok
+}
+
+// GetBreedingUnitByID returns a breeding unit by ID, loading only its row and
+// member join rows. Errors reading the database are returned.
+func (s *Store) GetBreedingUnitByID(id string) (domain.BreedingUnit, bool, error) {
+	ctx := context.Background()
+	breeding, err := loadBreedingUnitsWhere(ctx, s.db, selectBreedingByIDSQL, id)
+	if err != nil {
+		return domain.BreedingUnit{}, false, err
+	}
+	if err := loadBreedingUnitMembersWhere(ctx, s.db, breeding, selectBreedingFemalesByIDSQL, selectBreedingMalesByIDSQL, id); err != nil {
+		return domain.BreedingUnit{}, false, err
+	}
+	unit, ok := breeding[id]
+	if !ok {
+		return domain.BreedingUnit{}, false, nil
+	}
+	unit.FemaleIDs = append([]string(nil), unit.FemaleIDs...)
+	unit.MaleIDs = append([]string(nil), unit.MaleIDs...)
+	return unit, true, nil
+}
+
 // ListBreedingUnits returns all breeding units.
 func (s *Store) ListBreedingUnits() []domain.BreedingUnit {
 	return mapValues(s.snapshotOrCache(context.Background()).Breeding)
 }
 
+// ListBreedingUnitsByIntent returns the breeding units recorded with intent, ordered by ID.
+func (s *Store) ListBreedingUnitsByIntent(intent domain.PairingIntent) []domain.BreedingUnit {
+	out := make([]domain.BreedingUnit, 0)
+	for _, unit := range s.snapshotOrCache(context.Background()).Breeding {
+		if unit.PairingIntent != nil && *unit.PairingIntent == intent {
+			out = append(out, unit)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
 // ListProcedures returns all procedures.
 func (s *Store) ListProcedures() []domain.Procedure {
 	return mapValues(s.snapshotOrCache(context.Background()).Procedures)
 }
 
+// GetSupplyItem returns a supply item by ID via GetSupplyItemByID, falling
+// back to the cached snapshot when the database cannot be read.
+func (s *Store) GetSupplyItem(id string) (domain.SupplyItem, bool) {
+	item, ok, err := s.GetSupplyItemByID(id)
+	if err == nil {
+		return item, ok
+	}
+	item, ok = cachedEntry(s, id, func(snap memory.Snapshot) map[string]domain.SupplyItem { return snap.Supplies })
+	item.FacilityIDs = append([]string(nil), item.FacilityIDs...)
+	item.ProjectIDs = append([]string(nil), item.ProjectIDs...)
+	return item, ok
+}
+
+// GetSupplyItemByID returns a supply item by ID, loading its row together
+// with its facility and project join rows in one read-only transaction.
+// Errors reading the database are returned.
+func (s *Store) GetSupplyItemByID(id string) (domain.SupplyItem, bool, error) {
+	supplies, err := loadSupplyItemByID(context.Background(), s.db, id)
+	if err != nil {
+		return domain.SupplyItem{}, false, err
+	}
+	item, ok := supplies[id]
+	if !ok {
+		return domain.SupplyItem{}, false, nil
+	}
+	item.FacilityIDs = append([]string(nil), item.FacilityIDs...)
+	item.ProjectIDs = append([]string(nil), item.ProjectIDs...)
+	return item, true, nil
+}
+
+// GetFacilityByCode returns the facility with code using the unique code
+// index, loading its project join rows in the same read-only transaction.
+// Errors reading the database are returned.
+func (s *Store) GetFacilityByCode(code string) (domain.Facility, bool, error) {
+	facilities, err := loadFacilityByCode(context.Background(), s.db, code)
+	if err != nil {
+		return domain.Facility{}, false, err
+	}
+	f, ok := findByNaturalKey(facilities, func(f domain.Facility) bool { return f.Code == code })
+	if !ok {
+		return domain.Facility{}, false, nil
+	}
+	f.ProjectIDs = append([]string(nil), f.ProjectIDs...)
+	return f, true, nil
+}
+
+// GetProtocolByCode returns the protocol with code using the unique code
+// index. Errors reading the database are returned.
+func (s *Store) GetProtocolByCode(code string) (domain.Protocol, bool, error) {
+	protocols, err := loadProtocolsWhere(context.Background(), s.db, selectProtocolByCodeSQL, code)
+	if err != nil {
+		return domain.Protocol{}, false, err
+	}
+	p, ok := findByNaturalKey(protocols, func(p domain.Protocol) bool { return p.Code == code })
+	if !ok {
+		return domain.Protocol{}, false, nil
+	}
+	p.ReviewerIDs = append([]string(nil), p.ReviewerIDs...)
+	return p, true, nil
+}
+
+// GetLineByCode returns the line with code using the unique code index,
+// loading its genotype marker join rows in the same read-only transaction.
+// Errors reading the database are returned.
+func (s *Store) GetLineByCode(code string) (domain.Line, bool, error) {
+	lines, err := loadLineByCode(context.Background(), s.db, code)
+	if err != nil {
+		return domain.Line{}, false, err
+	}
+	l, ok := findByNaturalKey(lines, func(l domain.Line) bool { return l.Code == code })
+	if !ok {
+		return domain.Line{}, false, nil
+	}
+	l.GenotypeMarkerIDs = append([]string(nil), l.GenotypeMarkerIDs...)
+	l.Tags = append([]string(nil), l.Tags...)
+	return l, true, nil
+}
+
+// GetStrainByCode returns the strain with code on lineID using the unique
+// (line_id, code) index, loading its genotype marker join rows in the same
+// read-only transaction. Errors reading the database are returned.
+func (s *Store) GetStrainByCode(lineID, code string) (domain.Strain, bool, error) {
+	strains, err := loadStrainByCode(context.Background(), s.db, lineID, code)
+	if err != nil {
+		return domain.Strain{}, false, err
+	}
+	st, ok := findByNaturalKey(strains, func(st domain.Strain) bool { return st.LineID == lineID && st.Code == code })
+	if !ok {
+		return domain.Strain{}, false, nil
+	}
+	st.GenotypeMarkerIDs = append([]string(nil), st.GenotypeMarkerIDs...)
+	return st, true, nil
+}
+
+// findByNaturalKey returns the record matching a natural key, preferring the
+// lowest ID when duplicates match.
+func findByNaturalKey[T any](records map[string]T, match func(T) bool) (T, bool) {
+	var (
+		found   T
+		foundID string
+		ok      bool
+	)
+	for id, record := range records {
+		if match(record) && (!ok || id < foundID) {
+			found, foundID, ok = record, id, true
+		}
+	}
+	return found, ok
+}
+
 // ListSupplyItems returns all supply items.
 func (s *Store) ListSupplyItems() []domain.SupplyItem {
 	return mapValues(s.snapshotOrCache(context.Background()).Supplies)
 }
 
+// cachedEntry returns the cached entry for id from the map entries selects,
+// for getters that cannot report a database read error. Callers copy any
+// slices before handing the entry out.
+func cachedEntry[T any](s *Store, id string, entries func(memory.Snapshot) map[string]T) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := entries(s.cache)[id]
+	return v, ok
+}
+
+// refreshCacheEntry replaces the cached entry for id with the freshly loaded
+// one, or removes it when the database no longer has the row. Callers hold
+// s.mu.
+func refreshCacheEntry[T any](cache map[string]T, id string, loaded map[string]T) map[string]T {
+	v, ok := loaded[id]
+	if !ok {
+		delete(cache, id)
+		return cache
+	}
+	if cache == nil {
+		cache = make(map[string]T)
+	}
+	cache[id] = v
+	return cache
+}
+
 func mapValues[T any](m map[string]T) []T {
 	out := make([]T, 0, len(m))
 	for _, v := range m {
@@ -282,22 +922,23 @@ func mapValues[T any](m map[string]T) []T {
 
 func cloneSnapshot(s memory.Snapshot) memory.Snapshot {
 	out := memory.Snapshot{
-		Organisms:    make(map[string]memory.Organism, len(s.Organisms)),
-		Cohorts:      make(map[string]memory.Cohort, len(s.Cohorts)),
-		Housing:      make(map[string]memory.HousingUnit, len(s.Housing)),
-		Facilities:   make(map[string]memory.Facility, len(s.Facilities)),
-		Breeding:     make(map[string]memory.BreedingUnit, len(s.Breeding)),
-		Lines:        make(map[string]memory.Line, len(s.Lines)),
-		Strains:      make(map[string]memory.Strain, len(s.Strains)),
-		Markers:      make(map[string]memory.GenotypeMarker, len(s.Markers)),
-		Procedures:   make(map[string]memory.Procedure, len(s.Procedures)),
-		Treatments:   make(map[string]memory.Treatment, len(s.Treatments)),
-		Observations: make(map[string]memory.Observation, len(s.Observations)),
-		Samples:      make(map[string]memory.Sample, len(s.Samples)),
-		Protocols:    make(map[string]memory.Protocol, len(s.Protocols)),
-		Permits:      make(map[string]memory.Permit, len(s.Permits)),
-		Projects:     make(map[string]memory.Project, len(s.Projects)),
-		Supplies:     make(map[string]memory.SupplyItem, len(s.Supplies)),
+		SchemaVersion: s.SchemaVersion,
+		Organisms:     make(map[string]memory.Organism, len(s.Organisms)),
+		Cohorts:       make(map[string]memory.Cohort, len(s.Cohorts)),
+		Housing:       make(map[string]memory.HousingUnit, len(s.Housing)),
+		Facilities:    make(map[string]memory.Facility, len(s.Facilities)),
+		Breeding:      make(map[string]memory.BreedingUnit, len(s.Breeding)),
+		Lines:         make(map[string]memory.Line, len(s.Lines)),
+		Strains:       make(map[string]memory.Strain, len(s.Strains)),
+		Markers:       make(map[string]memory.GenotypeMarker, len(s.Markers)),
+		Procedures:    make(map[string]memory.Procedure, len(s.Procedures)),
+		Treatments:    make(map[string]memory.Treatment, len(s.Treatments)),
+		Observations:  make(map[string]memory.Observation, len(s.Observations)),
+		Samples:       make(map[string]memory.Sample, len(s.Samples)),
+		Protocols:     make(map[string]memory.Protocol, len(s.Protocols)),
+		Permits:       make(map[string]memory.Permit, len(s.Permits)),
+		Projects:      make(map[string]memory.Project, len(s.Projects)),
+		Supplies:      make(map[string]memory.SupplyItem, len(s.Supplies)),
 	}
 	for k, v := range s.Organisms {
 		out.Organisms[k] = v
@@ -352,10 +993,23 @@ func cloneSnapshot(s memory.Snapshot) memory.Snapshot {
 
 // ImportState replaces the normalized data with the provided snapshot (primarily for tests).
 func (s *Store) ImportState(snapshot memory.Snapshot) {
-	if err := persistNormalized(context.Background(), s.db, snapshot); err != nil {
+	if err := s.Import(context.Background(), snapshot); err != nil {
 		panic(fmt.Errorf("postgres import state: %w", err))
 	}
+}
+
+// Import replaces the normalized data with the provided snapshot, returning
+// memory.ErrSnapshotTooNew for snapshots from a newer schema version and any
+// persistence error instead of panicking.
+func (s *Store) Import(ctx context.Context, snapshot memory.Snapshot) error {
+	if err := memory.CheckSnapshotVersion(snapshot.SchemaVersion); err != nil {
+		return err
+	}
+	if err := persistNormalized(ctx, s.db, snapshot); err != nil {
+		return err
+	}
 	s.cache = cloneSnapshot(snapshot)
+	return nil
 }
 
 // ExportState returns the current normalized snapshot (primarily for tests).
@@ -365,6 +1019,7 @@ func (s *Store) ExportState() memory.Snapshot {
 		panic(fmt.Errorf("postgres export state: %w", err))
 	}
 	s.cache = snap
+	snap.SchemaVersion = entitymodel.SchemaVersion
 	return snap
 }
 
@@ -379,11 +1034,15 @@ type delta[T any] struct {
 	deleted []string
 }
 
-func diffMaps[T any](before, after map[string]T) delta[T] {
-	d := delta[T]{
+func newDelta[T any]() delta[T] {
+	return delta[T]{
 		created: make(map[string]T),
 		updated: make(map[string]T),
 	}
+}
+
+func diffMaps[T any](before, after map[string]T) delta[T] {
+	d := newDelta[T]()
 	for id, afterVal := range after {
 		if prev, ok := before[id]; !ok {
 			d.created[id] = afterVal
@@ -413,127 +1072,331 @@ func mergeMaps[T any](first, second map[string]T) map[string]T {
 	return out
 }
 
-// applySnapshotDelta persists the difference between two snapshots inside an active SQL transaction.
+// snapshotDelta holds the per-entity record changes to write in one commit.
+type snapshotDelta struct {
+	facilities   delta[domain.Facility]
+	markers      delta[domain.GenotypeMarker]
+	lines        delta[domain.Line]
+	strains      delta[domain.Strain]
+	housing      delta[domain.HousingUnit]
+	protocols    delta[domain.Protocol]
+	projects     delta[domain.Project]
+	permits      delta[domain.Permit]
+	cohorts      delta[domain.Cohort]
+	breeding     delta[domain.BreedingUnit]
+	organisms    delta[domain.Organism]
+	procedures   delta[domain.Procedure]
+	observations delta[domain.Observation]
+	samples      delta[domain.Sample]
+	supplies     delta[domain.SupplyItem]
+	treatments   delta[domain.Treatment]
+}
+
+// newSnapshotDelta returns an empty delta ready to accumulate changes.
+func newSnapshotDelta() snapshotDelta {
+	return snapshotDelta{
+		facilities:   newDelta[domain.Facility](),
+		markers:      newDelta[domain.GenotypeMarker](),
+		lines:        newDelta[domain.Line](),
+		strains:      newDelta[domain.Strain](),
+		housing:      newDelta[domain.HousingUnit](),
+		protocols:    newDelta[domain.Protocol](),
+		projects:     newDelta[domain.Project](),
+		permits:      newDelta[domain.Permit](),
+		cohorts:      newDelta[domain.Cohort](),
+		breeding:     newDelta[domain.BreedingUnit](),
+		organisms:    newDelta[domain.Organism](),
+		procedures:   newDelta[domain.Procedure](),
+		observations: newDelta[domain.Observation](),
+		samples:      newDelta[domain.Sample](),
+		supplies:     newDelta[domain.SupplyItem](),
+		treatments:   newDelta[domain.Treatment](),
+	}
+}
+
+// diffSnapshots computes the record changes that turn before into after.
+func diffSnapshots(before, after memory.Snapshot) snapshotDelta {
+	return snapshotDelta{
+		facilities:   diffMaps(before.Facilities, after.Facilities),
+		markers:      diffMaps(before.Markers, after.Markers),
+		lines:        diffMaps(before.Lines, after.Lines),
+		strains:      diffMaps(before.Strains, after.Strains),
+		housing:      diffMaps(before.Housing, after.Housing),
+		protocols:    diffMaps(before.Protocols, after.Protocols),
+		projects:     diffMaps(before.Projects, after.Projects),
+		permits:      diffMaps(before.Permits, after.Permits),
+		cohorts:      diffMaps(before.Cohorts, after.Cohorts),
+		breeding:     diffMaps(before.Breeding, after.Breeding),
+		organisms:    diffMaps(before.Organisms, after.Organisms),
+		procedures:   diffMaps(before.Procedures, after.Procedures),
+		observations: diffMaps(before.Observations, after.Observations),
+		samples:      diffMaps(before.Samples, after.Samples),
+		supplies:     diffMaps(before.Supplies, after.Supplies),
+		treatments:   diffMaps(before.Treatments, after.Treatments),
+	}
+}
+
+// applySnapshotDelta persists the difference between two snapshots inside an
+// active SQL transaction. It serves callers that hold only before and after
+// snapshots; commits with a change log use applyDelta through changeLogDelta.
 func applySnapshotDelta(ctx context.Context, exec execQuerier, before, after memory.Snapshot) error {
-	facilities := diffMaps(before.Facilities, after.Facilities)
-	markers := diffMaps(before.Markers, after.Markers)
-	lines := diffMaps(before.Lines, after.Lines)
-	strains := diffMaps(before.Strains, after.Strains)
-	housing := diffMaps(before.Housing, after.Housing)
-	protocols := diffMaps(before.Protocols, after.Protocols)
-	projects := diffMaps(before.Projects, after.Projects)
-	permits := diffMaps(before.Permits, after.Permits)
-	cohorts := diffMaps(before.Cohorts, after.Cohorts)
-	breeding := diffMaps(before.Breeding, after.Breeding)
-	organisms := diffMaps(before.Organisms, after.Organisms)
-	procedures := diffMaps(before.Procedures, after.Procedures)
-	observations := diffMaps(before.Observations, after.Observations)
-	samples := diffMaps(before.Samples, after.Samples)
-	supplies := diffMaps(before.Supplies, after.Supplies)
-	treatments := diffMaps(before.Treatments, after.Treatments)
+	return applyDelta(ctx, exec, diffSnapshots(before, after))
+}
 
+// applyDelta writes d inside an active SQL transaction.
+func applyDelta(ctx context.Context, exec execQuerier, d snapshotDelta) error {
 	// Deletes from leaf to root to satisfy FK constraints.
-	if err := deleteTreatments(ctx, exec, treatments.deleted); err != nil {
+	if err := deleteTreatments(ctx, exec, d.treatments.deleted); err != nil {
 		return err
 	}
-	if err := deleteSupplyItems(ctx, exec, supplies.deleted); err != nil {
+	if err := deleteSupplyItems(ctx, exec, d.supplies.deleted); err != nil {
 		return err
 	}
-	if err := deleteSamples(ctx, exec, samples.deleted); err != nil {
+	if err := deleteSamples(ctx, exec, d.samples.deleted); err != nil {
 		return err
 	}
-	if err := deleteObservations(ctx, exec, observations.deleted); err != nil {
+	if err := deleteObservations(ctx, exec, d.observations.deleted); err != nil {
 		return err
 	}
-	if err := deleteProcedures(ctx, exec, procedures.deleted); err != nil {
+	if err := deleteProcedures(ctx, exec, d.procedures.deleted); err != nil {
 		return err
 	}
-	if err := deleteBreedingUnits(ctx, exec, breeding.deleted); err != nil {
+	if err := deleteBreedingUnits(ctx, exec, d.breeding.deleted); err != nil {
 		return err
 	}
-	if err := deleteOrganisms(ctx, exec, organisms.deleted); err != nil {
+	if err := deleteOrganisms(ctx, exec, d.organisms.deleted); err != nil {
 		return err
 	}
-	if err := deleteCohorts(ctx, exec, cohorts.deleted); err != nil {
+	if err := detachCohorts(ctx, exec, d); err != nil {
 		return err
 	}
-	if err := deletePermits(ctx, exec, permits.deleted); err != nil {
+	if err := deleteCohorts(ctx, exec, d.cohorts.deleted); err != nil {
 		return err
 	}
-	if err := deleteProjects(ctx, exec, projects.deleted); err != nil {
+	if err := deletePermits(ctx, exec, d.permits.deleted); err != nil {
 		return err
 	}
-	if err := deleteProtocols(ctx, exec, protocols.deleted); err != nil {
+	if err := deleteProjects(ctx, exec, d.projects.deleted); err != nil {
 		return err
 	}
-	if err := deleteHousingUnits(ctx, exec, housing.deleted); err != nil {
+	if err := deleteProtocols(ctx, exec, d.protocols.deleted); err != nil {
 		return err
 	}
-	if err := deleteStrains(ctx, exec, strains.deleted); err != nil {
+	if err := deleteHousingUnits(ctx, exec, d.housing.deleted); err != nil {
 		return err
 	}
-	if err := deleteLines(ctx, exec, lines.deleted); err != nil {
+	if err := deleteStrains(ctx, exec, d.strains.deleted); err != nil {
 		return err
 	}
-	if err := deleteGenotypeMarkers(ctx, exec, markers.deleted); err != nil {
+	if err := deleteLines(ctx, exec, d.lines.deleted); err != nil {
 		return err
 	}
-	if err := deleteFacilities(ctx, exec, facilities.deleted); err != nil {
+	if err := deleteGenotypeMarkers(ctx, exec, d.markers.deleted); err != nil {
+		return err
+	}
+	if err := deleteFacilities(ctx, exec, d.facilities.deleted); err != nil {
 		return err
 	}
 
 	// Upserts from root to leaf to satisfy FK constraints.
-	if err := insertFacilities(ctx, exec, mergeMaps(facilities.created, facilities.updated)); err != nil {
+	if err := insertFacilities(ctx, exec, mergeMaps(d.facilities.created, d.facilities.updated)); err != nil {
 		return err
 	}
-	if err := insertGenotypeMarkers(ctx, exec, mergeMaps(markers.created, markers.updated)); err != nil {
+	if err := insertGenotypeMarkers(ctx, exec, mergeMaps(d.markers.created, d.markers.updated)); err != nil {
 		return err
 	}
-	if err := insertLines(ctx, exec, mergeMaps(lines.created, lines.updated)); err != nil {
+	if err := insertLines(ctx, exec, mergeMaps(d.lines.created, d.lines.updated)); err != nil {
 		return err
 	}
-	if err := insertStrains(ctx, exec, mergeMaps(strains.created, strains.updated)); err != nil {
+	if err := insertStrains(ctx, exec, mergeMaps(d.strains.created, d.strains.updated)); err != nil {
 		return err
 	}
-	if err := insertHousingUnits(ctx, exec, mergeMaps(housing.created, housing.updated)); err != nil {
+	if err := insertHousingUnits(ctx, exec, mergeMaps(d.housing.created, d.housing.updated)); err != nil {
 		return err
 	}
-	if err := insertProtocols(ctx, exec, mergeMaps(protocols.created, protocols.updated)); err != nil {
+	if err := insertProtocols(ctx, exec, mergeMaps(d.protocols.created, d.protocols.updated)); err != nil {
 		return err
 	}
-	if err := insertProjects(ctx, exec, mergeMaps(projects.created, projects.updated)); err != nil {
+	if err := insertProjects(ctx, exec, mergeMaps(d.projects.created, d.projects.updated)); err != nil {
 		return err
 	}
-	if err := insertPermits(ctx, exec, mergeMaps(permits.created, permits.updated)); err != nil {
+	if err := insertPermits(ctx, exec, mergeMaps(d.permits.created, d.permits.updated)); err != nil {
 		return err
 	}
-	if err := insertCohorts(ctx, exec, mergeMaps(cohorts.created, cohorts.updated)); err != nil {
+	if err := insertBreedingUnits(ctx, exec, mergeMaps(d.breeding.created, d.breeding.updated)); err != nil {
 		return err
 	}
-	if err := insertBreedingUnits(ctx, exec, mergeMaps(breeding.created, breeding.updated)); err != nil {
+	if err := insertCohorts(ctx, exec, mergeMaps(d.cohorts.created, d.cohorts.updated)); err != nil {
 		return err
 	}
-	if err := insertOrganisms(ctx, exec, mergeMaps(organisms.created, organisms.updated)); err != nil {
+	if err := insertOrganisms(ctx, exec, mergeMaps(d.organisms.created, d.organisms.updated)); err != nil {
 		return err
 	}
-	if err := insertProcedures(ctx, exec, mergeMaps(procedures.created, procedures.updated)); err != nil {
+	if err := insertProcedures(ctx, exec, mergeMaps(d.procedures.created, d.procedures.updated)); err != nil {
 		return err
 	}
-	if err := insertObservations(ctx, exec, mergeMaps(observations.created, observations.updated)); err != nil {
+	if err := insertObservations(ctx, exec, mergeMaps(d.observations.created, d.observations.updated)); err != nil {
 		return err
 	}
-	if err := insertSamples(ctx, exec, mergeMaps(samples.created, samples.updated)); err != nil {
+	if err := insertSamples(ctx, exec, mergeMaps(d.samples.created, d.samples.updated)); err != nil {
 		return err
 	}
-	if err := insertSupplyItems(ctx, exec, mergeMaps(supplies.created, supplies.updated)); err != nil {
+	if err := insertSupplyItems(ctx, exec, mergeMaps(d.supplies.created, d.supplies.updated)); err != nil {
 		return err
 	}
-	if err := insertTreatments(ctx, exec, mergeMaps(treatments.created, treatments.updated)); err != nil {
+	if err := insertTreatments(ctx, exec, mergeMaps(d.treatments.created, d.treatments.updated)); err != nil {
 		return err
 	}
 	return nil
 }
 
+// changeLogDelta folds an ordered change log into the last state of each
+// record it touches. A delete drops any earlier upsert of the same record, and
+// a later create or update replaces an earlier delete. Project supply item
+// links are derived from supply items, so links to supply items deleted later
+// in the log are dropped from project payloads recorded before the delete.
+func changeLogDelta(changes []domain.Change) (snapshotDelta, error) {
+	d := newSnapshotDelta()
+	for i, change := range changes {
+		var err error
+		switch change.Entity {
+		case domain.EntityFacility:
+			err = foldChange(&d.facilities, change)
+		case domain.EntityGenotypeMarker:
+			err = foldChange(&d.markers, change)
+		case domain.EntityLine:
+			err = foldChange(&d.lines, change)
+		case domain.EntityStrain:
+			err = foldChange(&d.strains, change)
+		case domain.EntityHousingUnit:
+			err = foldChange(&d.housing, change)
+		case domain.EntityProtocol:
+			err = foldChange(&d.protocols, change)
+		case domain.EntityProject:
+			err = foldChange(&d.projects, change)
+		case domain.EntityPermit:
+			err = foldChange(&d.permits, change)
+		case domain.EntityCohort:
+			err = foldChange(&d.cohorts, change)
+		case domain.EntityBreeding:
+			err = foldChange(&d.breeding, change)
+		case domain.EntityOrganism:
+			err = foldChange(&d.organisms, change)
+		case domain.EntityProcedure:
+			err = foldChange(&d.procedures, change)
+		case domain.EntityObservation:
+			err = foldChange(&d.observations, change)
+		case domain.EntitySample:
+			err = foldChange(&d.samples, change)
+		case domain.EntitySupplyItem:
+			err = foldChange(&d.supplies, change)
+		case domain.EntityTreatment:
+			err = foldChange(&d.treatments, change)
+		default:
+			err = errors.New("unsupported entity")
+		}
+		if err != nil {
+			return snapshotDelta{}, fmt.Errorf("change %d (%s %s): %w", i, change.Entity, change.Action, err)
+		}
+	}
+	if len(d.supplies.deleted) > 0 {
+		for _, projects := range []map[string]domain.Project{d.projects.created, d.projects.updated} {
+			for id, project := range projects {
+				project.SupplyItemIDs = slices.DeleteFunc(project.SupplyItemIDs, func(supplyID string) bool {
+					return slices.Contains(d.supplies.deleted, supplyID)
+				})
+				projects[id] = project
+			}
+		}
+	}
+	return d, nil
+}
+
+// foldChange records one change in d. Deletes are identified by their before
+// payload; every other action must carry the record's after payload.
+func foldChange[T any](d *delta[T], change domain.Change) error {
+	if change.Action == domain.ActionDelete {
+		id, err := changePayloadID(change.Before)
+		if err != nil {
+			return err
+		}
+		delete(d.created, id)
+		delete(d.updated, id)
+		if !slices.Contains(d.deleted, id) {
+			d.deleted = append(d.deleted, id)
+		}
+		return nil
+	}
+	id, err := changePayloadID(change.After)
+	if err != nil {
+		return err
+	}
+	var value T
+	if err := json.Unmarshal(change.After.Raw(), &value); err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+	d.deleted = slices.DeleteFunc(d.deleted, func(deletedID string) bool { return deletedID == id })
+	if _, created := d.created[id]; created || change.Action == domain.ActionCreate {
+		d.created[id] = value
+	} else {
+		d.updated[id] = value
+	}
+	return nil
+}
+
+// changePayloadID returns the id property of a record payload.
+func changePayloadID(payload domain.ChangePayload) (string, error) {
+	if payload.IsEmpty() {
+		return "", errors.New("missing record payload")
+	}
+	var record struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(payload.Raw(), &record); err != nil {
+		return "", fmt.Errorf("decode payload: %w", err)
+	}
+	if record.ID == "" {
+		return "", errors.New("record payload has no id")
+	}
+	return record.ID, nil
+}
+
+// patch applies d to snap and returns it.
+func (d snapshotDelta) patch(snap memory.Snapshot) memory.Snapshot {
+	snap.Facilities = patchMap(snap.Facilities, d.facilities)
+	snap.Markers = patchMap(snap.Markers, d.markers)
+	snap.Lines = patchMap(snap.Lines, d.lines)
+	snap.Strains = patchMap(snap.Strains, d.strains)
+	snap.Housing = patchMap(snap.Housing, d.housing)
+	snap.Protocols = patchMap(snap.Protocols, d.protocols)
+	snap.Projects = patchMap(snap.Projects, d.projects)
+	snap.Permits = patchMap(snap.Permits, d.permits)
+	snap.Cohorts = patchMap(snap.Cohorts, d.cohorts)
+	snap.Breeding = patchMap(snap.Breeding, d.breeding)
+	snap.Organisms = patchMap(snap.Organisms, d.organisms)
+	snap.Procedures = patchMap(snap.Procedures, d.procedures)
+	snap.Observations = patchMap(snap.Observations, d.observations)
+	snap.Samples = patchMap(snap.Samples, d.samples)
+	snap.Supplies = patchMap(snap.Supplies, d.supplies)
+	snap.Treatments = patchMap(snap.Treatments, d.treatments)
+	return snap
+}
+
+func patchMap[T any](records map[string]T, d delta[T]) map[string]T {
+	for _, id := range d.deleted {
+		delete(records, id)
+	}
+	for _, changed := range []map[string]T{d.created, d.updated} {
+		for id, value := range changed {
+			if records == nil {
+				records = make(map[string]T)
+			}
+			records[id] = value
+		}
+	}
+	return records
+}
+
 // OverrideSQLOpen swaps the sqlOpen function for tests and returns a restore function.
 func OverrideSQLOpen(fn func(driverName, dataSourceName string) (*sql.DB, error)) func() {
 	openMu.Lock()
@@ -552,15 +1415,40 @@ type execQuerier interface {
 	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
 }
 
+// duplicateObjectSQLStates lists the SQLSTATE codes Postgres reports when a
+// DDL statement targets an object that already exists. Constraint triggers
+// have no IF NOT EXISTS form, so re-applying the bundle relies on these.
+var duplicateObjectSQLStates = map[string]struct{}{
+	"42P06": {}, // duplicate_schema
+	"42P07": {}, // duplicate_table (also relations such as indexes)
+	"42710": {}, // duplicate_object (triggers, constraints)
+	"42723": {}, // duplicate_function
+}
+
+// applyDDLStatements executes each statement in ddl, skipping statements that
+// fail only because their object already exists so the store can start against
+// an initialized database.
 func applyDDLStatements(ctx context.Context, db execQuerier, ddl string) error {
 	for _, stmt := range sqlbundle.SplitStatements(ddl) {
 		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			if isDuplicateObjectError(err) {
+				continue
+			}
 			return fmt.Errorf("execute ddl: %w", err)
 		}
 	}
 	return nil
 }
 
+func isDuplicateObjectError(err error) bool {
+	var coded interface{ SQLState() string }
+	if !errors.As(err, &coded) {
+		return false
+	}
+	_, ok := duplicateObjectSQLStates[coded.SQLState()]
+	return ok
+}
+
 func persistNormalized(ctx context.Context, db *sql.DB, snapshot memory.Snapshot) error {
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
@@ -589,8 +1477,8 @@ func persistNormalized(ctx context.Context, db *sql.DB, snapshot memory.Snapshot
 		{"insert protocols", func(ctx context.Context) error { return insertProtocols(ctx, tx, snapshot.Protocols) }},
 		{"insert projects", func(ctx context.Context) error { return insertProjects(ctx, tx, snapshot.Projects) }},
 		{"insert permits", func(ctx context.Context) error { return insertPermits(ctx, tx, snapshot.Permits) }},
-		{"insert cohorts", func(ctx context.Context) error { return insertCohorts(ctx, tx, snapshot.Cohorts) }},
 		{"insert breeding units", func(ctx context.Context) error { return insertBreedingUnits(ctx, tx, snapshot.Breeding) }},
+		{"insert cohorts", func(ctx context.Context) error { return insertCohorts(ctx, tx, snapshot.Cohorts) }},
 		{"insert organisms", func(ctx context.Context) error { return insertOrganisms(ctx, tx, snapshot.Organisms) }},
 		{"insert procedures", func(ctx context.Context) error { return insertProcedures(ctx, tx, snapshot.Procedures) }},
 		{"insert observations", func(ctx context.Context) error { return insertObservations(ctx, tx, snapshot.Observations) }},
@@ -709,6 +1597,72 @@ func deletePermits(ctx context.Context, exec execQuerier, ids []string) error {
 	return nil
 }
 
+// Statements clearing one record's reference to a cohort; see detachCohorts.
+const (
+	detachOrganismCohortSQL    = `UPDATE organisms SET cohort_id=NULL WHERE id=$1 AND cohort_id=$2`
+	detachProcedureCohortSQL   = `UPDATE procedures SET cohort_id=NULL WHERE id=$1 AND cohort_id=$2`
+	detachObservationCohortSQL = `UPDATE observations SET cohort_id=NULL WHERE id=$1 AND cohort_id=$2`
+	detachSampleCohortSQL      = `UPDATE samples SET cohort_id=NULL WHERE id=$1 AND cohort_id=$2`
+	detachTreatmentCohortSQL   = `DELETE FROM treatments__cohort_ids WHERE treatment_id=$1 AND cohort_id=$2`
+)
+
+// detachCohorts clears the stored references to the cohorts d deletes, but
+// only on records d itself updates to drop them, as DeleteCohortCascade does.
+// Those rows are upserted after the deletes run, so without this the cohort
+// delete would trip the foreign keys. References d leaves in place are not
+// touched, so the delete still fails on them instead of silently detaching
+// records the transaction never changed.
+func detachCohorts(ctx context.Context, exec execQuerier, d snapshotDelta) error {
+	for _, cohortID := range d.cohorts.deleted {
+		detach := func(stmt, id string) error {
+			if _, err := exec.ExecContext(ctx, stmt, id, cohortID); err != nil {
+				return fmt.Errorf("detach cohort %s: %w", cohortID, err)
+			}
+			return nil
+		}
+		for _, id := range sortedKeys(d.organisms.updated) {
+			if !referencesCohort(d.organisms.updated[id].CohortID, cohortID) {
+				if err := detach(detachOrganismCohortSQL, id); err != nil {
+					return err
+				}
+			}
+		}
+		for _, id := range sortedKeys(d.procedures.updated) {
+			if !referencesCohort(d.procedures.updated[id].CohortID, cohortID) {
+				if err := detach(detachProcedureCohortSQL, id); err != nil {
+					return err
+				}
+			}
+		}
+		for _, id := range sortedKeys(d.observations.updated) {
+			if !referencesCohort(d.observations.updated[id].CohortID, cohortID) {
+				if err := detach(detachObservationCohortSQL, id); err != nil {
+					return err
+				}
+			}
+		}
+		for _, id := range sortedKeys(d.samples.updated) {
+			if !referencesCohort(d.samples.updated[id].CohortID, cohortID) {
+				if err := detach(detachSampleCohortSQL, id); err != nil {
+					return err
+				}
+			}
+		}
+		for _, id := range sortedKeys(d.treatments.updated) {
+			if !slices.Contains(d.treatments.updated[id].CohortIDs, cohortID) {
+				if err := detach(detachTreatmentCohortSQL, id); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func referencesCohort(ref *string, cohortID string) bool {
+	return ref != nil && *ref == cohortID
+}
+
 func deleteCohorts(ctx context.Context, exec execQuerier, ids []string) error {
 	for _, id := range ids {
 		if _, err := exec.ExecContext(ctx, deleteCohortSQL, id); err != nil {
@@ -806,6 +1760,13 @@ func deleteTreatments(ctx context.Context, exec execQuerier, ids []string) error
 }
 
 func loadNormalizedSnapshot(ctx context.Context, db execQuerier) (memory.Snapshot, error) {
+	version, err := loadSchemaVersion(ctx, db)
+	if err != nil {
+		return memory.Snapshot{}, err
+	}
+	if err := memory.CheckSnapshotVersion(version); err != nil {
+		return memory.Snapshot{}, err
+	}
 	facilities, err := loadFacilities(ctx, db)
 	if err != nil {
 		return memory.Snapshot{}, err
@@ -909,6 +1870,7 @@ func loadNormalizedSnapshot(ctx context.Context, db execQuerier) (memory.Snapsho
 	if err := loadTreatmentOrganisms(ctx, db, treatments); err != nil {
 		return memory.Snapshot{}, err
 	}
+	deriveProcedureChildren(procedures, treatments, observations)
 
 	return memory.Snapshot{
 		Facilities:   facilities,
@@ -975,7 +1937,7 @@ func insertFacilities(ctx context.Context, exec execQuerier, facilities map[stri
 			return fmt.Errorf("marshal facility environment_baselines: %w", err)
 		}
 		if _, err := exec.ExecContext(ctx, insertFacilitySQL,
-			f.ID, f.Code, f.Name, f.Zone, f.AccessPolicy, f.CreatedAt, f.UpdatedAt, env,
+			f.ID, f.Code, f.Name, f.Zone, f.AccessPolicy, f.Timezone, f.AccreditationNumber, f.AccreditationExpiresAt, f.CreatedAt, f.UpdatedAt, env, f.DefaultHousingEnvironment,
 		); err != nil {
 			return fmt.Errorf("insert facility %s: %w", f.ID, err)
 		}
@@ -991,8 +1953,12 @@ func insertGenotypeMarkers(ctx context.Context, exec execQuerier, markers map[st
 		if err != nil {
 			return err
 		}
+		attrs, err := marshalJSONNullable((&m).GenotypeMarkerAttributesByPlugin())
+		if err != nil {
+			return fmt.Errorf("marshal genotype_marker attributes: %w", err)
+		}
 		if _, err := exec.ExecContext(ctx, insertGenotypeMarkerSQL,
-			m.ID, m.Name, m.Locus, alleles, m.AssayMethod, m.Interpretation, m.Version, m.CreatedAt, m.UpdatedAt,
+			m.ID, m.Name, m.Locus, alleles, m.AssayMethod, m.Interpretation, m.Version, attrs, m.CreatedAt, m.UpdatedAt,
 		); err != nil {
 			return fmt.Errorf("insert genotype marker %s: %w", m.ID, err)
 		}
@@ -1026,8 +1992,12 @@ func insertLines(ctx context.Context, exec execQuerier, lines map[string]domain.
 		if err != nil {
 			return fmt.Errorf("marshal line extension_overrides: %w", err)
 		}
+		tags, err := marshalJSONNullable(line.Tags)
+		if err != nil {
+			return fmt.Errorf("marshal line tags: %w", err)
+		}
 		if _, err := exec.ExecContext(ctx, insertLineSQL,
-			line.ID, line.Code, line.Name, line.Origin, line.Description, defaultAttrs, overrides, line.DeprecatedAt, line.DeprecationReason, line.CreatedAt, line.UpdatedAt,
+			line.ID, line.Code, line.Name, line.Origin, line.Description, defaultAttrs, overrides, tags, line.DeprecatedAt, line.DeprecationReason, line.CreatedAt, line.UpdatedAt,
 		); err != nil {
 			return fmt.Errorf("insert line %s: %w", line.ID, err)
 		}
@@ -1055,8 +2025,12 @@ func insertStrains(ctx context.Context, exec execQuerier, strains map[string]dom
 		if _, err := exec.ExecContext(ctx, deleteStrainMarkersSQL, strain.ID); err != nil {
 			return fmt.Errorf("clear strain %s markers: %w", strain.ID, err)
 		}
+		attrs, err := marshalJSONNullable((&strain).StrainAttributesByPlugin())
+		if err != nil {
+			return fmt.Errorf("marshal strain attributes: %w", err)
+		}
 		if _, err := exec.ExecContext(ctx, insertStrainSQL,
-			strain.ID, strain.Code, strain.Name, strain.LineID, strain.Description, strain.Generation, strain.RetiredAt, strain.RetirementReason, strain.CreatedAt, strain.UpdatedAt,
+			strain.ID, strain.Code, strain.Name, strain.LineID, strain.Description, strain.Generation, strain.RetiredAt, strain.RetirementReason, attrs, strain.CreatedAt, strain.UpdatedAt,
 		); err != nil {
 			return fmt.Errorf("insert strain %s: %w", strain.ID, err)
 		}
@@ -1077,7 +2051,7 @@ func insertHousingUnits(ctx context.Context, exec execQuerier, housing map[strin
 			return fmt.Errorf("housing %s missing required facility_id", h.ID)
 		}
 		if _, err := exec.ExecContext(ctx, insertHousingSQL,
-			h.ID, h.FacilityID, h.Name, h.Capacity, h.Environment, h.State, h.CreatedAt, h.UpdatedAt,
+			h.ID, h.FacilityID, h.Name, h.Capacity, h.Environment, h.State, h.CreatedAt, h.UpdatedAt, h.Version,
 		); err != nil {
 			return fmt.Errorf("insert housing %s: %w", h.ID, err)
 		}
@@ -1089,8 +2063,12 @@ func insertProtocols(ctx context.Context, exec execQuerier, protocols map[string
 	keys := sortedKeys(protocols)
 	for _, id := range keys {
 		p := protocols[id]
+		reviewers, err := marshalJSONNullable(p.ReviewerIDs)
+		if err != nil {
+			return fmt.Errorf("marshal protocol reviewer_ids: %w", err)
+		}
 		if _, err := exec.ExecContext(ctx, insertProtocolSQL,
-			p.ID, p.Code, p.Title, p.Description, p.MaxSubjects, p.Status, p.CreatedAt, p.UpdatedAt,
+			p.ID, p.Code, p.Title, p.Description, p.MaxSubjects, reviewers, p.Status, p.CreatedAt, p.UpdatedAt, p.Version,
 		); err != nil {
 			return fmt.Errorf("insert protocol %s: %w", p.ID, err)
 		}
@@ -1115,7 +2093,7 @@ func insertProjects(ctx context.Context, exec execQuerier, projects map[string]d
 			return fmt.Errorf("clear project %s supplies: %w", p.ID, err)
 		}
 		if _, err := exec.ExecContext(ctx, insertProjectSQL,
-			p.ID, p.Code, p.Title, p.Description, p.CreatedAt, p.UpdatedAt,
+			p.ID, p.Code, p.Title, p.Description, p.ClosedAt, p.CreatedAt, p.UpdatedAt,
 		); err != nil {
 			return fmt.Errorf("insert project %s: %w", p.ID, err)
 		}
@@ -1159,7 +2137,7 @@ func insertPermits(ctx context.Context, exec execQuerier, permits map[string]dom
 			return err
 		}
 		if _, err := exec.ExecContext(ctx, insertPermitSQL,
-			p.ID, p.PermitNumber, p.Authority, p.Status, p.ValidFrom, p.ValidUntil, activities, p.Notes, p.CreatedAt, p.UpdatedAt,
+			p.ID, p.PermitNumber, p.Authority, p.Status, p.IssueDate, p.ValidFrom, p.ValidUntil, activities, p.Notes, p.CreatedAt, p.UpdatedAt,
 		); err != nil {
 			return fmt.Errorf("insert permit %s: %w", p.ID, err)
 		}
@@ -1182,7 +2160,7 @@ func insertCohorts(ctx context.Context, exec execQuerier, cohorts map[string]dom
 	for _, id := range keys {
 		c := cohorts[id]
 		if _, err := exec.ExecContext(ctx, insertCohortSQL,
-			c.ID, c.Name, c.Purpose, c.ProjectID, c.HousingID, c.ProtocolID, c.CreatedAt, c.UpdatedAt,
+			c.ID, c.Name, c.Purpose, c.Species, c.ProjectID, c.HousingID, c.ProtocolID, c.CreatedFromBreedingUnitID, c.CreatedAt, c.UpdatedAt,
 		); err != nil {
 			return fmt.Errorf("insert cohort %s: %w", c.ID, err)
 		}
@@ -1235,7 +2213,7 @@ func insertOrganisms(ctx context.Context, exec execQuerier, organisms map[string
 			return fmt.Errorf("marshal organism attributes: %w", err)
 		}
 		if _, err := exec.ExecContext(ctx, insertOrganismSQL,
-			o.ID, o.Name, o.Species, o.Line, o.Stage, o.LineID, o.StrainID, o.CohortID, o.HousingID, o.ProtocolID, o.ProjectID, attrs, o.CreatedAt, o.UpdatedAt,
+			o.ID, o.Name, o.Species, o.Line, o.Stage, o.LineID, o.StrainID, o.CohortID, o.HousingID, o.ProtocolID, o.ProjectID, attrs, o.CreatedAt, o.UpdatedAt, o.Version,
 		); err != nil {
 			return fmt.Errorf("insert organism %s: %w", o.ID, err)
 		}
@@ -1259,7 +2237,7 @@ func insertProcedures(ctx context.Context, exec execQuerier, procedures map[stri
 			return fmt.Errorf("procedure %s missing required protocol_id", p.ID)
 		}
 		if _, err := exec.ExecContext(ctx, insertProcedureSQL,
-			p.ID, p.Name, p.Status, p.ScheduledAt, p.ProtocolID, p.ProjectID, p.CohortID, p.CreatedAt, p.UpdatedAt,
+			p.ID, p.Name, p.Status, p.CancellationReason, p.ScheduledAt, p.ProtocolID, p.ProjectID, p.CohortID, p.CreatedAt, p.UpdatedAt,
 		); err != nil {
 			return fmt.Errorf("insert procedure %s: %w", p.ID, err)
 		}
@@ -1276,12 +2254,22 @@ func insertObservations(ctx context.Context, exec execQuerier, observations map[
 	keys := sortedKeys(observations)
 	for _, id := range keys {
 		o := observations[id]
-		data, err := marshalJSONNullable(o.Data)
+		payload := o.Data
+		if hydrated := (&o).ObservationData(); hydrated != nil {
+			payload = hydrated
+		}
+		data, err := marshalJSONNullable(payload)
 		if err != nil {
 			return fmt.Errorf("marshal observation data: %w", err)
 		}
+		var attachments []byte
+		if len(o.Attachments) > 0 {
+			if attachments, err = json.Marshal(o.Attachments); err != nil {
+				return fmt.Errorf("marshal observation attachments: %w", err)
+			}
+		}
 		if _, err := exec.ExecContext(ctx, insertObservationSQL,
-			o.ID, o.Observer, o.RecordedAt, o.ProcedureID, o.OrganismID, o.CohortID, data, o.Notes, o.CreatedAt, o.UpdatedAt,
+			o.ID, o.Observer, o.RecordedAt, o.ProcedureID, o.OrganismID, o.CohortID, data, o.Notes, o.RecordedBy, o.ReviewedBy, o.ReviewedAt, o.CreatedAt, o.UpdatedAt, o.Category, attachments, o.Weight, o.Length, o.Temperature,
 		); err != nil {
 			return fmt.Errorf("insert observation %s: %w", o.ID, err)
 		}
@@ -1299,6 +2287,9 @@ func insertSamples(ctx context.Context, exec execQuerier, samples map[string]dom
 		if s.FacilityID == "" {
 			return fmt.Errorf("sample %s missing required facility_id", s.ID)
 		}
+		if s.CollectedBy == "" {
+			return fmt.Errorf("sample %s missing required collected_by", s.ID)
+		}
 		chain, err := marshalJSONRequired("sample.chain_of_custody", s.ChainOfCustody)
 		if err != nil {
 			return err
@@ -1308,7 +2299,7 @@ func insertSamples(ctx context.Context, exec execQuerier, samples map[string]dom
 			return fmt.Errorf("marshal sample attributes: %w", err)
 		}
 		if _, err := exec.ExecContext(ctx, insertSampleSQL,
-			s.ID, s.Identifier, s.SourceType, s.Status, s.StorageLocation, s.AssayType, s.FacilityID, s.OrganismID, s.CohortID, chain, attrs, s.CollectedAt, s.CreatedAt, s.UpdatedAt,
+			s.ID, s.Identifier, s.SourceType, s.Status, s.StorageLocation, s.AssayType, s.FacilityID, s.OrganismID, s.CohortID, chain, attrs, s.CollectedAt, s.CollectedBy, s.CollectionProtocol, s.CreatedAt, s.UpdatedAt,
 		); err != nil {
 			return fmt.Errorf("insert sample %s: %w", s.ID, err)
 		}
@@ -1341,7 +2332,7 @@ func insertSupplyItems(ctx context.Context, exec execQuerier, supplies map[strin
 			return fmt.Errorf("marshal supply_item attributes: %w", err)
 		}
 		if _, err := exec.ExecContext(ctx, insertSupplySQL,
-			s.ID, s.SKU, s.Name, s.QuantityOnHand, s.Unit, s.ReorderLevel, s.Description, s.LotNumber, s.ExpiresAt, attrs, s.CreatedAt, s.UpdatedAt,
+			s.ID, s.SKU, s.Name, s.QuantityOnHand, s.Unit, s.Category, s.ReorderLevel, s.Status, s.Description, s.LotNumber, s.ExpiresAt, attrs, s.CreatedAt, s.UpdatedAt,
 		); err != nil {
 			return fmt.Errorf("insert supply_item %s: %w", s.ID, err)
 		}
@@ -1380,8 +2371,12 @@ func insertTreatments(ctx context.Context, exec execQuerier, treatments map[stri
 		if err != nil {
 			return fmt.Errorf("marshal treatment adverse_events: %w", err)
 		}
+		dosagePlan, err := json.Marshal(treatment.DosagePlan)
+		if err != nil {
+			return fmt.Errorf("marshal treatment dosage_plan: %w", err)
+		}
 		if _, err := exec.ExecContext(ctx, insertTreatmentSQL,
-			treatment.ID, treatment.Name, treatment.Status, treatment.ProcedureID, treatment.DosagePlan, adminLog, adverse, treatment.CreatedAt, treatment.UpdatedAt,
+			treatment.ID, treatment.Name, treatment.Status, treatment.ProcedureID, dosagePlan, adminLog, adverse, treatment.CreatedAt, treatment.UpdatedAt,
 		); err != nil {
 			return fmt.Errorf("insert treatment %s: %w", treatment.ID, err)
 		}
@@ -1390,19 +2385,128 @@ func insertTreatments(ctx context.Context, exec execQuerier, treatments map[stri
 				return fmt.Errorf("insert treatment %s cohort %s: %w", treatment.ID, cohortID, err)
 			}
 		}
-		for _, organismID := range treatment.OrganismIDs {
-			if _, err := exec.ExecContext(ctx, insertTreatmentOrganismSQL, treatment.ID, organismID); err != nil {
-				return fmt.Errorf("insert treatment %s organism %s: %w", treatment.ID, organismID, err)
-			}
+		for _, organismID := range treatment.OrganismIDs {
+			if _, err := exec.ExecContext(ctx, insertTreatmentOrganismSQL, treatment.ID, organismID); err != nil {
+				return fmt.Errorf("insert treatment %s organism %s: %w", treatment.ID, organismID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// --- load helpers ---
+
+func loadFacilities(ctx context.Context, db execQuerier) (map[string]domain.Facility, error) {
+	return loadFacilitiesWhere(ctx, db, selectFacilitiesSQL)
+}
+
+// loadFacilityByCode loads the facility with code and its project join rows
+// inside a single read-only transaction.
+func loadFacilityByCode(ctx context.Context, db *sql.DB, code string) (map[string]domain.Facility, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	facilities, err := loadFacilitiesWhere(ctx, tx, selectFacilityByCodeSQL, code)
+	if err != nil {
+		return nil, err
+	}
+	for id := range facilities {
+		if err := loadFacilityProjectsWhere(ctx, tx, facilities, selectFacilityProjectsByIDSQL, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return facilities, nil
+}
+
+// loadSingleFacility loads one facility row, its project join rows, and the
+// IDs of the housing units it hosts inside a single read-only transaction.
+func loadSingleFacility(ctx context.Context, db *sql.DB, id string) (map[string]domain.Facility, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	facilities, err := loadFacilitiesWhere(ctx, tx, selectFacilityByIDSQL, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadFacilityProjectsWhere(ctx, tx, facilities, selectFacilityProjectsByIDSQL, id); err != nil {
+		return nil, err
+	}
+	if facility, ok := facilities[id]; ok {
+		housingIDs, err := loadFacilityHousingIDs(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		facility.HousingUnitIDs = housingIDs
+		facilities[id] = facility
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return facilities, nil
+}
+
+// loadFacilityHousingIDs returns the IDs of the housing units in facilityID,
+// ordered by ID. The list is derived and never stored with the facility.
+func loadFacilityHousingIDs(ctx context.Context, db execQuerier, facilityID string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, selectFacilityHousingIDsSQL, facilityID)
+	if err != nil {
+		return nil, fmt.Errorf("select facility housing units: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan facility housing units: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate facility housing units: %w", err)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// loadFacilityProjectsWhere fills ProjectIDs from the facility project join
+// rows returned by query, invoked with args.
+func loadFacilityProjectsWhere(ctx context.Context, db execQuerier, facilities map[string]domain.Facility, query string, args ...any) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("select facility projects: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var facilityID, projectID string
+		if err := rows.Scan(&facilityID, &projectID); err != nil {
+			return fmt.Errorf("scan facility projects: %w", err)
+		}
+		if facility, ok := facilities[facilityID]; ok {
+			facility.ProjectIDs = append(facility.ProjectIDs, projectID)
+			facilities[facilityID] = facility
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate facility projects: %w", err)
+	}
+	for id, facility := range facilities {
+		sort.Strings(facility.ProjectIDs)
+		facilities[id] = facility
+	}
 	return nil
 }
 
-// --- load helpers ---
-
-func loadFacilities(ctx context.Context, db execQuerier) (map[string]domain.Facility, error) {
-	rows, err := db.QueryContext(ctx, selectFacilitiesSQL)
+// loadFacilitiesWhere loads facility rows returned by query, which must select
+// the columns of selectFacilitiesSQL.
+func loadFacilitiesWhere(ctx context.Context, db execQuerier, query string, args ...any) (map[string]domain.Facility, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("select facilities: %w", err)
 	}
@@ -1412,26 +2516,37 @@ func loadFacilities(ctx context.Context, db execQuerier) (map[string]domain.Faci
 	for rows.Next() {
 		var (
 			id, code, name, zone, policy string
+			timezone, accreditation      sql.NullString
+			defaultEnvironment           sql.NullString
+			accreditationExpiresAt       sql.NullTime
 			createdAt, updatedAt         time.Time
 			envRaw                       []byte
 		)
-		if err := rows.Scan(&id, &code, &name, &zone, &policy, &createdAt, &updatedAt, &envRaw); err != nil {
+		if err := rows.Scan(&id, &code, &name, &zone, &policy, &timezone, &accreditation, &accreditationExpiresAt, &createdAt, &updatedAt, &envRaw, &defaultEnvironment); err != nil {
 			return nil, fmt.Errorf("scan facilities: %w", err)
 		}
 		env, err := decodeMap(envRaw)
 		if err != nil {
 			return nil, fmt.Errorf("decode facility %s environment_baselines: %w", id, err)
 		}
-		out[id] = domain.Facility{Facility: entitymodel.Facility{
-			ID:                   id,
-			Code:                 code,
-			Name:                 name,
-			Zone:                 zone,
-			AccessPolicy:         policy,
-			CreatedAt:            createdAt,
-			UpdatedAt:            updatedAt,
-			EnvironmentBaselines: env,
+		facility := domain.Facility{Facility: entitymodel.Facility{
+			ID:                        id,
+			Code:                      code,
+			Name:                      name,
+			Zone:                      zone,
+			AccessPolicy:              policy,
+			Timezone:                  nullableString(timezone),
+			AccreditationNumber:       nullableString(accreditation),
+			AccreditationExpiresAt:    nullableTime(accreditationExpiresAt),
+			DefaultHousingEnvironment: nullableHousingEnvironment(defaultEnvironment),
+			CreatedAt:                 createdAt,
+			UpdatedAt:                 updatedAt,
+			EnvironmentBaselines:      env,
 		}}
+		if err := facility.ApplyEnvironmentBaselines(env); err != nil {
+			return nil, fmt.Errorf("hydrate facility %s environment_baselines: %w", id, err)
+		}
+		out[id] = facility
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate facilities: %w", err)
@@ -1451,16 +2566,20 @@ func loadGenotypeMarkers(ctx context.Context, db execQuerier) (map[string]domain
 		var (
 			id, name, locus, assayMethod, interpretation, version string
 			createdAt, updatedAt                                  time.Time
-			allelesRaw                                            []byte
+			allelesRaw, attributesRaw                             []byte
 		)
-		if err := rows.Scan(&id, &name, &locus, &allelesRaw, &assayMethod, &interpretation, &version, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &name, &locus, &allelesRaw, &assayMethod, &interpretation, &version, &attributesRaw, &createdAt, &updatedAt); err != nil {
 			return nil, fmt.Errorf("scan genotype_markers: %w", err)
 		}
 		alleles, err := decodeStringSlice(allelesRaw)
 		if err != nil {
 			return nil, fmt.Errorf("decode genotype_marker %s alleles: %w", id, err)
 		}
-		out[id] = domain.GenotypeMarker{GenotypeMarker: entitymodel.GenotypeMarker{
+		attrs, err := decodeMap(attributesRaw)
+		if err != nil {
+			return nil, fmt.Errorf("decode genotype_marker %s attributes: %w", id, err)
+		}
+		marker := domain.GenotypeMarker{GenotypeMarker: entitymodel.GenotypeMarker{
 			ID:             id,
 			Name:           name,
 			Locus:          locus,
@@ -1471,6 +2590,10 @@ func loadGenotypeMarkers(ctx context.Context, db execQuerier) (map[string]domain
 			CreatedAt:      createdAt,
 			UpdatedAt:      updatedAt,
 		}}
+		if err := marker.ApplyGenotypeMarkerAttributes(attrs); err != nil {
+			return nil, fmt.Errorf("hydrate genotype_marker %s attributes: %w", id, err)
+		}
+		out[id] = marker
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate genotype_markers: %w", err)
@@ -1479,7 +2602,57 @@ func loadGenotypeMarkers(ctx context.Context, db execQuerier) (map[string]domain
 }
 
 func loadLines(ctx context.Context, db execQuerier) (map[string]domain.Line, error) {
-	rows, err := db.QueryContext(ctx, selectLinesSQL)
+	return loadLinesWhere(ctx, db, selectLinesSQL)
+}
+
+// loadSingleLine loads one line row and its genotype marker join rows inside a
+// single read-only transaction.
+func loadSingleLine(ctx context.Context, db *sql.DB, id string) (map[string]domain.Line, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	lines, err := loadLinesWhere(ctx, tx, selectLineByIDSQL, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadLineMarkersWhere(ctx, tx, lines, selectLineMarkersByIDSQL, id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return lines, nil
+}
+
+// loadLineByCode loads the line with code and its genotype marker join rows
+// inside a single read-only transaction.
+func loadLineByCode(ctx context.Context, db *sql.DB, code string) (map[string]domain.Line, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	lines, err := loadLinesWhere(ctx, tx, selectLineByCodeSQL, code)
+	if err != nil {
+		return nil, err
+	}
+	for id := range lines {
+		if err := loadLineMarkersWhere(ctx, tx, lines, selectLineMarkersByIDSQL, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return lines, nil
+}
+
+// loadLinesWhere loads line rows returned by query, which must select the
+// columns of selectLinesSQL.
+func loadLinesWhere(ctx context.Context, db execQuerier, query string, args ...any) (map[string]domain.Line, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("select lines: %w", err)
 	}
@@ -1491,13 +2664,18 @@ func loadLines(ctx context.Context, db execQuerier) (map[string]domain.Line, err
 			id, code, name, origin        string
 			description                   sql.NullString
 			defaultAttrsRaw, overridesRaw []byte
+			tagsRaw                       []byte
 			deprecatedAt                  sql.NullTime
 			deprecationReason             sql.NullString
 			createdAt, updatedAt          time.Time
 		)
-		if err := rows.Scan(&id, &code, &name, &origin, &description, &defaultAttrsRaw, &overridesRaw, &deprecatedAt, &deprecationReason, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &code, &name, &origin, &description, &defaultAttrsRaw, &overridesRaw, &tagsRaw, &deprecatedAt, &deprecationReason, &createdAt, &updatedAt); err != nil {
 			return nil, fmt.Errorf("scan lines: %w", err)
 		}
+		tags, err := decodeStringSlice(tagsRaw)
+		if err != nil {
+			return nil, fmt.Errorf("decode line %s tags: %w", id, err)
+		}
 		defaultAttrs, err := decodeMap(defaultAttrsRaw)
 		if err != nil {
 			return nil, fmt.Errorf("decode line %s default_attributes: %w", id, err)
@@ -1518,7 +2696,7 @@ func loadLines(ctx context.Context, db execQuerier) (map[string]domain.Line, err
 		if description.Valid {
 			descriptionPtr = &description.String
 		}
-		out[id] = domain.Line{Line: entitymodel.Line{
+		line := domain.Line{Line: entitymodel.Line{
 			ID:                 id,
 			Code:               code,
 			Name:               name,
@@ -1526,11 +2704,19 @@ func loadLines(ctx context.Context, db execQuerier) (map[string]domain.Line, err
 			Description:        descriptionPtr,
 			DefaultAttributes:  defaultAttrs,
 			ExtensionOverrides: overrides,
+			Tags:               tags,
 			DeprecatedAt:       deprecatedPtr,
 			DeprecationReason:  deprecationReasonPtr,
 			CreatedAt:          createdAt,
 			UpdatedAt:          updatedAt,
 		}}
+		if err := line.ApplyDefaultAttributes(defaultAttrs); err != nil {
+			return nil, fmt.Errorf("hydrate line %s default_attributes: %w", id, err)
+		}
+		if err := line.ApplyExtensionOverrides(overrides); err != nil {
+			return nil, fmt.Errorf("hydrate line %s extension_overrides: %w", id, err)
+		}
+		out[id] = line
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate lines: %w", err)
@@ -1539,7 +2725,13 @@ func loadLines(ctx context.Context, db execQuerier) (map[string]domain.Line, err
 }
 
 func loadLineMarkers(ctx context.Context, db execQuerier, lines map[string]domain.Line) error {
-	rows, err := db.QueryContext(ctx, selectLineMarkersSQL)
+	return loadLineMarkersWhere(ctx, db, lines, selectLineMarkersSQL)
+}
+
+// loadLineMarkersWhere fills GenotypeMarkerIDs from the join rows returned by
+// query, invoked with args.
+func loadLineMarkersWhere(ctx context.Context, db execQuerier, lines map[string]domain.Line, query string, args ...any) error {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("select line markers: %w", err)
 	}
@@ -1570,7 +2762,57 @@ func loadLineMarkers(ctx context.Context, db execQuerier, lines map[string]domai
 }
 
 func loadStrains(ctx context.Context, db execQuerier) (map[string]domain.Strain, error) {
-	rows, err := db.QueryContext(ctx, selectStrainsSQL)
+	return loadStrainsWhere(ctx, db, selectStrainsSQL)
+}
+
+// loadSingleStrain loads one strain row and its genotype marker join rows
+// inside a single read-only transaction.
+func loadSingleStrain(ctx context.Context, db *sql.DB, id string) (map[string]domain.Strain, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	strains, err := loadStrainsWhere(ctx, tx, selectStrainByIDSQL, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadStrainMarkersWhere(ctx, tx, strains, selectStrainMarkersByIDSQL, id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return strains, nil
+}
+
+// loadStrainByCode loads the strain with code on lineID and its genotype
+// marker join rows inside a single read-only transaction.
+func loadStrainByCode(ctx context.Context, db *sql.DB, lineID, code string) (map[string]domain.Strain, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	strains, err := loadStrainsWhere(ctx, tx, selectStrainByCodeSQL, lineID, code)
+	if err != nil {
+		return nil, err
+	}
+	for id := range strains {
+		if err := loadStrainMarkersWhere(ctx, tx, strains, selectStrainMarkersByIDSQL, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return strains, nil
+}
+
+// loadStrainsWhere loads strain rows returned by query, which must select the
+// columns of selectStrainsSQL.
+func loadStrainsWhere(ctx context.Context, db execQuerier, query string, args ...any) (map[string]domain.Strain, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("select strains: %w", err)
 	}
@@ -1583,11 +2825,16 @@ func loadStrains(ctx context.Context, db execQuerier) (map[string]domain.Strain,
 			description, generation sql.NullString
 			retiredAt               sql.NullTime
 			retirementReason        sql.NullString
+			attributesRaw           []byte
 			createdAt, updatedAt    time.Time
 		)
-		if err := rows.Scan(&id, &code, &name, &lineID, &description, &generation, &retiredAt, &retirementReason, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &code, &name, &lineID, &description, &generation, &retiredAt, &retirementReason, &attributesRaw, &createdAt, &updatedAt); err != nil {
 			return nil, fmt.Errorf("scan strains: %w", err)
 		}
+		attrs, err := decodeMap(attributesRaw)
+		if err != nil {
+			return nil, fmt.Errorf("decode strain %s attributes: %w", id, err)
+		}
 		var descriptionPtr *string
 		if description.Valid {
 			descriptionPtr = &description.String
@@ -1604,7 +2851,7 @@ func loadStrains(ctx context.Context, db execQuerier) (map[string]domain.Strain,
 		if retirementReason.Valid {
 			retirementReasonPtr = &retirementReason.String
 		}
-		out[id] = domain.Strain{Strain: entitymodel.Strain{
+		strain := domain.Strain{Strain: entitymodel.Strain{
 			ID:               id,
 			Code:             code,
 			Name:             name,
@@ -1616,6 +2863,10 @@ func loadStrains(ctx context.Context, db execQuerier) (map[string]domain.Strain,
 			CreatedAt:        createdAt,
 			UpdatedAt:        updatedAt,
 		}}
+		if err := strain.ApplyStrainAttributes(attrs); err != nil {
+			return nil, fmt.Errorf("hydrate strain %s attributes: %w", id, err)
+		}
+		out[id] = strain
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate strains: %w", err)
@@ -1624,7 +2875,13 @@ func loadStrains(ctx context.Context, db execQuerier) (map[string]domain.Strain,
 }
 
 func loadStrainMarkers(ctx context.Context, db execQuerier, strains map[string]domain.Strain) error {
-	rows, err := db.QueryContext(ctx, selectStrainMarkersSQL)
+	return loadStrainMarkersWhere(ctx, db, strains, selectStrainMarkersSQL)
+}
+
+// loadStrainMarkersWhere fills GenotypeMarkerIDs from the join rows returned
+// by query, invoked with args.
+func loadStrainMarkersWhere(ctx context.Context, db execQuerier, strains map[string]domain.Strain, query string, args ...any) error {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("select strain markers: %w", err)
 	}
@@ -1652,7 +2909,13 @@ func loadStrainMarkers(ctx context.Context, db execQuerier, strains map[string]d
 }
 
 func loadHousingUnits(ctx context.Context, db execQuerier) (map[string]domain.HousingUnit, error) {
-	rows, err := db.QueryContext(ctx, selectHousingSQL)
+	return loadHousingUnitsWhere(ctx, db, selectHousingSQL)
+}
+
+// loadHousingUnitsWhere loads housing unit rows returned by query, which must
+// select the columns of selectHousingSQL.
+func loadHousingUnitsWhere(ctx context.Context, db execQuerier, query string, args ...any) (map[string]domain.HousingUnit, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("select housing_units: %w", err)
 	}
@@ -1662,12 +2925,12 @@ func loadHousingUnits(ctx context.Context, db execQuerier) (map[string]domain.Ho
 	for rows.Next() {
 		var (
 			id, facilityID, name string
-			capacity             int
+			capacity, version    int
 			environment          domain.HousingEnvironment
 			state                domain.HousingState
 			createdAt, updatedAt time.Time
 		)
-		if err := rows.Scan(&id, &facilityID, &name, &capacity, &environment, &state, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &facilityID, &name, &capacity, &environment, &state, &createdAt, &updatedAt, &version); err != nil {
 			return nil, fmt.Errorf("scan housing_units: %w", err)
 		}
 		out[id] = domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{
@@ -1679,6 +2942,7 @@ func loadHousingUnits(ctx context.Context, db execQuerier) (map[string]domain.Ho
 			State:       entitymodel.HousingState(state),
 			CreatedAt:   createdAt,
 			UpdatedAt:   updatedAt,
+			Version:     version,
 		}}
 	}
 	if err := rows.Err(); err != nil {
@@ -1688,7 +2952,13 @@ func loadHousingUnits(ctx context.Context, db execQuerier) (map[string]domain.Ho
 }
 
 func loadProtocols(ctx context.Context, db execQuerier) (map[string]domain.Protocol, error) {
-	rows, err := db.QueryContext(ctx, selectProtocolSQL)
+	return loadProtocolsWhere(ctx, db, selectProtocolSQL)
+}
+
+// loadProtocolsWhere loads protocol rows returned by query, which must select
+// the columns of selectProtocolSQL.
+func loadProtocolsWhere(ctx context.Context, db execQuerier, query string, args ...any) (map[string]domain.Protocol, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("select protocols: %w", err)
 	}
@@ -1699,13 +2969,18 @@ func loadProtocols(ctx context.Context, db execQuerier) (map[string]domain.Proto
 		var (
 			id, code, title      string
 			description          sql.NullString
-			maxSubjects          int
+			maxSubjects, version int
+			reviewersRaw         []byte
 			status               domain.ProtocolStatus
 			createdAt, updatedAt time.Time
 		)
-		if err := rows.Scan(&id, &code, &title, &description, &maxSubjects, &status, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &code, &title, &description, &maxSubjects, &reviewersRaw, &status, &createdAt, &updatedAt, &version); err != nil {
 			return nil, fmt.Errorf("scan protocols: %w", err)
 		}
+		reviewers, err := decodeStringSlice(reviewersRaw)
+		if err != nil {
+			return nil, fmt.Errorf("decode protocol %s reviewer_ids: %w", id, err)
+		}
 		var descriptionPtr *string
 		if description.Valid {
 			descriptionPtr = &description.String
@@ -1716,9 +2991,11 @@ func loadProtocols(ctx context.Context, db execQuerier) (map[string]domain.Proto
 			Title:       title,
 			Description: descriptionPtr,
 			MaxSubjects: maxSubjects,
+			ReviewerIDs: reviewers,
 			Status:      entitymodel.ProtocolStatus(status),
 			CreatedAt:   createdAt,
 			UpdatedAt:   updatedAt,
+			Version:     version,
 		}}
 	}
 	if err := rows.Err(); err != nil {
@@ -1739,9 +3016,10 @@ func loadProjects(ctx context.Context, db execQuerier) (map[string]domain.Projec
 		var (
 			id, code, title      string
 			description          sql.NullString
+			closedAt             sql.NullTime
 			createdAt, updatedAt time.Time
 		)
-		if err := rows.Scan(&id, &code, &title, &description, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &code, &title, &description, &closedAt, &createdAt, &updatedAt); err != nil {
 			return nil, fmt.Errorf("scan projects: %w", err)
 		}
 		var descriptionPtr *string
@@ -1753,6 +3031,7 @@ func loadProjects(ctx context.Context, db execQuerier) (map[string]domain.Projec
 			Code:        code,
 			Title:       title,
 			Description: descriptionPtr,
+			ClosedAt:    nullableTime(closedAt),
 			CreatedAt:   createdAt,
 			UpdatedAt:   updatedAt,
 		}}
@@ -1831,7 +3110,37 @@ func loadProjectProtocols(ctx context.Context, db execQuerier, projects map[stri
 }
 
 func loadPermits(ctx context.Context, db execQuerier) (map[string]domain.Permit, error) {
-	rows, err := db.QueryContext(ctx, selectPermitSQL)
+	return loadPermitsWhere(ctx, db, selectPermitSQL)
+}
+
+// loadSinglePermit loads one permit row and its facility and protocol join
+// rows inside a single read-only transaction.
+func loadSinglePermit(ctx context.Context, db *sql.DB, id string) (map[string]domain.Permit, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	permits, err := loadPermitsWhere(ctx, tx, selectPermitByIDSQL, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadPermitFacilitiesWhere(ctx, tx, permits, selectPermitFacilitiesByIDSQL, id); err != nil {
+		return nil, err
+	}
+	if err := loadPermitProtocolsWhere(ctx, tx, permits, selectPermitProtocolsByIDSQL, id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return permits, nil
+}
+
+// loadPermitsWhere loads permit rows returned by query, which must select the
+// columns of selectPermitSQL.
+func loadPermitsWhere(ctx context.Context, db execQuerier, query string, args ...any) (map[string]domain.Permit, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("select permits: %w", err)
 	}
@@ -1842,12 +3151,13 @@ func loadPermits(ctx context.Context, db execQuerier) (map[string]domain.Permit,
 		var (
 			id, permitNumber, authority string
 			status                      domain.PermitStatus
+			issueDate                   time.Time
 			validFrom, validUntil       time.Time
 			activitiesRaw               []byte
 			notes                       sql.NullString
 			createdAt, updatedAt        time.Time
 		)
-		if err := rows.Scan(&id, &permitNumber, &authority, &status, &validFrom, &validUntil, &activitiesRaw, &notes, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &permitNumber, &authority, &status, &issueDate, &validFrom, &validUntil, &activitiesRaw, &notes, &createdAt, &updatedAt); err != nil {
 			return nil, fmt.Errorf("scan permits: %w", err)
 		}
 		activities, err := decodeStringSlice(activitiesRaw)
@@ -1863,6 +3173,7 @@ func loadPermits(ctx context.Context, db execQuerier) (map[string]domain.Permit,
 			PermitNumber:      permitNumber,
 			Authority:         authority,
 			Status:            entitymodel.PermitStatus(status),
+			IssueDate:         issueDate,
 			ValidFrom:         validFrom,
 			ValidUntil:        validUntil,
 			AllowedActivities: activities,
@@ -1878,7 +3189,13 @@ func loadPermits(ctx context.Context, db execQuerier) (map[string]domain.Permit,
 }
 
 func loadPermitFacilities(ctx context.Context, db execQuerier, permits map[string]domain.Permit) error {
-	rows, err := db.QueryContext(ctx, selectPermitFacilitiesSQL)
+	return loadPermitFacilitiesWhere(ctx, db, permits, selectPermitFacilitiesSQL)
+}
+
+// loadPermitFacilitiesWhere fills FacilityIDs from the permit facility join
+// rows returned by query, invoked with args.
+func loadPermitFacilitiesWhere(ctx context.Context, db execQuerier, permits map[string]domain.Permit, query string, args ...any) error {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("select permit facilities: %w", err)
 	}
@@ -1909,7 +3226,13 @@ func loadPermitFacilities(ctx context.Context, db execQuerier, permits map[strin
 }
 
 func loadPermitProtocols(ctx context.Context, db execQuerier, permits map[string]domain.Permit) error {
-	rows, err := db.QueryContext(ctx, selectPermitProtocolsSQL)
+	return loadPermitProtocolsWhere(ctx, db, permits, selectPermitProtocolsSQL)
+}
+
+// loadPermitProtocolsWhere fills ProtocolIDs from the permit protocol join rows
+// returned by query, invoked with args.
+func loadPermitProtocolsWhere(ctx context.Context, db execQuerier, permits map[string]domain.Permit, query string, args ...any) error {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("select permit protocols: %w", err)
 	}
@@ -1950,21 +3273,25 @@ func loadCohorts(ctx context.Context, db execQuerier) (map[string]domain.Cohort,
 	for rows.Next() {
 		var (
 			id, name, purpose                string
+			species                          sql.NullString
 			projectID, housingID, protocolID sql.NullString
+			createdFromBreedingUnitID        sql.NullString
 			createdAt, updatedAt             time.Time
 		)
-		if err := rows.Scan(&id, &name, &purpose, &projectID, &housingID, &protocolID, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &name, &purpose, &species, &projectID, &housingID, &protocolID, &createdFromBreedingUnitID, &createdAt, &updatedAt); err != nil {
 			return nil, fmt.Errorf("scan cohorts: %w", err)
 		}
 		out[id] = domain.Cohort{Cohort: entitymodel.Cohort{
-			ID:         id,
-			Name:       name,
-			Purpose:    purpose,
-			ProjectID:  nullableString(projectID),
-			HousingID:  nullableString(housingID),
-			ProtocolID: nullableString(protocolID),
-			CreatedAt:  createdAt,
-			UpdatedAt:  updatedAt,
+			ID:                        id,
+			Name:                      name,
+			Purpose:                   purpose,
+			Species:                   nullableString(species),
+			ProjectID:                 nullableString(projectID),
+			HousingID:                 nullableString(housingID),
+			ProtocolID:                nullableString(protocolID),
+			CreatedFromBreedingUnitID: nullableString(createdFromBreedingUnitID),
+			CreatedAt:                 createdAt,
+			UpdatedAt:                 updatedAt,
 		}}
 	}
 	if err := rows.Err(); err != nil {
@@ -1974,7 +3301,13 @@ func loadCohorts(ctx context.Context, db execQuerier) (map[string]domain.Cohort,
 }
 
 func loadBreedingUnits(ctx context.Context, db execQuerier) (map[string]domain.BreedingUnit, error) {
-	rows, err := db.QueryContext(ctx, selectBreedingSQL)
+	return loadBreedingUnitsWhere(ctx, db, selectBreedingSQL)
+}
+
+// loadBreedingUnitsWhere loads breeding unit rows returned by query, which
+// must select the columns of selectBreedingSQL.
+func loadBreedingUnitsWhere(ctx context.Context, db execQuerier, query string, args ...any) (map[string]domain.BreedingUnit, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("select breeding_units: %w", err)
 	}
@@ -1997,7 +3330,7 @@ func loadBreedingUnits(ctx context.Context, db execQuerier) (map[string]domain.B
 		if err != nil {
 			return nil, fmt.Errorf("decode breeding_unit %s pairing_attributes: %w", id, err)
 		}
-		out[id] = domain.BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{
+		unit := domain.BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{
 			ID:                id,
 			Name:              name,
 			Strategy:          strategy,
@@ -2008,11 +3341,15 @@ func loadBreedingUnits(ctx context.Context, db execQuerier) (map[string]domain.B
 			TargetStrainID:    nullableString(targetStrainID),
 			ProtocolID:        nullableString(protocolID),
 			PairingAttributes: pairingAttrs,
-			PairingIntent:     nullableString(pairingIntent),
+			PairingIntent:     nullablePairingIntent(pairingIntent),
 			PairingNotes:      nullableString(pairingNotes),
 			CreatedAt:         createdAt,
 			UpdatedAt:         updatedAt,
 		}}
+		if err := unit.ApplyPairingAttributes(pairingAttrs); err != nil {
+			return nil, fmt.Errorf("hydrate breeding_unit %s pairing_attributes: %w", id, err)
+		}
+		out[id] = unit
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate breeding_units: %w", err)
@@ -2021,7 +3358,13 @@ func loadBreedingUnits(ctx context.Context, db execQuerier) (map[string]domain.B
 }
 
 func loadBreedingUnitMembers(ctx context.Context, db execQuerier, breeding map[string]domain.BreedingUnit) error {
-	femaleRows, err := db.QueryContext(ctx, selectBreedingFemalesSQL)
+	return loadBreedingUnitMembersWhere(ctx, db, breeding, selectBreedingFemalesSQL, selectBreedingMalesSQL)
+}
+
+// loadBreedingUnitMembersWhere fills FemaleIDs and MaleIDs from the join rows
+// returned by femalesQuery and malesQuery, both invoked with args.
+func loadBreedingUnitMembersWhere(ctx context.Context, db execQuerier, breeding map[string]domain.BreedingUnit, femalesQuery, malesQuery string, args ...any) error {
+	femaleRows, err := db.QueryContext(ctx, femalesQuery, args...)
 	if err != nil {
 		return fmt.Errorf("select breeding female_ids: %w", err)
 	}
@@ -2042,7 +3385,7 @@ func loadBreedingUnitMembers(ctx context.Context, db execQuerier, breeding map[s
 		return fmt.Errorf("iterate breeding female_ids: %w", err)
 	}
 
-	maleRows, err := db.QueryContext(ctx, selectBreedingMalesSQL)
+	maleRows, err := db.QueryContext(ctx, malesQuery, args...)
 	if err != nil {
 		return fmt.Errorf("select breeding male_ids: %w", err)
 	}
@@ -2071,7 +3414,34 @@ func loadBreedingUnitMembers(ctx context.Context, db execQuerier, breeding map[s
 }
 
 func loadOrganisms(ctx context.Context, db execQuerier) (map[string]domain.Organism, error) {
-	rows, err := db.QueryContext(ctx, selectOrganismSQL)
+	return loadOrganismsWhere(ctx, db, selectOrganismSQL)
+}
+
+// loadSingleOrganism loads one organism row and its parent join rows inside a
+// single read-only transaction.
+func loadSingleOrganism(ctx context.Context, db *sql.DB, id string) (map[string]domain.Organism, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	organisms, err := loadOrganismsWhere(ctx, tx, selectOrganismByIDSQL, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadOrganismParentsWhere(ctx, tx, organisms, selectOrganismParentsByIDSQL, id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return organisms, nil
+}
+
+// loadOrganismsWhere loads organism rows returned by query, which must select
+// the columns of selectOrganismSQL.
+func loadOrganismsWhere(ctx context.Context, db execQuerier, query string, args ...any) (map[string]domain.Organism, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("select organisms: %w", err)
 	}
@@ -2087,15 +3457,16 @@ func loadOrganisms(ctx context.Context, db execQuerier) (map[string]domain.Organ
 			protocolID, projectID   sql.NullString
 			attributesRaw           []byte
 			createdAt, updatedAt    time.Time
+			version                 int
 		)
-		if err := rows.Scan(&id, &name, &species, &line, &stage, &lineID, &strainID, &cohortID, &housingID, &protocolID, &projectID, &attributesRaw, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &name, &species, &line, &stage, &lineID, &strainID, &cohortID, &housingID, &protocolID, &projectID, &attributesRaw, &createdAt, &updatedAt, &version); err != nil {
 			return nil, fmt.Errorf("scan organisms: %w", err)
 		}
 		attrs, err := decodeMap(attributesRaw)
 		if err != nil {
 			return nil, fmt.Errorf("decode organism %s attributes: %w", id, err)
 		}
-		out[id] = domain.Organism{Organism: entitymodel.Organism{
+		organism := domain.Organism{Organism: entitymodel.Organism{
 			ID:         id,
 			Name:       name,
 			Species:    species,
@@ -2110,7 +3481,12 @@ func loadOrganisms(ctx context.Context, db execQuerier) (map[string]domain.Organ
 			Attributes: attrs,
 			CreatedAt:  createdAt,
 			UpdatedAt:  updatedAt,
+			Version:    version,
 		}}
+		if err := organism.SetCoreAttributes(attrs); err != nil {
+			return nil, fmt.Errorf("hydrate organism %s attributes: %w", id, err)
+		}
+		out[id] = organism
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate organisms: %w", err)
@@ -2119,7 +3495,13 @@ func loadOrganisms(ctx context.Context, db execQuerier) (map[string]domain.Organ
 }
 
 func loadOrganismParents(ctx context.Context, db execQuerier, organisms map[string]domain.Organism) error {
-	rows, err := db.QueryContext(ctx, selectOrganismParentsSQL)
+	return loadOrganismParentsWhere(ctx, db, organisms, selectOrganismParentsSQL)
+}
+
+// loadOrganismParentsWhere fills ParentIDs from the organism parent join rows
+// returned by query, invoked with args.
+func loadOrganismParentsWhere(ctx context.Context, db execQuerier, organisms map[string]domain.Organism, query string, args ...any) error {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("select organism parents: %w", err)
 	}
@@ -2160,21 +3542,23 @@ func loadProcedures(ctx context.Context, db execQuerier) (map[string]domain.Proc
 			status                            domain.ProcedureStatus
 			scheduledAt, createdAt, updatedAt time.Time
 			protocolID                        string
+			cancellationReason                sql.NullString
 			projectID, cohortID               sql.NullString
 		)
-		if err := rows.Scan(&id, &name, &status, &scheduledAt, &protocolID, &projectID, &cohortID, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &name, &status, &cancellationReason, &scheduledAt, &protocolID, &projectID, &cohortID, &createdAt, &updatedAt); err != nil {
 			return nil, fmt.Errorf("scan procedures: %w", err)
 		}
 		out[id] = domain.Procedure{Procedure: entitymodel.Procedure{
-			ID:          id,
-			Name:        name,
-			Status:      entitymodel.ProcedureStatus(status),
-			ScheduledAt: scheduledAt,
-			ProtocolID:  protocolID,
-			ProjectID:   nullableString(projectID),
-			CohortID:    nullableString(cohortID),
-			CreatedAt:   createdAt,
-			UpdatedAt:   updatedAt,
+			ID:                 id,
+			Name:               name,
+			Status:             entitymodel.ProcedureStatus(status),
+			CancellationReason: nullableString(cancellationReason),
+			ScheduledAt:        scheduledAt,
+			ProtocolID:         protocolID,
+			ProjectID:          nullableString(projectID),
+			CohortID:           nullableString(cohortID),
+			CreatedAt:          createdAt,
+			UpdatedAt:          updatedAt,
 		}}
 	}
 	if err := rows.Err(); err != nil {
@@ -2211,11 +3595,48 @@ func loadProcedureOrganisms(ctx context.Context, db execQuerier, procedures map[
 	return nil
 }
 
+// deriveProcedureChildren populates Procedure.TreatmentIDs and
+// Procedure.ObservationIDs from the child rows' procedure_id columns. The IDs
+// are not persisted on the procedure itself, so this mirrors the derivation the
+// in-memory store applies when listing procedures.
+func deriveProcedureChildren(procedures map[string]domain.Procedure, treatments map[string]domain.Treatment, observations map[string]domain.Observation) {
+	treatmentIDs := make(map[string][]string)
+	for id, treatment := range treatments {
+		if _, ok := procedures[treatment.ProcedureID]; ok {
+			treatmentIDs[treatment.ProcedureID] = append(treatmentIDs[treatment.ProcedureID], id)
+		}
+	}
+	observationIDs := make(map[string][]string)
+	for id, observation := range observations {
+		if observation.ProcedureID == nil {
+			continue
+		}
+		if _, ok := procedures[*observation.ProcedureID]; ok {
+			observationIDs[*observation.ProcedureID] = append(observationIDs[*observation.ProcedureID], id)
+		}
+	}
+	for id, proc := range procedures {
+		proc.TreatmentIDs = treatmentIDs[id]
+		proc.ObservationIDs = observationIDs[id]
+		sort.Strings(proc.TreatmentIDs)
+		sort.Strings(proc.ObservationIDs)
+		procedures[id] = proc
+	}
+}
+
 func loadObservations(ctx context.Context, db execQuerier) (map[string]domain.Observation, error) {
-	rows, err := db.QueryContext(ctx, selectObservationSQL)
+	return loadObservationsWhere(ctx, db, selectObservationSQL)
+}
+
+func loadObservationsWhere(ctx context.Context, db execQuerier, query string, args ...any) (map[string]domain.Observation, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("select observations: %w", err)
 	}
+	return scanObservations(rows)
+}
+
+func scanObservations(rows *sql.Rows) (map[string]domain.Observation, error) {
 	defer func() { _ = rows.Close() }()
 
 	out := make(map[string]domain.Observation)
@@ -2225,16 +3646,26 @@ func loadObservations(ctx context.Context, db execQuerier) (map[string]domain.Ob
 			recordedAt, createdAt, updatedAt  time.Time
 			procedureID, organismID, cohortID sql.NullString
 			dataRaw                           []byte
-			notes                             sql.NullString
+			notes, recordedBy, reviewedBy     sql.NullString
+			reviewedAt                        sql.NullTime
+			category                          sql.NullString
+			attachmentsRaw                    []byte
+			weight, length, temperature       sql.NullFloat64
 		)
-		if err := rows.Scan(&id, &observer, &recordedAt, &procedureID, &organismID, &cohortID, &dataRaw, &notes, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &observer, &recordedAt, &procedureID, &organismID, &cohortID, &dataRaw, &notes, &recordedBy, &reviewedBy, &reviewedAt, &createdAt, &updatedAt, &category, &attachmentsRaw, &weight, &length, &temperature); err != nil {
 			return nil, fmt.Errorf("scan observations: %w", err)
 		}
 		data, err := decodeMap(dataRaw)
 		if err != nil {
 			return nil, fmt.Errorf("decode observation %s data: %w", id, err)
 		}
-		out[id] = domain.Observation{Observation: entitymodel.Observation{
+		var attachments []domain.AttachmentRef
+		if len(attachmentsRaw) > 0 {
+			if err := json.Unmarshal(attachmentsRaw, &attachments); err != nil {
+				return nil, fmt.Errorf("decode observation %s attachments: %w", id, err)
+			}
+		}
+		observation := domain.Observation{Observation: entitymodel.Observation{
 			ID:          id,
 			Observer:    observer,
 			RecordedAt:  recordedAt,
@@ -2243,9 +3674,21 @@ func loadObservations(ctx context.Context, db execQuerier) (map[string]domain.Ob
 			CohortID:    nullableString(cohortID),
 			Data:        data,
 			Notes:       nullableString(notes),
+			RecordedBy:  nullableString(recordedBy),
+			ReviewedBy:  nullableString(reviewedBy),
+			ReviewedAt:  nullableTime(reviewedAt),
+			Category:    nullableString(category),
+			Attachments: attachments,
+			Weight:      nullableFloat(weight),
+			Length:      nullableFloat(length),
+			Temperature: nullableFloat(temperature),
 			CreatedAt:   createdAt,
 			UpdatedAt:   updatedAt,
 		}}
+		if err := observation.ApplyObservationData(data); err != nil {
+			return nil, fmt.Errorf("hydrate observation %s data: %w", id, err)
+		}
+		out[id] = observation
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate observations: %w", err)
@@ -2254,7 +3697,13 @@ func loadObservations(ctx context.Context, db execQuerier) (map[string]domain.Ob
 }
 
 func loadSamples(ctx context.Context, db execQuerier) (map[string]domain.Sample, error) {
-	rows, err := db.QueryContext(ctx, selectSampleSQL)
+	return loadSamplesWhere(ctx, db, selectSampleSQL)
+}
+
+// loadSamplesWhere loads sample rows returned by query, which must select the
+// columns of selectSampleSQL.
+func loadSamplesWhere(ctx context.Context, db execQuerier, query string, args ...any) (map[string]domain.Sample, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("select samples: %w", err)
 	}
@@ -2264,12 +3713,12 @@ func loadSamples(ctx context.Context, db execQuerier) (map[string]domain.Sample,
 	for rows.Next() {
 		var (
 			id, identifier, sourceType, status, storageLocation, assayType string
-			facilityID                                                     string
-			organismID, cohortID                                           sql.NullString
+			facilityID, collectedBy                                        string
+			organismID, cohortID, collectionProtocol                       sql.NullString
 			chainRaw, attrsRaw                                             []byte
 			collectedAt, createdAt, updatedAt                              time.Time
 		)
-		if err := rows.Scan(&id, &identifier, &sourceType, &status, &storageLocation, &assayType, &facilityID, &organismID, &cohortID, &chainRaw, &attrsRaw, &collectedAt, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &identifier, &sourceType, &status, &storageLocation, &assayType, &facilityID, &organismID, &cohortID, &chainRaw, &attrsRaw, &collectedAt, &collectedBy, &collectionProtocol, &createdAt, &updatedAt); err != nil {
 			return nil, fmt.Errorf("scan samples: %w", err)
 		}
 		chain, err := decodeCustody(chainRaw)
@@ -2280,22 +3729,28 @@ func loadSamples(ctx context.Context, db execQuerier) (map[string]domain.Sample,
 		if err != nil {
 			return nil, fmt.Errorf("decode sample %s attributes: %w", id, err)
 		}
-		out[id] = domain.Sample{Sample: entitymodel.Sample{
-			ID:              id,
-			Identifier:      identifier,
-			SourceType:      sourceType,
-			Status:          entitymodel.SampleStatus(status),
-			StorageLocation: storageLocation,
-			AssayType:       assayType,
-			FacilityID:      facilityID,
-			OrganismID:      nullableString(organismID),
-			CohortID:        nullableString(cohortID),
-			ChainOfCustody:  chain,
-			Attributes:      attrs,
-			CollectedAt:     collectedAt,
-			CreatedAt:       createdAt,
-			UpdatedAt:       updatedAt,
+		sample := domain.Sample{Sample: entitymodel.Sample{
+			ID:                 id,
+			Identifier:         identifier,
+			SourceType:         sourceType,
+			Status:             entitymodel.SampleStatus(status),
+			StorageLocation:    storageLocation,
+			AssayType:          assayType,
+			FacilityID:         facilityID,
+			OrganismID:         nullableString(organismID),
+			CohortID:           nullableString(cohortID),
+			ChainOfCustody:     chain,
+			Attributes:         attrs,
+			CollectedAt:        collectedAt,
+			CollectedBy:        collectedBy,
+			CollectionProtocol: nullableString(collectionProtocol),
+			CreatedAt:          createdAt,
+			UpdatedAt:          updatedAt,
 		}}
+		if err := sample.ApplySampleAttributes(attrs); err != nil {
+			return nil, fmt.Errorf("hydrate sample %s attributes: %w", id, err)
+		}
+		out[id] = sample
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate samples: %w", err)
@@ -2304,7 +3759,37 @@ func loadSamples(ctx context.Context, db execQuerier) (map[string]domain.Sample,
 }
 
 func loadSupplyItems(ctx context.Context, db execQuerier) (map[string]domain.SupplyItem, error) {
-	rows, err := db.QueryContext(ctx, selectSupplySQL)
+	return loadSupplyItemsWhere(ctx, db, selectSupplySQL)
+}
+
+// loadSupplyItemByID loads one supply item row and its facility and project
+// join rows inside a single read-only transaction.
+func loadSupplyItemByID(ctx context.Context, db *sql.DB, id string) (map[string]domain.SupplyItem, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	supplies, err := loadSupplyItemsWhere(ctx, tx, selectSupplyByIDSQL, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadSupplyItemFacilitiesWhere(ctx, tx, supplies, selectSupplyFacilitiesByIDSQL, id); err != nil {
+		return nil, err
+	}
+	if err := loadSupplyItemProjectsWhere(ctx, tx, supplies, selectProjectSuppliesBySupplyIDSQL, id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return supplies, nil
+}
+
+// loadSupplyItemsWhere loads supply item rows returned by query, which must
+// select the columns of selectSupplySQL.
+func loadSupplyItemsWhere(ctx context.Context, db execQuerier, query string, args ...any) (map[string]domain.SupplyItem, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("select supply_items: %w", err)
 	}
@@ -2314,26 +3799,30 @@ func loadSupplyItems(ctx context.Context, db execQuerier) (map[string]domain.Sup
 	for rows.Next() {
 		var (
 			id, sku, name, unit  string
+			status               string
 			quantity, reorder    int
+			category             sql.NullString
 			description, lot     sql.NullString
 			expiresAt            sql.NullTime
 			attrsRaw             []byte
 			createdAt, updatedAt time.Time
 		)
-		if err := rows.Scan(&id, &sku, &name, &quantity, &unit, &reorder, &description, &lot, &expiresAt, &attrsRaw, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &sku, &name, &quantity, &unit, &category, &reorder, &status, &description, &lot, &expiresAt, &attrsRaw, &createdAt, &updatedAt); err != nil {
 			return nil, fmt.Errorf("scan supply_items: %w", err)
 		}
 		attrs, err := decodeMap(attrsRaw)
 		if err != nil {
 			return nil, fmt.Errorf("decode supply_item %s attributes: %w", id, err)
 		}
-		out[id] = domain.SupplyItem{SupplyItem: entitymodel.SupplyItem{
+		supply := domain.SupplyItem{SupplyItem: entitymodel.SupplyItem{
 			ID:             id,
 			SKU:            sku,
 			Name:           name,
 			QuantityOnHand: quantity,
 			Unit:           unit,
+			Category:       nullableString(category),
 			ReorderLevel:   reorder,
+			Status:         entitymodel.SupplyStatus(status),
 			Description:    nullableString(description),
 			LotNumber:      nullableString(lot),
 			ExpiresAt:      nullableTime(expiresAt),
@@ -2341,6 +3830,10 @@ func loadSupplyItems(ctx context.Context, db execQuerier) (map[string]domain.Sup
 			CreatedAt:      createdAt,
 			UpdatedAt:      updatedAt,
 		}}
+		if err := supply.ApplySupplyAttributes(attrs); err != nil {
+			return nil, fmt.Errorf("hydrate supply_item %s attributes: %w", id, err)
+		}
+		out[id] = supply
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate supply_items: %w", err)
@@ -2349,7 +3842,13 @@ func loadSupplyItems(ctx context.Context, db execQuerier) (map[string]domain.Sup
 }
 
 func loadSupplyItemFacilities(ctx context.Context, db execQuerier, supplies map[string]domain.SupplyItem) error {
-	rows, err := db.QueryContext(ctx, selectSupplyFacilitiesSQL)
+	return loadSupplyItemFacilitiesWhere(ctx, db, supplies, selectSupplyFacilitiesSQL)
+}
+
+// loadSupplyItemFacilitiesWhere fills FacilityIDs from the join rows returned
+// by query, invoked with args.
+func loadSupplyItemFacilitiesWhere(ctx context.Context, db execQuerier, supplies map[string]domain.SupplyItem, query string, args ...any) error {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("select supply facilities: %w", err)
 	}
@@ -2379,6 +3878,36 @@ func loadSupplyItemFacilities(ctx context.Context, db execQuerier, supplies map[
 	return nil
 }
 
+// loadSupplyItemProjectsWhere fills ProjectIDs from the project join rows
+// returned by query, invoked with args, without loading the projects.
+func loadSupplyItemProjectsWhere(ctx context.Context, db execQuerier, supplies map[string]domain.SupplyItem, query string, args ...any) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("select supply projects: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var projectID, supplyID string
+		if err := rows.Scan(&projectID, &supplyID); err != nil {
+			return fmt.Errorf("scan supply projects: %w", err)
+		}
+		supply, ok := supplies[supplyID]
+		if !ok {
+			return fmt.Errorf("project supply row references missing supply_item %s", supplyID)
+		}
+		supply.ProjectIDs = append(supply.ProjectIDs, projectID)
+		supplies[supplyID] = supply
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate supply projects: %w", err)
+	}
+	for id, supply := range supplies {
+		sort.Strings(supply.ProjectIDs)
+		supplies[id] = supply
+	}
+	return nil
+}
+
 func loadProjectSupplyItems(ctx context.Context, db execQuerier, projects map[string]domain.Project, supplies map[string]domain.SupplyItem) error {
 	rows, err := db.QueryContext(ctx, selectProjectSupplySQL)
 	if err != nil {
@@ -2419,7 +3948,13 @@ func loadProjectSupplyItems(ctx context.Context, db execQuerier, projects map[st
 }
 
 func loadTreatments(ctx context.Context, db execQuerier) (map[string]domain.Treatment, error) {
-	rows, err := db.QueryContext(ctx, selectTreatmentSQL)
+	return loadTreatmentsWhere(ctx, db, selectTreatmentSQL)
+}
+
+// loadTreatmentsWhere loads treatment rows returned by query, which must
+// select the columns of selectTreatmentSQL.
+func loadTreatmentsWhere(ctx context.Context, db execQuerier, query string, args ...any) (map[string]domain.Treatment, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("select treatments: %w", err)
 	}
@@ -2428,22 +3963,26 @@ func loadTreatments(ctx context.Context, db execQuerier) (map[string]domain.Trea
 	out := make(map[string]domain.Treatment)
 	for rows.Next() {
 		var (
-			id, name, procedureID, dosagePlan string
-			status                            domain.TreatmentStatus
-			adminLogRaw, adverseRaw           []byte
-			createdAt, updatedAt              time.Time
+			id, name, procedureID                  string
+			status                                 domain.TreatmentStatus
+			dosagePlanRaw, adminLogRaw, adverseRaw []byte
+			createdAt, updatedAt                   time.Time
 		)
-		if err := rows.Scan(&id, &name, &status, &procedureID, &dosagePlan, &adminLogRaw, &adverseRaw, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &name, &status, &procedureID, &dosagePlanRaw, &adminLogRaw, &adverseRaw, &createdAt, &updatedAt); err != nil {
 			return nil, fmt.Errorf("scan treatments: %w", err)
 		}
 		adminLog, err := decodeStringSlice(adminLogRaw)
 		if err != nil {
 			return nil, fmt.Errorf("decode treatment %s administration_log: %w", id, err)
 		}
-		adverseEvents, err := decodeStringSlice(adverseRaw)
+		adverseEvents, err := domain.ParseAdverseEvents(adverseRaw)
 		if err != nil {
 			return nil, fmt.Errorf("decode treatment %s adverse_events: %w", id, err)
 		}
+		dosagePlan, err := domain.ParseDosagePlan(dosagePlanRaw)
+		if err != nil {
+			return nil, fmt.Errorf("decode treatment %s dosage_plan: %w", id, err)
+		}
 		out[id] = domain.Treatment{Treatment: entitymodel.Treatment{
 			ID:                id,
 			Name:              name,
@@ -2462,8 +4001,36 @@ func loadTreatments(ctx context.Context, db execQuerier) (map[string]domain.Trea
 	return out, nil
 }
 
+// loadSingleTreatment loads one treatment row and its cohort and organism join
+// rows inside a single read-only transaction.
+func loadSingleTreatment(ctx context.Context, db *sql.DB, id string) (map[string]domain.Treatment, error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	treatments, err := loadTreatmentsWhere(ctx, tx, selectTreatmentByIDSQL, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadTreatmentCohortsWhere(ctx, tx, treatments, selectTreatmentCohortsByIDSQL, id); err != nil {
+		return nil, err
+	}
+	if err := loadTreatmentOrganismsWhere(ctx, tx, treatments, selectTreatmentOrganismsByIDSQL, id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return treatments, nil
+}
+
 func loadTreatmentCohorts(ctx context.Context, db execQuerier, treatments map[string]domain.Treatment) error {
-	rows, err := db.QueryContext(ctx, selectTreatmentCohortsSQL)
+	return loadTreatmentCohortsWhere(ctx, db, treatments, selectTreatmentCohortsSQL)
+}
+
+func loadTreatmentCohortsWhere(ctx context.Context, db execQuerier, treatments map[string]domain.Treatment, query string, args ...any) error {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("select treatment cohorts: %w", err)
 	}
@@ -2491,7 +4058,11 @@ func loadTreatmentCohorts(ctx context.Context, db execQuerier, treatments map[st
 }
 
 func loadTreatmentOrganisms(ctx context.Context, db execQuerier, treatments map[string]domain.Treatment) error {
-	rows, err := db.QueryContext(ctx, selectTreatmentOrganismsSQL)
+	return loadTreatmentOrganismsWhere(ctx, db, treatments, selectTreatmentOrganismsSQL)
+}
+
+func loadTreatmentOrganismsWhere(ctx context.Context, db execQuerier, treatments map[string]domain.Treatment, query string, args ...any) error {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("select treatment organisms: %w", err)
 	}
@@ -2521,38 +4092,57 @@ func loadTreatmentOrganisms(ctx context.Context, db execQuerier, treatments map[
 // --- SQL constants ---
 
 const (
-	insertFacilitySQL           = `INSERT INTO facilities (id, code, name, zone, access_policy, created_at, updated_at, environment_baselines) VALUES ($1,$2,$3,$4,$5,$6,$7,$8) ON CONFLICT (id) DO UPDATE SET code=EXCLUDED.code, name=EXCLUDED.name, zone=EXCLUDED.zone, access_policy=EXCLUDED.access_policy, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at, environment_baselines=EXCLUDED.environment_baselines`
+	insertFacilitySQL           = `INSERT INTO facilities (id, code, name, zone, access_policy, timezone, accreditation_number, accreditation_expires_at, created_at, updated_at, environment_baselines, default_housing_environment) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) ON CONFLICT (id) DO UPDATE SET code=EXCLUDED.code, name=EXCLUDED.name, zone=EXCLUDED.zone, access_policy=EXCLUDED.access_policy, timezone=EXCLUDED.timezone, accreditation_number=EXCLUDED.accreditation_number, accreditation_expires_at=EXCLUDED.accreditation_expires_at, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at, environment_baselines=EXCLUDED.environment_baselines, default_housing_environment=EXCLUDED.default_housing_environment`
 	deleteFacilitySQL           = `DELETE FROM facilities WHERE id=$1`
 	deleteFacilitiesProjectsSQL = `DELETE FROM facilities__project_ids WHERE facility_id=$1`
-	selectFacilitiesSQL         = `SELECT id, code, name, zone, access_policy, created_at, updated_at, environment_baselines FROM facilities`
+	selectFacilitiesSQL         = `SELECT id, code, name, zone, access_policy, timezone, accreditation_number, accreditation_expires_at, created_at, updated_at, environment_baselines, default_housing_environment FROM facilities`
+
+	selectFacilityByIDSQL         = selectFacilitiesSQL + ` WHERE id = $1`
+	selectFacilityByCodeSQL       = selectFacilitiesSQL + ` WHERE code = $1`
+	selectFacilityProjectsByIDSQL = selectProjectFacilitiesSQL + ` WHERE facility_id = $1`
+	selectFacilityHousingIDsSQL   = `SELECT id FROM housing_units WHERE facility_id = $1`
 
-	insertGenotypeMarkerSQL  = `INSERT INTO genotype_markers (id, name, locus, alleles, assay_method, interpretation, version, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name, locus=EXCLUDED.locus, alleles=EXCLUDED.alleles, assay_method=EXCLUDED.assay_method, interpretation=EXCLUDED.interpretation, version=EXCLUDED.version, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
+	insertGenotypeMarkerSQL  = `INSERT INTO genotype_markers (id, name, locus, alleles, assay_method, interpretation, version, attributes, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name, locus=EXCLUDED.locus, alleles=EXCLUDED.alleles, assay_method=EXCLUDED.assay_method, interpretation=EXCLUDED.interpretation, version=EXCLUDED.version, attributes=EXCLUDED.attributes, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
 	deleteGenotypeMarkerSQL  = `DELETE FROM genotype_markers WHERE id=$1`
-	selectGenotypeMarkersSQL = `SELECT id, name, locus, alleles, assay_method, interpretation, version, created_at, updated_at FROM genotype_markers`
+	selectGenotypeMarkersSQL = `SELECT id, name, locus, alleles, assay_method, interpretation, version, attributes, created_at, updated_at FROM genotype_markers`
 
-	insertLineSQL        = `INSERT INTO lines (id, code, name, origin, description, default_attributes, extension_overrides, deprecated_at, deprecation_reason, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) ON CONFLICT (id) DO UPDATE SET code=EXCLUDED.code, name=EXCLUDED.name, origin=EXCLUDED.origin, description=EXCLUDED.description, default_attributes=EXCLUDED.default_attributes, extension_overrides=EXCLUDED.extension_overrides, deprecated_at=EXCLUDED.deprecated_at, deprecation_reason=EXCLUDED.deprecation_reason, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
+	insertLineSQL        = `INSERT INTO lines (id, code, name, origin, description, default_attributes, extension_overrides, tags, deprecated_at, deprecation_reason, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) ON CONFLICT (id) DO UPDATE SET code=EXCLUDED.code, name=EXCLUDED.name, origin=EXCLUDED.origin, description=EXCLUDED.description, default_attributes=EXCLUDED.default_attributes, extension_overrides=EXCLUDED.extension_overrides, tags=EXCLUDED.tags, deprecated_at=EXCLUDED.deprecated_at, deprecation_reason=EXCLUDED.deprecation_reason, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
 	deleteLineSQL        = `DELETE FROM lines WHERE id=$1`
 	insertLineMarkerSQL  = `INSERT INTO lines__genotype_marker_ids (line_id, genotype_marker_id) VALUES ($1,$2)`
 	deleteLineMarkersSQL = `DELETE FROM lines__genotype_marker_ids WHERE line_id=$1`
-	selectLinesSQL       = `SELECT id, code, name, origin, description, default_attributes, extension_overrides, deprecated_at, deprecation_reason, created_at, updated_at FROM lines`
+	selectLinesSQL       = `SELECT id, code, name, origin, description, default_attributes, extension_overrides, tags, deprecated_at, deprecation_reason, created_at, updated_at FROM lines`
 	selectLineMarkersSQL = `SELECT line_id, genotype_marker_id FROM lines__genotype_marker_ids`
+	countActiveLinesSQL  = `SELECT COUNT(*) FROM lines WHERE deprecated_at IS NULL`
 
-	insertStrainSQL        = `INSERT INTO strains (id, code, name, line_id, description, generation, retired_at, retirement_reason, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) ON CONFLICT (id) DO UPDATE SET code=EXCLUDED.code, name=EXCLUDED.name, line_id=EXCLUDED.line_id, description=EXCLUDED.description, generation=EXCLUDED.generation, retired_at=EXCLUDED.retired_at, retirement_reason=EXCLUDED.retirement_reason, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
+	selectLineByIDSQL        = selectLinesSQL + ` WHERE id = $1`
+	selectLineByCodeSQL      = selectLinesSQL + ` WHERE code = $1`
+	selectLineMarkersByIDSQL = selectLineMarkersSQL + ` WHERE line_id = $1`
+
+	insertStrainSQL        = `INSERT INTO strains (id, code, name, line_id, description, generation, retired_at, retirement_reason, attributes, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) ON CONFLICT (id) DO UPDATE SET code=EXCLUDED.code, name=EXCLUDED.name, line_id=EXCLUDED.line_id, description=EXCLUDED.description, generation=EXCLUDED.generation, retired_at=EXCLUDED.retired_at, retirement_reason=EXCLUDED.retirement_reason, attributes=EXCLUDED.attributes, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
 	deleteStrainSQL        = `DELETE FROM strains WHERE id=$1`
 	insertStrainMarkerSQL  = `INSERT INTO strains__genotype_marker_ids (strain_id, genotype_marker_id) VALUES ($1,$2)`
 	deleteStrainMarkersSQL = `DELETE FROM strains__genotype_marker_ids WHERE strain_id=$1`
-	selectStrainsSQL       = `SELECT id, code, name, line_id, description, generation, retired_at, retirement_reason, created_at, updated_at FROM strains`
+	selectStrainsSQL       = `SELECT id, code, name, line_id, description, generation, retired_at, retirement_reason, attributes, created_at, updated_at FROM strains`
 	selectStrainMarkersSQL = `SELECT strain_id, genotype_marker_id FROM strains__genotype_marker_ids`
+	countActiveStrainsSQL  = `SELECT COUNT(*) FROM strains WHERE line_id = $1 AND retired_at IS NULL`
+
+	selectStrainByIDSQL        = selectStrainsSQL + ` WHERE id = $1`
+	selectStrainByCodeSQL      = selectStrainsSQL + ` WHERE line_id = $1 AND code = $2`
+	selectStrainMarkersByIDSQL = selectStrainMarkersSQL + ` WHERE strain_id = $1`
 
-	insertHousingSQL = `INSERT INTO housing_units (id, facility_id, name, capacity, environment, state, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8) ON CONFLICT (id) DO UPDATE SET facility_id=EXCLUDED.facility_id, name=EXCLUDED.name, capacity=EXCLUDED.capacity, environment=EXCLUDED.environment, state=EXCLUDED.state, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
+	insertHousingSQL = `INSERT INTO housing_units (id, facility_id, name, capacity, environment, state, created_at, updated_at, version) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) ON CONFLICT (id) DO UPDATE SET facility_id=EXCLUDED.facility_id, name=EXCLUDED.name, capacity=EXCLUDED.capacity, environment=EXCLUDED.environment, state=EXCLUDED.state, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at, version=EXCLUDED.version`
 	deleteHousingSQL = `DELETE FROM housing_units WHERE id=$1`
-	selectHousingSQL = `SELECT id, facility_id, name, capacity, environment, state, created_at, updated_at FROM housing_units`
+	selectHousingSQL = `SELECT id, facility_id, name, capacity, environment, state, created_at, updated_at, version FROM housing_units`
 
-	insertProtocolSQL = `INSERT INTO protocols (id, code, title, description, max_subjects, status, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8) ON CONFLICT (id) DO UPDATE SET code=EXCLUDED.code, title=EXCLUDED.title, description=EXCLUDED.description, max_subjects=EXCLUDED.max_subjects, status=EXCLUDED.status, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
+	selectHousingByIDSQL = selectHousingSQL + ` WHERE id = $1`
+
+	insertProtocolSQL = `INSERT INTO protocols (id, code, title, description, max_subjects, reviewer_ids, status, created_at, updated_at, version) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) ON CONFLICT (id) DO UPDATE SET code=EXCLUDED.code, title=EXCLUDED.title, description=EXCLUDED.description, max_subjects=EXCLUDED.max_subjects, reviewer_ids=EXCLUDED.reviewer_ids, status=EXCLUDED.status, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at, version=EXCLUDED.version`
 	deleteProtocolSQL = `DELETE FROM protocols WHERE id=$1`
-	selectProtocolSQL = `SELECT id, code, title, description, max_subjects, status, created_at, updated_at FROM protocols`
+	selectProtocolSQL = `SELECT id, code, title, description, max_subjects, reviewer_ids, status, created_at, updated_at, version FROM protocols`
+
+	selectProtocolByCodeSQL = selectProtocolSQL + ` WHERE code = $1`
 
-	insertProjectSQL           = `INSERT INTO projects (id, code, title, description, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT (id) DO UPDATE SET code=EXCLUDED.code, title=EXCLUDED.title, description=EXCLUDED.description, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
+	insertProjectSQL           = `INSERT INTO projects (id, code, title, description, closed_at, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7) ON CONFLICT (id) DO UPDATE SET code=EXCLUDED.code, title=EXCLUDED.title, description=EXCLUDED.description, closed_at=EXCLUDED.closed_at, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
 	deleteProjectSQL           = `DELETE FROM projects WHERE id=$1`
 	insertProjectFacilitySQL   = `INSERT INTO facilities__project_ids (facility_id, project_id) VALUES ($1,$2)`
 	deleteProjectFacilitiesSQL = `DELETE FROM facilities__project_ids WHERE project_id=$1`
@@ -2560,24 +4150,28 @@ const (
 	deleteProjectProtocolsSQL  = `DELETE FROM projects__protocol_ids WHERE project_id=$1`
 	insertProjectSupplySQL     = `INSERT INTO projects__supply_item_ids (project_id, supply_item_id) VALUES ($1,$2)`
 	deleteProjectSuppliesSQL   = `DELETE FROM projects__supply_item_ids WHERE project_id=$1`
-	selectProjectSQL           = `SELECT id, code, title, description, created_at, updated_at FROM projects`
+	selectProjectSQL           = `SELECT id, code, title, description, closed_at, created_at, updated_at FROM projects`
 	selectProjectFacilitiesSQL = `SELECT facility_id, project_id FROM facilities__project_ids`
 	selectProjectProtocolsSQL  = `SELECT project_id, protocol_id FROM projects__protocol_ids`
 	selectProjectSupplySQL     = `SELECT project_id, supply_item_id FROM projects__supply_item_ids`
 
-	insertPermitSQL           = `INSERT INTO permits (id, permit_number, authority, status, valid_from, valid_until, allowed_activities, notes, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) ON CONFLICT (id) DO UPDATE SET permit_number=EXCLUDED.permit_number, authority=EXCLUDED.authority, status=EXCLUDED.status, valid_from=EXCLUDED.valid_from, valid_until=EXCLUDED.valid_until, allowed_activities=EXCLUDED.allowed_activities, notes=EXCLUDED.notes, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
+	insertPermitSQL           = `INSERT INTO permits (id, permit_number, authority, status, issue_date, valid_from, valid_until, allowed_activities, notes, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) ON CONFLICT (id) DO UPDATE SET permit_number=EXCLUDED.permit_number, authority=EXCLUDED.authority, status=EXCLUDED.status, issue_date=EXCLUDED.issue_date, valid_from=EXCLUDED.valid_from, valid_until=EXCLUDED.valid_until, allowed_activities=EXCLUDED.allowed_activities, notes=EXCLUDED.notes, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
 	deletePermitSQL           = `DELETE FROM permits WHERE id=$1`
 	insertPermitFacilitySQL   = `INSERT INTO permits__facility_ids (permit_id, facility_id) VALUES ($1,$2)`
 	deletePermitFacilitiesSQL = `DELETE FROM permits__facility_ids WHERE permit_id=$1`
 	insertPermitProtocolSQL   = `INSERT INTO permits__protocol_ids (permit_id, protocol_id) VALUES ($1,$2)`
 	deletePermitProtocolsSQL  = `DELETE FROM permits__protocol_ids WHERE permit_id=$1`
-	selectPermitSQL           = `SELECT id, permit_number, authority, status, valid_from, valid_until, allowed_activities, notes, created_at, updated_at FROM permits`
+	selectPermitSQL           = `SELECT id, permit_number, authority, status, issue_date, valid_from, valid_until, allowed_activities, notes, created_at, updated_at FROM permits`
 	selectPermitFacilitiesSQL = `SELECT permit_id, facility_id FROM permits__facility_ids`
 	selectPermitProtocolsSQL  = `SELECT permit_id, protocol_id FROM permits__protocol_ids`
 
-	insertCohortSQL   = `INSERT INTO cohorts (id, name, purpose, project_id, housing_id, protocol_id, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8) ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name, purpose=EXCLUDED.purpose, project_id=EXCLUDED.project_id, housing_id=EXCLUDED.housing_id, protocol_id=EXCLUDED.protocol_id, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
+	selectPermitByIDSQL           = selectPermitSQL + ` WHERE id = $1`
+	selectPermitFacilitiesByIDSQL = selectPermitFacilitiesSQL + ` WHERE permit_id = $1`
+	selectPermitProtocolsByIDSQL  = selectPermitProtocolsSQL + ` WHERE permit_id = $1`
+
+	insertCohortSQL   = `INSERT INTO cohorts (id, name, purpose, species, project_id, housing_id, protocol_id, created_from_breeding_unit_id, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name, purpose=EXCLUDED.purpose, species=EXCLUDED.species, project_id=EXCLUDED.project_id, housing_id=EXCLUDED.housing_id, protocol_id=EXCLUDED.protocol_id, created_from_breeding_unit_id=EXCLUDED.created_from_breeding_unit_id, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
 	deleteCohortSQL   = `DELETE FROM cohorts WHERE id=$1`
-	selectCohortSQL   = `SELECT id, name, purpose, project_id, housing_id, protocol_id, created_at, updated_at FROM cohorts`
+	selectCohortSQL   = `SELECT id, name, purpose, species, project_id, housing_id, protocol_id, created_from_breeding_unit_id, created_at, updated_at FROM cohorts`
 	selectBreedingSQL = `SELECT id, name, strategy, housing_id, line_id, strain_id, target_line_id, target_strain_id, protocol_id, pairing_attributes, pairing_intent, pairing_notes, created_at, updated_at FROM breeding_units`
 
 	insertBreedingSQL        = `INSERT INTO breeding_units (id, name, strategy, housing_id, line_id, strain_id, target_line_id, target_strain_id, protocol_id, pairing_attributes, pairing_intent, pairing_notes, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name, strategy=EXCLUDED.strategy, housing_id=EXCLUDED.housing_id, line_id=EXCLUDED.line_id, strain_id=EXCLUDED.strain_id, target_line_id=EXCLUDED.target_line_id, target_strain_id=EXCLUDED.target_strain_id, protocol_id=EXCLUDED.protocol_id, pairing_attributes=EXCLUDED.pairing_attributes, pairing_intent=EXCLUDED.pairing_intent, pairing_notes=EXCLUDED.pairing_notes, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
@@ -2589,35 +4183,55 @@ const (
 	selectBreedingFemalesSQL = `SELECT breeding_unit_id, organism_id FROM breeding_units__female_ids`
 	selectBreedingMalesSQL   = `SELECT breeding_unit_id, organism_id FROM breeding_units__male_ids`
 
-	insertOrganismSQL        = `INSERT INTO organisms (id, name, species, line, stage, line_id, strain_id, cohort_id, housing_id, protocol_id, project_id, attributes, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name, species=EXCLUDED.species, line=EXCLUDED.line, stage=EXCLUDED.stage, line_id=EXCLUDED.line_id, strain_id=EXCLUDED.strain_id, cohort_id=EXCLUDED.cohort_id, housing_id=EXCLUDED.housing_id, protocol_id=EXCLUDED.protocol_id, project_id=EXCLUDED.project_id, attributes=EXCLUDED.attributes, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
+	selectBreedingByIDSQL        = selectBreedingSQL + ` WHERE id = $1`
+	selectBreedingFemalesByIDSQL = selectBreedingFemalesSQL + ` WHERE breeding_unit_id = $1`
+	selectBreedingMalesByIDSQL   = selectBreedingMalesSQL + ` WHERE breeding_unit_id = $1`
+
+	insertOrganismSQL        = `INSERT INTO organisms (id, name, species, line, stage, line_id, strain_id, cohort_id, housing_id, protocol_id, project_id, attributes, created_at, updated_at, version) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15) ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name, species=EXCLUDED.species, line=EXCLUDED.line, stage=EXCLUDED.stage, line_id=EXCLUDED.line_id, strain_id=EXCLUDED.strain_id, cohort_id=EXCLUDED.cohort_id, housing_id=EXCLUDED.housing_id, protocol_id=EXCLUDED.protocol_id, project_id=EXCLUDED.project_id, attributes=EXCLUDED.attributes, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at, version=EXCLUDED.version`
 	deleteOrganismSQL        = `DELETE FROM organisms WHERE id=$1`
 	insertOrganismParentSQL  = `INSERT INTO organisms__parent_ids (organism_id, parent_ids_id) VALUES ($1,$2)`
 	deleteOrganismParentsSQL = `DELETE FROM organisms__parent_ids WHERE organism_id=$1`
-	selectOrganismSQL        = `SELECT id, name, species, line, stage, line_id, strain_id, cohort_id, housing_id, protocol_id, project_id, attributes, created_at, updated_at FROM organisms`
+	selectOrganismSQL        = `SELECT id, name, species, line, stage, line_id, strain_id, cohort_id, housing_id, protocol_id, project_id, attributes, created_at, updated_at, version FROM organisms`
 	selectOrganismParentsSQL = `SELECT organism_id, parent_ids_id FROM organisms__parent_ids`
 
-	insertProcedureSQL          = `INSERT INTO procedures (id, name, status, scheduled_at, protocol_id, project_id, cohort_id, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name, status=EXCLUDED.status, scheduled_at=EXCLUDED.scheduled_at, protocol_id=EXCLUDED.protocol_id, project_id=EXCLUDED.project_id, cohort_id=EXCLUDED.cohort_id, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
+	selectOrganismByIDSQL        = selectOrganismSQL + ` WHERE id = $1`
+	selectOrganismParentsByIDSQL = selectOrganismParentsSQL + ` WHERE organism_id = $1`
+
+	insertProcedureSQL          = `INSERT INTO procedures (id, name, status, cancellation_reason, scheduled_at, protocol_id, project_id, cohort_id, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name, status=EXCLUDED.status, cancellation_reason=EXCLUDED.cancellation_reason, scheduled_at=EXCLUDED.scheduled_at, protocol_id=EXCLUDED.protocol_id, project_id=EXCLUDED.project_id, cohort_id=EXCLUDED.cohort_id, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
 	deleteProcedureSQL          = `DELETE FROM procedures WHERE id=$1`
 	insertProcedureOrganismSQL  = `INSERT INTO procedures__organism_ids (procedure_id, organism_id) VALUES ($1,$2)`
 	deleteProcedureOrganismsSQL = `DELETE FROM procedures__organism_ids WHERE procedure_id=$1`
-	selectProcedureSQL          = `SELECT id, name, status, scheduled_at, protocol_id, project_id, cohort_id, created_at, updated_at FROM procedures`
+	selectProcedureSQL          = `SELECT id, name, status, cancellation_reason, scheduled_at, protocol_id, project_id, cohort_id, created_at, updated_at FROM procedures`
 	selectProcedureOrganismsSQL = `SELECT procedure_id, organism_id FROM procedures__organism_ids`
 
-	insertObservationSQL = `INSERT INTO observations (id, observer, recorded_at, procedure_id, organism_id, cohort_id, data, notes, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) ON CONFLICT (id) DO UPDATE SET observer=EXCLUDED.observer, recorded_at=EXCLUDED.recorded_at, procedure_id=EXCLUDED.procedure_id, organism_id=EXCLUDED.organism_id, cohort_id=EXCLUDED.cohort_id, data=EXCLUDED.data, notes=EXCLUDED.notes, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
+	insertObservationSQL = `INSERT INTO observations (id, observer, recorded_at, procedure_id, organism_id, cohort_id, data, notes, recorded_by, reviewed_by, reviewed_at, created_at, updated_at, category, attachments, weight, length, temperature) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18) ON CONFLICT (id) DO UPDATE SET observer=EXCLUDED.observer, recorded_at=EXCLUDED.recorded_at, procedure_id=EXCLUDED.procedure_id, organism_id=EXCLUDED.organism_id, cohort_id=EXCLUDED.cohort_id, data=EXCLUDED.data, notes=EXCLUDED.notes, recorded_by=EXCLUDED.recorded_by, reviewed_by=EXCLUDED.reviewed_by, reviewed_at=EXCLUDED.reviewed_at, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at, category=EXCLUDED.category, attachments=EXCLUDED.attachments, weight=EXCLUDED.weight, length=EXCLUDED.length, temperature=EXCLUDED.temperature`
 	deleteObservationSQL = `DELETE FROM observations WHERE id=$1`
-	selectObservationSQL = `SELECT id, observer, recorded_at, procedure_id, organism_id, cohort_id, data, notes, created_at, updated_at FROM observations`
+	selectObservationSQL = `SELECT id, observer, recorded_at, procedure_id, organism_id, cohort_id, data, notes, recorded_by, reviewed_by, reviewed_at, created_at, updated_at, category, attachments, weight, length, temperature FROM observations`
+
+	selectObservationByIDSQL        = selectObservationSQL + ` WHERE id = $1`
+	selectObservationsByOrganismSQL = selectObservationSQL + ` WHERE organism_id = $1`
+	selectObservationsByCohortSQL   = selectObservationSQL + ` WHERE cohort_id = $1`
+	selectObservationsBetweenSQL    = selectObservationSQL + ` WHERE recorded_at >= $1 AND recorded_at < $2`
 
-	insertSampleSQL = `INSERT INTO samples (id, identifier, source_type, status, storage_location, assay_type, facility_id, organism_id, cohort_id, chain_of_custody, attributes, collected_at, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) ON CONFLICT (id) DO UPDATE SET identifier=EXCLUDED.identifier, source_type=EXCLUDED.source_type, status=EXCLUDED.status, storage_location=EXCLUDED.storage_location, assay_type=EXCLUDED.assay_type, facility_id=EXCLUDED.facility_id, organism_id=EXCLUDED.organism_id, cohort_id=EXCLUDED.cohort_id, chain_of_custody=EXCLUDED.chain_of_custody, attributes=EXCLUDED.attributes, collected_at=EXCLUDED.collected_at, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
+	insertSampleSQL = `INSERT INTO samples (id, identifier, source_type, status, storage_location, assay_type, facility_id, organism_id, cohort_id, chain_of_custody, attributes, collected_at, collected_by, collection_protocol, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16) ON CONFLICT (id) DO UPDATE SET identifier=EXCLUDED.identifier, source_type=EXCLUDED.source_type, status=EXCLUDED.status, storage_location=EXCLUDED.storage_location, assay_type=EXCLUDED.assay_type, facility_id=EXCLUDED.facility_id, organism_id=EXCLUDED.organism_id, cohort_id=EXCLUDED.cohort_id, chain_of_custody=EXCLUDED.chain_of_custody, attributes=EXCLUDED.attributes, collected_at=EXCLUDED.collected_at, collected_by=EXCLUDED.collected_by, collection_protocol=EXCLUDED.collection_protocol, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
 	deleteSampleSQL = `DELETE FROM samples WHERE id=$1`
-	selectSampleSQL = `SELECT id, identifier, source_type, status, storage_location, assay_type, facility_id, organism_id, cohort_id, chain_of_custody, attributes, collected_at, created_at, updated_at FROM samples`
+	selectSampleSQL = `SELECT id, identifier, source_type, status, storage_location, assay_type, facility_id, organism_id, cohort_id, chain_of_custody, attributes, collected_at, collected_by, collection_protocol, created_at, updated_at FROM samples`
 
-	insertSupplySQL                  = `INSERT INTO supply_items (id, sku, name, quantity_on_hand, unit, reorder_level, description, lot_number, expires_at, attributes, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) ON CONFLICT (id) DO UPDATE SET sku=EXCLUDED.sku, name=EXCLUDED.name, quantity_on_hand=EXCLUDED.quantity_on_hand, unit=EXCLUDED.unit, reorder_level=EXCLUDED.reorder_level, description=EXCLUDED.description, lot_number=EXCLUDED.lot_number, expires_at=EXCLUDED.expires_at, attributes=EXCLUDED.attributes, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
+	selectSampleByIDSQL        = selectSampleSQL + ` WHERE id = $1`
+	selectSamplesByFacilitySQL = selectSampleSQL + ` WHERE facility_id = $1`
+	selectSamplesByOrganismSQL = selectSampleSQL + ` WHERE organism_id = $1`
+
+	insertSupplySQL                  = `INSERT INTO supply_items (id, sku, name, quantity_on_hand, unit, category, reorder_level, status, description, lot_number, expires_at, attributes, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) ON CONFLICT (id) DO UPDATE SET sku=EXCLUDED.sku, name=EXCLUDED.name, quantity_on_hand=EXCLUDED.quantity_on_hand, unit=EXCLUDED.unit, category=EXCLUDED.category, reorder_level=EXCLUDED.reorder_level, status=EXCLUDED.status, description=EXCLUDED.description, lot_number=EXCLUDED.lot_number, expires_at=EXCLUDED.expires_at, attributes=EXCLUDED.attributes, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
 	deleteSupplySQL                  = `DELETE FROM supply_items WHERE id=$1`
 	insertSupplyFacilitySQL          = `INSERT INTO supply_items__facility_ids (supply_item_id, facility_id) VALUES ($1,$2)`
 	deleteSupplyFacilitiesSQL        = `DELETE FROM supply_items__facility_ids WHERE supply_item_id=$1`
 	selectSupplyFacilitiesSQL        = `SELECT supply_item_id, facility_id FROM supply_items__facility_ids`
 	deleteProjectSuppliesBySupplySQL = `DELETE FROM projects__supply_item_ids WHERE supply_item_id=$1`
-	selectSupplySQL                  = `SELECT id, sku, name, quantity_on_hand, unit, reorder_level, description, lot_number, expires_at, attributes, created_at, updated_at FROM supply_items`
+	selectSupplySQL                  = `SELECT id, sku, name, quantity_on_hand, unit, category, reorder_level, status, description, lot_number, expires_at, attributes, created_at, updated_at FROM supply_items`
+
+	selectSupplyByIDSQL                = selectSupplySQL + ` WHERE id = $1`
+	selectSupplyFacilitiesByIDSQL      = selectSupplyFacilitiesSQL + ` WHERE supply_item_id = $1`
+	selectProjectSuppliesBySupplyIDSQL = selectProjectSupplySQL + ` WHERE supply_item_id = $1`
 
 	insertTreatmentSQL          = `INSERT INTO treatments (id, name, status, procedure_id, dosage_plan, administration_log, adverse_events, created_at, updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name, status=EXCLUDED.status, procedure_id=EXCLUDED.procedure_id, dosage_plan=EXCLUDED.dosage_plan, administration_log=EXCLUDED.administration_log, adverse_events=EXCLUDED.adverse_events, created_at=EXCLUDED.created_at, updated_at=EXCLUDED.updated_at`
 	deleteTreatmentSQL          = `DELETE FROM treatments WHERE id=$1`
@@ -2628,6 +4242,13 @@ const (
 	selectTreatmentSQL          = `SELECT id, name, status, procedure_id, dosage_plan, administration_log, adverse_events, created_at, updated_at FROM treatments`
 	selectTreatmentCohortsSQL   = `SELECT treatment_id, cohort_id FROM treatments__cohort_ids`
 	selectTreatmentOrganismsSQL = `SELECT treatment_id, organism_id FROM treatments__organism_ids`
+
+	selectTreatmentByIDSQL               = selectTreatmentSQL + ` WHERE id = $1`
+	selectTreatmentCohortsByIDSQL        = selectTreatmentCohortsSQL + ` WHERE treatment_id = $1`
+	selectTreatmentOrganismsByIDSQL      = selectTreatmentOrganismsSQL + ` WHERE treatment_id = $1`
+	selectTreatmentsByProcedureSQL       = selectTreatmentSQL + ` WHERE procedure_id = $1`
+	selectTreatmentCohortsByProcedureSQL = selectTreatmentCohortsSQL + ` WHERE treatment_id IN (SELECT id FROM treatments WHERE procedure_id = $1)`
+	selectTreatmentOrgsByProcedureSQL    = selectTreatmentOrganismsSQL + ` WHERE treatment_id IN (SELECT id FROM treatments WHERE procedure_id = $1)`
 )
 
 // --- helpers ---
@@ -2706,6 +4327,29 @@ func nullableString(val sql.NullString) *string {
 	return nil
 }
 
+func nullablePairingIntent(val sql.NullString) *domain.PairingIntent {
+	if val.Valid {
+		intent := domain.PairingIntent(val.String)
+		return &intent
+	}
+	return nil
+}
+
+func nullableHousingEnvironment(val sql.NullString) *domain.HousingEnvironment {
+	if val.Valid {
+		env := domain.HousingEnvironment(val.String)
+		return &env
+	}
+	return nil
+}
+
+func nullableFloat(val sql.NullFloat64) *float64 {
+	if val.Valid {
+		return &val.Float64
+	}
+	return nil
+}
+
 func nullableTime(val sql.NullTime) *time.Time {
 	if val.Valid {
 		return &val.Time
@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRecordedAtIndexServesRangeQueries runs against a real Postgres when
+// COLONYCORE_POSTGRES_DSN is set and is skipped otherwise.
+func TestRecordedAtIndexServesRangeQueries(t *testing.T) {
+	dsn := os.Getenv("COLONYCORE_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("COLONYCORE_POSTGRES_DSN not set")
+	}
+	store, err := NewStore(dsn, domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.DB().Close() })
+	ctx := context.Background()
+
+	var indexCount int
+	if err := store.DB().QueryRowContext(ctx, `SELECT COUNT(*) FROM pg_indexes WHERE tablename = 'observations' AND indexname = 'idx_observations_recorded_at'`).Scan(&indexCount); err != nil {
+		t.Fatalf("query pg_indexes: %v", err)
+	}
+	if indexCount != 1 {
+		t.Fatalf("expected idx_observations_recorded_at to exist, found %d", indexCount)
+	}
+
+	conn, err := store.DB().Conn(ctx)
+	if err != nil {
+		t.Fatalf("acquire conn: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+	// Small test tables favour sequential scans; disable them so the plan
+	// shows whether the index is usable at all.
+	if _, err := conn.ExecContext(ctx, `SET enable_seqscan = off`); err != nil {
+		t.Fatalf("disable seqscan: %v", err)
+	}
+	to := time.Now().UTC()
+	rows, err := conn.QueryContext(ctx, "EXPLAIN "+selectObservationsBetweenSQL, to.Add(-24*time.Hour), to)
+	if err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var plan strings.Builder
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			t.Fatalf("scan plan: %v", err)
+		}
+		plan.WriteString(line)
+		plan.WriteString("\n")
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("iterate plan: %v", err)
+	}
+	if !strings.Contains(plan.String(), "idx_observations_recorded_at") {
+		t.Fatalf("expected range query to use idx_observations_recorded_at, got plan:\n%s", plan.String())
+	}
+}
+
+// TestApplyChangeLogAgainstPostgres runs against a real Postgres when
+// COLONYCORE_POSTGRES_DSN is set and is skipped otherwise.
+func TestApplyChangeLogAgainstPostgres(t *testing.T) {
+	dsn := os.Getenv("COLONYCORE_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("COLONYCORE_POSTGRES_DSN not set")
+	}
+	store, err := NewStore(dsn, domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.DB().Close() })
+	ctx := context.Background()
+	fixture := loadFixtureSnapshot(t)
+	if err := store.Import(ctx, fixture); err != nil {
+		t.Fatalf("import fixture: %v", err)
+	}
+
+	var lineID, treatmentID string
+	for id := range fixture.Lines {
+		lineID = id
+	}
+	for id := range fixture.Treatments {
+		treatmentID = id
+	}
+	changes, want := changeLogFromMemory(t, fixture, func(tx domain.Transaction) error {
+		created, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "FAC-LOG", Name: "Log Facility"}})
+		if err != nil {
+			return err
+		}
+		if _, err := tx.UpdateFacility(created.ID, func(f *domain.Facility) error {
+			f.Name = "Log Facility Renamed"
+			return nil
+		}); err != nil {
+			return err
+		}
+		if _, err := tx.UpdateLine(lineID, func(l *domain.Line) error {
+			l.Name = "Line From Log"
+			return nil
+		}); err != nil {
+			return err
+		}
+		return tx.DeleteTreatment(treatmentID)
+	})
+	if err := store.ApplyChangeLog(ctx, changes); err != nil {
+		t.Fatalf("ApplyChangeLog: %v", err)
+	}
+
+	got, err := loadNormalizedSnapshot(ctx, store.DB())
+	if err != nil {
+		t.Fatalf("load snapshot: %v", err)
+	}
+	for id, facility := range want.Facilities {
+		if got.Facilities[id].Name != facility.Name {
+			t.Fatalf("facility %s: expected name %q, got %q", id, facility.Name, got.Facilities[id].Name)
+		}
+	}
+	if len(got.Facilities) != len(want.Facilities) || len(got.Treatments) != len(want.Treatments) {
+		t.Fatalf("expected database to match the memory store after the change log")
+	}
+	if got.Lines[lineID].Name != "Line From Log" {
+		t.Fatalf("expected updated line, got %q", got.Lines[lineID].Name)
+	}
+}
+
+// recordingQuerier records the queries run through it.
+type recordingQuerier struct {
+	execQuerier
+	queries []string
+}
+
+func (r *recordingQuerier) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	r.queries = append(r.queries, query)
+	return r.execQuerier.QueryContext(ctx, query, args...)
+}
+
+// TestLoadLightSnapshotAgainstPostgres runs against a real Postgres when
+// COLONYCORE_POSTGRES_DSN is set and is skipped otherwise.
+func TestLoadLightSnapshotAgainstPostgres(t *testing.T) {
+	dsn := os.Getenv("COLONYCORE_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("COLONYCORE_POSTGRES_DSN not set")
+	}
+	store, err := NewStore(dsn, domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.DB().Close() })
+	ctx := context.Background()
+	fixture := loadFixtureSnapshot(t)
+	if err := store.Import(ctx, fixture); err != nil {
+		t.Fatalf("import fixture: %v", err)
+	}
+
+	light, err := store.LoadLightSnapshot(ctx)
+	if err != nil {
+		t.Fatalf("LoadLightSnapshot: %v", err)
+	}
+	if len(light.Organisms) != len(fixture.Organisms) || len(light.Facilities) != len(fixture.Facilities) {
+		t.Fatalf("expected light buckets to match fixture counts, got %+v", light)
+	}
+	for _, record := range light.Organisms {
+		if organism, ok := fixture.Organisms[record.ID]; !ok || record.Name != organism.Name {
+			t.Fatalf("unexpected organism record %+v", record)
+		}
+	}
+	for _, record := range light.Facilities {
+		if facility, ok := fixture.Facilities[record.ID]; !ok || record.Name != facility.Name || record.Code != facility.Code {
+			t.Fatalf("unexpected facility record %+v", record)
+		}
+	}
+
+	tx, err := store.DB().BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		t.Fatalf("begin tx: %v", err)
+	}
+	recorder := &recordingQuerier{execQuerier: tx}
+	if _, err := loadLightBuckets(ctx, recorder); err != nil {
+		t.Fatalf("load light buckets: %v", err)
+	}
+	_ = tx.Rollback()
+	for _, query := range recorder.queries {
+		if strings.Contains(query, "__") {
+			t.Fatalf("light load queried a join table: %s", query)
+		}
+	}
+
+	// Each write adds a facility and a housing unit together, so a snapshot
+	// read in one transaction always sees the same number of each.
+	drift := len(light.Housing) - len(light.Facilities)
+	done := make(chan error, 1)
+	go func() {
+		for i := 0; i < 25; i++ {
+			if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+				facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: fmt.Sprintf("FAC-LIGHT-%d", i), Name: "Light Facility"}})
+				if err != nil {
+					return err
+				}
+				_, err = tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Light Tank", FacilityID: facility.ID, Capacity: 1}})
+				return err
+			}); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("write facility and housing: %v", err)
+			}
+			return
+		default:
+		}
+		light, err := store.LoadLightSnapshot(ctx)
+		if err != nil {
+			t.Fatalf("LoadLightSnapshot during writes: %v", err)
+		}
+		if got := len(light.Housing) - len(light.Facilities); got != drift {
+			t.Fatalf("expected housing and facility counts to move together, drift %d became %d", drift, got)
+		}
+	}
+}
+
+// TestApplyDDLTwiceAgainstPostgres runs against a real Postgres when
+// COLONYCORE_POSTGRES_DSN is set and is skipped otherwise.
+func TestApplyDDLTwiceAgainstPostgres(t *testing.T) {
+	dsn := os.Getenv("COLONYCORE_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("COLONYCORE_POSTGRES_DSN not set")
+	}
+	store, err := NewStore(dsn, domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.DB().Close() })
+	ctx := context.Background()
+
+	for i := range 2 {
+		if err := applyEntityModelDDL(ctx, store.DB()); err != nil {
+			t.Fatalf("apply ddl pass %d: %v", i+1, err)
+		}
+	}
+	reopened, err := NewStore(dsn, domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("reopen initialized database: %v", err)
+	}
+	_ = reopened.DB().Close()
+
+	if _, err := store.DB().ExecContext(ctx, `UPDATE colonycore_schema_version SET version = '0.2.0'`); err != nil {
+		t.Fatalf("stamp older version: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := stampSchemaVersion(context.Background(), store.DB()); err != nil {
+			t.Errorf("restore schema version: %v", err)
+		}
+	})
+	if _, err := NewStore(dsn, domain.NewRulesEngine()); !errors.Is(err, ErrSchemaMigrationRequired) {
+		t.Fatalf("expected ErrSchemaMigrationRequired for tables from schema 0.2.0, got %v", err)
+	}
+}
@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"colonycore/internal/infra/persistence/memory"
+	pgtu "colonycore/internal/infra/persistence/postgres/testutil"
+	"colonycore/internal/infra/persistence/sqlite"
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// StoreOperation is one step of the store parity script. Apply records the IDs
+// of the entities it creates under logical names so later steps and snapshot
+// comparison can refer to them independently of the generated IDs.
+type StoreOperation struct {
+	Name  string
+	Apply func(tx domain.Transaction, ids map[string]string) error
+}
+
+// parityStore is the slice of the persistent store contract exercised by the
+// parity test, plus a reopen hook that reloads state from durable storage.
+type parityStore struct {
+	name   string
+	store  domain.PersistentStore
+	export func() any
+	reopen func(t *testing.T) (domain.PersistentStore, func() any)
+	ids    map[string]string
+}
+
+var parityBase = time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+func strPtr(v string) *string { return &v }
+
+func parityOperations() []StoreOperation {
+	return []StoreOperation{
+		{Name: "create facility", Apply: func(tx domain.Transaction, ids map[string]string) error {
+			facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "FAC", Name: "Facility", Zone: "A", AccessPolicy: "badge"}})
+			ids["facility"] = facility.ID
+			return err
+		}},
+		{Name: "create housing and protocol", Apply: func(tx domain.Transaction, ids map[string]string) error {
+			housing, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: ids["facility"], Capacity: 4, Environment: domain.HousingEnvironmentAquatic}})
+			if err != nil {
+				return err
+			}
+			ids["housing"] = housing.ID
+			protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PROT", Title: "Protocol", MaxSubjects: 10}})
+			ids["protocol"] = protocol.ID
+			return err
+		}},
+		{Name: "create project and cohort", Apply: func(tx domain.Transaction, ids map[string]string) error {
+			project, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{Code: "PRJ", Title: "Project", FacilityIDs: []string{ids["facility"]}}})
+			if err != nil {
+				return err
+			}
+			ids["project"] = project.ID
+			cohort, err := tx.CreateCohort(domain.Cohort{Cohort: entitymodel.Cohort{Name: "Cohort", Purpose: "Study", Species: strPtr("Xenopus laevis"), ProjectID: strPtr(project.ID), HousingID: strPtr(ids["housing"])}})
+			ids["cohort"] = cohort.ID
+			return err
+		}},
+		{Name: "create organisms", Apply: func(tx domain.Transaction, ids map[string]string) error {
+			for _, name := range []string{"frog-a", "frog-b"} {
+				organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: name, Species: "Xenopus laevis", HousingID: strPtr(ids["housing"])}})
+				if err != nil {
+					return err
+				}
+				ids[name] = organism.ID
+			}
+			return nil
+		}},
+		{Name: "update organism", Apply: func(tx domain.Transaction, ids map[string]string) error {
+			_, err := tx.UpdateOrganism(ids["frog-a"], func(o *domain.Organism) error {
+				o.Stage = domain.StageAdult
+				return o.SetCoreAttributes(map[string]any{"tag": "A-1"})
+			})
+			return err
+		}},
+		{Name: "enroll organism in cohort", Apply: func(tx domain.Transaction, ids map[string]string) error {
+			_, err := tx.AddOrganismToCohort(ids["frog-a"], ids["cohort"])
+			return err
+		}},
+		{Name: "create permit", Apply: func(tx domain.Transaction, ids map[string]string) error {
+			permit, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{
+				PermitNumber:      "PERMIT-1",
+				Authority:         "Gov",
+				IssueDate:         parityBase.AddDate(0, 0, -7),
+				ValidFrom:         parityBase,
+				ValidUntil:        parityBase.AddDate(1, 0, 0),
+				AllowedActivities: []string{"hold"},
+				FacilityIDs:       []string{ids["facility"]},
+				ProtocolIDs:       []string{ids["protocol"]},
+			}})
+			ids["permit"] = permit.ID
+			return err
+		}},
+		{Name: "delete organism", Apply: func(tx domain.Transaction, ids map[string]string) error {
+			return tx.DeleteOrganism(ids["frog-b"])
+		}},
+	}
+}
+
+func newSQLiteParityStore(t *testing.T) parityStore {
+	t.Helper()
+	dir := t.TempDir()
+	view, err := sqlite.NewStore(filepath.Join(dir, "view.db"), nil)
+	if err != nil {
+		t.Fatalf("open sqlite view: %v", err)
+	}
+	t.Cleanup(func() { _ = view.DB().Close() })
+	open := func(t *testing.T) (domain.PersistentStore, func() any) {
+		t.Helper()
+		store, err := sqlite.NewStore(filepath.Join(dir, "parity.db"), domain.NewRulesEngine())
+		if err != nil {
+			t.Fatalf("open sqlite store: %v", err)
+		}
+		t.Cleanup(func() { _ = store.DB().Close() })
+		// Derived relationship fields are only filled in by migrateSnapshot, so
+		// compare the migrated view each transaction starts from.
+		return store, func() any {
+			if err := view.ImportState(store.ExportState()); err != nil {
+				t.Fatalf("import state: %v", err)
+			}
+			return view.ExportState()
+		}
+	}
+	store, export := open(t)
+	return parityStore{name: "sqlite", store: store, export: export, reopen: open, ids: map[string]string{}}
+}
+
+func newPostgresParityStore(t *testing.T) parityStore {
+	t.Helper()
+	db, _ := pgtu.NewStubDB()
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	t.Cleanup(restore)
+	open := func(t *testing.T) (domain.PersistentStore, func() any) {
+		t.Helper()
+		store, err := NewStore("parity", domain.NewRulesEngine())
+		if err != nil {
+			t.Fatalf("open postgres store: %v", err)
+		}
+		// Transactions run against the normalized load after memory's
+		// migrateSnapshot; compare that view, which is what rules observe.
+		return store, func() any {
+			view := memory.NewStore(nil)
+			if err := view.ImportState(store.ExportState()); err != nil {
+				t.Fatalf("import state: %v", err)
+			}
+			return view.ExportState()
+		}
+	}
+	store, export := open(t)
+	return parityStore{name: "postgres", store: store, export: export, reopen: open, ids: map[string]string{}}
+}
+
+// canonicalSnapshot renders a store snapshot with generated IDs replaced by
+// their logical names and store-assigned timestamps removed, so snapshots
+// from different backends can be compared structurally.
+func canonicalSnapshot(t *testing.T, snapshot any, ids map[string]string) any {
+	t.Helper()
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("marshal snapshot: %v", err)
+	}
+	text := string(raw)
+	names := make([]string, 0, len(ids))
+	for name := range ids {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if ids[name] != "" {
+			text = strings.ReplaceAll(text, ids[name], "<"+name+">")
+		}
+	}
+	var decoded any
+	if err := json.Unmarshal([]byte(text), &decoded); err != nil {
+		t.Fatalf("unmarshal snapshot: %v", err)
+	}
+	return stripStoreTimestamps(decoded)
+}
+
+func stripStoreTimestamps(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		delete(v, "created_at")
+		delete(v, "updated_at")
+		for key, child := range v {
+			v[key] = stripStoreTimestamps(child)
+		}
+		return v
+	case []any:
+		for i, child := range v {
+			v[i] = stripStoreTimestamps(child)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func assertParity(t *testing.T, step string, stores []parityStore) {
+	t.Helper()
+	want := canonicalSnapshot(t, stores[0].export(), stores[0].ids)
+	for _, other := range stores[1:] {
+		got := canonicalSnapshot(t, other.export(), other.ids)
+		if !reflect.DeepEqual(want, got) {
+			wantJSON, _ := json.MarshalIndent(want, "", "  ")
+			gotJSON, _ := json.MarshalIndent(got, "", "  ")
+			t.Fatalf("%s: %s and %s snapshots diverge\n%s:\n%s\n%s:\n%s", step, stores[0].name, other.name, stores[0].name, wantJSON, other.name, gotJSON)
+		}
+	}
+}
+
+func TestStoreParity(t *testing.T) {
+	ctx := context.Background()
+	stores := []parityStore{newSQLiteParityStore(t), newPostgresParityStore(t)}
+
+	for _, op := range parityOperations() {
+		for i := range stores {
+			s := &stores[i]
+			if _, err := s.store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+				return op.Apply(tx, s.ids)
+			}); err != nil {
+				t.Fatalf("%s on %s: %v", op.Name, s.name, err)
+			}
+		}
+		assertParity(t, op.Name, stores)
+
+		// Reload from durable storage: sqlite replays migrateSnapshot over the
+		// persisted buckets while postgres rebuilds from normalized tables.
+		for i := range stores {
+			stores[i].store, stores[i].export = stores[i].reopen(t)
+		}
+		assertParity(t, op.Name+" after reload", stores)
+	}
+}
+
+func TestStoreParityAfterFixtureReload(t *testing.T) {
+	ctx := context.Background()
+	fixture := loadFixtureSnapshot(t)
+	stores := []parityStore{newSQLiteParityStore(t), newPostgresParityStore(t)}
+
+	raw, err := json.Marshal(fixture)
+	if err != nil {
+		t.Fatalf("marshal fixture: %v", err)
+	}
+	var sqliteFixture sqlite.Snapshot
+	if err := json.Unmarshal(raw, &sqliteFixture); err != nil {
+		t.Fatalf("decode sqlite fixture: %v", err)
+	}
+	sqliteStore := stores[0].store.(*sqlite.Store)
+	if err := sqliteStore.ImportState(sqliteFixture); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
+	// sqlite persists its buckets after each committed transaction.
+	if _, err := sqliteStore.RunInTransaction(ctx, func(domain.Transaction) error { return nil }); err != nil {
+		t.Fatalf("persist sqlite fixture: %v", err)
+	}
+	stores[1].store.(*Store).ImportState(fixture)
+
+	for i := range stores {
+		stores[i].store, stores[i].export = stores[i].reopen(t)
+	}
+	assertParity(t, "fixture reload", stores)
+}
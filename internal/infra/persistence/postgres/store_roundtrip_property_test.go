@@ -0,0 +1,449 @@
+package postgres
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"testing"
+	"time"
+
+	"colonycore/internal/infra/persistence/memory"
+	pgtu "colonycore/internal/infra/persistence/postgres/testutil"
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+// roundTripSeeds drive TestPostgresRoundTripMatchesMemory. Each seed yields a
+// different random snapshot; the seeds are fixed so a failure reproduces.
+var roundTripSeeds = []uint64{1, 2, 7, 42, 99, 1337, 2024, 65535}
+
+// snapshotGen builds random but valid snapshots by driving a memory store, so
+// every generated state passes the same validation as real writes.
+type snapshotGen struct {
+	rng *rand.Rand
+	tx  domain.Transaction
+	seq int
+}
+
+func (g *snapshotGen) name(prefix string) string {
+	g.seq++
+	return fmt.Sprintf("%s-%d-%03d", prefix, g.seq, g.rng.IntN(1000))
+}
+
+func (g *snapshotGen) count(lo, hi int) int { return lo + g.rng.IntN(hi-lo+1) }
+
+func (g *snapshotGen) chance() bool { return g.rng.IntN(2) == 0 }
+
+func (g *snapshotGen) at() time.Time {
+	return parityBase.Add(time.Duration(g.rng.IntN(90*24*60)) * time.Minute)
+}
+
+func (g *snapshotGen) pick(ids []string) string { return ids[g.rng.IntN(len(ids))] }
+
+func (g *snapshotGen) optional(ids []string) *string {
+	if len(ids) == 0 || g.chance() {
+		return nil
+	}
+	return strPtr(g.pick(ids))
+}
+
+// subset returns between lo and len(ids) distinct IDs in random order.
+func (g *snapshotGen) subset(ids []string, lo int) []string {
+	n := g.count(min(lo, len(ids)), len(ids))
+	out := make([]string, 0, n)
+	for _, i := range g.rng.Perm(len(ids))[:n] {
+		out = append(out, ids[i])
+	}
+	return out
+}
+
+func (g *snapshotGen) attributes() map[string]any {
+	attrs := map[string]any{}
+	for i := g.count(0, 3); i > 0; i-- {
+		switch g.rng.IntN(3) {
+		case 0:
+			attrs[g.name("text")] = g.name("value")
+		case 1:
+			attrs[g.name("count")] = float64(g.rng.IntN(100))
+		default:
+			attrs[g.name("flag")] = g.chance()
+		}
+	}
+	return attrs
+}
+
+// pluginAttributes returns random attributes keyed by the plugin owning them,
+// the shape of plugin-scoped extension slots such as strain attributes.
+func (g *snapshotGen) pluginAttributes() map[string]any {
+	return map[string]any{"core": g.attributes(), g.name("plugin"): g.attributes()}
+}
+
+// randomSnapshot generates a snapshot holding every entity type, with the
+// optional fields and relationships of each record chosen by seed.
+func randomSnapshot(t *testing.T, seed uint64) memory.Snapshot {
+	t.Helper()
+	store := memory.NewStore(nil)
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		g := &snapshotGen{rng: rand.New(rand.NewPCG(seed, seed^0x5eed)), tx: tx}
+		return g.populate()
+	}); err != nil {
+		t.Fatalf("seed %d: generate snapshot: %v", seed, err)
+	}
+	return store.ExportState()
+}
+
+func (g *snapshotGen) populate() error {
+	tx := g.tx
+	environments := []domain.HousingEnvironment{domain.HousingEnvironmentAquatic, domain.HousingEnvironmentTerrestrial, domain.HousingEnvironmentArboreal, domain.HousingEnvironmentHumid}
+	stages := []domain.LifecycleStage{domain.StagePlanned, domain.StageLarva, domain.StageJuvenile, domain.StageAdult, domain.StageRetired}
+
+	var markers, lines, strains, facilities, protocols, projects, housing, cohorts, organisms, procedures []string
+	for i := g.count(1, 3); i > 0; i-- {
+		marker := domain.GenotypeMarker{GenotypeMarker: entitymodel.GenotypeMarker{Name: g.name("marker"), Locus: g.name("locus"),
+			Alleles: []string{"A", g.name("allele")}, AssayMethod: "PCR", Interpretation: g.name("interp"), Version: "v1"}}
+		if g.chance() {
+			if err := marker.ApplyGenotypeMarkerAttributes(g.pluginAttributes()); err != nil {
+				return err
+			}
+		}
+		marker, err := tx.CreateGenotypeMarker(marker)
+		if err != nil {
+			return err
+		}
+		markers = append(markers, marker.ID)
+	}
+	for i := g.count(1, 2); i > 0; i-- {
+		line, err := tx.CreateLine(domain.Line{Line: entitymodel.Line{Code: g.name("L"), Name: g.name("line"), Origin: g.name("origin"),
+			GenotypeMarkerIDs: g.subset(markers, 1)}})
+		if err != nil {
+			return err
+		}
+		lines = append(lines, line.ID)
+		strain := domain.Strain{Strain: entitymodel.Strain{Code: g.name("S"), Name: g.name("strain"), LineID: line.ID,
+			GenotypeMarkerIDs: g.subset(markers, 0)}}
+		if g.chance() {
+			if err := strain.ApplyStrainAttributes(g.pluginAttributes()); err != nil {
+				return err
+			}
+		}
+		strain, err = tx.CreateStrain(strain)
+		if err != nil {
+			return err
+		}
+		strains = append(strains, strain.ID)
+	}
+	for i := g.count(1, 3); i > 0; i-- {
+		facility := domain.Facility{Facility: entitymodel.Facility{Code: g.name("FAC"), Name: g.name("facility"), Zone: g.name("zone"), AccessPolicy: g.name("policy")}}
+		if g.chance() {
+			if err := facility.ApplyEnvironmentBaselines(g.attributes()); err != nil {
+				return err
+			}
+		}
+		created, err := tx.CreateFacility(facility)
+		if err != nil {
+			return err
+		}
+		facilities = append(facilities, created.ID)
+	}
+	for i := g.count(1, 2); i > 0; i-- {
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: g.name("PROT"), Title: g.name("protocol"), MaxSubjects: g.count(10, 50)}})
+		if err != nil {
+			return err
+		}
+		protocols = append(protocols, protocol.ID)
+	}
+	for i := g.count(1, 2); i > 0; i-- {
+		project, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{Code: g.name("PRJ"), Title: g.name("project"),
+			FacilityIDs: g.subset(facilities, 1), ProtocolIDs: g.subset(protocols, 0)}})
+		if err != nil {
+			return err
+		}
+		projects = append(projects, project.ID)
+	}
+	for i := g.count(1, 3); i > 0; i-- {
+		unit, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: g.name("tank"), FacilityID: g.pick(facilities),
+			Capacity: g.count(20, 40), Environment: environments[g.rng.IntN(len(environments))]}})
+		if err != nil {
+			return err
+		}
+		housing = append(housing, unit.ID)
+	}
+	for i := g.count(1, 2); i > 0; i-- {
+		cohort, err := tx.CreateCohort(domain.Cohort{Cohort: entitymodel.Cohort{Name: g.name("cohort"), Purpose: g.name("purpose"),
+			ProjectID: g.optional(projects), HousingID: g.optional(housing), ProtocolID: g.optional(protocols)}})
+		if err != nil {
+			return err
+		}
+		cohorts = append(cohorts, cohort.ID)
+	}
+	for i := g.count(2, 5); i > 0; i-- {
+		organism := domain.Organism{Organism: entitymodel.Organism{Name: g.name("frog"), Species: "Xenopus laevis", Stage: stages[g.rng.IntN(len(stages))],
+			HousingID: g.optional(housing), CohortID: g.optional(cohorts), ProjectID: g.optional(projects), ProtocolID: g.optional(protocols),
+			LineID: g.optional(lines), StrainID: g.optional(strains), ParentIDs: g.subset(organisms, 0)}}
+		if err := organism.SetCoreAttributes(g.attributes()); err != nil {
+			return err
+		}
+		created, err := tx.CreateOrganism(organism)
+		if err != nil {
+			return err
+		}
+		organisms = append(organisms, created.ID)
+	}
+	if _, err := tx.CreateBreedingUnit(domain.BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{Name: g.name("pair"), Strategy: "pair",
+		HousingID: g.optional(housing), ProtocolID: g.optional(protocols), FemaleIDs: g.subset(organisms, 1), MaleIDs: g.subset(organisms, 0)}}); err != nil {
+		return err
+	}
+	for i := g.count(1, 3); i > 0; i-- {
+		procedure, err := tx.CreateProcedure(domain.Procedure{Procedure: entitymodel.Procedure{Name: g.name("procedure"), Status: domain.ProcedureStatusScheduled,
+			ScheduledAt: g.at(), ProtocolID: g.pick(protocols), ProjectID: g.optional(projects), CohortID: g.optional(cohorts), OrganismIDs: g.subset(organisms, 0)}})
+		if err != nil {
+			return err
+		}
+		procedures = append(procedures, procedure.ID)
+	}
+	for i := g.count(1, 2); i > 0; i-- {
+		if _, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{Name: g.name("treatment"), Status: domain.TreatmentStatusPlanned,
+			ProcedureID: g.pick(procedures), OrganismIDs: g.subset(organisms, 1), CohortIDs: g.subset(cohorts, 0),
+			DosagePlan: domain.DosagePlan{Drug: g.name("drug"), DoseAmount: float64(g.count(1, 20)) / 4, DoseUnit: "mg/kg", FrequencyPerDay: float64(g.count(1, 3)), DurationDays: g.count(1, 14)}}}); err != nil {
+			return err
+		}
+	}
+	for i := g.count(1, 3); i > 0; i-- {
+		observation := domain.Observation{Observation: entitymodel.Observation{OrganismID: strPtr(g.pick(organisms)), ProcedureID: g.optional(procedures),
+			RecordedAt: g.at(), Observer: g.name("tech")}}
+		if g.chance() {
+			observation.Data = g.attributes()
+		}
+		if _, err := tx.CreateObservation(observation); err != nil {
+			return err
+		}
+	}
+	for i := g.count(1, 2); i > 0; i-- {
+		collected := g.at()
+		if _, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{Identifier: g.name("SMP"), SourceType: "blood", OrganismID: strPtr(g.pick(organisms)),
+			CohortID: g.optional(cohorts), FacilityID: g.pick(facilities), CollectedAt: collected, Status: domain.SampleStatusStored,
+			StorageLocation: g.name("freezer"), AssayType: "PCR", CollectedBy: g.name("tech"),
+			ChainOfCustody: []domain.SampleCustodyEvent{{Actor: g.name("tech"), Location: g.name("bench"), Timestamp: collected}}}}); err != nil {
+			return err
+		}
+	}
+	for i := g.count(1, 2); i > 0; i-- {
+		from := g.at()
+		if _, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: g.name("PERMIT"), Authority: g.name("authority"),
+			Status: domain.PermitStatusApproved, IssueDate: from.AddDate(0, 0, -7), ValidFrom: from, ValidUntil: from.AddDate(1, 0, 0),
+			AllowedActivities: []string{g.name("activity")}, FacilityIDs: g.subset(facilities, 1), ProtocolIDs: g.subset(protocols, 1)}}); err != nil {
+			return err
+		}
+	}
+	for i := g.count(1, 2); i > 0; i-- {
+		if _, err := tx.CreateSupplyItem(domain.SupplyItem{SupplyItem: entitymodel.SupplyItem{SKU: g.name("SKU"), Name: g.name("supply"),
+			QuantityOnHand: g.count(0, 100), Unit: "box", ReorderLevel: g.count(0, 10),
+			FacilityIDs: g.subset(facilities, 1), ProjectIDs: g.subset(projects, 1)}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// memoryRoundTrip imports snapshot into a fresh memory store and exports it
+// again, which normalizes derived relationship fields.
+func memoryRoundTrip(t *testing.T, snapshot memory.Snapshot) memory.Snapshot {
+	t.Helper()
+	store := memory.NewStore(nil)
+	if err := store.ImportState(snapshot); err != nil {
+		t.Fatalf("memory import: %v", err)
+	}
+	return store.ExportState()
+}
+
+// postgresRoundTrip persists snapshot through a postgres store opened on dsn,
+// reopens the store, and returns the state loaded back from the normalized
+// tables after the same memory normalization.
+func postgresRoundTrip(t *testing.T, dsn string, snapshot memory.Snapshot) memory.Snapshot {
+	t.Helper()
+	store, err := NewStore(dsn, domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("open postgres store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.DB().Close() })
+	if err := store.Import(context.Background(), snapshot); err != nil {
+		t.Fatalf("postgres import: %v", err)
+	}
+	reopened, err := NewStore(dsn, domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("reopen postgres store: %v", err)
+	}
+	t.Cleanup(func() { _ = reopened.DB().Close() })
+	return memoryRoundTrip(t, reopened.ExportState())
+}
+
+// stubRoundTripDSN routes NewStore to a fresh in-process stub database for
+// the rest of the test and returns the DSN to open it with.
+func stubRoundTripDSN(t *testing.T) string {
+	t.Helper()
+	db, _ := pgtu.NewStubDB()
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	t.Cleanup(restore)
+	return "roundtrip"
+}
+
+// realRoundTripDSN returns COLONYCORE_POSTGRES_DSN, skipping the test when
+// it is unset. Import truncates the entity tables, so each round trip starts
+// from an empty database.
+func realRoundTripDSN(t *testing.T) string {
+	t.Helper()
+	dsn := os.Getenv("COLONYCORE_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("COLONYCORE_POSTGRES_DSN not set")
+	}
+	return dsn
+}
+
+func snapshotHash(t *testing.T, snapshot memory.Snapshot) string {
+	t.Helper()
+	raw, err := memory.MarshalStableSnapshot(snapshot)
+	if err != nil {
+		t.Fatalf("encode snapshot: %v", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// assertPostgresRoundTrip requires snapshot to load back from postgres exactly
+// as it comes back from a memory import/export round trip. Snapshots are
+// compared by the hash of their stable encoding; on a mismatch the differing
+// records are reported from memory.DiffSnapshots.
+func assertPostgresRoundTrip(t *testing.T, dsn string, snapshot memory.Snapshot) {
+	t.Helper()
+	want := memoryRoundTrip(t, snapshot)
+	got := postgresRoundTrip(t, dsn, snapshot)
+	if snapshotHash(t, want) == snapshotHash(t, got) {
+		return
+	}
+	diff, err := memory.DiffSnapshots(want, got)
+	if err != nil {
+		t.Fatalf("diff snapshots: %v", err)
+	}
+	// DiffSnapshots compares plain encodings, so it also reports records whose
+	// set-valued ID lists differ only in order; skip those.
+	wantRecords, gotRecords := recordsByID(t, want), recordsByID(t, got)
+	reported := false
+	for _, entity := range diff.Entities {
+		for _, id := range entity.Changed {
+			if wantRecords[id] == gotRecords[id] {
+				continue
+			}
+			reported = true
+			t.Errorf("%s %s changed:\nmemory:   %s\npostgres: %s", entity.Entity, id, wantRecords[id], gotRecords[id])
+		}
+		for _, id := range entity.Removed {
+			reported = true
+			t.Errorf("%s %s missing after postgres round trip", entity.Entity, id)
+		}
+		for _, id := range entity.Added {
+			reported = true
+			t.Errorf("%s %s appeared after postgres round trip", entity.Entity, id)
+		}
+	}
+	if !reported {
+		t.Errorf("snapshot hashes differ but every record encodes the same")
+	}
+}
+
+// recordsByID indexes the stable JSON encoding of every record by its ID.
+func recordsByID(t *testing.T, snapshot memory.Snapshot) map[string]string {
+	t.Helper()
+	raw, err := memory.MarshalStableSnapshot(snapshot)
+	if err != nil {
+		t.Fatalf("encode snapshot: %v", err)
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("decode snapshot: %v", err)
+	}
+	records := map[string]string{}
+	for _, bucket := range doc {
+		var entries map[string]json.RawMessage
+		if json.Unmarshal(bucket, &entries) != nil {
+			continue
+		}
+		for id, record := range entries {
+			compact, _ := json.Marshal(record)
+			records[id] = string(compact)
+		}
+	}
+	return records
+}
+
+// runRoundTripSeeds asserts every seeded snapshot round-trips through the
+// postgres store opened on the DSN that dsn returns for each subtest.
+func runRoundTripSeeds(t *testing.T, dsn func(*testing.T) string) {
+	withAttributes := 0
+	for _, seed := range roundTripSeeds {
+		t.Run(fmt.Sprintf("seed-%d", seed), func(t *testing.T) {
+			snapshot := randomSnapshot(t, seed)
+			for _, entity := range []struct {
+				name  domain.EntityType
+				count int
+			}{
+				{domain.EntityOrganism, len(snapshot.Organisms)},
+				{domain.EntityCohort, len(snapshot.Cohorts)},
+				{domain.EntityHousingUnit, len(snapshot.Housing)},
+				{domain.EntityFacility, len(snapshot.Facilities)},
+				{domain.EntityBreeding, len(snapshot.Breeding)},
+				{domain.EntityLine, len(snapshot.Lines)},
+				{domain.EntityStrain, len(snapshot.Strains)},
+				{domain.EntityGenotypeMarker, len(snapshot.Markers)},
+				{domain.EntityProcedure, len(snapshot.Procedures)},
+				{domain.EntityTreatment, len(snapshot.Treatments)},
+				{domain.EntityObservation, len(snapshot.Observations)},
+				{domain.EntitySample, len(snapshot.Samples)},
+				{domain.EntityProtocol, len(snapshot.Protocols)},
+				{domain.EntityPermit, len(snapshot.Permits)},
+				{domain.EntityProject, len(snapshot.Projects)},
+				{domain.EntitySupplyItem, len(snapshot.Supplies)},
+			} {
+				if entity.count == 0 {
+					t.Fatalf("generated snapshot has no %s records", entity.name)
+				}
+			}
+			for _, strain := range snapshot.Strains {
+				if len(strain.StrainAttributesByPlugin()) > 0 {
+					withAttributes++
+				}
+			}
+			for _, marker := range snapshot.Markers {
+				if len(marker.GenotypeMarkerAttributesByPlugin()) > 0 {
+					withAttributes++
+				}
+			}
+			assertPostgresRoundTrip(t, dsn(t), snapshot)
+		})
+	}
+	if !t.Skipped() && withAttributes == 0 {
+		t.Fatalf("expected some seeds to generate strain or marker attributes")
+	}
+}
+
+func TestPostgresRoundTripMatchesMemory(t *testing.T) {
+	runRoundTripSeeds(t, stubRoundTripDSN)
+}
+
+// TestPostgresRoundTripMatchesMemoryAgainstPostgres runs the seeded round
+// trip against a real Postgres when COLONYCORE_POSTGRES_DSN is set and is
+// skipped otherwise.
+func TestPostgresRoundTripMatchesMemoryAgainstPostgres(t *testing.T) {
+	if os.Getenv("COLONYCORE_POSTGRES_DSN") == "" {
+		t.Skip("COLONYCORE_POSTGRES_DSN not set")
+	}
+	runRoundTripSeeds(t, realRoundTripDSN)
+}
+
+func TestPostgresRoundTripFixture(t *testing.T) {
+	assertPostgresRoundTrip(t, stubRoundTripDSN(t), loadFixtureSnapshot(t))
+}
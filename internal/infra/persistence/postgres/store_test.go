@@ -14,9 +14,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 func firstKey[T any](m map[string]T) (string, bool) {
@@ -726,6 +730,7 @@ func TestInsertHelperValidationErrors(t *testing.T) {
 				return insertSamples(ctx, exec, map[string]domain.Sample{
 					"sample": {Sample: entitymodel.Sample{
 						ID:             "sample",
+						CollectedBy:    "tech",
 						ChainOfCustody: []entitymodel.SampleCustodyEvent{{Location: "loc", Timestamp: now}},
 					}},
 				})
@@ -936,6 +941,64 @@ func TestLifecycleStatusesRoundTripNormalizedSnapshot(t *testing.T) {
 	}
 }
 
+func TestPermitIssueDateRoundTripNormalizedSnapshot(t *testing.T) {
+	ctx := context.Background()
+	db, _ := pgtu.NewStubDB()
+
+	orig := loadFixtureSnapshot(t)
+	var permitID string
+	for id, permit := range orig.Permits {
+		permit.IssueDate = permit.ValidFrom.AddDate(0, 0, -7)
+		orig.Permits[id] = permit
+		permitID = id
+		break
+	}
+	if permitID == "" {
+		t.Fatalf("fixture missing permits to validate")
+	}
+	if err := persistNormalized(ctx, db, orig); err != nil {
+		t.Fatalf("persistNormalized: %v", err)
+	}
+	loaded, err := loadNormalizedSnapshot(ctx, db)
+	if err != nil {
+		t.Fatalf("loadNormalizedSnapshot: %v", err)
+	}
+	want := orig.Permits[permitID].IssueDate
+	if got := loaded.Permits[permitID].IssueDate; !got.Equal(want) {
+		t.Fatalf("permit %s issue_date mismatch: want %s got %s", permitID, want, got)
+	}
+}
+
+func TestSampleCollectionRoundTripNormalizedSnapshot(t *testing.T) {
+	ctx := context.Background()
+	db, _ := pgtu.NewStubDB()
+
+	orig := loadFixtureSnapshot(t)
+	protocol := "SOP-BLOOD-7"
+	var sampleID string
+	for id, sample := range orig.Samples {
+		sample.CollectedBy = "Technician Two"
+		sample.CollectionProtocol = &protocol
+		orig.Samples[id] = sample
+		sampleID = id
+		break
+	}
+	if sampleID == "" {
+		t.Fatalf("fixture missing samples to validate")
+	}
+	if err := persistNormalized(ctx, db, orig); err != nil {
+		t.Fatalf("persistNormalized: %v", err)
+	}
+	loaded, err := loadNormalizedSnapshot(ctx, db)
+	if err != nil {
+		t.Fatalf("loadNormalizedSnapshot: %v", err)
+	}
+	got := loaded.Samples[sampleID]
+	if got.CollectedBy != "Technician Two" || got.CollectionProtocol == nil || *got.CollectionProtocol != protocol {
+		t.Fatalf("sample %s collection mismatch: got collected_by %q protocol %v", sampleID, got.CollectedBy, got.CollectionProtocol)
+	}
+}
+
 func TestPersistMissingRequiredRelationshipsError(t *testing.T) {
 	db, _ := pgtu.NewStubDB()
 	now := time.Now().UTC()
@@ -979,6 +1042,63 @@ func TestApplyDDLStatementsError(t *testing.T) {
 	}
 }
 
+// catalogExec mimics Postgres DDL semantics: IF NOT EXISTS and OR REPLACE
+// statements always succeed, while re-creating any other object fails with
+// the server's duplicate-object error.
+type catalogExec struct {
+	created map[string]bool
+	failOn  string
+}
+
+func (c *catalogExec) ExecContext(_ context.Context, query string, _ ...any) (sql.Result, error) {
+	if c.failOn != "" && strings.Contains(query, c.failOn) {
+		return nil, &pgconn.PgError{Code: "42601", Message: "syntax error"}
+	}
+	upper := strings.ToUpper(query)
+	if strings.Contains(upper, "IF NOT EXISTS") || strings.Contains(upper, "OR REPLACE") {
+		return driver.RowsAffected(0), nil
+	}
+	if c.created[query] {
+		return nil, fmt.Errorf("exec: %w", &pgconn.PgError{Code: "42710", Message: "already exists"})
+	}
+	if c.created == nil {
+		c.created = make(map[string]bool)
+	}
+	c.created[query] = true
+	return driver.RowsAffected(0), nil
+}
+
+func (c *catalogExec) QueryContext(context.Context, string, ...any) (*sql.Rows, error) {
+	return nil, fmt.Errorf("QueryContext not implemented")
+}
+
+func TestApplyDDLStatementsTwiceSucceeds(t *testing.T) {
+	ctx := context.Background()
+	db := &catalogExec{}
+	ddl := sqlbundle.Postgres()
+	if err := applyDDLStatements(ctx, db, ddl); err != nil {
+		t.Fatalf("first apply: %v", err)
+	}
+	if len(db.created) == 0 {
+		t.Fatalf("expected bundle to include statements without IF NOT EXISTS")
+	}
+	if err := applyDDLStatements(ctx, db, ddl); err != nil {
+		t.Fatalf("second apply: %v", err)
+	}
+}
+
+func TestApplyDDLStatementsFailsOnGenuineErrors(t *testing.T) {
+	db := &catalogExec{failOn: "CREATE TABLE broken"}
+	err := applyDDLStatements(context.Background(), db, "CREATE TABLE ok(id text); CREATE TABLE broken(;")
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "42601" {
+		t.Fatalf("expected syntax error to surface, got %v", err)
+	}
+	if isDuplicateObjectError(fmt.Errorf("plain failure")) {
+		t.Fatalf("expected errors without SQLSTATE to be treated as genuine")
+	}
+}
+
 func TestNewStoreOpenError(t *testing.T) {
 	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) {
 		return nil, fmt.Errorf("open fail")
@@ -1049,6 +1169,7 @@ func TestInsertSamplesRequireFacility(t *testing.T) {
 		Status:          domain.SampleStatusStored,
 		StorageLocation: "loc",
 		AssayType:       "assay",
+		CollectedBy:     "tech",
 		ChainOfCustody:  []domain.SampleCustodyEvent{{Actor: "a", Location: "b", Timestamp: time.Now()}},
 		CollectedAt:     time.Now(),
 		CreatedAt:       time.Now(),
@@ -1059,6 +1180,26 @@ func TestInsertSamplesRequireFacility(t *testing.T) {
 	}
 }
 
+func TestInsertSamplesRequireCollectedBy(t *testing.T) {
+	exec := &recordingExec{}
+	s := domain.Sample{Sample: entitymodel.Sample{
+		ID:              "s3",
+		Identifier:      "ID3",
+		SourceType:      "type",
+		Status:          domain.SampleStatusStored,
+		StorageLocation: "loc",
+		AssayType:       "assay",
+		FacilityID:      "f1",
+		ChainOfCustody:  []domain.SampleCustodyEvent{{Actor: "a", Location: "b", Timestamp: time.Now()}},
+		CollectedAt:     time.Now(),
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}}
+	if err := insertSamples(context.Background(), exec, map[string]domain.Sample{"s3": s}); err == nil || !strings.Contains(err.Error(), "collected_by") {
+		t.Fatalf("expected collected_by error, got %v", err)
+	}
+}
+
 func TestInsertProceduresRequireProtocol(t *testing.T) {
 	exec := &recordingExec{}
 	p := domain.Procedure{Procedure: entitymodel.Procedure{
@@ -1096,7 +1237,7 @@ func TestInsertTreatmentsRequireProcedure(t *testing.T) {
 		ID:         "t2",
 		Name:       "Name",
 		Status:     domain.TreatmentStatusPlanned,
-		DosagePlan: "plan",
+		DosagePlan: domain.DosagePlan{Drug: "plan", DoseAmount: 1, DoseUnit: "mg"},
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}}
@@ -1809,8 +1950,52 @@ func TestRunInTransactionStopsOnUserError(t *testing.T) {
 	if _, err := store.RunInTransaction(context.Background(), func(domain.Transaction) error { return userErr }); !errors.Is(err, userErr) {
 		t.Fatalf("expected user error to propagate, got %v", err)
 	}
-	if conn != nil && len(conn.Tables) != 0 {
-		t.Fatalf("expected no persistence when user fn errors")
+	for table, rows := range conn.Tables {
+		if table != "colonycore_schema_version" && len(rows) != 0 {
+			t.Fatalf("expected no persistence when user fn errors, got %s rows", table)
+		}
+	}
+}
+
+func TestNewStoreChecksAndStampsSchemaVersion(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+
+	if _, err := NewStore("ignored", domain.NewRulesEngine()); err != nil {
+		t.Fatalf("NewStore on unstamped database: %v", err)
+	}
+	stamped := conn.Tables["colonycore_schema_version"]
+	if len(stamped) != 1 || stamped[0]["version"] != entitymodel.SchemaVersion {
+		t.Fatalf("expected schema version %s to be stamped, got %+v", entitymodel.SchemaVersion, stamped)
+	}
+
+	conn.Tables["colonycore_schema_version"] = []map[string]any{{"id": int64(1), "version": "999.0.0"}}
+	if _, err := NewStore("ignored", domain.NewRulesEngine()); !errors.Is(err, memory.ErrSnapshotTooNew) {
+		t.Fatalf("expected ErrSnapshotTooNew for a newer database, got %v", err)
+	}
+	if _, err := loadNormalizedSnapshot(context.Background(), db); !errors.Is(err, memory.ErrSnapshotTooNew) {
+		t.Fatalf("expected snapshot loads to refuse a newer database, got %v", err)
+	}
+}
+
+func TestNewStoreRefusesTablesFromOlderSchema(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+
+	conn.Tables["colonycore_schema_version"] = []map[string]any{{"id": int64(1), "version": "0.2.0"}}
+	if _, err := NewStore("ignored", domain.NewRulesEngine()); !errors.Is(err, ErrSchemaMigrationRequired) {
+		t.Fatalf("expected ErrSchemaMigrationRequired for a database stamped 0.2.0, got %v", err)
+	}
+	if stamped := conn.Tables["colonycore_schema_version"]; stamped[0]["version"] != "0.2.0" {
+		t.Fatalf("expected refused database to keep its stamp, got %+v", stamped)
+	}
+
+	conn.Tables["colonycore_schema_version"] = nil
+	conn.Tables["information_schema.tables"] = []map[string]any{{"table_name": "facilities"}}
+	if _, err := NewStore("ignored", domain.NewRulesEngine()); !errors.Is(err, ErrSchemaMigrationRequired) {
+		t.Fatalf("expected ErrSchemaMigrationRequired for unversioned entity tables, got %v", err)
 	}
 }
 
@@ -2280,6 +2465,7 @@ func TestInsertHelpersExecFailures(t *testing.T) {
 					StorageLocation: "loc",
 					AssayType:       "assay",
 					FacilityID:      "fac-1",
+					CollectedBy:     "tech",
 					ChainOfCustody:  []domain.SampleCustodyEvent{{Actor: "a", Location: "b", Timestamp: now}},
 					CollectedAt:     now,
 					CreatedAt:       now,
@@ -2378,6 +2564,8 @@ func TestPersistAndLoadWithOptionalFields(t *testing.T) {
 	if err := facility.ApplyEnvironmentBaselines(map[string]any{"temp": 22}); err != nil {
 		t.Fatalf("ApplyEnvironmentBaselines: %v", err)
 	}
+	defaultEnvironment := entitymodel.HousingEnvironmentAquatic
+	facility.DefaultHousingEnvironment = &defaultEnvironment
 
 	marker := domain.GenotypeMarker{GenotypeMarker: entitymodel.GenotypeMarker{
 		ID:             "marker-1",
@@ -2401,6 +2589,7 @@ func TestPersistAndLoadWithOptionalFields(t *testing.T) {
 		DeprecatedAt:      &deprecatedAt,
 		DeprecationReason: &deprecationReason,
 		GenotypeMarkerIDs: []string{marker.ID},
+		Tags:              []string{"knockout", "reporter"},
 		CreatedAt:         now,
 		UpdatedAt:         now,
 	}}
@@ -2432,6 +2621,7 @@ func TestPersistAndLoadWithOptionalFields(t *testing.T) {
 		State:       entitymodel.HousingStateActive,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		Version:     3,
 	}}
 
 	protoDesc := "protocol"
@@ -2444,6 +2634,7 @@ func TestPersistAndLoadWithOptionalFields(t *testing.T) {
 		Status:      domain.ProtocolStatusApproved,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		Version:     2,
 	}}
 
 	projectDesc := "project"
@@ -2485,7 +2676,7 @@ func TestPersistAndLoadWithOptionalFields(t *testing.T) {
 		UpdatedAt:  now,
 	}}
 
-	pairingIntent := "intent"
+	pairingIntent := domain.PairingIntentMaintenance
 	pairingNotes := "notes"
 	breeding := domain.BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{
 		ID:                "breed-1",
@@ -2517,6 +2708,7 @@ func TestPersistAndLoadWithOptionalFields(t *testing.T) {
 	protocolID := protocol.ID
 	org1 := domain.Organism{Organism: entitymodel.Organism{
 		ID:         "org-1",
+		Version:    4,
 		Name:       "Org1",
 		Species:    "frog",
 		Line:       "line",
@@ -2564,10 +2756,18 @@ func TestPersistAndLoadWithOptionalFields(t *testing.T) {
 
 	data := map[string]any{"note": "value"}
 	obsNote := "obs-note"
+	recordedBy := "observer"
+	reviewedBy := "reviewer"
+	reviewedAt := now.Add(time.Hour)
+	obsWeight := 28.5
 	observation := domain.Observation{Observation: entitymodel.Observation{
 		ID:          "obs-1",
+		Weight:      &obsWeight,
 		Observer:    "observer",
 		RecordedAt:  now,
+		RecordedBy:  &recordedBy,
+		ReviewedBy:  &reviewedBy,
+		ReviewedAt:  &reviewedAt,
 		ProcedureID: &procedure.ID,
 		OrganismID:  &org1.ID,
 		CohortID:    &cohort.ID,
@@ -2592,6 +2792,7 @@ func TestPersistAndLoadWithOptionalFields(t *testing.T) {
 		FacilityID:      facility.ID,
 		OrganismID:      &org1.ID,
 		CohortID:        &cohort.ID,
+		CollectedBy:     "tech",
 		ChainOfCustody:  chain,
 		CollectedAt:     now,
 		CreatedAt:       now,
@@ -2630,9 +2831,9 @@ func TestPersistAndLoadWithOptionalFields(t *testing.T) {
 		Name:              "Treatment",
 		Status:            domain.TreatmentStatusPlanned,
 		ProcedureID:       procedure.ID,
-		DosagePlan:        "plan",
+		DosagePlan:        domain.DosagePlan{Drug: "plan", DoseAmount: 1, DoseUnit: "mg"},
 		AdministrationLog: []string{"admin"},
-		AdverseEvents:     []string{"ae"},
+		AdverseEvents:     []domain.AdverseEvent{{Description: "ae", Severity: domain.AdverseEventSeverityMild, ObservedAt: now}},
 		CohortIDs:         []string{cohort.ID},
 		OrganismIDs:       []string{org1.ID},
 		CreatedAt:         now,
@@ -2667,7 +2868,7 @@ func TestPersistAndLoadWithOptionalFields(t *testing.T) {
 	}
 
 	gotLine := loaded.Lines[line.ID]
-	if gotLine.DeprecatedAt == nil || gotLine.DeprecationReason == nil {
+	if gotLine.DeprecatedAt == nil || gotLine.DeprecationReason == nil || !reflect.DeepEqual(gotLine.Tags, line.Tags) {
 		t.Fatalf("expected line optional fields to persist, got %+v", gotLine)
 	}
 	gotSupply := loaded.Supplies[supply.ID]
@@ -2677,6 +2878,19 @@ func TestPersistAndLoadWithOptionalFields(t *testing.T) {
 	if gotPermit := loaded.Permits[permit.ID]; gotPermit.Notes == nil {
 		t.Fatalf("expected permit notes to persist")
 	}
+	gotObservation := loaded.Observations[observation.ID]
+	if gotObservation.RecordedBy == nil || gotObservation.ReviewedBy == nil || gotObservation.ReviewedAt == nil {
+		t.Fatalf("expected observation review fields to persist, got %+v", gotObservation)
+	}
+	if gotFacility := loaded.Facilities[facility.ID]; gotFacility.DefaultHousingEnvironment == nil || *gotFacility.DefaultHousingEnvironment != defaultEnvironment {
+		t.Fatalf("expected facility default housing environment to persist, got %+v", gotFacility.DefaultHousingEnvironment)
+	}
+	if gotObservation.Weight == nil || *gotObservation.Weight != obsWeight || gotObservation.Length != nil {
+		t.Fatalf("expected observation weight to persist without length, got %+v", gotObservation)
+	}
+	if got := [3]int{loaded.Housing[housing.ID].Version, loaded.Protocols[protocol.ID].Version, loaded.Organisms[org1.ID].Version}; got != [3]int{3, 2, 4} {
+		t.Fatalf("expected housing, protocol, and organism versions to persist, got %v", got)
+	}
 }
 
 func loadFixtureSnapshot(t *testing.T) memory.Snapshot {
@@ -2693,3 +2907,1025 @@ func loadFixtureSnapshot(t *testing.T) memory.Snapshot {
 	}
 	return snapshot
 }
+
+func TestListObservationsBySubjectUsesFilteredQueries(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	orgID, cohortID, procID := "org-1", "cohort-1", "proc-1"
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	row := func(id string, recordedAt time.Time, organismID, cohortID, procedureID any) map[string]any {
+		return map[string]any{
+			"id":           id,
+			"observer":     "tech",
+			"recorded_at":  recordedAt,
+			"procedure_id": procedureID,
+			"organism_id":  organismID,
+			"cohort_id":    cohortID,
+			"data":         []byte(`{}`),
+			"created_at":   base,
+			"updated_at":   base,
+		}
+	}
+	conn.Tables["observations"] = []map[string]any{
+		row("obs-late", base.Add(time.Hour), orgID, nil, nil),
+		row("obs-early", base, orgID, nil, nil),
+		row("obs-procedure", base, nil, nil, procID),
+		row("obs-cohort", base, nil, cohortID, nil),
+	}
+	store := &Store{db: db, engine: domain.NewRulesEngine()}
+
+	byOrganism, err := store.ListObservationsByOrganism(orgID)
+	if err != nil {
+		t.Fatalf("list observations by organism: %v", err)
+	}
+	if len(byOrganism) != 2 || byOrganism[0].ID != "obs-early" || byOrganism[1].ID != "obs-late" {
+		t.Fatalf("expected organism observations ordered by recorded_at, got %+v", byOrganism)
+	}
+	byCohort, err := store.ListObservationsByCohort(cohortID)
+	if err != nil {
+		t.Fatalf("list observations by cohort: %v", err)
+	}
+	if len(byCohort) != 1 || byCohort[0].ID != "obs-cohort" {
+		t.Fatalf("expected single cohort observation, got %+v", byCohort)
+	}
+
+	if weighed, err := store.ListObservationsByOrganism(orgID, domain.ObservationHasWeight()); err != nil || len(weighed) != 0 {
+		t.Fatalf("expected weight filter to exclude unweighed observations, got %+v (%v)", weighed, err)
+	}
+
+	conn.FailTables = map[string]bool{"observations": true}
+	if _, err := store.ListObservationsByOrganism(orgID); err == nil {
+		t.Fatalf("expected failing observation query to be returned")
+	}
+	if _, err := store.ListObservationsByCohort(cohortID); err == nil {
+		t.Fatalf("expected failing cohort observation query to be returned")
+	}
+}
+
+func TestGetBreedingUnitUsesFilteredQueries(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	row := func(id string) map[string]any {
+		return map[string]any{"id": id, "name": id, "strategy": "pair", "created_at": base, "updated_at": base}
+	}
+	member := func(breedingID, organismID string) map[string]any {
+		return map[string]any{"breeding_unit_id": breedingID, "organism_id": organismID}
+	}
+	conn.Tables["breeding_units"] = []map[string]any{row("bu-1"), row("bu-2")}
+	conn.Tables["breeding_units__female_ids"] = []map[string]any{member("bu-1", "f-2"), member("bu-1", "f-1"), member("bu-2", "f-9")}
+	conn.Tables["breeding_units__male_ids"] = []map[string]any{member("bu-1", "m-1"), member("bu-2", "m-9")}
+	store := &Store{db: db, engine: domain.NewRulesEngine()}
+
+	unit, ok := store.GetBreedingUnit("bu-1")
+	if !ok || unit.ID != "bu-1" {
+		t.Fatalf("expected breeding unit bu-1, got %+v (%v)", unit, ok)
+	}
+	if !reflect.DeepEqual(unit.FemaleIDs, []string{"f-1", "f-2"}) || !reflect.DeepEqual(unit.MaleIDs, []string{"m-1"}) {
+		t.Fatalf("expected only bu-1 members, got females %v males %v", unit.FemaleIDs, unit.MaleIDs)
+	}
+	if _, ok := store.GetBreedingUnit("missing"); ok {
+		t.Fatalf("expected missing breeding unit to return false")
+	}
+
+	conn.FailTables = map[string]bool{"breeding_units__male_ids": true}
+	if _, _, err := store.GetBreedingUnitByID("bu-1"); err == nil {
+		t.Fatalf("expected failing member query to be returned")
+	}
+	store.cache = memory.Snapshot{Breeding: map[string]domain.BreedingUnit{
+		"cached": {BreedingUnit: entitymodel.BreedingUnit{ID: "cached", FemaleIDs: []string{"f-c"}, MaleIDs: []string{"m-c"}}},
+	}}
+	cached, ok := store.GetBreedingUnit("cached")
+	if !ok || len(cached.FemaleIDs) != 1 || len(cached.MaleIDs) != 1 {
+		t.Fatalf("expected cached fallback breeding unit, got %+v (%v)", cached, ok)
+	}
+	cached.FemaleIDs[0] = "mutated"
+	if again, _ := store.GetBreedingUnit("cached"); again.FemaleIDs[0] != "f-c" {
+		t.Fatalf("expected defensive copy of cached member ids, got %v", again.FemaleIDs)
+	}
+}
+
+func TestGetSupplyItemLoadsJoinRows(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	row := func(id string) map[string]any {
+		return map[string]any{"id": id, "sku": "SKU-" + id, "name": id, "quantity_on_hand": int64(4), "unit": "box", "reorder_level": int64(1), "status": "active", "created_at": base, "updated_at": base}
+	}
+	facility := func(supplyID, facilityID string) map[string]any {
+		return map[string]any{"supply_item_id": supplyID, "facility_id": facilityID}
+	}
+	project := func(projectID, supplyID string) map[string]any {
+		return map[string]any{"project_id": projectID, "supply_item_id": supplyID}
+	}
+	conn.Tables["supply_items"] = []map[string]any{row("sup-1"), row("sup-2")}
+	conn.Tables["supply_items__facility_ids"] = []map[string]any{facility("sup-1", "fac-2"), facility("sup-1", "fac-1"), facility("sup-2", "fac-9")}
+	conn.Tables["projects__supply_item_ids"] = []map[string]any{project("proj-1", "sup-1"), project("proj-9", "sup-2")}
+	store := &Store{db: db, engine: domain.NewRulesEngine()}
+
+	item, ok := store.GetSupplyItem("sup-1")
+	if !ok || item.ID != "sup-1" || item.SKU != "SKU-sup-1" {
+		t.Fatalf("expected supply item sup-1, got %+v (%v)", item, ok)
+	}
+	if !reflect.DeepEqual(item.FacilityIDs, []string{"fac-1", "fac-2"}) || !reflect.DeepEqual(item.ProjectIDs, []string{"proj-1"}) {
+		t.Fatalf("expected only sup-1 join rows, got facilities %v projects %v", item.FacilityIDs, item.ProjectIDs)
+	}
+	if _, ok := store.GetSupplyItem("missing"); ok {
+		t.Fatalf("expected missing supply item to return false")
+	}
+
+	conn.FailTables = map[string]bool{"supply_items": true}
+	if _, _, err := store.GetSupplyItemByID("sup-1"); err == nil {
+		t.Fatalf("expected failing supply item query to be returned")
+	}
+	store.cache = memory.Snapshot{Supplies: map[string]domain.SupplyItem{
+		"cached": {SupplyItem: entitymodel.SupplyItem{ID: "cached", FacilityIDs: []string{"fac-c"}, ProjectIDs: []string{"proj-c"}}},
+	}}
+	cached, ok := store.GetSupplyItem("cached")
+	if !ok || len(cached.FacilityIDs) != 1 || len(cached.ProjectIDs) != 1 {
+		t.Fatalf("expected cached fallback supply item, got %+v (%v)", cached, ok)
+	}
+	cached.FacilityIDs[0] = "mutated"
+	if again, _ := store.GetSupplyItem("cached"); again.FacilityIDs[0] != "fac-c" {
+		t.Fatalf("expected defensive copy of cached facility ids, got %v", again.FacilityIDs)
+	}
+
+	conn.FailTables = nil
+	conn.FailBegin = true
+	if fallback, ok := store.GetSupplyItem("cached"); !ok || fallback.ID != "cached" {
+		t.Fatalf("expected cache fallback when the transaction cannot begin, got %+v (%v)", fallback, ok)
+	}
+}
+
+func TestListTreatmentsByProcedureUsesFilteredQueries(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	row := func(id, procedureID string) map[string]any {
+		return map[string]any{"id": id, "name": id, "status": "planned", "procedure_id": procedureID, "created_at": base, "updated_at": base}
+	}
+	link := func(treatmentID, col, id string) map[string]any {
+		return map[string]any{"treatment_id": treatmentID, col: id}
+	}
+	conn.Tables["treatments"] = []map[string]any{row("t-2", "proc-1"), row("t-1", "proc-1"), row("t-9", "proc-2")}
+	conn.Tables["treatments__cohort_ids"] = []map[string]any{link("t-1", "cohort_id", "c-1"), link("t-9", "cohort_id", "c-9")}
+	conn.Tables["treatments__organism_ids"] = []map[string]any{link("t-2", "organism_id", "o-2"), link("t-2", "organism_id", "o-1")}
+	store := &Store{db: db, engine: domain.NewRulesEngine()}
+
+	got, err := store.ListTreatmentsByProcedure("proc-1")
+	if err != nil {
+		t.Fatalf("list treatments by procedure: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "t-1" || got[1].ID != "t-2" {
+		t.Fatalf("expected proc-1 treatments ordered by id, got %+v", got)
+	}
+	if len(conn.Queries) != 3 {
+		t.Fatalf("expected one query per table regardless of treatment count, got %d: %v", len(conn.Queries), conn.Queries)
+	}
+	if !reflect.DeepEqual(got[0].CohortIDs, []string{"c-1"}) || !reflect.DeepEqual(got[1].OrganismIDs, []string{"o-1", "o-2"}) {
+		t.Fatalf("expected join ids scoped to each treatment, got %+v", got)
+	}
+	if none, err := store.ListTreatmentsByProcedure("missing"); err != nil || len(none) != 0 {
+		t.Fatalf("expected no treatments for unknown procedure, got %+v (%v)", none, err)
+	}
+
+	conn.FailTables = map[string]bool{"treatments__organism_ids": true}
+	if _, err := store.ListTreatmentsByProcedure("proc-1"); err == nil {
+		t.Fatalf("expected failing join query to be returned")
+	}
+}
+
+func TestListSamplesByFacilityAndOrganismUseFilteredQueries(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	row := func(id, facilityID string, organismID, cohortID any) map[string]any {
+		return map[string]any{
+			"id":               id,
+			"identifier":       id,
+			"source_type":      "tissue",
+			"status":           "stored",
+			"storage_location": "freezer",
+			"assay_type":       "dna",
+			"facility_id":      facilityID,
+			"organism_id":      organismID,
+			"cohort_id":        cohortID,
+			"chain_of_custody": []byte(`[]`),
+			"attributes":       []byte(`{}`),
+			"collected_at":     base,
+			"collected_by":     "tech",
+			"created_at":       base,
+			"updated_at":       base,
+		}
+	}
+	conn.Tables["samples"] = []map[string]any{
+		row("s-org", "fac-1", "org-1", nil),
+		row("s-cohort", "fac-1", nil, "cohort-1"),
+		row("s-elsewhere", "fac-2", "org-1", nil),
+	}
+	store := &Store{db: db, engine: domain.NewRulesEngine()}
+
+	byFacility, err := store.ListSamplesByFacility("fac-1")
+	if err != nil {
+		t.Fatalf("list samples by facility: %v", err)
+	}
+	if len(byFacility) != 2 || byFacility[0].ID != "s-cohort" || byFacility[1].ID != "s-org" {
+		t.Fatalf("expected fac-1 samples including cohort-linked sample, got %+v", byFacility)
+	}
+	byOrganism, err := store.ListSamplesByOrganism("org-1")
+	if err != nil {
+		t.Fatalf("list samples by organism: %v", err)
+	}
+	if len(byOrganism) != 2 || byOrganism[0].ID != "s-elsewhere" || byOrganism[1].ID != "s-org" {
+		t.Fatalf("expected organism samples ordered by id without cohort-only sample, got %+v", byOrganism)
+	}
+
+	conn.FailTables = map[string]bool{"samples": true}
+	if _, err := store.ListSamplesByFacility("fac-1"); err == nil {
+		t.Fatalf("expected failing facility sample query to be returned")
+	}
+	if _, err := store.ListSamplesByOrganism("org-1"); err == nil {
+		t.Fatalf("expected failing organism sample query to be returned")
+	}
+}
+
+func TestListSamplesByFacilityAndOrganismNormalizeLikeImport(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	row := func(id, status string) map[string]any {
+		return map[string]any{
+			"id":               id,
+			"identifier":       id,
+			"source_type":      "tissue",
+			"status":           status,
+			"storage_location": "freezer",
+			"assay_type":       "dna",
+			"facility_id":      "fac-1",
+			"organism_id":      "org-1",
+			"cohort_id":        nil,
+			"chain_of_custody": []byte(`[]`),
+			"attributes":       []byte(`{}`),
+			"collected_at":     base,
+			"collected_by":     "tech",
+			"created_at":       base,
+			"updated_at":       base,
+		}
+	}
+	conn.Tables["samples"] = []map[string]any{
+		row("s-blank", ""),
+		row("s-lost", "lost"),
+		row("s-stored", "stored"),
+	}
+	store := &Store{db: db, engine: domain.NewRulesEngine()}
+
+	for name, list := range map[string]func(string) ([]domain.Sample, error){
+		"facility": store.ListSamplesByFacility,
+		"organism": store.ListSamplesByOrganism,
+	} {
+		id := map[string]string{"facility": "fac-1", "organism": "org-1"}[name]
+		got, err := list(id)
+		if err != nil {
+			t.Fatalf("%s: list samples: %v", name, err)
+		}
+		if len(got) != 2 || got[0].ID != "s-blank" || got[1].ID != "s-stored" {
+			t.Fatalf("%s: expected unsupported status to be skipped, got %+v", name, got)
+		}
+		if got[0].Status != domain.SampleStatusStored {
+			t.Fatalf("%s: expected blank status to default to stored, got %q", name, got[0].Status)
+		}
+	}
+}
+
+func TestProcedureChildIDsMatchMemoryDerivationAcrossRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	fixture := loadFixtureSnapshot(t)
+	var procedureID string
+	for id := range fixture.Procedures {
+		procedureID = id
+		break
+	}
+	for id, treatment := range fixture.Treatments {
+		extra := treatment
+		extra.ID = id + "-b"
+		extra.ProcedureID = procedureID
+		fixture.Treatments[extra.ID] = extra
+		break
+	}
+	for id, observation := range fixture.Observations {
+		extra := observation
+		extra.ID = id + "-b"
+		extra.ProcedureID = &procedureID
+		fixture.Observations[extra.ID] = extra
+		break
+	}
+
+	mem := memory.NewStore(domain.NewRulesEngine())
+	if err := mem.ImportState(fixture); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
+	expected := make(map[string]domain.Procedure)
+	for _, proc := range mem.ListProcedures() {
+		expected[proc.ID] = proc
+	}
+	if len(expected[procedureID].TreatmentIDs) < 2 || len(expected[procedureID].ObservationIDs) < 2 {
+		t.Fatalf("fixture procedure %s lacks derived children: %+v", procedureID, expected[procedureID])
+	}
+
+	assertMatches := func(label string, got []domain.Procedure) {
+		t.Helper()
+		if len(got) != len(expected) {
+			t.Fatalf("%s: expected %d procedures, got %d", label, len(expected), len(got))
+		}
+		for _, proc := range got {
+			want := expected[proc.ID]
+			if !reflect.DeepEqual(proc.TreatmentIDs, want.TreatmentIDs) || !reflect.DeepEqual(proc.ObservationIDs, want.ObservationIDs) {
+				t.Fatalf("%s: procedure %s children diverge: treatments %v observations %v, want %v %v", label, proc.ID, proc.TreatmentIDs, proc.ObservationIDs, want.TreatmentIDs, want.ObservationIDs)
+			}
+		}
+	}
+
+	db, _ := pgtu.NewStubDB()
+	if err := persistNormalized(ctx, db, fixture); err != nil {
+		t.Fatalf("persistNormalized: %v", err)
+	}
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	store, err := NewStore("", domain.NewRulesEngine())
+	restore()
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	assertMatches("postgres", store.ListProcedures())
+	exported := store.ExportState()
+	assertMatches("postgres export", mapValues(exported.Procedures))
+
+	reimportDB, _ := pgtu.NewStubDB()
+	restore = OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return reimportDB, nil })
+	reimported, err := NewStore("", domain.NewRulesEngine())
+	restore()
+	if err != nil {
+		t.Fatalf("NewStore reimport: %v", err)
+	}
+	if err := reimported.Import(ctx, exported); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	assertMatches("postgres reimport", reimported.ListProcedures())
+
+	var viewed domain.Procedure
+	if err := reimported.View(ctx, func(view domain.TransactionView) error {
+		viewed, _ = view.FindProcedure(procedureID)
+		return nil
+	}); err != nil {
+		t.Fatalf("View: %v", err)
+	}
+	if want := expected[procedureID]; !reflect.DeepEqual(viewed.TreatmentIDs, want.TreatmentIDs) || !reflect.DeepEqual(viewed.ObservationIDs, want.ObservationIDs) {
+		t.Fatalf("view: procedure children diverge: %+v", viewed)
+	}
+}
+
+func TestWithMaxConcurrentTransactionsQueuesAndHonoursCancellation(t *testing.T) {
+	db, _ := pgtu.NewStubDB()
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+	store, err := NewStore("", domain.NewRulesEngine(), WithMaxConcurrentTransactions(1))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	entered := make(chan struct{})
+	unblock := make(chan struct{})
+	firstDone := make(chan error, 1)
+	go func() {
+		_, err := store.RunInTransaction(context.Background(), func(domain.Transaction) error {
+			close(entered)
+			<-unblock
+			return nil
+		})
+		firstDone <- err
+	}()
+	<-entered
+	if got := store.InFlightTransactions(); got != 1 {
+		t.Fatalf("expected 1 in-flight transaction, got %d", got)
+	}
+
+	const queued = 3
+	var running, peak atomic.Int32
+	queuedDone := make(chan error, queued)
+	for i := 0; i < queued; i++ {
+		go func() {
+			_, err := store.RunInTransaction(context.Background(), func(domain.Transaction) error {
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				running.Add(-1)
+				return nil
+			})
+			queuedDone <- err
+		}()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() {
+		_, err := store.RunInTransaction(ctx, func(domain.Transaction) error {
+			t.Error("cancelled caller should not run")
+			return nil
+		})
+		cancelled <- err
+	}()
+	cancel()
+	select {
+	case err := <-cancelled:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled for queued caller, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("cancelled queued caller did not return promptly")
+	}
+	if got := store.InFlightTransactions(); got != 1 {
+		t.Fatalf("expected queued callers excluded from in-flight count, got %d", got)
+	}
+
+	close(unblock)
+	if err := <-firstDone; err != nil {
+		t.Fatalf("first transaction: %v", err)
+	}
+	for i := 0; i < queued; i++ {
+		if err := <-queuedDone; err != nil {
+			t.Fatalf("queued transaction: %v", err)
+		}
+	}
+	if peak.Load() != 1 {
+		t.Fatalf("expected queued transactions to run one at a time, peak %d", peak.Load())
+	}
+	if got := store.InFlightTransactions(); got != 0 {
+		t.Fatalf("expected no in-flight transactions after completion, got %d", got)
+	}
+}
+
+func TestActiveStrainAndLineCountsUseCountQueries(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+	store, err := NewStore("", domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	retired := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	conn.Tables["lines"] = []map[string]any{
+		{"id": "line-1", "deprecated_at": nil},
+		{"id": "line-2", "deprecated_at": retired},
+	}
+	conn.Tables["strains"] = []map[string]any{
+		{"id": "strain-1", "line_id": "line-1", "retired_at": nil},
+		{"id": "strain-2", "line_id": "line-1", "retired_at": nil},
+		{"id": "strain-3", "line_id": "line-2", "retired_at": nil},
+	}
+	if got, err := store.ActiveStrainCount("line-1"); err != nil || got != 2 {
+		t.Fatalf("expected 2 active strains, got %d (%v)", got, err)
+	}
+	if got, err := store.ActiveLineCount(); err != nil || got != 1 {
+		t.Fatalf("expected 1 active line, got %d (%v)", got, err)
+	}
+
+	conn.Tables["strains"][1]["retired_at"] = retired
+	if got, err := store.ActiveStrainCount("line-1"); err != nil || got != 1 {
+		t.Fatalf("expected 1 active strain after retirement, got %d (%v)", got, err)
+	}
+
+	conn.FailTables = map[string]bool{"strains": true, "lines": true}
+	store.cache = memory.Snapshot{
+		Lines:   map[string]domain.Line{"line-1": {Line: entitymodel.Line{ID: "line-1"}}},
+		Strains: map[string]domain.Strain{"strain-1": {Strain: entitymodel.Strain{ID: "strain-1", LineID: "line-1"}}},
+	}
+	if _, err := store.ActiveStrainCount("line-1"); err == nil {
+		t.Fatalf("expected a failed strain count to return its error rather than a cached count")
+	}
+	if _, err := store.ActiveLineCount(); err == nil {
+		t.Fatalf("expected a failed line count to return its error rather than a cached count")
+	}
+}
+
+func TestListObservationsBetweenUsesRecordedAtRange(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	row := func(id string, recordedAt time.Time) map[string]any {
+		return map[string]any{
+			"id":          id,
+			"observer":    "tech",
+			"recorded_at": recordedAt,
+			"data":        []byte(`{}`),
+			"created_at":  base,
+			"updated_at":  base,
+		}
+	}
+	conn.Tables["observations"] = []map[string]any{
+		row("obs-before", base.Add(-time.Minute)),
+		row("obs-late", base.Add(time.Hour)),
+		row("obs-start", base),
+		row("obs-end", base.Add(2*time.Hour)),
+	}
+	store := &Store{db: db, engine: domain.NewRulesEngine()}
+
+	got, err := store.ListObservationsBetween(base, base.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("list observations between: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "obs-start" || got[1].ID != "obs-late" {
+		t.Fatalf("expected half-open range ordered by recorded_at, got %+v", got)
+	}
+
+	conn.FailTables = map[string]bool{"observations": true}
+	if _, err := store.ListObservationsBetween(base, base.Add(2*time.Hour)); err == nil {
+		t.Fatalf("expected failing range query to be returned")
+	}
+}
+
+func TestGetStrainAndLineLoadSingleRows(t *testing.T) {
+	ctx := context.Background()
+	db, conn := pgtu.NewStubDB()
+	fixture := loadFixtureSnapshot(t)
+	if err := persistNormalized(ctx, db, fixture); err != nil {
+		t.Fatalf("seed fixture: %v", err)
+	}
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+	store, err := NewStore("", domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	var strainID, lineID, otherID string
+	for id, strain := range fixture.Strains {
+		strainID, lineID = id, strain.LineID
+	}
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		other, err := tx.CreateStrain(domain.Strain{Strain: entitymodel.Strain{Code: "S-OTHER", Name: "Other", LineID: lineID}})
+		otherID = other.ID
+		return err
+	}); err != nil {
+		t.Fatalf("create strain: %v", err)
+	}
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdateStrain(strainID, func(s *domain.Strain) error {
+			s.Name = "Renamed"
+			return nil
+		})
+		return err
+	}); err != nil {
+		t.Fatalf("update strain: %v", err)
+	}
+
+	conn.Queries = nil
+	strain, ok := store.GetStrain(strainID)
+	if !ok || strain.Name != "Renamed" {
+		t.Fatalf("expected renamed strain, got %+v (%v)", strain, ok)
+	}
+	if want := []string{selectStrainByIDSQL, selectStrainMarkersByIDSQL}; !reflect.DeepEqual(conn.Queries, want) {
+		t.Fatalf("expected only single-strain queries, got %v", conn.Queries)
+	}
+
+	// Rows changed behind the store's back refresh only the requested entry.
+	for _, row := range conn.Tables["strains"] {
+		row["name"] = "External"
+	}
+	if strain, _ := store.GetStrain(strainID); strain.Name != "External" {
+		t.Fatalf("expected strain reloaded from its row, got %q", strain.Name)
+	}
+	store.mu.Lock()
+	cachedStrain, cachedOther := store.cache.Strains[strainID], store.cache.Strains[otherID]
+	store.mu.Unlock()
+	if cachedStrain.Name != "External" || cachedOther.Name != "Other" {
+		t.Fatalf("expected only %s refreshed in cache, got %q and %q", strainID, cachedStrain.Name, cachedOther.Name)
+	}
+
+	conn.Queries = nil
+	line, ok := store.GetLine(lineID)
+	if !ok || line.ID != lineID || len(line.GenotypeMarkerIDs) == 0 {
+		t.Fatalf("expected line %s with markers, got %+v (%v)", lineID, line, ok)
+	}
+	if want := []string{selectLineByIDSQL, selectLineMarkersByIDSQL}; !reflect.DeepEqual(conn.Queries, want) {
+		t.Fatalf("expected only single-line queries, got %v", conn.Queries)
+	}
+
+	conn.Tables["strains"] = nil
+	if _, ok := store.GetStrain(otherID); ok {
+		t.Fatalf("expected deleted strain row to be reported missing")
+	}
+	store.mu.Lock()
+	_, stillCached := store.cache.Strains[otherID]
+	store.mu.Unlock()
+	if stillCached {
+		t.Fatalf("expected missing strain to be dropped from the cache")
+	}
+
+	conn.FailBegin = true
+	if _, _, err := store.GetStrainByID(strainID); err == nil {
+		t.Fatalf("expected GetStrainByID to return the read error")
+	}
+	if _, _, err := store.GetLineByID(lineID); err == nil {
+		t.Fatalf("expected GetLineByID to return the read error")
+	}
+	if cached, ok := store.GetStrain(strainID); !ok || cached.Name != "External" {
+		t.Fatalf("expected failed read to serve the cached strain, got %+v (%v)", cached, ok)
+	}
+	if cached, ok := store.GetLine(lineID); !ok || cached.ID != lineID {
+		t.Fatalf("expected failed read to serve the cached line, got %+v (%v)", cached, ok)
+	}
+	conn.FailBegin = false
+
+	conn.FailTables = map[string]bool{"strains": true, "lines": true}
+	if _, _, err := store.GetStrainByID(strainID); err == nil || !strings.Contains(err.Error(), "query fail") {
+		t.Fatalf("expected failing strain query to be returned, got %v", err)
+	}
+	if _, _, err := store.GetLineByID(lineID); err == nil || !strings.Contains(err.Error(), "query fail") {
+		t.Fatalf("expected failing line query to be returned, got %v", err)
+	}
+}
+
+func TestTargetedReadsReturnQueryErrors(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	conn.FailQuery = true
+	store := &Store{db: db, engine: domain.NewRulesEngine(), cache: memory.Snapshot{
+		Lines:    map[string]domain.Line{"line": {Line: entitymodel.Line{ID: "line", GenotypeMarkerIDs: []string{"gm"}}}},
+		Strains:  map[string]domain.Strain{"strain": {Strain: entitymodel.Strain{ID: "strain", LineID: "line"}}},
+		Breeding: map[string]domain.BreedingUnit{"bu": {BreedingUnit: entitymodel.BreedingUnit{ID: "bu", FemaleIDs: []string{"f"}}}},
+		Supplies: map[string]domain.SupplyItem{"sup": {SupplyItem: entitymodel.SupplyItem{ID: "sup", FacilityIDs: []string{"fac"}}}},
+	}}
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	reads := map[string]func() error{
+		"GetLineByID":                func() error { _, _, err := store.GetLineByID("line"); return err },
+		"GetStrainByID":              func() error { _, _, err := store.GetStrainByID("strain"); return err },
+		"GetBreedingUnitByID":        func() error { _, _, err := store.GetBreedingUnitByID("bu"); return err },
+		"GetSupplyItemByID":          func() error { _, _, err := store.GetSupplyItemByID("sup"); return err },
+		"ListTreatmentsByProcedure":  func() error { _, err := store.ListTreatmentsByProcedure("proc"); return err },
+		"ListObservationsByOrganism": func() error { _, err := store.ListObservationsByOrganism("org"); return err },
+		"ListObservationsByCohort":   func() error { _, err := store.ListObservationsByCohort("cohort"); return err },
+		"ListObservationsBetween":    func() error { _, err := store.ListObservationsBetween(base, base.Add(time.Hour)); return err },
+		"ListSamplesByFacility":      func() error { _, err := store.ListSamplesByFacility("fac"); return err },
+		"ListSamplesByOrganism":      func() error { _, err := store.ListSamplesByOrganism("org"); return err },
+		"ActiveStrainCount":          func() error { _, err := store.ActiveStrainCount("line"); return err },
+		"ActiveLineCount":            func() error { _, err := store.ActiveLineCount(); return err },
+		"GetFacilityByCode":          func() error { _, _, err := store.GetFacilityByCode("FAC"); return err },
+		"GetProtocolByCode":          func() error { _, _, err := store.GetProtocolByCode("PROT"); return err },
+		"GetLineByCode":              func() error { _, _, err := store.GetLineByCode("LINE"); return err },
+		"GetStrainByCode":            func() error { _, _, err := store.GetStrainByCode("line", "STRAIN"); return err },
+	}
+	for name, read := range reads {
+		if err := read(); err == nil || !strings.Contains(err.Error(), "query fail") {
+			t.Fatalf("%s: expected the query error, got %v", name, err)
+		}
+	}
+
+	// The PersistentStore getters have no error result and serve the cache.
+	if line, ok := store.GetLine("line"); !ok || line.GenotypeMarkerIDs[0] != "gm" {
+		t.Fatalf("expected cached line, got %+v (%v)", line, ok)
+	}
+	if strain, ok := store.GetStrain("strain"); !ok || strain.LineID != "line" {
+		t.Fatalf("expected cached strain, got %+v (%v)", strain, ok)
+	}
+	if unit, ok := store.GetBreedingUnit("bu"); !ok || unit.FemaleIDs[0] != "f" {
+		t.Fatalf("expected cached breeding unit, got %+v (%v)", unit, ok)
+	}
+	if item, ok := store.GetSupplyItem("sup"); !ok || item.FacilityIDs[0] != "fac" {
+		t.Fatalf("expected cached supply item, got %+v (%v)", item, ok)
+	}
+	if _, ok := store.GetLine("missing"); ok {
+		t.Fatalf("expected uncached line to be reported missing")
+	}
+}
+
+func TestNaturalKeyLookupsQueryByCode(t *testing.T) {
+	ctx := context.Background()
+	db, conn := pgtu.NewStubDB()
+	fixture := loadFixtureSnapshot(t)
+	if err := persistNormalized(ctx, db, fixture); err != nil {
+		t.Fatalf("seed fixture: %v", err)
+	}
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+	store, err := NewStore("", domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	var facility domain.Facility
+	for _, f := range fixture.Facilities {
+		facility = f
+	}
+	var protocol domain.Protocol
+	for _, p := range fixture.Protocols {
+		protocol = p
+	}
+	var strain domain.Strain
+	for _, s := range fixture.Strains {
+		strain = s
+	}
+	line := fixture.Lines[strain.LineID]
+
+	conn.Queries = nil
+	got, ok, err := store.GetFacilityByCode(facility.Code)
+	if err != nil || !ok || got.ID != facility.ID || len(got.ProjectIDs) == 0 {
+		t.Fatalf("expected facility %s with projects, got %+v (%v)", facility.ID, got, ok)
+	}
+	if want := []string{selectFacilityByCodeSQL, selectFacilityProjectsByIDSQL}; !reflect.DeepEqual(conn.Queries, want) {
+		t.Fatalf("expected facility lookup by code, got %v", conn.Queries)
+	}
+	if _, ok, err := store.GetFacilityByCode("missing"); ok || err != nil {
+		t.Fatalf("expected unknown facility code to miss")
+	}
+
+	conn.Queries = nil
+	if got, ok, err := store.GetProtocolByCode(protocol.Code); err != nil || !ok || got.ID != protocol.ID {
+		t.Fatalf("expected protocol %s, got %+v (%v, %v)", protocol.ID, got, ok, err)
+	}
+	if want := []string{selectProtocolByCodeSQL}; !reflect.DeepEqual(conn.Queries, want) {
+		t.Fatalf("expected protocol lookup by code, got %v", conn.Queries)
+	}
+	if missing, ok, err := store.GetProtocolByCode("missing"); err != nil || ok || missing.ID != "" {
+		t.Fatalf("expected unknown protocol code to return the zero value, got %+v (%v)", missing, ok)
+	}
+
+	if got, ok, err := store.GetLineByCode(line.Code); err != nil || !ok || got.ID != line.ID || len(got.GenotypeMarkerIDs) == 0 {
+		t.Fatalf("expected line %s with markers, got %+v (%v)", line.ID, got, ok)
+	}
+	if got, ok, err := store.GetStrainByCode(strain.LineID, strain.Code); err != nil || !ok || got.ID != strain.ID {
+		t.Fatalf("expected strain %s, got %+v (%v, %v)", strain.ID, got, ok, err)
+	}
+	if _, ok, err := store.GetStrainByCode("other-line", strain.Code); ok || err != nil {
+		t.Fatalf("expected strain code to be scoped to its line")
+	}
+
+	conn.FailBegin = true
+	if _, ok, err := store.GetFacilityByCode(facility.Code); err == nil || ok {
+		t.Fatalf("expected a failed facility lookup to return its error, got %v (%v)", ok, err)
+	}
+	if _, ok, err := store.GetLineByCode(line.Code); err == nil || ok {
+		t.Fatalf("expected a failed line lookup to return its error, got %v (%v)", ok, err)
+	}
+	if _, ok, err := store.GetStrainByCode(strain.LineID, strain.Code); err == nil || ok {
+		t.Fatalf("expected a failed strain lookup to return its error, got %v (%v)", ok, err)
+	}
+	conn.FailBegin = false
+	conn.FailTables = map[string]bool{"protocols": true}
+	if _, ok, err := store.GetProtocolByCode(protocol.Code); err == nil || ok {
+		t.Fatalf("expected a failed protocol lookup to return its error, got %v (%v)", ok, err)
+	}
+}
+
+// changeLogFromMemory runs fn against a memory store seeded with fixture and
+// returns the change log it commits along with the resulting snapshot.
+func changeLogFromMemory(t *testing.T, fixture memory.Snapshot, fn func(domain.Transaction) error) ([]domain.Change, memory.Snapshot) {
+	t.Helper()
+	mem := memory.NewStore(nil)
+	if err := mem.ImportState(fixture); err != nil {
+		t.Fatalf("import fixture: %v", err)
+	}
+	var changes []domain.Change
+	mem.AddCommitObserver(domain.ChangeSinkFunc(func(_ context.Context, batch []domain.Change) error {
+		changes = batch
+		return nil
+	}))
+	if _, err := mem.RunInTransaction(context.Background(), fn); err != nil {
+		t.Fatalf("memory transaction: %v", err)
+	}
+	return changes, mem.ExportState()
+}
+
+func TestApplyChangeLogWritesCreateUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	db, _ := pgtu.NewStubDB()
+	fixture := loadFixtureSnapshot(t)
+	if err := persistNormalized(ctx, db, fixture); err != nil {
+		t.Fatalf("seed fixture: %v", err)
+	}
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+	store, err := NewStore("", domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	var lineID, treatmentID, createdID string
+	for id := range fixture.Lines {
+		lineID = id
+	}
+	for id := range fixture.Treatments {
+		treatmentID = id
+	}
+	changes, want := changeLogFromMemory(t, fixture, func(tx domain.Transaction) error {
+		created, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "FAC-LOG", Name: "Log Facility"}})
+		if err != nil {
+			return err
+		}
+		createdID = created.ID
+		if _, err := tx.UpdateFacility(created.ID, func(f *domain.Facility) error {
+			f.Name = "Log Facility Renamed"
+			return nil
+		}); err != nil {
+			return err
+		}
+		if _, err := tx.UpdateLine(lineID, func(l *domain.Line) error {
+			l.Name = "Line From Log"
+			return nil
+		}); err != nil {
+			return err
+		}
+		return tx.DeleteTreatment(treatmentID)
+	})
+
+	if err := store.ApplyChangeLog(ctx, changes); err != nil {
+		t.Fatalf("ApplyChangeLog: %v", err)
+	}
+	got, err := loadNormalizedSnapshot(ctx, db)
+	if err != nil {
+		t.Fatalf("load snapshot: %v", err)
+	}
+	if facility, ok := got.Facilities[createdID]; !ok || facility.Name != "Log Facility Renamed" || facility.Code != "FAC-LOG" {
+		t.Fatalf("expected created facility with its final name, got %+v (%v)", facility, ok)
+	}
+	if got.Lines[lineID].Name != want.Lines[lineID].Name {
+		t.Fatalf("expected updated line name %q, got %q", want.Lines[lineID].Name, got.Lines[lineID].Name)
+	}
+	if _, ok := got.Treatments[treatmentID]; ok {
+		t.Fatalf("expected treatment %s deleted", treatmentID)
+	}
+	if len(got.Facilities) != len(want.Facilities) || len(got.Lines) != len(want.Lines) || len(got.Treatments) != len(want.Treatments) {
+		t.Fatalf("expected database to match the memory store after the change log")
+	}
+	store.mu.Lock()
+	cached := store.cache
+	store.mu.Unlock()
+	if _, ok := cached.Treatments[treatmentID]; ok || cached.Facilities[createdID].Name != "Log Facility Renamed" {
+		t.Fatalf("expected cache patched with the change log")
+	}
+}
+
+func TestApplyChangeLogRejectsUndecodableChanges(t *testing.T) {
+	ctx := context.Background()
+	db, conn := pgtu.NewStubDB()
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+	store, err := NewStore("", domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	payload := domain.NewChangePayload([]byte(`{"id":"x"}`))
+	cases := map[string]domain.Change{
+		"unknown entity":   {Entity: "widget", Action: domain.ActionCreate, After: payload},
+		"missing after":    {Entity: domain.EntityFacility, Action: domain.ActionUpdate},
+		"missing id":       {Entity: domain.EntityFacility, Action: domain.ActionDelete, Before: domain.NewChangePayload([]byte(`{}`))},
+		"malformed record": {Entity: domain.EntityFacility, Action: domain.ActionCreate, After: domain.NewChangePayload([]byte(`{"id":"x","name":7}`))},
+	}
+	for name, change := range cases {
+		conn.Queries = nil
+		if err := store.ApplyChangeLog(ctx, []domain.Change{change}); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+		if len(conn.Queries) != 0 {
+			t.Fatalf("%s: expected no statements before the log is decoded, got %v", name, conn.Queries)
+		}
+	}
+}
+
+func TestChangeLogDeltaFoldsRecordHistory(t *testing.T) {
+	facility := func(id, name string) domain.ChangePayload {
+		payload, err := domain.NewChangePayloadFromValue(domain.Facility{Facility: entitymodel.Facility{ID: id, Name: name}})
+		if err != nil {
+			t.Fatalf("payload: %v", err)
+		}
+		return payload
+	}
+	project, err := domain.NewChangePayloadFromValue(domain.Project{Project: entitymodel.Project{ID: "p1", SupplyItemIDs: []string{"s1", "s2"}}})
+	if err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	supply, err := domain.NewChangePayloadFromValue(domain.SupplyItem{SupplyItem: entitymodel.SupplyItem{ID: "s1"}})
+	if err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	d, err := changeLogDelta([]domain.Change{
+		{Entity: domain.EntityFacility, Action: domain.ActionCreate, After: facility("f1", "first")},
+		{Entity: domain.EntityFacility, Action: domain.ActionUpdate, After: facility("f1", "second")},
+		{Entity: domain.EntityFacility, Action: domain.ActionCreate, After: facility("f2", "gone")},
+		{Entity: domain.EntityFacility, Action: domain.ActionDelete, Before: facility("f2", "gone")},
+		{Entity: domain.EntityFacility, Action: domain.ActionDelete, Before: facility("f3", "old")},
+		{Entity: domain.EntityFacility, Action: domain.ActionCreate, After: facility("f3", "reused")},
+		{Entity: domain.EntityProject, Action: domain.ActionUpdate, After: project},
+		{Entity: domain.EntitySupplyItem, Action: domain.ActionDelete, Before: supply},
+	})
+	if err != nil {
+		t.Fatalf("changeLogDelta: %v", err)
+	}
+	if d.facilities.created["f1"].Name != "second" || len(d.facilities.updated) != 0 {
+		t.Fatalf("expected created facility to keep its last state, got %+v", d.facilities)
+	}
+	if !reflect.DeepEqual(d.facilities.deleted, []string{"f2"}) || d.facilities.created["f3"].Name != "reused" {
+		t.Fatalf("expected deletes to drop earlier upserts and later creates to win, got %+v", d.facilities)
+	}
+	if got := d.projects.updated["p1"].SupplyItemIDs; !reflect.DeepEqual(got, []string{"s2"}) {
+		t.Fatalf("expected links to deleted supply items dropped, got %v", got)
+	}
+}
+
+func TestDetachCohortsClearsOnlyRecordsTheDeltaDetaches(t *testing.T) {
+	cohortID := "cohort"
+	d := newSnapshotDelta()
+	d.cohorts.deleted = []string{cohortID}
+	d.organisms.updated["org-detached"] = domain.Organism{Organism: entitymodel.Organism{ID: "org-detached"}}
+	d.organisms.updated["org-kept"] = domain.Organism{Organism: entitymodel.Organism{ID: "org-kept", CohortID: &cohortID}}
+	d.samples.updated["sample-detached"] = domain.Sample{Sample: entitymodel.Sample{ID: "sample-detached"}}
+	d.treatments.updated["treatment-detached"] = domain.Treatment{Treatment: entitymodel.Treatment{ID: "treatment-detached", CohortIDs: []string{"other"}}}
+	d.treatments.updated["treatment-kept"] = domain.Treatment{Treatment: entitymodel.Treatment{ID: "treatment-kept", CohortIDs: []string{cohortID}}}
+
+	rec := &recordingExec{}
+	if err := detachCohorts(context.Background(), rec, d); err != nil {
+		t.Fatalf("detachCohorts: %v", err)
+	}
+	want := []string{detachOrganismCohortSQL, detachSampleCohortSQL, detachTreatmentCohortSQL}
+	if !reflect.DeepEqual(rec.Execs, want) {
+		t.Fatalf("expected %v, got %v", want, rec.Execs)
+	}
+
+	rec = &recordingExec{}
+	if err := detachCohorts(context.Background(), rec, diffSnapshots(memory.Snapshot{}, memory.Snapshot{})); err != nil {
+		t.Fatalf("detachCohorts without deletes: %v", err)
+	}
+	if len(rec.Execs) != 0 {
+		t.Fatalf("expected no detach without cohort deletes, got %v", rec.Execs)
+	}
+	if err := detachCohorts(context.Background(), failingExec{}, d); err == nil {
+		t.Fatalf("expected detach error to propagate")
+	}
+}
+
+func TestCommitObserversNotifiedAfterCommit(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+	store, err := NewStore("ignored", domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	var batches [][]domain.Change
+	store.AddCommitObserver(nil)
+	store.AddCommitObserver(domain.ChangeSinkFunc(func(_ context.Context, changes []domain.Change) error {
+		if len(conn.Tables["organisms"]) != 1 {
+			t.Fatalf("expected observer to run after the organism row committed")
+		}
+		batches = append(batches, changes)
+		return errors.New("observer failure is discarded")
+	}))
+	ctx := context.Background()
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus"}})
+		return err
+	}); err != nil {
+		t.Fatalf("expected observer error to be discarded, got %v", err)
+	}
+	if len(batches) != 1 || len(batches[0]) != 1 || batches[0][0].Entity != domain.EntityOrganism || batches[0][0].Action != domain.ActionCreate {
+		t.Fatalf("expected one batch with the committed create, got %+v", batches)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(domain.Transaction) error { return errors.New("rolled back") }); err == nil {
+		t.Fatalf("expected user error")
+	}
+	if _, err := store.RunInTransaction(ctx, func(domain.Transaction) error { return nil }); err != nil {
+		t.Fatalf("empty transaction: %v", err)
+	}
+	if len(batches) != 1 {
+		t.Fatalf("expected rolled back and empty transactions not to notify, got %d batches", len(batches))
+	}
+}
+
+func TestWithAttributeLimitsRejectsDeepPayloads(t *testing.T) {
+	db, _ := pgtu.NewStubDB()
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+	store, err := NewStore("ignored", domain.NewRulesEngine(), WithAttributeLimits(2, 0))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	create := func(attrs map[string]any) error {
+		_, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+			organism := domain.Organism{Organism: entitymodel.Organism{Name: "Specimen", Species: "Danio rerio"}}
+			if err := organism.SetCoreAttributes(attrs); err != nil {
+				return err
+			}
+			_, err := tx.CreateOrganism(organism)
+			return err
+		})
+		return err
+	}
+	if err := create(map[string]any{"child": map[string]any{"leaf": true}}); err != nil {
+		t.Fatalf("expected payload within limits to be stored: %v", err)
+	}
+	var tooComplex memory.ErrAttributesTooComplex
+	if err := create(map[string]any{"child": map[string]any{"grandchild": map[string]any{"leaf": true}}}); !errors.As(err, &tooComplex) || tooComplex.MaxDepth != 2 {
+		t.Fatalf("expected ErrAttributesTooComplex, got %v", err)
+	}
+	if got := len(store.ListOrganisms()); got != 1 {
+		t.Fatalf("expected rejected organism not to be persisted, got %d organisms", got)
+	}
+}
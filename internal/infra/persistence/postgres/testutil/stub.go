@@ -14,11 +14,13 @@ import (
 // StubConn records normalized statements for the postgres store during tests.
 type StubConn struct {
 	Execs      []string
+	Queries    []string
 	Tables     map[string][]map[string]any
 	FailExec   bool
 	FailBegin  bool
 	RowsErr    error
 	FailTables map[string]bool
+	FailQuery  bool
 	FailCommit bool
 }
 
@@ -131,7 +133,11 @@ func (c *StubConn) ExecContext(_ context.Context, query string, args []driver.Na
 }
 
 // QueryContext implements driver.QueryerContext.
-func (c *StubConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+func (c *StubConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.Queries = append(c.Queries, query)
+	if c.FailQuery {
+		return nil, fmt.Errorf("query fail")
+	}
 	if c.Tables == nil {
 		c.Tables = make(map[string][]map[string]any)
 	}
@@ -143,8 +149,24 @@ func (c *StubConn) QueryContext(_ context.Context, query string, _ []driver.Name
 		return nil, fmt.Errorf("query fail for %s", table)
 	}
 	tableRows := c.Tables[table]
+	if len(cols) == 1 && cols[0] == "count(*)" {
+		return countRows(query, cols, tableRows, args, c.RowsErr)
+	}
+	if join, ok := parseSemiJoin(query); ok {
+		if len(args) < 1 {
+			return nil, fmt.Errorf("missing args for select %s", table)
+		}
+		tableRows = join.apply(tableRows, c.Tables[join.subTable], args[0].Value)
+	}
+	filters, filtered := parseSelectFilter(query)
+	if filtered && len(args) < len(filters) {
+		return nil, fmt.Errorf("missing args for select %s", table)
+	}
 	values := make([][]driver.Value, 0, len(tableRows))
 	for _, row := range tableRows {
+		if filtered && !matchFilters(filters, row, args) {
+			continue
+		}
 		vals := make([]driver.Value, len(cols))
 		for i, col := range cols {
 			vals[i] = row[col]
@@ -251,6 +273,130 @@ func parseSelect(query string) (string, []string, error) {
 	return strings.ToLower(table), splitColumns(cols), nil
 }
 
+type selectFilter struct {
+	col string
+	op  string
+}
+
+// parseSelectFilter recognises a trailing WHERE clause made of AND-ed
+// `col = $N`, `col >= $N`, or `col < $N` predicates, bound to arguments in
+// order.
+func parseSelectFilter(query string) ([]selectFilter, bool) {
+	lower := strings.ToLower(query)
+	whereIdx := strings.Index(lower, " where ")
+	if whereIdx == -1 {
+		return nil, false
+	}
+	var filters []selectFilter
+	for i, term := range strings.Split(lower[whereIdx+len(" where "):], " and ") {
+		predicate := strings.Fields(term)
+		if len(predicate) != 3 || predicate[2] != fmt.Sprintf("$%d", i+1) {
+			return nil, false
+		}
+		switch predicate[1] {
+		case "=", ">=", "<":
+			filters = append(filters, selectFilter{col: predicate[0], op: predicate[1]})
+		default:
+			return nil, false
+		}
+	}
+	return filters, true
+}
+
+// semiJoin is a `col IN (SELECT subCol FROM subTable WHERE subFilter = $1)`
+// predicate.
+type semiJoin struct {
+	col, subCol, subTable, subFilter string
+}
+
+// parseSemiJoin recognises a WHERE clause made of a single semiJoin.
+func parseSemiJoin(query string) (semiJoin, bool) {
+	lower := strings.ToLower(query)
+	whereIdx := strings.Index(lower, " where ")
+	if whereIdx == -1 {
+		return semiJoin{}, false
+	}
+	clause := strings.Replace(lower[whereIdx+len(" where "):], "(", "( ", 1)
+	fields := strings.Fields(clause)
+	if len(fields) != 11 || fields[1] != "in" || fields[2] != "(" || fields[3] != "select" || fields[5] != "from" || fields[7] != "where" || fields[9] != "=" || fields[10] != "$1)" {
+		return semiJoin{}, false
+	}
+	return semiJoin{col: fields[0], subCol: fields[4], subTable: fields[6], subFilter: fields[8]}, true
+}
+
+// apply keeps the rows whose col matches subCol of a subRows row whose
+// subFilter equals arg.
+func (j semiJoin) apply(rows, subRows []map[string]any, arg any) []map[string]any {
+	keys := make(map[any]struct{})
+	for _, row := range subRows {
+		if row[j.subFilter] == arg {
+			keys[row[j.subCol]] = struct{}{}
+		}
+	}
+	var out []map[string]any
+	for _, row := range rows {
+		if _, ok := keys[row[j.col]]; ok {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+func matchFilters(filters []selectFilter, row map[string]any, args []driver.NamedValue) bool {
+	for i, f := range filters {
+		value, arg := row[f.col], args[i].Value
+		switch f.op {
+		case "=":
+			if value != arg {
+				return false
+			}
+		case ">=", "<":
+			at, ok := value.(time.Time)
+			bound, boundOK := arg.(time.Time)
+			if !ok || !boundOK {
+				return false
+			}
+			if (f.op == ">=" && at.Before(bound)) || (f.op == "<" && !at.Before(bound)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// countRows answers `SELECT COUNT(*) FROM t [WHERE ...]` where the predicate
+// is an AND of `col = $N` and `col IS NULL` terms.
+func countRows(query string, cols []string, tableRows []map[string]any, args []driver.NamedValue, rowsErr error) (driver.Rows, error) {
+	lower := strings.ToLower(query)
+	var terms []string
+	if whereIdx := strings.Index(lower, " where "); whereIdx != -1 {
+		terms = strings.Split(lower[whereIdx+len(" where "):], " and ")
+	}
+	var count int64
+	for _, row := range tableRows {
+		matched := true
+		for _, term := range terms {
+			fields := strings.Fields(term)
+			switch {
+			case len(fields) == 3 && fields[1] == "is" && fields[2] == "null":
+				matched = matched && row[fields[0]] == nil
+			case len(fields) == 3 && fields[1] == "=" && strings.HasPrefix(fields[2], "$"):
+				var idx int
+				if _, err := fmt.Sscanf(fields[2], "$%d", &idx); err != nil || idx < 1 || idx > len(args) {
+					return nil, fmt.Errorf("cannot bind count predicate: %s", term)
+				}
+				matched = matched && row[fields[0]] == args[idx-1].Value
+			default:
+				return nil, fmt.Errorf("cannot parse count predicate: %s", term)
+			}
+		}
+		if matched {
+			count++
+		}
+	}
+	return &stubRows{cols: cols, rows: [][]driver.Value{{count}}, err: rowsErr}, nil
+}
+
 func splitColumns(raw string) []string {
 	parts := strings.Split(raw, ",")
 	out := make([]string, 0, len(parts))
@@ -35,6 +35,9 @@ func TestStubDBStoresAndQueriesRows(t *testing.T) {
 	if err != nil {
 		t.Fatalf("QueryContext: %v", err)
 	}
+	if len(conn.Queries) != 1 || conn.Queries[0] != "select id, code from facilities" {
+		t.Fatalf("expected query to be recorded, got %v", conn.Queries)
+	}
 	defer func() { _ = rows.Close() }()
 
 	dest := make([]driver.Value, 2)
@@ -45,3 +48,61 @@ func TestStubDBStoresAndQueriesRows(t *testing.T) {
 		t.Fatalf("unexpected row values: %v", dest)
 	}
 }
+
+func TestStubDBFiltersSingleColumnPredicate(t *testing.T) {
+	ctx := context.Background()
+	_, conn := NewStubDB()
+	conn.Tables["observations"] = []map[string]any{
+		{"id": "obs-1", "organism_id": "org-1"},
+		{"id": "obs-2", "organism_id": "org-2"},
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT id FROM observations WHERE organism_id = $1", []driver.NamedValue{{Value: "org-2"}})
+	if err != nil {
+		t.Fatalf("QueryContext: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil || dest[0] != "obs-2" {
+		t.Fatalf("expected filtered row obs-2, got %v err=%v", dest, err)
+	}
+	if err := rows.Next(dest); err == nil {
+		t.Fatalf("expected filter to exclude non-matching rows")
+	}
+
+	if _, err := conn.QueryContext(ctx, "SELECT id FROM observations WHERE organism_id = $1", nil); err == nil {
+		t.Fatalf("expected missing filter argument to fail")
+	}
+}
+
+func TestStubDBCountsWithPredicates(t *testing.T) {
+	ctx := context.Background()
+	_, conn := NewStubDB()
+	conn.Tables["strains"] = []map[string]any{
+		{"id": "s-1", "line_id": "line-1", "retired_at": nil},
+		{"id": "s-2", "line_id": "line-1", "retired_at": "2024-01-01"},
+		{"id": "s-3", "line_id": "line-2", "retired_at": nil},
+	}
+	count := func(query string, args ...driver.NamedValue) int64 {
+		t.Helper()
+		rows, err := conn.QueryContext(ctx, query, args)
+		if err != nil {
+			t.Fatalf("QueryContext %q: %v", query, err)
+		}
+		defer func() { _ = rows.Close() }()
+		dest := make([]driver.Value, 1)
+		if err := rows.Next(dest); err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		return dest[0].(int64)
+	}
+	if got := count("SELECT COUNT(*) FROM strains"); got != 3 {
+		t.Fatalf("expected 3 strains, got %d", got)
+	}
+	if got := count("SELECT COUNT(*) FROM strains WHERE line_id = $1 AND retired_at IS NULL", driver.NamedValue{Value: "line-1"}); got != 1 {
+		t.Fatalf("expected 1 active line-1 strain, got %d", got)
+	}
+	if _, err := conn.QueryContext(ctx, "SELECT COUNT(*) FROM strains WHERE line_id > $1", []driver.NamedValue{{Value: "x"}}); err == nil {
+		t.Fatalf("expected unsupported predicate error")
+	}
+}
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"colonycore/internal/infra/persistence/memory"
+	"colonycore/pkg/domain"
+)
+
+// WithVerifyOnRead re-validates records returned by GetVerified and
+// ListVerified against the write-time invariants of the memory store. See
+// memory.WithVerifyOnRead.
+func WithVerifyOnRead(enabled bool) Option {
+	return func(s *Store) {
+		s.verifyOnRead = enabled
+	}
+}
+
+// GetVerified implements domain.VerifiedReader. Unlike GetOrganism and the
+// other accessors it reads the database directly and returns load errors
+// instead of falling back to the cached snapshot. Organisms, housing units,
+// facilities, treatments, observations, samples, permits, supply items, lines,
+// and strains are read with ID-scoped queries; other entities load the full
+// snapshot.
+func (s *Store) GetVerified(entity domain.EntityType, id string) (any, bool, error) {
+	records, err := s.loadRecord(entity, id)
+	if err != nil {
+		return nil, false, err
+	}
+	record, ok := records[id]
+	if !ok {
+		return nil, false, nil
+	}
+	if !s.verifyOnRead {
+		return record, true, nil
+	}
+	return record, true, memory.VerifyRecord(entity, id, record)
+}
+
+// ListVerified implements domain.VerifiedReader. Records are returned in ID
+// order; load errors are returned rather than served from the cache.
+func (s *Store) ListVerified(entity domain.EntityType) ([]any, error) {
+	records, err := s.loadRecords(entity)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(records))
+	for id := range records {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	out := make([]any, 0, len(records))
+	var errs []error
+	for _, id := range ids {
+		out = append(out, records[id])
+		if s.verifyOnRead {
+			errs = append(errs, memory.VerifyRecord(entity, id, records[id]))
+		}
+	}
+	return out, errors.Join(errs...)
+}
+
+// loadRecords reads the current snapshot, refreshing the cache, and returns
+// the records of entity keyed by ID.
+func (s *Store) loadRecords(entity domain.EntityType) (map[string]any, error) {
+	s.mu.Lock()
+	snap, err := loadNormalizedSnapshot(context.Background(), s.db)
+	if err == nil {
+		s.cache = snap
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", entity, err)
+	}
+	switch entity {
+	case domain.EntityOrganism:
+		return anyRecords(snap.Organisms), nil
+	case domain.EntityCohort:
+		return anyRecords(snap.Cohorts), nil
+	case domain.EntityHousingUnit:
+		return anyRecords(snap.Housing), nil
+	case domain.EntityFacility:
+		return anyRecords(snap.Facilities), nil
+	case domain.EntityBreeding:
+		return anyRecords(snap.Breeding), nil
+	case domain.EntityLine:
+		return anyRecords(snap.Lines), nil
+	case domain.EntityStrain:
+		return anyRecords(snap.Strains), nil
+	case domain.EntityGenotypeMarker:
+		return anyRecords(snap.Markers), nil
+	case domain.EntityProcedure:
+		return anyRecords(snap.Procedures), nil
+	case domain.EntityTreatment:
+		return anyRecords(snap.Treatments), nil
+	case domain.EntityObservation:
+		return anyRecords(snap.Observations), nil
+	case domain.EntitySample:
+		return anyRecords(snap.Samples), nil
+	case domain.EntityProtocol:
+		return anyRecords(snap.Protocols), nil
+	case domain.EntityPermit:
+		return anyRecords(snap.Permits), nil
+	case domain.EntityProject:
+		return anyRecords(snap.Projects), nil
+	case domain.EntitySupplyItem:
+		return anyRecords(snap.Supplies), nil
+	default:
+		return nil, fmt.Errorf("read %s: unknown entity", entity)
+	}
+}
+
+// loadRecord reads the record of entity stored under id, refreshing its cache
+// entry, and returns it keyed by ID. Entities without an ID-scoped query fall
+// back to loadRecords.
+func (s *Store) loadRecord(entity domain.EntityType, id string) (map[string]any, error) {
+	switch entity {
+	case domain.EntityOrganism:
+		return loadCachedRecord(s, entity, id, loadSingleOrganism, func(c *memory.Snapshot) *map[string]domain.Organism { return &c.Organisms })
+	case domain.EntityHousingUnit:
+		return loadCachedRecord(s, entity, id, func(ctx context.Context, db *sql.DB, id string) (map[string]domain.HousingUnit, error) {
+			return loadHousingUnitsWhere(ctx, db, selectHousingByIDSQL, id)
+		}, func(c *memory.Snapshot) *map[string]domain.HousingUnit { return &c.Housing })
+	case domain.EntityFacility:
+		return loadCachedRecord(s, entity, id, loadSingleFacility, func(c *memory.Snapshot) *map[string]domain.Facility { return &c.Facilities })
+	case domain.EntityTreatment:
+		return loadCachedRecord(s, entity, id, loadSingleTreatment, func(c *memory.Snapshot) *map[string]domain.Treatment { return &c.Treatments })
+	case domain.EntityObservation:
+		return loadCachedRecord(s, entity, id, func(ctx context.Context, db *sql.DB, id string) (map[string]domain.Observation, error) {
+			return loadObservationsWhere(ctx, db, selectObservationByIDSQL, id)
+		}, func(c *memory.Snapshot) *map[string]domain.Observation { return &c.Observations })
+	case domain.EntitySample:
+		return loadCachedRecord(s, entity, id, func(ctx context.Context, db *sql.DB, id string) (map[string]domain.Sample, error) {
+			return loadSamplesWhere(ctx, db, selectSampleByIDSQL, id)
+		}, func(c *memory.Snapshot) *map[string]domain.Sample { return &c.Samples })
+	case domain.EntityPermit:
+		return loadCachedRecord(s, entity, id, loadSinglePermit, func(c *memory.Snapshot) *map[string]domain.Permit { return &c.Permits })
+	case domain.EntitySupplyItem:
+		return loadCachedRecord(s, entity, id, loadSupplyItemByID, func(c *memory.Snapshot) *map[string]domain.SupplyItem { return &c.Supplies })
+	case domain.EntityLine:
+		return loadCachedRecord(s, entity, id, loadSingleLine, func(c *memory.Snapshot) *map[string]domain.Line { return &c.Lines })
+	case domain.EntityStrain:
+		return loadCachedRecord(s, entity, id, loadSingleStrain, func(c *memory.Snapshot) *map[string]domain.Strain { return &c.Strains })
+	}
+	return s.loadRecords(entity)
+}
+
+// loadCachedRecord runs load for id and replaces the entry for id in the cache
+// map selected by cached with the result.
+func loadCachedRecord[T any](s *Store, entity domain.EntityType, id string, load func(context.Context, *sql.DB, string) (map[string]T, error), cached func(*memory.Snapshot) *map[string]T) (map[string]any, error) {
+	records, err := load(context.Background(), s.db, id)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", entity, err)
+	}
+	s.mu.Lock()
+	entries := cached(&s.cache)
+	*entries = refreshCacheEntry(*entries, id, records)
+	s.mu.Unlock()
+	return anyRecords(records), nil
+}
+
+func anyRecords[T any](records map[string]T) map[string]any {
+	out := make(map[string]any, len(records))
+	for id, record := range records {
+		out[id] = record
+	}
+	return out
+}
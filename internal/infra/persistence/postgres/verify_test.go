@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	pgtu "colonycore/internal/infra/persistence/postgres/testutil"
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestVerifiedReadsReportInvalidRecords(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+	store, err := NewStore("ignored", domain.NewRulesEngine(), WithVerifyOnRead(true))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	var housingID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "FAC", Name: "Facility"}})
+		if err != nil {
+			return err
+		}
+		housing, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: facility.ID, Capacity: 2}})
+		housingID = housing.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if _, ok, err := domain.GetVerified[domain.HousingUnit](store, domain.EntityHousingUnit, housingID); !ok || err != nil {
+		t.Fatalf("expected valid housing unit, got ok=%v err=%v", ok, err)
+	}
+
+	conn.Tables["housing_units"][0]["capacity"] = int64(-3)
+	housing, ok, err := domain.GetVerified[domain.HousingUnit](store, domain.EntityHousingUnit, housingID)
+	var invalid domain.InvalidEntityError
+	if !ok || housing.Capacity != -3 || !errors.As(err, &invalid) || invalid.ID != housingID {
+		t.Fatalf("expected InvalidEntityError for housing unit, got %+v ok=%v err=%v", housing, ok, err)
+	}
+	if units, err := domain.ListVerified[domain.HousingUnit](store, domain.EntityHousingUnit); len(units) != 1 || !errors.As(err, &invalid) {
+		t.Fatalf("expected list to report the invalid unit, got %d units, err=%v", len(units), err)
+	}
+	if _, err := store.ListVerified(domain.EntityType("unknown")); err == nil {
+		t.Fatalf("expected unknown entity to fail")
+	}
+
+	conn.FailTables = map[string]bool{"housing_units": true}
+	if _, _, err := store.GetVerified(domain.EntityHousingUnit, housingID); err == nil {
+		t.Fatalf("expected load failure to be returned")
+	}
+}
+
+func TestGetVerifiedReadsSingleRecords(t *testing.T) {
+	db, conn := pgtu.NewStubDB()
+	restore := OverrideSQLOpen(func(_, _ string) (*sql.DB, error) { return db, nil })
+	defer restore()
+	store, err := NewStore("ignored", domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	var facilityID, housingID, organismID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "FAC", Name: "Facility"}})
+		if err != nil {
+			return err
+		}
+		facilityID = facility.ID
+		housing, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: facility.ID, Capacity: 2}})
+		if err != nil {
+			return err
+		}
+		housingID = housing.ID
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "frog", HousingID: &housing.ID}})
+		organismID = organism.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	conn.Queries = nil
+	organism, ok, err := domain.GetVerified[domain.Organism](store, domain.EntityOrganism, organismID)
+	if !ok || err != nil || organism.Name != "Frog" {
+		t.Fatalf("expected organism, got %+v ok=%v err=%v", organism, ok, err)
+	}
+	facility, ok, err := domain.GetVerified[domain.Facility](store, domain.EntityFacility, facilityID)
+	if !ok || err != nil || len(facility.HousingUnitIDs) != 1 || facility.HousingUnitIDs[0] != housingID {
+		t.Fatalf("expected facility with its housing unit, got %+v ok=%v err=%v", facility, ok, err)
+	}
+	if len(conn.Queries) == 0 {
+		t.Fatalf("expected targeted queries")
+	}
+	for _, query := range conn.Queries {
+		if !strings.Contains(query, " WHERE ") {
+			t.Fatalf("expected every read to be ID-scoped, got %s", query)
+		}
+	}
+	if _, ok, err := store.GetVerified(domain.EntityOrganism, "missing"); ok || err != nil {
+		t.Fatalf("expected missing organism to be reported absent, got ok=%v err=%v", ok, err)
+	}
+
+	conn.FailTables = map[string]bool{"organisms": true}
+	if _, _, err := store.GetVerified(domain.EntityOrganism, organismID); err == nil {
+		t.Fatalf("expected load failure to be returned")
+	}
+}
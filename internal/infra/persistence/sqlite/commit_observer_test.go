@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestStoreNotifiesCommitObserversAfterPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "observer.db")
+	store, err := NewStore(path, domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	var persisted []string
+	store.AddCommitObserver(domain.ChangeSinkFunc(func(context.Context, []domain.Change) error {
+		reopened, err := NewStore(path, domain.NewRulesEngine())
+		if err != nil {
+			return err
+		}
+		for _, organism := range reopened.ListOrganisms() {
+			persisted = append(persisted, organism.Name)
+		}
+		return reopened.DB().Close()
+	}))
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		_, err := tx.CreateOrganism(Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus"}})
+		return err
+	}); err != nil {
+		t.Fatalf("create organism: %v", err)
+	}
+	if len(persisted) != 1 || persisted[0] != "Frog" {
+		t.Fatalf("expected observer to see the persisted organism, got %v", persisted)
+	}
+}
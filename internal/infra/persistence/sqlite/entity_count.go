@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"colonycore/pkg/domain"
+)
+
+// CountEntities counts the records of entity admitted by filter without
+// cloning them. Attribution follows domain.CountFilter.
+func (s *memStore) CountEntities(entity domain.EntityType, filter domain.CountFilter) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	st := &s.state
+	housingFacility := func(id *string) []string {
+		if id == nil {
+			return nil
+		}
+		return []string{st.housing[*id].FacilityID}
+	}
+	switch entity {
+	case domain.EntityOrganism:
+		return countAdmitted(st.organisms, filter, func(o Organism) ([]string, []string) {
+			return housingFacility(o.HousingID), optionalID(o.ProjectID)
+		}), nil
+	case domain.EntityCohort:
+		return countAdmitted(st.cohorts, filter, func(c Cohort) ([]string, []string) {
+			return housingFacility(c.HousingID), optionalID(c.ProjectID)
+		}), nil
+	case domain.EntityHousingUnit:
+		return countAdmitted(st.housing, filter, func(h HousingUnit) ([]string, []string) {
+			return []string{h.FacilityID}, nil
+		}), nil
+	case domain.EntityFacility:
+		return countAdmitted(st.facilities, filter, func(f Facility) ([]string, []string) {
+			return []string{f.ID}, nil
+		}), nil
+	case domain.EntitySample:
+		return countAdmitted(st.samples, filter, func(sample Sample) ([]string, []string) {
+			return []string{sample.FacilityID}, nil
+		}), nil
+	case domain.EntityProject:
+		return countAdmitted(st.projects, filter, func(p Project) ([]string, []string) {
+			return p.FacilityIDs, []string{p.ID}
+		}), nil
+	case domain.EntityPermit:
+		return countAdmitted(st.permits, filter, func(p Permit) ([]string, []string) {
+			return p.FacilityIDs, nil
+		}), nil
+	case domain.EntitySupplyItem:
+		return countAdmitted(st.supplies, filter, func(item SupplyItem) ([]string, []string) {
+			return item.FacilityIDs, item.ProjectIDs
+		}), nil
+	case domain.EntityProcedure:
+		return countAdmitted(st.procedures, filter, func(p Procedure) ([]string, []string) {
+			return nil, optionalID(p.ProjectID)
+		}), nil
+	case domain.EntityBreeding:
+		return len(st.breeding), nil
+	case domain.EntityLine:
+		return len(st.lines), nil
+	case domain.EntityStrain:
+		return len(st.strains), nil
+	case domain.EntityGenotypeMarker:
+		return len(st.markers), nil
+	case domain.EntityTreatment:
+		return len(st.treatments), nil
+	case domain.EntityObservation:
+		return len(st.observations), nil
+	case domain.EntityProtocol:
+		return len(st.protocols), nil
+	default:
+		return 0, fmt.Errorf("count %s: unknown entity", entity)
+	}
+}
+
+func countAdmitted[T any](records map[string]T, filter domain.CountFilter, attribution func(T) (facilities, projects []string)) int {
+	count := 0
+	for _, record := range records {
+		if filter.Admits(attribution(record)) {
+			count++
+		}
+	}
+	return count
+}
+
+func optionalID(id *string) []string {
+	if id == nil {
+		return nil
+	}
+	return []string{*id}
+}
@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func nestedAttributes(depth int) map[string]any {
+	attrs := map[string]any{"leaf": true}
+	for i := 1; i < depth; i++ {
+		attrs = map[string]any{"child": attrs}
+	}
+	return attrs
+}
+
+func wideAttributes(keys int) map[string]any {
+	attrs := make(map[string]any, keys)
+	for i := 0; i < keys; i++ {
+		attrs[fmt.Sprintf("key-%02d", i)] = i
+	}
+	return attrs
+}
+
+func createOrganismWithAttributes(store *memStore, attrs map[string]any) (Organism, error) {
+	var created Organism
+	_, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		organism := domain.Organism{Organism: entitymodel.Organism{Name: "Specimen", Species: "Danio rerio"}}
+		if err := organism.SetCoreAttributes(attrs); err != nil {
+			return err
+		}
+		var err error
+		created, err = tx.CreateOrganism(organism)
+		return err
+	})
+	return created, err
+}
+
+func TestWithAttributeLimitsRejectsDeepAndWidePayloads(t *testing.T) {
+	store := newMemStore(nil, WithAttributeLimits(3, 10))
+
+	var tooComplex ErrAttributesTooComplex
+	_, err := createOrganismWithAttributes(store, nestedAttributes(4))
+	if !errors.As(err, &tooComplex) {
+		t.Fatalf("expected ErrAttributesTooComplex for deep payload, got %v", err)
+	}
+	if tooComplex.Depth != 4 || tooComplex.MaxDepth != 3 || tooComplex.Entity != domain.EntityOrganism {
+		t.Fatalf("unexpected depth error details %+v", tooComplex)
+	}
+
+	_, err = createOrganismWithAttributes(store, wideAttributes(11))
+	if !errors.As(err, &tooComplex) {
+		t.Fatalf("expected ErrAttributesTooComplex for wide payload, got %v", err)
+	}
+	if tooComplex.Keys != 11 || tooComplex.MaxKeys != 10 {
+		t.Fatalf("unexpected key error details %+v", tooComplex)
+	}
+	if len(store.ListOrganisms()) != 0 {
+		t.Fatalf("expected rejected organisms not to be stored")
+	}
+
+	organism, err := createOrganismWithAttributes(store, map[string]any{"tank": map[string]any{"row": 1, "col": []any{"a", "b"}}})
+	if err != nil {
+		t.Fatalf("expected payload within limits to be accepted, got %v", err)
+	}
+
+	_, err = store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		_, err := tx.UpdateOrganism(organism.ID, func(o *domain.Organism) error {
+			return o.SetCoreAttributes(nestedAttributes(5))
+		})
+		return err
+	})
+	if !errors.As(err, &tooComplex) {
+		t.Fatalf("expected update to enforce limits, got %v", err)
+	}
+}
+
+func TestAttributeLimitsDefaultToUnlimited(t *testing.T) {
+	store := newMemStore(nil, WithAttributeLimits(-1, 0))
+	if _, err := createOrganismWithAttributes(store, nestedAttributes(64)); err != nil {
+		t.Fatalf("expected unlimited depth by default, got %v", err)
+	}
+	if _, err := createOrganismWithAttributes(newMemStore(nil), wideAttributes(500)); err != nil {
+		t.Fatalf("expected unlimited keys by default, got %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+type cohortRefs struct {
+	cohort, organism, observation, sample, procedure, treatment string
+}
+
+// seedCohortReferences creates a cohort referenced by one record of every
+// entity that can point at a cohort.
+func seedCohortReferences(t *testing.T, store *memStore) cohortRefs {
+	t.Helper()
+	var ids cohortRefs
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		cohort, err := tx.CreateCohort(Cohort{Cohort: entitymodel.Cohort{Name: "Tank A"}})
+		if err != nil {
+			return err
+		}
+		ids.cohort = cohort.ID
+		organism, err := tx.CreateOrganism(Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus", Stage: domain.StageAdult, CohortID: &cohort.ID}})
+		if err != nil {
+			return err
+		}
+		ids.organism = organism.ID
+		observation, err := tx.CreateObservation(Observation{Observation: entitymodel.Observation{OrganismID: &organism.ID, CohortID: &cohort.ID, RecordedAt: time.Now().UTC(), Observer: "tech"}})
+		if err != nil {
+			return err
+		}
+		ids.observation = observation.ID
+		facility, err := tx.CreateFacility(Facility{Facility: entitymodel.Facility{Code: "FAC", Name: "Facility"}})
+		if err != nil {
+			return err
+		}
+		sample, err := tx.CreateSample(Sample{Sample: entitymodel.Sample{Identifier: "S-1", SourceType: "blood", OrganismID: &organism.ID, CohortID: &cohort.ID, FacilityID: facility.ID,
+			CollectedAt: time.Now().UTC(), Status: domain.SampleStatusStored, StorageLocation: "freezer", AssayType: "PCR", CollectedBy: "tech",
+			ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "bench", Timestamp: time.Now().UTC()}}}})
+		if err != nil {
+			return err
+		}
+		ids.sample = sample.ID
+		protocol, err := tx.CreateProtocol(Protocol{Protocol: entitymodel.Protocol{Code: "PROT", Title: "Protocol", MaxSubjects: 5}})
+		if err != nil {
+			return err
+		}
+		procedure, err := tx.CreateProcedure(Procedure{Procedure: entitymodel.Procedure{Name: "Check", Status: domain.ProcedureStatusScheduled,
+			ScheduledAt: time.Now().Add(time.Hour), ProtocolID: protocol.ID, CohortID: &cohort.ID}})
+		if err != nil {
+			return err
+		}
+		ids.procedure = procedure.ID
+		treatment, err := tx.CreateTreatment(Treatment{Treatment: entitymodel.Treatment{Name: "Dose", Status: domain.TreatmentStatusPlanned, ProcedureID: procedure.ID,
+			OrganismIDs: []string{organism.ID}, CohortIDs: []string{cohort.ID}, DosagePlan: domain.DosagePlan{Drug: "compound", DoseAmount: 1, DoseUnit: "mg/kg"}}})
+		if err != nil {
+			return err
+		}
+		ids.treatment = treatment.ID
+		return nil
+	}); err != nil {
+		t.Fatalf("seed cohort references: %v", err)
+	}
+	return ids
+}
+
+func TestDeleteCohortBlocksOnReferencingOrganism(t *testing.T) {
+	store := newMemStore(nil)
+	var cohortID, organismID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		cohort, err := tx.CreateCohort(Cohort{Cohort: entitymodel.Cohort{Name: "Tank A"}})
+		if err != nil {
+			return err
+		}
+		cohortID = cohort.ID
+		organism, err := tx.CreateOrganism(Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus", Stage: domain.StageAdult, CohortID: &cohort.ID}})
+		organismID = organism.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	_, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		return tx.DeleteCohort(cohortID)
+	})
+	if err == nil || !strings.Contains(err.Error(), "still referenced by organism "+`"`+organismID+`"`) {
+		t.Fatalf("expected delete to be blocked by the organism, got %v", err)
+	}
+	if organism, ok := store.GetOrganism(organismID); !ok || organism.CohortID == nil || *organism.CohortID != cohortID {
+		t.Fatalf("expected organism to keep its cohort after the blocked delete")
+	}
+}
+
+func TestDeleteCohortBlocksOnEveryReferenceKind(t *testing.T) {
+	store := newMemStore(nil)
+	ids := seedCohortReferences(t, store)
+	_, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		return tx.DeleteCohort(ids.cohort)
+	})
+	if err == nil || !strings.Contains(err.Error(), "still referenced by sample") {
+		t.Fatalf("expected samples to be reported first, got %v", err)
+	}
+}
+
+func TestDeleteCohortCascadeNullsReferences(t *testing.T) {
+	store := newMemStore(nil)
+	ids := seedCohortReferences(t, store)
+	var changes []Change
+	store.AddCommitObserver(domain.ChangeSinkFunc(func(_ context.Context, batch []Change) error {
+		changes = batch
+		return nil
+	}))
+
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		return tx.DeleteCohortCascade(ids.cohort)
+	}); err != nil {
+		t.Fatalf("cascade delete: %v", err)
+	}
+
+	if organism, ok := store.GetOrganism(ids.organism); !ok || organism.CohortID != nil {
+		t.Fatalf("expected organism cohort_id to be cleared, got %+v", organism.CohortID)
+	}
+	if err := store.View(context.Background(), func(view TransactionView) error {
+		if _, ok := view.FindCohort(ids.cohort); ok {
+			t.Fatalf("expected cohort to be deleted")
+		}
+		if observation, ok := view.FindObservation(ids.observation); !ok || observation.CohortID != nil {
+			t.Fatalf("expected observation cohort_id to be cleared")
+		}
+		if sample, ok := view.FindSample(ids.sample); !ok || sample.CohortID != nil {
+			t.Fatalf("expected sample cohort_id to be cleared")
+		}
+		if procedure, ok := view.FindProcedure(ids.procedure); !ok || procedure.CohortID != nil {
+			t.Fatalf("expected procedure cohort_id to be cleared")
+		}
+		if treatment, ok := view.FindTreatment(ids.treatment); !ok || len(treatment.CohortIDs) != 0 || len(treatment.OrganismIDs) != 1 {
+			t.Fatalf("expected treatment to drop only the cohort, got %+v", treatment.CohortIDs)
+		}
+		if refs := view.ReferencesTo(domain.EntityCohort, ids.cohort); len(refs) != 0 {
+			t.Fatalf("expected no dangling references, got %v", refs)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+
+	if len(changes) != 6 {
+		t.Fatalf("expected five detach updates and one delete, got %d changes", len(changes))
+	}
+	for _, change := range changes[:5] {
+		if change.Action != domain.ActionUpdate {
+			t.Fatalf("expected detach updates before the delete, got %s %s", change.Action, change.Entity)
+		}
+	}
+	if last := changes[5]; last.Entity != domain.EntityCohort || last.Action != domain.ActionDelete {
+		t.Fatalf("expected the cohort delete last, got %s %s", last.Action, last.Entity)
+	}
+}
+
+func TestDeleteCohortCascadeMissingCohort(t *testing.T) {
+	store := newMemStore(nil)
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		return tx.DeleteCohortCascade("missing")
+	}); err == nil {
+		t.Fatalf("expected missing cohort to fail")
+	}
+}
+
+func TestDeleteCohortCascadeDetachesLegacyRecords(t *testing.T) {
+	store := newMemStore(nil)
+	ids := seedCohortReferences(t, store)
+	legacy := "two drops daily"
+	snapshot := store.ExportState()
+	treatment := snapshot.Treatments[ids.treatment]
+	treatment.DosagePlan = domain.DosagePlan{LegacyText: &legacy}
+	snapshot.Treatments[ids.treatment] = treatment
+	sample := snapshot.Samples[ids.sample]
+	sample.CollectedBy = ""
+	snapshot.Samples[ids.sample] = sample
+	if err := store.ImportState(snapshot); err != nil {
+		t.Fatalf("import legacy records: %v", err)
+	}
+
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		return tx.DeleteCohortCascade(ids.cohort)
+	}); err != nil {
+		t.Fatalf("cascade delete with legacy records: %v", err)
+	}
+	if err := store.View(context.Background(), func(view TransactionView) error {
+		treatment, ok := view.FindTreatment(ids.treatment)
+		if !ok || len(treatment.CohortIDs) != 0 || !domain.IsLegacyDosagePlan(treatment.DosagePlan) {
+			t.Fatalf("expected legacy treatment detached with its plan intact, got %+v", treatment)
+		}
+		if sample, ok := view.FindSample(ids.sample); !ok || sample.CohortID != nil {
+			t.Fatalf("expected legacy sample detached, got %+v", sample)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+}
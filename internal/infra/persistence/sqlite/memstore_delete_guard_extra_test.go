@@ -3,6 +3,7 @@ package sqlite
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"colonycore/pkg/domain"
 	entitymodel "colonycore/pkg/domain/entitymodel"
@@ -10,6 +11,7 @@ import (
 
 func TestMemStoreDeleteProtocolBlockedByPermit(t *testing.T) {
 	store := newMemStore(nil)
+	now := time.Now().UTC()
 
 	runTx(t, store, func(tx domain.Transaction) error {
 		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Name: "Lab"}})
@@ -23,6 +25,9 @@ func TestMemStoreDeleteProtocolBlockedByPermit(t *testing.T) {
 		if _, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{
 			PermitNumber:      "PER-DEL",
 			Authority:         "Gov",
+			IssueDate:         now,
+			ValidFrom:         now,
+			ValidUntil:        now.Add(time.Hour),
 			AllowedActivities: []string{"use"},
 			FacilityIDs:       []string{facility.ID},
 			ProtocolIDs:       []string{protocol.ID},
@@ -217,6 +217,7 @@ func TestDeleteFacilityBlockedBySample(t *testing.T) {
 			CollectedAt:     now,
 			StorageLocation: "cold",
 			AssayType:       "assay",
+			CollectedBy:     "tech",
 			ChainOfCustody:  []domain.SampleCustodyEvent{{Actor: "tech", Location: "cold", Timestamp: now}},
 		}}); err != nil {
 			return err
@@ -229,3 +230,302 @@ func TestDeleteFacilityBlockedBySample(t *testing.T) {
 		t.Fatalf("RunInTransaction: %v", err)
 	}
 }
+
+func TestTransactionFindProjectDecoratesDerivedIDs(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "find-project.db"), domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.DB().Close() })
+	ctx := context.Background()
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{
+			Code:         "FAC-PRJ",
+			Name:         "Facility",
+			Zone:         "Z",
+			AccessPolicy: "all",
+		}})
+		if err != nil {
+			return err
+		}
+		project, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{
+			Code:        "PRJ-FIND",
+			Title:       "Project",
+			FacilityIDs: []string{facility.ID},
+		}})
+		if err != nil {
+			return err
+		}
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{
+			Code:        "PROT-PRJ",
+			Title:       "Protocol",
+			MaxSubjects: 5,
+			Status:      domain.ProtocolStatusApproved,
+		}})
+		if err != nil {
+			return err
+		}
+		projectID := project.ID
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{
+			Name:      "Org",
+			Species:   "species",
+			Stage:     domain.StageAdult,
+			ProjectID: &projectID,
+		}})
+		if err != nil {
+			return err
+		}
+		procedure, err := tx.CreateProcedure(domain.Procedure{Procedure: entitymodel.Procedure{
+			Name:        "Check",
+			Status:      domain.ProcedureStatusScheduled,
+			ScheduledAt: time.Now(),
+			ProtocolID:  protocol.ID,
+			ProjectID:   &projectID,
+		}})
+		if err != nil {
+			return err
+		}
+		supply, err := tx.CreateSupplyItem(domain.SupplyItem{SupplyItem: entitymodel.SupplyItem{
+			SKU:            "SKU-PRJ",
+			Name:           "Supply",
+			QuantityOnHand: 1,
+			Unit:           "unit",
+			FacilityIDs:    []string{facility.ID},
+			ProjectIDs:     []string{projectID},
+		}})
+		if err != nil {
+			return err
+		}
+
+		found, ok := tx.FindProject(projectID)
+		if !ok {
+			return fmt.Errorf("expected project %q to be found", projectID)
+		}
+		if len(found.OrganismIDs) != 1 || found.OrganismIDs[0] != organism.ID {
+			return fmt.Errorf("unexpected organism ids: %+v", found.OrganismIDs)
+		}
+		if len(found.ProcedureIDs) != 1 || found.ProcedureIDs[0] != procedure.ID {
+			return fmt.Errorf("unexpected procedure ids: %+v", found.ProcedureIDs)
+		}
+		if len(found.SupplyItemIDs) != 1 || found.SupplyItemIDs[0] != supply.ID {
+			return fmt.Errorf("unexpected supply item ids: %+v", found.SupplyItemIDs)
+		}
+		found.OrganismIDs[0] = "mutated"
+		again, _ := tx.FindProject(projectID)
+		if again.OrganismIDs[0] != organism.ID {
+			return fmt.Errorf("expected FindProject to return a clone")
+		}
+		if _, ok := tx.FindProject("missing"); ok {
+			return fmt.Errorf("expected missing project lookup to return false")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("find project: %v", err)
+	}
+}
+
+func TestTransactionViewListObservationsBySubject(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "observations-by-subject.db"), domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.DB().Close() })
+	ctx := context.Background()
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	var organismID, cohortID, directID, laterID, procedureOnlyID, cohortObsID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{
+			Code:        "PROT-OBS",
+			Title:       "Protocol",
+			MaxSubjects: 5,
+			Status:      domain.ProtocolStatusApproved,
+		}})
+		if err != nil {
+			return err
+		}
+		cohort, err := tx.CreateCohort(domain.Cohort{Cohort: entitymodel.Cohort{Name: "Cohort", Purpose: "observation"}})
+		if err != nil {
+			return err
+		}
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{
+			Name:    "Subject",
+			Species: "species",
+			Stage:   domain.StageAdult,
+		}})
+		if err != nil {
+			return err
+		}
+		procedure, err := tx.CreateProcedure(domain.Procedure{Procedure: entitymodel.Procedure{
+			Name:        "Check",
+			Status:      domain.ProcedureStatusScheduled,
+			ScheduledAt: base,
+			ProtocolID:  protocol.ID,
+			OrganismIDs: []string{organism.ID},
+		}})
+		if err != nil {
+			return err
+		}
+		notes := "baseline"
+		later, err := tx.CreateObservation(domain.Observation{Observation: entitymodel.Observation{
+			OrganismID: &organism.ID,
+			RecordedAt: base.Add(2 * time.Hour),
+			Observer:   "tech",
+		}})
+		if err != nil {
+			return err
+		}
+		direct, err := tx.CreateObservation(domain.Observation{Observation: entitymodel.Observation{
+			OrganismID: &organism.ID,
+			RecordedAt: base,
+			Observer:   "tech",
+			Notes:      &notes,
+		}})
+		if err != nil {
+			return err
+		}
+		procedureOnly, err := tx.CreateObservation(domain.Observation{Observation: entitymodel.Observation{
+			ProcedureID: &procedure.ID,
+			RecordedAt:  base.Add(time.Hour),
+			Observer:    "tech",
+		}})
+		if err != nil {
+			return err
+		}
+		cohortObs, err := tx.CreateObservation(domain.Observation{Observation: entitymodel.Observation{
+			CohortID:   &cohort.ID,
+			RecordedAt: base,
+			Observer:   "tech",
+		}})
+		if err != nil {
+			return err
+		}
+		organismID, cohortID = organism.ID, cohort.ID
+		directID, laterID, procedureOnlyID, cohortObsID = direct.ID, later.ID, procedureOnly.ID, cohortObs.ID
+		return nil
+	}); err != nil {
+		t.Fatalf("seed observations: %v", err)
+	}
+
+	if err := store.View(ctx, func(view domain.TransactionView) error {
+		byOrganism := view.ListObservationsByOrganism(organismID)
+		if len(byOrganism) != 2 || byOrganism[0].ID != directID || byOrganism[1].ID != laterID {
+			t.Fatalf("expected direct organism observations ordered by recorded_at, got %+v", byOrganism)
+		}
+		for _, obs := range byOrganism {
+			if obs.ID == procedureOnlyID {
+				t.Fatalf("procedure-linked observation %s must not be listed by organism", procedureOnlyID)
+			}
+		}
+		*byOrganism[0].Notes = "mutated"
+
+		byCohort := view.ListObservationsByCohort(cohortID)
+		if len(byCohort) != 1 || byCohort[0].ID != cohortObsID {
+			t.Fatalf("expected cohort observation %s, got %+v", cohortObsID, byCohort)
+		}
+		if got := view.ListObservationsByOrganism("missing"); len(got) != 0 {
+			t.Fatalf("expected no observations for unknown organism, got %+v", got)
+		}
+		if got := view.ListObservationsByCohort("missing"); len(got) != 0 {
+			t.Fatalf("expected no observations for unknown cohort, got %+v", got)
+		}
+
+		again := view.ListObservationsByOrganism(organismID)
+		if again[0].Notes == nil || *again[0].Notes != "baseline" {
+			t.Fatalf("expected defensive copy to preserve notes, got %+v", again[0].Notes)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+}
+
+func TestTransactionViewListTreatmentsByProcedure(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "treatments-by-procedure.db"), domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.DB().Close() })
+	ctx := context.Background()
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	var procedureID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PROT-TRT", Title: "Protocol", MaxSubjects: 5}})
+		if err != nil {
+			return err
+		}
+		procedure, err := tx.CreateProcedure(domain.Procedure{Procedure: entitymodel.Procedure{Name: "Dose", Status: domain.ProcedureStatusScheduled, ScheduledAt: base, ProtocolID: protocol.ID}})
+		if err != nil {
+			return err
+		}
+		procedureID = procedure.ID
+		for _, id := range []string{"t-b", "t-a"} {
+			if _, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{ID: id, Name: id, Status: domain.TreatmentStatusPlanned, ProcedureID: procedure.ID,
+				DosagePlan: domain.DosagePlan{Drug: "compound", DoseAmount: 1, DoseUnit: "mg"}}}); err != nil {
+				return err
+			}
+		}
+		return tx.DeleteTreatment("t-b")
+	}); err != nil {
+		t.Fatalf("seed treatments: %v", err)
+	}
+
+	if err := store.View(ctx, func(view domain.TransactionView) error {
+		got := view.ListTreatmentsByProcedure(procedureID)
+		if len(got) != 1 || got[0].ID != "t-a" {
+			t.Fatalf("expected remaining treatment t-a, got %+v", got)
+		}
+		if none := view.ListTreatmentsByProcedure("missing"); len(none) != 0 {
+			t.Fatalf("expected no treatments for unknown procedure, got %+v", none)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+}
+
+func TestTransactionViewReferencesTo(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "references.db"), domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.DB().Close() })
+	ctx := context.Background()
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{ID: "fac-1", Name: "Lab"}})
+		if err != nil {
+			return err
+		}
+		for _, id := range []string{"hu-b", "hu-a"} {
+			if _, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{ID: id, Name: id, FacilityID: facility.ID, Capacity: 2}}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("seed housing: %v", err)
+	}
+
+	if err := store.View(ctx, func(view domain.TransactionView) error {
+		got := view.ReferencesTo(domain.EntityFacility, "fac-1")
+		want := []domain.Reference{
+			{Entity: domain.EntityHousingUnit, ID: "hu-a", Field: "facility_id"},
+			{Entity: domain.EntityHousingUnit, ID: "hu-b", Field: "facility_id"},
+		}
+		if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+			t.Fatalf("expected %+v, got %+v", want, got)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		return tx.DeleteFacility("fac-1")
+	}); err == nil {
+		t.Fatalf("expected referenced facility delete to fail")
+	}
+}
@@ -98,7 +98,7 @@ func TestSQLiteMemStoreFullCrudCoverage(t *testing.T) {
 			return err
 		}
 		if _, err := tx.UpdateBreedingUnit(breeding.ID, func(b *domain.BreedingUnit) error {
-			intent := "intent"
+			intent := domain.PairingIntentExperimental
 			b.PairingIntent = &intent
 			return nil
 		}); err != nil {
@@ -129,13 +129,13 @@ func TestSQLiteMemStoreFullCrudCoverage(t *testing.T) {
 			return err
 		}
 
-		treatment, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{ID: "treatment-full-sqlite", Name: "Treat", Status: domain.TreatmentStatusPlanned, ProcedureID: procedure.ID, OrganismIDs: []string{organism.ID}, CohortIDs: []string{cohort.ID}}})
+		treatment, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{ID: "treatment-full-sqlite", Name: "Treat", Status: domain.TreatmentStatusPlanned, ProcedureID: procedure.ID, OrganismIDs: []string{organism.ID}, CohortIDs: []string{cohort.ID}, DosagePlan: domain.DosagePlan{Drug: "compound", DoseAmount: 1, DoseUnit: "mg"}}})
 		if err != nil {
 			return err
 		}
 
 		if _, err := tx.UpdateTreatment(treatment.ID, func(t *domain.Treatment) error {
-			t.DosagePlan = "plan"
+			t.DosagePlan = domain.DosagePlan{Drug: "plan", DoseAmount: 1, DoseUnit: "mg"}
 			return nil
 		}); err != nil {
 			return err
@@ -153,7 +153,7 @@ func TestSQLiteMemStoreFullCrudCoverage(t *testing.T) {
 			return err
 		}
 
-		sample, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{ID: "sample-full-sqlite", Identifier: "S1", SourceType: "organism", OrganismID: &organism.ID, FacilityID: facility.ID, CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "cold", AssayType: "type", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "cold", Timestamp: now}}}})
+		sample, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{ID: "sample-full-sqlite", Identifier: "S1", SourceType: "organism", OrganismID: &organism.ID, FacilityID: facility.ID, CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "cold", AssayType: "type", CollectedBy: "tech", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "cold", Timestamp: now}}}})
 		if err != nil {
 			return err
 		}
@@ -165,7 +165,7 @@ func TestSQLiteMemStoreFullCrudCoverage(t *testing.T) {
 			return err
 		}
 
-		permit, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{ID: "permit-full-sqlite", PermitNumber: "PERMIT", Authority: "Gov", ValidFrom: now, ValidUntil: now.Add(time.Hour), AllowedActivities: []string{"store"}, FacilityIDs: []string{facility.ID}, ProtocolIDs: []string{protocol.ID}}})
+		permit, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{ID: "permit-full-sqlite", PermitNumber: "PERMIT", Authority: "Gov", IssueDate: now, ValidFrom: now, ValidUntil: now.Add(time.Hour), AllowedActivities: []string{"store"}, FacilityIDs: []string{facility.ID}, ProtocolIDs: []string{protocol.ID}}})
 		if err != nil {
 			return err
 		}
@@ -81,7 +81,7 @@ func TestMemStore_FullCRUDAndErrors(t *testing.T) { //nolint:gocyclo // exhausti
 		protocol = p
 		pr, _ := tx.CreateProcedure(domain.Procedure{Procedure: entitymodel.Procedure{Name: "Proc", Status: domain.ProcedureStatusScheduled, ProtocolID: protocol.ID, OrganismIDs: []string{o1.ID}, ScheduledAt: time.Now().UTC()}})
 		procedure = pr
-		t, _ := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{Name: "Dose", Status: domain.TreatmentStatusPlanned, ProcedureID: procedure.ID, OrganismIDs: []string{o1.ID}, CohortIDs: []string{cohort.ID}, DosagePlan: "10mg"}})
+		t, _ := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{Name: "Dose", Status: domain.TreatmentStatusPlanned, ProcedureID: procedure.ID, OrganismIDs: []string{o1.ID}, CohortIDs: []string{cohort.ID}, DosagePlan: domain.DosagePlan{Drug: "compound", DoseAmount: 10, DoseUnit: "mg"}}})
 		treatment = t
 		now := time.Now().UTC()
 		obInput := domain.Observation{Observation: entitymodel.Observation{ProcedureID: &procedure.ID, OrganismID: &o1.ID, RecordedAt: now, Observer: "tech"}}
@@ -99,6 +99,7 @@ func TestMemStore_FullCRUDAndErrors(t *testing.T) { //nolint:gocyclo // exhausti
 			Status:          domain.SampleStatusStored,
 			StorageLocation: "freezer",
 			AssayType:       "PCR",
+			CollectedBy:     "tech",
 			ChainOfCustody:  custody},
 		}
 		if err := sampleInput.ApplySampleAttributes(map[string]any{"volume_ml": 1.0}); err != nil {
@@ -109,6 +110,7 @@ func TestMemStore_FullCRUDAndErrors(t *testing.T) { //nolint:gocyclo // exhausti
 		per, _ := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PER-1",
 			Authority:         "Agency",
 			Status:            domain.PermitStatusApproved,
+			IssueDate:         now.Add(-time.Hour),
 			ValidFrom:         now.Add(-time.Hour),
 			ValidUntil:        now.Add(time.Hour),
 			AllowedActivities: []string{"collect"},
@@ -281,7 +283,7 @@ func TestMemStore_FullCRUDAndErrors(t *testing.T) { //nolint:gocyclo // exhausti
 		_, _ = tx.UpdateHousingUnit(housing.ID, func(h *domain.HousingUnit) error { h.Environment = "humid"; h.Capacity = 3; return nil })
 		_, _ = tx.UpdateBreedingUnit(breeding.ID, func(b *domain.BreedingUnit) error { b.FemaleIDs = append(b.FemaleIDs, orgB.ID); return nil })
 		_, _ = tx.UpdateProtocol(protocol.ID, func(p *domain.Protocol) error { p.Description = strPtr("desc"); return nil })
-		_, _ = tx.UpdateProcedure(procedure.ID, func(p *domain.Procedure) error { p.Status = domain.ProcedureStatusCompleted; return nil })
+		_, _ = tx.UpdateProcedure(procedure.ID, func(p *domain.Procedure) error { p.Status = domain.ProcedureStatusInProgress; return nil })
 		_, _ = tx.UpdateProject(project.ID, func(p *domain.Project) error { p.Description = strPtr("d"); return nil })
 		_, _ = tx.UpdateFacility(facility.ID, func(f *domain.Facility) error {
 			f.AccessPolicy = "training"
@@ -316,11 +318,15 @@ func TestMemStore_FullCRUDAndErrors(t *testing.T) { //nolint:gocyclo // exhausti
 	if len(snap.Organisms) != 2 || len(snap.Housing) != 1 || len(snap.Breeding) != 1 || len(snap.Procedures) != 1 || len(snap.Treatments) != 1 || len(snap.Samples) != 1 || len(snap.Facilities) != 1 {
 		t.Fatalf("unexpected snapshot counts: %+v", snap)
 	}
-	store.ImportState(Snapshot{})
+	if err := store.ImportState(Snapshot{}); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 	if len(store.ListOrganisms()) != 0 {
 		t.Fatalf("expected cleared state after import empty")
 	}
-	store.ImportState(snap)
+	if err := store.ImportState(snap); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 	if len(store.ListOrganisms()) != 2 {
 		t.Fatalf("expected restore after import")
 	}
@@ -510,7 +516,7 @@ func TestSQLiteStore_Persist_Reload_Full(t *testing.T) {
 			ProcedureID: procedure.ID,
 			OrganismIDs: []string{organism.ID},
 			CohortIDs:   []string{cohort.ID},
-			DosagePlan:  "10mg/kg"},
+			DosagePlan:  domain.DosagePlan{Drug: "compound", DoseAmount: 10, DoseUnit: "mg/kg"}},
 		})
 		if err != nil {
 			return err
@@ -540,6 +546,7 @@ func TestSQLiteStore_Persist_Reload_Full(t *testing.T) {
 			Status:          domain.SampleStatusStored,
 			StorageLocation: "freezer",
 			AssayType:       "PCR",
+			CollectedBy:     "tech",
 			ChainOfCustody:  custody},
 		}
 		if err := sampleInput2.ApplySampleAttributes(map[string]any{"volume_ml": 1.0}); err != nil {
@@ -554,6 +561,7 @@ func TestSQLiteStore_Persist_Reload_Full(t *testing.T) {
 		permit, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PER-1",
 			Authority:         "Agency",
 			Status:            domain.PermitStatusApproved,
+			IssueDate:         now.Add(-time.Hour),
 			ValidFrom:         now.Add(-time.Hour),
 			ValidUntil:        now.Add(time.Hour),
 			AllowedActivities: []string{"collect"},
@@ -638,7 +646,7 @@ func TestSQLiteStore_Persist_Reload_Full(t *testing.T) {
 		if sample, ok := view.FindSample(sampleID); !ok || sample.Status != domain.SampleStatusStored {
 			return fmt.Errorf("expected sample persisted via view")
 		}
-		if treatment, ok := view.FindTreatment(treatmentID); !ok || treatment.DosagePlan != "10mg/kg" {
+		if treatment, ok := view.FindTreatment(treatmentID); !ok || treatment.DosagePlan.DoseUnit != "mg/kg" {
 			return fmt.Errorf("expected treatment persisted via view")
 		}
 		if observation, ok := view.FindObservation(observationID); !ok || observation.Observer != "tech" {
@@ -886,6 +894,7 @@ func TestMemStoreDeleteFacilityEnforcesReferences(t *testing.T) {
 			CollectedAt:     now,
 			Status:          domain.SampleStatusStored,
 			StorageLocation: "room",
+			CollectedBy:     "tech",
 			ChainOfCustody: []domain.SampleCustodyEvent{{
 				Actor:     "tech",
 				Location:  "room",
@@ -897,6 +906,7 @@ func TestMemStoreDeleteFacilityEnforcesReferences(t *testing.T) {
 		}
 		permit, err = tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PERM",
 			Authority:         "Gov",
+			IssueDate:         now,
 			ValidFrom:         now,
 			ValidUntil:        now.Add(time.Hour),
 			AllowedActivities: []string{"collect"},
@@ -995,7 +1005,7 @@ func TestMemStoreRelationshipValidations(t *testing.T) {
 			return err
 		}
 
-		if _, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{Name: "ValidTreatment", ProcedureID: procedure.ID, OrganismIDs: []string{organism.ID}}}); err != nil {
+		if _, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{Name: "ValidTreatment", ProcedureID: procedure.ID, OrganismIDs: []string{organism.ID}, DosagePlan: domain.DosagePlan{Drug: "compound", DoseAmount: 1, DoseUnit: "mg"}}}); err != nil {
 			t.Fatalf("expected treatment creation to succeed: %v", err)
 		}
 
@@ -1007,13 +1017,13 @@ func TestMemStoreRelationshipValidations(t *testing.T) {
 			t.Fatalf("expected observation creation to succeed: %v", err)
 		}
 
-		if _, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{Identifier: "S0", SourceType: "blood", CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}}); err == nil {
+		if _, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{Identifier: "S0", SourceType: "blood", CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", CollectedBy: "tech", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}}); err == nil {
 			t.Fatalf("expected sample without facility to fail")
 		}
-		if _, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{Identifier: "S1", SourceType: "blood", FacilityID: facility.ID, CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}}); err == nil {
+		if _, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{Identifier: "S1", SourceType: "blood", FacilityID: facility.ID, CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", CollectedBy: "tech", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "room", Timestamp: now}}}}); err == nil {
 			t.Fatalf("expected sample without organism or cohort to fail")
 		}
-		if _, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{Identifier: "S2", SourceType: "blood", FacilityID: facility.ID, OrganismID: &organism.ID, CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", ChainOfCustody: []domain.SampleCustodyEvent{{
+		if _, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{Identifier: "S2", SourceType: "blood", FacilityID: facility.ID, OrganismID: &organism.ID, CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "room", CollectedBy: "tech", ChainOfCustody: []domain.SampleCustodyEvent{{
 			Actor:     "tech",
 			Location:  "room",
 			Timestamp: now,
@@ -1028,6 +1038,7 @@ func TestMemStoreRelationshipValidations(t *testing.T) {
 			AllowedActivities: []string{"collect"},
 			FacilityIDs:       []string{facility.ID},
 			ProtocolIDs:       []string{"missing"},
+			IssueDate:         now,
 			ValidFrom:         now,
 			ValidUntil:        now.Add(time.Hour)},
 		}); err == nil {
@@ -1035,6 +1046,7 @@ func TestMemStoreRelationshipValidations(t *testing.T) {
 		}
 		if _, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PERM-OK",
 			Authority:         "Gov",
+			IssueDate:         now,
 			ValidFrom:         now,
 			ValidUntil:        now.Add(time.Hour),
 			AllowedActivities: []string{"collect"},
@@ -1111,7 +1123,7 @@ func TestSQLiteMigrateSnapshotCleansDataVariants(t *testing.T) {
 			"prot-keep": {Protocol: entitymodel.Protocol{ID: "prot-keep", Code: "PR", Title: "Protocol", MaxSubjects: 5, Status: domain.ProtocolStatusApproved}},
 		},
 		Permits: map[string]domain.Permit{
-			"permit-valid": {Permit: entitymodel.Permit{ID: "permit-valid", PermitNumber: "P", Authority: "Gov", ValidFrom: now, ValidUntil: now.Add(time.Hour), FacilityIDs: []string{facilityID, facilityID, "missing"}, ProtocolIDs: []string{"prot-keep", "missing"}}},
+			"permit-valid": {Permit: entitymodel.Permit{ID: "permit-valid", PermitNumber: "P", Authority: "Gov", IssueDate: now, ValidFrom: now, ValidUntil: now.Add(time.Hour), FacilityIDs: []string{facilityID, facilityID, "missing"}, ProtocolIDs: []string{"prot-keep", "missing"}}},
 		},
 		Projects: map[string]domain.Project{
 			"project-valid": {Project: entitymodel.Project{ID: "project-valid", Code: "PRJ", Title: "Project", FacilityIDs: []string{facilityID, facilityID, "missing"}}},
@@ -1262,6 +1274,7 @@ func TestMemStoreUpdatePermitDedupe(t *testing.T) {
 		}
 		permit, err = tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PERM-DEDUP",
 			Authority:         "Gov",
+			IssueDate:         time.Now().UTC(),
 			ValidFrom:         time.Now().UTC(),
 			ValidUntil:        time.Now().UTC().Add(time.Hour),
 			AllowedActivities: []string{"collect"},
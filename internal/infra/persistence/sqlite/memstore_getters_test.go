@@ -1,8 +1,12 @@
 package sqlite
 
 import (
+	"context"
 	"strings"
 	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
 )
 
 func TestMemStoreGetMissing(t *testing.T) {
@@ -28,6 +32,8 @@ func TestMemStoreGetMissing(t *testing.T) {
 		{"strain", func(id string) bool { _, ok := store.GetStrain(id); return ok }},
 		{"marker", func(id string) bool { _, ok := store.GetGenotypeMarker(id); return ok }},
 		{"permit", func(id string) bool { _, ok := store.GetPermit(id); return ok }},
+		{"breeding", func(id string) bool { _, ok := store.GetBreedingUnit(id); return ok }},
+		{"supply", func(id string) bool { _, ok := store.GetSupplyItem(id); return ok }},
 	}
 
 	for _, tc := range cases {
@@ -42,3 +48,61 @@ func TestMemStoreGetMissing(t *testing.T) {
 		})
 	}
 }
+
+func TestMemStoreGetBreedingUnitReturnsMembers(t *testing.T) {
+	store := newMemStore(nil)
+	var unitID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		female, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Female", Species: "Xenopus"}})
+		if err != nil {
+			return err
+		}
+		male, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Male", Species: "Xenopus"}})
+		if err != nil {
+			return err
+		}
+		unit, err := tx.CreateBreedingUnit(domain.BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{Name: "Pair", Strategy: "pair", FemaleIDs: []string{female.ID}, MaleIDs: []string{male.ID}}})
+		unitID = unit.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed breeding unit: %v", err)
+	}
+
+	unit, ok := store.GetBreedingUnit(unitID)
+	if !ok || len(unit.FemaleIDs) != 1 || len(unit.MaleIDs) != 1 {
+		t.Fatalf("expected breeding unit with members, got %+v (%v)", unit, ok)
+	}
+	unit.FemaleIDs[0] = "mutated"
+	if again, _ := store.GetBreedingUnit(unitID); again.FemaleIDs[0] == "mutated" {
+		t.Fatalf("expected GetBreedingUnit to return a defensive copy")
+	}
+}
+
+func TestMemStoreGetSupplyItemReturnsReferences(t *testing.T) {
+	store := newMemStore(nil)
+	var supplyID, facilityID, projectID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "FAC", Name: "Facility"}})
+		if err != nil {
+			return err
+		}
+		project, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{Code: "PRJ", Title: "Project", FacilityIDs: []string{facility.ID}}})
+		if err != nil {
+			return err
+		}
+		supply, err := tx.CreateSupplyItem(domain.SupplyItem{SupplyItem: entitymodel.SupplyItem{SKU: "SKU-1", Name: "Diet Blocks", Unit: "grams", FacilityIDs: []string{facility.ID}, ProjectIDs: []string{project.ID}}})
+		supplyID, facilityID, projectID = supply.ID, facility.ID, project.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed supply item: %v", err)
+	}
+
+	item, ok := store.GetSupplyItem(supplyID)
+	if !ok || len(item.FacilityIDs) != 1 || item.FacilityIDs[0] != facilityID || len(item.ProjectIDs) != 1 || item.ProjectIDs[0] != projectID {
+		t.Fatalf("expected supply item with references, got %+v (%v)", item, ok)
+	}
+	item.ProjectIDs[0] = "mutated"
+	if again, _ := store.GetSupplyItem(supplyID); again.ProjectIDs[0] == "mutated" {
+		t.Fatalf("expected GetSupplyItem to return a defensive copy")
+	}
+}
@@ -146,6 +146,7 @@ func TestMemStoreUpdateSampleGuardsSQLite(t *testing.T) {
 			CollectedAt:     now,
 			Status:          domain.SampleStatusStored,
 			StorageLocation: "cold",
+			CollectedBy:     "tech",
 			ChainOfCustody: []domain.SampleCustodyEvent{{
 				Actor:     "tech",
 				Location:  "cold",
@@ -229,7 +230,7 @@ func TestMemStoreUpdateTreatmentGuardsSQLite(t *testing.T) {
 		if err != nil {
 			return err
 		}
-		treatment, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{ID: "treat-guard", Name: "Treat", Status: domain.TreatmentStatusPlanned, ProcedureID: procedure.ID, OrganismIDs: []string{organism.ID}}})
+		treatment, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{ID: "treat-guard", Name: "Treat", Status: domain.TreatmentStatusPlanned, ProcedureID: procedure.ID, OrganismIDs: []string{organism.ID}, DosagePlan: domain.DosagePlan{Drug: "compound", DoseAmount: 1, DoseUnit: "mg"}}})
 		if err != nil {
 			return err
 		}
@@ -385,6 +386,7 @@ func TestMemStoreDeleteOrganismAndCohortGuardsSQLite(t *testing.T) {
 			CollectedAt:     now,
 			Status:          domain.SampleStatusStored,
 			StorageLocation: "cold",
+			CollectedBy:     "tech",
 			ChainOfCustody: []domain.SampleCustodyEvent{{
 				Actor:     "tech",
 				Location:  "cold",
@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+var testIDPrefixes = map[domain.EntityType]string{
+	domain.EntityOrganism: "org_",
+	domain.EntityFacility: "fac_",
+}
+
+func TestWithIDPrefixesPrefixesGeneratedIDs(t *testing.T) {
+	store := newMemStore(nil, WithIDPrefixes(testIDPrefixes))
+	var organism Organism
+	var facility Facility
+	if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		var err error
+		if organism, err = tx.CreateOrganism(Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus"}}); err != nil {
+			return err
+		}
+		facility, err = tx.CreateFacility(Facility{Facility: entitymodel.Facility{Code: "FAC", Name: "Facility"}})
+		return err
+	}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !strings.HasPrefix(organism.ID, "org_") || len(organism.ID) != len("org_")+32 {
+		t.Fatalf("expected org_ prefixed id with random suffix, got %q", organism.ID)
+	}
+	if !strings.HasPrefix(facility.ID, "fac_") {
+		t.Fatalf("expected fac_ prefixed id, got %q", facility.ID)
+	}
+	if _, ok := store.GetOrganism(organism.ID); !ok {
+		t.Fatalf("expected organism stored under prefixed id")
+	}
+}
+
+func TestWithIDPrefixesKeepsSuppliedIDs(t *testing.T) {
+	store := newMemStore(nil, WithIDPrefixes(testIDPrefixes))
+	for _, id := range []string{"org_custom", "frog-1"} {
+		if _, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+			created, err := tx.CreateOrganism(Organism{Organism: entitymodel.Organism{ID: id, Name: "Frog", Species: "Xenopus"}})
+			if err == nil && created.ID != id {
+				t.Fatalf("expected supplied id %q to be kept, got %q", id, created.ID)
+			}
+			return err
+		}); err != nil {
+			t.Fatalf("create %q: %v", id, err)
+		}
+	}
+}
+
+func TestWithIDPrefixesRejectsCrossTypePrefix(t *testing.T) {
+	store := newMemStore(nil, WithIDPrefixes(testIDPrefixes))
+	_, err := store.RunInTransaction(context.Background(), func(tx Transaction) error {
+		_, err := tx.CreateOrganism(Organism{Organism: entitymodel.Organism{ID: "fac_123", Name: "Frog", Species: "Xenopus"}})
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), `has the facility prefix "fac_"`) {
+		t.Fatalf("expected cross-type prefix to be rejected, got %v", err)
+	}
+	if _, ok := store.GetOrganism("fac_123"); ok {
+		t.Fatalf("expected rejected organism not to be stored")
+	}
+}
+
+func TestCheckIDPrefixPrefersLongestMatch(t *testing.T) {
+	store := newMemStore(nil, WithIDPrefixes(map[domain.EntityType]string{
+		domain.EntityProject:   "p_",
+		domain.EntityProtocol:  "p_proto_",
+		domain.EntityProcedure: "",
+	}))
+	if err := store.checkIDPrefix(domain.EntityProtocol, "p_proto_1"); err != nil {
+		t.Fatalf("expected protocol id to match its own longer prefix: %v", err)
+	}
+	if err := store.checkIDPrefix(domain.EntityProject, "p_proto_1"); err == nil {
+		t.Fatalf("expected project id carrying the protocol prefix to be rejected")
+	}
+	if err := store.checkIDPrefix(domain.EntityProcedure, "proc-1"); err != nil {
+		t.Fatalf("expected unprefixed id to pass: %v", err)
+	}
+	if got := store.newEntityID(domain.EntityProcedure); len(got) != 32 {
+		t.Fatalf("expected empty prefix to be ignored, got %q", got)
+	}
+}
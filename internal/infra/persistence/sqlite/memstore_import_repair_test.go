@@ -0,0 +1,42 @@
+package sqlite
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"errors"
+	"testing"
+)
+
+func TestMemStoreImportRepairPolicies(t *testing.T) {
+	snapshot := func() Snapshot {
+		return Snapshot{Lines: map[string]Line{
+			"line-1": {Line: entitymodel.Line{ID: "line-1", Code: "L1", Name: "Line", Origin: "lab", GenotypeMarkerIDs: []string{"marker-gone"}}},
+		}}
+	}
+	want := EmptyListRepair{Entity: domain.EntityLine, ID: "line-1", Field: "genotype_marker_ids"}
+	for _, tc := range []struct {
+		policy      RepairPolicy
+		wantLine    bool
+		wantDropped int
+		wantErr     error
+	}{
+		{RepairPolicyDelete, false, 1, nil},
+		{RepairPolicyKeep, true, 0, nil},
+		{RepairPolicyFail, false, 0, ErrRequiredListEmptied},
+	} {
+		store := newMemStore(nil)
+		report, err := store.Import(snapshot(), WithRepairPolicy(tc.policy))
+		if !errors.Is(err, tc.wantErr) {
+			t.Fatalf("%s: expected error %v, got %v", tc.policy, tc.wantErr, err)
+		}
+		if len(report.EmptiedLists) != 1 || report.EmptiedLists[0] != want {
+			t.Fatalf("%s: unexpected report %+v", tc.policy, report)
+		}
+		if report.Received.Lines != 1 || report.Dropped.Lines != tc.wantDropped {
+			t.Fatalf("%s: unexpected counts received %+v dropped %+v", tc.policy, report.Received, report.Dropped)
+		}
+		if _, ok := store.GetLine("line-1"); ok != tc.wantLine {
+			t.Fatalf("%s: expected line present=%v", tc.policy, tc.wantLine)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func lineCodes(lines []domain.Line) []string {
+	codes := make([]string, len(lines))
+	for i, line := range lines {
+		codes[i] = line.Code
+	}
+	return codes
+}
+
+func TestFindLinesByTag(t *testing.T) {
+	store := newMemStore(nil)
+	ctx := context.Background()
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		marker, err := tx.CreateGenotypeMarker(domain.GenotypeMarker{GenotypeMarker: entitymodel.GenotypeMarker{Name: "Marker", Locus: "loc", Alleles: []string{"A"}, AssayMethod: "PCR", Interpretation: "control", Version: "v1"}})
+		if err != nil {
+			return err
+		}
+		for _, line := range []entitymodel.Line{
+			{ID: "line-a", Code: "KO", Tags: []string{"Knockout", "conditional"}},
+			{ID: "line-b", Code: "KO-REP", Tags: []string{"knockout", "Reporter"}},
+			{ID: "line-c", Code: "UNTAGGED"},
+		} {
+			line.Name, line.Origin, line.GenotypeMarkerIDs = line.Code, "lab", []string{marker.ID}
+			if _, err := tx.CreateLine(domain.Line{Line: line}); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.CreateLine(domain.Line{Line: entitymodel.Line{Code: "BLANK", Name: "Blank", Origin: "lab", GenotypeMarkerIDs: []string{marker.ID}, Tags: []string{" "}}}); err == nil || !strings.Contains(err.Error(), "must not be empty") {
+			t.Fatalf("expected blank tag to be rejected, got %v", err)
+		}
+		if _, err := tx.UpdateLine("line-a", func(l *domain.Line) error {
+			l.Tags = append(l.Tags, "KNOCKOUT")
+			return nil
+		}); err == nil || !strings.Contains(err.Error(), "duplicate tag") {
+			t.Fatalf("expected duplicate tag to be rejected, got %v", err)
+		}
+		if got, want := lineCodes(tx.FindLinesByTag("reporter")), []string{"KO-REP"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("expected transaction lookup %v, got %v", want, got)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("seed lines: %v", err)
+	}
+
+	if err := store.View(ctx, func(view domain.TransactionView) error {
+		cases := []struct {
+			name string
+			tags []string
+			want []string
+		}{
+			{"single tag", []string{"conditional"}, []string{"KO"}},
+			{"case-insensitive", []string{"KNOCKOUT"}, []string{"KO", "KO-REP"}},
+			{"all tags required", []string{"knockout", "reporter"}, []string{"KO-REP"}},
+			{"no line has every tag", []string{"conditional", "reporter"}, []string{}},
+			{"empty tag list", nil, []string{}},
+			{"blank tag", []string{""}, []string{}},
+		}
+		for _, tc := range cases {
+			if got := lineCodes(view.FindLinesByTag(tc.tags...)); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+// seedParentChain creates org-0 <- org-1 <- ... <- org-(n-1), where each
+// organism lists the previous one as its parent.
+func seedParentChain(t *testing.T, store *memStore, n int) {
+	t.Helper()
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		for i := 0; i < n; i++ {
+			organism := entitymodel.Organism{ID: fmt.Sprintf("org-%d", i), Name: fmt.Sprintf("Frog %d", i), Species: "Xenopus"}
+			if i > 0 {
+				organism.ParentIDs = []string{fmt.Sprintf("org-%d", i-1)}
+			}
+			if _, err := tx.CreateOrganism(domain.Organism{Organism: organism}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("seed parent chain: %v", err)
+	}
+}
+
+func TestLineageDepthCap(t *testing.T) {
+	store := newMemStore(nil, WithMaxLineageDepth(5))
+	seedParentChain(t, store, 10)
+
+	ancestors, err := store.Ancestors("org-4", 0)
+	if err != nil {
+		t.Fatalf("expected within-cap ancestors to succeed: %v", err)
+	}
+	if len(ancestors) != 4 || ancestors[0].ID != "org-3" || ancestors[3].ID != "org-0" {
+		t.Fatalf("expected ancestors nearest first, got %+v", ancestors)
+	}
+	if descendants, err := store.Descendants("org-4", 5); err != nil || len(descendants) != 5 || descendants[4].ID != "org-9" {
+		t.Fatalf("expected five descendants within cap, got %d (%v)", len(descendants), err)
+	}
+
+	if _, err := store.Ancestors("org-9", 0); !errors.Is(err, ErrLineageDepthExceeded) {
+		t.Fatalf("expected unbounded ancestor query to hit the cap, got %v", err)
+	}
+	if _, err := store.Descendants("org-0", 100); !errors.Is(err, ErrLineageDepthExceeded) {
+		t.Fatalf("expected caller depth above the cap to be clamped and fail, got %v", err)
+	}
+
+	truncated, err := store.Ancestors("org-9", 2)
+	if err != nil || len(truncated) != 2 || truncated[1].ID != "org-7" {
+		t.Fatalf("expected caller depth below the cap to truncate quietly, got %+v (%v)", truncated, err)
+	}
+	if _, err := store.Ancestors("missing", 0); err == nil {
+		t.Fatalf("expected unknown organism to fail")
+	}
+}
+
+func TestLineageDepthDefaultCap(t *testing.T) {
+	store := newMemStore(nil)
+	seedParentChain(t, store, DefaultMaxLineageDepth+2)
+	last := fmt.Sprintf("org-%d", DefaultMaxLineageDepth+1)
+	if _, err := store.Ancestors(last, 0); !errors.Is(err, ErrLineageDepthExceeded) {
+		t.Fatalf("expected default cap to bound traversal, got %v", err)
+	}
+	if ancestors, err := store.Ancestors(fmt.Sprintf("org-%d", DefaultMaxLineageDepth), 0); err != nil || len(ancestors) != DefaultMaxLineageDepth {
+		t.Fatalf("expected a full-depth chain within the default cap, got %d (%v)", len(ancestors), err)
+	}
+}
@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestObservationMeasurementsPopulateDataAndFilter(t *testing.T) {
+	store := newMemStore(nil)
+	ctx := context.Background()
+	weight, length, temperature := 41.5, 92.0, 21.25
+	recordedAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	var organismID, weighedID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Subject", Species: "species", Stage: domain.StageAdult}})
+		if err != nil {
+			return err
+		}
+		organismID = organism.ID
+		input := domain.Observation{Observation: entitymodel.Observation{
+			OrganismID:  &organism.ID,
+			RecordedAt:  recordedAt,
+			Observer:    "tech",
+			Weight:      &weight,
+			Length:      &length,
+			Temperature: &temperature,
+		}}
+		if err := input.ApplyObservationData(map[string]any{"weight": 1.0, "note": "fed"}); err != nil {
+			return err
+		}
+		weighed, err := tx.CreateObservation(input)
+		if err != nil {
+			return err
+		}
+		weighedID = weighed.ID
+		data := weighed.ObservationData()
+		if data[domain.ObservationDataWeight] != weight || data[domain.ObservationDataLength] != length || data[domain.ObservationDataTemperature] != temperature {
+			t.Fatalf("expected measurements copied into data, got %+v", data)
+		}
+		if data["note"] != "fed" {
+			t.Fatalf("expected existing data preserved, got %+v", data)
+		}
+		unweighed, err := tx.CreateObservation(domain.Observation{Observation: entitymodel.Observation{
+			OrganismID: &organism.ID,
+			RecordedAt: recordedAt.Add(time.Hour),
+			Observer:   "tech",
+			Length:     &length,
+		}})
+		if err != nil {
+			return err
+		}
+		if data := unweighed.ObservationData(); len(data) != 1 || data[domain.ObservationDataLength] != length {
+			t.Fatalf("expected only length in data, got %+v", data)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("seed observations: %v", err)
+	}
+
+	if err := store.View(ctx, func(view domain.TransactionView) error {
+		if all := view.ListObservationsByOrganism(organismID); len(all) != 2 {
+			t.Fatalf("expected both observations without filters, got %d", len(all))
+		}
+		weighed := view.ListObservationsByOrganism(organismID, domain.ObservationHasWeight())
+		if len(weighed) != 1 || weighed[0].ID != weighedID || *weighed[0].Weight != weight {
+			t.Fatalf("expected only the weighed observation, got %+v", weighed)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+}
+
+func TestUpdateObservationAppliesMeasurements(t *testing.T) {
+	store := newMemStore(nil)
+	ctx := context.Background()
+	weight := 12.5
+
+	var observationID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Subject", Species: "species", Stage: domain.StageAdult}})
+		if err != nil {
+			return err
+		}
+		observation, err := tx.CreateObservation(domain.Observation{Observation: entitymodel.Observation{
+			OrganismID: &organism.ID,
+			RecordedAt: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC),
+			Observer:   "tech",
+		}})
+		observationID = observation.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed observation: %v", err)
+	}
+
+	var updated domain.Observation
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		var err error
+		updated, err = tx.UpdateObservation(observationID, func(o *domain.Observation) error {
+			o.Weight = &weight
+			return nil
+		})
+		return err
+	}); err != nil {
+		t.Fatalf("update observation: %v", err)
+	}
+	if data := updated.ObservationData(); data[domain.ObservationDataWeight] != weight {
+		t.Fatalf("expected updated weight copied into data, got %+v", data)
+	}
+	if err := store.View(ctx, func(view domain.TransactionView) error {
+		stored, ok := view.FindObservation(observationID)
+		if !ok || stored.ObservationData()[domain.ObservationDataWeight] != weight {
+			t.Fatalf("expected stored data to carry the updated weight, got %+v", stored.ObservationData())
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+}
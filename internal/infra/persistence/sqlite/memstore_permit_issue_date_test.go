@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestUpdatePermitKeepsLegacyPermitsEditable(t *testing.T) {
+	ctx := context.Background()
+	validFrom := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	store := newMemStore(nil)
+	if err := store.ImportState(Snapshot{
+		Facilities: map[string]Facility{"facility-1": {Facility: entitymodel.Facility{ID: "facility-1", Code: "LAB", Name: "Lab"}}},
+		Protocols:  map[string]Protocol{"protocol-1": {Protocol: entitymodel.Protocol{ID: "protocol-1", Code: "PROT", Title: "Protocol", MaxSubjects: 5, Status: domain.ProtocolStatusDraft}}},
+		Permits: map[string]Permit{"permit-legacy": {Permit: entitymodel.Permit{
+			ID:                "permit-legacy",
+			PermitNumber:      "LEGACY",
+			Authority:         "Gov",
+			Status:            domain.PermitStatusDraft,
+			ValidFrom:         validFrom,
+			ValidUntil:        validFrom.AddDate(1, 0, 0),
+			AllowedActivities: []string{"store"},
+			FacilityIDs:       []string{"facility-1"},
+			ProtocolIDs:       []string{"protocol-1"},
+		}}},
+	}); err != nil {
+		t.Fatalf("import legacy permit: %v", err)
+	}
+	update := func(mutator func(*domain.Permit)) (domain.Permit, error) {
+		var updated domain.Permit
+		_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+			var err error
+			updated, err = tx.UpdatePermit("permit-legacy", func(p *domain.Permit) error {
+				mutator(p)
+				return nil
+			})
+			return err
+		})
+		return updated, err
+	}
+
+	if _, err := update(func(p *domain.Permit) { p.AllowedActivities = []string{"store", "transport"} }); err != nil {
+		t.Fatalf("expected legacy permit without issue date to stay editable: %v", err)
+	}
+	if _, err := update(func(p *domain.Permit) { p.IssueDate = validFrom.AddDate(0, 0, 1) }); err == nil || !strings.Contains(err.Error(), "issue_date") {
+		t.Fatalf("expected an issue date after valid_from to fail, got %v", err)
+	}
+	issued := validFrom.AddDate(0, 0, -7)
+	if updated, err := update(func(p *domain.Permit) { p.IssueDate = issued }); err != nil || !updated.IssueDate.Equal(issued) {
+		t.Fatalf("expected update to record the issue date, got %+v %v", updated.IssueDate, err)
+	}
+	if _, err := update(func(p *domain.Permit) { p.IssueDate = time.Time{} }); err == nil || !strings.Contains(err.Error(), "requires issue_date") {
+		t.Fatalf("expected clearing a recorded issue date to fail, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemStoreTransactionFindersDecorateFromPendingWrites(t *testing.T) {
+	store := newMemStore(nil)
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "VIV", Name: "Vivarium"}})
+		if err != nil {
+			return err
+		}
+		housing, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: facility.ID, Capacity: 2}})
+		if err != nil {
+			return err
+		}
+		project, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{Code: "PRJ", Title: "Project", FacilityIDs: []string{facility.ID}}})
+		if err != nil {
+			return err
+		}
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PR", Title: "Protocol", MaxSubjects: 1, Status: domain.ProtocolStatusApproved}})
+		if err != nil {
+			return err
+		}
+		procedure, err := tx.CreateProcedure(domain.Procedure{Procedure: entitymodel.Procedure{Name: "Proc", Status: domain.ProcedureStatusScheduled, ScheduledAt: time.Now().UTC(), ProtocolID: protocol.ID, ProjectID: &project.ID}})
+		if err != nil {
+			return err
+		}
+		treatment, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{
+			Name:        "Analgesia",
+			ProcedureID: procedure.ID,
+			DosagePlan:  domain.DosagePlan{Drug: "meloxicam", DoseAmount: 0.5, DoseUnit: "mg/kg"},
+		}})
+		if err != nil {
+			return err
+		}
+
+		foundProcedure, ok := tx.FindProcedure(procedure.ID)
+		if !ok || len(foundProcedure.TreatmentIDs) != 1 || foundProcedure.TreatmentIDs[0] != treatment.ID {
+			t.Fatalf("expected tx.FindProcedure to include pending treatment %s, got %+v", treatment.ID, foundProcedure.TreatmentIDs)
+		}
+		viewProcedure, ok := tx.Snapshot().FindProcedure(procedure.ID)
+		if !ok || len(viewProcedure.TreatmentIDs) != 1 || viewProcedure.TreatmentIDs[0] != treatment.ID {
+			t.Fatalf("expected snapshot FindProcedure to include pending treatment %s, got %+v", treatment.ID, viewProcedure.TreatmentIDs)
+		}
+		foundFacility, ok := tx.FindFacility(facility.ID)
+		if !ok || len(foundFacility.HousingUnitIDs) != 1 || foundFacility.HousingUnitIDs[0] != housing.ID {
+			t.Fatalf("expected tx.FindFacility to include pending housing %s, got %+v", housing.ID, foundFacility.HousingUnitIDs)
+		}
+		if len(foundFacility.ProjectIDs) != 1 || foundFacility.ProjectIDs[0] != project.ID {
+			t.Fatalf("expected tx.FindFacility to include pending project %s, got %+v", project.ID, foundFacility.ProjectIDs)
+		}
+		foundProject, ok := tx.FindProject(project.ID)
+		if !ok || len(foundProject.ProcedureIDs) != 1 || foundProject.ProcedureIDs[0] != procedure.ID {
+			t.Fatalf("expected tx.FindProject to include pending procedure %s, got %+v", procedure.ID, foundProject.ProcedureIDs)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("run transaction: %v", err)
+	}
+}
@@ -49,11 +49,12 @@ func TestPermitRequiresRequiredArraysSQLite(t *testing.T) {
 		if err != nil {
 			return err
 		}
-		if _, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PERM-0", Authority: "Auth", ValidFrom: now, ValidUntil: now.Add(time.Hour), FacilityIDs: []string{facility.ID}, ProtocolIDs: []string{protocol.ID}}}); err == nil {
+		if _, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PERM-0", Authority: "Auth", IssueDate: now, ValidFrom: now, ValidUntil: now.Add(time.Hour), FacilityIDs: []string{facility.ID}, ProtocolIDs: []string{protocol.ID}}}); err == nil {
 			t.Fatalf("expected error for missing allowed activities")
 		}
 		permit, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PERM-1",
 			Authority:         "Auth",
+			IssueDate:         now,
 			ValidFrom:         now,
 			ValidUntil:        now.Add(time.Hour),
 			AllowedActivities: []string{"store"},
@@ -190,6 +191,7 @@ func TestSampleRequiresChainOfCustodySQLite(t *testing.T) {
 			Status:          domain.SampleStatusStored,
 			StorageLocation: "loc",
 			AssayType:       "assay",
+			CollectedBy:     "tech",
 			ChainOfCustody: []domain.SampleCustodyEvent{{
 				Actor:     "tech",
 				Location:  "loc",
@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestMemStoreSelfCheckReportsDanglingReferenceAndDuplicateCode(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore(nil)
+	var housingID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "VIV", Name: "Vivarium"}})
+		if err != nil {
+			return err
+		}
+		housing, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: facility.ID, Capacity: 2}})
+		housingID = housing.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	report, err := store.SelfCheck(ctx)
+	if err != nil {
+		t.Fatalf("self check: %v", err)
+	}
+	if !report.Passed {
+		t.Fatalf("expected consistent store to pass, got %+v", report)
+	}
+
+	store.mu.Lock()
+	housing := store.state.housing[housingID]
+	housing.FacilityID = "facility-gone"
+	store.state.housing[housingID] = housing
+	store.state.facilities["dup"] = Facility{Facility: entitymodel.Facility{ID: "dup", Code: "VIV", Name: "Annex"}}
+	store.mu.Unlock()
+
+	report, err = store.SelfCheck(ctx)
+	if err != nil {
+		t.Fatalf("self check: %v", err)
+	}
+	if report.Passed {
+		t.Fatalf("expected self check to fail, got %+v", report)
+	}
+	if len(report.ReferentialIntegrity) != 1 || !strings.Contains(report.ReferentialIntegrity[0], `facility_id references missing facility "facility-gone"`) {
+		t.Fatalf("expected dangling facility reference, got %v", report.ReferentialIntegrity)
+	}
+	if len(report.NaturalKeys) != 1 || !strings.Contains(report.NaturalKeys[0], `facility (code) "VIV" shared by`) {
+		t.Fatalf("expected duplicate facility code, got %v", report.NaturalKeys)
+	}
+	if len(report.Validation) != 0 {
+		t.Fatalf("expected no validation problems, got %v", report.Validation)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := store.SelfCheck(cancelled); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancellation, got %v", err)
+	}
+}
+
+func TestMemStoreNaturalKeyFieldsResolveOnRecords(t *testing.T) {
+	records := map[domain.EntityType]any{
+		domain.EntityOrganism:       Organism{},
+		domain.EntityCohort:         Cohort{},
+		domain.EntityHousingUnit:    HousingUnit{},
+		domain.EntityFacility:       Facility{},
+		domain.EntityBreeding:       BreedingUnit{},
+		domain.EntityLine:           Line{},
+		domain.EntityStrain:         Strain{},
+		domain.EntityGenotypeMarker: GenotypeMarker{},
+		domain.EntityProcedure:      Procedure{},
+		domain.EntityTreatment:      Treatment{},
+		domain.EntityObservation:    Observation{},
+		domain.EntitySample:         Sample{},
+		domain.EntityProtocol:       Protocol{},
+		domain.EntityPermit:         Permit{},
+		domain.EntityProject:        Project{},
+		domain.EntitySupplyItem:     SupplyItem{},
+	}
+	for name, keys := range entitymodel.NaturalKeys() {
+		record, ok := records[schemaEntityType(name)]
+		if !ok {
+			t.Fatalf("schema entity %s has no record type", name)
+		}
+		for _, key := range keys {
+			for _, field := range key.Fields {
+				if _, ok := jsonField(reflect.ValueOf(record), field); !ok {
+					t.Fatalf("%s natural key field %q is not a field of %T", name, field, record)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"testing"
+	"time"
+)
+
+func seedOneOfEach(tx domain.Transaction) error {
+	now := time.Now().UTC()
+	marker, err := tx.CreateGenotypeMarker(domain.GenotypeMarker{GenotypeMarker: entitymodel.GenotypeMarker{Name: "Marker", Locus: "loc", Alleles: []string{"A"}, AssayMethod: "PCR", Interpretation: "ctrl", Version: "v1"}})
+	if err != nil {
+		return err
+	}
+	line, err := tx.CreateLine(domain.Line{Line: entitymodel.Line{Code: "L", Name: "Line", Origin: "field", GenotypeMarkerIDs: []string{marker.ID}}})
+	if err != nil {
+		return err
+	}
+	strain, err := tx.CreateStrain(domain.Strain{Strain: entitymodel.Strain{Code: "S", Name: "Strain", LineID: line.ID, GenotypeMarkerIDs: []string{marker.ID}}})
+	if err != nil {
+		return err
+	}
+	facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "FAC", Name: "Facility", Zone: "Z", AccessPolicy: "policy"}})
+	if err != nil {
+		return err
+	}
+	project, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{Code: "PRJ", Title: "Project", FacilityIDs: []string{facility.ID}}})
+	if err != nil {
+		return err
+	}
+	housing, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Housing", FacilityID: facility.ID, Capacity: 2, Environment: domain.HousingEnvironmentAquatic}})
+	if err != nil {
+		return err
+	}
+	protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PROT", Title: "Protocol", MaxSubjects: 5}})
+	if err != nil {
+		return err
+	}
+	cohort, err := tx.CreateCohort(domain.Cohort{Cohort: entitymodel.Cohort{Name: "Cohort", Purpose: "Study", ProjectID: &project.ID, HousingID: &housing.ID, ProtocolID: &protocol.ID}})
+	if err != nil {
+		return err
+	}
+	if _, err := tx.CreateBreedingUnit(domain.BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{Name: "Breeding", Strategy: "pair", LineID: &line.ID, StrainID: &strain.ID}}); err != nil {
+		return err
+	}
+	organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Org", Species: "Spec", LineID: &line.ID, StrainID: &strain.ID, CohortID: &cohort.ID, HousingID: &housing.ID}})
+	if err != nil {
+		return err
+	}
+	procedure, err := tx.CreateProcedure(domain.Procedure{Procedure: entitymodel.Procedure{Name: "Proc", Status: domain.ProcedureStatusScheduled, ScheduledAt: now, ProtocolID: protocol.ID, CohortID: &cohort.ID, OrganismIDs: []string{organism.ID}}})
+	if err != nil {
+		return err
+	}
+	if _, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{Name: "Treat", Status: domain.TreatmentStatusPlanned, ProcedureID: procedure.ID, OrganismIDs: []string{organism.ID}, CohortIDs: []string{cohort.ID}, DosagePlan: domain.DosagePlan{Drug: "compound", DoseAmount: 1, DoseUnit: "mg"}}}); err != nil {
+		return err
+	}
+	if _, err := tx.CreateObservation(domain.Observation{Observation: entitymodel.Observation{ProcedureID: &procedure.ID, OrganismID: &organism.ID, RecordedAt: now, Observer: "tech"}}); err != nil {
+		return err
+	}
+	if _, err := tx.CreateSample(domain.Sample{Sample: entitymodel.Sample{Identifier: "S1", SourceType: "organism", OrganismID: &organism.ID, FacilityID: facility.ID, CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "cold", AssayType: "type", CollectedBy: "tech", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "cold", Timestamp: now}}}}); err != nil {
+		return err
+	}
+	if _, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PERMIT", Authority: "Gov", IssueDate: now, ValidFrom: now, ValidUntil: now.Add(time.Hour), AllowedActivities: []string{"store"}, FacilityIDs: []string{facility.ID}, ProtocolIDs: []string{protocol.ID}}}); err != nil {
+		return err
+	}
+	_, err = tx.CreateSupplyItem(domain.SupplyItem{SupplyItem: entitymodel.SupplyItem{SKU: "SKU", Name: "Item", QuantityOnHand: 1, Unit: "unit", FacilityIDs: []string{facility.ID}, ProjectIDs: []string{project.ID}}})
+	return err
+}
+
+func TestSnapshotStatsEmpty(t *testing.T) {
+	if stats := newMemStore(nil).ExportState().Stats(); stats != (SnapshotStats{}) {
+		t.Fatalf("expected zero stats for empty store, got %+v", stats)
+	}
+	if stats := (Snapshot{}).Stats(); stats != (SnapshotStats{}) {
+		t.Fatalf("expected zero stats for zero snapshot, got %+v", stats)
+	}
+}
+
+func TestSnapshotStatsCountsEachEntityType(t *testing.T) {
+	store := newMemStore(nil)
+	if _, err := store.RunInTransaction(context.Background(), seedOneOfEach); err != nil {
+		t.Fatalf("seed store: %v", err)
+	}
+	stats := store.ExportState().Stats()
+	want := SnapshotStats{
+		Organisms: 1, Cohorts: 1, HousingUnits: 1, Facilities: 1, BreedingUnits: 1,
+		Lines: 1, Strains: 1, GenotypeMarkers: 1, Procedures: 1, Treatments: 1,
+		Observations: 1, Samples: 1, Protocols: 1, Permits: 1, Projects: 1, SupplyItems: 1,
+	}
+	if stats != want {
+		t.Fatalf("expected one entity of each type, got %+v", stats)
+	}
+}
+
+func TestSnapshotStatsSubAndTotal(t *testing.T) {
+	received := SnapshotStats{Organisms: 3, Lines: 2, SupplyItems: 1}
+	kept := SnapshotStats{Organisms: 3, Lines: 1}
+	if got := received.Sub(kept); got != (SnapshotStats{Lines: 1, SupplyItems: 1}) {
+		t.Fatalf("unexpected difference %+v", got)
+	}
+	if received.Total() != 6 || (SnapshotStats{}).Total() != 0 {
+		t.Fatalf("unexpected totals %d and %d", received.Total(), (SnapshotStats{}).Total())
+	}
+}
@@ -106,7 +106,7 @@ func TestSQLiteStoreNormalizesLifecycleStatuses(t *testing.T) {
 		treatment, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{
 			Name:        "Treatment",
 			ProcedureID: procedure.ID,
-			DosagePlan:  "dose",
+			DosagePlan:  domain.DosagePlan{Drug: "dose", DoseAmount: 1, DoseUnit: "mg"},
 			OrganismIDs: []string{organism.ID},
 		}})
 		if err != nil {
@@ -135,6 +135,7 @@ func TestSQLiteStoreNormalizesLifecycleStatuses(t *testing.T) {
 			CollectedAt:     now,
 			StorageLocation: "cold",
 			AssayType:       "assay",
+			CollectedBy:     "tech",
 			ChainOfCustody:  []domain.SampleCustodyEvent{{Actor: "tech", Location: "cold", Timestamp: now}},
 		}})
 		if err != nil {
@@ -252,7 +253,7 @@ func TestSQLiteStoreRejectsInvalidLifecycleStatuses(t *testing.T) {
 			Name:        "InvalidTreatment",
 			ProcedureID: procedure.ID,
 			Status:      domain.TreatmentStatus("invalid"),
-			DosagePlan:  "dose",
+			DosagePlan:  domain.DosagePlan{Drug: "dose", DoseAmount: 1, DoseUnit: "mg"},
 		}}); err == nil {
 			return fmt.Errorf("expected invalid treatment status to error")
 		}
@@ -266,6 +267,7 @@ func TestSQLiteStoreRejectsInvalidLifecycleStatuses(t *testing.T) {
 			Status:          domain.SampleStatus("invalid"),
 			StorageLocation: "cold",
 			AssayType:       "assay",
+			CollectedBy:     "tech",
 			ChainOfCustody:  []domain.SampleCustodyEvent{{Actor: "tech", Location: "cold", Timestamp: now}},
 		}}); err == nil {
 			return fmt.Errorf("expected invalid sample status to error")
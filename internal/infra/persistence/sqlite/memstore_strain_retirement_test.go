@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+// seedStrainUsage creates strains that are free, used by a living organism,
+// used only by a deceased organism, and targeted by a breeding unit.
+func seedStrainUsage(t *testing.T, store *memStore) {
+	t.Helper()
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		marker, err := tx.CreateGenotypeMarker(domain.GenotypeMarker{GenotypeMarker: entitymodel.GenotypeMarker{Name: "Marker", Locus: "loc", Alleles: []string{"A"}, AssayMethod: "PCR", Interpretation: "ctrl", Version: "v1"}})
+		if err != nil {
+			return err
+		}
+		line, err := tx.CreateLine(domain.Line{Line: entitymodel.Line{Code: "L1", Name: "Line", Origin: "field", GenotypeMarkerIDs: []string{marker.ID}}})
+		if err != nil {
+			return err
+		}
+		for _, id := range []string{"strain-free", "strain-alive", "strain-dead", "strain-bred"} {
+			if _, err := tx.CreateStrain(domain.Strain{Strain: entitymodel.Strain{ID: id, Code: id, Name: id, LineID: line.ID}}); err != nil {
+				return err
+			}
+		}
+		alive, dead, bred := "strain-alive", "strain-dead", "strain-bred"
+		if _, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{ID: "org-alive", Name: "Alive", Species: "mouse", Stage: domain.StageAdult, StrainID: &alive}}); err != nil {
+			return err
+		}
+		if _, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{ID: "org-dead", Name: "Dead", Species: "mouse", Stage: domain.StageDeceased, StrainID: &dead}}); err != nil {
+			return err
+		}
+		_, err = tx.CreateBreedingUnit(domain.BreedingUnit{BreedingUnit: entitymodel.BreedingUnit{Name: "Pair", Strategy: "pair", TargetStrainID: &bred}})
+		return err
+	}); err != nil {
+		t.Fatalf("seed strains: %v", err)
+	}
+}
+
+func retirableStrainIDs(t *testing.T, store *memStore) []string {
+	t.Helper()
+	var ids []string
+	if err := store.View(context.Background(), func(view domain.TransactionView) error {
+		for _, strain := range view.ListRetirableStrains() {
+			ids = append(ids, strain.ID)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+	return ids
+}
+
+func TestListRetirableStrains(t *testing.T) {
+	store := newMemStore(nil)
+	seedStrainUsage(t, store)
+	if got := strings.Join(retirableStrainIDs(t, store), ","); got != "strain-dead,strain-free" {
+		t.Fatalf("expected strains without active organisms or breeding units, got %s", got)
+	}
+}
+
+func TestRetireStrainsRefusesStrainWithActiveOrganism(t *testing.T) {
+	store := newMemStore(nil)
+	seedStrainUsage(t, store)
+	_, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		_, err := tx.RetireStrains([]string{"strain-free", "strain-alive"}, "colony closed")
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), `active organism "org-alive"`) {
+		t.Fatalf("expected refusal naming the active organism, got %v", err)
+	}
+	if strain, _ := store.GetStrain("strain-free"); strain.RetiredAt != nil {
+		t.Fatalf("expected rejected batch to leave every strain unretired")
+	}
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		_, err := tx.RetireStrains([]string{"strain-free"}, "  ")
+		return err
+	}); err == nil {
+		t.Fatalf("expected blank reason to be rejected")
+	}
+}
+
+func TestRetireStrainsStampsRetirement(t *testing.T) {
+	store := newMemStore(nil)
+	seedStrainUsage(t, store)
+	var changes []domain.Change
+	store.AddCommitObserver(domain.ChangeSinkFunc(func(_ context.Context, batch []domain.Change) error {
+		changes = append(changes, batch...)
+		return nil
+	}))
+
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		retired, err := tx.RetireStrains([]string{"strain-free", "strain-dead", "strain-free"}, "no living stock")
+		if err != nil {
+			return err
+		}
+		if len(retired) != 2 {
+			t.Fatalf("expected one result per distinct id, got %d", len(retired))
+		}
+		for _, strain := range retired {
+			if strain.RetiredAt == nil || strain.RetirementReason == nil || *strain.RetirementReason != "no living stock" {
+				t.Fatalf("expected retirement stamped on %s, got %+v", strain.ID, strain)
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("retire strains: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Entity != domain.EntityStrain || changes[0].Action != domain.ActionUpdate {
+		t.Fatalf("expected one strain update per retired strain, got %+v", changes)
+	}
+	if ids := retirableStrainIDs(t, store); len(ids) != 0 {
+		t.Fatalf("expected retired strains to leave the retirable list, got %v", ids)
+	}
+
+	changes = nil
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		_, err := tx.RetireStrains([]string{"strain-free"}, "again")
+		return err
+	}); err != nil {
+		t.Fatalf("retire again: %v", err)
+	}
+	if strain, _ := store.GetStrain("strain-free"); len(changes) != 0 || *strain.RetirementReason != "no living stock" {
+		t.Fatalf("expected retiring an already retired strain to be a no-op, got %d changes and reason %q", len(changes), *strain.RetirementReason)
+	}
+}
@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConsumeSupplyItem(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "supply.db")
+	store, err := NewStore(path, domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.DB().Close() })
+	ctx := context.Background()
+
+	var supplyID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Name: "Lab"}})
+		if err != nil {
+			return err
+		}
+		project, err := tx.CreateProject(domain.Project{Project: entitymodel.Project{Code: "PRJ", Title: "Project", FacilityIDs: []string{facility.ID}}})
+		if err != nil {
+			return err
+		}
+		supply, err := tx.CreateSupplyItem(domain.SupplyItem{SupplyItem: entitymodel.SupplyItem{
+			SKU:            "FEED-1",
+			Name:           "Feed",
+			QuantityOnHand: 10,
+			Unit:           "kg",
+			FacilityIDs:    []string{facility.ID},
+			ProjectIDs:     []string{project.ID},
+		}})
+		if err != nil {
+			return err
+		}
+		supplyID = supply.ID
+		return nil
+	}); err != nil {
+		t.Fatalf("seed supply item: %v", err)
+	}
+
+	consume := func(quantity float64) (domain.SupplyItem, error) {
+		var consumed domain.SupplyItem
+		_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+			var err error
+			consumed, err = tx.ConsumeSupplyItem(supplyID, quantity, "tech")
+			return err
+		})
+		return consumed, err
+	}
+
+	consumed, err := consume(4)
+	if err != nil {
+		t.Fatalf("consume: %v", err)
+	}
+	if consumed.QuantityOnHand != 6 || consumed.Status != domain.SupplyStatusActive {
+		t.Fatalf("expected 6 remaining and active status, got %d %q", consumed.QuantityOnHand, consumed.Status)
+	}
+	if _, err := consume(2); err != nil {
+		t.Fatalf("partial consume: %v", err)
+	}
+	if _, err := consume(5); err == nil || !strings.Contains(err.Error(), "cannot consume") {
+		t.Fatalf("expected over-consumption error, got %v", err)
+	}
+	if _, err := consume(0); err == nil {
+		t.Fatalf("expected zero quantity to be rejected")
+	}
+	consumed, err = consume(4)
+	if err != nil {
+		t.Fatalf("consume remaining: %v", err)
+	}
+	if consumed.QuantityOnHand != 0 || consumed.Status != domain.SupplyStatusConsumed {
+		t.Fatalf("expected consumed status at zero stock, got %d %q", consumed.QuantityOnHand, consumed.Status)
+	}
+
+	reopened, err := NewStore(path, domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("reopen store: %v", err)
+	}
+	t.Cleanup(func() { _ = reopened.DB().Close() })
+	if err := reopened.View(ctx, func(view domain.TransactionView) error {
+		supply, ok := view.FindSupplyItem(supplyID)
+		if !ok {
+			t.Fatalf("expected supply item after reopen")
+		}
+		if supply.QuantityOnHand != 0 || supply.Status != domain.SupplyStatusConsumed {
+			t.Fatalf("expected consumed supply after reopen, got %d %q", supply.QuantityOnHand, supply.Status)
+		}
+		if events, _ := supply.SupplyAttributes()[supplyConsumptionLogKey].([]any); len(events) != 3 {
+			t.Fatalf("expected three consumption events after reopen, got %d", len(events))
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+}
@@ -45,11 +45,15 @@ func TestMemStoreBasicLifecycle(t *testing.T) {
 		t.Fatalf("expected 1 organism")
 	}
 	snapshot := store.ExportState()
-	store.ImportState(Snapshot{})
+	if err := store.ImportState(Snapshot{}); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 	if len(store.ListOrganisms()) != 0 {
 		t.Fatalf("expected cleared state")
 	}
-	store.ImportState(snapshot)
+	if err := store.ImportState(snapshot); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 	if len(store.ListOrganisms()) != 1 {
 		t.Fatalf("expected restored organism")
 	}
@@ -179,11 +183,11 @@ func TestMigrateSnapshotRelationships(t *testing.T) {
 			"obs-1": {Observation: entitymodel.Observation{ID: "obs-1", ProcedureID: ptr("proc-1"), Observer: "Tech", RecordedAt: now}},
 		},
 		Samples: map[string]domain.Sample{
-			"sample-1": {Sample: entitymodel.Sample{ID: "sample-1", Identifier: "S1", SourceType: "blood", FacilityID: "fac-1", OrganismID: ptr("org-1"), CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "freezer", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "freezer", Timestamp: now}}}},
+			"sample-1": {Sample: entitymodel.Sample{ID: "sample-1", Identifier: "S1", SourceType: "blood", FacilityID: "fac-1", OrganismID: ptr("org-1"), CollectedAt: now, Status: domain.SampleStatusStored, StorageLocation: "freezer", CollectedBy: "tech", ChainOfCustody: []domain.SampleCustodyEvent{{Actor: "tech", Location: "freezer", Timestamp: now}}}},
 		},
 		Protocols: protocols,
 		Permits: map[string]domain.Permit{
-			"permit-1": {Permit: entitymodel.Permit{ID: "permit-1", PermitNumber: "P1", Authority: "Gov", Status: domain.PermitStatusApproved, ValidFrom: now, ValidUntil: now.AddDate(1, 0, 0), FacilityIDs: []string{"fac-1", "fac-1"}, ProtocolIDs: []string{"prot-1"}}},
+			"permit-1": {Permit: entitymodel.Permit{ID: "permit-1", PermitNumber: "P1", Authority: "Gov", Status: domain.PermitStatusApproved, IssueDate: now, ValidFrom: now, ValidUntil: now.AddDate(1, 0, 0), FacilityIDs: []string{"fac-1", "fac-1"}, ProtocolIDs: []string{"prot-1"}}},
 		},
 		Projects: map[string]domain.Project{
 			"proj-1": {Project: entitymodel.Project{ID: "proj-1", Code: "P1", Title: "Project", FacilityIDs: []string{"fac-1", "fac-1"}}},
@@ -193,7 +197,9 @@ func TestMigrateSnapshotRelationships(t *testing.T) {
 		},
 	}
 
-	store.ImportState(snapshot)
+	if err := store.ImportState(snapshot); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 
 	facility, ok := store.GetFacility("fac-1")
 	if !ok {
@@ -251,6 +257,7 @@ func TestMemStoreStateNormalization(t *testing.T) {
 
 		permit, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PER",
 			Authority:         "Gov",
+			IssueDate:         now,
 			ValidFrom:         now,
 			ValidUntil:        now.Add(time.Hour),
 			AllowedActivities: []string{"store"},
@@ -266,6 +273,7 @@ func TestMemStoreStateNormalization(t *testing.T) {
 		if _, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PER-2",
 			Authority:         "Gov",
 			Status:            domain.PermitStatus("invalid"),
+			IssueDate:         now,
 			ValidFrom:         now,
 			ValidUntil:        now.Add(time.Hour),
 			AllowedActivities: []string{"store"},
@@ -521,6 +529,7 @@ func TestSnapshotRoundTripCoverageSQLite(t *testing.T) {
 		PermitNumber:      "PERMIT",
 		Authority:         "Gov",
 		Status:            domain.PermitStatusApproved,
+		IssueDate:         now,
 		ValidFrom:         now,
 		ValidUntil:        now.Add(time.Hour),
 		AllowedActivities: []string{"store"},
@@ -603,10 +612,11 @@ func TestMemStoreProcedureObservationSampleLifecycle(t *testing.T) {
 		}
 		treatment, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{Name: "Treat",
 			Status:            domain.TreatmentStatusPlanned,
+			DosagePlan:        domain.DosagePlan{Drug: "compound", DoseAmount: 1, DoseUnit: "mg"},
 			ProcedureID:       procedure.ID,
 			OrganismIDs:       []string{organism.ID},
 			AdministrationLog: []string{},
-			AdverseEvents:     []string{}},
+			AdverseEvents:     []domain.AdverseEvent{}},
 		})
 		if err != nil {
 			return err
@@ -640,6 +650,7 @@ func TestMemStoreProcedureObservationSampleLifecycle(t *testing.T) {
 			Status:          domain.SampleStatusStored,
 			StorageLocation: "loc",
 			OrganismID:      &organism.ID,
+			CollectedBy:     "tech",
 			ChainOfCustody: []domain.SampleCustodyEvent{{
 				Actor:     "tech",
 				Location:  "loc",
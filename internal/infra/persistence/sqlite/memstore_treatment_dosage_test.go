@@ -0,0 +1,178 @@
+package sqlite
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTreatmentDosagePlanValidation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dosage.db")
+	store, err := NewStore(path, domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { _ = store.DB().Close() })
+	ctx := context.Background()
+
+	var procedureID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PR-D", Title: "Dosage", MaxSubjects: 1, Status: domain.ProtocolStatusApproved}})
+		if err != nil {
+			return err
+		}
+		procedure, err := tx.CreateProcedure(domain.Procedure{Procedure: entitymodel.Procedure{
+			Name:        "Proc",
+			Status:      domain.ProcedureStatusScheduled,
+			ScheduledAt: time.Now().UTC(),
+			ProtocolID:  protocol.ID,
+		}})
+		if err != nil {
+			return err
+		}
+		procedureID = procedure.ID
+		return nil
+	}); err != nil {
+		t.Fatalf("seed procedure: %v", err)
+	}
+
+	valid := domain.DosagePlan{Drug: "meloxicam", DoseAmount: 0.5, DoseUnit: "mg/kg", FrequencyPerDay: 2, DurationDays: 5}
+	var treatmentID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		treatment, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{
+			Name:        "Analgesia",
+			ProcedureID: procedureID,
+			DosagePlan:  valid,
+		}})
+		if err != nil {
+			return err
+		}
+		if treatment.DosagePlan != valid {
+			t.Fatalf("expected dosage plan %+v, got %+v", valid, treatment.DosagePlan)
+		}
+		treatmentID = treatment.ID
+		return nil
+	}); err != nil {
+		t.Fatalf("create valid treatment: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		plan domain.DosagePlan
+		want string
+	}{
+		{name: "zero dose", plan: domain.DosagePlan{Drug: "meloxicam", DoseUnit: "mg/kg", DurationDays: 5}, want: "dose_amount"},
+		{name: "negative duration", plan: domain.DosagePlan{Drug: "meloxicam", DoseAmount: 1, DoseUnit: "mg/kg", DurationDays: -1}, want: "duration_days"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+				_, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{
+					Name:        "Invalid " + tc.name,
+					ProcedureID: procedureID,
+					DosagePlan:  tc.plan,
+				}})
+				return err
+			})
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected create error mentioning %s, got %v", tc.want, err)
+			}
+			_, err = store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+				_, err := tx.UpdateTreatment(treatmentID, func(treatment *domain.Treatment) error {
+					treatment.DosagePlan = tc.plan
+					return nil
+				})
+				return err
+			})
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected update error mentioning %s, got %v", tc.want, err)
+			}
+		})
+	}
+
+	reopened, err := NewStore(path, domain.NewRulesEngine())
+	if err != nil {
+		t.Fatalf("reopen store: %v", err)
+	}
+	t.Cleanup(func() { _ = reopened.DB().Close() })
+	if err := reopened.View(ctx, func(view domain.TransactionView) error {
+		treatment, ok := view.FindTreatment(treatmentID)
+		if !ok || treatment.DosagePlan != valid {
+			t.Fatalf("expected persisted dosage plan to survive rejected updates, got %+v", treatment.DosagePlan)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("view treatment: %v", err)
+	}
+}
+
+func TestLegacyDosagePlanStaysEditable(t *testing.T) {
+	store := newMemStore(nil)
+	ctx := context.Background()
+	var treatmentID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "PR-L", Title: "Legacy", MaxSubjects: 1, Status: domain.ProtocolStatusApproved}})
+		if err != nil {
+			return err
+		}
+		procedure, err := tx.CreateProcedure(domain.Procedure{Procedure: entitymodel.Procedure{Name: "Proc", Status: domain.ProcedureStatusScheduled, ScheduledAt: time.Now().UTC(), ProtocolID: protocol.ID}})
+		if err != nil {
+			return err
+		}
+		treatment, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{
+			Name:        "Analgesia",
+			ProcedureID: procedure.ID,
+			DosagePlan:  domain.DosagePlan{Drug: "meloxicam", DoseAmount: 0.5, DoseUnit: "mg/kg", FrequencyPerDay: 2, DurationDays: 5},
+		}})
+		treatmentID = treatment.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed treatment: %v", err)
+	}
+
+	legacyText := "10mg daily"
+	snapshot := store.ExportState()
+	legacy := snapshot.Treatments[treatmentID]
+	legacy.DosagePlan = domain.DosagePlan{LegacyText: &legacyText}
+	snapshot.Treatments[treatmentID] = legacy
+	if err := store.ImportState(snapshot); err != nil {
+		t.Fatalf("import legacy treatment: %v", err)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdateTreatment(treatmentID, func(treatment *domain.Treatment) error {
+			treatment.Name = "Renamed"
+			return nil
+		})
+		return err
+	}); err != nil {
+		t.Fatalf("expected legacy treatment to accept unrelated updates, got %v", err)
+	}
+
+	_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdateTreatment(treatmentID, func(treatment *domain.Treatment) error {
+			treatment.DosagePlan.Drug = "meloxicam"
+			return nil
+		})
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), "dose_amount") {
+		t.Fatalf("expected a partially structured legacy plan to be validated, got %v", err)
+	}
+
+	_, err = store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.CreateTreatment(domain.Treatment{Treatment: entitymodel.Treatment{
+			Name:        "New legacy",
+			ProcedureID: legacy.ProcedureID,
+			DosagePlan:  domain.DosagePlan{LegacyText: &legacyText},
+		}})
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), "legacy_text") {
+		t.Fatalf("expected create with legacy_text to fail, got %v", err)
+	}
+}
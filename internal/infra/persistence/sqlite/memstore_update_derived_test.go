@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+	"context"
+	"testing"
+)
+
+func TestUpdateFacilityMutatorSeesDerivedHousing(t *testing.T) {
+	store := newMemStore(nil)
+	ctx := context.Background()
+	var facilityID, housingID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "VIV", Name: "Vivarium"}})
+		if err != nil {
+			return err
+		}
+		housing, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: facility.ID, Capacity: 2}})
+		if err != nil {
+			return err
+		}
+		facilityID, housingID = facility.ID, housing.ID
+		return nil
+	}); err != nil {
+		t.Fatalf("seed facility: %v", err)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		var seen []string
+		updated, err := tx.UpdateFacility(facilityID, func(f *domain.Facility) error {
+			seen = append([]string(nil), f.HousingUnitIDs...)
+			f.Name = "Vivarium East"
+			f.HousingUnitIDs = nil
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(seen) != 1 || seen[0] != housingID {
+			t.Fatalf("expected mutator to see housing %s, got %v", housingID, seen)
+		}
+		if updated.Name != "Vivarium East" || len(updated.HousingUnitIDs) != 1 || updated.HousingUnitIDs[0] != housingID {
+			t.Fatalf("expected derived housing recomputed after update, got %+v", updated)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("update facility: %v", err)
+	}
+}
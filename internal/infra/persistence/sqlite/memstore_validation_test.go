@@ -217,6 +217,7 @@ func TestMemStoreValidationErrors(t *testing.T) {
 			Status:          domain.SampleStatusStored,
 			StorageLocation: "cold",
 			AssayType:       "PCR",
+			CollectedBy:     "tech",
 			ChainOfCustody: []domain.SampleCustodyEvent{{
 				Actor:     "tech",
 				Location:  "bench",
@@ -267,6 +268,7 @@ func TestMemStoreValidationErrors(t *testing.T) {
 		p, err := tx.CreatePermit(domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PER-1",
 			Authority:         "Gov",
 			Status:            domain.PermitStatusApproved,
+			IssueDate:         now,
 			ValidFrom:         now,
 			ValidUntil:        now.Add(time.Hour),
 			AllowedActivities: []string{"use"},
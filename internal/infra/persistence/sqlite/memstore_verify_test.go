@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func seedHousingForVerify(t *testing.T, store *memStore) string {
+	t.Helper()
+	var housingID string
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "FAC", Name: "Facility", Zone: "Z", AccessPolicy: "open"}})
+		if err != nil {
+			return err
+		}
+		housing, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: facility.ID, Capacity: 2}})
+		housingID = housing.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed housing: %v", err)
+	}
+	return housingID
+}
+
+// forceHousingCapacity writes straight into committed state, bypassing the
+// import repairs that would otherwise clamp an invalid capacity.
+func forceHousingCapacity(store *memStore, id string, capacity int) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	housing := store.state.housing[id]
+	housing.Capacity = capacity
+	store.state.housing[id] = housing
+}
+
+func TestVerifyOnReadRejectsInvalidHousingUnit(t *testing.T) {
+	store := newMemStore(nil, WithVerifyOnRead(true))
+	housingID := seedHousingForVerify(t, store)
+	if _, ok := store.GetHousingUnit(housingID); !ok {
+		t.Fatalf("expected valid housing unit to be readable")
+	}
+	if err := store.Verify(domain.EntityHousingUnit, housingID); err != nil {
+		t.Fatalf("expected valid housing unit to verify, got %v", err)
+	}
+
+	if housing, ok, err := domain.GetVerified[HousingUnit](store, domain.EntityHousingUnit, housingID); !ok || err != nil || housing.ID != housingID {
+		t.Fatalf("expected valid housing unit to pass verified read, got %+v ok=%v err=%v", housing, ok, err)
+	}
+
+	forceHousingCapacity(store, housingID, -3)
+	housing, ok, err := domain.GetVerified[HousingUnit](store, domain.EntityHousingUnit, housingID)
+	var invalid InvalidEntityError
+	if !ok || housing.Capacity != -3 || !errors.As(err, &invalid) || invalid.ID != housingID {
+		t.Fatalf("expected invalid housing unit with InvalidEntityError, got %+v ok=%v err=%v", housing, ok, err)
+	}
+	if _, ok := store.GetHousingUnit(housingID); !ok {
+		t.Fatalf("expected plain reads to keep returning the stored housing unit")
+	}
+	units, err := domain.ListVerified[HousingUnit](store, domain.EntityHousingUnit)
+	if len(units) != 1 || !errors.As(err, &invalid) {
+		t.Fatalf("expected list to return the unit with its validation error, got %d units, err=%v", len(units), err)
+	}
+	if _, ok, err := store.GetVerified(domain.EntityHousingUnit, "missing"); ok || err != nil {
+		t.Fatalf("expected missing housing unit to be not found, got ok=%v err=%v", ok, err)
+	}
+	if _, err := store.ListVerified(domain.EntityType("unknown")); err == nil {
+		t.Fatalf("expected unknown entity to fail")
+	}
+
+	err = store.Verify(domain.EntityHousingUnit, housingID)
+	if !errors.As(err, &invalid) || invalid.Entity != domain.EntityHousingUnit || invalid.ID != housingID {
+		t.Fatalf("expected InvalidEntityError for housing unit, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "capacity -3 must be positive") {
+		t.Fatalf("expected capacity violation in error, got %v", err)
+	}
+	if err := store.Verify(domain.EntityHousingUnit, "missing"); err != nil {
+		t.Fatalf("expected nil for missing entity, got %v", err)
+	}
+}
+
+func TestVerifyOnReadDisabledByDefault(t *testing.T) {
+	store := newMemStore(nil)
+	housingID := seedHousingForVerify(t, store)
+	forceHousingCapacity(store, housingID, -3)
+	housing, ok, err := domain.GetVerified[HousingUnit](store, domain.EntityHousingUnit, housingID)
+	if !ok || err != nil || housing.Capacity != -3 {
+		t.Fatalf("expected unverified read to return stored entity, got %+v ok=%v err=%v", housing, ok, err)
+	}
+}
+
+func TestVerifiedReadsCoverEveryEntity(t *testing.T) {
+	store := newMemStore(nil, WithVerifyOnRead(true))
+	housingID := seedHousingForVerify(t, store)
+	for _, entity := range []domain.EntityType{
+		domain.EntityOrganism, domain.EntityCohort, domain.EntityHousingUnit, domain.EntityFacility,
+		domain.EntityBreeding, domain.EntityLine, domain.EntityStrain, domain.EntityGenotypeMarker,
+		domain.EntityProcedure, domain.EntityTreatment, domain.EntityObservation, domain.EntitySample,
+		domain.EntityProtocol, domain.EntityPermit, domain.EntityProject, domain.EntitySupplyItem,
+	} {
+		if _, err := store.ListVerified(entity); err != nil {
+			t.Fatalf("list %s: %v", entity, err)
+		}
+	}
+	housing, _ := store.GetHousingUnit(housingID)
+	facility, ok, err := domain.GetVerified[Facility](store, domain.EntityFacility, housing.FacilityID)
+	plain, _ := store.GetFacility(housing.FacilityID)
+	if !ok || err != nil || len(facility.HousingUnitIDs) != len(plain.HousingUnitIDs) {
+		t.Fatalf("expected verified facility to match GetFacility, got %+v ok=%v err=%v", facility, ok, err)
+	}
+	if _, _, err := domain.GetVerified[Organism](store, domain.EntityFacility, housing.FacilityID); err == nil {
+		t.Fatalf("expected mismatched record type to fail")
+	}
+}
@@ -0,0 +1,203 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"colonycore/pkg/domain"
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestUpdateOrganismVersionConflict(t *testing.T) {
+	store := newMemStore(nil)
+	ctx := context.Background()
+	var organismID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus"}})
+		organismID = organism.ID
+		return err
+	}); err != nil {
+		t.Fatalf("seed organism: %v", err)
+	}
+
+	// Both writers read the organism before either of them commits.
+	first, _ := store.GetOrganism(organismID)
+	second, _ := store.GetOrganism(organismID)
+	if first.Version != 1 || second.Version != 1 {
+		t.Fatalf("expected created organism at version 1, got %d and %d", first.Version, second.Version)
+	}
+
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		updated, err := tx.UpdateOrganismIfVersion(organismID, first.Version, func(o *domain.Organism) error {
+			o.Name = "First"
+			o.Version = 99
+			return nil
+		})
+		if err == nil && updated.Version != 2 {
+			t.Fatalf("expected version 2 after update, got %d", updated.Version)
+		}
+		return err
+	}); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+
+	_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdateOrganismIfVersion(organismID, second.Version, func(o *domain.Organism) error {
+			o.Name = "Second"
+			return nil
+		})
+		return err
+	})
+	var conflict domain.ErrVersionConflict
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+	if conflict.Entity != domain.EntityOrganism || conflict.ID != organismID || conflict.Expected != 1 || conflict.Actual != 2 {
+		t.Fatalf("unexpected conflict %+v", conflict)
+	}
+	if stored, _ := store.GetOrganism(organismID); stored.Name != "First" || stored.Version != 2 {
+		t.Fatalf("expected conflicting update to roll back, got %q at version %d", stored.Name, stored.Version)
+	}
+}
+
+func TestUpdateOrganismZeroVersionSkipsCheck(t *testing.T) {
+	store := newMemStore(nil)
+	ctx := context.Background()
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus"}})
+		if err != nil {
+			return err
+		}
+		for i := 0; i < 2; i++ {
+			if organism, err = tx.UpdateOrganismIfVersion(organism.ID, 0, func(*domain.Organism) error { return nil }); err != nil {
+				return err
+			}
+		}
+		if organism, err = tx.UpdateOrganism(organism.ID, func(*domain.Organism) error { return nil }); err != nil {
+			return err
+		}
+		if organism.Version != 4 {
+			t.Fatalf("expected version 4 after three updates, got %d", organism.Version)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("unchecked updates: %v", err)
+	}
+}
+
+func TestProtocolAndHousingVersionsIncrement(t *testing.T) {
+	store := newMemStore(nil)
+	ctx := context.Background()
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		facility, err := tx.CreateFacility(domain.Facility{Facility: entitymodel.Facility{Code: "VIV", Name: "Vivarium"}})
+		if err != nil {
+			return err
+		}
+		housing, err := tx.CreateHousingUnit(domain.HousingUnit{HousingUnit: entitymodel.HousingUnit{Name: "Tank", FacilityID: facility.ID, Capacity: 2}})
+		if err != nil {
+			return err
+		}
+		protocol, err := tx.CreateProtocol(domain.Protocol{Protocol: entitymodel.Protocol{Code: "P1", Title: "Protocol", MaxSubjects: 5}})
+		if err != nil {
+			return err
+		}
+		if housing.Version != 1 || protocol.Version != 1 {
+			t.Fatalf("expected created records at version 1, got housing %d protocol %d", housing.Version, protocol.Version)
+		}
+		if housing, err = tx.UpdateHousingUnit(housing.ID, func(h *domain.HousingUnit) error {
+			h.Capacity = 3
+			return nil
+		}); err != nil {
+			return err
+		}
+		if protocol, err = tx.UpdateProtocol(protocol.ID, func(p *domain.Protocol) error {
+			p.Title = "Protocol v2"
+			return nil
+		}); err != nil {
+			return err
+		}
+		if protocol, err = tx.AddProtocolReviewer(protocol.ID, "reviewer-1"); err != nil {
+			return err
+		}
+		if housing.Version != 2 || protocol.Version != 3 {
+			t.Fatalf("expected updates to bump versions, got housing %d protocol %d", housing.Version, protocol.Version)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("versioned updates: %v", err)
+	}
+}
+
+func TestUpdateOrganismForceOverridesStaleVersion(t *testing.T) {
+	store := newMemStore(nil)
+	ctx := context.Background()
+	var organismID string
+	if _, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		organism, err := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Frog", Species: "Xenopus"}})
+		if err != nil {
+			return err
+		}
+		organismID = organism.ID
+		_, err = tx.UpdateOrganism(organism.ID, func(o *domain.Organism) error {
+			o.Name = "Moved"
+			return nil
+		})
+		return err
+	}); err != nil {
+		t.Fatalf("seed organism: %v", err)
+	}
+	const stale = 1
+	rename := func(o *domain.Organism) error {
+		o.Name = "Forced"
+		return nil
+	}
+
+	_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+		_, err := tx.UpdateOrganismIfVersion(organismID, stale, rename)
+		return err
+	})
+	var conflict domain.ErrVersionConflict
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected non-force update to conflict, got %v", err)
+	}
+
+	for name, ctx := range map[string]context.Context{
+		"no override": ctx,
+		"no reason":   domain.WithForceUpdate(ctx, "admin", " "),
+		"no actor":    domain.WithForceUpdate(ctx, "", "fix import"),
+	} {
+		_, err := store.RunInTransaction(ctx, func(tx domain.Transaction) error {
+			_, err := tx.UpdateOrganismForce(organismID, rename)
+			return err
+		})
+		if !errors.Is(err, domain.ErrForceUpdateUnattributed) {
+			t.Fatalf("%s: expected ErrForceUpdateUnattributed, got %v", name, err)
+		}
+	}
+
+	var observed []domain.Change
+	store.AddCommitObserver(domain.ChangeSinkFunc(func(_ context.Context, changes []domain.Change) error {
+		observed = changes
+		return nil
+	}))
+	forceCtx := domain.WithForceUpdate(ctx, "admin", "repair stale import")
+	if _, err := store.RunInTransaction(forceCtx, func(tx domain.Transaction) error {
+		updated, err := tx.UpdateOrganismForce(organismID, rename)
+		if err == nil && updated.Version != 3 {
+			t.Fatalf("expected force update to bump version to 3, got %d", updated.Version)
+		}
+		return err
+	}); err != nil {
+		t.Fatalf("force update: %v", err)
+	}
+	if stored, _ := store.GetOrganism(organismID); stored.Name != "Forced" {
+		t.Fatalf("expected force update to persist, got %q", stored.Name)
+	}
+	if len(observed) != 1 || observed[0].Action != domain.ActionForceUpdate || observed[0].Entity != domain.EntityOrganism {
+		t.Fatalf("expected one force_update change, got %+v", observed)
+	}
+	if override := observed[0].Override; override == nil || override.Actor != "admin" || override.Reason != "repair stale import" {
+		t.Fatalf("expected force_update change to carry the actor and reason, got %+v", override)
+	}
+}
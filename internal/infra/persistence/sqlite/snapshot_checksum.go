@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Checksum returns the hex-encoded SHA-256 digest of the snapshot's canonical
+// JSON form. The snapshot is marshalled and re-encoded through a generic
+// value so every object, including extension attribute payloads, is written
+// with sorted keys and no insignificant whitespace.
+func (s Snapshot) Checksum() (string, error) {
+	canonical, err := canonicalSnapshotJSON(s)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// VerifyChecksum recomputes the snapshot checksum and reports a mismatch
+// against expected, which is compared case-insensitively.
+func (s Snapshot) VerifyChecksum(expected string) error {
+	actual, err := s.Checksum()
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(actual, strings.TrimSpace(expected)) {
+		return fmt.Errorf("snapshot checksum mismatch: expected %s, computed %s", expected, actual)
+	}
+	return nil
+}
+
+func canonicalSnapshotJSON(s Snapshot) ([]byte, error) {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("marshal snapshot: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("decode snapshot: %w", err)
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(generic); err != nil {
+		return nil, fmt.Errorf("encode canonical snapshot: %w", err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
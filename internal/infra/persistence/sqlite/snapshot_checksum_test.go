@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotChecksumDeterministic(t *testing.T) {
+	store := newMemStore(nil)
+	if _, err := store.RunInTransaction(context.Background(), seedOneOfEach); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	first, err := store.ExportState().Checksum()
+	if err != nil {
+		t.Fatalf("checksum: %v", err)
+	}
+	second, err := store.ExportState().Checksum()
+	if err != nil {
+		t.Fatalf("checksum: %v", err)
+	}
+	if first != second || len(first) != 64 {
+		t.Fatalf("expected identical 64-char digests, got %q and %q", first, second)
+	}
+	if err := store.ExportState().VerifyChecksum(strings.ToUpper(first)); err != nil {
+		t.Fatalf("verify matching checksum: %v", err)
+	}
+
+	mutated := store.ExportState()
+	for id, facility := range mutated.Facilities {
+		facility.Name += "!"
+		mutated.Facilities[id] = facility
+	}
+	changed, err := mutated.Checksum()
+	if err != nil {
+		t.Fatalf("checksum mutated: %v", err)
+	}
+	if changed == first {
+		t.Fatalf("expected single-byte change to alter checksum")
+	}
+	if err := mutated.VerifyChecksum(first); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected mismatch error, got %v", err)
+	}
+}
+
+func TestSnapshotChecksumEmptyIsStable(t *testing.T) {
+	const want = "2615579a8d1226d50ecec3831e7a41badb3d3454f37819f7c3d11025f62c9f92"
+	got, err := Snapshot{}.Checksum()
+	if err != nil {
+		t.Fatalf("checksum: %v", err)
+	}
+	if got != want {
+		t.Fatalf("expected empty snapshot checksum %s, got %s", want, got)
+	}
+}
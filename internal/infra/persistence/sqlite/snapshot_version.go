@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+// ErrSnapshotTooNew reports a snapshot stamped with an entity-model schema
+// version newer than the one this binary was generated from. Importing it would
+// silently drop fields the binary does not know about, so the import is
+// refused instead.
+var ErrSnapshotTooNew = errors.New("snapshot schema version is newer than supported")
+
+// CheckSnapshotVersion reports whether a snapshot stamped with version can be
+// imported by this binary. Unstamped snapshots predate version stamping and are
+// treated as older; equal and older versions are accepted.
+func CheckSnapshotVersion(version string) error {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return nil
+	}
+	cmp, err := compareSchemaVersions(version, entitymodel.SchemaVersion)
+	if err != nil {
+		return err
+	}
+	if cmp > 0 {
+		return fmt.Errorf("%w: snapshot %s, binary %s", ErrSnapshotTooNew, version, entitymodel.SchemaVersion)
+	}
+	return nil
+}
+
+// compareSchemaVersions compares two MAJOR.MINOR.PATCH versions, returning -1,
+// 0, or 1 as a is older than, equal to, or newer than b.
+func compareSchemaVersions(a, b string) (int, error) {
+	pa, err := parseSchemaVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	pb, err := parseSchemaVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range pa {
+		switch {
+		case pa[i] < pb[i]:
+			return -1, nil
+		case pa[i] > pb[i]:
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func parseSchemaVersion(version string) ([3]int, error) {
+	var out [3]int
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) != len(out) {
+		return out, fmt.Errorf("invalid schema version %q: want MAJOR.MINOR.PATCH", version)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return out, fmt.Errorf("invalid schema version %q: want MAJOR.MINOR.PATCH", version)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
@@ -27,7 +27,7 @@ type Store struct {
 }
 
 // NewStore constructs a snapshotting SQLite-backed persistent store.
-func NewStore(path string, engine *RulesEngine) (*Store, error) {
+func NewStore(path string, engine *RulesEngine, opts ...StoreOption) (*Store, error) {
 	if path == "" {
 		path = "colonycore.db"
 	}
@@ -47,7 +47,7 @@ func NewStore(path string, engine *RulesEngine) (*Store, error) {
 	if err := applyEntityModelDDL(db); err != nil {
 		return nil, fmt.Errorf("apply entity-model ddl: %w", err)
 	}
-	ms := newMemStore(engine)
+	ms := newMemStore(engine, opts...)
 	s := &Store{memStore: ms, db: db, path: path}
 	if err := s.load(); err != nil {
 		return nil, err
@@ -56,6 +56,7 @@ func NewStore(path string, engine *RulesEngine) (*Store, error) {
 }
 
 var sqliteBuckets = []string{
+	"schema_version",
 	"organisms",
 	"cohorts",
 	"housing",
@@ -96,6 +97,20 @@ func (s *Store) load() error {
 		return nil
 	}
 	snapshot := Snapshot{}
+	// Check the stamped version before decoding any entity: buckets written by
+	// a newer binary may carry fields this one rejects as undeclared, and the
+	// caller should see ErrSnapshotTooNew rather than an unknown-field error.
+	for _, r := range raws {
+		if r.bucket != "schema_version" {
+			continue
+		}
+		if err := json.Unmarshal(r.payload, &snapshot.SchemaVersion); err != nil {
+			return fmt.Errorf("decode schema_version: %w", err)
+		}
+	}
+	if err := CheckSnapshotVersion(snapshot.SchemaVersion); err != nil {
+		return fmt.Errorf("import %s: %w", s.path, err)
+	}
 	for _, r := range raws {
 		switch r.bucket {
 		case "organisms":
@@ -164,7 +179,9 @@ func (s *Store) load() error {
 			}
 		}
 	}
-	s.ImportState(snapshot)
+	if err := s.ImportState(snapshot); err != nil {
+		return fmt.Errorf("import %s: %w", s.path, err)
+	}
 	return nil
 }
 
@@ -184,6 +201,8 @@ func (s *Store) persist() (retErr error) {
 	for _, bucket := range sqliteBuckets {
 		var data []byte
 		switch bucket {
+		case "schema_version":
+			data, err = json.Marshal(snapshot.SchemaVersion)
 		case "organisms":
 			data, err = json.Marshal(snapshot.Organisms)
 		case "cohorts":
@@ -233,14 +252,16 @@ func (s *Store) persist() (retErr error) {
 }
 
 // RunInTransaction applies the provided function within a transaction, then snapshots state to SQLite if successful.
+// Commit observers are notified only once the snapshot has been persisted.
 func (s *Store) RunInTransaction(ctx context.Context, fn func(tx Transaction) error) (Result, error) {
-	res, err := s.memStore.RunInTransaction(ctx, fn)
+	res, commit, err := s.memStore.runInTransaction(ctx, fn)
 	if err != nil {
 		return res, err
 	}
 	if pErr := s.persist(); pErr != nil {
 		return res, pErr
 	}
+	commit.notify(ctx)
 	return res, nil
 }
 
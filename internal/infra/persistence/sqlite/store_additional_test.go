@@ -40,6 +40,7 @@ func TestSQLiteStorePersistMarshalError(t *testing.T) {
 			Status:          domain.SampleStatusStored,
 			StorageLocation: "cold",
 			AssayType:       "chromatography",
+			CollectedBy:     "tech",
 			ChainOfCustody: []domain.SampleCustodyEvent{{
 				Actor:     "tech",
 				Location:  "cold",
@@ -101,7 +102,11 @@ func TestSQLiteStoreLoadAllBuckets(t *testing.T) {
 	t.Cleanup(func() { _ = store.DB().Close() })
 
 	for _, bucket := range sqliteBuckets {
-		if _, err := store.DB().Exec(`INSERT INTO state(bucket,payload) VALUES(?,?)`, bucket, []byte(`{}`)); err != nil {
+		payload := []byte(`{}`)
+		if bucket == "schema_version" {
+			payload = []byte(`""`)
+		}
+		if _, err := store.DB().Exec(`INSERT INTO state(bucket,payload) VALUES(?,?)`, bucket, payload); err != nil {
 			t.Fatalf("insert %s: %v", bucket, err)
 		}
 	}
@@ -128,7 +133,9 @@ func TestSQLiteStorePersistMarshalErrorImportThenPersist(t *testing.T) {
 	if err := organism.SetCoreAttributes(map[string]any{"score": math.NaN()}); err != nil {
 		t.Fatalf("set core attributes: %v", err)
 	}
-	store.ImportState(Snapshot{Organisms: map[string]Organism{organism.ID: organism}})
+	if err := store.ImportState(Snapshot{Organisms: map[string]Organism{organism.ID: organism}}); err != nil {
+		t.Fatalf("import state: %v", err)
+	}
 
 	if err := store.persist(); err == nil {
 		t.Fatalf("expected persist marshal error")
@@ -7,6 +7,8 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -88,3 +90,84 @@ func (r *recordingSQLiteExec) Exec(query string, _ ...any) (sql.Result, error) {
 	r.execs = append(r.execs, query)
 	return driver.RowsAffected(1), nil
 }
+
+func TestSQLiteStoreRefusesNewerSchemaVersion(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "newer.db")
+	store, err := NewStore(path, domain.NewRulesEngine())
+	if err != nil {
+		t.Skipf("sqlite unavailable: %v", err)
+	}
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		_, e := tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Persist"}})
+		return e
+	}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	var stamped string
+	if err := store.DB().QueryRow(`SELECT payload FROM state WHERE bucket='schema_version'`).Scan(&stamped); err != nil {
+		t.Fatalf("read schema_version bucket: %v", err)
+	}
+	if stamped != `"`+entitymodel.SchemaVersion+`"` {
+		t.Fatalf("expected persisted schema version %s, got %s", entitymodel.SchemaVersion, stamped)
+	}
+	if _, err := store.DB().Exec(`UPDATE state SET payload=? WHERE bucket='schema_version'`, []byte(`"999.0.0"`)); err != nil {
+		t.Fatalf("stamp newer version: %v", err)
+	}
+	_ = store.DB().Close()
+
+	if _, err := NewStore(path, domain.NewRulesEngine()); !errors.Is(err, ErrSnapshotTooNew) {
+		t.Fatalf("expected ErrSnapshotTooNew reopening newer database, got %v", err)
+	}
+}
+
+func TestSQLiteStoreRefusesNewerSchemaVersionWithUnknownField(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "newer-field.db")
+	store, err := NewStore(path, domain.NewRulesEngine())
+	if err != nil {
+		t.Skipf("sqlite unavailable: %v", err)
+	}
+	var organism domain.Organism
+	if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+		var e error
+		organism, e = tx.CreateOrganism(domain.Organism{Organism: entitymodel.Organism{Name: "Persist"}})
+		return e
+	}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	var payload []byte
+	if err := store.DB().QueryRow(`SELECT payload FROM state WHERE bucket='organisms'`).Scan(&payload); err != nil {
+		t.Fatalf("read organisms bucket: %v", err)
+	}
+	var organisms map[string]map[string]any
+	if err := json.Unmarshal(payload, &organisms); err != nil {
+		t.Fatalf("decode organisms bucket: %v", err)
+	}
+	organisms[organism.ID]["added_in_a_later_release"] = "value"
+	payload, err = json.Marshal(organisms)
+	if err != nil {
+		t.Fatalf("encode organisms bucket: %v", err)
+	}
+	if _, err := store.DB().Exec(`UPDATE state SET payload=? WHERE bucket='organisms'`, payload); err != nil {
+		t.Fatalf("write organisms bucket: %v", err)
+	}
+	_ = store.DB().Close()
+
+	if _, err := NewStore(path, domain.NewRulesEngine()); !errors.Is(err, domain.ErrUnknownField) {
+		t.Fatalf("expected ErrUnknownField for an undeclared field at the current version, got %v", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("reopen database: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE state SET payload=? WHERE bucket='schema_version'`, []byte(`"999.0.0"`)); err != nil {
+		t.Fatalf("stamp newer version: %v", err)
+	}
+	_ = db.Close()
+
+	if _, err := NewStore(path, domain.NewRulesEngine()); !errors.Is(err, ErrSnapshotTooNew) {
+		t.Fatalf("expected ErrSnapshotTooNew for a newer snapshot with an unknown field, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"colonycore/pkg/domain"
+)
+
+// ListRetirableStrains returns the strains that are not yet retired and that
+// no non-terminal organism and no breeding unit references, ordered by ID.
+// Organisms in the deceased or retired stage do not keep a strain alive.
+// Breeding units have no closed state, so any unit naming the strain as its
+// strain or target strain counts as active until it is deleted.
+func (v transactionView) ListRetirableStrains() []Strain {
+	inUse := strainsInUse(v.state)
+	var out []Strain
+	for id, strain := range v.state.strains {
+		if strain.RetiredAt != nil {
+			continue
+		}
+		if _, ok := inUse[id]; ok {
+			continue
+		}
+		out = append(out, cloneStrain(strain))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// RetireStrains stamps RetiredAt and RetirementReason on each strain and
+// returns them in the order given. Every strain is checked before any is
+// changed, so one missing ID or one strain still referenced by a non-terminal
+// organism rejects the whole batch. Strains that are already retired are
+// returned unchanged.
+func (tx *transaction) RetireStrains(ids []string, reason string) ([]Strain, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.New("strain retirement requires a reason")
+	}
+	ids = dedupeStrings(ids)
+	for _, id := range ids {
+		if _, ok := tx.state.strains[id]; !ok {
+			return nil, fmt.Errorf("strain %q not found", id)
+		}
+		if organismID, ok := activeOrganismForStrain(&tx.state, id); ok {
+			return nil, fmt.Errorf("strain %q still referenced by active organism %q", id, organismID)
+		}
+	}
+	out := make([]Strain, 0, len(ids))
+	for _, id := range ids {
+		current := tx.state.strains[id]
+		before := cloneStrain(current)
+		if current.RetiredAt != nil {
+			out = append(out, before)
+			continue
+		}
+		retiredAt := tx.now
+		current.RetiredAt = &retiredAt
+		current.RetirementReason = &reason
+		current.UpdatedAt = tx.now
+		tx.state.strains[id] = cloneStrain(current)
+		after := cloneStrain(current)
+		beforePayload, err := changePayloadFromValue(before)
+		if err != nil {
+			return nil, err
+		}
+		afterPayload, err := changePayloadFromValue(after)
+		if err != nil {
+			return nil, err
+		}
+		tx.recordChange(Change{Entity: domain.EntityStrain, Action: domain.ActionUpdate, Before: beforePayload, After: afterPayload})
+		out = append(out, after)
+	}
+	return out, nil
+}
+
+// strainsInUse returns the IDs of strains referenced by a non-terminal
+// organism or by any breeding unit.
+func strainsInUse(state *memoryState) map[string]struct{} {
+	inUse := make(map[string]struct{})
+	for _, organism := range state.organisms {
+		if organism.StrainID != nil && !isTerminalStage(organism.Stage) {
+			inUse[*organism.StrainID] = struct{}{}
+		}
+	}
+	for _, unit := range state.breeding {
+		for _, strainID := range []*string{unit.StrainID, unit.TargetStrainID} {
+			if strainID != nil {
+				inUse[*strainID] = struct{}{}
+			}
+		}
+	}
+	return inUse
+}
+
+// activeOrganismForStrain returns the lowest ID of a non-terminal organism
+// referencing strainID.
+func activeOrganismForStrain(state *memoryState, strainID string) (string, bool) {
+	found := ""
+	for id, organism := range state.organisms {
+		if organism.StrainID == nil || *organism.StrainID != strainID || isTerminalStage(organism.Stage) {
+			continue
+		}
+		if found == "" || id < found {
+			found = id
+		}
+	}
+	return found, found != ""
+}
+
+// isTerminalStage reports whether an organism in stage has left the colony.
+func isTerminalStage(stage domain.LifecycleStage) bool {
+	return stage == domain.StageDeceased || stage == domain.StageRetired
+}
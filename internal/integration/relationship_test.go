@@ -96,7 +96,8 @@ func TestIntegrationEntityRelationships(t *testing.T) {
 				Title:       "Protocol 1",
 				Description: strPtr("baseline protocol"),
 				MaxSubjects: 10,
-				Status:      domain.ProtocolStatusApproved},
+				Status:      domain.ProtocolStatusApproved,
+				ReviewerIDs: []string{"reviewer-1"}},
 			})
 			if err != nil {
 				t.Fatalf("create protocol: %v", err)
@@ -143,7 +144,7 @@ func TestIntegrationEntityRelationships(t *testing.T) {
 				Status:      domain.TreatmentStatusPlanned,
 				ProcedureID: procedure.ID,
 				OrganismIDs: []string{organism.ID, organism.ID},
-				DosagePlan:  "Plan A"},
+				DosagePlan:  domain.DosagePlan{Drug: "Plan A", DoseAmount: 1, DoseUnit: "mg"}},
 			})
 			if err != nil {
 				t.Fatalf("create treatment: %v", err)
@@ -188,6 +189,7 @@ func TestIntegrationEntityRelationships(t *testing.T) {
 				CollectedAt:     now,
 				Status:          domain.SampleStatusStored,
 				StorageLocation: "freezer-a",
+				CollectedBy:     "tech",
 				ChainOfCustody: []domain.SampleCustodyEvent{{
 					Actor:     "tech",
 					Location:  "freezer-a",
@@ -203,6 +205,7 @@ func TestIntegrationEntityRelationships(t *testing.T) {
 				CollectedAt:     now,
 				Status:          domain.SampleStatusStored,
 				StorageLocation: "freezer-a",
+				CollectedBy:     "tech",
 				ChainOfCustody: []domain.SampleCustodyEvent{{
 					Actor:     "tech",
 					Location:  "freezer-a",
@@ -219,6 +222,7 @@ func TestIntegrationEntityRelationships(t *testing.T) {
 				CollectedAt:     now,
 				Status:          domain.SampleStatusStored,
 				StorageLocation: "freezer-a",
+				CollectedBy:     "tech",
 				ChainOfCustody: []domain.SampleCustodyEvent{{
 					Actor:     "tech",
 					Location:  "freezer-a",
@@ -235,6 +239,7 @@ func TestIntegrationEntityRelationships(t *testing.T) {
 			if _, _, err := svc.CreatePermit(ctx, domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PERM-ERR",
 				Authority:   "Gov",
 				Status:      domain.PermitStatusSubmitted,
+				IssueDate:   now,
 				ValidFrom:   now,
 				ValidUntil:  now.AddDate(1, 0, 0),
 				FacilityIDs: []string{facility.ID},
@@ -246,6 +251,7 @@ func TestIntegrationEntityRelationships(t *testing.T) {
 			permit, res, err := svc.CreatePermit(ctx, domain.Permit{Permit: entitymodel.Permit{PermitNumber: "PERM-1",
 				Authority:         "Gov",
 				Status:            domain.PermitStatusApproved,
+				IssueDate:         now,
 				ValidFrom:         now,
 				ValidUntil:        now.AddDate(1, 0, 0),
 				AllowedActivities: []string{"activity"},
@@ -1,4 +1,5 @@
-// Program entitymodeldiff validates schema fingerprints to prevent breaking changes.
+// Program entitymodeldiff validates schema fingerprints to prevent breaking
+// changes and, with -migration, drafts the Postgres migration between them.
 package main
 
 import (
@@ -38,9 +39,10 @@ type stateSpec struct {
 }
 
 type schemaDoc struct {
-	Version  string                `json:"version"`
-	Enums    map[string]enumSpec   `json:"enums"`
-	Entities map[string]entitySpec `json:"entities"`
+	Version     string                     `json:"version"`
+	Enums       map[string]enumSpec        `json:"enums"`
+	Entities    map[string]entitySpec      `json:"entities"`
+	Definitions map[string]json.RawMessage `json:"definitions"`
 }
 
 type fingerprintDoc struct {
@@ -63,12 +65,20 @@ type relationshipFingerprint struct {
 	Storage     string `json:"storage"`
 }
 
+// diffOptions relaxes individual compatibility checks for intentional changes.
+type diffOptions struct {
+	// allowNewRequired accepts fields newly added to an entity's required list.
+	allowNewRequired bool
+}
+
 var exitFunc = os.Exit
 
 func main() {
 	schemaPath := flag.String("schema", "docs/schema/entity-model.json", "path to the entity model schema")
 	fingerprintPath := flag.String("fingerprint", "docs/schema/entity-model.fingerprint.json", "path to the fingerprint file")
 	write := flag.Bool("write", false, "rewrite the fingerprint file instead of diffing")
+	allowNewRequired := flag.Bool("allow-new-required", false, "accept fields newly added to an existing entity's required list")
+	migration := flag.Bool("migration", false, "print a best-effort Postgres migration from the fingerprint to the schema instead of diffing")
 	flag.Parse()
 
 	doc, err := loadSchema(*schemaPath)
@@ -94,7 +104,16 @@ func main() {
 		exitErr(err)
 	}
 
-	issues := diffFingerprints(baseline, current)
+	if *migration {
+		script, err := buildMigration(baseline, doc)
+		if err != nil {
+			exitErr(err)
+		}
+		fmt.Print(script)
+		return
+	}
+
+	issues := diffFingerprints(baseline, current, diffOptions{allowNewRequired: *allowNewRequired})
 	if len(issues) > 0 {
 		for _, issue := range issues {
 			fmt.Println(issue)
@@ -180,7 +199,11 @@ func writeFingerprint(path string, fp fingerprintDoc) error {
 	return nil
 }
 
-func diffFingerprints(old, updated fingerprintDoc) []string {
+// diffFingerprints reports every change from old to updated that breaks
+// existing readers or writers: removals, relationship and state changes, and,
+// unless opts allows it, fields that became required on an existing entity.
+// Optional field additions are backward compatible and never reported.
+func diffFingerprints(old, updated fingerprintDoc, opts diffOptions) []string {
 	var issues []string
 
 	for name, oldEnt := range old.Entities {
@@ -191,6 +214,9 @@ func diffFingerprints(old, updated fingerprintDoc) []string {
 		}
 		issues = append(issues, diffList(fmt.Sprintf("entity %s", name), "property", oldEnt.Properties, newEnt.Properties)...)
 		issues = append(issues, diffList(fmt.Sprintf("entity %s", name), "required field", oldEnt.Required, newEnt.Required)...)
+		if !opts.allowNewRequired {
+			issues = append(issues, diffAdded(fmt.Sprintf("entity %s", name), "required field", oldEnt.Required, newEnt.Required)...)
+		}
 		issues = append(issues, diffList(fmt.Sprintf("entity %s", name), "invariant", oldEnt.Invariants, newEnt.Invariants)...)
 
 		for relName, oldRel := range oldEnt.Relationships {
@@ -240,6 +266,21 @@ func diffList(scope, label string, oldVals, newVals []string) []string {
 	return issues
 }
 
+// diffAdded reports entries present in newVals but not oldVals.
+func diffAdded(scope, label string, oldVals, newVals []string) []string {
+	var issues []string
+	oldSet := make(map[string]struct{}, len(oldVals))
+	for _, v := range oldVals {
+		oldSet[v] = struct{}{}
+	}
+	for _, v := range newVals {
+		if _, ok := oldSet[v]; !ok {
+			issues = append(issues, fmt.Sprintf("%s %s added: %s", scope, label, v))
+		}
+	}
+	return issues
+}
+
 func diffStates(entity string, oldState, newState *stateSpec) string {
 	if oldState == nil {
 		return ""
@@ -45,12 +45,42 @@ func TestDiffFingerprintsDetectsRemovals(t *testing.T) {
 		},
 	}
 
-	issues := diffFingerprints(baseline, current)
+	issues := diffFingerprints(baseline, current, diffOptions{})
 	if len(issues) == 0 {
 		t.Fatalf("expected removals detected, got %v", issues)
 	}
 }
 
+func TestDiffFingerprintsRequiredFieldAdditions(t *testing.T) {
+	baseline := fingerprintDoc{
+		Entities: map[string]entityFingerprint{
+			"Facility": {Properties: []string{"id", "name"}, Required: []string{"id", "name"}},
+		},
+	}
+	optional := fingerprintDoc{
+		Entities: map[string]entityFingerprint{
+			"Facility": {Properties: []string{"id", "name", "timezone"}, Required: []string{"id", "name"}},
+			"Room":     {Properties: []string{"id", "label"}, Required: []string{"id", "label"}},
+		},
+	}
+	if issues := diffFingerprints(baseline, optional, diffOptions{}); len(issues) != 0 {
+		t.Fatalf("expected optional field and new entity to be compatible, got %v", issues)
+	}
+
+	required := fingerprintDoc{
+		Entities: map[string]entityFingerprint{
+			"Facility": {Properties: []string{"id", "name", "timezone"}, Required: []string{"id", "name", "timezone"}},
+		},
+	}
+	issues := diffFingerprints(baseline, required, diffOptions{})
+	if len(issues) != 1 || issues[0] != "entity Facility required field added: timezone" {
+		t.Fatalf("expected new required field reported, got %v", issues)
+	}
+	if issues := diffFingerprints(baseline, required, diffOptions{allowNewRequired: true}); len(issues) != 0 {
+		t.Fatalf("expected --allow-new-required to suppress the addition, got %v", issues)
+	}
+}
+
 func TestDiffStatesDetectsChanges(t *testing.T) {
 	base := &stateSpec{Enum: "state", Initial: "draft", Terminal: []string{"done"}}
 	changed := &stateSpec{Enum: "state", Initial: "new", Terminal: []string{"done"}}
@@ -124,7 +154,7 @@ func TestDiffFingerprintsVersionAndRelationshipChange(t *testing.T) {
 			},
 		},
 	}
-	issues := diffFingerprints(baseline, current)
+	issues := diffFingerprints(baseline, current, diffOptions{})
 	joined := strings.Join(issues, "\n")
 	if !strings.Contains(joined, "schema version changed") {
 		t.Fatalf("expected schema version change reported, got %v", issues)
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// propertySpec captures the subset of a JSON Schema property needed to pick a
+// Postgres column type.
+type propertySpec struct {
+	Type   string `json:"type"`
+	Format string `json:"format"`
+	Ref    string `json:"$ref"`
+}
+
+const manualReview = "-- MANUAL REVIEW REQUIRED: "
+
+// buildMigration renders a best-effort Postgres migration from the baseline
+// fingerprint to the current schema. Additive changes (new entities, new
+// properties, new relationships) are emitted as SQL; destructive or tightening
+// changes are emitted only as commented statements for manual review.
+func buildMigration(old fingerprintDoc, doc schemaDoc) (string, error) {
+	var b strings.Builder
+	b.WriteString("-- Migration generated by internal/tools/entitymodel/diff. Review before applying.\n")
+	b.WriteString("-- Dialect: postgres\n")
+	fmt.Fprintf(&b, "-- From fingerprint version %s to schema version %s.\n", orUnknown(old.Version), orUnknown(doc.Version))
+
+	var statements, constraints, reviews []string
+
+	for _, name := range sortedKeys(doc.Entities) {
+		ent := doc.Entities[name]
+		table := tableName(name)
+		oldEnt, existed := old.Entities[name]
+		if !existed {
+			stmt, fks, err := createEntityTable(doc, name)
+			if err != nil {
+				return "", err
+			}
+			statements = append(statements, fmt.Sprintf("-- Entity %s added.\n%s", name, stmt))
+			constraints = append(constraints, fks...)
+			for _, relName := range sortedKeys(ent.Relationships) {
+				if stmt, ok := joinTableFor(doc, name, relName); ok {
+					statements = append(statements, fmt.Sprintf("-- Entity %s relationship %s added.\n%s", name, relName, stmt))
+				}
+			}
+			continue
+		}
+
+		for _, prop := range sortedKeys(ent.Properties) {
+			if contains(oldEnt.Properties, prop) {
+				if contains(ent.Required, prop) && !contains(oldEnt.Required, prop) {
+					reviews = append(reviews, fmt.Sprintf("%sentity %s field %s became required; backfill existing rows, then run:\n-- ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", manualReview, name, prop, table, prop))
+				}
+				continue
+			}
+			if _, isRel := ent.Relationships[prop]; isRel && relationshipStorage(doc, name, prop) != storageFK {
+				continue
+			}
+			colType, err := columnType(doc, ent.Properties[prop])
+			if err != nil {
+				return "", fmt.Errorf("entity %s property %s: %w", name, prop, err)
+			}
+			statements = append(statements, fmt.Sprintf("-- Entity %s property %s added.\nALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s;", name, prop, table, prop, colType))
+			if contains(ent.Required, prop) {
+				reviews = append(reviews, fmt.Sprintf("%sentity %s property %s is required; backfill existing rows, then run:\n-- ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", manualReview, name, prop, table, prop))
+			}
+		}
+
+		for _, relName := range sortedKeys(ent.Relationships) {
+			if _, ok := oldEnt.Relationships[relName]; ok {
+				continue
+			}
+			switch relationshipStorage(doc, name, relName) {
+			case storageJoin:
+				stmt, _ := joinTableFor(doc, name, relName)
+				statements = append(statements, fmt.Sprintf("-- Entity %s relationship %s added.\n%s", name, relName, stmt))
+			case storageFK:
+				constraints = append(constraints, foreignKey(table, relName, ent.Relationships[relName].Target))
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(old.Entities) {
+		oldEnt := old.Entities[name]
+		table := tableName(name)
+		ent, ok := doc.Entities[name]
+		if !ok {
+			reviews = append(reviews, fmt.Sprintf("%sentity %s removed; table %s is left in place.\n-- DROP TABLE %s;", manualReview, name, table, table))
+			continue
+		}
+		for _, prop := range oldEnt.Properties {
+			if _, ok := ent.Properties[prop]; !ok {
+				reviews = append(reviews, fmt.Sprintf("%sentity %s property %s removed; column is left in place.\n-- ALTER TABLE %s DROP COLUMN %s;", manualReview, name, prop, table, prop))
+			}
+		}
+		for _, relName := range sortedKeys(oldEnt.Relationships) {
+			oldRel := oldEnt.Relationships[relName]
+			rel, ok := ent.Relationships[relName]
+			switch {
+			case !ok:
+				reviews = append(reviews, fmt.Sprintf("%sentity %s relationship %s removed; drop its foreign key or join table %s__%s after review.", manualReview, name, relName, table, toSnake(relName)))
+			case rel.Target != oldRel.Target || rel.Cardinality != oldRel.Cardinality || rel.Storage != oldRel.Storage:
+				reviews = append(reviews, fmt.Sprintf("%sentity %s relationship %s changed; migrate its storage by hand.", manualReview, name, relName))
+			}
+		}
+	}
+
+	for _, enumName := range sortedKeys(doc.Enums) {
+		oldValues, ok := old.Enums[enumName]
+		if !ok {
+			continue
+		}
+		var added, removed []string
+		for _, v := range doc.Enums[enumName].Values {
+			if !contains(oldValues, v) {
+				added = append(added, v)
+			}
+		}
+		for _, v := range oldValues {
+			if !contains(doc.Enums[enumName].Values, v) {
+				removed = append(removed, v)
+			}
+		}
+		if len(added)+len(removed) > 0 {
+			sort.Strings(added)
+			sort.Strings(removed)
+			reviews = append(reviews, fmt.Sprintf("%senum %s values changed (added %v, removed %v); update CHECK constraints on columns using it.", manualReview, enumName, added, removed))
+		}
+	}
+
+	if len(statements)+len(constraints)+len(reviews) == 0 {
+		b.WriteString("\n-- No schema changes.\n")
+		return b.String(), nil
+	}
+	for _, stmt := range statements {
+		fmt.Fprintf(&b, "\n%s\n", stmt)
+	}
+	if len(constraints) > 0 {
+		b.WriteString("\n-- Foreign keys for added tables and columns.\n")
+		for _, stmt := range constraints {
+			fmt.Fprintf(&b, "%s\n", stmt)
+		}
+	}
+	for _, review := range reviews {
+		fmt.Fprintf(&b, "\n%s\n", review)
+	}
+	return b.String(), nil
+}
+
+func createEntityTable(doc schemaDoc, name string) (string, []string, error) {
+	ent := doc.Entities[name]
+	table := tableName(name)
+	var (
+		columns []string
+		fks     []string
+	)
+	for _, prop := range sortedKeys(ent.Properties) {
+		if _, isRel := ent.Relationships[prop]; isRel {
+			switch relationshipStorage(doc, name, prop) {
+			case storageFK:
+				fks = append(fks, foreignKey(table, prop, ent.Relationships[prop].Target))
+			case storageJoin, storageDerived:
+				continue
+			}
+		}
+		colType, err := columnType(doc, ent.Properties[prop])
+		if err != nil {
+			return "", nil, fmt.Errorf("entity %s property %s: %w", name, prop, err)
+		}
+		column := fmt.Sprintf("    %s %s", prop, colType)
+		if contains(ent.Required, prop) {
+			column += " NOT NULL"
+		}
+		columns = append(columns, column)
+	}
+	columns = append(columns, "    PRIMARY KEY (id)")
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n%s\n);", table, strings.Join(columns, ",\n")), fks, nil
+}
+
+// joinTableFor mirrors the generator's join-table layout for an array
+// relationship.
+func joinTableFor(doc schemaDoc, entity, relName string) (string, bool) {
+	if relationshipStorage(doc, entity, relName) != storageJoin {
+		return "", false
+	}
+	target := doc.Entities[entity].Relationships[relName].Target
+	sourceTable, targetTable := tableName(entity), tableName(target)
+	sourceCol := toSnake(entity) + "_id"
+	targetBase := toSnake(target)
+	if targetBase == toSnake(entity) {
+		targetBase = toSnake(relName)
+	}
+	targetCol := targetBase + "_id"
+	join := fmt.Sprintf("%s__%s", sourceTable, toSnake(relName))
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+    %[2]s UUID NOT NULL,
+    %[3]s UUID NOT NULL,
+    PRIMARY KEY (%[2]s, %[3]s),
+    FOREIGN KEY (%[2]s) REFERENCES %[4]s(id) ON DELETE CASCADE,
+    FOREIGN KEY (%[3]s) REFERENCES %[5]s(id) ON DELETE RESTRICT
+);
+CREATE INDEX IF NOT EXISTS idx_%[1]s_%[3]s ON %[1]s (%[3]s);`, join, sourceCol, targetCol, sourceTable, targetTable), true
+}
+
+func foreignKey(table, column, target string) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT fk_%s_%s FOREIGN KEY (%s) REFERENCES %s(id);", table, table, column, column, tableName(target))
+}
+
+const (
+	storageFK      = "fk"
+	storageJoin    = "join"
+	storageDerived = "derived"
+)
+
+// relationshipStorage resolves a relationship's storage the way the generator
+// does when it is not declared explicitly: arrays use join tables, scalars use
+// foreign-key columns.
+func relationshipStorage(doc schemaDoc, entity, relName string) string {
+	ent := doc.Entities[entity]
+	if storage := strings.TrimSpace(ent.Relationships[relName].Storage); storage != "" {
+		return storage
+	}
+	prop, err := resolvePropertySpec(doc, ent.Properties[relName])
+	if err == nil && prop.Type == "array" {
+		return storageJoin
+	}
+	return storageFK
+}
+
+func columnType(doc schemaDoc, raw json.RawMessage) (string, error) {
+	prop, err := resolvePropertySpec(doc, raw)
+	if err != nil {
+		return "", err
+	}
+	switch prop.Type {
+	case "string":
+		switch strings.ToLower(prop.Format) {
+		case "uuid", "uuidv7", "uuidv4":
+			return "UUID", nil
+		case "date-time":
+			return "TIMESTAMPTZ", nil
+		default:
+			return "TEXT", nil
+		}
+	case "integer":
+		return "INTEGER", nil
+	case "number":
+		return "DOUBLE PRECISION", nil
+	case "boolean":
+		return "BOOLEAN", nil
+	default:
+		return "JSONB", nil
+	}
+}
+
+func resolvePropertySpec(doc schemaDoc, raw json.RawMessage) (propertySpec, error) {
+	var prop propertySpec
+	if err := json.Unmarshal(raw, &prop); err != nil {
+		return propertySpec{}, fmt.Errorf("parse property: %w", err)
+	}
+	switch {
+	case prop.Ref == "":
+		return prop, nil
+	case strings.HasPrefix(prop.Ref, "#/enums/"):
+		return propertySpec{Type: "string"}, nil
+	case strings.HasPrefix(prop.Ref, "#/definitions/"):
+		def, ok := doc.Definitions[strings.TrimPrefix(prop.Ref, "#/definitions/")]
+		if !ok {
+			return propertySpec{}, fmt.Errorf("unknown ref %q", prop.Ref)
+		}
+		return resolvePropertySpec(doc, def)
+	default:
+		return propertySpec{}, fmt.Errorf("unsupported ref %q", prop.Ref)
+	}
+}
+
+func tableName(entity string) string {
+	return pluralize(toSnake(entity))
+}
+
+// toSnake and pluralize match the generator's table naming.
+func toSnake(s string) string {
+	runes := []rune(s)
+	var out []rune
+	for i, r := range runes {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 && ((runes[i-1] >= 'a' && runes[i-1] <= 'z') || (i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z')) {
+				out = append(out, '_')
+			}
+			out = append(out, r+('a'-'A'))
+			continue
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+func pluralize(s string) string {
+	if strings.HasSuffix(s, "s") {
+		return s
+	}
+	if strings.HasSuffix(s, "y") && len(s) > 1 && !strings.ContainsRune("aeiou", rune(s[len(s)-2])) {
+		return s[:len(s)-1] + "ies"
+	}
+	return s + "s"
+}
+
+func contains(values []string, needle string) bool {
+	for _, v := range values {
+		if v == needle {
+			return true
+		}
+	}
+	return false
+}
+
+func orUnknown(version string) string {
+	if version == "" {
+		return "(unversioned)"
+	}
+	return version
+}
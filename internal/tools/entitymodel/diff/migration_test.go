@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite migration golden files")
+
+const migrationBaseSchema = `{
+  "version": "0.1.0",
+  "enums": {"room_state": {"values": ["active", "closed"]}},
+  "definitions": {
+    "id": {"type": "string", "format": "uuid"},
+    "entity_id": {"type": "string", "format": "uuid"},
+    "timestamp": {"type": "string", "format": "date-time"}
+  },
+  "entities": {
+    "Facility": {
+      "required": ["id", "name"],
+      "properties": {
+        "id": {"$ref": "#/definitions/id"},
+        "name": {"type": "string"},
+        "legacy_code": {"type": "string"}
+      },
+      "relationships": {}
+    }
+  }
+}`
+
+func parseMigrationSchema(t *testing.T, raw string) schemaDoc {
+	t.Helper()
+	var doc schemaDoc
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		t.Fatalf("parse schema: %v", err)
+	}
+	return doc
+}
+
+func assertMigrationGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		if err := os.WriteFile(path, []byte(got), 0o600); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+	}
+	want, err := os.ReadFile(path) //nolint:gosec // golden path is fixed under testdata
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if got != string(want) {
+		t.Fatalf("migration mismatch for %s (run with -update to refresh)\n--- got ---\n%s\n--- want ---\n%s", name, got, want)
+	}
+}
+
+func TestBuildMigrationAddedProperty(t *testing.T) {
+	base := parseMigrationSchema(t, migrationBaseSchema)
+	updated := parseMigrationSchema(t, strings.Replace(migrationBaseSchema,
+		`"legacy_code": {"type": "string"}`,
+		`"timezone": {"type": "string"}, "capacity": {"type": "integer"}, "opened_at": {"$ref": "#/definitions/timestamp"}`, 1))
+	updated.Version = "0.2.0"
+	ent := updated.Entities["Facility"]
+	ent.Required = append(ent.Required, "capacity")
+	updated.Entities["Facility"] = ent
+
+	got, err := buildMigration(computeFingerprint(base), updated)
+	if err != nil {
+		t.Fatalf("buildMigration: %v", err)
+	}
+	if strings.Contains(got, "\nALTER TABLE facilities DROP") {
+		t.Fatalf("expected removed columns to stay commented, got:\n%s", got)
+	}
+	assertMigrationGolden(t, "migration_added_property.sql", got)
+}
+
+func TestBuildMigrationAddedEntity(t *testing.T) {
+	base := parseMigrationSchema(t, migrationBaseSchema)
+	updated := parseMigrationSchema(t, migrationBaseSchema)
+	updated.Version = "0.2.0"
+	updated.Entities["Room"] = entitySpec{
+		Required: []string{"id", "label", "facility_id", "state"},
+		Properties: map[string]json.RawMessage{
+			"id":           json.RawMessage(`{"$ref": "#/definitions/id"}`),
+			"label":        json.RawMessage(`{"type": "string"}`),
+			"facility_id":  json.RawMessage(`{"$ref": "#/definitions/entity_id"}`),
+			"state":        json.RawMessage(`{"$ref": "#/enums/room_state"}`),
+			"neighbor_ids": json.RawMessage(`{"type": "array", "items": {"$ref": "#/definitions/entity_id"}}`),
+			"attributes":   json.RawMessage(`{"type": "object"}`),
+		},
+		Relationships: map[string]relationshipSpec{
+			"facility_id":  {Target: "Facility", Cardinality: "1..1"},
+			"neighbor_ids": {Target: "Room", Cardinality: "0..n"},
+		},
+	}
+
+	got, err := buildMigration(computeFingerprint(base), updated)
+	if err != nil {
+		t.Fatalf("buildMigration: %v", err)
+	}
+	assertMigrationGolden(t, "migration_added_entity.sql", got)
+}
+
+func TestBuildMigrationNoChanges(t *testing.T) {
+	base := parseMigrationSchema(t, migrationBaseSchema)
+	got, err := buildMigration(computeFingerprint(base), base)
+	if err != nil {
+		t.Fatalf("buildMigration: %v", err)
+	}
+	if !strings.Contains(got, "-- No schema changes.") {
+		t.Fatalf("expected no-op migration, got:\n%s", got)
+	}
+}
+
+func TestBuildMigrationRejectsUnknownRef(t *testing.T) {
+	base := parseMigrationSchema(t, migrationBaseSchema)
+	updated := parseMigrationSchema(t, strings.Replace(migrationBaseSchema,
+		`"legacy_code": {"type": "string"}`, `"legacy_code": {"type": "string"}, "zone": {"$ref": "#/definitions/missing"}`, 1))
+	if _, err := buildMigration(computeFingerprint(base), updated); err == nil || !strings.Contains(err.Error(), "unknown ref") {
+		t.Fatalf("expected unknown ref error, got %v", err)
+	}
+}
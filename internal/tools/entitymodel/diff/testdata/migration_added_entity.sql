@@ -0,0 +1,26 @@
+-- Migration generated by internal/tools/entitymodel/diff. Review before applying.
+-- Dialect: postgres
+-- From fingerprint version 0.1.0 to schema version 0.2.0.
+
+-- Entity Room added.
+CREATE TABLE IF NOT EXISTS rooms (
+    attributes JSONB,
+    facility_id UUID NOT NULL,
+    id UUID NOT NULL,
+    label TEXT NOT NULL,
+    state TEXT NOT NULL,
+    PRIMARY KEY (id)
+);
+
+-- Entity Room relationship neighbor_ids added.
+CREATE TABLE IF NOT EXISTS rooms__neighbor_ids (
+    room_id UUID NOT NULL,
+    neighbor_ids_id UUID NOT NULL,
+    PRIMARY KEY (room_id, neighbor_ids_id),
+    FOREIGN KEY (room_id) REFERENCES rooms(id) ON DELETE CASCADE,
+    FOREIGN KEY (neighbor_ids_id) REFERENCES rooms(id) ON DELETE RESTRICT
+);
+CREATE INDEX IF NOT EXISTS idx_rooms__neighbor_ids_neighbor_ids_id ON rooms__neighbor_ids (neighbor_ids_id);
+
+-- Foreign keys for added tables and columns.
+ALTER TABLE rooms ADD CONSTRAINT fk_rooms_facility_id FOREIGN KEY (facility_id) REFERENCES facilities(id);
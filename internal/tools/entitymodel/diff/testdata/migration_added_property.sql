@@ -0,0 +1,18 @@
+-- Migration generated by internal/tools/entitymodel/diff. Review before applying.
+-- Dialect: postgres
+-- From fingerprint version 0.1.0 to schema version 0.2.0.
+
+-- Entity Facility property capacity added.
+ALTER TABLE facilities ADD COLUMN IF NOT EXISTS capacity INTEGER;
+
+-- Entity Facility property opened_at added.
+ALTER TABLE facilities ADD COLUMN IF NOT EXISTS opened_at TIMESTAMPTZ;
+
+-- Entity Facility property timezone added.
+ALTER TABLE facilities ADD COLUMN IF NOT EXISTS timezone TEXT;
+
+-- MANUAL REVIEW REQUIRED: entity Facility property capacity is required; backfill existing rows, then run:
+-- ALTER TABLE facilities ALTER COLUMN capacity SET NOT NULL;
+
+-- MANUAL REVIEW REQUIRED: entity Facility property legacy_code removed; column is left in place.
+-- ALTER TABLE facilities DROP COLUMN legacy_code;
@@ -122,6 +122,7 @@ func buildFixtureSnapshot(doc schemaDoc) (fixtureSnapshot, error) {
 				"id":          housingID,
 				"created_at":  baseTime,
 				"updated_at":  baseTime,
+				"version":     1,
 				"name":        "Main Habitat",
 				"facility_id": facilityID,
 				"capacity":    4,
@@ -146,11 +147,13 @@ func buildFixtureSnapshot(doc schemaDoc) (fixtureSnapshot, error) {
 				"id":           protocolID,
 				"created_at":   baseTime,
 				"updated_at":   baseTime,
+				"version":      1,
 				"code":         "PROTO-FXT",
 				"title":        "Fixture Protocol",
 				"description":  "Approved protocol for fixture coverage",
 				"max_subjects": 5,
 				"status":       "approved",
+				"reviewer_ids": []string{"reviewer-fxt"},
 			},
 		},
 		Permits: map[string]map[string]any{
@@ -161,6 +164,7 @@ func buildFixtureSnapshot(doc schemaDoc) (fixtureSnapshot, error) {
 				"permit_number": "PERMIT-123",
 				"authority":     "Regulatory Body",
 				"status":        "approved",
+				"issue_date":    baseTime,
 				"valid_from":    baseTime,
 				"valid_until":   validUntil,
 				"allowed_activities": []string{
@@ -258,6 +262,7 @@ func buildFixtureSnapshot(doc schemaDoc) (fixtureSnapshot, error) {
 				"id":          organismAID,
 				"created_at":  baseTime,
 				"updated_at":  baseTime,
+				"version":     1,
 				"name":        "Alpha",
 				"species":     "Specimenus fixture",
 				"line":        lineLabel,
@@ -278,6 +283,7 @@ func buildFixtureSnapshot(doc schemaDoc) (fixtureSnapshot, error) {
 				"id":          organismBID,
 				"created_at":  baseTime,
 				"updated_at":  baseTime,
+				"version":     1,
 				"name":        "Bravo",
 				"species":     "Specimenus fixture",
 				"line":        lineLabel,
@@ -298,6 +304,7 @@ func buildFixtureSnapshot(doc schemaDoc) (fixtureSnapshot, error) {
 				"id":          organismCID,
 				"created_at":  baseTime,
 				"updated_at":  baseTime,
+				"version":     1,
 				"name":        "Charlie",
 				"species":     "Specimenus fixture",
 				"line":        lineLabel,
@@ -329,7 +336,7 @@ func buildFixtureSnapshot(doc schemaDoc) (fixtureSnapshot, error) {
 				"strain_id":        strainID,
 				"target_line_id":   lineID,
 				"target_strain_id": strainID,
-				"pairing_intent":   "Expand fixture line",
+				"pairing_intent":   "expansion",
 				"pairing_notes":    "Healthy adults selected",
 				"female_ids":       []string{organismAID},
 				"male_ids":         []string{organismBID},
@@ -362,7 +369,13 @@ func buildFixtureSnapshot(doc schemaDoc) (fixtureSnapshot, error) {
 				"procedure_id": procedureID,
 				"organism_ids": []string{organismAID, organismBID},
 				"cohort_ids":   []string{cohortID},
-				"dosage_plan":  "5ml oral dose",
+				"dosage_plan": map[string]any{
+					"drug":              "Fixture Compound",
+					"dose_amount":       5,
+					"dose_unit":         "ml",
+					"frequency_per_day": 1,
+					"duration_days":     7,
+				},
 				"administration_log": []string{
 					"dose recorded at 10:00",
 				},
@@ -396,6 +409,7 @@ func buildFixtureSnapshot(doc schemaDoc) (fixtureSnapshot, error) {
 				"organism_id":      organismAID,
 				"facility_id":      facilityID,
 				"collected_at":     collectionTS,
+				"collected_by":     "Technician One",
 				"status":           "stored",
 				"storage_location": "Freezer A1",
 				"assay_type":       "PCR",
@@ -427,6 +441,7 @@ func buildFixtureSnapshot(doc schemaDoc) (fixtureSnapshot, error) {
 				"facility_ids":     []string{facilityID},
 				"project_ids":      []string{projectID},
 				"reorder_level":    5,
+				"status":           "active",
 				"attributes": map[string]any{
 					"core": map[string]any{
 						"storage": "dry",
@@ -454,6 +469,9 @@ func validateFixture(snapshot fixtureSnapshot, doc schemaDoc) error {
 			if err := ensureMinItems(entry, spec.Properties, entity); err != nil {
 				return err
 			}
+			if err := ensureNoUnknownFields(entry, spec.Properties, spec.AdditionalProperties, entity); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -483,6 +501,37 @@ func ensureMinItems(entry map[string]any, rawProps map[string]json.RawMessage, e
 	return nil
 }
 
+// ensureNoUnknownFields rejects keys in entry that rawProps does not declare
+// when additionalProperties is false. Inline object properties are checked the
+// same way, so a stray key nested in a closed object is caught too.
+func ensureNoUnknownFields(entry map[string]any, rawProps map[string]json.RawMessage, additionalProps json.RawMessage, path string) error {
+	closed := false
+	if val, ok := additionalPropertiesValue(additionalProps); ok {
+		closed = !val
+	}
+	for _, name := range sortedKeys(entry) {
+		raw, declared := rawProps[name]
+		if !declared {
+			if closed {
+				return fmt.Errorf("fixture entity %s has unknown field %s", path, name)
+			}
+			continue
+		}
+		var prop definitionSpec
+		if err := json.Unmarshal(raw, &prop); err != nil || prop.Type != typeObject || len(prop.Properties) == 0 {
+			continue
+		}
+		nested, ok := entry[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		if err := ensureNoUnknownFields(nested, prop.Properties, prop.AdditionalProperties, path+"."+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (f fixtureSnapshot) entities(name string) []map[string]any {
 	switch name {
 	case "Organism":
@@ -28,6 +28,9 @@ type definitionSpec struct {
 	Format               string                     `json:"format"`
 	Ref                  string                     `json:"$ref"`
 	Description          string                     `json:"description"`
+	Unit                 string                     `json:"unit"`
+	Audit                bool                       `json:"x-audit"`
+	Index                bool                       `json:"x-index"`
 	Items                *definitionSpec            `json:"items"`
 	Properties           map[string]json.RawMessage `json:"properties"`
 	Required             []string                   `json:"required"`
@@ -54,13 +57,14 @@ type naturalKeySpec struct {
 }
 
 type entitySpec struct {
-	Description   string                      `json:"description"`
-	NaturalKeys   []naturalKeySpec            `json:"natural_keys"`
-	Required      []string                    `json:"required"`
-	Properties    map[string]json.RawMessage  `json:"properties"`
-	Relationships map[string]relationshipSpec `json:"relationships"`
-	States        *stateSpec                  `json:"states"`
-	Invariants    []string                    `json:"invariants"`
+	Description          string                      `json:"description"`
+	NaturalKeys          []naturalKeySpec            `json:"natural_keys"`
+	Required             []string                    `json:"required"`
+	Properties           map[string]json.RawMessage  `json:"properties"`
+	AdditionalProperties json.RawMessage             `json:"additionalProperties"`
+	Relationships        map[string]relationshipSpec `json:"relationships"`
+	States               *stateSpec                  `json:"states"`
+	Invariants           []string                    `json:"invariants"`
 }
 
 type metadataSpec struct {
@@ -93,14 +97,20 @@ func main() {
 	fixturesPath := flag.String("fixtures", "", "output path for generated entity-model fixtures (optional)")
 	pluginapiConstantsPath := flag.String("pluginapi-constants", "", "output file for generated pluginapi enum constants (optional)")
 	datasetapiConstantsPath := flag.String("datasetapi-constants", "", "output file for generated datasetapi enum constants (optional)")
+	jsonCaseFlag := flag.String("json-case", string(jsonCaseSnake), "casing for generated json struct tags: snake or camel")
 	flag.Parse()
 
+	casing, err := parseJSONCase(*jsonCaseFlag)
+	if err != nil {
+		exitErr(err)
+	}
+
 	doc, err := loadSchema(*schemaPath)
 	if err != nil {
 		exitErr(err)
 	}
 
-	code, err := generateCode(doc)
+	code, err := generateCode(doc, casing)
 	if err != nil {
 		exitErr(err)
 	}
@@ -201,13 +211,13 @@ func loadSchema(path string) (schemaDoc, error) {
 	return doc, nil
 }
 
-func generateCode(doc schemaDoc) ([]byte, error) {
+func generateCode(doc schemaDoc, casing jsonCase) ([]byte, error) {
 	var body strings.Builder
 	usesTime := false
 
 	writeEnums(&body, doc.Enums)
-	defTime := writeDefinitions(&body, doc.Definitions)
-	entityTime, err := writeEntities(&body, doc.Entities, doc.Enums)
+	defTime := writeDefinitions(&body, doc.Definitions, casing)
+	entityTime, err := writeEntities(&body, doc.Entities, doc.Enums, casing)
 	if err != nil {
 		return nil, err
 	}
@@ -215,6 +225,10 @@ func generateCode(doc schemaDoc) ([]byte, error) {
 	if defTime || entityTime {
 		usesTime = true
 	}
+	writeFieldUnits(&body, doc.Entities)
+	writeAuditFields(&body, doc.Entities)
+	writeNaturalKeyTable(&body, doc.Entities)
+	writeDeclaredProperties(&body, doc.Entities)
 
 	var file strings.Builder
 	file.WriteString("// Code generated by internal/tools/entitymodel/generate. DO NOT EDIT.\n")
@@ -222,6 +236,10 @@ func generateCode(doc schemaDoc) ([]byte, error) {
 	if usesTime {
 		file.WriteString("import \"time\"\n\n")
 	}
+	if doc.Version != "" {
+		file.WriteString("// SchemaVersion is the entity-model schema version this package was generated from.\n")
+		fmt.Fprintf(&file, "const SchemaVersion = %q\n\n", doc.Version)
+	}
 	file.WriteString(body.String())
 
 	formatted, err := format.Source([]byte(file.String()))
@@ -239,14 +257,31 @@ func writeEnums(body *strings.Builder, enums map[string]enumSpec) {
 		fmt.Fprintf(body, "// %s enumerates values for %s.\n", typeName, name)
 		fmt.Fprintf(body, "type %s string\n\n", typeName)
 		body.WriteString("const (\n")
+		constNames := make([]string, 0, len(enum.Values))
 		for _, v := range enum.Values {
-			fmt.Fprintf(body, "\t%s%s %s = \"%s\"\n", typeName, toCamel(v), typeName, v)
+			constName := typeName + toCamel(v)
+			constNames = append(constNames, constName)
+			fmt.Fprintf(body, "\t%s %s = \"%s\"\n", constName, typeName, v)
 		}
 		body.WriteString(")\n\n")
+
+		fmt.Fprintf(body, "// All%s returns every %s value in schema order.\n", typeName, name)
+		fmt.Fprintf(body, "func All%s() []%s {\n", typeName, typeName)
+		fmt.Fprintf(body, "\treturn []%s{%s}\n", typeName, strings.Join(constNames, ", "))
+		body.WriteString("}\n\n")
+
+		fmt.Fprintf(body, "// IsValid%s reports whether v is a declared %s value.\n", typeName, name)
+		fmt.Fprintf(body, "func IsValid%s(v %s) bool {\n", typeName, typeName)
+		body.WriteString("\tswitch v {\n")
+		fmt.Fprintf(body, "\tcase %s:\n", strings.Join(constNames, ", "))
+		body.WriteString("\t\treturn true\n")
+		body.WriteString("\t}\n")
+		body.WriteString("\treturn false\n")
+		body.WriteString("}\n\n")
 	}
 }
 
-func writeDefinitions(body *strings.Builder, definitions map[string]definitionSpec) bool {
+func writeDefinitions(body *strings.Builder, definitions map[string]definitionSpec, casing jsonCase) bool {
 	names := sortedKeys(definitions)
 	usesTime := false
 
@@ -269,11 +304,7 @@ func writeDefinitions(body *strings.Builder, definitions map[string]definitionSp
 			if propUsesTime {
 				usesTime = true
 			}
-			tag := fmt.Sprintf("`json:\"%s", propName)
-			if !required {
-				tag += ",omitempty"
-			}
-			tag += "\"`"
+			tag := jsonTag(casing.fieldName(propName), required)
 			fmt.Fprintf(body, "\t%s %s %s\n", toCamel(propName), goType, tag)
 		}
 		body.WriteString("}\n\n")
@@ -282,7 +313,7 @@ func writeDefinitions(body *strings.Builder, definitions map[string]definitionSp
 	return usesTime
 }
 
-func writeEntities(body *strings.Builder, entities map[string]entitySpec, enums map[string]enumSpec) (bool, error) {
+func writeEntities(body *strings.Builder, entities map[string]entitySpec, enums map[string]enumSpec, casing jsonCase) (bool, error) {
 	names := sortedKeys(entities)
 	usesTime := false
 
@@ -301,11 +332,7 @@ func writeEntities(body *strings.Builder, entities map[string]entitySpec, enums
 			if propUsesTime {
 				usesTime = true
 			}
-			tag := fmt.Sprintf("`json:\"%s", propName)
-			if !required {
-				tag += ",omitempty"
-			}
-			tag += "\"`"
+			tag := jsonTag(casing.fieldName(propName), required)
 			fmt.Fprintf(body, "\t%s %s %s\n", toCamel(propName), goType, tag)
 		}
 		body.WriteString("}\n\n")
@@ -314,6 +341,115 @@ func writeEntities(body *strings.Builder, entities map[string]entitySpec, enums
 	return usesTime, nil
 }
 
+// writeFieldUnits emits FieldUnits, exposing the unit declared on each
+// measured entity property so downstream projections can label values.
+func writeFieldUnits(body *strings.Builder, entities map[string]entitySpec) {
+	body.WriteString("// FieldUnits returns the measurement unit declared for entity properties,\n")
+	body.WriteString("// keyed by entity name and then JSON property name. Every entity is present,\n")
+	body.WriteString("// with an empty map when none of its properties declare a unit. Each call\n")
+	body.WriteString("// returns a fresh map that callers may modify.\n")
+	body.WriteString("func FieldUnits() map[string]map[string]string {\n")
+	body.WriteString("\treturn map[string]map[string]string{\n")
+	for _, name := range sortedKeys(entities) {
+		props, _ := parseProperties(entities[name].Properties)
+		var lines []string
+		for _, propName := range sortedKeys(props) {
+			if unit := props[propName].Unit; unit != "" {
+				lines = append(lines, fmt.Sprintf("\t\t\t%q: %q,\n", propName, unit))
+			}
+		}
+		if len(lines) == 0 {
+			fmt.Fprintf(body, "\t\t%q: {},\n", name)
+			continue
+		}
+		fmt.Fprintf(body, "\t\t%q: {\n", name)
+		for _, line := range lines {
+			body.WriteString(line)
+		}
+		body.WriteString("\t\t},\n")
+	}
+	body.WriteString("\t}\n}\n\n")
+}
+
+// writeAuditFields emits AuditFields, listing the properties annotated with
+// x-audit so change capture can keep a compact compliance snapshot.
+func writeAuditFields(body *strings.Builder, entities map[string]entitySpec) {
+	body.WriteString("// AuditFields returns the JSON property names annotated with x-audit for the\n")
+	body.WriteString("// named entity, sorted by name. Unknown entities and entities without audited\n")
+	body.WriteString("// properties return nil. Each call returns a fresh slice.\n")
+	body.WriteString("func AuditFields(entity string) []string {\n")
+	body.WriteString("\tswitch entity {\n")
+	for _, name := range sortedKeys(entities) {
+		props, _ := parseProperties(entities[name].Properties)
+		var fields []string
+		for _, propName := range sortedKeys(props) {
+			if props[propName].Audit {
+				fields = append(fields, fmt.Sprintf("%q", propName))
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		fmt.Fprintf(body, "\tcase %q:\n\t\treturn []string{%s}\n", name, strings.Join(fields, ", "))
+	}
+	body.WriteString("\t}\n\treturn nil\n}\n\n")
+}
+
+// writeNaturalKeyTable emits NaturalKeys, exposing each entity's declared
+// natural keys so stores can check uniqueness without hand-coded field lists.
+func writeNaturalKeyTable(body *strings.Builder, entities map[string]entitySpec) {
+	body.WriteString("// NaturalKey is a natural key declared in the entity model: the JSON\n")
+	body.WriteString("// property names that together identify a record and the scope within\n")
+	body.WriteString("// which they must be unique.\n")
+	body.WriteString("type NaturalKey struct {\n\tFields []string\n\tScope  string\n}\n\n")
+	body.WriteString("// NaturalKeys returns the natural keys declared for each entity, keyed by\n")
+	body.WriteString("// entity name. Every entity is present, with no keys when it declares none.\n")
+	body.WriteString("// Each call returns a fresh map that callers may modify.\n")
+	body.WriteString("func NaturalKeys() map[string][]NaturalKey {\n")
+	body.WriteString("\treturn map[string][]NaturalKey{\n")
+	for _, name := range sortedKeys(entities) {
+		keys := entities[name].NaturalKeys
+		if len(keys) == 0 {
+			fmt.Fprintf(body, "\t\t%q: nil,\n", name)
+			continue
+		}
+		fmt.Fprintf(body, "\t\t%q: {\n", name)
+		for _, key := range keys {
+			fields := make([]string, len(key.Fields))
+			for i, field := range key.Fields {
+				fields[i] = fmt.Sprintf("%q", field)
+			}
+			fmt.Fprintf(body, "\t\t\t{Fields: []string{%s}, Scope: %q},\n", strings.Join(fields, ", "), key.Scope)
+		}
+		body.WriteString("\t\t},\n")
+	}
+	body.WriteString("\t}\n}\n\n")
+}
+
+// writeDeclaredProperties emits DeclaredProperties, listing the properties of
+// entities whose schema sets additionalProperties to false so decoders can
+// reject keys the schema does not declare.
+func writeDeclaredProperties(body *strings.Builder, entities map[string]entitySpec) {
+	body.WriteString("// DeclaredProperties returns the JSON property names declared for the named\n")
+	body.WriteString("// entity, sorted by name, when its schema sets additionalProperties to false.\n")
+	body.WriteString("// Unknown entities and entities open to additional properties return nil.\n")
+	body.WriteString("// Each call returns a fresh slice.\n")
+	body.WriteString("func DeclaredProperties(entity string) []string {\n")
+	body.WriteString("\tswitch entity {\n")
+	for _, name := range sortedKeys(entities) {
+		spec := entities[name]
+		if open, ok := additionalPropertiesValue(spec.AdditionalProperties); !ok || open {
+			continue
+		}
+		fields := make([]string, 0, len(spec.Properties))
+		for _, propName := range sortedKeys(spec.Properties) {
+			fields = append(fields, fmt.Sprintf("%q", propName))
+		}
+		fmt.Fprintf(body, "\tcase %q:\n\t\treturn []string{%s}\n", name, strings.Join(fields, ", "))
+	}
+	body.WriteString("\t}\n\treturn nil\n}\n\n")
+}
+
 func parseProperties(raw map[string]json.RawMessage) (map[string]definitionSpec, bool) {
 	props := make(map[string]definitionSpec, len(raw))
 	usesTime := false
@@ -452,6 +588,44 @@ func toCamel(input string) string {
 	return strings.Join(parts, "")
 }
 
+// jsonCase selects the key casing used in generated json struct tags.
+type jsonCase string
+
+const (
+	jsonCaseSnake jsonCase = "snake"
+	jsonCaseCamel jsonCase = "camel"
+)
+
+func parseJSONCase(value string) (jsonCase, error) {
+	switch casing := jsonCase(strings.ToLower(strings.TrimSpace(value))); casing {
+	case jsonCaseSnake, jsonCaseCamel:
+		return casing, nil
+	default:
+		return "", fmt.Errorf("unsupported json case %q (want %s or %s)", value, jsonCaseSnake, jsonCaseCamel)
+	}
+}
+
+// fieldName maps a schema property name to its JSON key. Camel case lowers
+// the first word and capitalizes the rest without applying Go initialisms, so
+// housing_unit_ids becomes housingUnitIds.
+func (c jsonCase) fieldName(propName string) string {
+	if c != jsonCaseCamel {
+		return propName
+	}
+	parts := strings.Split(propName, "_")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = capitalize(parts[i])
+	}
+	return strings.Join(parts, "")
+}
+
+func jsonTag(name string, required bool) string {
+	if required {
+		return fmt.Sprintf("`json:\"%s\"`", name)
+	}
+	return fmt.Sprintf("`json:\"%s,omitempty\"`", name)
+}
+
 func capitalize(s string) string {
 	if s == "" {
 		return ""
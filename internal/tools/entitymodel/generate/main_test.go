@@ -24,7 +24,7 @@ func TestGenerateMatchesCommitted(t *testing.T) {
 		t.Fatalf("load schema: %v", err)
 	}
 
-	generated, err := generateCode(doc)
+	generated, err := generateCode(doc, jsonCaseSnake)
 	if err != nil {
 		t.Fatalf("generate code: %v", err)
 	}
@@ -191,7 +191,7 @@ func TestGenerateCodeIncludesTimeImport(t *testing.T) {
 		},
 	}
 
-	code, err := generateCode(doc)
+	code, err := generateCode(doc, jsonCaseSnake)
 	if err != nil {
 		t.Fatalf("generateCode: %v", err)
 	}
@@ -204,6 +204,204 @@ func TestGenerateCodeIncludesTimeImport(t *testing.T) {
 	}
 }
 
+func TestGenerateCodeJSONCaseGolden(t *testing.T) {
+	doc := schemaDoc{
+		Definitions: map[string]definitionSpec{
+			"id": {Type: typeString},
+		},
+		Entities: map[string]entitySpec{
+			"Pair": {
+				Required: []string{"pair_id"},
+				Properties: map[string]json.RawMessage{
+					"pair_id":      raw(`{"$ref":"#/definitions/id"}`),
+					"display_name": raw(`{"type":"string"}`),
+				},
+			},
+		},
+	}
+
+	cases := map[jsonCase]string{
+		jsonCaseSnake: "type Pair struct {\n" +
+			"\tDisplayName *string `json:\"display_name,omitempty\"`\n" +
+			"\tPairID      string  `json:\"pair_id\"`\n" +
+			"}\n",
+		jsonCaseCamel: "type Pair struct {\n" +
+			"\tDisplayName *string `json:\"displayName,omitempty\"`\n" +
+			"\tPairID      string  `json:\"pairId\"`\n" +
+			"}\n",
+	}
+	for casing, want := range cases {
+		code, err := generateCode(doc, casing)
+		if err != nil {
+			t.Fatalf("generateCode(%s): %v", casing, err)
+		}
+		text := string(code)
+		start := strings.Index(text, "type Pair struct {")
+		if start < 0 {
+			t.Fatalf("expected Pair struct in %s output:\n%s", casing, text)
+		}
+		end := strings.Index(text[start:], "}\n") + len("}\n")
+		if got := text[start : start+end]; got != want {
+			t.Fatalf("%s casing mismatch\nwant:\n%s\ngot:\n%s", casing, want, got)
+		}
+	}
+
+	if _, err := parseJSONCase("kebab"); err == nil {
+		t.Fatalf("expected unsupported json case to be rejected")
+	}
+	if casing, err := parseJSONCase(" Camel "); err != nil || casing != jsonCaseCamel {
+		t.Fatalf("expected camel casing, got %q (%v)", casing, err)
+	}
+}
+
+func TestGenerateCodeEmitsFieldUnits(t *testing.T) {
+	doc := schemaDoc{
+		Entities: map[string]entitySpec{
+			"Tank": {
+				Required: []string{"id", "capacity"},
+				Properties: map[string]json.RawMessage{
+					"id":       raw(`{"type":"string"}`),
+					"capacity": raw(`{"type":"integer","unit":"count"}`),
+				},
+			},
+			"Label": {
+				Required:   []string{"id"},
+				Properties: map[string]json.RawMessage{"id": raw(`{"type":"string"}`)},
+			},
+		},
+	}
+
+	code, err := generateCode(doc, jsonCaseSnake)
+	if err != nil {
+		t.Fatalf("generateCode: %v", err)
+	}
+	text := string(code)
+	for _, want := range []string{"func FieldUnits() map[string]map[string]string", `"capacity": "count"`, `"Label": {}`} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in generated code:\n%s", want, text)
+		}
+	}
+}
+
+func TestGenerateCodeEmitsAuditFields(t *testing.T) {
+	doc := schemaDoc{
+		Entities: map[string]entitySpec{
+			"Permit": {
+				Required: []string{"id", "status"},
+				Properties: map[string]json.RawMessage{
+					"id":     raw(`{"type":"string"}`),
+					"status": raw(`{"type":"string","x-audit":true}`),
+					"notes":  raw(`{"type":"string","x-audit":false}`),
+				},
+			},
+			"Label": {
+				Required:   []string{"id"},
+				Properties: map[string]json.RawMessage{"id": raw(`{"type":"string"}`)},
+			},
+		},
+	}
+
+	code, err := generateCode(doc, jsonCaseSnake)
+	if err != nil {
+		t.Fatalf("generateCode: %v", err)
+	}
+	text := string(code)
+	for _, want := range []string{"func AuditFields(entity string) []string", `case "Permit":`, `return []string{"status"}`} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in generated code:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, `case "Label":`) || strings.Contains(text, `"notes"}`) {
+		t.Fatalf("expected only x-audit properties to be listed:\n%s", text)
+	}
+}
+
+func TestGenerateCodeEmitsNaturalKeys(t *testing.T) {
+	doc := schemaDoc{
+		Entities: map[string]entitySpec{
+			"Tank": {
+				Required: []string{"id", "facility_id", "name"},
+				Properties: map[string]json.RawMessage{
+					"id":          raw(`{"type":"string"}`),
+					"facility_id": raw(`{"type":"string"}`),
+					"name":        raw(`{"type":"string"}`),
+				},
+				NaturalKeys: []naturalKeySpec{{Fields: []string{"facility_id", "name"}, Scope: "facility"}},
+			},
+			"Label": {
+				Required:    []string{"id"},
+				Properties:  map[string]json.RawMessage{"id": raw(`{"type":"string"}`)},
+				NaturalKeys: []naturalKeySpec{},
+			},
+		},
+	}
+
+	code, err := generateCode(doc, jsonCaseSnake)
+	if err != nil {
+		t.Fatalf("generateCode: %v", err)
+	}
+	text := string(code)
+	for _, want := range []string{"func NaturalKeys() map[string][]NaturalKey", `{Fields: []string{"facility_id", "name"}, Scope: "facility"}`, `"Label": nil`} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in generated code:\n%s", want, text)
+		}
+	}
+}
+
+func TestGenerateCodeEmitsDeclaredPropertiesForClosedEntities(t *testing.T) {
+	doc := schemaDoc{
+		Entities: map[string]entitySpec{
+			"Tank": {
+				Required: []string{"id"},
+				Properties: map[string]json.RawMessage{
+					"id":   raw(`{"type":"string"}`),
+					"name": raw(`{"type":"string"}`),
+				},
+				AdditionalProperties: raw(`false`),
+			},
+			"Label": {
+				Required:             []string{"id"},
+				Properties:           map[string]json.RawMessage{"id": raw(`{"type":"string"}`)},
+				AdditionalProperties: raw(`true`),
+			},
+		},
+	}
+
+	code, err := generateCode(doc, jsonCaseSnake)
+	if err != nil {
+		t.Fatalf("generateCode: %v", err)
+	}
+	text := string(code)
+	if want := "case \"Tank\":\n\t\treturn []string{\"id\", \"name\"}"; !strings.Contains(text, want) {
+		t.Fatalf("expected %q in generated code:\n%s", want, text)
+	}
+	if strings.Contains(text, "case \"Label\"") {
+		t.Fatalf("open entity listed in DeclaredProperties:\n%s", text)
+	}
+}
+
+func TestGenerateCodeEmitsEnumHelpers(t *testing.T) {
+	doc := schemaDoc{
+		Enums: map[string]enumSpec{
+			"tank_state": {Values: []string{"empty", "in_use"}},
+		},
+	}
+
+	code, err := generateCode(doc, jsonCaseSnake)
+	if err != nil {
+		t.Fatalf("generateCode: %v", err)
+	}
+	text := string(code)
+	for _, want := range []string{
+		"func AllTankState() []TankState {\n\treturn []TankState{TankStateEmpty, TankStateInUse}\n}",
+		"func IsValidTankState(v TankState) bool {\n\tswitch v {\n\tcase TankStateEmpty, TankStateInUse:\n\t\treturn true\n\t}\n\treturn false\n}",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in generated code:\n%s", want, text)
+		}
+	}
+}
+
 func TestGoTypeForPropertyVariants(t *testing.T) {
 	enums := map[string]enumSpec{
 		"status": {Values: []string{"a"}},
@@ -379,7 +577,7 @@ func TestDefinitionsGeneration(t *testing.T) {
 		Entities: map[string]entitySpec{},
 	}
 
-	code, err := generateCode(doc)
+	code, err := generateCode(doc, jsonCaseSnake)
 	if err != nil {
 		t.Fatalf("generateCode: %v", err)
 	}
@@ -1274,3 +1472,55 @@ func repoRoot(t *testing.T) string {
 func raw(s string) json.RawMessage {
 	return json.RawMessage([]byte(s))
 }
+
+func TestEnsureNoUnknownFieldsClosedObjectRejectsExtraKey(t *testing.T) {
+	props := map[string]json.RawMessage{
+		"id":         raw(`{"type":"string"}`),
+		"dimensions": raw(`{"type":"object","additionalProperties":false,"properties":{"width":{"type":"number"}}}`),
+	}
+	if err := ensureNoUnknownFields(map[string]any{"id": "w-1", "stray": true}, props, raw(`false`), "Widget"); err == nil || !strings.Contains(err.Error(), "unknown field stray") {
+		t.Fatalf("expected closed entity to reject stray key, got %v", err)
+	}
+	entry := map[string]any{"id": "w-1", "dimensions": map[string]any{"width": 2.0, "depth": 3.0}}
+	if err := ensureNoUnknownFields(entry, props, nil, "Widget"); err == nil || !strings.Contains(err.Error(), "Widget.dimensions has unknown field depth") {
+		t.Fatalf("expected closed nested object to reject stray key, got %v", err)
+	}
+}
+
+func TestEnsureNoUnknownFieldsOpenObjectAcceptsExtraKey(t *testing.T) {
+	props := map[string]json.RawMessage{
+		"id":    raw(`{"type":"string"}`),
+		"attrs": raw(`{"type":"object","additionalProperties":true,"properties":{"color":{"type":"string"}}}`),
+	}
+	entry := map[string]any{"id": "w-1", "extra": 1, "attrs": map[string]any{"color": "red", "shade": "dark"}}
+	for _, additional := range []json.RawMessage{nil, raw(`true`)} {
+		if err := ensureNoUnknownFields(entry, props, additional, "Widget"); err != nil {
+			t.Fatalf("expected open object to accept extra keys (additionalProperties %s), got %v", additional, err)
+		}
+	}
+}
+
+func TestSchemasFromEntityHonorsAdditionalProperties(t *testing.T) {
+	ent := entitySpec{
+		Required:             []string{"id"},
+		Properties:           map[string]json.RawMessage{"id": raw(`{"type":"string"}`)},
+		AdditionalProperties: raw(`false`),
+	}
+	read, create, update, err := schemasFromEntity(ent, nil, nil)
+	if err != nil {
+		t.Fatalf("schemasFromEntity: %v", err)
+	}
+	for name, schema := range map[string]map[string]any{"read": read, "create": create, "update": update} {
+		if schema["additionalProperties"] != false {
+			t.Fatalf("expected %s schema to be closed, got %v", name, schema["additionalProperties"])
+		}
+	}
+	ent.AdditionalProperties = nil
+	read, _, _, err = schemasFromEntity(ent, nil, nil)
+	if err != nil {
+		t.Fatalf("schemasFromEntity: %v", err)
+	}
+	if _, ok := read["additionalProperties"]; ok {
+		t.Fatalf("expected undeclared additionalProperties to be omitted, got %v", read)
+	}
+}
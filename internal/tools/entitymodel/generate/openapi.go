@@ -120,6 +120,12 @@ func schemasFromEntity(ent entitySpec, enums map[string]enumSpec, defs map[strin
 		"properties": updateProps,
 	}
 
+	if val, ok := additionalPropertiesValue(ent.AdditionalProperties); ok {
+		for _, schema := range []map[string]any{read, create, update} {
+			schema["additionalProperties"] = val
+		}
+	}
+
 	return read, create, update, nil
 }
 
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"colonycore/pkg/openapi"
+	"go.yaml.in/yaml/v2"
+)
+
+// TestEmbeddedOpenAPIMatchesGenerator guards the spec the binary serves at
+// runtime: the bytes pkg/openapi.Document returns must equal a fresh
+// generation from the schema and parse as an OpenAPI YAML document.
+func TestEmbeddedOpenAPIMatchesGenerator(t *testing.T) {
+	doc, err := loadSchema(filepath.Join(repoRoot(t), "docs", "schema", "entity-model.json"))
+	if err != nil {
+		t.Fatalf("load schema: %v", err)
+	}
+	generated, err := generateOpenAPI(doc)
+	if err != nil {
+		t.Fatalf("generate openapi: %v", err)
+	}
+	embedded := openapi.Document()
+	if !bytes.Equal(bytes.TrimSpace(generated), bytes.TrimSpace(embedded)) {
+		t.Fatalf("embedded OpenAPI differs from generator output; run `make entity-model-generate` and rebuild")
+	}
+
+	var parsed map[string]any
+	if err := yaml.Unmarshal(embedded, &parsed); err != nil {
+		t.Fatalf("embedded OpenAPI is not valid YAML: %v", err)
+	}
+	if _, ok := parsed["openapi"]; !ok {
+		t.Fatalf("embedded OpenAPI missing openapi version key")
+	}
+	components, ok := parsed["components"].(map[any]any)
+	if !ok || components["schemas"] == nil {
+		t.Fatalf("embedded OpenAPI missing components.schemas")
+	}
+}
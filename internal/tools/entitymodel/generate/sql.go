@@ -339,6 +339,11 @@ func compileTables(doc schemaDoc, dialect dialectSpec) (map[string]tableSpec, []
 			}
 			indexes = append(indexes, fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS idx_%s_nk_%d ON %s (%s)", pluralize(toSnake(entName)), idx+1, pluralize(toSnake(entName)), strings.Join(nk.Fields, ", ")))
 		}
+		for _, idx := range columnIndexes(entName, filtered, doc.Enums, doc.Definitions) {
+			if !contains(indexes, idx) {
+				indexes = append(indexes, idx)
+			}
+		}
 
 		tableName := pluralize(toSnake(entName))
 		tables[tableName] = tableSpec{
@@ -355,6 +360,34 @@ func compileTables(doc schemaDoc, dialect dialectSpec) (map[string]tableSpec, []
 	return tables, requiredJoins, nil
 }
 
+// columnIndexes returns single-column indexes for properties annotated
+// `x-index: true` and for timestamp properties that take part in a natural key.
+// Composite natural-key indexes cannot serve range scans on a trailing
+// timestamp, so those columns get their own index.
+func columnIndexes(entName string, ent entitySpec, enums map[string]enumSpec, defs map[string]definitionSpec) []string {
+	inNaturalKey := make(map[string]struct{})
+	for _, nk := range ent.NaturalKeys {
+		for _, field := range nk.Fields {
+			inNaturalKey[field] = struct{}{}
+		}
+	}
+	props, _ := parseProperties(ent.Properties)
+	table := pluralize(toSnake(entName))
+	var out []string
+	for _, name := range sortedKeys(props) {
+		prop := props[name]
+		indexed := prop.Index
+		if _, ok := inNaturalKey[name]; ok && !indexed {
+			resolved, err := resolveProperty(prop, enums, defs)
+			indexed = err == nil && resolved.Type == typeString && resolved.Format == dateTimeFormat
+		}
+		if indexed {
+			out = append(out, fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_%s ON %s (%s)", table, name, table, name))
+		}
+	}
+	return out
+}
+
 func joinPairKey(sourceEntity, targetEntity, relName string) string {
 	source := toSnake(sourceEntity)
 	target := toSnake(targetEntity)
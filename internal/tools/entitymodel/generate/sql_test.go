@@ -456,6 +456,48 @@ func TestEnumChecksAndNaturalKeys(t *testing.T) {
 	}
 }
 
+func TestTimestampNaturalKeyAndAnnotatedColumnsIndexed(t *testing.T) {
+	doc := schemaDoc{
+		Definitions: map[string]definitionSpec{
+			"id":        {Type: typeString, Format: "uuid"},
+			"timestamp": {Type: typeString, Format: dateTimeFormat},
+		},
+		Entities: map[string]entitySpec{
+			"Reading": {
+				Required: []string{"id", "observer", "recorded_at"},
+				NaturalKeys: []naturalKeySpec{
+					{Fields: []string{"observer", "recorded_at"}, Scope: "global"},
+				},
+				Properties: map[string]json.RawMessage{
+					"id":          raw(`{"$ref":"#/definitions/id"}`),
+					"observer":    raw(`{"type":"string"}`),
+					"recorded_at": raw(`{"$ref":"#/definitions/timestamp"}`),
+					"reviewed_at": raw(`{"$ref":"#/definitions/timestamp"}`),
+					"batch":       raw(`{"type":"string","x-index":true}`),
+				},
+			},
+		},
+	}
+
+	sql, err := buildSQLForDialect(doc, postgresDialect)
+	if err != nil {
+		t.Fatalf("buildSQLForDialect: %v", err)
+	}
+	for _, want := range []string{
+		"CREATE INDEX IF NOT EXISTS idx_readings_recorded_at ON readings (recorded_at);",
+		"CREATE INDEX IF NOT EXISTS idx_readings_batch ON readings (batch);",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Fatalf("expected %q:\n%s", want, sql)
+		}
+	}
+	for _, unwanted := range []string{"idx_readings_observer", "idx_readings_reviewed_at"} {
+		if strings.Contains(sql, unwanted) {
+			t.Fatalf("did not expect %s index for non-timestamp natural key or unannotated column:\n%s", unwanted, sql)
+		}
+	}
+}
+
 func TestRequiredJoinEnforcementTriggers(t *testing.T) {
 	doc := schemaDoc{
 		Definitions: map[string]definitionSpec{
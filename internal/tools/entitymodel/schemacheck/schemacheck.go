@@ -0,0 +1,616 @@
+// Package schemacheck checks that the entity-model schema stays structurally
+// valid. It backs the entitymodelvalidate tool and cmd/validate-schema.
+package schemacheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type enumSpec struct {
+	Values []string `json:"values"`
+}
+
+type stateSpec struct {
+	Enum     string   `json:"enum"`
+	Initial  string   `json:"initial"`
+	Terminal []string `json:"terminal"`
+}
+
+type relationshipSpec struct {
+	Target      string `json:"target"`
+	Cardinality string `json:"cardinality"`
+	Storage     string `json:"storage"`
+}
+
+type naturalKeySpec struct {
+	Fields      []string `json:"fields"`
+	Scope       string   `json:"scope"`
+	Description string   `json:"description"`
+}
+
+type idSemanticsSpec struct {
+	Type        string `json:"type"`
+	Scope       string `json:"scope"`
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+}
+
+type entitySpec struct {
+	Description          string                      `json:"description"`
+	NaturalKeys          []naturalKeySpec            `json:"natural_keys"`
+	Required             []string                    `json:"required"`
+	Properties           map[string]json.RawMessage  `json:"properties"`
+	Relationships        map[string]relationshipSpec `json:"relationships"`
+	AdditionalProperties json.RawMessage             `json:"additionalProperties"`
+	States               *stateSpec                  `json:"states"`
+	Invariants           []string                    `json:"invariants"`
+}
+
+type metadataSpec struct {
+	Status string `json:"status"`
+}
+
+type schemaDoc struct {
+	Version  string                `json:"version"`
+	Metadata metadataSpec          `json:"metadata"`
+	Enums    map[string]enumSpec   `json:"enums"`
+	ID       *idSemanticsSpec      `json:"id_semantics"`
+	Entities map[string]entitySpec `json:"entities"`
+
+	Definitions map[string]definitionSpec `json:"definitions"`
+}
+
+type definitionSpec struct {
+	Type                 string                     `json:"type"`
+	Properties           map[string]json.RawMessage `json:"properties"`
+	AdditionalProperties json.RawMessage            `json:"additionalProperties"`
+}
+
+// Severity classifies a Diagnostic.
+type Severity string
+
+const (
+	// SeverityError marks a problem that makes the schema invalid.
+	SeverityError Severity = "error"
+	// SeverityWarning marks a construct that is allowed but usually unintended.
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a single problem found in a schema. Entity is empty for
+// document-level problems; Field names the property, relationship, or schema
+// section the problem concerns. Message is self-contained and matches the
+// corresponding Report entry.
+type Diagnostic struct {
+	Entity   string   `json:"entity,omitempty"`
+	Field    string   `json:"field,omitempty"`
+	Message  string   `json:"message"`
+	Severity Severity `json:"severity"`
+}
+
+// Report lists the problems found in a schema. Errors make the schema
+// invalid; Warnings flag constructs that are allowed but usually unintended.
+// Both lists are sorted.
+type Report struct {
+	Errors   []string `json:"errors,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Err joins the report's errors into a single error, or returns nil when there
+// are none. Warnings are not included.
+func (r Report) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(r.Errors, "; "))
+}
+
+// NewReport splits diagnostics into a Report's sorted error and warning
+// messages.
+func NewReport(diags []Diagnostic) Report {
+	var report Report
+	for _, diag := range diags {
+		switch diag.Severity {
+		case SeverityWarning:
+			report.Warnings = append(report.Warnings, diag.Message)
+		default:
+			report.Errors = append(report.Errors, diag.Message)
+		}
+	}
+	sort.Strings(report.Errors)
+	sort.Strings(report.Warnings)
+	return report
+}
+
+// Check reads the schema at path and reports every structural problem found.
+// The error is non-nil only when the file cannot be read or parsed.
+func Check(path string) (Report, error) {
+	diags, err := Diagnose(path)
+	if err != nil {
+		return Report{}, err
+	}
+	return NewReport(diags), nil
+}
+
+// Diagnose reads the schema at path and returns its structured diagnostics.
+// The error is non-nil only when the file cannot be read or parsed.
+func Diagnose(path string) ([]Diagnostic, error) {
+	//nolint:gosec // path is provided by the caller; validator is intended to read the specified schema file.
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read schema: %w", err)
+	}
+
+	var doc schemaDoc
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("parse schema JSON: %w", err)
+	}
+	return Validate(doc), nil
+}
+
+type diagnostics []Diagnostic
+
+func (d *diagnostics) errorf(entity, field, format string, args ...any) {
+	*d = append(*d, Diagnostic{Entity: entity, Field: field, Message: fmt.Sprintf(format, args...), Severity: SeverityError})
+}
+
+func (d *diagnostics) warnf(entity, field, format string, args ...any) {
+	*d = append(*d, Diagnostic{Entity: entity, Field: field, Message: fmt.Sprintf(format, args...), Severity: SeverityWarning})
+}
+
+// Validate returns every structural problem in doc, ordered by severity
+// (errors first), then entity, field, and message.
+func Validate(doc schemaDoc) []Diagnostic {
+	var d diagnostics
+
+	if !isSemver(doc.Version) {
+		d.errorf("", "version", "version must be set (semver expected)")
+	}
+	if strings.TrimSpace(doc.Metadata.Status) == "" {
+		d.errorf("", "metadata.status", "metadata.status must be set")
+	}
+	if len(doc.Enums) == 0 {
+		d.errorf("", "enums", "enums must not be empty")
+	}
+	for name, spec := range doc.Enums {
+		if len(spec.Values) == 0 {
+			d.errorf("", "enums."+name, "enum %q must include at least one value", name)
+			continue
+		}
+		for i, v := range spec.Values {
+			if strings.TrimSpace(v) == "" {
+				d.errorf("", "enums."+name, "enum %q value #%d must not be empty", name, i)
+			}
+		}
+		if dup := firstDuplicate(spec.Values); dup != "" {
+			d.errorf("", "enums."+name, "enum %q has duplicate value %q", name, dup)
+		}
+	}
+
+	if len(doc.Entities) == 0 {
+		d.errorf("", "entities", "entities section must not be empty")
+	}
+
+	if doc.ID == nil {
+		d.errorf("", "id_semantics", "id_semantics must be declared")
+	} else {
+		if strings.TrimSpace(doc.ID.Type) == "" {
+			d.errorf("", "id_semantics.type", "id_semantics.type must be set")
+		}
+		if strings.TrimSpace(doc.ID.Scope) == "" {
+			d.errorf("", "id_semantics.scope", "id_semantics.scope must be set")
+		}
+		if !doc.ID.Required {
+			d.errorf("", "id_semantics.required", "id_semantics.required must be true")
+		}
+		if strings.TrimSpace(doc.ID.Description) == "" {
+			d.errorf("", "id_semantics.description", "id_semantics.description must be set")
+		}
+	}
+
+	allowedInvariants := map[string]struct{}{
+		"cohort_homogeneity":       {},
+		"facility_accreditation":   {},
+		"housing_capacity":         {},
+		"lineage_integrity":        {},
+		"lifecycle_transition":     {},
+		"permit_protocol_status":   {},
+		"protocol_approval_quorum": {},
+		"protocol_coverage":        {},
+		"protocol_subject_cap":     {},
+		"severe_adverse_event":     {},
+		"supply_reorder":           {},
+	}
+
+	allowedUnits := map[string]struct{}{
+		"celsius": {},
+		"cm":      {},
+		"count":   {},
+		"days":    {},
+		"g":       {},
+		"hours":   {},
+		"kg":      {},
+		"l":       {},
+		"mg":      {},
+		"mg/kg":   {},
+		"ml":      {},
+		"mm":      {},
+	}
+
+	usedEnums := make(map[string]struct{}, len(doc.Enums))
+
+	baseRequired := []string{"id", "created_at", "updated_at"}
+
+	for name, ent := range doc.Entities {
+		if len(ent.Required) == 0 {
+			d.errorf(name, "required", "entity %q must declare required fields", name)
+		}
+		if len(ent.Properties) == 0 {
+			d.errorf(name, "properties", "entity %q must declare properties", name)
+		}
+		if ent.NaturalKeys == nil {
+			d.errorf(name, "natural_keys", "entity %q must declare natural_keys (empty array allowed)", name)
+		} else if len(ent.NaturalKeys) == 0 {
+			d.warnf(name, "natural_keys", "entity %q declares no natural keys", name)
+		}
+		if ent.Relationships == nil {
+			d.errorf(name, "relationships", "entity %q must declare relationships (empty object allowed)", name)
+		}
+		if ent.Invariants == nil {
+			d.errorf(name, "invariants", "entity %q must declare invariants (empty array allowed)", name)
+		}
+		if _, ok := boolValue(ent.AdditionalProperties); len(ent.AdditionalProperties) > 0 && !ok {
+			d.errorf(name, "additionalProperties", "entity %q additionalProperties must be a boolean", name)
+		}
+
+		for _, base := range baseRequired {
+			if !contains(ent.Required, base) {
+				d.errorf(name, base, "entity %q must require base field %q", name, base)
+			}
+		}
+
+		for _, field := range ent.Required {
+			if _, ok := ent.Properties[field]; !ok {
+				d.errorf(name, field, "entity %q required field %q missing from properties", name, field)
+			}
+		}
+
+		for i, nk := range ent.NaturalKeys {
+			if len(nk.Fields) == 0 {
+				d.errorf(name, "natural_keys", "entity %q natural key #%d must declare at least one field", name, i)
+			}
+			for _, field := range nk.Fields {
+				if _, ok := ent.Properties[field]; !ok {
+					d.errorf(name, field, "entity %q natural key field %q missing from properties", name, field)
+				}
+			}
+			if nk.Scope == "" {
+				fieldLabel := strings.Join(nk.Fields, ",")
+				if fieldLabel == "" {
+					fieldLabel = "<unset>"
+				}
+				d.errorf(name, "natural_keys", "entity %q natural key [%s] must declare scope", name, fieldLabel)
+			}
+		}
+
+		if ent.States != nil {
+			if ent.States.Enum == "" {
+				d.errorf(name, "states", "entity %q states.enum must reference an enum name", name)
+			} else if _, ok := doc.Enums[ent.States.Enum]; !ok {
+				d.errorf(name, "states", "entity %q states.enum %q not found in enums", name, ent.States.Enum)
+			} else {
+				usedEnums[ent.States.Enum] = struct{}{}
+				enumValues := doc.Enums[ent.States.Enum].Values
+				if ent.States.Initial == "" {
+					d.errorf(name, "states", "entity %q states.initial must reference a value in enum %q", name, ent.States.Enum)
+				} else if !contains(enumValues, ent.States.Initial) {
+					d.errorf(name, "states", "entity %q states.initial %q not found in enum %q", name, ent.States.Initial, ent.States.Enum)
+				}
+				if len(ent.States.Terminal) == 0 {
+					d.errorf(name, "states", "entity %q states.terminal must include at least one value", name)
+				}
+				for _, term := range ent.States.Terminal {
+					if !contains(enumValues, term) {
+						d.errorf(name, "states", "entity %q states.terminal value %q not found in enum %q", name, term, ent.States.Enum)
+					}
+				}
+				if dup := firstDuplicate(ent.States.Terminal); dup != "" {
+					d.errorf(name, "states", "entity %q states.terminal has duplicate value %q", name, dup)
+				}
+			}
+		}
+
+		for relName, rel := range ent.Relationships {
+			if rel.Target == "" {
+				d.errorf(name, relName, "entity %q relationship %q missing target", name, relName)
+				continue
+			}
+			if _, ok := doc.Entities[rel.Target]; !ok {
+				d.errorf(name, relName, "entity %q relationship %q targets unknown entity %q", name, relName, rel.Target)
+			}
+			if _, ok := ent.Properties[relName]; !ok {
+				d.errorf(name, relName, "entity %q relationship %q missing property definition", name, relName)
+			}
+			if strings.TrimSpace(rel.Cardinality) == "" {
+				d.errorf(name, relName, "entity %q relationship %q missing cardinality", name, relName)
+			} else if !isValidCardinality(rel.Cardinality) {
+				d.errorf(name, relName, "entity %q relationship %q has invalid cardinality %q", name, relName, rel.Cardinality)
+			}
+			if storage := strings.TrimSpace(rel.Storage); storage != "" && !isValidStorage(storage) {
+				d.errorf(name, relName, "entity %q relationship %q has invalid storage %q", name, relName, storage)
+			}
+			if prop, ok := ent.Properties[relName]; ok {
+				meta, err := extractPropertyMeta(prop)
+				jsonStorage := strings.EqualFold(strings.TrimSpace(rel.Storage), "json")
+				switch {
+				case err != nil:
+				case jsonStorage && !meta.jsonCompatible():
+					d.errorf(name, relName, "entity %q relationship %q uses json storage but property type is %q", name, relName, meta.typ)
+				case !jsonStorage && meta.freeObject(doc.Definitions):
+					d.errorf(name, relName, "entity %q relationship %q conflicts with free-form object property; use json storage or an ID property", name, relName)
+				}
+			}
+		}
+
+		for i, invariant := range ent.Invariants {
+			if strings.TrimSpace(invariant) == "" {
+				d.errorf(name, "invariants", "entity %q invariants[%d] must not be empty", name, i)
+				continue
+			}
+			if _, ok := allowedInvariants[invariant]; !ok {
+				d.errorf(name, "invariants", "entity %q invariants[%d] %q is not in the allowed invariants list", name, i, invariant)
+			}
+		}
+		if dup := firstDuplicate(ent.Invariants); dup != "" {
+			d.errorf(name, "invariants", "entity %q invariants has duplicate entry %q", name, dup)
+		}
+
+		for propName, prop := range ent.Properties {
+			meta, err := extractPropertyMeta(prop)
+			if err != nil {
+				d.errorf(name, propName, "entity %q property %q invalid JSON: %v", name, propName, err)
+				continue
+			}
+			if !meta.hasType && !meta.hasRef {
+				d.errorf(name, propName, "entity %q property %q must declare a type or $ref", name, propName)
+			}
+			if meta.unit != nil {
+				if _, ok := allowedUnits[*meta.unit]; !ok {
+					d.errorf(name, propName, "entity %q property %q unit %q is not in the allowed units list", name, propName, *meta.unit)
+				}
+			}
+			if meta.auditInvalid {
+				d.errorf(name, propName, "entity %q property %q x-audit must be a boolean", name, propName)
+			}
+			if meta.indexInvalid {
+				d.errorf(name, propName, "entity %q property %q x-index must be a boolean", name, propName)
+			}
+			if propName == "tags" && !meta.tagList {
+				d.errorf(name, propName, "entity %q property %q must be an array of non-empty strings with uniqueItems", name, propName)
+			}
+			for _, enumName := range meta.enums {
+				if _, ok := doc.Enums[enumName]; !ok {
+					d.errorf(name, propName, "entity %q property %q references unknown enum %q", name, propName, enumName)
+					continue
+				}
+				usedEnums[enumName] = struct{}{}
+			}
+		}
+	}
+
+	for defName, def := range doc.Definitions {
+		for propName, prop := range def.Properties {
+			meta, err := extractPropertyMeta(prop)
+			if err != nil {
+				d.errorf("", "definitions."+defName+"."+propName, "definition %q property %q invalid JSON: %v", defName, propName, err)
+				continue
+			}
+			for _, enumName := range meta.enums {
+				if _, ok := doc.Enums[enumName]; !ok {
+					d.errorf("", "definitions."+defName+"."+propName, "definition %q property %q references unknown enum %q", defName, propName, enumName)
+					continue
+				}
+				usedEnums[enumName] = struct{}{}
+			}
+		}
+	}
+
+	for enumName := range doc.Enums {
+		if _, ok := usedEnums[enumName]; !ok {
+			d.errorf("", "enums."+enumName, "enum %q is defined but not referenced by any entity states or properties", enumName)
+		}
+	}
+
+	sort.Slice(d, func(i, j int) bool {
+		a, b := d[i], d[j]
+		if a.Severity != b.Severity {
+			return a.Severity == SeverityError
+		}
+		if a.Entity != b.Entity {
+			return a.Entity < b.Entity
+		}
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+		return a.Message < b.Message
+	})
+	return d
+}
+
+func contains(list []string, needle string) bool {
+	for _, candidate := range list {
+		if strings.EqualFold(candidate, needle) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSemver(version string) bool {
+	semverRe := regexp.MustCompile(`^v?[0-9]+\.[0-9]+\.[0-9]+(?:-[0-9A-Za-z.-]+)?$`)
+	return semverRe.MatchString(strings.TrimSpace(version))
+}
+
+func isValidCardinality(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "0..1", "1..1", "0..n", "1..n":
+		return true
+	default:
+		return false
+	}
+}
+
+func isValidStorage(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "fk", "join", "derived", "json":
+		return true
+	default:
+		return false
+	}
+}
+
+func firstDuplicate(values []string) string {
+	seen := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; ok {
+			return v
+		}
+		seen[v] = struct{}{}
+	}
+	return ""
+}
+
+type propertyMeta struct {
+	enums        []string
+	unit         *string
+	auditInvalid bool
+	indexInvalid bool
+	tagList      bool
+	hasType      bool
+	hasRef       bool
+	ref          string
+	typ          string
+	// open is true when the property itself is an object that either sets
+	// additionalProperties to true or declares no properties.
+	open bool
+}
+
+// jsonCompatible reports whether the property can back a relationship stored
+// in an extension attribute column. Objects and $ref properties such as
+// extension_attributes qualify; string and array columns do not.
+func (m propertyMeta) jsonCompatible() bool {
+	return m.typ != "string" && m.typ != "array"
+}
+
+// freeObject reports whether the property accepts arbitrary keys, either
+// inline or through a $ref to an open definition such as
+// extension_attributes. A free-form object cannot also hold the ID a
+// non-json relationship points through.
+func (m propertyMeta) freeObject(defs map[string]definitionSpec) bool {
+	if m.open {
+		return true
+	}
+	name, ok := strings.CutPrefix(m.ref, "#/definitions/")
+	if !ok {
+		return false
+	}
+	def, ok := defs[name]
+	if !ok || def.Type != "object" {
+		return false
+	}
+	open, _ := boolValue(def.AdditionalProperties)
+	return open || len(def.Properties) == 0
+}
+
+func extractPropertyMeta(raw json.RawMessage) (propertyMeta, error) {
+	var prop map[string]any
+	if err := json.Unmarshal(raw, &prop); err != nil {
+		return propertyMeta{}, err
+	}
+
+	var unit *string
+	if raw, ok := prop["unit"]; ok {
+		value := asString(raw)
+		unit = &value
+	}
+
+	auditInvalid := false
+	if raw, ok := prop["x-audit"]; ok {
+		_, isBool := raw.(bool)
+		auditInvalid = !isBool
+	}
+
+	indexInvalid := false
+	if raw, ok := prop["x-index"]; ok {
+		_, isBool := raw.(bool)
+		indexInvalid = !isBool
+	}
+
+	return propertyMeta{
+		enums:        enumRefs(prop),
+		unit:         unit,
+		auditInvalid: auditInvalid,
+		indexInvalid: indexInvalid,
+		tagList:      isTagList(prop),
+		hasType:      strings.TrimSpace(asString(prop["type"])) != "",
+		hasRef:       strings.TrimSpace(asString(prop["$ref"])) != "",
+		ref:          strings.TrimSpace(asString(prop["$ref"])),
+		typ:          strings.TrimSpace(asString(prop["type"])),
+		open:         isOpenObject(prop),
+	}, nil
+}
+
+// isOpenObject reports whether prop is an inline object that accepts keys it
+// does not declare.
+func isOpenObject(prop map[string]any) bool {
+	if asString(prop["type"]) != "object" {
+		return false
+	}
+	if prop["additionalProperties"] == true {
+		return true
+	}
+	props, _ := prop["properties"].(map[string]any)
+	return len(props) == 0
+}
+
+// boolValue decodes raw as a JSON boolean, reporting whether it was one.
+func boolValue(raw json.RawMessage) (bool, bool) {
+	var val bool
+	if len(raw) == 0 || json.Unmarshal(raw, &val) != nil {
+		return false, false
+	}
+	return val, true
+}
+
+// isTagList reports whether prop declares a list of non-empty, unique strings,
+// the shape required of tags properties.
+func isTagList(prop map[string]any) bool {
+	if asString(prop["type"]) != "array" || prop["uniqueItems"] != true {
+		return false
+	}
+	items, ok := prop["items"].(map[string]any)
+	if !ok || asString(items["type"]) != "string" {
+		return false
+	}
+	minLength, _ := items["minLength"].(float64)
+	return minLength >= 1
+}
+
+func enumRefs(prop map[string]any) []string {
+	var enums []string
+	ref := asString(prop["$ref"])
+	if strings.HasPrefix(ref, "#/enums/") {
+		enums = append(enums, strings.TrimPrefix(ref, "#/enums/"))
+	}
+	return enums
+}
+
+func asString(candidate any) string {
+	value, _ := candidate.(string)
+	return value
+}
@@ -0,0 +1,255 @@
+package schemacheck
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const schemaWithoutNaturalKeys = `{
+  "version": "0.0.1",
+  "id_semantics": { "type": "uuidv7", "scope": "global", "required": true, "description": "opaque" },
+  "metadata": { "status": "seed" },
+  "enums": {
+    "status": { "values": ["ok"] }
+  },
+  "entities": {
+    "Foo": {
+      "natural_keys": [],
+      "required": ["id", "created_at", "updated_at"],
+      "properties": {
+        "id": {"type":"string"},
+        "created_at": {"type":"string"},
+        "updated_at": {"type":"string"},
+        "status": {"$ref":"#/enums/status"}
+      },
+      "relationships": {},
+      "states": {"enum": "status", "initial": "ok", "terminal": ["ok"]},
+      "invariants": []
+    }
+  }
+}`
+
+func TestCheckSeparatesWarningsFromErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "entity-model.json")
+	if err := os.WriteFile(path, []byte(schemaWithoutNaturalKeys), 0o600); err != nil {
+		t.Fatalf("write schema: %v", err)
+	}
+	report, err := Check(path)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if report.Err() != nil {
+		t.Fatalf("expected no errors, got %v", report.Errors)
+	}
+	if len(report.Warnings) != 1 || report.Warnings[0] != `entity "Foo" declares no natural keys` {
+		t.Fatalf("expected missing natural key warning, got %v", report.Warnings)
+	}
+}
+
+func TestCheckRepositorySchema(t *testing.T) {
+	report, err := Check(filepath.Join("..", "..", "..", "..", "docs", "schema", "entity-model.json"))
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if len(report.Errors) != 0 || len(report.Warnings) != 0 {
+		t.Fatalf("expected repository schema to pass strictly, got errors %v warnings %v", report.Errors, report.Warnings)
+	}
+}
+
+func TestCheckUnreadableSchema(t *testing.T) {
+	if _, err := Check(filepath.Join(t.TempDir(), "missing.json")); err == nil || !strings.Contains(err.Error(), "read schema") {
+		t.Fatalf("expected read error, got %v", err)
+	}
+}
+
+func TestContains(t *testing.T) {
+	t.Helper()
+	if !contains([]string{"Id", "Created"}, "id") {
+		t.Fatalf("contains should be case insensitive")
+	}
+	if contains([]string{"foo"}, "bar") {
+		t.Fatalf("contains returned true for missing element")
+	}
+}
+
+func TestCheckJSONRelationshipStorage(t *testing.T) {
+	const template = `{
+  "version": "0.0.1",
+  "id_semantics": { "type": "uuidv7", "scope": "global", "required": true, "description": "opaque" },
+  "metadata": { "status": "seed" },
+  "enums": { "status": { "values": ["ok"] } },
+  "entities": {
+    "Foo": {
+      "natural_keys": [{"fields": ["id"], "scope": "global"}],
+      "required": ["id", "created_at", "updated_at"],
+      "properties": {
+        "id": {"type":"string"},
+        "created_at": {"type":"string"},
+        "updated_at": {"type":"string"},
+        "status": {"$ref":"#/enums/status"},
+        "bar_ref": %s
+      },
+      "relationships": {"bar_ref": {"target": "Foo", "cardinality": "0..1", "storage": %q}},
+      "invariants": []
+    }
+  }
+}`
+	cases := []struct {
+		name     string
+		property string
+		storage  string
+		wantErr  string
+	}{
+		{"string property", `{"type":"string"}`, "json", `entity "Foo" relationship "bar_ref" uses json storage but property type is "string"`},
+		{"array property", `{"type":"array","items":{"type":"string"}}`, "json", `entity "Foo" relationship "bar_ref" uses json storage but property type is "array"`},
+		{"object property", `{"type":"object"}`, "json", ""},
+		{"extension attributes ref", `{"$ref":"#/definitions/extension_attributes"}`, "json", ""},
+		{"fk storage skips check", `{"type":"string"}`, "fk", ""},
+		{"free object with fk storage", `{"type":"object","additionalProperties":true}`, "fk", `entity "Foo" relationship "bar_ref" conflicts with free-form object property; use json storage or an ID property`},
+		{"closed object with fk storage", `{"type":"object","additionalProperties":false,"properties":{"id":{"type":"string"}}}`, "fk", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "entity-model.json")
+			if err := os.WriteFile(path, []byte(fmt.Sprintf(template, tc.property, tc.storage)), 0o600); err != nil {
+				t.Fatalf("write schema: %v", err)
+			}
+			report, err := Check(path)
+			if err != nil {
+				t.Fatalf("check: %v", err)
+			}
+			if tc.wantErr == "" {
+				if len(report.Errors) != 0 {
+					t.Fatalf("expected no errors, got %v", report.Errors)
+				}
+				return
+			}
+			if len(report.Errors) != 1 || report.Errors[0] != tc.wantErr {
+				t.Fatalf("expected %q, got %v", tc.wantErr, report.Errors)
+			}
+		})
+	}
+}
+
+func TestCheckTagsProperty(t *testing.T) {
+	const template = `{
+  "version": "0.0.1",
+  "id_semantics": { "type": "uuidv7", "scope": "global", "required": true, "description": "opaque" },
+  "metadata": { "status": "seed" },
+  "enums": { "status": { "values": ["ok"] } },
+  "entities": {
+    "Foo": {
+      "natural_keys": [{"fields": ["id"], "scope": "global"}],
+      "required": ["id", "created_at", "updated_at"],
+      "properties": {
+        "id": {"type":"string"},
+        "created_at": {"type":"string"},
+        "updated_at": {"type":"string"},
+        "status": {"$ref":"#/enums/status"},
+        "tags": %s
+      },
+      "relationships": {},
+      "invariants": []
+    }
+  }
+}`
+	const wantErr = `entity "Foo" property "tags" must be an array of non-empty strings with uniqueItems`
+	cases := []struct {
+		name     string
+		property string
+		valid    bool
+	}{
+		{"non-empty unique strings", `{"type":"array","items":{"type":"string","minLength":1},"uniqueItems":true}`, true},
+		{"duplicates allowed", `{"type":"array","items":{"type":"string","minLength":1}}`, false},
+		{"empty strings allowed", `{"type":"array","items":{"type":"string"},"uniqueItems":true}`, false},
+		{"non-string items", `{"type":"array","items":{"type":"integer","minLength":1},"uniqueItems":true}`, false},
+		{"not a list", `{"type":"string","minLength":1}`, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "entity-model.json")
+			if err := os.WriteFile(path, []byte(fmt.Sprintf(template, tc.property)), 0o600); err != nil {
+				t.Fatalf("write schema: %v", err)
+			}
+			report, err := Check(path)
+			if err != nil {
+				t.Fatalf("check: %v", err)
+			}
+			if tc.valid {
+				if len(report.Errors) != 0 {
+					t.Fatalf("expected no errors, got %v", report.Errors)
+				}
+				return
+			}
+			if len(report.Errors) != 1 || report.Errors[0] != wantErr {
+				t.Fatalf("expected %q, got %v", wantErr, report.Errors)
+			}
+		})
+	}
+}
+
+func TestDiagnoseReturnsStructuredEntityDiagnostics(t *testing.T) {
+	const schema = `{
+  "version": "0.0.1",
+  "id_semantics": { "type": "uuidv7", "scope": "global", "required": true, "description": "opaque" },
+  "metadata": { "status": "seed" },
+  "enums": { "status": { "values": ["ok"] } },
+  "entities": {
+    "Bar": {
+      "natural_keys": [{"fields": ["id"], "scope": "global"}],
+      "required": ["id", "created_at", "updated_at"],
+      "properties": {
+        "id": {"type":"string"},
+        "created_at": {"type":"string"},
+        "updated_at": {"type":"string"},
+        "weight": {"type":"number", "unit": "stone"}
+      },
+      "relationships": {},
+      "invariants": []
+    },
+    "Foo": {
+      "natural_keys": [],
+      "required": ["id", "created_at", "updated_at"],
+      "properties": {
+        "id": {"type":"string"},
+        "created_at": {"type":"string"},
+        "updated_at": {"type":"string"},
+        "status": {"$ref":"#/enums/status"}
+      },
+      "relationships": {},
+      "invariants": ["unknown_rule"]
+    }
+  }
+}`
+	path := filepath.Join(t.TempDir(), "entity-model.json")
+	if err := os.WriteFile(path, []byte(schema), 0o600); err != nil {
+		t.Fatalf("write schema: %v", err)
+	}
+	diags, err := Diagnose(path)
+	if err != nil {
+		t.Fatalf("diagnose: %v", err)
+	}
+	want := []Diagnostic{
+		{Entity: "Bar", Field: "weight", Message: `entity "Bar" property "weight" unit "stone" is not in the allowed units list`, Severity: SeverityError},
+		{Entity: "Foo", Field: "invariants", Message: `entity "Foo" invariants[0] "unknown_rule" is not in the allowed invariants list`, Severity: SeverityError},
+		{Entity: "Foo", Field: "natural_keys", Message: `entity "Foo" declares no natural keys`, Severity: SeverityWarning},
+	}
+	if !reflect.DeepEqual(diags, want) {
+		t.Fatalf("unexpected diagnostics:\n got %+v\nwant %+v", diags, want)
+	}
+
+	report, err := Check(path)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if report.Err().Error() != want[0].Message+"; "+want[1].Message {
+		t.Fatalf("expected joined text derived from diagnostics, got %q", report.Err())
+	}
+	if len(report.Warnings) != 1 || report.Warnings[0] != want[2].Message {
+		t.Fatalf("expected warning derived from diagnostics, got %v", report.Warnings)
+	}
+}
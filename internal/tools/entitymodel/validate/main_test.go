@@ -2,9 +2,13 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
+
+	"colonycore/internal/tools/entitymodel/schemacheck"
 )
 
 func TestValidateOK(t *testing.T) {
@@ -566,6 +570,84 @@ func TestValidatePropertyRequiresTypeOrRef(t *testing.T) {
 	}
 }
 
+func TestValidatePropertyUnitAllowlist(t *testing.T) {
+	path := writeTemp(t, `{
+  "version": "0.1.3",
+  "id_semantics": { "type": "uuidv7", "scope": "global", "required": true, "description": "opaque" },
+  "metadata": { "status": "seed" },
+  "enums": {
+    "status": { "values": ["ok"] }
+  },
+  "entities": {
+    "Foo": {
+      "natural_keys": [],
+      "required": ["id", "created_at", "updated_at", "status"],
+      "properties": {
+        "id": {"type":"string"},
+        "created_at": {"type":"string"},
+        "updated_at": {"type":"string"},
+        "status": {"$ref":"#/enums/status"},
+        "dose": {"type":"number","unit":"mg"},
+        "volume": {"type":"number","unit":"furlongs"}
+      },
+      "relationships": {},
+      "invariants": []
+    }
+  }
+}`)
+
+	err := validate(path)
+	if err == nil {
+		t.Fatalf("validate() expected error")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, `entity "Foo" property "volume" unit "furlongs" is not in the allowed units list`) {
+		t.Fatalf("expected unit allowlist error, got %q", msg)
+	}
+	if strings.Contains(msg, `"dose"`) {
+		t.Fatalf("expected allowlisted unit to pass, got %q", msg)
+	}
+}
+
+func TestValidatePropertyAuditAnnotation(t *testing.T) {
+	path := writeTemp(t, `{
+  "version": "0.1.3",
+  "id_semantics": { "type": "uuidv7", "scope": "global", "required": true, "description": "opaque" },
+  "metadata": { "status": "seed" },
+  "enums": {
+    "status": { "values": ["ok"] }
+  },
+  "entities": {
+    "Foo": {
+      "natural_keys": [],
+      "required": ["id", "created_at", "updated_at", "status"],
+      "properties": {
+        "id": {"type":"string"},
+        "created_at": {"type":"string"},
+        "updated_at": {"type":"string"},
+        "status": {"$ref":"#/enums/status","x-audit":true},
+        "notes": {"type":"string","x-audit":false},
+        "stage": {"type":"string","x-audit":"yes"}
+      },
+      "relationships": {},
+      "invariants": []
+    }
+  }
+}`)
+
+	err := validate(path)
+	if err == nil {
+		t.Fatalf("validate() expected error")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, `entity "Foo" property "stage" x-audit must be a boolean`) {
+		t.Fatalf("expected x-audit type error, got %q", msg)
+	}
+	if strings.Contains(msg, `"status" x-audit`) || strings.Contains(msg, `"notes" x-audit`) {
+		t.Fatalf("expected boolean x-audit annotations to pass, got %q", msg)
+	}
+}
+
 func TestValidateRequiresRelationshipsAndInvariants(t *testing.T) {
 	path := writeTemp(t, `{
   "version": "0.1.31",
@@ -670,16 +752,6 @@ func TestValidatePropertyJSONError(t *testing.T) {
 	}
 }
 
-func TestContains(t *testing.T) {
-	t.Helper()
-	if !contains([]string{"Id", "Created"}, "id") {
-		t.Fatalf("contains should be case insensitive")
-	}
-	if contains([]string{"foo"}, "bar") {
-		t.Fatalf("contains returned true for missing element")
-	}
-}
-
 func TestMainSuccess(t *testing.T) {
 	originalArgs := os.Args
 	defer func() {
@@ -750,3 +822,73 @@ func writeTemp(t *testing.T, contents string) string {
 	}
 	return f.Name()
 }
+
+func TestMainJSONFormat(t *testing.T) {
+	originalArgs := os.Args
+	defer func() { os.Args = originalArgs }()
+	defer func() { exitFn = os.Exit }()
+	defer func() { outWriter = os.Stdout }()
+
+	path := writeTemp(t, `{
+  "version": "0.0.3",
+  "id_semantics": { "type": "uuidv7", "scope": "global", "required": true, "description": "opaque" },
+  "metadata": { "status": "seed" },
+  "enums": { "status": { "values": ["ok"] } },
+  "entities": {
+    "Foo": {
+      "natural_keys": [],
+      "required": ["id", "created_at", "updated_at", "name"],
+      "properties": {
+        "id": {"type":"string"},
+        "created_at": {"type":"string"},
+        "updated_at": {"type":"string"},
+        "status": {"$ref":"#/enums/status"},
+        "bar_id": {"type":"string"}
+      },
+      "relationships": {"bar_id": {"target": "Missing", "cardinality": "0..1"}},
+      "invariants": []
+    }
+  }
+}`)
+	os.Args = []string{"entitymodelvalidate", "-format", "json", path}
+	var out bytes.Buffer
+	outWriter = &out
+	var code int
+	exitFn = func(c int) { code = c }
+
+	main()
+
+	if code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	var diags []schemacheck.Diagnostic
+	if err := json.Unmarshal(out.Bytes(), &diags); err != nil {
+		t.Fatalf("decode diagnostics: %v\n%s", err, out.String())
+	}
+	want := []schemacheck.Diagnostic{
+		{Entity: "Foo", Field: "bar_id", Message: `entity "Foo" relationship "bar_id" targets unknown entity "Missing"`, Severity: schemacheck.SeverityError},
+		{Entity: "Foo", Field: "name", Message: `entity "Foo" required field "name" missing from properties`, Severity: schemacheck.SeverityError},
+	}
+	if !reflect.DeepEqual(diags, want) {
+		t.Fatalf("unexpected diagnostics:\n got %+v\nwant %+v", diags, want)
+	}
+}
+
+func TestMainRejectsUnknownFormat(t *testing.T) {
+	originalArgs := os.Args
+	defer func() { os.Args = originalArgs }()
+	defer func() { exitFn = os.Exit }()
+	defer func() { errWriter = os.Stderr }()
+
+	os.Args = []string{"entitymodelvalidate", "-format", "yaml"}
+	var buf bytes.Buffer
+	errWriter = &buf
+	var code int
+	exitFn = func(c int) { code = c }
+
+	main()
+
+	if code != 1 || !strings.Contains(buf.String(), `unknown format "yaml"`) {
+		t.Fatalf("expected unknown format failure, got code %d output %q", code, buf.String())
+	}
+}
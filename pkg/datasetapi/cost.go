@@ -0,0 +1,44 @@
+package datasetapi
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// CostEntityAnnotation names the store collection a template scans, such as
+	// "organisms" or "samples", so hosts can estimate a run's row count before
+	// executing it. Templates without the annotation cannot be estimated.
+	CostEntityAnnotation = "cost.entity"
+	// CostLimitParameter names the optional template parameter that caps the
+	// rows a run returns; estimates never exceed a positive limit.
+	CostLimitParameter = "limit"
+)
+
+// ErrQueryTooLarge is matched by QueryTooLargeError when an estimated run
+// exceeds the configured row budget.
+var ErrQueryTooLarge = errors.New("datasetapi: query exceeds cost budget")
+
+// CostEstimate reports the estimated size of a dataset run. Budget is zero
+// when no budget is configured; Estimated is false when the template does not
+// declare a CostEntityAnnotation.
+type CostEstimate struct {
+	Template      string `json:"template"`
+	Entity        string `json:"entity,omitempty"`
+	Rows          int    `json:"rows"`
+	Budget        int    `json:"budget,omitempty"`
+	Estimated     bool   `json:"estimated"`
+	ExceedsBudget bool   `json:"exceeds_budget"`
+}
+
+// QueryTooLargeError carries the estimate for a run rejected by the budget.
+type QueryTooLargeError struct {
+	Estimate CostEstimate
+}
+
+func (e *QueryTooLargeError) Error() string {
+	return fmt.Sprintf("%v: %s estimated %d rows, budget %d", ErrQueryTooLarge, e.Estimate.Template, e.Estimate.Rows, e.Estimate.Budget)
+}
+
+// Unwrap allows errors.Is(err, ErrQueryTooLarge).
+func (e *QueryTooLargeError) Unwrap() error { return ErrQueryTooLarge }
@@ -0,0 +1,190 @@
+package datasetapi
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResultEncoder renders a typed result set into a canonical tabular export
+// encoding. Rows are positional and aligned with the supplied columns.
+type ResultEncoder interface {
+	// Format reports the output format produced by the encoder.
+	Format() Format
+	// ContentType reports the MIME type of the encoded payload.
+	ContentType() string
+	// Encode writes columns and rows to w.
+	Encode(w io.Writer, columns []Column, rows iter.Seq[[]any]) error
+}
+
+// EncoderFor returns the tabular encoder registered for format.
+func EncoderFor(format Format) (ResultEncoder, bool) {
+	switch format {
+	case GetFormatProvider().CSV():
+		return csvEncoder{}, true
+	case GetFormatProvider().Parquet():
+		return parquetEncoder{}, true
+	default:
+		return nil, false
+	}
+}
+
+// RowValues projects map-shaped rows onto the positional layout expected by
+// ResultEncoder, yielding one value per column in column order.
+func RowValues(columns []Column, rows []Row) iter.Seq[[]any] {
+	return func(yield func([]any) bool) {
+		for _, row := range rows {
+			values := make([]any, len(columns))
+			for i, column := range columns {
+				values[i] = row[column.Name]
+			}
+			if !yield(values) {
+				return
+			}
+		}
+	}
+}
+
+// RowKey returns the canonical ordering key for row: the formatted cell values
+// in column order, JSON encoded. Rows with equal keys are indistinguishable in
+// every tabular export encoding.
+func RowKey(columns []Column, row Row) string {
+	cells := make([]string, len(columns))
+	for i, column := range columns {
+		cells[i] = formatCell(row[column.Name])
+	}
+	// Marshalling a slice of strings cannot fail.
+	encoded, _ := json.Marshal(cells)
+	return string(encoded)
+}
+
+// OrderRows returns a copy of rows sorted by RowKey, giving resumable exports a
+// stable ordering that does not depend on the order a runner emits rows.
+func OrderRows(columns []Column, rows []Row) []Row {
+	type keyed struct {
+		key string
+		row Row
+	}
+	sorted := make([]keyed, len(rows))
+	for i, row := range rows {
+		sorted[i] = keyed{key: RowKey(columns, row), row: row}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].key < sorted[j].key })
+	out := make([]Row, len(sorted))
+	for i, entry := range sorted {
+		out[i] = entry.row
+	}
+	return out
+}
+
+type csvEncoder struct{}
+
+func (csvEncoder) Format() Format { return GetFormatProvider().CSV() }
+
+func (csvEncoder) ContentType() string { return "text/csv" }
+
+func (csvEncoder) Encode(w io.Writer, columns []Column, rows iter.Seq[[]any]) error {
+	return EncodeCSV(w, columns, rows)
+}
+
+// EncodeCSV writes rows as RFC 4180 CSV preceded by a header record of column
+// names. When any column declares a unit, a leading "# units:" comment line
+// lists name=unit pairs in column order. Nil cells render as empty fields and
+// timestamps render as RFC 3339 UTC with nanosecond precision.
+func EncodeCSV(w io.Writer, columns []Column, rows iter.Seq[[]any]) error {
+	if len(columns) == 0 {
+		return fmt.Errorf("csv encoder: at least one column required")
+	}
+	if units := csvUnitsComment(columns); units != "" {
+		if _, err := io.WriteString(w, units); err != nil {
+			return err
+		}
+	}
+	writer := csv.NewWriter(w)
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Name
+	}
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+	index := 0
+	for values := range rows {
+		if len(values) != len(columns) {
+			return fmt.Errorf("csv encoder: row %d has %d values, expected %d", index, len(values), len(columns))
+		}
+		record := make([]string, len(columns))
+		for i, value := range values {
+			record[i] = formatCell(value)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		index++
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func csvUnitsComment(columns []Column) string {
+	var pairs []string
+	for _, column := range columns {
+		if unit := strings.TrimSpace(column.Unit); unit != "" {
+			pairs = append(pairs, column.Name+"="+unit)
+		}
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "# units: " + strings.Join(pairs, ",") + "\n"
+}
+
+// cellValue dereferences pointer cells so typed nil pointers are treated as
+// nulls by both encoders.
+func cellValue(value any) any {
+	if value == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	return rv.Interface()
+}
+
+func formatCell(value any) string {
+	switch v := cellValue(value).(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return formatTimestamp(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case json.Number:
+		return v.String()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
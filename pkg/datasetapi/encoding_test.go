@@ -0,0 +1,114 @@
+package datasetapi
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeCSVFraming(t *testing.T) {
+	columns := []Column{
+		{Name: "organism_id", Type: "string"},
+		{Name: "weight", Type: "number", Unit: "g"},
+		{Name: "recorded_at", Type: "timestamp", Unit: "iso8601"},
+		{Name: "notes", Type: "string"},
+	}
+	recorded := time.Date(2024, 3, 1, 9, 30, 0, 500, time.FixedZone("CET", 3600))
+	var missing *string
+	rows := slices.Values([][]any{
+		{"org-1", 12.5, recorded, "needs, quoting"},
+		{"org-2", nil, nil, missing},
+	})
+
+	var buf bytes.Buffer
+	if err := EncodeCSV(&buf, columns, rows); err != nil {
+		t.Fatalf("EncodeCSV: %v", err)
+	}
+	want := strings.Join([]string{
+		"# units: weight=g,recorded_at=iso8601",
+		"organism_id,weight,recorded_at,notes",
+		`org-1,12.5,2024-03-01T08:30:00.0000005Z,"needs, quoting"`,
+		"org-2,,,",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected csv output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestEncodeCSVOmitsUnitsCommentAndRejectsRaggedRows(t *testing.T) {
+	columns := []Column{{Name: "value", Type: "string"}}
+	var buf bytes.Buffer
+	if err := EncodeCSV(&buf, columns, slices.Values([][]any{{"a"}})); err != nil {
+		t.Fatalf("EncodeCSV: %v", err)
+	}
+	if got := buf.String(); got != "value\na\n" {
+		t.Fatalf("unexpected csv output %q", got)
+	}
+	if err := EncodeCSV(&buf, columns, slices.Values([][]any{{"a", "b"}})); err == nil {
+		t.Fatalf("expected ragged row error")
+	}
+	if err := EncodeCSV(&buf, nil, slices.Values([][]any{})); err == nil {
+		t.Fatalf("expected missing columns error")
+	}
+}
+
+func TestEncoderForSelectsByFormat(t *testing.T) {
+	provider := GetFormatProvider()
+	for _, format := range []Format{provider.CSV(), provider.Parquet()} {
+		encoder, ok := EncoderFor(format)
+		if !ok {
+			t.Fatalf("expected encoder for %s", format)
+		}
+		if encoder.Format() != format {
+			t.Fatalf("expected encoder format %s, got %s", format, encoder.Format())
+		}
+		if encoder.ContentType() == "" {
+			t.Fatalf("expected content type for %s", format)
+		}
+	}
+	if _, ok := EncoderFor(provider.PNG()); ok {
+		t.Fatalf("expected no tabular encoder for png")
+	}
+}
+
+func TestRowValuesProjectsColumns(t *testing.T) {
+	columns := []Column{{Name: "b"}, {Name: "a"}}
+	rows := []Row{{"a": 1, "b": 2}, {"a": 3}}
+	var got [][]any
+	for values := range RowValues(columns, rows) {
+		got = append(got, values)
+	}
+	if len(got) != 2 || got[0][0] != 2 || got[0][1] != 1 || got[1][0] != nil || got[1][1] != 3 {
+		t.Fatalf("unexpected projection %v", got)
+	}
+	for range RowValues(columns, rows) {
+		break
+	}
+}
+
+func TestOrderRowsIsIndependentOfRunnerOrder(t *testing.T) {
+	columns := []Column{{Name: "facility", Type: "string"}, {Name: "count", Type: "integer"}}
+	rows := []Row{
+		{"facility": "b", "count": 1},
+		{"facility": "a", "count": 2},
+		{"facility": "a", "count": 1},
+	}
+	reversed := slices.Clone(rows)
+	slices.Reverse(reversed)
+
+	first, second := OrderRows(columns, rows), OrderRows(columns, reversed)
+	for i := range first {
+		if RowKey(columns, first[i]) != RowKey(columns, second[i]) {
+			t.Fatalf("expected identical ordering, got %v and %v", first, second)
+		}
+	}
+	if first[0]["facility"] != "a" || first[0]["count"] != 1 || first[2]["facility"] != "b" {
+		t.Fatalf("unexpected ordering %v", first)
+	}
+	if rows[0]["facility"] != "b" {
+		t.Fatalf("expected input rows to be left untouched")
+	}
+}
@@ -96,7 +96,12 @@ func (h *HostTemplate) Bind(env Environment) error {
 }
 
 // Run executes the bound template after validating parameters. The template
-// must be bound via Bind before calling Run.
+// must be bound via Bind before calling Run. The result schema always lists the
+// declared columns in declared order, and runs that return undeclared columns
+// fail with ErrSchemaMismatch. When scope carries an RBAC scope the result rows
+// are narrowed to the granted facilities and projects. When ctx carries a
+// resume cursor (see WithResumeAfter) the runner is asked to seek past it, and
+// the result holds only later rows and is marked with ResumedAfterMetadataKey.
 func (h HostTemplate) Run(ctx context.Context, params map[string]any, scope Scope, format Format) (RunResult, []ParameterError, error) {
 	if h.runtime == nil {
 		return RunResult{}, nil, errors.New("datasetapi: template not bound")
@@ -105,24 +110,121 @@ func (h HostTemplate) Run(ctx context.Context, params map[string]any, scope Scop
 	if len(errs) > 0 {
 		return RunResult{}, errs, nil
 	}
+	after, _ := ResumeAfterFromContext(ctx)
 	result, err := h.runtime(ctx, RunRequest{
 		Template:   h.Descriptor(),
 		Parameters: cleaned,
 		Scope:      cloneScope(scope),
+		After:      after,
 	})
 	if err != nil {
 		return RunResult{}, nil, err
 	}
-	if len(result.Schema) == 0 {
-		result.Schema = cloneColumns(h.tpl.Columns)
+	seeked := after != "" && result.Metadata[ResumedAfterMetadataKey] == after
+	result, err = conformResult(h.tpl, result)
+	if err != nil {
+		return RunResult{}, nil, err
+	}
+	if after != "" {
+		if !seeked {
+			result.Rows = rowsAfter(result.Schema, result.Rows, after)
+		}
+		result.Metadata[ResumedAfterMetadataKey] = after
+	} else {
+		delete(result.Metadata, ResumedAfterMetadataKey)
+	}
+	if scope.RBAC != nil {
+		rows, err := applyRBACScope(h.tpl, *scope.RBAC, result.Rows)
+		if err != nil {
+			return RunResult{}, nil, err
+		}
+		result.Rows = rows
 	}
 	result.GeneratedAt = result.GeneratedAt.UTC()
 	result.Format = format
 	return result, nil, nil
 }
 
-// Ensure HostTemplate satisfies TemplateRuntime.
-var _ TemplateRuntime = (*HostTemplate)(nil)
+// StreamRows executes the bound template like Run but hands rows to emit one
+// at a time. Rows the runner streams through RunRequest.Emit are checked
+// against the declared columns and the RBAC scope as they arrive; rows it
+// returns in RunResult.Rows are emitted afterwards the same way. The first
+// error from emit stops the run and is returned.
+func (h HostTemplate) StreamRows(ctx context.Context, params map[string]any, scope Scope, emit RowSink) ([]ParameterError, error) {
+	if h.runtime == nil {
+		return nil, errors.New("datasetapi: template not bound")
+	}
+	if emit == nil {
+		return nil, errors.New("datasetapi: row sink required")
+	}
+	cleaned, errs := validateParameters(h.tpl.Parameters, params)
+	if len(errs) > 0 {
+		return errs, nil
+	}
+	visible := func(Row) bool { return true }
+	if scope.RBAC != nil {
+		filter, err := rbacFilter(h.tpl, *scope.RBAC)
+		if err != nil {
+			return nil, err
+		}
+		visible = filter
+	}
+	declared := make(map[string]struct{}, len(h.tpl.Columns))
+	for _, column := range h.tpl.Columns {
+		declared[column.Name] = struct{}{}
+	}
+	index := 0
+	sink := func(row Row) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := conformRow(h.tpl, declared, index, row); err != nil {
+			return err
+		}
+		index++
+		if !visible(row) {
+			return nil
+		}
+		return emit(row)
+	}
+	result, err := h.runtime(ctx, RunRequest{
+		Template:   h.Descriptor(),
+		Parameters: cleaned,
+		Scope:      cloneScope(scope),
+		Emit:       sink,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, column := range result.Schema {
+		if _, ok := declared[column.Name]; !ok {
+			return nil, fmt.Errorf("%w: %s@%s returned undeclared column %q", ErrSchemaMismatch, h.tpl.Key, h.tpl.Version, column.Name)
+		}
+	}
+	for _, row := range result.Rows {
+		if err := sink(row); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// RunWithEnvironment binds a fresh runner to env for a single run and executes
+// it like Run. The receiver's bound runner is left untouched, so callers can
+// pin one run to a dedicated environment, such as a point-in-time store.
+func (h HostTemplate) RunWithEnvironment(ctx context.Context, env Environment, params map[string]any, scope Scope, format Format) (RunResult, []ParameterError, error) {
+	pinned := h
+	if err := pinned.Bind(env); err != nil {
+		return RunResult{}, nil, err
+	}
+	return pinned.Run(ctx, params, scope, format)
+}
+
+// Ensure HostTemplate satisfies TemplateRuntime and RowStreamer.
+var (
+	_ TemplateRuntime = (*HostTemplate)(nil)
+	_ RowStreamer     = (*HostTemplate)(nil)
+)
 
 // SortTemplateDescriptors sorts the slice in-place using plugin/key/version for
 // deterministic ordering.
@@ -423,6 +525,7 @@ func cloneScope(scope Scope) Scope {
 	if len(scope.ProtocolIDs) > 0 {
 		cloned.ProtocolIDs = append([]string(nil), scope.ProtocolIDs...)
 	}
+	cloned.RBAC = cloneRBACScope(scope.RBAC)
 	return cloned
 }
 
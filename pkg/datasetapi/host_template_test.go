@@ -462,6 +462,122 @@ func TestHostTemplateRunRequiresBind(t *testing.T) {
 	}
 }
 
+func TestHostTemplateStreamRows(t *testing.T) {
+	var streamed []Row
+	tpl := Template{
+		Key:           "k",
+		Version:       "1",
+		Title:         "t",
+		Dialect:       GetDialectProvider().SQL(),
+		Query:         "select 1",
+		Columns:       []Column{{Name: "c", Type: "string"}},
+		OutputFormats: []Format{GetFormatProvider().JSON()},
+		Metadata:      Metadata{Annotations: map[string]string{RBACExemptAnnotation: "true"}},
+		Binder: func(Environment) (Runner, error) {
+			return func(_ context.Context, req RunRequest) (RunResult, error) {
+				if err := req.Emit(Row{"c": "streamed"}); err != nil {
+					return RunResult{}, err
+				}
+				return RunResult{Rows: []Row{{"c": "returned"}, {"extra": "x"}}}, nil
+			}, nil
+		},
+	}
+	host, err := NewHostTemplate("plugin", tpl)
+	if err != nil {
+		t.Fatalf("NewHostTemplate: %v", err)
+	}
+	emit := func(row Row) error {
+		streamed = append(streamed, row)
+		return nil
+	}
+	if _, err := host.StreamRows(context.Background(), nil, Scope{}, emit); err == nil {
+		t.Fatalf("expected stream to fail when not bound")
+	}
+	if err := host.Bind(Environment{}); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	if _, err := host.StreamRows(context.Background(), nil, Scope{}, nil); err == nil {
+		t.Fatalf("expected nil sink to be rejected")
+	}
+	_, err = host.StreamRows(context.Background(), nil, Scope{RBAC: &RBACScope{}}, emit)
+	if !errors.Is(err, ErrSchemaMismatch) {
+		t.Fatalf("expected undeclared column to fail the stream, got %v", err)
+	}
+	if len(streamed) != 2 || streamed[0]["c"] != "streamed" || streamed[1]["c"] != "returned" {
+		t.Fatalf("expected streamed then returned rows before the mismatch, got %+v", streamed)
+	}
+}
+
+func TestHostTemplateRunResumesAfterCursor(t *testing.T) {
+	var requests []string
+	seek := false
+	tpl := Template{
+		Key:           "k",
+		Version:       "1",
+		Title:         "t",
+		Dialect:       GetDialectProvider().SQL(),
+		Query:         "select 1",
+		Columns:       []Column{{Name: "c", Type: "string"}},
+		OutputFormats: []Format{GetFormatProvider().JSON()},
+		Metadata:      Metadata{Annotations: map[string]string{RBACExemptAnnotation: "true"}},
+		Binder: func(Environment) (Runner, error) {
+			return func(_ context.Context, req RunRequest) (RunResult, error) {
+				requests = append(requests, req.After)
+				if seek {
+					return RunResult{Rows: []Row{{"c": "c"}}, Metadata: map[string]any{ResumedAfterMetadataKey: req.After}}, nil
+				}
+				return RunResult{Rows: []Row{{"c": "c"}, {"c": "a"}, {"c": "b"}}}, nil
+			}, nil
+		},
+	}
+	host, err := NewHostTemplate("plugin", tpl)
+	if err != nil {
+		t.Fatalf("NewHostTemplate: %v", err)
+	}
+	if err := host.Bind(Environment{}); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	after := RowKey(tpl.Columns, Row{"c": "a"})
+	ctx := WithResumeAfter(context.Background(), after)
+	if got, ok := ResumeAfterFromContext(ctx); !ok || got != after {
+		t.Fatalf("expected cursor %q in context, got %q %v", after, got, ok)
+	}
+	if _, ok := ResumeAfterFromContext(WithResumeAfter(context.Background(), "")); ok {
+		t.Fatalf("expected an empty cursor to be ignored")
+	}
+
+	result, _, err := host.Run(ctx, nil, Scope{}, GetFormatProvider().JSON())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(result.Rows) != 2 || result.Rows[0]["c"] != "c" || result.Rows[1]["c"] != "b" {
+		t.Fatalf("expected the host to drop rows up to the cursor, got %+v", result.Rows)
+	}
+	if result.Metadata[ResumedAfterMetadataKey] != after {
+		t.Fatalf("expected resumed result to be marked, got %+v", result.Metadata)
+	}
+
+	seek = true
+	result, _, err = host.Run(ctx, nil, Scope{}, GetFormatProvider().JSON())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(result.Rows) != 1 || result.Metadata[ResumedAfterMetadataKey] != after {
+		t.Fatalf("expected seeking runner's rows to pass through, got %+v", result)
+	}
+
+	result, _, err = host.Run(context.Background(), nil, Scope{}, GetFormatProvider().JSON())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if _, marked := result.Metadata[ResumedAfterMetadataKey]; marked {
+		t.Fatalf("expected a run without a cursor to be unmarked, got %+v", result.Metadata)
+	}
+	if len(requests) != 3 || requests[0] != after || requests[1] != after || requests[2] != "" {
+		t.Fatalf("expected the cursor to reach the runner, got %q", requests)
+	}
+}
+
 func TestValidateTemplateDetailedErrors(t *testing.T) {
 	bad := Template{}
 	if err := validateTemplate(bad); err == nil {
@@ -0,0 +1,443 @@
+package datasetapi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"strings"
+	"time"
+)
+
+// The Parquet writer below emits a single row group with one uncompressed,
+// PLAIN-encoded data page per column. Every column is OPTIONAL so nil cells
+// are represented through definition levels rather than sentinel values.
+const (
+	parquetMagic           = "PAR1"
+	parquetCreatedBy       = "colonycore datasetapi"
+	parquetColumnsMetadata = "colonycore.dataset.columns"
+
+	parquetTypeBoolean   int32 = 0
+	parquetTypeInt64     int32 = 2
+	parquetTypeDouble    int32 = 5
+	parquetTypeByteArray int32 = 6
+
+	parquetConvertedUTF8            int32 = 0
+	parquetConvertedTimestampMicros int32 = 10
+
+	parquetRepetitionOptional int32 = 1
+	parquetEncodingPlain      int32 = 0
+	parquetEncodingRLE        int32 = 3
+	parquetCodecUncompressed  int32 = 0
+	parquetPageTypeData       int32 = 0
+)
+
+type parquetEncoder struct{}
+
+func (parquetEncoder) Format() Format { return GetFormatProvider().Parquet() }
+
+func (parquetEncoder) ContentType() string { return "application/vnd.apache.parquet" }
+
+func (parquetEncoder) Encode(w io.Writer, columns []Column, rows iter.Seq[[]any]) error {
+	return EncodeParquet(w, columns, rows)
+}
+
+// EncodeParquet writes rows as a Parquet file. Column types map onto physical
+// types as follows: integer to INT64, number to DOUBLE, boolean to BOOLEAN,
+// timestamp to INT64 annotated TIMESTAMP_MICROS (UTC), and everything else to
+// UTF8 BYTE_ARRAY. Column metadata including units is preserved as JSON in the
+// file key/value metadata under "colonycore.dataset.columns".
+func EncodeParquet(w io.Writer, columns []Column, rows iter.Seq[[]any]) error {
+	if len(columns) == 0 {
+		return fmt.Errorf("parquet encoder: at least one column required")
+	}
+	chunks := make([]*parquetColumnChunk, len(columns))
+	for i, column := range columns {
+		chunks[i] = &parquetColumnChunk{column: column, physical: parquetPhysicalType(column.Type)}
+	}
+	var rowCount int64
+	for values := range rows {
+		if len(values) != len(columns) {
+			return fmt.Errorf("parquet encoder: row %d has %d values, expected %d", rowCount, len(values), len(columns))
+		}
+		for i, value := range values {
+			if err := chunks[i].append(value); err != nil {
+				return fmt.Errorf("parquet encoder: row %d column %s: %w", rowCount, columns[i].Name, err)
+			}
+		}
+		rowCount++
+	}
+
+	out := &bytes.Buffer{}
+	out.WriteString(parquetMagic)
+	for _, chunk := range chunks {
+		chunk.flush(out, rowCount)
+	}
+	footer, err := parquetFileMetadata(columns, chunks, rowCount)
+	if err != nil {
+		return err
+	}
+	out.Write(footer)
+	_ = binary.Write(out, binary.LittleEndian, uint32(len(footer)))
+	out.WriteString(parquetMagic)
+	_, err = w.Write(out.Bytes())
+	return err
+}
+
+type parquetPhysical struct {
+	kind      int32
+	converted *int32
+}
+
+func parquetPhysicalType(columnType string) parquetPhysical {
+	switch strings.ToLower(strings.TrimSpace(columnType)) {
+	case "integer", "int", "bigint":
+		return parquetPhysical{kind: parquetTypeInt64}
+	case "number", "float", "double", "decimal":
+		return parquetPhysical{kind: parquetTypeDouble}
+	case "boolean", "bool":
+		return parquetPhysical{kind: parquetTypeBoolean}
+	case "timestamp", "datetime", "date-time":
+		converted := parquetConvertedTimestampMicros
+		return parquetPhysical{kind: parquetTypeInt64, converted: &converted}
+	default:
+		converted := parquetConvertedUTF8
+		return parquetPhysical{kind: parquetTypeByteArray, converted: &converted}
+	}
+}
+
+type parquetColumnChunk struct {
+	column   Column
+	physical parquetPhysical
+	defined  []bool
+	values   bytes.Buffer
+	bits     []bool
+
+	pageOffset int64
+	totalSize  int64
+}
+
+func (c *parquetColumnChunk) append(value any) error {
+	value = cellValue(value)
+	if value == nil {
+		c.defined = append(c.defined, false)
+		return nil
+	}
+	switch {
+	case c.physical.kind == parquetTypeBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("expected boolean, got %T", value)
+		}
+		c.bits = append(c.bits, b)
+	case c.physical.kind == parquetTypeDouble:
+		f, err := parquetFloat(value)
+		if err != nil {
+			return err
+		}
+		_ = binary.Write(&c.values, binary.LittleEndian, math.Float64bits(f))
+	case c.physical.converted != nil && *c.physical.converted == parquetConvertedTimestampMicros:
+		ts, err := parquetTimestamp(value)
+		if err != nil {
+			return err
+		}
+		_ = binary.Write(&c.values, binary.LittleEndian, ts.UnixMicro())
+	case c.physical.kind == parquetTypeInt64:
+		n, err := parquetInt(value)
+		if err != nil {
+			return err
+		}
+		_ = binary.Write(&c.values, binary.LittleEndian, n)
+	default:
+		s := formatCell(value)
+		_ = binary.Write(&c.values, binary.LittleEndian, uint32(len(s)))
+		c.values.WriteString(s)
+	}
+	c.defined = append(c.defined, true)
+	return nil
+}
+
+// flush writes the column's single data page to out and records its offsets.
+func (c *parquetColumnChunk) flush(out *bytes.Buffer, rowCount int64) {
+	levels := encodeDefinitionLevels(c.defined)
+	page := &bytes.Buffer{}
+	_ = binary.Write(page, binary.LittleEndian, uint32(len(levels)))
+	page.Write(levels)
+	if c.physical.kind == parquetTypeBoolean {
+		page.Write(packBits(c.bits))
+	} else {
+		page.Write(c.values.Bytes())
+	}
+
+	header := &thriftWriter{}
+	header.i32Field(1, parquetPageTypeData)
+	header.i32Field(2, int32(page.Len()))
+	header.i32Field(3, int32(page.Len()))
+	header.structField(5)
+	header.i32Field(1, int32(rowCount))
+	header.i32Field(2, parquetEncodingPlain)
+	header.i32Field(3, parquetEncodingRLE)
+	header.i32Field(4, parquetEncodingRLE)
+	header.endStruct()
+	header.endStruct()
+
+	c.pageOffset = int64(out.Len())
+	out.Write(header.buf.Bytes())
+	out.Write(page.Bytes())
+	c.totalSize = int64(out.Len()) - c.pageOffset
+}
+
+func parquetFileMetadata(columns []Column, chunks []*parquetColumnChunk, rowCount int64) ([]byte, error) {
+	columnsJSON, err := json.Marshal(columns)
+	if err != nil {
+		return nil, fmt.Errorf("parquet encoder: marshal column metadata: %w", err)
+	}
+	var totalSize int64
+	for _, chunk := range chunks {
+		totalSize += chunk.totalSize
+	}
+
+	meta := &thriftWriter{}
+	meta.i32Field(1, 1)
+	meta.listField(2, thriftTypeStruct, len(columns)+1)
+	meta.beginElement()
+	meta.stringField(4, "schema")
+	meta.i32Field(5, int32(len(columns)))
+	meta.endStruct()
+	for _, chunk := range chunks {
+		meta.beginElement()
+		meta.i32Field(1, chunk.physical.kind)
+		meta.i32Field(3, parquetRepetitionOptional)
+		meta.stringField(4, chunk.column.Name)
+		if chunk.physical.converted != nil {
+			meta.i32Field(6, *chunk.physical.converted)
+		}
+		meta.endStruct()
+	}
+	meta.i64Field(3, rowCount)
+	meta.listField(4, thriftTypeStruct, 1)
+	meta.beginElement()
+	meta.listField(1, thriftTypeStruct, len(chunks))
+	for _, chunk := range chunks {
+		meta.beginElement()
+		meta.i64Field(2, chunk.pageOffset)
+		meta.structField(3)
+		meta.i32Field(1, chunk.physical.kind)
+		meta.listField(2, thriftTypeI32, 2)
+		meta.writeZigzag(int64(parquetEncodingPlain))
+		meta.writeZigzag(int64(parquetEncodingRLE))
+		meta.listField(3, thriftTypeBinary, 1)
+		meta.writeBinary(chunk.column.Name)
+		meta.i32Field(4, parquetCodecUncompressed)
+		meta.i64Field(5, rowCount)
+		meta.i64Field(6, chunk.totalSize)
+		meta.i64Field(7, chunk.totalSize)
+		meta.i64Field(9, chunk.pageOffset)
+		meta.endStruct()
+		meta.endStruct()
+	}
+	meta.i64Field(2, totalSize)
+	meta.i64Field(3, rowCount)
+	meta.endStruct()
+	meta.listField(5, thriftTypeStruct, 1)
+	meta.beginElement()
+	meta.stringField(1, parquetColumnsMetadata)
+	meta.stringField(2, string(columnsJSON))
+	meta.endStruct()
+	meta.stringField(6, parquetCreatedBy)
+	meta.endStruct()
+	return meta.buf.Bytes(), nil
+}
+
+func parquetInt(value any) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint:
+		return parquetUint(uint64(v))
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return parquetUint(v)
+	case float32:
+		return parquetIntegral(float64(v))
+	case float64:
+		return parquetIntegral(v)
+	case json.Number:
+		return v.Int64()
+	default:
+		return 0, fmt.Errorf("expected integer, got %T", value)
+	}
+}
+
+func parquetUint(v uint64) (int64, error) {
+	if v > math.MaxInt64 {
+		return 0, fmt.Errorf("integer %d overflows int64", v)
+	}
+	return int64(v), nil
+}
+
+func parquetIntegral(v float64) (int64, error) {
+	if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+		return 0, fmt.Errorf("expected integer, got %g", v)
+	}
+	return int64(v), nil
+}
+
+func parquetFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	default:
+		n, err := parquetInt(value)
+		if err != nil {
+			return 0, fmt.Errorf("expected number, got %T", value)
+		}
+		return float64(n), nil
+	}
+}
+
+func parquetTimestamp(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		ts, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("expected RFC 3339 timestamp: %w", err)
+		}
+		return ts, nil
+	default:
+		return time.Time{}, fmt.Errorf("expected timestamp, got %T", value)
+	}
+}
+
+// encodeDefinitionLevels renders levels using the RLE half of the
+// RLE/bit-packing hybrid with a bit width of one.
+func encodeDefinitionLevels(defined []bool) []byte {
+	var out []byte
+	for i := 0; i < len(defined); {
+		j := i
+		for j < len(defined) && defined[j] == defined[i] {
+			j++
+		}
+		out = binary.AppendUvarint(out, uint64(j-i)<<1)
+		if defined[i] {
+			out = append(out, 1)
+		} else {
+			out = append(out, 0)
+		}
+		i = j
+	}
+	return out
+}
+
+func packBits(bits []bool) []byte {
+	out := make([]byte, (len(bits)+7)/8)
+	for i, bit := range bits {
+		if bit {
+			out[i/8] |= 1 << (i % 8)
+		}
+	}
+	return out
+}
+
+// Thrift compact protocol type identifiers used by the Parquet footer.
+const (
+	thriftTypeI32    byte = 5
+	thriftTypeI64    byte = 6
+	thriftTypeBinary byte = 8
+	thriftTypeList   byte = 9
+	thriftTypeStruct byte = 12
+)
+
+// thriftWriter emits the subset of the Thrift compact protocol needed to
+// describe Parquet page headers and file metadata.
+type thriftWriter struct {
+	buf    bytes.Buffer
+	lastID int16
+	stack  []int16
+}
+
+func (t *thriftWriter) fieldHeader(id int16, typ byte) {
+	delta := id - t.lastID
+	if delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.writeZigzag(int64(id))
+	}
+	t.lastID = id
+}
+
+func (t *thriftWriter) writeZigzag(v int64) {
+	t.buf.Write(binary.AppendUvarint(nil, uint64((v<<1)^(v>>63))))
+}
+
+func (t *thriftWriter) writeBinary(s string) {
+	t.buf.Write(binary.AppendUvarint(nil, uint64(len(s))))
+	t.buf.WriteString(s)
+}
+
+func (t *thriftWriter) i32Field(id int16, v int32) {
+	t.fieldHeader(id, thriftTypeI32)
+	t.writeZigzag(int64(v))
+}
+
+func (t *thriftWriter) i64Field(id int16, v int64) {
+	t.fieldHeader(id, thriftTypeI64)
+	t.writeZigzag(v)
+}
+
+func (t *thriftWriter) stringField(id int16, v string) {
+	t.fieldHeader(id, thriftTypeBinary)
+	t.writeBinary(v)
+}
+
+func (t *thriftWriter) listField(id int16, elem byte, size int) {
+	t.fieldHeader(id, thriftTypeList)
+	if size < 15 {
+		t.buf.WriteByte(byte(size)<<4 | elem)
+		return
+	}
+	t.buf.WriteByte(0xF0 | elem)
+	t.buf.Write(binary.AppendUvarint(nil, uint64(size)))
+}
+
+func (t *thriftWriter) structField(id int16) {
+	t.fieldHeader(id, thriftTypeStruct)
+	t.beginElement()
+}
+
+// beginElement opens a nested struct scope, used directly for list elements.
+func (t *thriftWriter) beginElement() {
+	t.stack = append(t.stack, t.lastID)
+	t.lastID = 0
+}
+
+func (t *thriftWriter) endStruct() {
+	t.buf.WriteByte(0)
+	if n := len(t.stack); n > 0 {
+		t.lastID = t.stack[n-1]
+		t.stack = t.stack[:n-1]
+	}
+}
@@ -0,0 +1,230 @@
+package datasetapi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEncodeParquetRoundTripSchema(t *testing.T) {
+	columns := []Column{
+		{Name: "organism_id", Type: "string", Description: "Organism identifier."},
+		{Name: "clutch_size", Type: "integer"},
+		{Name: "weight", Type: "number", Unit: "g"},
+		{Name: "alive", Type: "boolean"},
+		{Name: "recorded_at", Type: "timestamp", Unit: "iso8601"},
+	}
+	recorded := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	rows := slices.Values([][]any{
+		{"org-1", 4, 12.5, true, recorded},
+		{"org-2", nil, nil, false, "2024-03-02T10:00:00Z"},
+		{nil, int64(7), 3, nil, nil},
+	})
+
+	var buf bytes.Buffer
+	if err := EncodeParquet(&buf, columns, rows); err != nil {
+		t.Fatalf("EncodeParquet: %v", err)
+	}
+	file := buf.Bytes()
+	if string(file[:4]) != parquetMagic || string(file[len(file)-4:]) != parquetMagic {
+		t.Fatalf("missing parquet magic framing")
+	}
+	meta := readParquetFooter(t, file)
+
+	if got := meta[3]; got != int64(3) {
+		t.Fatalf("expected 3 rows, got %v", got)
+	}
+	schema := meta[2].([]any)
+	if len(schema) != len(columns)+1 {
+		t.Fatalf("expected root plus %d schema elements, got %d", len(columns), len(schema))
+	}
+	root := schema[0].(map[int16]any)
+	if string(root[4].([]byte)) != "schema" || root[5] != int64(len(columns)) {
+		t.Fatalf("unexpected root schema element %v", root)
+	}
+	wantTypes := []struct {
+		physical  int64
+		converted any
+	}{
+		{int64(parquetTypeByteArray), int64(parquetConvertedUTF8)},
+		{int64(parquetTypeInt64), nil},
+		{int64(parquetTypeDouble), nil},
+		{int64(parquetTypeBoolean), nil},
+		{int64(parquetTypeInt64), int64(parquetConvertedTimestampMicros)},
+	}
+	for i, want := range wantTypes {
+		element := schema[i+1].(map[int16]any)
+		if name := string(element[4].([]byte)); name != columns[i].Name {
+			t.Fatalf("schema element %d: expected name %s, got %s", i, columns[i].Name, name)
+		}
+		if element[1] != want.physical || element[6] != want.converted {
+			t.Fatalf("schema element %s: unexpected types %v", columns[i].Name, element)
+		}
+		if element[3] != int64(parquetRepetitionOptional) {
+			t.Fatalf("schema element %s: expected optional repetition", columns[i].Name)
+		}
+	}
+
+	kv := meta[5].([]any)[0].(map[int16]any)
+	if string(kv[1].([]byte)) != parquetColumnsMetadata {
+		t.Fatalf("unexpected key/value metadata key %s", kv[1])
+	}
+	var decoded []Column
+	if err := json.Unmarshal(kv[2].([]byte), &decoded); err != nil {
+		t.Fatalf("decode column metadata: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, columns) {
+		t.Fatalf("column metadata mismatch: %+v", decoded)
+	}
+
+	rowGroup := meta[4].([]any)[0].(map[int16]any)
+	chunks := rowGroup[1].([]any)
+	weight := chunks[2].(map[int16]any)[3].(map[int16]any)
+	values := readParquetPage(t, file, weight[9].(int64), 2, 8)
+	if math.Float64frombits(binary.LittleEndian.Uint64(values)) != 12.5 ||
+		math.Float64frombits(binary.LittleEndian.Uint64(values[8:])) != 3 {
+		t.Fatalf("unexpected weight values %v", values)
+	}
+	stamps := chunks[4].(map[int16]any)[3].(map[int16]any)
+	values = readParquetPage(t, file, stamps[9].(int64), 2, 8)
+	if got := int64(binary.LittleEndian.Uint64(values)); got != recorded.UnixMicro() {
+		t.Fatalf("unexpected timestamp %d", got)
+	}
+}
+
+func TestEncodeParquetRejectsMismatchedValues(t *testing.T) {
+	columns := []Column{{Name: "count", Type: "integer"}}
+	var buf bytes.Buffer
+	if err := EncodeParquet(&buf, columns, slices.Values([][]any{{"many"}})); err == nil {
+		t.Fatalf("expected type mismatch error")
+	}
+	if err := EncodeParquet(&buf, columns, slices.Values([][]any{{1.5}})); err == nil {
+		t.Fatalf("expected fractional integer error")
+	}
+	if err := EncodeParquet(&buf, columns, slices.Values([][]any{{1, 2}})); err == nil {
+		t.Fatalf("expected ragged row error")
+	}
+	if err := EncodeParquet(&buf, []Column{{Name: "at", Type: "timestamp"}}, slices.Values([][]any{{"yesterday"}})); err == nil {
+		t.Fatalf("expected timestamp parse error")
+	}
+	if err := EncodeParquet(&buf, nil, slices.Values([][]any{})); err == nil {
+		t.Fatalf("expected missing columns error")
+	}
+}
+
+// readParquetFooter decodes the file metadata into field-id keyed maps.
+func readParquetFooter(t *testing.T, file []byte) map[int16]any {
+	t.Helper()
+	size := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	reader := &thriftReader{data: file[len(file)-8-size : len(file)-8]}
+	meta, err := reader.readStruct()
+	if err != nil {
+		t.Fatalf("decode footer: %v", err)
+	}
+	return meta
+}
+
+// readParquetPage returns the PLAIN value section of the data page at offset,
+// asserting it carries present fixed-width values.
+func readParquetPage(t *testing.T, file []byte, offset int64, present, width int) []byte {
+	t.Helper()
+	reader := &thriftReader{data: file[offset:]}
+	header, err := reader.readStruct()
+	if err != nil {
+		t.Fatalf("decode page header: %v", err)
+	}
+	page := file[offset+int64(reader.pos):]
+	page = page[:header[2].(int64)]
+	levels := int(binary.LittleEndian.Uint32(page))
+	values := page[4+levels:]
+	if len(values) != present*width {
+		t.Fatalf("expected %d values, got %d bytes", present, len(values))
+	}
+	return values
+}
+
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) readStruct() (map[int16]any, error) {
+	fields := map[int16]any{}
+	var last int16
+	for {
+		if r.pos >= len(r.data) {
+			return nil, fmt.Errorf("unexpected end of struct")
+		}
+		b := r.data[r.pos]
+		r.pos++
+		if b == 0 {
+			return fields, nil
+		}
+		id := last + int16(b>>4)
+		if b>>4 == 0 {
+			id = int16(r.readZigzag())
+		}
+		value, err := r.readValue(b & 0x0F)
+		if err != nil {
+			return nil, err
+		}
+		fields[id] = value
+		last = id
+	}
+}
+
+func (r *thriftReader) readValue(typ byte) (any, error) {
+	switch typ {
+	case 1, 2:
+		return typ == 1, nil
+	case 3:
+		r.pos++
+		return int64(int8(r.data[r.pos-1])), nil
+	case 4, 5, 6:
+		return r.readZigzag(), nil
+	case 7:
+		r.pos += 8
+		return math.Float64frombits(binary.LittleEndian.Uint64(r.data[r.pos-8:])), nil
+	case 8:
+		n := int(r.readUvarint())
+		r.pos += n
+		return r.data[r.pos-n : r.pos], nil
+	case 9:
+		header := r.data[r.pos]
+		r.pos++
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.readUvarint())
+		}
+		items := make([]any, 0, size)
+		for i := 0; i < size; i++ {
+			item, err := r.readValue(header & 0x0F)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case 12:
+		return r.readStruct()
+	default:
+		return nil, fmt.Errorf("unsupported thrift type %d", typ)
+	}
+}
+
+func (r *thriftReader) readUvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) readZigzag() int64 {
+	v := r.readUvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
@@ -0,0 +1,129 @@
+package datasetapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// RBACExemptAnnotation marks a template whose output is not tied to a
+	// facility or project and may run unfiltered under an RBAC scope. Set the
+	// metadata annotation to "true" to opt out of row filtering.
+	RBACExemptAnnotation = "rbac.exempt"
+	// RBACFacilityColumn names the column used to filter rows by facility.
+	RBACFacilityColumn = "facility_id"
+	// RBACProjectColumn names the column used to filter rows by project.
+	RBACProjectColumn = "project_id"
+	// RBACWildcard grants access to every facility or project in a dimension.
+	RBACWildcard = "*"
+)
+
+// ErrTemplateNotScopable is returned when an RBAC scope is applied to a
+// template that exposes neither scoping column and is not marked exempt.
+var ErrTemplateNotScopable = errors.New("datasetapi: template cannot be rbac scoped")
+
+// RBACScope captures the facilities and projects an actor may read, resolved
+// from the actor's grants when a dataset run or export is submitted. Dataset
+// execution intersects template output with the scope: a row is kept only when
+// every scoping column the template declares holds a granted ID.
+type RBACScope struct {
+	Actor       string   `json:"actor"`
+	FacilityIDs []string `json:"facility_ids,omitempty"`
+	ProjectIDs  []string `json:"project_ids,omitempty"`
+}
+
+// RBACExempt reports whether the template opts out of RBAC row filtering.
+func RBACExempt(metadata Metadata) bool {
+	return strings.EqualFold(strings.TrimSpace(metadata.Annotations[RBACExemptAnnotation]), "true")
+}
+
+// applyRBACScope filters rows to those visible under scope. Exempt templates
+// pass through untouched; templates without a scoping column are rejected.
+func applyRBACScope(template Template, scope RBACScope, rows []Row) ([]Row, error) {
+	visible, err := rbacFilter(template, scope)
+	if err != nil {
+		return nil, err
+	}
+	filtered := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		if visible(row) {
+			filtered = append(filtered, row)
+		}
+	}
+	return filtered, nil
+}
+
+// rbacFilter returns a predicate reporting whether a row is visible under
+// scope, so rows can be filtered as they stream. It rejects templates that
+// are neither exempt nor expose a scoping column.
+func rbacFilter(template Template, scope RBACScope) (func(Row) bool, error) {
+	if RBACExempt(template.Metadata) {
+		return func(Row) bool { return true }, nil
+	}
+	type dimension struct {
+		column  string
+		granted map[string]struct{}
+		all     bool
+	}
+	var dimensions []dimension
+	for _, candidate := range []struct {
+		column string
+		ids    []string
+	}{
+		{RBACFacilityColumn, scope.FacilityIDs},
+		{RBACProjectColumn, scope.ProjectIDs},
+	} {
+		column, ok := findColumn(template.Columns, candidate.column)
+		if !ok {
+			continue
+		}
+		d := dimension{column: column, granted: make(map[string]struct{}, len(candidate.ids))}
+		for _, id := range candidate.ids {
+			if id == RBACWildcard {
+				d.all = true
+			}
+			d.granted[id] = struct{}{}
+		}
+		dimensions = append(dimensions, d)
+	}
+	if len(dimensions) == 0 {
+		return nil, fmt.Errorf("%w: %s@%s declares neither %s nor %s and is not marked %s", ErrTemplateNotScopable, template.Key, template.Version, RBACFacilityColumn, RBACProjectColumn, RBACExemptAnnotation)
+	}
+
+	return func(row Row) bool {
+		for _, d := range dimensions {
+			if d.all {
+				continue
+			}
+			id := formatCell(row[d.column])
+			if _, ok := d.granted[id]; !ok || id == "" {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func findColumn(columns []Column, name string) (string, bool) {
+	for _, column := range columns {
+		if strings.EqualFold(column.Name, name) {
+			return column.Name, true
+		}
+	}
+	return "", false
+}
+
+func cloneRBACScope(scope *RBACScope) *RBACScope {
+	if scope == nil {
+		return nil
+	}
+	cloned := &RBACScope{Actor: scope.Actor}
+	if len(scope.FacilityIDs) > 0 {
+		cloned.FacilityIDs = append([]string(nil), scope.FacilityIDs...)
+	}
+	if len(scope.ProjectIDs) > 0 {
+		cloned.ProjectIDs = append([]string(nil), scope.ProjectIDs...)
+	}
+	return cloned
+}
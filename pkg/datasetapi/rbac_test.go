@@ -0,0 +1,138 @@
+package datasetapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func organismRBACTemplate(t *testing.T, columns []Column, annotations map[string]string) HostTemplate {
+	t.Helper()
+	formatProvider := GetFormatProvider()
+	tpl := Template{
+		Key:           "organisms",
+		Version:       "1.0.0",
+		Title:         "Organisms",
+		Dialect:       GetDialectProvider().SQL(),
+		Query:         "SELECT id, facility_id FROM organisms",
+		Columns:       columns,
+		Metadata:      Metadata{Annotations: annotations},
+		OutputFormats: []Format{formatProvider.JSON()},
+		Binder: func(Environment) (Runner, error) {
+			return func(context.Context, RunRequest) (RunResult, error) {
+				rows := []Row{
+					{"id": "frog-1", "facility_id": "facility-a", "project_id": "project-a"},
+					{"id": "frog-2", "facility_id": "facility-b", "project_id": "project-a"},
+					{"id": "frog-3", "facility_id": "facility-a", "project_id": "project-b"},
+				}
+				for _, row := range rows {
+					for name := range row {
+						if _, ok := findColumn(columns, name); !ok {
+							delete(row, name)
+						}
+					}
+				}
+				return RunResult{Rows: rows}, nil
+			}, nil
+		},
+	}
+	host, err := NewHostTemplate("frog", tpl)
+	if err != nil {
+		t.Fatalf("NewHostTemplate: %v", err)
+	}
+	if err := host.Bind(Environment{}); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	return host
+}
+
+func runRBAC(t *testing.T, host HostTemplate, rbac *RBACScope) (RunResult, error) {
+	t.Helper()
+	result, paramErrs, err := host.Run(context.Background(), nil, Scope{Requestor: "analyst", RBAC: rbac}, GetFormatProvider().JSON())
+	if len(paramErrs) != 0 {
+		t.Fatalf("unexpected parameter errors: %+v", paramErrs)
+	}
+	return result, err
+}
+
+func rowIDs(rows []Row) []string {
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row["id"].(string))
+	}
+	return ids
+}
+
+func TestHostTemplateRunAppliesRBACScope(t *testing.T) {
+	host := organismRBACTemplate(t, []Column{{Name: "id", Type: "string"}, {Name: "facility_id", Type: "string"}}, nil)
+
+	result, err := runRBAC(t, host, &RBACScope{Actor: "analyst", FacilityIDs: []string{"facility-a"}})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if ids := rowIDs(result.Rows); len(ids) != 2 || ids[0] != "frog-1" || ids[1] != "frog-3" {
+		t.Fatalf("expected only facility-a organisms, got %v", ids)
+	}
+
+	result, err = runRBAC(t, host, &RBACScope{Actor: "analyst"})
+	if err != nil {
+		t.Fatalf("Run without grants: %v", err)
+	}
+	if len(result.Rows) != 0 {
+		t.Fatalf("expected no rows without grants, got %v", rowIDs(result.Rows))
+	}
+
+	result, err = runRBAC(t, host, &RBACScope{Actor: "admin", FacilityIDs: []string{RBACWildcard}})
+	if err != nil {
+		t.Fatalf("Run with wildcard: %v", err)
+	}
+	if len(result.Rows) != 3 {
+		t.Fatalf("expected wildcard grant to see every row, got %v", rowIDs(result.Rows))
+	}
+
+	result, err = runRBAC(t, host, nil)
+	if err != nil {
+		t.Fatalf("Run without rbac scope: %v", err)
+	}
+	if len(result.Rows) != 3 {
+		t.Fatalf("expected unscoped run to be unfiltered, got %v", rowIDs(result.Rows))
+	}
+}
+
+func TestHostTemplateRunIntersectsFacilityAndProjectGrants(t *testing.T) {
+	host := organismRBACTemplate(t, []Column{{Name: "id", Type: "string"}, {Name: "facility_id", Type: "string"}, {Name: "project_id", Type: "string"}}, nil)
+
+	result, err := runRBAC(t, host, &RBACScope{Actor: "analyst", FacilityIDs: []string{"facility-a"}, ProjectIDs: []string{"project-a"}})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if ids := rowIDs(result.Rows); len(ids) != 1 || ids[0] != "frog-1" {
+		t.Fatalf("expected facility and project grants to intersect, got %v", ids)
+	}
+}
+
+func TestHostTemplateRunRBACExemptTemplateIsUnfiltered(t *testing.T) {
+	host := organismRBACTemplate(t, []Column{{Name: "id", Type: "string"}}, map[string]string{RBACExemptAnnotation: "TRUE"})
+	if !RBACExempt(host.Template().Metadata) {
+		t.Fatalf("expected template to be exempt")
+	}
+
+	result, err := runRBAC(t, host, &RBACScope{Actor: "analyst", FacilityIDs: []string{"facility-a"}})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(result.Rows) != 3 {
+		t.Fatalf("expected exempt template to run unfiltered, got %v", rowIDs(result.Rows))
+	}
+}
+
+func TestHostTemplateRunRejectsUnscopableTemplate(t *testing.T) {
+	host := organismRBACTemplate(t, []Column{{Name: "id", Type: "string"}}, nil)
+
+	if _, err := runRBAC(t, host, &RBACScope{Actor: "analyst", FacilityIDs: []string{"facility-a"}}); !errors.Is(err, ErrTemplateNotScopable) {
+		t.Fatalf("expected ErrTemplateNotScopable, got %v", err)
+	}
+	if _, err := runRBAC(t, host, nil); err != nil {
+		t.Fatalf("expected unscoped run to succeed, got %v", err)
+	}
+}
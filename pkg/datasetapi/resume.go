@@ -0,0 +1,41 @@
+package datasetapi
+
+import "context"
+
+// ResumedAfterMetadataKey is the RunResult metadata key set on results that
+// hold only the rows ordered after a resume cursor. Its value is the cursor.
+const ResumedAfterMetadataKey = "resumed_after"
+
+type resumeAfterKey struct{}
+
+// WithResumeAfter returns a context asking template runs to resume after the
+// row whose RowKey over the template's columns is key. Exports use it to
+// continue from their last checkpoint instead of re-reading rows already
+// spooled. An empty key leaves ctx unchanged.
+func WithResumeAfter(ctx context.Context, key string) context.Context {
+	if key == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, resumeAfterKey{}, key)
+}
+
+// ResumeAfterFromContext returns the cursor attached by WithResumeAfter.
+func ResumeAfterFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	key, ok := ctx.Value(resumeAfterKey{}).(string)
+	return key, ok && key != ""
+}
+
+// rowsAfter returns the rows whose RowKey over columns sorts after key, in
+// their original order.
+func rowsAfter(columns []Column, rows []Row, key string) []Row {
+	out := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		if RowKey(columns, row) > key {
+			out = append(out, row)
+		}
+	}
+	return out
+}
@@ -0,0 +1,69 @@
+package datasetapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// SchemaHashMetadataKey is the RunResult metadata key carrying the schema hash
+// of the template that produced the result.
+const SchemaHashMetadataKey = "schema_hash"
+
+// ErrSchemaMismatch is returned when a runner produces columns that the
+// template does not declare.
+var ErrSchemaMismatch = errors.New("datasetapi: result does not match declared schema")
+
+// SchemaHash returns a stable hex-encoded SHA-256 digest over the column
+// definitions. Columns are sorted by name before hashing so the digest only
+// changes when a column's name, type, or unit changes.
+func SchemaHash(columns []Column) string {
+	entries := make([][3]string, 0, len(columns))
+	for _, column := range columns {
+		entries = append(entries, [3]string{column.Name, column.Type, column.Unit})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i][0] < entries[j][0] })
+	// Marshalling a slice of string arrays cannot fail.
+	encoded, _ := json.Marshal(entries)
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// conformResult pins the result schema to the declared columns in declared
+// order and rejects any column or row value the template does not declare.
+func conformResult(template Template, result RunResult) (RunResult, error) {
+	declared := make(map[string]struct{}, len(template.Columns))
+	for _, column := range template.Columns {
+		declared[column.Name] = struct{}{}
+	}
+	for _, column := range result.Schema {
+		if _, ok := declared[column.Name]; !ok {
+			return RunResult{}, fmt.Errorf("%w: %s@%s returned undeclared column %q", ErrSchemaMismatch, template.Key, template.Version, column.Name)
+		}
+	}
+	for i, row := range result.Rows {
+		if err := conformRow(template, declared, i, row); err != nil {
+			return RunResult{}, err
+		}
+	}
+	result.Schema = cloneColumns(template.Columns)
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]any, 1)
+	}
+	result.Metadata[SchemaHashMetadataKey] = SchemaHash(template.Columns)
+	return result, nil
+}
+
+// conformRow rejects a row carrying a column outside declared. index is the
+// row's position in the run, used in the error message.
+func conformRow(template Template, declared map[string]struct{}, index int, row Row) error {
+	for name := range row {
+		if _, ok := declared[name]; !ok {
+			return fmt.Errorf("%w: %s@%s row %d has undeclared column %q", ErrSchemaMismatch, template.Key, template.Version, index, name)
+		}
+	}
+	return nil
+}
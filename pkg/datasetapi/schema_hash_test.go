@@ -0,0 +1,100 @@
+package datasetapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSchemaHashStableAcrossRunsAndOrdering(t *testing.T) {
+	columns := []Column{
+		{Name: "organism_id", Type: "string"},
+		{Name: "weight", Type: "number", Unit: "g"},
+		{Name: "recorded_at", Type: "timestamp"},
+	}
+	first := SchemaHash(columns)
+	if len(first) != 64 {
+		t.Fatalf("expected hex sha256 digest, got %q", first)
+	}
+	if again := SchemaHash(cloneColumns(columns)); again != first {
+		t.Fatalf("expected stable hash, got %q then %q", first, again)
+	}
+	reordered := []Column{columns[2], columns[0], columns[1]}
+	if SchemaHash(reordered) != first {
+		t.Fatalf("expected hash to ignore declaration order")
+	}
+	described := cloneColumns(columns)
+	described[0].Description = "changed description"
+	if SchemaHash(described) != first {
+		t.Fatalf("expected hash to ignore column descriptions")
+	}
+
+	unitChanged := cloneColumns(columns)
+	unitChanged[1].Unit = "kg"
+	if SchemaHash(unitChanged) == first {
+		t.Fatalf("expected hash to change when a column unit changes")
+	}
+	typeChanged := cloneColumns(columns)
+	typeChanged[1].Type = "integer"
+	if SchemaHash(typeChanged) == first {
+		t.Fatalf("expected hash to change when a column type changes")
+	}
+}
+
+func schemaHashHost(t *testing.T, runner Runner) HostTemplate {
+	t.Helper()
+	tpl := Template{
+		Key:           "weights",
+		Version:       "1.0.0",
+		Title:         "Weights",
+		Dialect:       GetDialectProvider().SQL(),
+		Query:         "SELECT organism_id, weight FROM observations",
+		Columns:       []Column{{Name: "organism_id", Type: "string"}, {Name: "weight", Type: "number", Unit: "g"}},
+		OutputFormats: []Format{GetFormatProvider().JSON()},
+		Binder:        func(Environment) (Runner, error) { return runner, nil },
+	}
+	host, err := NewHostTemplate("frog", tpl)
+	if err != nil {
+		t.Fatalf("NewHostTemplate: %v", err)
+	}
+	if err := host.Bind(Environment{}); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	return host
+}
+
+func TestHostTemplateRunEmitsDeclaredColumnOrder(t *testing.T) {
+	host := schemaHashHost(t, func(context.Context, RunRequest) (RunResult, error) {
+		return RunResult{
+			Schema: []Column{{Name: "weight", Type: "number"}, {Name: "organism_id", Type: "string"}},
+			Rows:   []Row{{"organism_id": "frog-1", "weight": 12.5}},
+		}, nil
+	})
+	result, _, err := host.Run(context.Background(), nil, Scope{}, GetFormatProvider().JSON())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(result.Schema) != 2 || result.Schema[0].Name != "organism_id" || result.Schema[1].Name != "weight" || result.Schema[1].Unit != "g" {
+		t.Fatalf("expected declared column order and definitions, got %+v", result.Schema)
+	}
+	if result.Metadata[SchemaHashMetadataKey] != SchemaHash(host.Template().Columns) {
+		t.Fatalf("expected schema hash metadata, got %+v", result.Metadata)
+	}
+}
+
+func TestHostTemplateRunRejectsUndeclaredColumns(t *testing.T) {
+	cases := map[string]RunResult{
+		"schema": {
+			Schema: []Column{{Name: "organism_id", Type: "string"}, {Name: "weight", Type: "number"}, {Name: "length", Type: "number"}},
+		},
+		"row": {
+			Rows: []Row{{"organism_id": "frog-1", "weight": 12.5, "length": 4.2}},
+		},
+	}
+	for name, returned := range cases {
+		host := schemaHashHost(t, func(context.Context, RunRequest) (RunResult, error) { return returned, nil })
+		if _, _, err := host.Run(context.Background(), nil, Scope{}, GetFormatProvider().JSON()); !errors.Is(err, ErrSchemaMismatch) {
+			t.Fatalf("%s: expected ErrSchemaMismatch, got %v", name, err)
+		}
+	}
+}
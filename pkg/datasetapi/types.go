@@ -87,12 +87,15 @@ func GetFormatProvider() FormatProvider {
 	return DefaultFormatProvider{}
 }
 
-// Scope defines requestor identity and authorization context.
+// Scope defines requestor identity and authorization context. When RBAC is
+// set, dataset execution filters template output to the granted facilities
+// and projects.
 type Scope struct {
-	Requestor   string   `json:"requestor"`
-	Roles       []string `json:"roles,omitempty"`
-	ProjectIDs  []string `json:"project_ids,omitempty"`
-	ProtocolIDs []string `json:"protocol_ids,omitempty"`
+	Requestor   string     `json:"requestor"`
+	Roles       []string   `json:"roles,omitempty"`
+	ProjectIDs  []string   `json:"project_ids,omitempty"`
+	ProtocolIDs []string   `json:"protocol_ids,omitempty"`
+	RBAC        *RBACScope `json:"rbac,omitempty"`
 }
 
 // Parameter declares a runtime-supplied template parameter.
@@ -107,6 +110,12 @@ type Parameter struct {
 	Default     json.RawMessage `json:"default,omitempty"`
 }
 
+// SourceEntityAnnotation names the entity-model entity, such as "HousingUnit",
+// whose properties a template's columns project. Hosts fill in the Unit of any
+// column named after a property that declares a measurement unit in the
+// schema, leaving units set explicitly by the template untouched.
+const SourceEntityAnnotation = "source.entity"
+
 // Column describes a column returned by a dataset query.
 type Column struct {
 	Name        string `json:"name"`
@@ -168,8 +177,20 @@ type RunRequest struct {
 	Template   TemplateDescriptor
 	Parameters map[string]any
 	Scope      Scope
+	// Emit, when set, accepts rows as the runner produces them. Runners that
+	// stream deliver rows through Emit instead of RunResult.Rows and stop when
+	// it returns an error; runners that ignore it still return their rows.
+	Emit RowSink
+	// After, when set, is a resume cursor (see WithResumeAfter). Runners that
+	// can seek return only rows whose RowKey over the template's columns sorts
+	// after it and set RunResult.Metadata[ResumedAfterMetadataKey] to After;
+	// the host drops earlier rows from runners that do not.
+	After string
 }
 
+// RowSink receives dataset rows one at a time.
+type RowSink func(Row) error
+
 // ParameterError captures validation failures reported during parameter coercion
 // and validation when invoking dataset templates.
 type ParameterError struct {
@@ -210,3 +231,10 @@ type TemplateRuntime interface {
 	ValidateParameters(params map[string]any) (map[string]any, []ParameterError)
 	Run(ctx context.Context, params map[string]any, scope Scope, format Format) (RunResult, []ParameterError, error)
 }
+
+// RowStreamer is implemented by template runtimes that can deliver rows to a
+// sink one at a time instead of materializing them in a RunResult. Rows are
+// conformed to the declared columns and narrowed to the scope like Run.
+type RowStreamer interface {
+	StreamRows(ctx context.Context, params map[string]any, scope Scope, emit RowSink) ([]ParameterError, error)
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrAttachmentBlobsUnavailable is returned when an attachment operation runs
+// against a store configured without an attachment blob store.
+var ErrAttachmentBlobsUnavailable = errors.New("attachment blob store not configured")
+
+// BlobInfo describes a stored blob referenced by an attachment.
+type BlobInfo struct {
+	Size        int64
+	ContentType string
+}
+
+// AttachmentBlobStore is the subset of a blob store that persistence needs to
+// validate and clean up observation attachments. Stat returns an error when no
+// blob is stored under key.
+type AttachmentBlobStore interface {
+	Stat(key string) (BlobInfo, error)
+	Delete(key string) error
+}
+
+// ResolveAttachment confirms that ref names an existing blob and fills an
+// unset content type or size from the stored blob. A declared size that
+// disagrees with the stored blob is rejected.
+func ResolveAttachment(blobs AttachmentBlobStore, ref AttachmentRef) (AttachmentRef, error) {
+	if blobs == nil {
+		return AttachmentRef{}, ErrAttachmentBlobsUnavailable
+	}
+	ref.Key = strings.TrimSpace(ref.Key)
+	if ref.Key == "" {
+		return AttachmentRef{}, errors.New("attachment requires blob key")
+	}
+	if ref.SizeBytes < 0 {
+		return AttachmentRef{}, fmt.Errorf("attachment %q size %d must not be negative", ref.Key, ref.SizeBytes)
+	}
+	info, err := blobs.Stat(ref.Key)
+	if err != nil {
+		return AttachmentRef{}, fmt.Errorf("attachment blob %q: %w", ref.Key, err)
+	}
+	if ref.SizeBytes == 0 {
+		ref.SizeBytes = int(info.Size)
+	} else if int64(ref.SizeBytes) != info.Size {
+		return AttachmentRef{}, fmt.Errorf("attachment %q size %d does not match stored blob size %d", ref.Key, ref.SizeBytes, info.Size)
+	}
+	if strings.TrimSpace(ref.ContentType) == "" {
+		ref.ContentType = info.ContentType
+	}
+	if ref.ContentType == "" {
+		return AttachmentRef{}, fmt.Errorf("attachment %q requires content type", ref.Key)
+	}
+	return ref, nil
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"strings"
+
+	"colonycore/pkg/domain/entitymodel"
+)
+
+// AuditFields returns the JSON property names annotated with x-audit in the
+// entity model for the given entity type. Entity types without audited
+// properties return nil.
+func AuditFields(e EntityType) []string {
+	return entitymodel.AuditFields(entityModelName(e))
+}
+
+// entityModelName maps a snake_case entity type such as "housing_unit" onto
+// its entity-model name, "HousingUnit".
+func entityModelName(e EntityType) string {
+	parts := strings.Split(string(e), "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// EntityTypeOf reports the entity type of a domain record value such as an
+// Organism or Permit. Other values report false.
+func EntityTypeOf(value any) (EntityType, bool) {
+	switch value.(type) {
+	case Organism:
+		return EntityOrganism, true
+	case Cohort:
+		return EntityCohort, true
+	case HousingUnit:
+		return EntityHousingUnit, true
+	case Facility:
+		return EntityFacility, true
+	case BreedingUnit:
+		return EntityBreeding, true
+	case Line:
+		return EntityLine, true
+	case Strain:
+		return EntityStrain, true
+	case GenotypeMarker:
+		return EntityGenotypeMarker, true
+	case Procedure:
+		return EntityProcedure, true
+	case Treatment:
+		return EntityTreatment, true
+	case Observation:
+		return EntityObservation, true
+	case Sample:
+		return EntitySample, true
+	case Protocol:
+		return EntityProtocol, true
+	case Permit:
+		return EntityPermit, true
+	case Project:
+		return EntityProject, true
+	case SupplyItem:
+		return EntitySupplyItem, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "testing"
+
+func TestAuditFields(t *testing.T) {
+	if fields := AuditFields(EntityPermit); len(fields) != 1 || fields[0] != "status" {
+		t.Fatalf("expected permit status to be audited, got %v", fields)
+	}
+	if fields := AuditFields(EntityHousingUnit); fields != nil {
+		t.Fatalf("expected housing units to have no audited fields, got %v", fields)
+	}
+}
+
+func TestEntityTypeOf(t *testing.T) {
+	if entity, ok := EntityTypeOf(Permit{}); !ok || entity != EntityPermit {
+		t.Fatalf("expected permit entity type, got %q", entity)
+	}
+	if _, ok := EntityTypeOf("permit"); ok {
+		t.Fatalf("expected non-record values to have no entity type")
+	}
+}
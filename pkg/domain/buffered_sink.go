@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrSinkClosed is returned by BufferedSink.Publish after Close.
+var ErrSinkClosed = errors.New("change sink closed")
+
+// BufferedSink decouples a slow ChangeSink from the transactions that publish
+// to it. Publish enqueues each batch and returns immediately; a background
+// goroutine delivers queued batches to the inner sink in order. When the
+// queue already holds capacity batches, Publish never waits for room: it
+// hands the batch to the overflow callback, which may log or count it, and
+// the batch is then dropped; the callback cannot requeue it.
+//
+// Errors returned by the inner sink are discarded, matching commit observers:
+// by the time a batch is delivered its transaction has committed.
+type BufferedSink struct {
+	inner      ChangeSink
+	onOverflow func([]Change)
+	queue      chan bufferedBatch
+	done       chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+type bufferedBatch struct {
+	ctx     context.Context
+	changes []Change
+}
+
+// NewBufferedSink starts a BufferedSink that queues up to capacity batches for
+// inner. A nil onOverflow drops batches that do not fit. Callers must Close
+// the sink to stop its goroutine.
+func NewBufferedSink(inner ChangeSink, capacity int, onOverflow func([]Change)) (*BufferedSink, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("buffered sink requires an inner sink")
+	}
+	if capacity <= 0 {
+		return nil, fmt.Errorf("buffered sink capacity must be positive, got %d", capacity)
+	}
+	s := &BufferedSink{
+		inner:      inner,
+		onOverflow: onOverflow,
+		queue:      make(chan bufferedBatch, capacity),
+		done:       make(chan struct{}),
+	}
+	go s.drain()
+	return s, nil
+}
+
+// Publish enqueues a copy of changes for delivery. Delivery uses a context
+// that keeps ctx's values but not its cancellation, since the publishing
+// request may finish before the batch is drained. When the queue is full the
+// overflow callback runs on the calling goroutine before Publish returns.
+func (s *BufferedSink) Publish(ctx context.Context, changes []Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	batch := bufferedBatch{ctx: context.WithoutCancel(ctx), changes: append([]Change(nil), changes...)}
+	s.mu.RLock()
+	if s.closed {
+		s.mu.RUnlock()
+		return ErrSinkClosed
+	}
+	select {
+	case s.queue <- batch:
+		s.mu.RUnlock()
+		return nil
+	default:
+	}
+	s.mu.RUnlock()
+	if s.onOverflow != nil {
+		s.onOverflow(batch.changes)
+	}
+	return nil
+}
+
+// Close stops accepting batches and waits until every queued batch has been
+// delivered to the inner sink. It is safe to call more than once.
+func (s *BufferedSink) Close() {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+	<-s.done
+}
+
+func (s *BufferedSink) drain() {
+	defer close(s.done)
+	for batch := range s.queue {
+		_ = s.inner.Publish(batch.ctx, batch.changes)
+	}
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// gatedSink records delivered batches. When gate is set, each Publish signals
+// the buffered started channel and then waits for gate to be closed.
+type gatedSink struct {
+	mu      sync.Mutex
+	batches [][]Change
+	started chan struct{}
+	gate    chan struct{}
+}
+
+func (s *gatedSink) Publish(_ context.Context, changes []Change) error {
+	if s.gate != nil {
+		s.started <- struct{}{}
+		<-s.gate
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, changes)
+	return errors.New("inner errors are discarded")
+}
+
+func (s *gatedSink) delivered() []EntityType {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []EntityType
+	for _, batch := range s.batches {
+		out = append(out, batch[0].Entity)
+	}
+	return out
+}
+
+func newGatedSink() *gatedSink {
+	return &gatedSink{started: make(chan struct{}, 8), gate: make(chan struct{})}
+}
+
+func batchOf(entity EntityType) []Change {
+	return []Change{{Entity: entity, Action: ActionCreate}}
+}
+
+func TestNewBufferedSinkValidatesArguments(t *testing.T) {
+	if _, err := NewBufferedSink(nil, 1, nil); err == nil {
+		t.Fatalf("expected nil inner sink to be rejected")
+	}
+	if _, err := NewBufferedSink(&gatedSink{}, 0, nil); err == nil {
+		t.Fatalf("expected non-positive capacity to be rejected")
+	}
+}
+
+func TestBufferedSinkDrainsInOrder(t *testing.T) {
+	inner := &gatedSink{}
+	sink, err := NewBufferedSink(inner, 4, func([]Change) { t.Errorf("unexpected overflow") })
+	if err != nil {
+		t.Fatalf("NewBufferedSink: %v", err)
+	}
+	for _, entity := range []EntityType{EntityOrganism, EntityCohort, EntityProject} {
+		if err := sink.Publish(context.Background(), batchOf(entity)); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	if err := sink.Publish(context.Background(), nil); err != nil {
+		t.Fatalf("expected empty batch to be ignored, got %v", err)
+	}
+	sink.Close()
+	got := inner.delivered()
+	if len(got) != 3 || got[0] != EntityOrganism || got[1] != EntityCohort || got[2] != EntityProject {
+		t.Fatalf("expected batches delivered in publish order, got %v", got)
+	}
+}
+
+func TestBufferedSinkOverflowInvokesCallback(t *testing.T) {
+	inner := newGatedSink()
+	var overflowed [][]Change
+	sink, err := NewBufferedSink(inner, 1, func(changes []Change) { overflowed = append(overflowed, changes) })
+	if err != nil {
+		t.Fatalf("NewBufferedSink: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The first batch occupies the drain goroutine, the second fills the queue.
+	_ = sink.Publish(ctx, batchOf(EntityOrganism))
+	<-inner.started
+	_ = sink.Publish(ctx, batchOf(EntityCohort))
+	if err := sink.Publish(ctx, batchOf(EntityProject)); err != nil {
+		t.Fatalf("expected overflow not to fail the publisher, got %v", err)
+	}
+	if len(overflowed) != 1 || overflowed[0][0].Entity != EntityProject {
+		t.Fatalf("expected the third batch to overflow, got %v", overflowed)
+	}
+
+	cancel()
+	close(inner.gate)
+	sink.Close()
+	if got := inner.delivered(); len(got) != 2 || got[0] != EntityOrganism || got[1] != EntityCohort {
+		t.Fatalf("expected queued batches delivered after the overflow, got %v", got)
+	}
+}
+
+func TestBufferedSinkCloseFlushesQueuedBatches(t *testing.T) {
+	inner := newGatedSink()
+	sink, err := NewBufferedSink(inner, 4, nil)
+	if err != nil {
+		t.Fatalf("NewBufferedSink: %v", err)
+	}
+	for _, entity := range []EntityType{EntityOrganism, EntityCohort, EntityProject} {
+		_ = sink.Publish(context.Background(), batchOf(entity))
+	}
+	<-inner.started
+	close(inner.gate)
+	sink.Close()
+	if got := inner.delivered(); len(got) != 3 {
+		t.Fatalf("expected Close to flush every queued batch, got %v", got)
+	}
+	if err := sink.Publish(context.Background(), batchOf(EntityOrganism)); !errors.Is(err, ErrSinkClosed) {
+		t.Fatalf("expected ErrSinkClosed after Close, got %v", err)
+	}
+	sink.Close()
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ChangeSink receives entity changes from a change feed, typically the
+// changes committed by a single transaction in the order they were recorded.
+type ChangeSink interface {
+	Publish(ctx context.Context, changes []Change) error
+}
+
+// ChangeSinkFunc adapts an ordinary function to the ChangeSink interface.
+type ChangeSinkFunc func(ctx context.Context, changes []Change) error
+
+// Publish calls f(ctx, changes).
+func (f ChangeSinkFunc) Publish(ctx context.Context, changes []Change) error {
+	return f(ctx, changes)
+}
+
+// FieldPredicate matches the value found at Path inside a change payload.
+//
+// Path starts with "before" or "after" to select the payload and continues
+// with dot-separated JSON keys, for example "after.stage" or
+// "before.attributes.color". Equals is compared with the decoded JSON value,
+// so typed constants such as StageAdult match their string encoding. A nil
+// Equals matches only an explicit JSON null; paths that do not resolve, such as
+// "before.*" on a create, never match.
+type FieldPredicate struct {
+	Path   string
+	Equals any
+}
+
+// ChangeFilter selects changes by entity type, action, and payload fields.
+// Empty Entities or Actions match every value in that dimension; every entry in
+// Fields must match. The zero filter matches all changes.
+type ChangeFilter struct {
+	Entities []EntityType
+	Actions  []Action
+	Fields   []FieldPredicate
+}
+
+// Match reports whether change satisfies every criterion of the filter.
+func (f ChangeFilter) Match(change Change) bool {
+	if len(f.Entities) > 0 && !containsValue(f.Entities, change.Entity) {
+		return false
+	}
+	if len(f.Actions) > 0 && !containsValue(f.Actions, change.Action) {
+		return false
+	}
+	decoded := map[string]any{}
+	for _, predicate := range f.Fields {
+		if !predicate.match(change, decoded) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate reports predicates whose paths cannot address a change payload.
+func (f ChangeFilter) Validate() error {
+	for _, predicate := range f.Fields {
+		side, _, _ := strings.Cut(predicate.Path, ".")
+		if side != "before" && side != "after" {
+			return fmt.Errorf("change filter path %q must start with before. or after.", predicate.Path)
+		}
+		if _, err := json.Marshal(predicate.Equals); err != nil {
+			return fmt.Errorf("change filter path %q: encode expected value: %w", predicate.Path, err)
+		}
+	}
+	return nil
+}
+
+// match resolves the predicate path, decoding each payload at most once per
+// Match call via the decoded cache.
+func (p FieldPredicate) match(change Change, decoded map[string]any) bool {
+	side, rest, _ := strings.Cut(p.Path, ".")
+	var payload ChangePayload
+	switch side {
+	case "before":
+		payload = change.Before
+	case "after":
+		payload = change.After
+	default:
+		return false
+	}
+	root, cached := decoded[side]
+	if !cached {
+		root = nil
+		if raw := payload.Raw(); raw != nil {
+			if err := json.Unmarshal(raw, &root); err != nil {
+				root = nil
+			}
+		}
+		decoded[side] = root
+	}
+	if root == nil {
+		return false
+	}
+	value := root
+	if rest != "" {
+		for _, key := range strings.Split(rest, ".") {
+			object, ok := value.(map[string]any)
+			if !ok {
+				return false
+			}
+			if value, ok = object[key]; !ok {
+				return false
+			}
+		}
+	}
+	expected, err := normalizeJSONValue(p.Equals)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(value, expected)
+}
+
+// normalizeJSONValue round-trips v through JSON so it compares equal to values
+// decoded from change payloads.
+func normalizeJSONValue(v any) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func containsValue[T comparable](values []T, needle T) bool {
+	for _, value := range values {
+		if value == needle {
+			return true
+		}
+	}
+	return false
+}
+
+// FilteredSink forwards to an inner ChangeSink only the changes that match
+// its filter, preserving their order. Batches with no matching change are not
+// forwarded at all.
+type FilteredSink struct {
+	inner  ChangeSink
+	filter ChangeFilter
+}
+
+// NewFilteredSink wraps inner so it only receives changes matching filter.
+func NewFilteredSink(inner ChangeSink, filter ChangeFilter) (*FilteredSink, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("filtered sink requires an inner sink")
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return &FilteredSink{inner: inner, filter: filter}, nil
+}
+
+// Publish forwards the matching subset of changes to the inner sink.
+func (s *FilteredSink) Publish(ctx context.Context, changes []Change) error {
+	var matched []Change
+	for _, change := range changes {
+		if s.filter.Match(change) {
+			matched = append(matched, change)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+	return s.inner.Publish(ctx, matched)
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func organismChange(t *testing.T, action Action, before, after *Organism) Change {
+	t.Helper()
+	change := Change{Entity: EntityOrganism, Action: action}
+	if before != nil {
+		payload, err := NewChangePayloadFromValue(*before)
+		if err != nil {
+			t.Fatalf("encode before: %v", err)
+		}
+		change.Before = payload
+	}
+	if after != nil {
+		payload, err := NewChangePayloadFromValue(*after)
+		if err != nil {
+			t.Fatalf("encode after: %v", err)
+		}
+		change.After = payload
+	}
+	return change
+}
+
+type recordingSink struct {
+	batches [][]Change
+}
+
+func (s *recordingSink) Publish(_ context.Context, changes []Change) error {
+	s.batches = append(s.batches, changes)
+	return nil
+}
+
+func TestChangeFilterMatchesEntityAndAction(t *testing.T) {
+	frog := Organism{Organism: entitymodel.Organism{ID: "frog", Name: "Frog", Stage: StageAdult}}
+	filter := ChangeFilter{Entities: []EntityType{EntityOrganism}, Actions: []Action{ActionCreate, ActionDelete}}
+
+	if !filter.Match(organismChange(t, ActionCreate, nil, &frog)) {
+		t.Fatalf("expected organism create to match")
+	}
+	if filter.Match(organismChange(t, ActionUpdate, &frog, &frog)) {
+		t.Fatalf("expected organism update not to match")
+	}
+	if filter.Match(Change{Entity: EntityCohort, Action: ActionCreate}) {
+		t.Fatalf("expected cohort create not to match")
+	}
+	if !(ChangeFilter{}).Match(Change{Entity: EntityCohort, Action: ActionCreate}) {
+		t.Fatalf("expected zero filter to match everything")
+	}
+}
+
+func TestChangeFilterMatchesFieldTransition(t *testing.T) {
+	planned := Organism{Organism: entitymodel.Organism{ID: "frog", Name: "Frog", Stage: StagePlanned, Attributes: map[string]any{"color": "green"}}}
+	adult := planned
+	adult.Stage = StageAdult
+	retired := planned
+	retired.Stage = StageRetired
+
+	filter := ChangeFilter{
+		Entities: []EntityType{EntityOrganism},
+		Actions:  []Action{ActionUpdate},
+		Fields: []FieldPredicate{
+			{Path: "before.stage", Equals: StagePlanned},
+			{Path: "after.stage", Equals: StageAdult},
+		},
+	}
+	if !filter.Match(organismChange(t, ActionUpdate, &planned, &adult)) {
+		t.Fatalf("expected planned -> adult transition to match")
+	}
+	if filter.Match(organismChange(t, ActionUpdate, &adult, &retired)) {
+		t.Fatalf("expected adult -> retired transition not to match")
+	}
+	if filter.Match(organismChange(t, ActionUpdate, &planned, &retired)) {
+		t.Fatalf("expected planned -> retired transition not to match")
+	}
+
+	missing := ChangeFilter{Fields: []FieldPredicate{{Path: "before.stage", Equals: StagePlanned}}}
+	if missing.Match(organismChange(t, ActionCreate, nil, &planned)) {
+		t.Fatalf("expected before.* predicate not to match a create without a before payload")
+	}
+	if err := (ChangeFilter{Fields: []FieldPredicate{{Path: "stage"}}}).Validate(); err == nil {
+		t.Fatalf("expected path without before/after prefix to be rejected")
+	}
+}
+
+func TestFilteredSinkDropsNonMatchingChanges(t *testing.T) {
+	planned := Organism{Organism: entitymodel.Organism{ID: "frog", Name: "Frog", Stage: StagePlanned}}
+	adult := planned
+	adult.Stage = StageAdult
+
+	inner := &recordingSink{}
+	sink, err := NewFilteredSink(inner, ChangeFilter{Fields: []FieldPredicate{{Path: "after.stage", Equals: "adult"}}})
+	if err != nil {
+		t.Fatalf("new filtered sink: %v", err)
+	}
+
+	if err := sink.Publish(context.Background(), []Change{organismChange(t, ActionCreate, nil, &planned)}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if len(inner.batches) != 0 {
+		t.Fatalf("expected non-matching batch to be dropped, got %+v", inner.batches)
+	}
+
+	matching := organismChange(t, ActionUpdate, &planned, &adult)
+	if err := sink.Publish(context.Background(), []Change{{Entity: EntityCohort, Action: ActionDelete}, matching}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if len(inner.batches) != 1 || len(inner.batches[0]) != 1 || inner.batches[0][0].Action != ActionUpdate {
+		t.Fatalf("expected only the matching change forwarded, got %+v", inner.batches)
+	}
+
+	if _, err := NewFilteredSink(nil, ChangeFilter{}); err == nil {
+		t.Fatalf("expected nil inner sink to be rejected")
+	}
+}
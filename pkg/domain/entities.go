@@ -3,9 +3,15 @@
 package domain
 
 import (
+	"bytes"
 	"colonycore/pkg/domain/entitymodel"
 	"colonycore/pkg/domain/extension"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
 )
 
 // EntityType identifies the type of record stored in the core domain.
@@ -109,6 +115,15 @@ const (
 	SampleStatusDisposed  SampleStatus = entitymodel.SampleStatusDisposed
 )
 
+// SupplyStatus enumerates supply inventory availability states.
+type SupplyStatus = entitymodel.SupplyStatus
+
+// Canonical supply statuses tracking whether stock remains on hand.
+const (
+	SupplyStatusActive   SupplyStatus = entitymodel.SupplyStatusActive
+	SupplyStatusConsumed SupplyStatus = entitymodel.SupplyStatusConsumed
+)
+
 // PermitStatus enumerates permit validity states consumed by compliance workflows.
 type PermitStatus = entitymodel.PermitStatus
 
@@ -144,6 +159,17 @@ const (
 	HousingEnvironmentHumid       HousingEnvironment = entitymodel.HousingEnvironmentHumid
 )
 
+// PairingIntent enumerates the breeding goal recorded on a breeding unit.
+type PairingIntent = entitymodel.PairingIntent
+
+// Canonical pairing intents aligned to Entity Model v0.
+const (
+	PairingIntentMaintenance  PairingIntent = entitymodel.PairingIntentMaintenance
+	PairingIntentExpansion    PairingIntent = entitymodel.PairingIntentExpansion
+	PairingIntentExperimental PairingIntent = entitymodel.PairingIntentExperimental
+	PairingIntentRederivation PairingIntent = entitymodel.PairingIntentRederivation
+)
+
 // Severity captures rule outcomes.
 type Severity string
 
@@ -214,12 +240,31 @@ type Treatment struct {
 	entitymodel.Treatment
 }
 
+// DosagePlan describes the structured dosing regimen attached to a treatment.
+type DosagePlan = entitymodel.DosagePlan
+
+// AdverseEvent records a complication observed during a treatment.
+type AdverseEvent = entitymodel.AdverseEvent
+
+// AdverseEventSeverity grades an adverse event.
+type AdverseEventSeverity = entitymodel.AdverseEventSeverity
+
+// Canonical adverse event severities aligned to Entity Model v0.
+const (
+	AdverseEventSeverityMild     AdverseEventSeverity = entitymodel.AdverseEventSeverityMild
+	AdverseEventSeverityModerate AdverseEventSeverity = entitymodel.AdverseEventSeverityModerate
+	AdverseEventSeveritySevere   AdverseEventSeverity = entitymodel.AdverseEventSeveritySevere
+)
+
 // Observation records structured or free-form notes captured during workflows.
 type Observation struct {
 	entitymodel.Observation
 	extensions *extension.Container `json:"-"`
 }
 
+// AttachmentRef points an observation at a file held in the blob store.
+type AttachmentRef = entitymodel.AttachmentRef
+
 // Sample tracks material derived from organisms or cohorts.
 type Sample struct {
 	entitymodel.Sample
@@ -250,6 +295,129 @@ type SupplyItem struct {
 	extensions *extension.Container `json:"-"`
 }
 
+// ErrUnknownField reports a JSON key that the entity model does not declare
+// for an entity whose schema is closed to additional properties.
+var ErrUnknownField = errors.New("unknown field")
+
+// extensionsKey carries plugin payloads outside the core slots. The entity
+// marshallers write it beside the schema properties, so decoding accepts it.
+const extensionsKey = "extensions"
+
+// checkDeclaredFields rejects keys in data that entity does not declare when
+// its schema sets additionalProperties to false. allowed lists further keys
+// the entity's own marshaller writes.
+func checkDeclaredFields(entity string, data []byte, allowed ...string) error {
+	declared := entitymodel.DeclaredProperties(entity)
+	if declared == nil {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !slices.Contains(declared, key) && !slices.Contains(allowed, key) {
+			return fmt.Errorf("%w %q in %s", ErrUnknownField, key, entity)
+		}
+	}
+	return nil
+}
+
+type cohortAlias entitymodel.Cohort
+
+// UnmarshalJSON decodes a cohort, rejecting keys the entity model does not declare.
+func (c *Cohort) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("Cohort", data); err != nil {
+		return err
+	}
+	var aux cohortAlias
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	c.Cohort = entitymodel.Cohort(aux)
+	return nil
+}
+
+type housingUnitAlias entitymodel.HousingUnit
+
+// UnmarshalJSON decodes a housing unit, rejecting keys the entity model does not declare.
+func (h *HousingUnit) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("HousingUnit", data); err != nil {
+		return err
+	}
+	var aux housingUnitAlias
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	h.HousingUnit = entitymodel.HousingUnit(aux)
+	return nil
+}
+
+type procedureAlias entitymodel.Procedure
+
+// UnmarshalJSON decodes a procedure, rejecting keys the entity model does not declare.
+func (p *Procedure) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("Procedure", data); err != nil {
+		return err
+	}
+	var aux procedureAlias
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.Procedure = entitymodel.Procedure(aux)
+	return nil
+}
+
+type protocolAlias entitymodel.Protocol
+
+// UnmarshalJSON decodes a protocol, rejecting keys the entity model does not declare.
+func (p *Protocol) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("Protocol", data); err != nil {
+		return err
+	}
+	var aux protocolAlias
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.Protocol = entitymodel.Protocol(aux)
+	return nil
+}
+
+type permitAlias entitymodel.Permit
+
+// UnmarshalJSON decodes a permit, rejecting keys the entity model does not declare.
+func (p *Permit) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("Permit", data); err != nil {
+		return err
+	}
+	var aux permitAlias
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.Permit = entitymodel.Permit(aux)
+	return nil
+}
+
+type projectAlias entitymodel.Project
+
+// UnmarshalJSON decodes a project, rejecting keys the entity model does not declare.
+func (p *Project) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("Project", data); err != nil {
+		return err
+	}
+	var aux projectAlias
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.Project = entitymodel.Project(aux)
+	return nil
+}
+
 type organismAlias entitymodel.Organism
 
 // MarshalJSON ensures organism attributes are serialised via the core plugin payload.
@@ -276,6 +444,9 @@ func (o Organism) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON hydrates organism extension slots from the JSON payload.
 func (o *Organism) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("Organism", data, extensionsKey); err != nil {
+		return err
+	}
 	type payload struct {
 		organismAlias
 		Attributes map[string]any            `json:"attributes"`
@@ -327,6 +498,9 @@ func (f Facility) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON hydrates facility extension slots from the JSON payload.
 func (f *Facility) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("Facility", data, extensionsKey); err != nil {
+		return err
+	}
 	type payload struct {
 		facilityAlias
 		EnvironmentBaselines map[string]any            `json:"environment_baselines"`
@@ -375,6 +549,9 @@ func (b BreedingUnit) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON hydrates breeding unit extension slots from the JSON payload.
 func (b *BreedingUnit) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("BreedingUnit", data, extensionsKey); err != nil {
+		return err
+	}
 	type payload struct {
 		breedingUnitAlias
 		PairingAttributes map[string]any            `json:"pairing_attributes"`
@@ -423,6 +600,9 @@ func (o Observation) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON hydrates observation extension slots from the JSON payload.
 func (o *Observation) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("Observation", data, extensionsKey); err != nil {
+		return err
+	}
 	type payload struct {
 		observationAlias
 		Data       map[string]any            `json:"data"`
@@ -474,6 +654,9 @@ func (s Sample) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON hydrates sample extension slots from the JSON payload.
 func (s *Sample) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("Sample", data, extensionsKey); err != nil {
+		return err
+	}
 	type payload struct {
 		sampleAlias
 		Attributes map[string]any            `json:"attributes"`
@@ -525,6 +708,9 @@ func (s SupplyItem) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON hydrates supply item extension slots from the JSON payload.
 func (s *SupplyItem) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("SupplyItem", data, extensionsKey); err != nil {
+		return err
+	}
 	type payload struct {
 		supplyAlias
 		Attributes map[string]any            `json:"attributes"`
@@ -569,6 +755,9 @@ func (l Line) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON hydrates line extension slots from the JSON payload.
 func (l *Line) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("Line", data); err != nil {
+		return err
+	}
 	type payload struct {
 		lineAlias
 		DefaultAttributes  map[string]any `json:"default_attributes"`
@@ -602,6 +791,9 @@ func (s Strain) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON hydrates strain extension slot from the payload.
 func (s *Strain) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("Strain", data); err != nil {
+		return err
+	}
 	type payload struct {
 		strainAlias
 		Attributes map[string]any `json:"attributes"`
@@ -631,6 +823,9 @@ func (g GenotypeMarker) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON hydrates genotype marker attributes from the JSON payload.
 func (g *GenotypeMarker) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("GenotypeMarker", data); err != nil {
+		return err
+	}
 	type payload struct {
 		genotypeMarkerAlias
 		Attributes map[string]any `json:"attributes"`
@@ -643,12 +838,129 @@ func (g *GenotypeMarker) UnmarshalJSON(data []byte) error {
 	return g.ApplyGenotypeMarkerAttributes(aux.Attributes)
 }
 
+type treatmentAlias entitymodel.Treatment
+
+// UnmarshalJSON hydrates treatments, accepting legacy free-form dosage plans
+// and adverse events.
+func (t *Treatment) UnmarshalJSON(data []byte) error {
+	if err := checkDeclaredFields("Treatment", data); err != nil {
+		return err
+	}
+	type payload struct {
+		treatmentAlias
+		DosagePlan    json.RawMessage `json:"dosage_plan"`
+		AdverseEvents json.RawMessage `json:"adverse_events"`
+	}
+	var aux payload
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	plan, err := ParseDosagePlan(aux.DosagePlan)
+	if err != nil {
+		return err
+	}
+	events, err := ParseAdverseEvents(aux.AdverseEvents)
+	if err != nil {
+		return err
+	}
+	t.Treatment = entitymodel.Treatment(aux.treatmentAlias)
+	t.DosagePlan = plan
+	t.AdverseEvents = events
+	return nil
+}
+
+// ParseDosagePlan decodes a persisted dosage plan. Structured JSON objects are
+// decoded directly. Legacy free text, either a JSON string or raw text read
+// from a pre-migration TEXT column, is preserved as LegacyText with no
+// structured fields so historical records remain readable. Other JSON values,
+// such as numbers and arrays, are rejected.
+func ParseDosagePlan(raw []byte) (DosagePlan, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return DosagePlan{}, nil
+	}
+	switch trimmed[0] {
+	case '{':
+		var plan DosagePlan
+		if err := json.Unmarshal(trimmed, &plan); err != nil {
+			return DosagePlan{}, err
+		}
+		return plan, nil
+	case '"':
+		var legacy string
+		if err := json.Unmarshal(trimmed, &legacy); err != nil {
+			return DosagePlan{}, err
+		}
+		return DosagePlan{LegacyText: &legacy}, nil
+	}
+	if !json.Valid(trimmed) {
+		legacy := string(trimmed)
+		return DosagePlan{LegacyText: &legacy}, nil
+	}
+	return DosagePlan{}, fmt.Errorf("dosage plan must be a JSON object or legacy string, got %s", trimmed)
+}
+
+// IsLegacyDosagePlan reports whether plan was migrated from the legacy
+// free-text form and has not since been given structured fields.
+func IsLegacyDosagePlan(plan DosagePlan) bool {
+	return plan.LegacyText != nil && plan == DosagePlan{LegacyText: plan.LegacyText}
+}
+
+// ParseAdverseEvents decodes persisted adverse events. Structured JSON objects
+// are decoded directly; legacy free-text entries are preserved as the event
+// description with no severity or observation time.
+func ParseAdverseEvents(raw []byte) ([]AdverseEvent, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil, nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(trimmed, &items); err != nil {
+		return nil, err
+	}
+	events := make([]AdverseEvent, 0, len(items))
+	for _, item := range items {
+		item = bytes.TrimSpace(item)
+		if len(item) > 0 && item[0] == '"' {
+			var legacy string
+			if err := json.Unmarshal(item, &legacy); err != nil {
+				return nil, err
+			}
+			events = append(events, AdverseEvent{Description: legacy})
+			continue
+		}
+		var event AdverseEvent
+		if err := json.Unmarshal(item, &event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// ValidateAdverseEvent checks an adverse event before it is recorded: the
+// description must not be blank and the severity must be a canonical value.
+func ValidateAdverseEvent(event AdverseEvent) error {
+	if strings.TrimSpace(event.Description) == "" {
+		return errors.New("adverse event description is required")
+	}
+	switch event.Severity {
+	case AdverseEventSeverityMild, AdverseEventSeverityModerate, AdverseEventSeveritySevere:
+		return nil
+	default:
+		return fmt.Errorf("unsupported adverse event severity %q", event.Severity)
+	}
+}
+
 // Change describes a mutation applied to an entity during a transaction.
 type Change struct {
 	Entity EntityType
 	Action Action
 	Before ChangePayload
 	After  ChangePayload
+	// Override attributes an ActionForceUpdate change to the actor and reason
+	// that authorised it. It is nil for every other action.
+	Override *ForceUpdate
 }
 
 // Action indicates the type of modification performed.
@@ -661,6 +973,12 @@ const (
 	// ActionUpdate indicates an entity was updated.
 	ActionUpdate Action = "update"
 	ActionDelete Action = "delete"
+	// ActionConsume indicates stock was drawn down from a supply item.
+	ActionConsume Action = "consume"
+	// ActionClose indicates a project was closed.
+	ActionClose Action = "close"
+	// ActionForceUpdate indicates an update that overrode a version conflict.
+	ActionForceUpdate Action = "force_update"
 )
 
 // Violation reports a failed rule evaluation.
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"encoding/json"
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -1448,3 +1450,107 @@ func TestGenotypeMarkerUnmarshalJSONInvalidAttributes(t *testing.T) {
 		t.Fatalf("expected error when genotype marker attributes use invalid payload shape")
 	}
 }
+
+func TestTreatmentDosagePlanJSONRoundTrip(t *testing.T) {
+	treatment := Treatment{Treatment: entitymodel.Treatment{
+		ID:          "treatment-1",
+		Name:        "Analgesia",
+		ProcedureID: "procedure-1",
+		DosagePlan:  DosagePlan{Drug: "meloxicam", DoseAmount: 0.5, DoseUnit: "mg/kg", FrequencyPerDay: 2, DurationDays: 5},
+	}}
+	data, err := json.Marshal(treatment)
+	mustNoError(t, "marshal treatment", err)
+
+	var decoded Treatment
+	mustNoError(t, "unmarshal treatment", json.Unmarshal(data, &decoded))
+	if decoded.DosagePlan != treatment.DosagePlan {
+		t.Fatalf("expected dosage plan %+v, got %+v", treatment.DosagePlan, decoded.DosagePlan)
+	}
+	if decoded.ID != treatment.ID || decoded.ProcedureID != treatment.ProcedureID {
+		t.Fatalf("expected core fields to round-trip, got %+v", decoded.Treatment)
+	}
+}
+
+func TestTreatmentUnmarshalJSONLegacyDosagePlan(t *testing.T) {
+	var treatment Treatment
+	mustNoError(t, "unmarshal legacy treatment", json.Unmarshal([]byte(`{"id":"treatment-legacy","dosage_plan":"10mg daily"}`), &treatment))
+	if treatment.ID != "treatment-legacy" || !IsLegacyDosagePlan(treatment.DosagePlan) || *treatment.DosagePlan.LegacyText != "10mg daily" {
+		t.Fatalf("expected legacy dosage plan to keep its text, got %+v", treatment.Treatment)
+	}
+	if err := json.Unmarshal([]byte(`{"dosage_plan":{"dose_amount":"bad"}}`), &treatment); err == nil {
+		t.Fatalf("expected malformed dosage plan to fail")
+	}
+}
+
+func TestParseDosagePlan(t *testing.T) {
+	legacy := func(text string) DosagePlan { return DosagePlan{LegacyText: &text} }
+	cases := []struct {
+		name string
+		raw  string
+		want DosagePlan
+	}{
+		{name: "empty", raw: "", want: DosagePlan{}},
+		{name: "null", raw: "null", want: DosagePlan{}},
+		{name: "structured", raw: `{"drug":"enrofloxacin","dose_amount":10,"dose_unit":"mg/kg","frequency_per_day":1,"duration_days":7}`, want: DosagePlan{Drug: "enrofloxacin", DoseAmount: 10, DoseUnit: "mg/kg", FrequencyPerDay: 1, DurationDays: 7}},
+		{name: "quoted legacy", raw: `"5 ml twice daily"`, want: legacy("5 ml twice daily")},
+		{name: "raw legacy", raw: "5 ml twice daily", want: legacy("5 ml twice daily")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseDosagePlan([]byte(tc.raw))
+			mustNoError(t, "ParseDosagePlan", err)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %+v, got %+v", tc.want, got)
+			}
+			if IsLegacyDosagePlan(got) != (tc.want.LegacyText != nil) {
+				t.Fatalf("unexpected IsLegacyDosagePlan for %+v", got)
+			}
+		})
+	}
+	for _, raw := range []string{`{"drug":`, `"unterminated`, `42`, `["5 ml"]`, `true`} {
+		if _, err := ParseDosagePlan([]byte(raw)); err == nil {
+			t.Fatalf("expected %s to be rejected", raw)
+		}
+	}
+	text := "5 ml"
+	if IsLegacyDosagePlan(DosagePlan{LegacyText: &text, DoseAmount: 5}) {
+		t.Fatalf("expected a legacy plan with structured fields to no longer count as legacy")
+	}
+}
+
+func TestUnmarshalJSONRejectsUndeclaredFields(t *testing.T) {
+	cases := map[string]any{
+		"organism":     &Organism{},
+		"cohort":       &Cohort{},
+		"housing unit": &HousingUnit{},
+		"treatment":    &Treatment{},
+	}
+	for name, target := range cases {
+		err := json.Unmarshal([]byte(`{"id":"x","nickname":"unknown"}`), target)
+		if !errors.Is(err, ErrUnknownField) {
+			t.Fatalf("%s: expected ErrUnknownField, got %v", name, err)
+		}
+	}
+}
+
+func TestUnmarshalJSONAcceptsMarshalledExtensions(t *testing.T) {
+	organism := Organism{Organism: entitymodel.Organism{ID: "o1", Name: "Frog"}}
+	container := extension.NewContainer()
+	if err := container.Set(extension.HookOrganismAttributes, extension.PluginID("frog"), map[string]any{"colour": "green"}); err != nil {
+		t.Fatalf("set extension: %v", err)
+	}
+	if err := organism.SetOrganismExtensions(container); err != nil {
+		t.Fatalf("set extensions: %v", err)
+	}
+	data, err := json.Marshal(organism)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded Organism
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+	if decoded.ID != "o1" {
+		t.Fatalf("expected organism o1, got %+v", decoded)
+	}
+}
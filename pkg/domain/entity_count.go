@@ -0,0 +1,48 @@
+package domain
+
+// CountFilter restricts EntityCounter.CountEntities to records attributed to
+// the listed facilities and projects. A nil slice leaves that dimension
+// unfiltered; an empty non-nil slice admits only records without attribution
+// in it. Records with no attribution in a filtered dimension are still
+// counted, so a filtered count is an upper bound on what a scoped read returns.
+//
+// Records are attributed as follows: organisms and cohorts to the facility of
+// their housing unit and to their project; housing units and samples to their
+// facility; facilities to themselves; projects to their facilities and to
+// themselves; permits to their facilities; supply items to their facilities
+// and projects; procedures to their project. Other entities are unattributed.
+type CountFilter struct {
+	FacilityIDs []string
+	ProjectIDs  []string
+}
+
+// Admits reports whether a record attributed to facilities and projects is
+// counted under the filter. An empty attribution admits the record in that
+// dimension.
+func (f CountFilter) Admits(facilities, projects []string) bool {
+	return admitsAny(f.FacilityIDs, facilities) && admitsAny(f.ProjectIDs, projects)
+}
+
+func admitsAny(granted, ids []string) bool {
+	if granted == nil || len(ids) == 0 {
+		return true
+	}
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		for _, candidate := range granted {
+			if candidate == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// EntityCounter is implemented by stores that count an entity's records in
+// place instead of listing them, so callers such as dataset cost estimates do
+// not load whole collections. Unknown entities return an error.
+type EntityCounter interface {
+	CountEntities(entity EntityType, filter CountFilter) (int, error)
+}
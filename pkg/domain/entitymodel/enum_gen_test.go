@@ -0,0 +1,52 @@
+package entitymodel
+
+import (
+	"testing"
+
+	"colonycore/testutil"
+)
+
+func TestAllProtocolStatusListsEveryValue(t *testing.T) {
+	want := []ProtocolStatus{
+		ProtocolStatusDraft,
+		ProtocolStatusSubmitted,
+		ProtocolStatusApproved,
+		ProtocolStatusOnHold,
+		ProtocolStatusExpired,
+		ProtocolStatusArchived,
+	}
+	got := AllProtocolStatus()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d protocol statuses, got %v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected schema order %v, got %v", want, got)
+		}
+	}
+	got[0] = "mutated"
+	if AllProtocolStatus()[0] != ProtocolStatusDraft {
+		t.Fatalf("expected AllProtocolStatus to return a fresh slice")
+	}
+}
+
+func TestIsValidProtocolStatus(t *testing.T) {
+	for _, status := range AllProtocolStatus() {
+		if !IsValidProtocolStatus(status) {
+			t.Fatalf("expected %q to be valid", status)
+		}
+	}
+	for _, status := range []ProtocolStatus{"", "retired", "Draft"} {
+		if IsValidProtocolStatus(status) {
+			t.Fatalf("expected %q to be rejected", status)
+		}
+	}
+	testutil.AssertExhaustive(t, AllProtocolStatus(), map[ProtocolStatus]bool{
+		ProtocolStatusDraft:     true,
+		ProtocolStatusSubmitted: true,
+		ProtocolStatusApproved:  true,
+		ProtocolStatusOnHold:    true,
+		ProtocolStatusExpired:   true,
+		ProtocolStatusArchived:  true,
+	})
+}
@@ -3,6 +3,32 @@ package entitymodel
 
 import "time"
 
+// SchemaVersion is the entity-model schema version this package was generated from.
+const SchemaVersion = "1.0.0"
+
+// AdverseEventSeverity enumerates values for adverse_event_severity.
+type AdverseEventSeverity string
+
+const (
+	AdverseEventSeverityMild     AdverseEventSeverity = "mild"
+	AdverseEventSeverityModerate AdverseEventSeverity = "moderate"
+	AdverseEventSeveritySevere   AdverseEventSeverity = "severe"
+)
+
+// AllAdverseEventSeverity returns every adverse_event_severity value in schema order.
+func AllAdverseEventSeverity() []AdverseEventSeverity {
+	return []AdverseEventSeverity{AdverseEventSeverityMild, AdverseEventSeverityModerate, AdverseEventSeveritySevere}
+}
+
+// IsValidAdverseEventSeverity reports whether v is a declared adverse_event_severity value.
+func IsValidAdverseEventSeverity(v AdverseEventSeverity) bool {
+	switch v {
+	case AdverseEventSeverityMild, AdverseEventSeverityModerate, AdverseEventSeveritySevere:
+		return true
+	}
+	return false
+}
+
 // HousingEnvironment enumerates values for housing_environment.
 type HousingEnvironment string
 
@@ -13,6 +39,20 @@ const (
 	HousingEnvironmentHumid       HousingEnvironment = "humid"
 )
 
+// AllHousingEnvironment returns every housing_environment value in schema order.
+func AllHousingEnvironment() []HousingEnvironment {
+	return []HousingEnvironment{HousingEnvironmentAquatic, HousingEnvironmentTerrestrial, HousingEnvironmentArboreal, HousingEnvironmentHumid}
+}
+
+// IsValidHousingEnvironment reports whether v is a declared housing_environment value.
+func IsValidHousingEnvironment(v HousingEnvironment) bool {
+	switch v {
+	case HousingEnvironmentAquatic, HousingEnvironmentTerrestrial, HousingEnvironmentArboreal, HousingEnvironmentHumid:
+		return true
+	}
+	return false
+}
+
 // HousingState enumerates values for housing_state.
 type HousingState string
 
@@ -23,6 +63,20 @@ const (
 	HousingStateDecommissioned HousingState = "decommissioned"
 )
 
+// AllHousingState returns every housing_state value in schema order.
+func AllHousingState() []HousingState {
+	return []HousingState{HousingStateQuarantine, HousingStateActive, HousingStateCleaning, HousingStateDecommissioned}
+}
+
+// IsValidHousingState reports whether v is a declared housing_state value.
+func IsValidHousingState(v HousingState) bool {
+	switch v {
+	case HousingStateQuarantine, HousingStateActive, HousingStateCleaning, HousingStateDecommissioned:
+		return true
+	}
+	return false
+}
+
 // LifecycleStage enumerates values for lifecycle_stage.
 type LifecycleStage string
 
@@ -35,6 +89,44 @@ const (
 	LifecycleStageDeceased    LifecycleStage = "deceased"
 )
 
+// AllLifecycleStage returns every lifecycle_stage value in schema order.
+func AllLifecycleStage() []LifecycleStage {
+	return []LifecycleStage{LifecycleStagePlanned, LifecycleStageEmbryoLarva, LifecycleStageJuvenile, LifecycleStageAdult, LifecycleStageRetired, LifecycleStageDeceased}
+}
+
+// IsValidLifecycleStage reports whether v is a declared lifecycle_stage value.
+func IsValidLifecycleStage(v LifecycleStage) bool {
+	switch v {
+	case LifecycleStagePlanned, LifecycleStageEmbryoLarva, LifecycleStageJuvenile, LifecycleStageAdult, LifecycleStageRetired, LifecycleStageDeceased:
+		return true
+	}
+	return false
+}
+
+// PairingIntent enumerates values for pairing_intent.
+type PairingIntent string
+
+const (
+	PairingIntentMaintenance  PairingIntent = "maintenance"
+	PairingIntentExpansion    PairingIntent = "expansion"
+	PairingIntentExperimental PairingIntent = "experimental"
+	PairingIntentRederivation PairingIntent = "rederivation"
+)
+
+// AllPairingIntent returns every pairing_intent value in schema order.
+func AllPairingIntent() []PairingIntent {
+	return []PairingIntent{PairingIntentMaintenance, PairingIntentExpansion, PairingIntentExperimental, PairingIntentRederivation}
+}
+
+// IsValidPairingIntent reports whether v is a declared pairing_intent value.
+func IsValidPairingIntent(v PairingIntent) bool {
+	switch v {
+	case PairingIntentMaintenance, PairingIntentExpansion, PairingIntentExperimental, PairingIntentRederivation:
+		return true
+	}
+	return false
+}
+
 // PermitStatus enumerates values for permit_status.
 type PermitStatus string
 
@@ -47,6 +139,20 @@ const (
 	PermitStatusArchived  PermitStatus = "archived"
 )
 
+// AllPermitStatus returns every permit_status value in schema order.
+func AllPermitStatus() []PermitStatus {
+	return []PermitStatus{PermitStatusDraft, PermitStatusSubmitted, PermitStatusApproved, PermitStatusOnHold, PermitStatusExpired, PermitStatusArchived}
+}
+
+// IsValidPermitStatus reports whether v is a declared permit_status value.
+func IsValidPermitStatus(v PermitStatus) bool {
+	switch v {
+	case PermitStatusDraft, PermitStatusSubmitted, PermitStatusApproved, PermitStatusOnHold, PermitStatusExpired, PermitStatusArchived:
+		return true
+	}
+	return false
+}
+
 // ProcedureStatus enumerates values for procedure_status.
 type ProcedureStatus string
 
@@ -58,6 +164,20 @@ const (
 	ProcedureStatusFailed     ProcedureStatus = "failed"
 )
 
+// AllProcedureStatus returns every procedure_status value in schema order.
+func AllProcedureStatus() []ProcedureStatus {
+	return []ProcedureStatus{ProcedureStatusScheduled, ProcedureStatusInProgress, ProcedureStatusCompleted, ProcedureStatusCancelled, ProcedureStatusFailed}
+}
+
+// IsValidProcedureStatus reports whether v is a declared procedure_status value.
+func IsValidProcedureStatus(v ProcedureStatus) bool {
+	switch v {
+	case ProcedureStatusScheduled, ProcedureStatusInProgress, ProcedureStatusCompleted, ProcedureStatusCancelled, ProcedureStatusFailed:
+		return true
+	}
+	return false
+}
+
 // ProtocolStatus enumerates values for protocol_status.
 type ProtocolStatus string
 
@@ -70,6 +190,20 @@ const (
 	ProtocolStatusArchived  ProtocolStatus = "archived"
 )
 
+// AllProtocolStatus returns every protocol_status value in schema order.
+func AllProtocolStatus() []ProtocolStatus {
+	return []ProtocolStatus{ProtocolStatusDraft, ProtocolStatusSubmitted, ProtocolStatusApproved, ProtocolStatusOnHold, ProtocolStatusExpired, ProtocolStatusArchived}
+}
+
+// IsValidProtocolStatus reports whether v is a declared protocol_status value.
+func IsValidProtocolStatus(v ProtocolStatus) bool {
+	switch v {
+	case ProtocolStatusDraft, ProtocolStatusSubmitted, ProtocolStatusApproved, ProtocolStatusOnHold, ProtocolStatusExpired, ProtocolStatusArchived:
+		return true
+	}
+	return false
+}
+
 // SampleStatus enumerates values for sample_status.
 type SampleStatus string
 
@@ -80,6 +214,42 @@ const (
 	SampleStatusDisposed  SampleStatus = "disposed"
 )
 
+// AllSampleStatus returns every sample_status value in schema order.
+func AllSampleStatus() []SampleStatus {
+	return []SampleStatus{SampleStatusStored, SampleStatusInTransit, SampleStatusConsumed, SampleStatusDisposed}
+}
+
+// IsValidSampleStatus reports whether v is a declared sample_status value.
+func IsValidSampleStatus(v SampleStatus) bool {
+	switch v {
+	case SampleStatusStored, SampleStatusInTransit, SampleStatusConsumed, SampleStatusDisposed:
+		return true
+	}
+	return false
+}
+
+// SupplyStatus enumerates values for supply_status.
+type SupplyStatus string
+
+const (
+	SupplyStatusActive   SupplyStatus = "active"
+	SupplyStatusConsumed SupplyStatus = "consumed"
+)
+
+// AllSupplyStatus returns every supply_status value in schema order.
+func AllSupplyStatus() []SupplyStatus {
+	return []SupplyStatus{SupplyStatusActive, SupplyStatusConsumed}
+}
+
+// IsValidSupplyStatus reports whether v is a declared supply_status value.
+func IsValidSupplyStatus(v SupplyStatus) bool {
+	switch v {
+	case SupplyStatusActive, SupplyStatusConsumed:
+		return true
+	}
+	return false
+}
+
 // TreatmentStatus enumerates values for treatment_status.
 type TreatmentStatus string
 
@@ -90,6 +260,45 @@ const (
 	TreatmentStatusFlagged    TreatmentStatus = "flagged"
 )
 
+// AllTreatmentStatus returns every treatment_status value in schema order.
+func AllTreatmentStatus() []TreatmentStatus {
+	return []TreatmentStatus{TreatmentStatusPlanned, TreatmentStatusInProgress, TreatmentStatusCompleted, TreatmentStatusFlagged}
+}
+
+// IsValidTreatmentStatus reports whether v is a declared treatment_status value.
+func IsValidTreatmentStatus(v TreatmentStatus) bool {
+	switch v {
+	case TreatmentStatusPlanned, TreatmentStatusInProgress, TreatmentStatusCompleted, TreatmentStatusFlagged:
+		return true
+	}
+	return false
+}
+
+// AdverseEvent is generated from entity-model.json definitions.
+type AdverseEvent struct {
+	AcknowledgedBy *string              `json:"acknowledged_by,omitempty"`
+	Description    string               `json:"description"`
+	ObservedAt     time.Time            `json:"observed_at"`
+	Severity       AdverseEventSeverity `json:"severity"`
+}
+
+// AttachmentRef is generated from entity-model.json definitions.
+type AttachmentRef struct {
+	ContentType string `json:"content_type"`
+	Key         string `json:"key"`
+	SizeBytes   int    `json:"size_bytes"`
+}
+
+// DosagePlan is generated from entity-model.json definitions.
+type DosagePlan struct {
+	DoseAmount      float64 `json:"dose_amount"`
+	DoseUnit        string  `json:"dose_unit"`
+	Drug            string  `json:"drug"`
+	DurationDays    int     `json:"duration_days"`
+	FrequencyPerDay float64 `json:"frequency_per_day"`
+	LegacyText      *string `json:"legacy_text,omitempty"`
+}
+
 // SampleCustodyEvent is generated from entity-model.json definitions.
 type SampleCustodyEvent struct {
 	Actor     string    `json:"actor"`
@@ -108,7 +317,7 @@ type BreedingUnit struct {
 	MaleIDs           []string       `json:"male_ids,omitempty"`
 	Name              string         `json:"name"`
 	PairingAttributes map[string]any `json:"pairing_attributes,omitempty"`
-	PairingIntent     *string        `json:"pairing_intent,omitempty"`
+	PairingIntent     *PairingIntent `json:"pairing_intent,omitempty"`
 	PairingNotes      *string        `json:"pairing_notes,omitempty"`
 	ProtocolID        *string        `json:"protocol_id,omitempty"`
 	StrainID          *string        `json:"strain_id,omitempty"`
@@ -120,41 +329,48 @@ type BreedingUnit struct {
 
 // Cohort is generated from entity-model.json entities.
 type Cohort struct {
-	CreatedAt  time.Time `json:"created_at"`
-	HousingID  *string   `json:"housing_id,omitempty"`
-	ID         string    `json:"id"`
-	Name       string    `json:"name"`
-	ProjectID  *string   `json:"project_id,omitempty"`
-	ProtocolID *string   `json:"protocol_id,omitempty"`
-	Purpose    string    `json:"purpose"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	CreatedAt                 time.Time `json:"created_at"`
+	CreatedFromBreedingUnitID *string   `json:"created_from_breeding_unit_id,omitempty"`
+	HousingID                 *string   `json:"housing_id,omitempty"`
+	ID                        string    `json:"id"`
+	Name                      string    `json:"name"`
+	ProjectID                 *string   `json:"project_id,omitempty"`
+	ProtocolID                *string   `json:"protocol_id,omitempty"`
+	Purpose                   string    `json:"purpose"`
+	Species                   *string   `json:"species,omitempty"`
+	UpdatedAt                 time.Time `json:"updated_at"`
 }
 
 // Facility is generated from entity-model.json entities.
 type Facility struct {
-	AccessPolicy         string         `json:"access_policy"`
-	Code                 string         `json:"code"`
-	CreatedAt            time.Time      `json:"created_at"`
-	EnvironmentBaselines map[string]any `json:"environment_baselines,omitempty"`
-	HousingUnitIDs       []string       `json:"housing_unit_ids,omitempty"`
-	ID                   string         `json:"id"`
-	Name                 string         `json:"name"`
-	ProjectIDs           []string       `json:"project_ids,omitempty"`
-	UpdatedAt            time.Time      `json:"updated_at"`
-	Zone                 string         `json:"zone"`
+	AccessPolicy              string              `json:"access_policy"`
+	AccreditationExpiresAt    *time.Time          `json:"accreditation_expires_at,omitempty"`
+	AccreditationNumber       *string             `json:"accreditation_number,omitempty"`
+	Code                      string              `json:"code"`
+	CreatedAt                 time.Time           `json:"created_at"`
+	DefaultHousingEnvironment *HousingEnvironment `json:"default_housing_environment,omitempty"`
+	EnvironmentBaselines      map[string]any      `json:"environment_baselines,omitempty"`
+	HousingUnitIDs            []string            `json:"housing_unit_ids,omitempty"`
+	ID                        string              `json:"id"`
+	Name                      string              `json:"name"`
+	ProjectIDs                []string            `json:"project_ids,omitempty"`
+	Timezone                  *string             `json:"timezone,omitempty"`
+	UpdatedAt                 time.Time           `json:"updated_at"`
+	Zone                      string              `json:"zone"`
 }
 
 // GenotypeMarker is generated from entity-model.json entities.
 type GenotypeMarker struct {
-	Alleles        []string  `json:"alleles"`
-	AssayMethod    string    `json:"assay_method"`
-	CreatedAt      time.Time `json:"created_at"`
-	ID             string    `json:"id"`
-	Interpretation string    `json:"interpretation"`
-	Locus          string    `json:"locus"`
-	Name           string    `json:"name"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	Version        string    `json:"version"`
+	Alleles        []string       `json:"alleles"`
+	AssayMethod    string         `json:"assay_method"`
+	Attributes     map[string]any `json:"attributes,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	ID             string         `json:"id"`
+	Interpretation string         `json:"interpretation"`
+	Locus          string         `json:"locus"`
+	Name           string         `json:"name"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	Version        string         `json:"version"`
 }
 
 // HousingUnit is generated from entity-model.json entities.
@@ -167,6 +383,7 @@ type HousingUnit struct {
 	Name        string             `json:"name"`
 	State       HousingState       `json:"state"`
 	UpdatedAt   time.Time          `json:"updated_at"`
+	Version     int                `json:"version"`
 }
 
 // Line is generated from entity-model.json entities.
@@ -182,21 +399,30 @@ type Line struct {
 	ID                 string         `json:"id"`
 	Name               string         `json:"name"`
 	Origin             string         `json:"origin"`
+	Tags               []string       `json:"tags,omitempty"`
 	UpdatedAt          time.Time      `json:"updated_at"`
 }
 
 // Observation is generated from entity-model.json entities.
 type Observation struct {
-	CohortID    *string        `json:"cohort_id,omitempty"`
-	CreatedAt   time.Time      `json:"created_at"`
-	Data        map[string]any `json:"data,omitempty"`
-	ID          string         `json:"id"`
-	Notes       *string        `json:"notes,omitempty"`
-	Observer    string         `json:"observer"`
-	OrganismID  *string        `json:"organism_id,omitempty"`
-	ProcedureID *string        `json:"procedure_id,omitempty"`
-	RecordedAt  time.Time      `json:"recorded_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	Attachments []AttachmentRef `json:"attachments,omitempty"`
+	Category    *string         `json:"category,omitempty"`
+	CohortID    *string         `json:"cohort_id,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Data        map[string]any  `json:"data,omitempty"`
+	ID          string          `json:"id"`
+	Length      *float64        `json:"length,omitempty"`
+	Notes       *string         `json:"notes,omitempty"`
+	Observer    string          `json:"observer"`
+	OrganismID  *string         `json:"organism_id,omitempty"`
+	ProcedureID *string         `json:"procedure_id,omitempty"`
+	RecordedAt  time.Time       `json:"recorded_at"`
+	RecordedBy  *string         `json:"recorded_by,omitempty"`
+	ReviewedAt  *time.Time      `json:"reviewed_at,omitempty"`
+	ReviewedBy  *string         `json:"reviewed_by,omitempty"`
+	Temperature *float64        `json:"temperature,omitempty"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	Weight      *float64        `json:"weight,omitempty"`
 }
 
 // Organism is generated from entity-model.json entities.
@@ -216,6 +442,7 @@ type Organism struct {
 	Stage      LifecycleStage `json:"stage"`
 	StrainID   *string        `json:"strain_id,omitempty"`
 	UpdatedAt  time.Time      `json:"updated_at"`
+	Version    int            `json:"version"`
 }
 
 // Permit is generated from entity-model.json entities.
@@ -225,6 +452,7 @@ type Permit struct {
 	CreatedAt         time.Time    `json:"created_at"`
 	FacilityIDs       []string     `json:"facility_ids"`
 	ID                string       `json:"id"`
+	IssueDate         time.Time    `json:"issue_date"`
 	Notes             *string      `json:"notes,omitempty"`
 	PermitNumber      string       `json:"permit_number"`
 	ProtocolIDs       []string     `json:"protocol_ids"`
@@ -236,33 +464,35 @@ type Permit struct {
 
 // Procedure is generated from entity-model.json entities.
 type Procedure struct {
-	CohortID       *string         `json:"cohort_id,omitempty"`
-	CreatedAt      time.Time       `json:"created_at"`
-	ID             string          `json:"id"`
-	Name           string          `json:"name"`
-	ObservationIDs []string        `json:"observation_ids,omitempty"`
-	OrganismIDs    []string        `json:"organism_ids,omitempty"`
-	ProjectID      *string         `json:"project_id,omitempty"`
-	ProtocolID     string          `json:"protocol_id"`
-	ScheduledAt    time.Time       `json:"scheduled_at"`
-	Status         ProcedureStatus `json:"status"`
-	TreatmentIDs   []string        `json:"treatment_ids,omitempty"`
-	UpdatedAt      time.Time       `json:"updated_at"`
+	CancellationReason *string         `json:"cancellation_reason,omitempty"`
+	CohortID           *string         `json:"cohort_id,omitempty"`
+	CreatedAt          time.Time       `json:"created_at"`
+	ID                 string          `json:"id"`
+	Name               string          `json:"name"`
+	ObservationIDs     []string        `json:"observation_ids,omitempty"`
+	OrganismIDs        []string        `json:"organism_ids,omitempty"`
+	ProjectID          *string         `json:"project_id,omitempty"`
+	ProtocolID         string          `json:"protocol_id"`
+	ScheduledAt        time.Time       `json:"scheduled_at"`
+	Status             ProcedureStatus `json:"status"`
+	TreatmentIDs       []string        `json:"treatment_ids,omitempty"`
+	UpdatedAt          time.Time       `json:"updated_at"`
 }
 
 // Project is generated from entity-model.json entities.
 type Project struct {
-	Code          string    `json:"code"`
-	CreatedAt     time.Time `json:"created_at"`
-	Description   *string   `json:"description,omitempty"`
-	FacilityIDs   []string  `json:"facility_ids"`
-	ID            string    `json:"id"`
-	OrganismIDs   []string  `json:"organism_ids,omitempty"`
-	ProcedureIDs  []string  `json:"procedure_ids,omitempty"`
-	ProtocolIDs   []string  `json:"protocol_ids,omitempty"`
-	SupplyItemIDs []string  `json:"supply_item_ids,omitempty"`
-	Title         string    `json:"title"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ClosedAt      *time.Time `json:"closed_at,omitempty"`
+	Code          string     `json:"code"`
+	CreatedAt     time.Time  `json:"created_at"`
+	Description   *string    `json:"description,omitempty"`
+	FacilityIDs   []string   `json:"facility_ids"`
+	ID            string     `json:"id"`
+	OrganismIDs   []string   `json:"organism_ids,omitempty"`
+	ProcedureIDs  []string   `json:"procedure_ids,omitempty"`
+	ProtocolIDs   []string   `json:"protocol_ids,omitempty"`
+	SupplyItemIDs []string   `json:"supply_item_ids,omitempty"`
+	Title         string     `json:"title"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
 // Protocol is generated from entity-model.json entities.
@@ -272,47 +502,53 @@ type Protocol struct {
 	Description *string        `json:"description,omitempty"`
 	ID          string         `json:"id"`
 	MaxSubjects int            `json:"max_subjects"`
+	ReviewerIDs []string       `json:"reviewer_ids,omitempty"`
 	Status      ProtocolStatus `json:"status"`
 	Title       string         `json:"title"`
 	UpdatedAt   time.Time      `json:"updated_at"`
+	Version     int            `json:"version"`
 }
 
 // Sample is generated from entity-model.json entities.
 type Sample struct {
-	AssayType       string               `json:"assay_type"`
-	Attributes      map[string]any       `json:"attributes,omitempty"`
-	ChainOfCustody  []SampleCustodyEvent `json:"chain_of_custody"`
-	CohortID        *string              `json:"cohort_id,omitempty"`
-	CollectedAt     time.Time            `json:"collected_at"`
-	CreatedAt       time.Time            `json:"created_at"`
-	FacilityID      string               `json:"facility_id"`
-	ID              string               `json:"id"`
-	Identifier      string               `json:"identifier"`
-	OrganismID      *string              `json:"organism_id,omitempty"`
-	SourceType      string               `json:"source_type"`
-	Status          SampleStatus         `json:"status"`
-	StorageLocation string               `json:"storage_location"`
-	UpdatedAt       time.Time            `json:"updated_at"`
+	AssayType          string               `json:"assay_type"`
+	Attributes         map[string]any       `json:"attributes,omitempty"`
+	ChainOfCustody     []SampleCustodyEvent `json:"chain_of_custody"`
+	CohortID           *string              `json:"cohort_id,omitempty"`
+	CollectedAt        time.Time            `json:"collected_at"`
+	CollectedBy        string               `json:"collected_by"`
+	CollectionProtocol *string              `json:"collection_protocol,omitempty"`
+	CreatedAt          time.Time            `json:"created_at"`
+	FacilityID         string               `json:"facility_id"`
+	ID                 string               `json:"id"`
+	Identifier         string               `json:"identifier"`
+	OrganismID         *string              `json:"organism_id,omitempty"`
+	SourceType         string               `json:"source_type"`
+	Status             SampleStatus         `json:"status"`
+	StorageLocation    string               `json:"storage_location"`
+	UpdatedAt          time.Time            `json:"updated_at"`
 }
 
 // Strain is generated from entity-model.json entities.
 type Strain struct {
-	Code              string     `json:"code"`
-	CreatedAt         time.Time  `json:"created_at"`
-	Description       *string    `json:"description,omitempty"`
-	Generation        *string    `json:"generation,omitempty"`
-	GenotypeMarkerIDs []string   `json:"genotype_marker_ids,omitempty"`
-	ID                string     `json:"id"`
-	LineID            string     `json:"line_id"`
-	Name              string     `json:"name"`
-	RetiredAt         *time.Time `json:"retired_at,omitempty"`
-	RetirementReason  *string    `json:"retirement_reason,omitempty"`
-	UpdatedAt         time.Time  `json:"updated_at"`
+	Attributes        map[string]any `json:"attributes,omitempty"`
+	Code              string         `json:"code"`
+	CreatedAt         time.Time      `json:"created_at"`
+	Description       *string        `json:"description,omitempty"`
+	Generation        *string        `json:"generation,omitempty"`
+	GenotypeMarkerIDs []string       `json:"genotype_marker_ids,omitempty"`
+	ID                string         `json:"id"`
+	LineID            string         `json:"line_id"`
+	Name              string         `json:"name"`
+	RetiredAt         *time.Time     `json:"retired_at,omitempty"`
+	RetirementReason  *string        `json:"retirement_reason,omitempty"`
+	UpdatedAt         time.Time      `json:"updated_at"`
 }
 
 // SupplyItem is generated from entity-model.json entities.
 type SupplyItem struct {
 	Attributes     map[string]any `json:"attributes,omitempty"`
+	Category       *string        `json:"category,omitempty"`
 	CreatedAt      time.Time      `json:"created_at"`
 	Description    *string        `json:"description,omitempty"`
 	ExpiresAt      *time.Time     `json:"expires_at,omitempty"`
@@ -324,6 +560,7 @@ type SupplyItem struct {
 	QuantityOnHand int            `json:"quantity_on_hand"`
 	ReorderLevel   int            `json:"reorder_level"`
 	SKU            string         `json:"sku"`
+	Status         SupplyStatus   `json:"status"`
 	Unit           string         `json:"unit"`
 	UpdatedAt      time.Time      `json:"updated_at"`
 }
@@ -331,10 +568,10 @@ type SupplyItem struct {
 // Treatment is generated from entity-model.json entities.
 type Treatment struct {
 	AdministrationLog []string        `json:"administration_log,omitempty"`
-	AdverseEvents     []string        `json:"adverse_events,omitempty"`
+	AdverseEvents     []AdverseEvent  `json:"adverse_events,omitempty"`
 	CohortIDs         []string        `json:"cohort_ids,omitempty"`
 	CreatedAt         time.Time       `json:"created_at"`
-	DosagePlan        string          `json:"dosage_plan"`
+	DosagePlan        DosagePlan      `json:"dosage_plan"`
 	ID                string          `json:"id"`
 	Name              string          `json:"name"`
 	OrganismIDs       []string        `json:"organism_ids,omitempty"`
@@ -342,3 +579,157 @@ type Treatment struct {
 	Status            TreatmentStatus `json:"status"`
 	UpdatedAt         time.Time       `json:"updated_at"`
 }
+
+// FieldUnits returns the measurement unit declared for entity properties,
+// keyed by entity name and then JSON property name. Every entity is present,
+// with an empty map when none of its properties declare a unit. Each call
+// returns a fresh map that callers may modify.
+func FieldUnits() map[string]map[string]string {
+	return map[string]map[string]string{
+		"BreedingUnit":   {},
+		"Cohort":         {},
+		"Facility":       {},
+		"GenotypeMarker": {},
+		"HousingUnit": {
+			"capacity": "count",
+		},
+		"Line": {},
+		"Observation": {
+			"length":      "mm",
+			"temperature": "celsius",
+			"weight":      "g",
+		},
+		"Organism":  {},
+		"Permit":    {},
+		"Procedure": {},
+		"Project":   {},
+		"Protocol": {
+			"max_subjects": "count",
+		},
+		"Sample":     {},
+		"Strain":     {},
+		"SupplyItem": {},
+		"Treatment":  {},
+	}
+}
+
+// AuditFields returns the JSON property names annotated with x-audit for the
+// named entity, sorted by name. Unknown entities and entities without audited
+// properties return nil. Each call returns a fresh slice.
+func AuditFields(entity string) []string {
+	switch entity {
+	case "Organism":
+		return []string{"stage"}
+	case "Permit":
+		return []string{"status"}
+	case "Protocol":
+		return []string{"status"}
+	}
+	return nil
+}
+
+// NaturalKey is a natural key declared in the entity model: the JSON
+// property names that together identify a record and the scope within
+// which they must be unique.
+type NaturalKey struct {
+	Fields []string
+	Scope  string
+}
+
+// NaturalKeys returns the natural keys declared for each entity, keyed by
+// entity name. Every entity is present, with no keys when it declares none.
+// Each call returns a fresh map that callers may modify.
+func NaturalKeys() map[string][]NaturalKey {
+	return map[string][]NaturalKey{
+		"BreedingUnit": {
+			{Fields: []string{"name", "line_id"}, Scope: "line"},
+		},
+		"Cohort": {
+			{Fields: []string{"project_id", "name"}, Scope: "project"},
+		},
+		"Facility": {
+			{Fields: []string{"code"}, Scope: "global"},
+		},
+		"GenotypeMarker": {
+			{Fields: []string{"name", "version"}, Scope: "global"},
+		},
+		"HousingUnit": {
+			{Fields: []string{"facility_id", "name"}, Scope: "facility"},
+		},
+		"Line": {
+			{Fields: []string{"code"}, Scope: "global"},
+		},
+		"Observation": {
+			{Fields: []string{"procedure_id", "recorded_at", "observer"}, Scope: "procedure"},
+		},
+		"Organism": {
+			{Fields: []string{"species", "line", "name"}, Scope: "species"},
+		},
+		"Permit": {
+			{Fields: []string{"authority", "permit_number"}, Scope: "authority"},
+		},
+		"Procedure": {
+			{Fields: []string{"protocol_id", "name", "scheduled_at"}, Scope: "protocol"},
+		},
+		"Project": {
+			{Fields: []string{"code"}, Scope: "global"},
+		},
+		"Protocol": {
+			{Fields: []string{"code"}, Scope: "global"},
+		},
+		"Sample": {
+			{Fields: []string{"facility_id", "identifier"}, Scope: "facility"},
+		},
+		"Strain": {
+			{Fields: []string{"line_id", "code"}, Scope: "line"},
+		},
+		"SupplyItem": {
+			{Fields: []string{"sku"}, Scope: "global"},
+		},
+		"Treatment": {
+			{Fields: []string{"procedure_id", "name"}, Scope: "procedure"},
+		},
+	}
+}
+
+// DeclaredProperties returns the JSON property names declared for the named
+// entity, sorted by name, when its schema sets additionalProperties to false.
+// Unknown entities and entities open to additional properties return nil.
+// Each call returns a fresh slice.
+func DeclaredProperties(entity string) []string {
+	switch entity {
+	case "BreedingUnit":
+		return []string{"created_at", "female_ids", "housing_id", "id", "line_id", "male_ids", "name", "pairing_attributes", "pairing_intent", "pairing_notes", "protocol_id", "strain_id", "strategy", "target_line_id", "target_strain_id", "updated_at"}
+	case "Cohort":
+		return []string{"created_at", "created_from_breeding_unit_id", "housing_id", "id", "name", "project_id", "protocol_id", "purpose", "species", "updated_at"}
+	case "Facility":
+		return []string{"access_policy", "accreditation_expires_at", "accreditation_number", "code", "created_at", "default_housing_environment", "environment_baselines", "housing_unit_ids", "id", "name", "project_ids", "timezone", "updated_at", "zone"}
+	case "GenotypeMarker":
+		return []string{"alleles", "assay_method", "attributes", "created_at", "id", "interpretation", "locus", "name", "updated_at", "version"}
+	case "HousingUnit":
+		return []string{"capacity", "created_at", "environment", "facility_id", "id", "name", "state", "updated_at", "version"}
+	case "Line":
+		return []string{"code", "created_at", "default_attributes", "deprecated_at", "deprecation_reason", "description", "extension_overrides", "genotype_marker_ids", "id", "name", "origin", "tags", "updated_at"}
+	case "Observation":
+		return []string{"attachments", "category", "cohort_id", "created_at", "data", "id", "length", "notes", "observer", "organism_id", "procedure_id", "recorded_at", "recorded_by", "reviewed_at", "reviewed_by", "temperature", "updated_at", "weight"}
+	case "Organism":
+		return []string{"attributes", "cohort_id", "created_at", "housing_id", "id", "line", "line_id", "name", "parent_ids", "project_id", "protocol_id", "species", "stage", "strain_id", "updated_at", "version"}
+	case "Permit":
+		return []string{"allowed_activities", "authority", "created_at", "facility_ids", "id", "issue_date", "notes", "permit_number", "protocol_ids", "status", "updated_at", "valid_from", "valid_until"}
+	case "Procedure":
+		return []string{"cancellation_reason", "cohort_id", "created_at", "id", "name", "observation_ids", "organism_ids", "project_id", "protocol_id", "scheduled_at", "status", "treatment_ids", "updated_at"}
+	case "Project":
+		return []string{"closed_at", "code", "created_at", "description", "facility_ids", "id", "organism_ids", "procedure_ids", "protocol_ids", "supply_item_ids", "title", "updated_at"}
+	case "Protocol":
+		return []string{"code", "created_at", "description", "id", "max_subjects", "reviewer_ids", "status", "title", "updated_at", "version"}
+	case "Sample":
+		return []string{"assay_type", "attributes", "chain_of_custody", "cohort_id", "collected_at", "collected_by", "collection_protocol", "created_at", "facility_id", "id", "identifier", "organism_id", "source_type", "status", "storage_location", "updated_at"}
+	case "Strain":
+		return []string{"attributes", "code", "created_at", "description", "generation", "genotype_marker_ids", "id", "line_id", "name", "retired_at", "retirement_reason", "updated_at"}
+	case "SupplyItem":
+		return []string{"attributes", "category", "created_at", "description", "expires_at", "facility_ids", "id", "lot_number", "name", "project_ids", "quantity_on_hand", "reorder_level", "sku", "status", "unit", "updated_at"}
+	case "Treatment":
+		return []string{"administration_log", "adverse_events", "cohort_ids", "created_at", "dosage_plan", "id", "name", "organism_ids", "procedure_id", "status", "updated_at"}
+	}
+	return nil
+}
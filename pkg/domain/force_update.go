@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrForceUpdateUnattributed is returned by force updates whose context does
+// not carry both an actor and a reason via WithForceUpdate.
+var ErrForceUpdateUnattributed = errors.New("force update requires an actor and a reason")
+
+// ForceUpdate attributes an administrative override of a version conflict.
+type ForceUpdate struct {
+	Actor  string
+	Reason string
+}
+
+type forceUpdateKey struct{}
+
+// WithForceUpdate returns a context authorising Transaction.UpdateOrganismForce
+// on behalf of actor for reason. Stores read it from the context passed to
+// RunInTransaction. It does not check permissions: callers must gate force
+// updates behind an access policy before attaching it.
+func WithForceUpdate(ctx context.Context, actor, reason string) context.Context {
+	return context.WithValue(ctx, forceUpdateKey{}, ForceUpdate{Actor: actor, Reason: reason})
+}
+
+// ForceUpdateFromContext returns the override attached by WithForceUpdate. It
+// reports false unless both the actor and the reason are non-blank.
+func ForceUpdateFromContext(ctx context.Context) (ForceUpdate, bool) {
+	if ctx == nil {
+		return ForceUpdate{}, false
+	}
+	override, _ := ctx.Value(forceUpdateKey{}).(ForceUpdate)
+	if strings.TrimSpace(override.Actor) == "" || strings.TrimSpace(override.Reason) == "" {
+		return ForceUpdate{}, false
+	}
+	return override, true
+}
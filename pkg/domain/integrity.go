@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrReferentialIntegrity reports a record whose foreign-key style field names
+// a related record that does not exist. Callers can recover it with errors.As
+// to build field-level messages.
+type ErrReferentialIntegrity struct {
+	Entity           EntityType
+	Field            string
+	ReferencedEntity EntityType
+	ReferencedID     string
+}
+
+// Error implements error.
+func (e ErrReferentialIntegrity) Error() string {
+	return fmt.Sprintf("%s %q not found for %s", entityLabel(e.ReferencedEntity), e.ReferencedID, entityLabel(e.Entity))
+}
+
+// entityLabel renders an entity type such as "housing_unit" as "housing unit".
+func entityLabel(e EntityType) string {
+	return strings.ReplaceAll(string(e), "_", " ")
+}
+
+// Reference names a record field that points at another entity, as reported
+// by TransactionView.ReferencesTo.
+type Reference struct {
+	Entity EntityType
+	ID     string
+	Field  string
+}
+
+// ErrVersionConflict reports an update whose expected version no longer
+// matches the stored record, meaning another writer changed it first. Callers
+// can recover it with errors.As to reload the record and retry.
+type ErrVersionConflict struct {
+	Entity   EntityType
+	ID       string
+	Expected int
+	Actual   int
+}
+
+// Error implements error.
+func (e ErrVersionConflict) Error() string {
+	return fmt.Sprintf("%s %q version conflict: expected %d, found %d", entityLabel(e.Entity), e.ID, e.Expected, e.Actual)
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrReferentialIntegrity(t *testing.T) {
+	err := fmt.Errorf("create: %w", ErrReferentialIntegrity{
+		Entity:           EntityHousingUnit,
+		Field:            "facility_id",
+		ReferencedEntity: EntityFacility,
+		ReferencedID:     "F1",
+	})
+	var ri ErrReferentialIntegrity
+	if !errors.As(err, &ri) {
+		t.Fatalf("expected errors.As to recover ErrReferentialIntegrity from %v", err)
+	}
+	if ri.Field != "facility_id" || ri.ReferencedEntity != EntityFacility {
+		t.Fatalf("unexpected fields: %+v", ri)
+	}
+	if got, want := ri.Error(), `facility "F1" not found for housing unit`; got != want {
+		t.Fatalf("unexpected message %q, want %q", got, want)
+	}
+}
+
+func TestErrVersionConflict(t *testing.T) {
+	err := fmt.Errorf("update: %w", ErrVersionConflict{Entity: EntityHousingUnit, ID: "H1", Expected: 2, Actual: 3})
+	var conflict ErrVersionConflict
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected errors.As to recover ErrVersionConflict from %v", err)
+	}
+	if got, want := conflict.Error(), `housing unit "H1" version conflict: expected 2, found 3`; got != want {
+		t.Fatalf("unexpected message %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+// NaturalKeyScope names the set of records within which a natural key must be
+// unique, matching the scope declared for the key in the entity model.
+type NaturalKeyScope string
+
+// Natural key scopes that stores resolve for uniqueness checks.
+const (
+	ScopeGlobal   NaturalKeyScope = "global"
+	ScopeFacility NaturalKeyScope = "facility"
+	ScopeProject  NaturalKeyScope = "project"
+)
+
+// ScopeResolver returns the ID of the scope record belongs to for scope, so a
+// natural key only conflicts with records resolved to the same scope ID. It
+// reports false when the record has no such scope, and the key is then not
+// checked. Records are passed by value, for example a Sample.
+type ScopeResolver func(record any, scope NaturalKeyScope) (string, bool)
+
+// DefaultScopeResolver resolves the global scope to "" for every record, the
+// facility scope to the facility_id of housing units and samples, and the
+// project scope to the project_id of cohorts, organisms, and procedures that
+// have one. Every other combination is unresolved.
+func DefaultScopeResolver(record any, scope NaturalKeyScope) (string, bool) {
+	switch scope {
+	case ScopeGlobal:
+		return "", true
+	case ScopeFacility:
+		switch r := record.(type) {
+		case HousingUnit:
+			return r.FacilityID, r.FacilityID != ""
+		case Sample:
+			return r.FacilityID, r.FacilityID != ""
+		}
+	case ScopeProject:
+		switch r := record.(type) {
+		case Cohort:
+			return optionalScope(r.ProjectID)
+		case Organism:
+			return optionalScope(r.ProjectID)
+		case Procedure:
+			return optionalScope(r.ProjectID)
+		}
+	}
+	return "", false
+}
+
+func optionalScope(id *string) (string, bool) {
+	if id == nil || *id == "" {
+		return "", false
+	}
+	return *id, true
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestDefaultScopeResolver(t *testing.T) {
+	project := "project-1"
+	cases := []struct {
+		name   string
+		record any
+		scope  NaturalKeyScope
+		want   string
+		ok     bool
+	}{
+		{"global", Facility{}, ScopeGlobal, "", true},
+		{"sample facility", Sample{Sample: entitymodel.Sample{FacilityID: "fac-1"}}, ScopeFacility, "fac-1", true},
+		{"housing facility", HousingUnit{HousingUnit: entitymodel.HousingUnit{FacilityID: "fac-2"}}, ScopeFacility, "fac-2", true},
+		{"sample without facility", Sample{}, ScopeFacility, "", false},
+		{"cohort project", Cohort{Cohort: entitymodel.Cohort{ProjectID: &project}}, ScopeProject, project, true},
+		{"cohort without project", Cohort{}, ScopeProject, "", false},
+		{"organism project", Organism{Organism: entitymodel.Organism{ProjectID: &project}}, ScopeProject, project, true},
+		{"procedure project", Procedure{Procedure: entitymodel.Procedure{ProjectID: &project}}, ScopeProject, project, true},
+		{"facility has no facility scope", Facility{}, ScopeFacility, "", false},
+		{"sample has no project scope", Sample{}, ScopeProject, "", false},
+		{"unknown scope", Sample{Sample: entitymodel.Sample{FacilityID: "fac-1"}}, NaturalKeyScope("line"), "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := DefaultScopeResolver(tc.record, tc.scope)
+			if got != tc.want || ok != tc.ok {
+				t.Fatalf("DefaultScopeResolver = (%q, %v), want (%q, %v)", got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
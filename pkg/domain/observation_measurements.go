@@ -0,0 +1,54 @@
+package domain
+
+// Canonical observation Data keys populated from the typed measurement fields.
+const (
+	ObservationDataWeight      = "weight"
+	ObservationDataLength      = "length"
+	ObservationDataTemperature = "temperature"
+)
+
+// ApplyMeasurementData copies the non-nil Weight, Length, and Temperature
+// fields into the observation Data payload under their canonical keys. The
+// typed fields win over existing Data entries with the same key.
+func (o *Observation) ApplyMeasurementData() error {
+	measurements := map[string]*float64{
+		ObservationDataWeight:      o.Weight,
+		ObservationDataLength:      o.Length,
+		ObservationDataTemperature: o.Temperature,
+	}
+	data := o.ObservationData()
+	changed := false
+	for key, value := range measurements {
+		if value == nil {
+			continue
+		}
+		if data == nil {
+			data = make(map[string]any, len(measurements))
+		}
+		data[key] = *value
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return o.ApplyObservationData(data)
+}
+
+// ObservationFilter narrows observation listings such as
+// TransactionView.ListObservationsByOrganism.
+type ObservationFilter func(Observation) bool
+
+// ObservationHasWeight matches observations that record a weight.
+func ObservationHasWeight() ObservationFilter {
+	return func(o Observation) bool { return o.Weight != nil }
+}
+
+// MatchesObservationFilters reports whether o satisfies every filter.
+func MatchesObservationFilters(o Observation, filters ...ObservationFilter) bool {
+	for _, filter := range filters {
+		if filter != nil && !filter(o) {
+			return false
+		}
+	}
+	return true
+}
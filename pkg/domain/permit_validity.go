@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// IsPermitActive reports whether p is approved and at falls within
+// [ValidFrom-skew, ValidUntil+skew]. The skew absorbs clock drift between the
+// host and the issuing authority, so a permit that expired moments ago is
+// still treated as active. A zero ValidFrom or ValidUntil leaves that side of
+// the window open, and a negative skew counts as zero. All comparisons are
+// made in UTC.
+func IsPermitActive(p Permit, at time.Time, skew time.Duration) bool {
+	if p.Status != PermitStatusApproved {
+		return false
+	}
+	if skew < 0 {
+		skew = 0
+	}
+	at = at.UTC()
+	if !p.ValidFrom.IsZero() && at.Before(p.ValidFrom.UTC().Add(-skew)) {
+		return false
+	}
+	if !p.ValidUntil.IsZero() && at.After(p.ValidUntil.UTC().Add(skew)) {
+		return false
+	}
+	return true
+}
+
+// PermitSkewView is implemented by rule views whose store is configured with
+// a permit clock-skew tolerance, and by stores that report the tolerance
+// outside a transaction.
+type PermitSkewView interface {
+	PermitSkew() time.Duration
+}
+
+// PermitSkew returns the permit clock-skew tolerance configured for view, or
+// zero when the view does not carry one. Rules checking active permits pass
+// it to IsPermitActive.
+func PermitSkew(view RuleView) time.Duration {
+	if v, ok := view.(PermitSkewView); ok {
+		return v.PermitSkew()
+	}
+	return 0
+}
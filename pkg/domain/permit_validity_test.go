@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestIsPermitActiveBoundaries(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+	permit := Permit{Permit: entitymodel.Permit{Status: PermitStatusApproved, ValidFrom: from, ValidUntil: until}}
+	skew := 5 * time.Minute
+
+	cases := []struct {
+		name string
+		at   time.Time
+		skew time.Duration
+		want bool
+	}{
+		{"at valid_from", from, 0, true},
+		{"just before valid_from", from.Add(-time.Second), 0, false},
+		{"at valid_until", until, 0, true},
+		{"just expired", until.Add(time.Second), 0, false},
+		{"early within skew", from.Add(-skew), skew, true},
+		{"early beyond skew", from.Add(-skew - time.Second), skew, false},
+		{"just expired within skew", until.Add(time.Second), skew, true},
+		{"expired at skew edge", until.Add(skew), skew, true},
+		{"expired beyond skew", until.Add(skew + time.Second), skew, false},
+		{"negative skew counts as zero", until.Add(time.Second), -skew, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsPermitActive(permit, tc.at, tc.skew); got != tc.want {
+				t.Fatalf("IsPermitActive(%s, %s) = %v, want %v", tc.at, tc.skew, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsPermitActiveComparesInUTC(t *testing.T) {
+	until := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	permit := Permit{Permit: entitymodel.Permit{Status: PermitStatusApproved, ValidUntil: until}}
+	tokyo := time.FixedZone("JST", 9*60*60)
+	// 20:00 JST is 11:00 UTC, an hour before expiry despite the later wall clock.
+	if !IsPermitActive(permit, time.Date(2026, 6, 30, 20, 0, 0, 0, tokyo), 0) {
+		t.Fatalf("expected permit active at 11:00 UTC")
+	}
+	if IsPermitActive(permit, time.Date(2026, 6, 30, 22, 0, 0, 0, tokyo), 0) {
+		t.Fatalf("expected permit expired at 13:00 UTC")
+	}
+}
+
+func TestIsPermitActiveRequiresApprovedStatus(t *testing.T) {
+	permit := Permit{Permit: entitymodel.Permit{Status: PermitStatusOnHold}}
+	if IsPermitActive(permit, time.Now(), time.Hour) {
+		t.Fatalf("expected on-hold permit to be inactive")
+	}
+	permit.Status = PermitStatusApproved
+	if !IsPermitActive(permit, time.Now(), 0) {
+		t.Fatalf("expected approved permit without a window to be active")
+	}
+}
+
+type skewRuleView struct {
+	RuleView
+	skew time.Duration
+}
+
+func (v skewRuleView) PermitSkew() time.Duration { return v.skew }
+
+func TestPermitSkewReadsConfiguredView(t *testing.T) {
+	if got := PermitSkew(skewRuleView{skew: time.Minute}); got != time.Minute {
+		t.Fatalf("expected configured skew, got %s", got)
+	}
+	if got := PermitSkew(nil); got != 0 {
+		t.Fatalf("expected zero skew for a view without one, got %s", got)
+	}
+}
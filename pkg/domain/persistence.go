@@ -1,20 +1,45 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Transaction exposes the domain operations that a persistence implementation
 // must support within an atomic scope.
+//
+// Update mutators receive the record with its derived reference lists (such as
+// Facility.HousingUnitIDs, Procedure.ObservationIDs, and Project.OrganismIDs)
+// populated from current state. Those lists are recomputed after the mutator
+// returns, so changes a mutator makes to them are discarded.
+//
+// Organisms, protocols, and housing units carry a Version that the store sets
+// to 1 on create and increments on every update; mutator changes to it are
+// ignored. UpdateOrganismIfVersion fails with ErrVersionConflict when the
+// stored version differs from expectedVersion; a zero expectedVersion skips
+// the check, making it equivalent to UpdateOrganism. UpdateOrganismForce applies the update whatever the stored
+// version and records it as ActionForceUpdate with the override on the
+// change's Override; it fails with ErrForceUpdateUnattributed unless the
+// transaction context carries an actor and reason from WithForceUpdate. Force
+// updates bypass conflict detection, so callers must gate them behind an
+// access policy.
 type Transaction interface {
 	Snapshot() TransactionView
 	CreateOrganism(Organism) (Organism, error)
 	UpdateOrganism(id string, mutator func(*Organism) error) (Organism, error)
+	UpdateOrganismIfVersion(id string, expectedVersion int, mutator func(*Organism) error) (Organism, error)
+	UpdateOrganismForce(id string, mutator func(*Organism) error) (Organism, error)
 	DeleteOrganism(id string) error
 	CreateCohort(Cohort) (Cohort, error)
 	UpdateCohort(id string, mutator func(*Cohort) error) (Cohort, error)
 	DeleteCohort(id string) error
+	DeleteCohortCascade(id string) error
+	AddOrganismToCohort(organismID, cohortID string) (Organism, error)
+	MoveOrganismToProject(organismID, targetProjectID string) (Organism, error)
 	CreateHousingUnit(HousingUnit) (HousingUnit, error)
 	UpdateHousingUnit(id string, mutator func(*HousingUnit) error) (HousingUnit, error)
 	DeleteHousingUnit(id string) error
+	TransferHousing(housingID, targetFacilityID string) (HousingUnit, error)
 	CreateFacility(Facility) (Facility, error)
 	UpdateFacility(id string, mutator func(*Facility) error) (Facility, error)
 	DeleteFacility(id string) error
@@ -27,23 +52,29 @@ type Transaction interface {
 	CreateStrain(Strain) (Strain, error)
 	UpdateStrain(id string, mutator func(*Strain) error) (Strain, error)
 	DeleteStrain(id string) error
+	RetireStrains(ids []string, reason string) ([]Strain, error)
 	CreateGenotypeMarker(GenotypeMarker) (GenotypeMarker, error)
 	UpdateGenotypeMarker(id string, mutator func(*GenotypeMarker) error) (GenotypeMarker, error)
 	DeleteGenotypeMarker(id string) error
 	CreateProcedure(Procedure) (Procedure, error)
 	UpdateProcedure(id string, mutator func(*Procedure) error) (Procedure, error)
 	DeleteProcedure(id string) error
+	TransitionProcedures(ids []string, to ProcedureStatus) ([]Procedure, error)
 	CreateTreatment(Treatment) (Treatment, error)
 	UpdateTreatment(id string, mutator func(*Treatment) error) (Treatment, error)
+	AppendAdverseEvent(treatmentID string, event AdverseEvent) (Treatment, error)
 	DeleteTreatment(id string) error
 	CreateObservation(Observation) (Observation, error)
 	UpdateObservation(id string, mutator func(*Observation) error) (Observation, error)
 	DeleteObservation(id string) error
+	ReviewObservation(id, reviewer string, at time.Time) (Observation, error)
+	AttachObservationFile(observationID string, ref AttachmentRef) (Observation, error)
 	CreateSample(Sample) (Sample, error)
 	UpdateSample(id string, mutator func(*Sample) error) (Sample, error)
 	DeleteSample(id string) error
 	CreateProtocol(Protocol) (Protocol, error)
 	UpdateProtocol(id string, mutator func(*Protocol) error) (Protocol, error)
+	AddProtocolReviewer(protocolID, reviewerID string) (Protocol, error)
 	DeleteProtocol(id string) error
 	CreatePermit(Permit) (Permit, error)
 	UpdatePermit(id string, mutator func(*Permit) error) (Permit, error)
@@ -51,13 +82,16 @@ type Transaction interface {
 	CreateProject(Project) (Project, error)
 	UpdateProject(id string, mutator func(*Project) error) (Project, error)
 	DeleteProject(id string) error
+	CloseProject(id string, closedAt time.Time) (Project, error)
 	CreateSupplyItem(SupplyItem) (SupplyItem, error)
 	UpdateSupplyItem(id string, mutator func(*SupplyItem) error) (SupplyItem, error)
 	DeleteSupplyItem(id string) error
+	ConsumeSupplyItem(id string, quantity float64, consumedBy string) (SupplyItem, error)
 	FindHousingUnit(id string) (HousingUnit, bool)
 	FindProtocol(id string) (Protocol, bool)
 	FindFacility(id string) (Facility, bool)
 	FindLine(id string) (Line, bool)
+	FindLinesByTag(tags ...string) []Line
 	FindStrain(id string) (Strain, bool)
 	FindGenotypeMarker(id string) (GenotypeMarker, bool)
 	FindTreatment(id string) (Treatment, bool)
@@ -66,6 +100,7 @@ type Transaction interface {
 	FindPermit(id string) (Permit, bool)
 	FindSupplyItem(id string) (SupplyItem, bool)
 	FindProcedure(id string) (Procedure, bool)
+	FindProject(id string) (Project, bool)
 }
 
 // TransactionView provides read-only access to snapshot data for rules.
@@ -77,14 +112,24 @@ type TransactionView interface {
 	ListStrains() []Strain
 	ListGenotypeMarkers() []GenotypeMarker
 	FindOrganism(id string) (Organism, bool)
+	FindCohort(id string) (Cohort, bool)
 	FindHousingUnit(id string) (HousingUnit, bool)
 	FindFacility(id string) (Facility, bool)
 	FindLine(id string) (Line, bool)
+	FindLinesByTag(tags ...string) []Line
 	FindStrain(id string) (Strain, bool)
 	FindGenotypeMarker(id string) (GenotypeMarker, bool)
+	ActiveStrainCount(lineID string) int
+	ActiveLineCount() int
+	ListRetirableStrains() []Strain
 	ListTreatments() []Treatment
+	ListTreatmentsByProcedure(procedureID string) []Treatment
 	ListObservations() []Observation
+	ListObservationsByOrganism(organismID string, filters ...ObservationFilter) []Observation
+	ListObservationsByCohort(cohortID string) []Observation
 	ListSamples() []Sample
+	ListSamplesByFacility(facilityID string) []Sample
+	ListSamplesByOrganism(organismID string) []Sample
 	ListProtocols() []Protocol
 	ListPermits() []Permit
 	ListProjects() []Project
@@ -95,10 +140,13 @@ type TransactionView interface {
 	FindPermit(id string) (Permit, bool)
 	FindSupplyItem(id string) (SupplyItem, bool)
 	FindProcedure(id string) (Procedure, bool)
+	ReferencesTo(entity EntityType, id string) []Reference
 }
 
 // PersistentStore is a minimal abstraction over durable backends. It mirrors
-// the subset of store capabilities used directly by higher layers.
+// the subset of store capabilities used directly by higher layers. The
+// natural-key lookups (Get*ByCode) return errors reading the backend instead
+// of reporting the record as missing.
 type PersistentStore interface {
 	RunInTransaction(ctx context.Context, fn func(Transaction) error) (Result, error)
 	View(ctx context.Context, fn func(TransactionView) error) error
@@ -122,7 +170,14 @@ type PersistentStore interface {
 	GetPermit(id string) (Permit, bool)
 	ListPermits() []Permit
 	ListProjects() []Project
+	GetBreedingUnit(id string) (BreedingUnit, bool)
 	ListBreedingUnits() []BreedingUnit
+	ListBreedingUnitsByIntent(intent PairingIntent) []BreedingUnit
 	ListProcedures() []Procedure
+	GetSupplyItem(id string) (SupplyItem, bool)
 	ListSupplyItems() []SupplyItem
+	GetFacilityByCode(code string) (Facility, bool, error)
+	GetProtocolByCode(code string) (Protocol, bool, error)
+	GetLineByCode(code string) (Line, bool, error)
+	GetStrainByCode(lineID, code string) (Strain, bool, error)
 }
@@ -0,0 +1,22 @@
+package domain
+
+// procedureTransitions lists the statuses each non-terminal procedure status
+// may move to. Completed, cancelled, and failed procedures are terminal. It is
+// the only procedure transition table: the stores and the lifecycle rule both
+// consult it through CanTransitionProcedure.
+var procedureTransitions = map[ProcedureStatus][]ProcedureStatus{
+	ProcedureStatusScheduled:  {ProcedureStatusInProgress, ProcedureStatusCancelled},
+	ProcedureStatusInProgress: {ProcedureStatusCompleted, ProcedureStatusCancelled, ProcedureStatusFailed},
+}
+
+// CanTransitionProcedure reports whether a procedure in status from may move
+// to status to: scheduled to in_progress or cancelled, and in_progress to
+// completed, cancelled, or failed.
+func CanTransitionProcedure(from, to ProcedureStatus) bool {
+	for _, next := range procedureTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+
+	entitymodel "colonycore/pkg/domain/entitymodel"
+)
+
+func TestCanTransitionProcedure(t *testing.T) {
+	allowed := map[[2]ProcedureStatus]bool{
+		{ProcedureStatusScheduled, ProcedureStatusInProgress}: true,
+		{ProcedureStatusScheduled, ProcedureStatusCancelled}:  true,
+		{ProcedureStatusInProgress, ProcedureStatusCompleted}: true,
+		{ProcedureStatusInProgress, ProcedureStatusCancelled}: true,
+		{ProcedureStatusInProgress, ProcedureStatusFailed}:    true,
+	}
+	for _, from := range entitymodel.AllProcedureStatus() {
+		for _, to := range entitymodel.AllProcedureStatus() {
+			if got, want := CanTransitionProcedure(from, to), allowed[[2]ProcedureStatus{from, to}]; got != want {
+				t.Errorf("CanTransitionProcedure(%s, %s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
@@ -68,6 +68,11 @@ func (emptyView) FindOrganism(string) (Organism, bool) {
 		Organism: entitymodel.Organism{},
 	}, false
 }
+func (emptyView) FindCohort(string) (Cohort, bool) {
+	return Cohort{
+		Cohort: entitymodel.Cohort{},
+	}, false
+}
 func (emptyView) FindHousingUnit(string) (HousingUnit, bool) {
 	return HousingUnit{
 		HousingUnit: entitymodel.HousingUnit{},
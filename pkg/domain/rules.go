@@ -19,6 +19,7 @@ type RuleView interface {
 	ListProjects() []Project
 	ListSupplyItems() []SupplyItem
 	FindOrganism(id string) (Organism, bool)
+	FindCohort(id string) (Cohort, bool)
 	FindHousingUnit(id string) (HousingUnit, bool)
 	FindFacility(id string) (Facility, bool)
 	FindTreatment(id string) (Treatment, bool)
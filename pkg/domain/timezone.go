@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ValidateTimezone reports whether name is an IANA time zone name that
+// time.LoadLocation can resolve. The empty string and "Local" are rejected
+// because they do not identify a facility's zone independently of the host.
+func ValidateTimezone(name string) error {
+	_, err := loadTimezone(name)
+	return err
+}
+
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("timezone %q is not an IANA location name", name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// NormalizeScheduledAt interprets the wall-clock reading of scheduledAt
+// (ignoring its location) as a time in facilityTimezone and returns the
+// matching UTC instant. A "9:00" schedule in America/New_York therefore maps to
+// 13:00 or 14:00 UTC depending on daylight saving time. Wall-clock times that
+// fall in a DST gap or overlap resolve as documented for time.Date.
+func NormalizeScheduledAt(scheduledAt time.Time, facilityTimezone string) (time.Time, error) {
+	loc, err := loadTimezone(facilityTimezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	year, month, day := scheduledAt.Date()
+	hour, minute, second := scheduledAt.Clock()
+	return time.Date(year, month, day, hour, minute, second, scheduledAt.Nanosecond(), loc).UTC(), nil
+}
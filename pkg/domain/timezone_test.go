@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNormalizeScheduledAtConvertsFacilityWallClock(t *testing.T) {
+	wall := time.Date(2025, time.January, 15, 9, 0, 0, 0, time.UTC)
+	got, err := NormalizeScheduledAt(wall, "America/New_York")
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if want := time.Date(2025, time.January, 15, 14, 0, 0, 0, time.UTC); !got.Equal(want) || got.Location() != time.UTC {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestNormalizeScheduledAtUTCIsNoOp(t *testing.T) {
+	wall := time.Date(2025, time.June, 1, 9, 30, 15, 500, time.UTC)
+	got, err := NormalizeScheduledAt(wall, "UTC")
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if !got.Equal(wall) {
+		t.Fatalf("expected UTC schedule to be unchanged, got %s", got)
+	}
+}
+
+func TestNormalizeScheduledAtAcrossDSTBoundary(t *testing.T) {
+	// Europe/Berlin switches from CET (+01:00) to CEST (+02:00) on 2025-03-30.
+	before, err := NormalizeScheduledAt(time.Date(2025, time.March, 29, 9, 0, 0, 0, time.UTC), "Europe/Berlin")
+	if err != nil {
+		t.Fatalf("normalize before: %v", err)
+	}
+	after, err := NormalizeScheduledAt(time.Date(2025, time.March, 30, 9, 0, 0, 0, time.UTC), "Europe/Berlin")
+	if err != nil {
+		t.Fatalf("normalize after: %v", err)
+	}
+	if before.Hour() != 8 || after.Hour() != 7 {
+		t.Fatalf("expected 08:00 and 07:00 UTC around the DST switch, got %s and %s", before, after)
+	}
+}
+
+func TestNormalizeScheduledAtRejectsInvalidTimezone(t *testing.T) {
+	for _, name := range []string{"Mars/Olympus_Mons", "", "Local"} {
+		if _, err := NormalizeScheduledAt(time.Now(), name); err == nil {
+			t.Fatalf("expected error for timezone %q", name)
+		}
+		if err := ValidateTimezone(name); err == nil {
+			t.Fatalf("expected ValidateTimezone to reject %q", name)
+		}
+	}
+	if err := ValidateTimezone("Australia/Sydney"); err != nil {
+		t.Fatalf("expected IANA name to validate: %v", err)
+	}
+}
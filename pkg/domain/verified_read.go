@@ -0,0 +1,60 @@
+package domain
+
+import "fmt"
+
+// InvalidEntityError reports a stored entity that no longer satisfies the
+// invariants the store enforces on write, typically after an import or a
+// schema change.
+type InvalidEntityError struct {
+	Entity EntityType
+	ID     string
+	Err    error
+}
+
+func (e InvalidEntityError) Error() string {
+	return fmt.Sprintf("stored %s %q is invalid: %v", e.Entity, e.ID, e.Err)
+}
+
+func (e InvalidEntityError) Unwrap() error { return e.Err }
+
+// VerifiedReader is implemented by stores that can re-validate records as they
+// are read. GetVerified returns the record of entity stored under id and
+// reports false when there is none; ListVerified returns every record of
+// entity. When verify-on-read is enabled on the store, a record violating a
+// write-time invariant is returned alongside an InvalidEntityError (joined
+// across records for ListVerified) instead of being hidden. Errors reading the
+// backend are returned as is. Unknown entities return an error.
+type VerifiedReader interface {
+	GetVerified(entity EntityType, id string) (any, bool, error)
+	ListVerified(entity EntityType) ([]any, error)
+}
+
+// GetVerified is the typed form of VerifiedReader.GetVerified. T must be the
+// record type stored for entity, for example Organism for EntityOrganism.
+func GetVerified[T any](reader VerifiedReader, entity EntityType, id string) (T, bool, error) {
+	var zero T
+	record, ok, err := reader.GetVerified(entity, id)
+	if !ok {
+		return zero, false, err
+	}
+	typed, isT := record.(T)
+	if !isT {
+		return zero, false, fmt.Errorf("get %s %q: stored as %T, not %T", entity, id, record, zero)
+	}
+	return typed, true, err
+}
+
+// ListVerified is the typed form of VerifiedReader.ListVerified.
+func ListVerified[T any](reader VerifiedReader, entity EntityType) ([]T, error) {
+	records, err := reader.ListVerified(entity)
+	out := make([]T, 0, len(records))
+	for _, record := range records {
+		typed, ok := record.(T)
+		if !ok {
+			var zero T
+			return nil, fmt.Errorf("list %s: stored as %T, not %T", entity, record, zero)
+		}
+		out = append(out, typed)
+	}
+	return out, err
+}
@@ -0,0 +1,40 @@
+// Package openapi exposes the OpenAPI document compiled into the binary so a
+// running server can publish the exact contract it was built from.
+package openapi
+
+import (
+	"net/http"
+
+	entitymodelopenapi "colonycore/docs/schema/openapi"
+)
+
+// Path is the route servers publish the document at.
+const Path = "/openapi.yaml"
+
+// ContentType is the media type the document is served with.
+const ContentType = "application/yaml"
+
+// Document returns a copy of the embedded OpenAPI YAML. The bytes are the
+// generator's output for the schema the binary was built from; the
+// entity-model generator tests fail when they drift.
+func Document() []byte {
+	return entitymodelopenapi.Spec()
+}
+
+// Handler returns an http.Handler serving Document for GET and HEAD requests
+// and rejecting other methods with 405.
+func Handler() http.Handler {
+	doc := Document()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", ContentType)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(doc)
+		}
+	})
+}
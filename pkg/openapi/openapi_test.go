@@ -0,0 +1,60 @@
+package openapi
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.yaml.in/yaml/v2"
+)
+
+func TestDocumentIsEmbeddedSpecCopy(t *testing.T) {
+	want, err := os.ReadFile(filepath.Join("..", "..", "docs", "schema", "openapi", "entity-model.yaml"))
+	if err != nil {
+		t.Fatalf("read generated spec: %v", err)
+	}
+	doc := Document()
+	if !bytes.Equal(doc, want) {
+		t.Fatalf("Document does not match the generated spec")
+	}
+	doc[0] ^= 0xFF
+	if !bytes.Equal(Document(), want) {
+		t.Fatalf("Document mutation leaked into the embedded spec")
+	}
+
+	var parsed map[string]any
+	if err := yaml.Unmarshal(Document(), &parsed); err != nil {
+		t.Fatalf("Document is not valid YAML: %v", err)
+	}
+	if _, ok := parsed["openapi"]; !ok {
+		t.Fatalf("Document missing openapi version key")
+	}
+}
+
+func TestHandlerServesDocument(t *testing.T) {
+	handler := Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != ContentType {
+		t.Fatalf("expected 200 %s, got %d %q", ContentType, rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if !bytes.Equal(rec.Body.Bytes(), Document()) {
+		t.Fatalf("handler body does not match Document")
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, Path, nil))
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Fatalf("expected HEAD to return 200 without a body, got %d with %d bytes", rec.Code, rec.Body.Len())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, Path, nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != "GET, HEAD" {
+		t.Fatalf("expected 405 with Allow header, got %d %q", rec.Code, rec.Header().Get("Allow"))
+	}
+}
@@ -0,0 +1,80 @@
+package pluginapi
+
+import (
+	"context"
+	"time"
+)
+
+type auditContextKey int
+
+// Context keys the host reads when building an AuditEntry. The HTTP layer
+// attaches request identity with context.WithValue before invoking the
+// service; values must be strings.
+const (
+	// ActorKey identifies the user or system principal performing the change.
+	ActorKey auditContextKey = iota
+	// SessionIDKey identifies the authenticated session.
+	SessionIDKey
+	// IPAddressKey carries the client IP address.
+	IPAddressKey
+)
+
+// AuditEntry describes one committed transaction together with the request
+// context that produced it. It is immutable to plugin authors and accessed via
+// getter methods.
+type AuditEntry struct {
+	actor     string
+	sessionID string
+	ipAddress string
+	timestamp time.Time
+	changes   []Change
+}
+
+// NewAuditEntry constructs an AuditEntry for delivery to audit emitters. The
+// changes slice is copied; Change values are already immutable.
+func NewAuditEntry(actor, sessionID, ipAddress string, timestamp time.Time, changes []Change) AuditEntry {
+	return AuditEntry{
+		actor:     actor,
+		sessionID: sessionID,
+		ipAddress: ipAddress,
+		timestamp: timestamp,
+		changes:   append([]Change(nil), changes...),
+	}
+}
+
+// Actor returns the principal read from ActorKey, or "" when unset.
+func (e AuditEntry) Actor() string { return e.actor }
+
+// SessionID returns the session read from SessionIDKey, or "" when unset.
+func (e AuditEntry) SessionID() string { return e.sessionID }
+
+// IPAddress returns the client address read from IPAddressKey, or "" when unset.
+func (e AuditEntry) IPAddress() string { return e.ipAddress }
+
+// Timestamp returns the time the host emitted the entry.
+func (e AuditEntry) Timestamp() time.Time { return e.timestamp }
+
+// Changes returns the committed changes in the order they were recorded.
+func (e AuditEntry) Changes() []Change { return append([]Change(nil), e.changes...) }
+
+// AuditEmitter receives an AuditEntry after each transaction commits. Emitters
+// run synchronously after the commit; an error is logged by the host and does
+// not undo the transaction.
+type AuditEmitter interface {
+	EmitAudit(ctx context.Context, entry AuditEntry) error
+}
+
+// AuditEmitterRegistry is implemented by hosts that accept audit emitters.
+// Plugins type-assert the Registry passed to Register to discover support.
+type AuditEmitterRegistry interface {
+	RegisterAuditEmitter(emitter AuditEmitter) error
+}
+
+// AuditContextValue returns the string stored in ctx under key, or "".
+func AuditContextValue(ctx context.Context, key any) string {
+	if ctx == nil {
+		return ""
+	}
+	value, _ := ctx.Value(key).(string)
+	return value
+}
@@ -0,0 +1,201 @@
+package pluginapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"colonycore/pkg/datasetapi"
+)
+
+// DatasetService exposes dataset templates to species-agnostic consumers such
+// as analytics endpoints. Templates are addressed by their descriptor slug
+// (plugin/key@version).
+type DatasetService interface {
+	EnumerateTemplates() []DatasetTemplate
+	ValidateParameters(templateID string, params map[string]any) error
+	StreamResults(ctx context.Context, templateID string, params map[string]any, w io.Writer) error
+}
+
+// DatasetTemplate describes a template offered by a DatasetService. It is
+// immutable to plugin authors and accessed via getter methods.
+type DatasetTemplate struct {
+	id         string
+	descriptor datasetapi.TemplateDescriptor
+}
+
+// NewDatasetTemplate constructs a DatasetTemplate for the template addressed
+// by id.
+func NewDatasetTemplate(id string, descriptor datasetapi.TemplateDescriptor) DatasetTemplate {
+	return DatasetTemplate{id: id, descriptor: descriptor}
+}
+
+// ID returns the identifier ValidateParameters and StreamResults accept.
+func (t DatasetTemplate) ID() string { return t.id }
+
+// Descriptor returns the template's descriptor.
+func (t DatasetTemplate) Descriptor() datasetapi.TemplateDescriptor { return t.descriptor }
+
+// MarshalJSON encodes the template as {"id": ..., "descriptor": ...}.
+func (t DatasetTemplate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		ID         string                        `json:"id"`
+		Descriptor datasetapi.TemplateDescriptor `json:"descriptor"`
+	}{t.id, t.descriptor})
+}
+
+type datasetScopeKey struct{}
+
+// WithDatasetScope returns a context carrying the caller's dataset scope for
+// DatasetService.StreamResults. Hosts attach the scope they resolved for the
+// authenticated principal, including its RBAC grants.
+func WithDatasetScope(ctx context.Context, scope datasetapi.Scope) context.Context {
+	return context.WithValue(ctx, datasetScopeKey{}, scope)
+}
+
+// DatasetScopeFromContext returns the scope attached by WithDatasetScope.
+func DatasetScopeFromContext(ctx context.Context) (datasetapi.Scope, bool) {
+	if ctx == nil {
+		return datasetapi.Scope{}, false
+	}
+	scope, ok := ctx.Value(datasetScopeKey{}).(datasetapi.Scope)
+	return scope, ok
+}
+
+// DatasetServiceRegistry is implemented by hosts that accept dataset services.
+// Plugins type-assert the Registry passed to Register to discover support.
+type DatasetServiceRegistry interface {
+	RegisterDatasetService(svc DatasetService) error
+}
+
+// DefaultDatasetService implements DatasetService by delegating to registered
+// template runtimes. The zero value is ready to use.
+type DefaultDatasetService struct {
+	mu        sync.RWMutex
+	templates map[string]datasetapi.TemplateRuntime
+}
+
+var _ DatasetService = (*DefaultDatasetService)(nil)
+
+// Register adds a template runtime, keyed by its descriptor slug.
+func (s *DefaultDatasetService) Register(template datasetapi.TemplateRuntime) error {
+	if template == nil {
+		return errors.New("dataset template runtime nil")
+	}
+	slug := template.Descriptor().Slug
+	if slug == "" {
+		return errors.New("dataset template slug required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.templates[slug]; exists {
+		return fmt.Errorf("dataset template %s already registered", slug)
+	}
+	if s.templates == nil {
+		s.templates = make(map[string]datasetapi.TemplateRuntime)
+	}
+	s.templates[slug] = template
+	return nil
+}
+
+// EnumerateTemplates returns every registered template sorted by ID.
+func (s *DefaultDatasetService) EnumerateTemplates() []DatasetTemplate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]DatasetTemplate, 0, len(s.templates))
+	for slug, template := range s.templates {
+		out = append(out, NewDatasetTemplate(slug, template.Descriptor()))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID() < out[j].ID() })
+	return out
+}
+
+// ValidateParameters checks params against the template's declared parameters.
+func (s *DefaultDatasetService) ValidateParameters(templateID string, params map[string]any) error {
+	template, err := s.lookup(templateID)
+	if err != nil {
+		return err
+	}
+	_, errs := template.ValidateParameters(params)
+	return parameterErrors(templateID, errs)
+}
+
+// StreamResults runs the template under the caller's scope and writes each
+// result row to w as a line of JSON (NDJSON). The scope is read with
+// DatasetScopeFromContext; without one the requestor is the ActorKey principal.
+// A scope without RBAC grants denies access, so only rbac.exempt templates
+// return rows. Runtimes implementing datasetapi.RowStreamer write rows as they
+// are produced; others are run to completion first. Streaming stops with the
+// context error once ctx is done.
+func (s *DefaultDatasetService) StreamResults(ctx context.Context, templateID string, params map[string]any, w io.Writer) error {
+	template, err := s.lookup(templateID)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	scope, ok := DatasetScopeFromContext(ctx)
+	if !ok {
+		scope = datasetapi.Scope{Requestor: AuditContextValue(ctx, ActorKey)}
+	}
+	if scope.RBAC == nil {
+		scope.RBAC = &datasetapi.RBACScope{Actor: scope.Requestor}
+	}
+	enc := json.NewEncoder(w)
+	emit := func(row datasetapi.Row) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := enc.Encode(row); err != nil {
+			return fmt.Errorf("write dataset row: %w", err)
+		}
+		return nil
+	}
+	if streamer, ok := template.(datasetapi.RowStreamer); ok {
+		errs, err := streamer.StreamRows(ctx, params, scope, emit)
+		if err != nil {
+			return err
+		}
+		return parameterErrors(templateID, errs)
+	}
+	result, errs, err := template.Run(ctx, params, scope, datasetapi.GetFormatProvider().JSON())
+	if err != nil {
+		return err
+	}
+	if err := parameterErrors(templateID, errs); err != nil {
+		return err
+	}
+	for _, row := range result.Rows {
+		if err := emit(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *DefaultDatasetService) lookup(templateID string) (datasetapi.TemplateRuntime, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	template, ok := s.templates[templateID]
+	if !ok {
+		return nil, fmt.Errorf("dataset template %s not registered", templateID)
+	}
+	return template, nil
+}
+
+func parameterErrors(templateID string, errs []datasetapi.ParameterError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = fmt.Sprintf("%s: %s", e.Name, e.Message)
+	}
+	return fmt.Errorf("dataset template %s parameters invalid: %s", templateID, strings.Join(msgs, "; "))
+}
@@ -0,0 +1,217 @@
+package pluginapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"colonycore/pkg/datasetapi"
+)
+
+type cancelingWriter struct {
+	bytes.Buffer
+	cancel context.CancelFunc
+}
+
+func (w *cancelingWriter) Write(p []byte) (int, error) {
+	w.cancel()
+	return w.Buffer.Write(p)
+}
+
+func newDatasetServiceTemplate(t *testing.T, key string, rows int) datasetapi.TemplateRuntime {
+	t.Helper()
+	formats := datasetapi.GetFormatProvider()
+	tpl := datasetapi.Template{
+		Key:         key,
+		Version:     "1.0.0",
+		Title:       "Counts",
+		Description: "row counts",
+		Dialect:     datasetapi.GetDialectProvider().SQL(),
+		Query:       "SELECT 1",
+		Parameters: []datasetapi.Parameter{{
+			Name:     "limit",
+			Type:     "integer",
+			Required: true,
+		}},
+		Columns: []datasetapi.Column{{Name: "value", Type: "integer"}},
+		Metadata: datasetapi.Metadata{
+			Source:      "tests",
+			Annotations: map[string]string{datasetapi.RBACExemptAnnotation: "true"},
+		},
+		OutputFormats: []datasetapi.Format{formats.JSON()},
+		Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
+			return func(_ context.Context, _ datasetapi.RunRequest) (datasetapi.RunResult, error) {
+				out := make([]datasetapi.Row, rows)
+				for i := range out {
+					out[i] = datasetapi.Row{"value": i}
+				}
+				return datasetapi.RunResult{Rows: out, GeneratedAt: time.Now()}, nil
+			}, nil
+		},
+	}
+	host, err := datasetapi.NewHostTemplate("frog", tpl)
+	if err != nil {
+		t.Fatalf("new host template: %v", err)
+	}
+	if err := host.Bind(datasetapi.Environment{Now: time.Now}); err != nil {
+		t.Fatalf("bind template: %v", err)
+	}
+	return host
+}
+
+func TestDefaultDatasetServiceEnumerateTemplates(t *testing.T) {
+	var svc DefaultDatasetService
+	for _, key := range []string{"weights", "counts"} {
+		if err := svc.Register(newDatasetServiceTemplate(t, key, 1)); err != nil {
+			t.Fatalf("register %s: %v", key, err)
+		}
+	}
+	if err := svc.Register(newDatasetServiceTemplate(t, "counts", 1)); err == nil {
+		t.Fatalf("expected duplicate template error")
+	}
+	if err := svc.Register(nil); err == nil {
+		t.Fatalf("expected nil template error")
+	}
+	templates := svc.EnumerateTemplates()
+	if len(templates) != 2 || templates[0].ID() != "frog/counts@1.0.0" || templates[1].ID() != "frog/weights@1.0.0" {
+		t.Fatalf("expected both templates sorted by ID, got %+v", templates)
+	}
+	if templates[0].Descriptor().Key != "counts" || templates[0].Descriptor().Slug != templates[0].ID() {
+		t.Fatalf("expected descriptor alongside ID, got %+v", templates[0])
+	}
+	encoded, err := json.Marshal(templates[0])
+	if err != nil || !strings.HasPrefix(string(encoded), `{"id":"frog/counts@1.0.0","descriptor":{`) {
+		t.Fatalf("expected id and descriptor in JSON, got %s (%v)", encoded, err)
+	}
+}
+
+func TestDefaultDatasetServiceValidateParameters(t *testing.T) {
+	var svc DefaultDatasetService
+	if err := svc.Register(newDatasetServiceTemplate(t, "counts", 1)); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := svc.ValidateParameters("frog/counts@1.0.0", map[string]any{"limit": 5}); err != nil {
+		t.Fatalf("expected valid parameters, got %v", err)
+	}
+	err := svc.ValidateParameters("frog/counts@1.0.0", map[string]any{})
+	if err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Fatalf("expected missing required parameter error, got %v", err)
+	}
+	if err := svc.ValidateParameters("frog/unknown@1.0.0", nil); err == nil {
+		t.Fatalf("expected unknown template error")
+	}
+}
+
+func TestDefaultDatasetServiceStreamResults(t *testing.T) {
+	var svc DefaultDatasetService
+	if err := svc.Register(newDatasetServiceTemplate(t, "counts", 3)); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	params := map[string]any{"limit": 3}
+
+	var buf bytes.Buffer
+	if err := svc.StreamResults(context.Background(), "frog/counts@1.0.0", params, &buf); err != nil {
+		t.Fatalf("stream results: %v", err)
+	}
+	if got := buf.String(); got != "{\"value\":0}\n{\"value\":1}\n{\"value\":2}\n" {
+		t.Fatalf("unexpected NDJSON output %q", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	writer := &cancelingWriter{cancel: cancel}
+	err := svc.StreamResults(ctx, "frog/counts@1.0.0", params, writer)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancellation, got %v", err)
+	}
+	if lines := strings.Count(writer.String(), "\n"); lines != 1 {
+		t.Fatalf("expected streaming to stop after the first row, got %d rows", lines)
+	}
+
+	if err := svc.StreamResults(context.Background(), "frog/counts@1.0.0", map[string]any{}, &buf); err == nil {
+		t.Fatalf("expected parameter error before streaming")
+	}
+}
+
+func newScopedStreamingTemplate(t *testing.T, failAfter int) datasetapi.TemplateRuntime {
+	t.Helper()
+	tpl := datasetapi.Template{
+		Key:           "frogs",
+		Version:       "1.0.0",
+		Title:         "Frogs",
+		Description:   "frogs by facility",
+		Dialect:       datasetapi.GetDialectProvider().SQL(),
+		Query:         "SELECT 1",
+		Columns:       []datasetapi.Column{{Name: "value", Type: "string"}, {Name: datasetapi.RBACFacilityColumn, Type: "string"}},
+		OutputFormats: []datasetapi.Format{datasetapi.GetFormatProvider().JSON()},
+		Binder: func(datasetapi.Environment) (datasetapi.Runner, error) {
+			return func(_ context.Context, req datasetapi.RunRequest) (datasetapi.RunResult, error) {
+				for i, facility := range []string{"facility-a", "facility-b", "facility-a"} {
+					if failAfter >= 0 && i == failAfter {
+						return datasetapi.RunResult{}, errors.New("source closed")
+					}
+					if err := req.Emit(datasetapi.Row{"value": i, datasetapi.RBACFacilityColumn: facility}); err != nil {
+						return datasetapi.RunResult{}, err
+					}
+				}
+				return datasetapi.RunResult{GeneratedAt: time.Now()}, nil
+			}, nil
+		},
+	}
+	host, err := datasetapi.NewHostTemplate("frog", tpl)
+	if err != nil {
+		t.Fatalf("new host template: %v", err)
+	}
+	if err := host.Bind(datasetapi.Environment{Now: time.Now}); err != nil {
+		t.Fatalf("bind template: %v", err)
+	}
+	return host
+}
+
+func TestDefaultDatasetServiceStreamResultsAppliesCallerScope(t *testing.T) {
+	var svc DefaultDatasetService
+	if err := svc.Register(newScopedStreamingTemplate(t, -1)); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	ctx := WithDatasetScope(context.Background(), datasetapi.Scope{
+		Requestor: "analyst",
+		RBAC:      &datasetapi.RBACScope{Actor: "analyst", FacilityIDs: []string{"facility-a"}},
+	})
+	var buf bytes.Buffer
+	if err := svc.StreamResults(ctx, "frog/frogs@1.0.0", nil, &buf); err != nil {
+		t.Fatalf("stream results: %v", err)
+	}
+	if got := buf.String(); got != "{\"facility_id\":\"facility-a\",\"value\":0}\n{\"facility_id\":\"facility-a\",\"value\":2}\n" {
+		t.Fatalf("expected only granted rows, got %q", got)
+	}
+
+	buf.Reset()
+	if err := svc.StreamResults(context.Background(), "frog/frogs@1.0.0", nil, &buf); err != nil {
+		t.Fatalf("stream results without scope: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected no rows without a caller scope, got %q", buf.String())
+	}
+}
+
+func TestDefaultDatasetServiceStreamResultsWritesRowsAsProduced(t *testing.T) {
+	var svc DefaultDatasetService
+	if err := svc.Register(newScopedStreamingTemplate(t, 2)); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	ctx := WithDatasetScope(context.Background(), datasetapi.Scope{
+		RBAC: &datasetapi.RBACScope{FacilityIDs: []string{datasetapi.RBACWildcard}},
+	})
+	var buf bytes.Buffer
+	err := svc.StreamResults(ctx, "frog/frogs@1.0.0", nil, &buf)
+	if err == nil || !strings.Contains(err.Error(), "source closed") {
+		t.Fatalf("expected runner failure, got %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 2 {
+		t.Fatalf("expected rows emitted before the failure to be written, got %d", lines)
+	}
+}
@@ -2,7 +2,11 @@
 // (plugins) which can register schemas, rules, and dataset templates.
 package pluginapi
 
-import "colonycore/pkg/datasetapi"
+import (
+	"encoding/json"
+
+	"colonycore/pkg/datasetapi"
+)
 
 // EntityModelCompatibilityProvider allows plugins to declare the Entity Model
 // major version they target. Hosts may reject plugins that declare a different
@@ -12,6 +16,29 @@ type EntityModelCompatibilityProvider interface {
 	EntityModelMajor() int
 }
 
+// ObservationCategoryTaxonomy declares the observation categories a plugin
+// recognises. Once a taxonomy is registered, hosts reject observations whose
+// non-empty Category is not listed by any installed taxonomy.
+type ObservationCategoryTaxonomy interface {
+	AllowedCategories() []string
+}
+
+// ObservationCategoryRegistry is implemented by hosts that accept observation
+// category taxonomies. Plugins type-assert the Registry passed to Register to
+// discover support.
+type ObservationCategoryRegistry interface {
+	RegisterObservationCategoryTaxonomy(taxonomy ObservationCategoryTaxonomy) error
+}
+
+// ExtensionAttributeSchemaRegistry is implemented by hosts that validate
+// extension attributes. A plugin registers a JSON Schema for one namespace of
+// an entity's attributes (for example entity "organism", namespace "frog");
+// the host rejects writes whose namespace payload does not satisfy it, and
+// rejects schemas using keywords it cannot enforce.
+type ExtensionAttributeSchemaRegistry interface {
+	RegisterExtensionSchema(entity string, namespace string, schema json.RawMessage) error
+}
+
 // Registry is implemented by the host to allow plugins to register resources.
 type Registry interface {
 	RegisterSchema(entity string, schema map[string]any)
@@ -0,0 +1,39 @@
+package pluginapi
+
+// ReadModel is a read-only, facade-typed query interface over committed colony
+// data. Plugins use it when they need colony data outside rule evaluation, for
+// example to compute species-specific derived values, without importing
+// pkg/domain. Each call reads the latest committed state and returns the same
+// views rules receive; nothing returned aliases host storage. Errors reading
+// the host store are returned rather than reported as empty results. Find
+// methods read only the requested record where the store supports it; a
+// record that fails the store's read verification is returned together with
+// the error.
+type ReadModel interface {
+	ListOrganisms() ([]OrganismView, error)
+	ListHousingUnits() ([]HousingUnitView, error)
+	ListFacilities() ([]FacilityView, error)
+	ListTreatments() ([]TreatmentView, error)
+	ListObservations() ([]ObservationView, error)
+	ListSamples() ([]SampleView, error)
+	ListProtocols() ([]ProtocolView, error)
+	ListPermits() ([]PermitView, error)
+	ListProjects() ([]ProjectView, error)
+	ListSupplyItems() ([]SupplyItemView, error)
+	FindOrganism(id string) (OrganismView, bool, error)
+	FindHousingUnit(id string) (HousingUnitView, bool, error)
+	FindFacility(id string) (FacilityView, bool, error)
+	FindTreatment(id string) (TreatmentView, bool, error)
+	FindObservation(id string) (ObservationView, bool, error)
+	FindSample(id string) (SampleView, bool, error)
+	FindPermit(id string) (PermitView, bool, error)
+	FindSupplyItem(id string) (SupplyItemView, bool, error)
+}
+
+// ReadModelProvider is implemented by hosts that offer plugins a ReadModel.
+// Plugins type-assert the Registry passed to Register to discover support and
+// may keep the ReadModel for use after registration. ReadModel returns nil
+// when the host has no store to read from.
+type ReadModelProvider interface {
+	ReadModel() ReadModel
+}
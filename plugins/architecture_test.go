@@ -8,8 +8,9 @@ import (
 )
 
 // TestPluginsDoNotImportDomain enforces that plugin implementation packages do not
-// import the internal domain model directly. Plugins must depend only on the
-// stable facades in pkg/datasetapi or pkg/pluginapi. The test deliberately
+// import the internal domain model (or its subpackages) or host internals
+// directly. Plugins must depend only on the stable facades in pkg/datasetapi or
+// pkg/pluginapi, reading colony data through pluginapi.ReadModel and rule views. The test deliberately
 // skips the test fixture helper package at plugins/testhelper which is an
 // explicit escape hatch for building facade fixtures from domain entities.
 func TestPluginsDoNotImportDomain(t *testing.T) {
@@ -20,7 +21,7 @@ func TestPluginsDoNotImportDomain(t *testing.T) {
 
 	root := wd // this file lives in the plugins directory
 
-	forbidden := "colonycore/pkg/domain"
+	forbidden := []string{"colonycore/pkg/domain", "colonycore/internal"}
 	fixtureDir := filepath.Join(root, "testhelper")
 
 	var violations []string
@@ -57,8 +58,8 @@ func TestPluginsDoNotImportDomain(t *testing.T) {
 					continue
 				}
 				if strings.HasPrefix(line, "import ") { // single import form
-					if q := extractQuoted(line); q == forbidden {
-						violations = append(violations, path)
+					if q := extractQuoted(line); isForbiddenImport(q, forbidden) {
+						violations = append(violations, path+" ("+q+")")
 					}
 				}
 				continue
@@ -68,8 +69,8 @@ func TestPluginsDoNotImportDomain(t *testing.T) {
 				inImport = false
 				continue
 			}
-			if q := extractQuoted(line); q == forbidden {
-				violations = append(violations, path)
+			if q := extractQuoted(line); isForbiddenImport(q, forbidden) {
+				violations = append(violations, path+" ("+q+")")
 			}
 		}
 		return nil
@@ -83,7 +84,7 @@ func TestPluginsDoNotImportDomain(t *testing.T) {
 			// Report each offending file for clarity
 			// (Keep error format stable for grepping / future tooling.)
 			//nolint:lll // readability > line length here
-			t.Errorf("plugin file imports forbidden %s: %s", forbidden, v)
+			t.Errorf("plugin file imports forbidden %s: %s", strings.Join(forbidden, " or "), v)
 		}
 		// Fail fast after listing all violations.
 		// Using Fatalf would hide multiple offenders; we collect first.
@@ -92,6 +93,17 @@ func TestPluginsDoNotImportDomain(t *testing.T) {
 	}
 }
 
+// isForbiddenImport reports whether importPath is one of the forbidden
+// packages or lives beneath one.
+func isForbiddenImport(importPath string, forbidden []string) bool {
+	for _, prefix := range forbidden {
+		if importPath == prefix || strings.HasPrefix(importPath, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 // extractQuoted mirrors the helper in pkg/domain/architecture_test.go but is
 // duplicated locally to keep the test self-contained and avoid importing domain.
 func extractQuoted(line string) string {
@@ -105,3 +117,19 @@ func extractQuoted(line string) string {
 	}
 	return line[start+1 : start+1+end]
 }
+
+func TestIsForbiddenImport(t *testing.T) {
+	forbidden := []string{"colonycore/pkg/domain", "colonycore/internal"}
+	for path, want := range map[string]bool{
+		"colonycore/pkg/domain":             true,
+		"colonycore/pkg/domain/entitymodel": true,
+		"colonycore/internal/core":          true,
+		"colonycore/pkg/domainx":            false,
+		"colonycore/pkg/pluginapi":          false,
+		"colonycore/pkg/datasetapi":         false,
+	} {
+		if got := isForbiddenImport(path, forbidden); got != want {
+			t.Errorf("isForbiddenImport(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
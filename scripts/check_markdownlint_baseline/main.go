@@ -19,7 +19,7 @@ const (
 	defaultTool    = "markdownlint-cli"
 	defaultConfig  = ".markdownlint.yaml"
 	defaultScope   = "**/*.md"
-	defaultUsage   = "Usage: check_markdownlint_baseline --baseline <file> [--input <file>] [--update]\n"
+	defaultUsage   = "Usage: check_markdownlint_baseline --baseline <file> [--input <file>] [--update] [--fail-on-overdue-review]\n"
 	defaultRunHint = "Update the baseline with: make lint-docs-update\n"
 	dateLayout     = "2006-01-02"
 )
@@ -76,6 +76,7 @@ func Run(args []string, stderr io.Writer, stdin io.Reader) int {
 	baselinePath := fs.String("baseline", "", "path to markdownlint baseline file")
 	inputPath := fs.String("input", "", "path to markdownlint JSON output (defaults to stdin)")
 	update := fs.Bool("update", false, "overwrite the baseline with the current lint results")
+	failOnOverdueReview := fs.Bool("fail-on-overdue-review", false, "fail when the baseline next_review_date has passed")
 	if err := fs.Parse(args[1:]); err != nil {
 		return 2
 	}
@@ -113,6 +114,14 @@ func Run(args []string, stderr io.Writer, stdin io.Reader) int {
 	if err != nil {
 		return reportError(stderr, "load baseline: %v\n", err)
 	}
+	if warning := checkNextReviewDate(baseline.Meta, nowUTC()); warning != "" {
+		if *failOnOverdueReview {
+			return reportError(stderr, "%s\n", warning)
+		}
+		if _, err := fmt.Fprintf(stderr, "%s\n", warning); err != nil {
+			return 1
+		}
+	}
 
 	newIssues := diffIssues(issues, normalizeIssues(baseline.Issues))
 	if len(newIssues) > 0 {
@@ -357,6 +366,26 @@ func validateMetaDates(meta baselineMeta, today time.Time) error {
 	return nil
 }
 
+// checkNextReviewDate returns a warning when the baseline's next review date
+// is set and now falls on a later day, or when the date cannot be parsed. It
+// returns "" otherwise.
+func checkNextReviewDate(meta baselineMeta, now time.Time) string {
+	value := strings.TrimSpace(meta.NextReviewDate)
+	if value == "" {
+		return ""
+	}
+	reviewDate, err := parseISODate(value)
+	if err != nil {
+		return fmt.Sprintf("warning: markdownlint baseline next_review_date %q is invalid: %v", value, err)
+	}
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if !today.After(reviewDate) {
+		return ""
+	}
+	return fmt.Sprintf("warning: markdownlint baseline review was due on %s; review the remaining issues and refresh it with make lint-docs-update", value)
+}
+
 func isZeroMeta(meta baselineMeta) bool {
 	return meta.Tool == "" &&
 		meta.Config == "" &&
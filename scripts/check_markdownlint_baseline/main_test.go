@@ -520,6 +520,53 @@ func TestRunUsesInputFile(t *testing.T) {
 	}
 }
 
+func TestCheckNextReviewDate(t *testing.T) {
+	meta := baselineMeta{NextReviewDate: "2026-04-01"}
+	if got := checkNextReviewDate(meta, time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)); got != "" {
+		t.Fatalf("expected no warning before the review date, got %q", got)
+	}
+	if got := checkNextReviewDate(meta, time.Date(2026, 4, 1, 23, 59, 0, 0, time.UTC)); got != "" {
+		t.Fatalf("expected no warning on the review date, got %q", got)
+	}
+	if got := checkNextReviewDate(meta, time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC)); !strings.Contains(got, "review was due on 2026-04-01") {
+		t.Fatalf("expected overdue warning the day after the review date, got %q", got)
+	}
+	if got := checkNextReviewDate(baselineMeta{}, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)); got != "" {
+		t.Fatalf("expected no warning without a review date, got %q", got)
+	}
+	if got := checkNextReviewDate(baselineMeta{NextReviewDate: "April"}, time.Now()); !strings.Contains(got, "invalid") {
+		t.Fatalf("expected invalid date warning, got %q", got)
+	}
+}
+
+func TestRunOverdueReview(t *testing.T) {
+	dir := t.TempDir()
+	baselinePath := filepath.Join(dir, "baseline.json")
+	setNowUTCForTest(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	meta := defaultBaselineMeta()
+	meta.NextReviewDate = "2026-04-01"
+	if err := writeBaseline(baselinePath, meta, []lintIssue{{File: "docs/a.md", Line: 1, Rule: "MD001"}}); err != nil {
+		t.Fatalf("write baseline: %v", err)
+	}
+	setNowUTCForTest(t, time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC))
+
+	var stderr bytes.Buffer
+	if exitCode := Run([]string{"cmd", "--baseline", baselinePath}, &stderr, strings.NewReader(sampleLintJSON)); exitCode != 0 {
+		t.Fatalf("expected overdue review to warn only, got %d (%s)", exitCode, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "warning: markdownlint baseline review was due on 2026-04-01") {
+		t.Fatalf("expected overdue warning, got %q", stderr.String())
+	}
+
+	stderr.Reset()
+	if exitCode := Run([]string{"cmd", "--baseline", baselinePath, "--fail-on-overdue-review"}, &stderr, strings.NewReader(sampleLintJSON)); exitCode != 1 {
+		t.Fatalf("expected --fail-on-overdue-review to fail, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "review was due") {
+		t.Fatalf("expected overdue message, got %q", stderr.String())
+	}
+}
+
 func TestReadLintInputMissingFile(t *testing.T) {
 	if _, err := readLintInput(filepath.Join(t.TempDir(), "missing.json"), strings.NewReader("")); err == nil {
 		t.Fatalf("expected error for missing input file")
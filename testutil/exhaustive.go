@@ -0,0 +1,42 @@
+package testutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// AssertExhaustive fails the test when handled lacks an entry for any value in
+// all, or holds an entry for a value outside all. Pair it with the generated
+// entitymodel All<Enum> functions so hand-maintained lookup tables fail loudly
+// when the schema gains or drops an enum value.
+func AssertExhaustive[E comparable, V any](t testing.TB, all []E, handled map[E]V) {
+	t.Helper()
+	declared := make(map[E]struct{}, len(all))
+	var missing, unknown []string
+	for _, value := range all {
+		declared[value] = struct{}{}
+		if _, ok := handled[value]; !ok {
+			missing = append(missing, fmt.Sprint(value))
+		}
+	}
+	for value := range handled {
+		if _, ok := declared[value]; !ok {
+			unknown = append(unknown, fmt.Sprint(value))
+		}
+	}
+	if len(missing) == 0 && len(unknown) == 0 {
+		return
+	}
+	sort.Strings(missing)
+	sort.Strings(unknown)
+	var problems []string
+	if len(missing) > 0 {
+		problems = append(problems, "missing "+strings.Join(missing, ", "))
+	}
+	if len(unknown) > 0 {
+		problems = append(problems, "unknown "+strings.Join(unknown, ", "))
+	}
+	t.Errorf("handler map is not exhaustive: %s", strings.Join(problems, "; "))
+}
@@ -0,0 +1,37 @@
+package testutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertExhaustive(t *testing.T) {
+	all := []string{"draft", "approved", "archived"}
+
+	complete := &recordingTB{TB: t}
+	AssertExhaustive(complete, all, map[string]bool{"draft": true, "approved": true, "archived": true})
+	if len(complete.errors) != 0 {
+		t.Fatalf("expected exhaustive map to pass, got %v", complete.errors)
+	}
+
+	partial := &recordingTB{TB: t}
+	AssertExhaustive(partial, all, map[string]struct{}{"draft": {}, "retired": {}})
+	if len(partial.errors) != 1 {
+		t.Fatalf("expected a single failure, got %v", partial.errors)
+	}
+	if msg := partial.errors[0]; !strings.Contains(msg, "missing approved, archived") || !strings.Contains(msg, "unknown retired") {
+		t.Fatalf("unexpected failure message %q", msg)
+	}
+}
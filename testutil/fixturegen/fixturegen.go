@@ -0,0 +1,421 @@
+// Package fixturegen builds deterministic, schema-driven entity fixtures for
+// tests. Fixtures are derived from the canonical entity-model JSON so they
+// track required fields, enums, and relationships as the schema evolves.
+package fixturegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	schema "colonycore/docs/schema"
+	"colonycore/internal/infra/persistence/memory"
+	"colonycore/pkg/domain"
+)
+
+// BaseTime is the fixed timestamp assigned to generated date-time fields.
+var BaseTime = time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC)
+
+// timestampOffsets shifts date-time fields whose ordering the schema cannot
+// express, keyed by entity-model name and property.
+var timestampOffsets = map[string]map[string]time.Duration{
+	"Permit": {"valid_until": 365 * 24 * time.Hour},
+}
+
+// companionRefs lists optional references a record still needs to pass store
+// validation, such as a sample's organism, keyed by entity-model name.
+var companionRefs = map[string][]string{
+	"Observation": {"organism_id"},
+	"Sample":      {"organism_id"},
+}
+
+type propertySpec struct {
+	Type       string                  `json:"type"`
+	Ref        string                  `json:"$ref"`
+	Format     string                  `json:"format"`
+	Minimum    *float64                `json:"minimum"`
+	MinItems   int                     `json:"minItems"`
+	Items      *propertySpec           `json:"items"`
+	Required   []string                `json:"required"`
+	Properties map[string]propertySpec `json:"properties"`
+}
+
+type relationshipSpec struct {
+	Target      string `json:"target"`
+	Cardinality string `json:"cardinality"`
+}
+
+type naturalKeySpec struct {
+	Fields []string `json:"fields"`
+}
+
+type entitySpec struct {
+	Required      []string                    `json:"required"`
+	Properties    map[string]propertySpec     `json:"properties"`
+	Relationships map[string]relationshipSpec `json:"relationships"`
+	NaturalKeys   []naturalKeySpec            `json:"natural_keys"`
+}
+
+type schemaDoc struct {
+	Enums map[string]struct {
+		Values []string `json:"values"`
+	} `json:"enums"`
+	Entities    map[string]entitySpec   `json:"entities"`
+	Definitions map[string]propertySpec `json:"definitions"`
+}
+
+var (
+	docOnce sync.Once
+	doc     schemaDoc
+	docErr  error
+)
+
+func loadSchema() (schemaDoc, error) {
+	docOnce.Do(func() {
+		docErr = json.Unmarshal(schema.EntityModelJSON(), &doc)
+	})
+	return doc, docErr
+}
+
+// GenerateFixture returns a populated domain record, such as a
+// domain.Organism, for entity. Required fields are set, enums are chosen
+// deterministically from seed, and timestamps are fixed at BaseTime. Required
+// references point at the IDs GenerateGraph would create for the same seed.
+func GenerateFixture(entity domain.EntityType, seed int64) (any, error) {
+	doc, err := loadSchema()
+	if err != nil {
+		return nil, fmt.Errorf("load entity model: %w", err)
+	}
+	fields, err := buildFields(doc, entity, seed)
+	if err != nil {
+		return nil, err
+	}
+	return decodeRecord(entity, fields)
+}
+
+// GenerateFixtures returns n fixtures for entity using seeds seed..seed+n-1.
+// It fails if two fixtures would share a natural key.
+func GenerateFixtures(entity domain.EntityType, seed int64, n int) ([]any, error) {
+	doc, err := loadSchema()
+	if err != nil {
+		return nil, fmt.Errorf("load entity model: %w", err)
+	}
+	spec, ok := doc.Entities[modelName(entity)]
+	if !ok {
+		return nil, fmt.Errorf("unknown entity %q", entity)
+	}
+	seen := make(map[string]int64, n)
+	out := make([]any, 0, n)
+	for i := int64(0); i < int64(n); i++ {
+		fields, err := buildFields(doc, entity, seed+i)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range spec.NaturalKeys {
+			tuple, _ := json.Marshal(pick(fields, key.Fields))
+			id := strings.Join(key.Fields, ",") + "=" + string(tuple)
+			if prior, dup := seen[id]; dup {
+				return nil, fmt.Errorf("%s seeds %d and %d share natural key %s", entity, prior, seed+i, id)
+			}
+			seen[id] = seed + i
+		}
+		record, err := decodeRecord(entity, fields)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, record)
+	}
+	return out, nil
+}
+
+// GenerateGraph returns a snapshot holding the fixture for entity together
+// with every record it depends on, so the graph is referentially valid.
+func GenerateGraph(entity domain.EntityType, seed int64) (memory.Snapshot, error) {
+	doc, err := loadSchema()
+	if err != nil {
+		return memory.Snapshot{}, fmt.Errorf("load entity model: %w", err)
+	}
+	snapshot := memory.Snapshot{}
+	if err := addToGraph(doc, &snapshot, entity, seed, map[domain.EntityType]bool{}); err != nil {
+		return memory.Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+func addToGraph(doc schemaDoc, snapshot *memory.Snapshot, entity domain.EntityType, seed int64, added map[domain.EntityType]bool) error {
+	if added[entity] {
+		return nil
+	}
+	added[entity] = true
+	record, err := GenerateFixture(entity, seed)
+	if err != nil {
+		return err
+	}
+	spec := doc.Entities[modelName(entity)]
+	for _, field := range referencedFields(spec, modelName(entity)) {
+		target := entityType(spec.Relationships[field].Target)
+		if err := addToGraph(doc, snapshot, target, seed, added); err != nil {
+			return err
+		}
+	}
+	return storeRecord(snapshot, record)
+}
+
+// referencedFields returns the relationship fields a generated record fills.
+func referencedFields(spec entitySpec, name string) []string {
+	var fields []string
+	for field, rel := range spec.Relationships {
+		if strings.HasPrefix(rel.Cardinality, "1") || contains(companionRefs[name], field) {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func buildFields(doc schemaDoc, entity domain.EntityType, seed int64) (map[string]any, error) {
+	name := modelName(entity)
+	spec, ok := doc.Entities[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown entity %q", entity)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	fields := map[string]any{"id": FixtureID(entity, seed)}
+	for _, field := range referencedFields(spec, name) {
+		id := FixtureID(entityType(spec.Relationships[field].Target), seed)
+		if spec.Properties[field].Type == "array" {
+			fields[field] = []string{id}
+		} else {
+			fields[field] = id
+		}
+	}
+	required := append([]string(nil), spec.Required...)
+	sort.Strings(required)
+	for _, field := range required {
+		if _, done := fields[field]; done {
+			continue
+		}
+		value, err := generateValue(doc, name, field, spec.Properties[field], seed, rng)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", name, field, err)
+		}
+		fields[field] = value
+	}
+	return fields, nil
+}
+
+func generateValue(doc schemaDoc, entity, field string, prop propertySpec, seed int64, rng *rand.Rand) (any, error) {
+	if prop.Ref != "" {
+		kind, ref, _ := strings.Cut(strings.TrimPrefix(prop.Ref, "#/"), "/")
+		switch kind {
+		case "enums":
+			values := doc.Enums[ref].Values
+			if len(values) == 0 {
+				return nil, fmt.Errorf("enum %q has no values", ref)
+			}
+			return values[rng.Intn(len(values))], nil
+		case "definitions":
+			def, ok := doc.Definitions[ref]
+			if !ok {
+				return nil, fmt.Errorf("unknown definition %q", ref)
+			}
+			prop = def
+		default:
+			return nil, fmt.Errorf("unsupported $ref %q", prop.Ref)
+		}
+	}
+	switch prop.Type {
+	case "string":
+		if prop.Format == "date-time" {
+			return BaseTime.Add(timestampOffsets[entity][field]), nil
+		}
+		return fmt.Sprintf("%s-%s-%d", strings.ToLower(entity), strings.ReplaceAll(field, "_", "-"), seed), nil
+	case "integer", "number":
+		low := 1
+		if prop.Minimum != nil && *prop.Minimum > 1 {
+			low = int(*prop.Minimum)
+		}
+		return low + rng.Intn(10), nil
+	case "boolean":
+		return rng.Intn(2) == 1, nil
+	case "array":
+		count := prop.MinItems
+		if count == 0 {
+			count = 1
+		}
+		items := make([]any, 0, count)
+		for i := 0; i < count; i++ {
+			item, err := generateValue(doc, entity, field, *prop.Items, seed+int64(i), rng)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case "object":
+		object := map[string]any{}
+		for _, key := range prop.Required {
+			value, err := generateValue(doc, entity, key, prop.Properties[key], seed, rng)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = value
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("unsupported property type %q", prop.Type)
+	}
+}
+
+// FixtureID returns the deterministic ID generated for entity and seed.
+func FixtureID(entity domain.EntityType, seed int64) string {
+	return fmt.Sprintf("%s-%d", strings.ReplaceAll(string(entity), "_", "-"), seed)
+}
+
+func decodeRecord(entity domain.EntityType, fields map[string]any) (any, error) {
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s fixture: %w", entity, err)
+	}
+	switch entity {
+	case domain.EntityOrganism:
+		return decodeAs[domain.Organism](entity, raw)
+	case domain.EntityCohort:
+		return decodeAs[domain.Cohort](entity, raw)
+	case domain.EntityHousingUnit:
+		return decodeAs[domain.HousingUnit](entity, raw)
+	case domain.EntityFacility:
+		return decodeAs[domain.Facility](entity, raw)
+	case domain.EntityBreeding:
+		return decodeAs[domain.BreedingUnit](entity, raw)
+	case domain.EntityLine:
+		return decodeAs[domain.Line](entity, raw)
+	case domain.EntityStrain:
+		return decodeAs[domain.Strain](entity, raw)
+	case domain.EntityGenotypeMarker:
+		return decodeAs[domain.GenotypeMarker](entity, raw)
+	case domain.EntityProcedure:
+		return decodeAs[domain.Procedure](entity, raw)
+	case domain.EntityTreatment:
+		return decodeAs[domain.Treatment](entity, raw)
+	case domain.EntityObservation:
+		return decodeAs[domain.Observation](entity, raw)
+	case domain.EntitySample:
+		return decodeAs[domain.Sample](entity, raw)
+	case domain.EntityProtocol:
+		return decodeAs[domain.Protocol](entity, raw)
+	case domain.EntityPermit:
+		return decodeAs[domain.Permit](entity, raw)
+	case domain.EntityProject:
+		return decodeAs[domain.Project](entity, raw)
+	case domain.EntitySupplyItem:
+		return decodeAs[domain.SupplyItem](entity, raw)
+	default:
+		return nil, fmt.Errorf("unknown entity %q", entity)
+	}
+}
+
+func decodeAs[T any](entity domain.EntityType, raw []byte) (any, error) {
+	var record T
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return nil, fmt.Errorf("decode %s fixture: %w", entity, err)
+	}
+	return record, nil
+}
+
+func storeRecord(snapshot *memory.Snapshot, record any) error {
+	switch r := record.(type) {
+	case domain.Organism:
+		snapshot.Organisms = put(snapshot.Organisms, r.ID, r)
+	case domain.Cohort:
+		snapshot.Cohorts = put(snapshot.Cohorts, r.ID, r)
+	case domain.HousingUnit:
+		snapshot.Housing = put(snapshot.Housing, r.ID, r)
+	case domain.Facility:
+		snapshot.Facilities = put(snapshot.Facilities, r.ID, r)
+	case domain.BreedingUnit:
+		snapshot.Breeding = put(snapshot.Breeding, r.ID, r)
+	case domain.Line:
+		snapshot.Lines = put(snapshot.Lines, r.ID, r)
+	case domain.Strain:
+		snapshot.Strains = put(snapshot.Strains, r.ID, r)
+	case domain.GenotypeMarker:
+		snapshot.Markers = put(snapshot.Markers, r.ID, r)
+	case domain.Procedure:
+		snapshot.Procedures = put(snapshot.Procedures, r.ID, r)
+	case domain.Treatment:
+		snapshot.Treatments = put(snapshot.Treatments, r.ID, r)
+	case domain.Observation:
+		snapshot.Observations = put(snapshot.Observations, r.ID, r)
+	case domain.Sample:
+		snapshot.Samples = put(snapshot.Samples, r.ID, r)
+	case domain.Protocol:
+		snapshot.Protocols = put(snapshot.Protocols, r.ID, r)
+	case domain.Permit:
+		snapshot.Permits = put(snapshot.Permits, r.ID, r)
+	case domain.Project:
+		snapshot.Projects = put(snapshot.Projects, r.ID, r)
+	case domain.SupplyItem:
+		snapshot.Supplies = put(snapshot.Supplies, r.ID, r)
+	default:
+		return fmt.Errorf("unsupported fixture record %T", record)
+	}
+	return nil
+}
+
+func put[T any](m map[string]T, id string, value T) map[string]T {
+	if m == nil {
+		m = make(map[string]T)
+	}
+	m[id] = value
+	return m
+}
+
+// modelName maps a snake_case entity type onto its entity-model name.
+func modelName(entity domain.EntityType) string {
+	parts := strings.Split(string(entity), "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// entityType maps an entity-model name such as "HousingUnit" onto its
+// snake_case entity type.
+func entityType(name string) domain.EntityType {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return domain.EntityType(b.String())
+}
+
+func pick(fields map[string]any, keys []string) []any {
+	values := make([]any, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, fields[key])
+	}
+	return values
+}
+
+func contains(values []string, needle string) bool {
+	for _, value := range values {
+		if value == needle {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package fixturegen
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"colonycore/internal/infra/persistence/memory"
+	"colonycore/pkg/domain"
+)
+
+var allEntities = []domain.EntityType{
+	domain.EntityOrganism,
+	domain.EntityCohort,
+	domain.EntityHousingUnit,
+	domain.EntityFacility,
+	domain.EntityBreeding,
+	domain.EntityLine,
+	domain.EntityStrain,
+	domain.EntityGenotypeMarker,
+	domain.EntityProcedure,
+	domain.EntityTreatment,
+	domain.EntityObservation,
+	domain.EntitySample,
+	domain.EntityProtocol,
+	domain.EntityPermit,
+	domain.EntityProject,
+	domain.EntitySupplyItem,
+}
+
+func TestGeneratedOrganismsPassCreateOrganism(t *testing.T) {
+	store := memory.NewStore(nil)
+	for seed := int64(1); seed <= 5; seed++ {
+		value, err := GenerateFixture(domain.EntityOrganism, seed)
+		if err != nil {
+			t.Fatalf("generate organism seed %d: %v", seed, err)
+		}
+		organism, ok := value.(domain.Organism)
+		if !ok {
+			t.Fatalf("expected domain.Organism, got %T", value)
+		}
+		if organism.Name == "" || organism.Species == "" || organism.Stage == "" {
+			t.Fatalf("expected required fields populated, got %+v", organism)
+		}
+		if _, err := store.RunInTransaction(context.Background(), func(tx domain.Transaction) error {
+			_, err := tx.CreateOrganism(organism)
+			return err
+		}); err != nil {
+			t.Fatalf("create organism seed %d: %v", seed, err)
+		}
+	}
+}
+
+func TestGenerateFixtureIsDeterministic(t *testing.T) {
+	for _, entity := range allEntities {
+		first, err := GenerateFixture(entity, 42)
+		if err != nil {
+			t.Fatalf("generate %s: %v", entity, err)
+		}
+		second, err := GenerateFixture(entity, 42)
+		if err != nil {
+			t.Fatalf("regenerate %s: %v", entity, err)
+		}
+		if !reflect.DeepEqual(first, second) {
+			t.Fatalf("expected %s fixtures to match for the same seed", entity)
+		}
+		if got, ok := domain.EntityTypeOf(first); !ok || got != entity {
+			t.Fatalf("expected %s record, got %T", entity, first)
+		}
+	}
+}
+
+func TestGenerateFixturesRespectsNaturalKeys(t *testing.T) {
+	for _, entity := range allEntities {
+		records, err := GenerateFixtures(entity, 1, 10)
+		if err != nil {
+			t.Fatalf("generate %s fixtures: %v", entity, err)
+		}
+		if len(records) != 10 {
+			t.Fatalf("expected 10 %s fixtures, got %d", entity, len(records))
+		}
+	}
+}
+
+func TestGenerateGraphIsReferentiallyValid(t *testing.T) {
+	for _, entity := range allEntities {
+		for seed := int64(1); seed <= 3; seed++ {
+			snapshot, err := GenerateGraph(entity, seed)
+			if err != nil {
+				t.Fatalf("generate %s graph: %v", entity, err)
+			}
+			if err := memory.ValidateSnapshot(snapshot); err != nil {
+				t.Fatalf("%s graph seed %d failed validation: %v", entity, seed, err)
+			}
+		}
+	}
+
+	snapshot, err := GenerateGraph(domain.EntitySupplyItem, 7)
+	if err != nil {
+		t.Fatalf("generate supply graph: %v", err)
+	}
+	item := snapshot.Supplies[FixtureID(domain.EntitySupplyItem, 7)]
+	if len(item.ProjectIDs) != 1 || snapshot.Projects[item.ProjectIDs[0]].ID == "" {
+		t.Fatalf("expected supply item project to be included, got %+v", snapshot.Projects)
+	}
+	if _, ok := snapshot.Facilities[FixtureID(domain.EntityFacility, 7)]; !ok {
+		t.Fatalf("expected transitive facility dependency, got %+v", snapshot.Facilities)
+	}
+}
+
+func TestGenerateFixtureUnknownEntity(t *testing.T) {
+	if _, err := GenerateFixture(domain.EntityType("widget"), 1); err == nil || !strings.Contains(err.Error(), "unknown entity") {
+		t.Fatalf("expected unknown entity error, got %v", err)
+	}
+	if _, err := GenerateGraph(domain.EntityType("widget"), 1); err == nil {
+		t.Fatalf("expected graph error for unknown entity")
+	}
+}